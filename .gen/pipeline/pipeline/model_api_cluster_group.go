@@ -22,5 +22,7 @@ type ApiClusterGroup struct {
 
 	OrganizationId int32 `json:"organizationId,omitempty"`
 
+	Selector ApiClusterSelector `json:"selector,omitempty"`
+
 	Uid string `json:"uid,omitempty"`
 }
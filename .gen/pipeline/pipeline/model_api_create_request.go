@@ -15,4 +15,6 @@ type ApiCreateRequest struct {
 	Members []int32 `json:"members,omitempty"`
 
 	Name string `json:"name,omitempty"`
+
+	Selector ApiClusterSelector `json:"selector,omitempty"`
 }
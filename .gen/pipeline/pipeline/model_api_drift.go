@@ -0,0 +1,24 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type ApiDrift struct {
+
+	Actual string `json:"actual,omitempty"`
+
+	Expected string `json:"expected,omitempty"`
+
+	Kind string `json:"kind,omitempty"`
+
+	Message string `json:"message,omitempty"`
+
+	Name string `json:"name,omitempty"`
+}
@@ -0,0 +1,28 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+import (
+	"time"
+)
+
+type ApiDriftReport struct {
+
+	ClusterGroupId int32 `json:"clusterGroupId,omitempty"`
+
+	Consistent bool `json:"consistent,omitempty"`
+
+	GeneratedAt time.Time `json:"generatedAt,omitempty"`
+
+	Members []ApiMemberDriftReport `json:"members,omitempty"`
+
+	Name string `json:"name,omitempty"`
+}
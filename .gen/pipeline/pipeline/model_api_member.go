@@ -16,6 +16,8 @@ type ApiMember struct {
 
 	Distribution string `json:"distribution,omitempty"`
 
+	Dynamic bool `json:"dynamic,omitempty"`
+
 	Id int32 `json:"id,omitempty"`
 
 	Name string `json:"name,omitempty"`
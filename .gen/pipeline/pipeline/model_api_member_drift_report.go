@@ -0,0 +1,26 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type ApiMemberDriftReport struct {
+
+	ClusterId int32 `json:"clusterId,omitempty"`
+
+	ClusterName string `json:"clusterName,omitempty"`
+
+	Drifted bool `json:"drifted,omitempty"`
+
+	Drifts []ApiDrift `json:"drifts,omitempty"`
+
+	Error string `json:"error,omitempty"`
+
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+}
@@ -0,0 +1,53 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+import (
+	"time"
+)
+
+// AuditEvent - API request recorded by Pipeline
+type AuditEvent struct {
+
+	Id int32 `json:"id,omitempty"`
+
+	Time time.Time `json:"time,omitempty"`
+
+	CorrelationId string `json:"correlationId,omitempty"`
+
+	OrganizationId int32 `json:"organizationId,omitempty"`
+
+	UserId int32 `json:"userId,omitempty"`
+
+	ClientIp string `json:"clientIp,omitempty"`
+
+	UserAgent string `json:"userAgent,omitempty"`
+
+	Method string `json:"method,omitempty"`
+
+	Path string `json:"path,omitempty"`
+
+	StatusCode int32 `json:"statusCode,omitempty"`
+
+	// Response time in milliseconds
+	ResponseTime int32 `json:"responseTime,omitempty"`
+
+	ResponseSize int32 `json:"responseSize,omitempty"`
+
+	// Request body (sensitive data removed)
+	Body map[string]interface{} `json:"body,omitempty"`
+
+	// Whitelisted request headers
+	Headers map[string]interface{} `json:"headers,omitempty"`
+
+	// Errors occurred during the request
+	Errors map[string]interface{} `json:"errors,omitempty"`
+}
@@ -33,9 +33,13 @@ type BucketInfo struct {
 
 	Oracle OracleStorageProps `json:"oracle,omitempty"`
 
+	S3compatible S3CompatibleStorageProps `json:"s3compatible,omitempty"`
+
 	// the status of the bucket
 	Status string `json:"status"`
 
 	// the reason for the error status
 	StatusMessage string `json:"statusMessage,omitempty"`
+
+	Settings BucketSettings `json:"settings,omitempty"`
 }
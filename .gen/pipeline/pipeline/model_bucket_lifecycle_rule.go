@@ -0,0 +1,27 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type BucketLifecycleRule struct {
+
+	Id string `json:"id"`
+
+	// limits the rule to the objects with the given key prefix
+	Prefix string `json:"prefix,omitempty"`
+
+	// number of days after which objects are deleted
+	ExpirationDays int32 `json:"expirationDays,omitempty"`
+
+	// number of days after which objects are moved to the transition storage class
+	TransitionDays int32 `json:"transitionDays,omitempty"`
+
+	TransitionStorageClass string `json:"transitionStorageClass,omitempty"`
+}
@@ -0,0 +1,25 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type BucketReference struct {
+
+	ClusterId int32 `json:"clusterId"`
+
+	ClusterName string `json:"clusterName"`
+
+	Kind string `json:"kind"`
+
+	IntegratedService string `json:"integratedService,omitempty"`
+
+	// false if the cluster or the backup deployment has been deleted
+	Active bool `json:"active"`
+}
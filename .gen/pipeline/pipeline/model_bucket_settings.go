@@ -0,0 +1,21 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type BucketSettings struct {
+
+	// keep every version of the objects stored in the bucket
+	Versioning bool `json:"versioning,omitempty"`
+
+	Encryption BucketEncryption `json:"encryption,omitempty"`
+
+	LifecycleRules []BucketLifecycleRule `json:"lifecycleRules,omitempty"`
+}
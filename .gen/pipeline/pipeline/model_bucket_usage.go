@@ -0,0 +1,42 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+import (
+	"time"
+)
+
+type BucketUsage struct {
+
+	Cloud string `json:"cloud"`
+
+	Name string `json:"name"`
+
+	Location string `json:"location,omitempty"`
+
+	ObjectCount int64 `json:"objectCount"`
+
+	// total size of the objects in bytes
+	TotalSize int64 `json:"totalSize"`
+
+	Prefixes []BucketPrefixUsage `json:"prefixes,omitempty"`
+
+	References []BucketReference `json:"references"`
+
+	// true if the bucket is only referenced by deleted clusters
+	Orphaned bool `json:"orphaned"`
+
+	// the reason the objects of the bucket could not be listed
+	Error string `json:"error,omitempty"`
+
+	// the time the usage was collected
+	CollectedAt time.Time `json:"collectedAt"`
+}
@@ -0,0 +1,34 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+package pipeline
+
+type ClusterCostEstimate struct {
+
+	Currency string `json:"currency,omitempty"`
+
+	ControlPlane ControlPlaneCost `json:"controlPlane,omitempty"`
+
+	NodePools []NodePoolCost `json:"nodePools,omitempty"`
+
+	OnDemand Cost `json:"onDemand,omitempty"`
+
+	Spot Cost `json:"spot,omitempty"`
+
+	Cost Cost `json:"cost,omitempty"`
+
+	MinCost Cost `json:"minCost,omitempty"`
+
+	MaxCost Cost `json:"maxCost,omitempty"`
+
+	// False if some of the node pools could not be priced
+	Complete bool `json:"complete,omitempty"`
+}
@@ -0,0 +1,21 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+package pipeline
+
+type ControlPlaneCost struct {
+
+	Fee Cost `json:"fee,omitempty"`
+
+	Nodes Cost `json:"nodes,omitempty"`
+
+	Cost Cost `json:"cost,omitempty"`
+}
@@ -0,0 +1,19 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+package pipeline
+
+type Cost struct {
+
+	Hourly float64 `json:"hourly,omitempty"`
+
+	Monthly float64 `json:"monthly,omitempty"`
+}
@@ -28,5 +28,7 @@ type CreateClusterRequest struct {
 
 	ScaleOptions ScaleOptions `json:"scaleOptions,omitempty"`
 
+	Labels map[string]string `json:"labels,omitempty"`
+
 	Properties map[string]interface{} `json:"properties"`
 }
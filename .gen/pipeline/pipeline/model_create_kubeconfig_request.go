@@ -0,0 +1,21 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+package pipeline
+
+type CreateKubeconfigRequest struct {
+
+	// Namespace the kubeconfig is scoped to (defaults to the configured namespace)
+	Namespace string `json:"namespace,omitempty"`
+
+	// Lifetime of the kubeconfig as a duration (eg. 1h30m); it cannot exceed the configured maximum
+	Ttl string `json:"ttl,omitempty"`
+}
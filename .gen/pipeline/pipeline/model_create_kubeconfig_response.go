@@ -0,0 +1,42 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+package pipeline
+
+import (
+	"time"
+)
+
+type CreateKubeconfigResponse struct {
+
+	Id string `json:"id,omitempty"`
+
+	ClusterId int32 `json:"clusterId,omitempty"`
+
+	UserId int32 `json:"userId,omitempty"`
+
+	UserLogin string `json:"userLogin,omitempty"`
+
+	// Organization role of the user at creation time
+	Role string `json:"role,omitempty"`
+
+	Namespace string `json:"namespace,omitempty"`
+
+	// Kubernetes cluster role bound in the namespace
+	ClusterRole string `json:"clusterRole,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+
+	// Kubeconfig in YAML format
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+}
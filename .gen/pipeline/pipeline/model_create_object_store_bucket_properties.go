@@ -21,4 +21,6 @@ type CreateObjectStoreBucketProperties struct {
 	Google *CreateGoogleObjectStoreBucketProperties `json:"google,omitempty"`
 
 	Oracle *CreateOracleObjectStoreBucketProperties `json:"oracle,omitempty"`
+
+	S3compatible *CreateS3CompatibleObjectStoreBucketProperties `json:"s3compatible,omitempty"`
 }
@@ -19,4 +19,6 @@ type CreateObjectStoreBucketRequest struct {
 	Name string `json:"name"`
 
 	Properties CreateObjectStoreBucketProperties `json:"properties"`
+
+	Settings BucketSettings `json:"settings,omitempty"`
 }
@@ -0,0 +1,19 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type CreatePkeOnBaremetalClusterRequestAllOf struct {
+
+	// Address of the Kubernetes API server the nodes join to. Defaults to the private IP of the master host, required for multiple master hosts.
+	ApiServerAddress string `json:"apiServerAddress,omitempty"`
+
+	NodePools []PkeOnBaremetalNodePool `json:"nodePools"`
+}
@@ -28,6 +28,8 @@ type DeploymentClusterGroupDeployment struct {
 
 	RollingMode bool `json:"rollingMode,omitempty"`
 
+	Rollout DeploymentRolloutStrategy `json:"rollout,omitempty"`
+
 	ValueOverrides map[string]interface{} `json:"valueOverrides,omitempty"`
 
 	Values map[string]interface{} `json:"values,omitempty"`
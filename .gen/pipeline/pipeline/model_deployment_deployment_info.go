@@ -26,6 +26,8 @@ type DeploymentDeploymentInfo struct {
 
 	ReleaseName string `json:"releaseName,omitempty"`
 
+	Rollout DeploymentRolloutStatus `json:"rollout,omitempty"`
+
 	TargetClusters []DeploymentTargetClusterStatus `json:"targetClusters,omitempty"`
 
 	UpdatedAt string `json:"updatedAt,omitempty"`
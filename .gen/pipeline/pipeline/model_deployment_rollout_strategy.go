@@ -0,0 +1,22 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type DeploymentRolloutStrategy struct {
+
+	// number of target clusters deployed to at the same time (batch and canary strategies)
+	BatchSize int32 `json:"batchSize,omitempty"`
+
+	// seconds a batch has to become healthy before the rollout is paused (default 300)
+	HealthCheckTimeout int32 `json:"healthCheckTimeout,omitempty"`
+
+	Type string `json:"type"`
+}
@@ -21,4 +21,7 @@ type GenericNodePool struct {
 
 	// Node pool labels.
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// Node pool taints.
+	Taints []NodePoolTaint `json:"taints,omitempty"`
 }
@@ -46,6 +46,8 @@ type GetClusterStatusResponse struct {
 
 	Region string `json:"region,omitempty"`
 
+	Labels map[string]string `json:"labels,omitempty"`
+
 	NodePools map[string]NodePoolStatus `json:"nodePools,omitempty"`
 
 	TotalSummary ResourceSummary `json:"totalSummary,omitempty"`
@@ -0,0 +1,23 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type NetworkPolicyDefaults struct {
+
+	// deny all ingress and egress traffic of the pods in user namespaces
+	DenyAll bool `json:"denyAll,omitempty"`
+
+	// allow DNS queries to kube-system from the pods in user namespaces
+	AllowDns bool `json:"allowDns,omitempty"`
+
+	// namespaces the default network policies are not applied to
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+}
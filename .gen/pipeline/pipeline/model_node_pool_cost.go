@@ -0,0 +1,46 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+package pipeline
+
+type NodePoolCost struct {
+
+	Name string `json:"name,omitempty"`
+
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// The node pool runs the control plane (self-managed distributions)
+	ControlPlane bool `json:"controlPlane,omitempty"`
+
+	// The nodes are spot (or preemptible) instances
+	Spot bool `json:"spot,omitempty"`
+
+	Count int32 `json:"count,omitempty"`
+
+	MinCount int32 `json:"minCount,omitempty"`
+
+	MaxCount int32 `json:"maxCount,omitempty"`
+
+	// Hourly price of a single node
+	NodePrice float64 `json:"nodePrice,omitempty"`
+
+	Cost Cost `json:"cost,omitempty"`
+
+	MinCost Cost `json:"minCost,omitempty"`
+
+	MaxCost Cost `json:"maxCost,omitempty"`
+
+	// False if no price is available for the node pool (eg. on-premise clusters or unknown instance types)
+	Priced bool `json:"priced,omitempty"`
+
+	// Reason why the node pool could not be priced
+	Reason string `json:"reason,omitempty"`
+}
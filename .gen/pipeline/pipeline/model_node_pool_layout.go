@@ -0,0 +1,28 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+package pipeline
+
+type NodePoolLayout struct {
+
+	NodePools []RecommendedNodePool `json:"nodePools,omitempty"`
+
+	// Total number of vCPUs of the layout
+	Cpu float64 `json:"cpu,omitempty"`
+
+	// Total memory of the layout in GB
+	Memory float64 `json:"memory,omitempty"`
+
+	// Total number of GPUs of the layout
+	Gpu float64 `json:"gpu,omitempty"`
+
+	Cost Cost `json:"cost,omitempty"`
+}
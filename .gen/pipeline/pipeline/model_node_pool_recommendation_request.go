@@ -0,0 +1,43 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+package pipeline
+
+type NodePoolRecommendationRequest struct {
+
+	Cloud string `json:"cloud,omitempty"`
+
+	Distribution string `json:"distribution,omitempty"`
+
+	Location string `json:"location,omitempty"`
+
+	// Zones the nodes are placed in (every zone must offer the instance type)
+	Zones []string `json:"zones,omitempty"`
+
+	// Total number of vCPUs required
+	Cpu float64 `json:"cpu,omitempty"`
+
+	// Total memory required in GB
+	Memory float64 `json:"memory,omitempty"`
+
+	// Total number of GPUs required (GPU instance types are only recommended if it is positive)
+	Gpu float64 `json:"gpu,omitempty"`
+
+	MinNodes int32 `json:"minNodes,omitempty"`
+
+	MaxNodes int32 `json:"maxNodes,omitempty"`
+
+	// Maximum ratio of nodes that may run on spot (preemptible) instances (0 disables spot instances)
+	SpotRatio float64 `json:"spotRatio,omitempty"`
+
+	// Maximum number of layouts returned
+	Limit int32 `json:"limit,omitempty"`
+}
@@ -28,5 +28,8 @@ type NodePoolStatusAmazon struct {
 
 	Labels map[string]string `json:"labels,omitempty"`
 
+	// Node pool taints.
+	Taints []NodePoolTaint `json:"taints,omitempty"`
+
 	ResourceSummary map[string]ResourceSummary `json:"resourceSummary,omitempty"`
 }
@@ -22,5 +22,8 @@ type NodePoolStatusAzure struct {
 
 	InstanceType string `json:"instanceType,omitempty"`
 
+	// Node pool taints.
+	Taints []NodePoolTaint `json:"taints,omitempty"`
+
 	ResourceSummary map[string]ResourceSummary `json:"resourceSummary,omitempty"`
 }
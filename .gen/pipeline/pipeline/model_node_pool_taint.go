@@ -0,0 +1,21 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments.
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+// NodePoolTaint - Kubernetes taint applied to every node in a node pool.
+type NodePoolTaint struct {
+
+	Key string `json:"key"`
+
+	Value string `json:"value,omitempty"`
+
+	Effect string `json:"effect"`
+}
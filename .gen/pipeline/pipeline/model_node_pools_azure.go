@@ -23,4 +23,7 @@ type NodePoolsAzure struct {
 	InstanceType string `json:"instanceType"`
 
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// Node pool taints.
+	Taints []NodePoolTaint `json:"taints,omitempty"`
 }
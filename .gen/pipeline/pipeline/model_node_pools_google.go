@@ -25,4 +25,7 @@ type NodePoolsGoogle struct {
 	InstanceType string `json:"instanceType"`
 
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// Node pool taints.
+	Taints []NodePoolTaint `json:"taints,omitempty"`
 }
@@ -0,0 +1,23 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type PkeOnBaremetalHost struct {
+
+	// Hostname of the machine, it becomes the name of the Kubernetes node.
+	Name string `json:"name"`
+
+	// Address of the machine to connect to over SSH with the cluster's SSH secret. The port defaults to 22.
+	Address string `json:"address"`
+
+	// IP address the other nodes can reach the machine on. Defaults to the address if it is an IP address.
+	PrivateIP string `json:"privateIP,omitempty"`
+}
@@ -0,0 +1,33 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+package pipeline
+
+type RecommendedNodePool struct {
+
+	InstanceType string `json:"instanceType,omitempty"`
+
+	Count int32 `json:"count,omitempty"`
+
+	Spot bool `json:"spot,omitempty"`
+
+	// Number of vCPUs of a single node
+	Cpu float64 `json:"cpu,omitempty"`
+
+	// Memory of a single node in GB
+	Memory float64 `json:"memory,omitempty"`
+
+	// Number of GPUs of a single node
+	Gpu float64 `json:"gpu,omitempty"`
+
+	// Hourly price of a single node
+	NodePrice float64 `json:"nodePrice,omitempty"`
+}
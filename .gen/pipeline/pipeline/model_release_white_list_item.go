@@ -10,6 +10,10 @@
 
 package pipeline
 
+import (
+	"time"
+)
+
 type ReleaseWhiteListItem struct {
 
 	Name string `json:"name"`
@@ -17,4 +21,11 @@ type ReleaseWhiteListItem struct {
 	Owner string `json:"owner"`
 
 	Reason string `json:"reason,omitempty"`
+
+	// The item is removed from the cluster after this time
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+
+	Status string `json:"status,omitempty"`
+
+	ApprovedBy string `json:"approvedBy,omitempty"`
 }
@@ -0,0 +1,20 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments.
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type ReplaceNodePoolRequest struct {
+
+	// Machine image new nodes are started from. The current image is kept when omitted.
+	Image string `json:"image,omitempty"`
+
+	// Number of extra nodes started (and old nodes drained) at a time.
+	MaxSurge int32 `json:"maxSurge,omitempty"`
+}
@@ -12,6 +12,9 @@ package pipeline
 
 type ScanLogItem struct {
 
+	// Image scanner that checked the images
+	Scanner string `json:"scanner,omitempty"`
+
 	ReleaseName string `json:"releaseName,omitempty"`
 
 	Resource string `json:"resource,omitempty"`
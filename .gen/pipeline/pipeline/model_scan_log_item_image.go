@@ -19,4 +19,6 @@ type ScanLogItemImage struct {
 	ImageDigest string `json:"imageDigest,omitempty"`
 
 	LastUpdated string `json:"lastUpdated,omitempty"`
+
+	Vulnerabilities []ScanLogVulnerability `json:"vulnerabilities,omitempty"`
 }
@@ -0,0 +1,26 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type ScanLogVulnerability struct {
+
+	Id string `json:"id,omitempty"`
+
+	Severity string `json:"severity,omitempty"`
+
+	Package string `json:"package,omitempty"`
+
+	Version string `json:"version,omitempty"`
+
+	FixedVersion string `json:"fixedVersion,omitempty"`
+
+	Url string `json:"url,omitempty"`
+}
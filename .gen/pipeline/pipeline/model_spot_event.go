@@ -0,0 +1,38 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+
+package pipeline
+
+import (
+	"time"
+)
+
+type SpotEvent struct {
+
+	ClusterId int32 `json:"clusterId,omitempty"`
+
+	NodePool string `json:"nodePool,omitempty"`
+
+	InstanceId string `json:"instanceId,omitempty"`
+
+	InstanceType string `json:"instanceType,omitempty"`
+
+	Zone string `json:"zone,omitempty"`
+
+	Type string `json:"type,omitempty"`
+
+	Source string `json:"source,omitempty"`
+
+	Reason string `json:"reason,omitempty"`
+
+	OccurredAt time.Time `json:"occurredAt,omitempty"`
+}
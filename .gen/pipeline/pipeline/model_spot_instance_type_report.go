@@ -0,0 +1,47 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+
+package pipeline
+
+type SpotInstanceTypeReport struct {
+
+	Cloud string `json:"cloud,omitempty"`
+
+	Distribution string `json:"distribution,omitempty"`
+
+	Location string `json:"location,omitempty"`
+
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// Number of spot instances running in the period
+	Instances int32 `json:"instances,omitempty"`
+
+	// Number of spot instances interrupted in the period
+	Interruptions int32 `json:"interruptions,omitempty"`
+
+	InterruptionRate float64 `json:"interruptionRate,omitempty"`
+
+	// Total running time of the spot instances in hours
+	Hours float64 `json:"hours,omitempty"`
+
+	SpotCost float64 `json:"spotCost,omitempty"`
+
+	// Cost of the same capacity on demand
+	OnDemandCost float64 `json:"onDemandCost,omitempty"`
+
+	Savings float64 `json:"savings,omitempty"`
+
+	// False if no price is available for the instance type
+	Priced bool `json:"priced,omitempty"`
+
+	Reason string `json:"reason,omitempty"`
+}
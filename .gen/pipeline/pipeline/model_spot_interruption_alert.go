@@ -0,0 +1,25 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+
+package pipeline
+
+import (
+	"time"
+)
+
+type SpotInterruptionAlert struct {
+
+	// Alert labels (instance_id, instance_type, availability_zone, nodepool and alertname are recorded)
+	Labels map[string]string `json:"labels,omitempty"`
+
+	StartsAt time.Time `json:"startsAt,omitempty"`
+}
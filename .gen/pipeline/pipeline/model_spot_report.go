@@ -0,0 +1,41 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+
+
+package pipeline
+
+import (
+	"time"
+)
+
+type SpotReport struct {
+
+	Currency string `json:"currency,omitempty"`
+
+	From time.Time `json:"from,omitempty"`
+
+	To time.Time `json:"to,omitempty"`
+
+	InstanceTypes []SpotInstanceTypeReport `json:"instanceTypes,omitempty"`
+
+	SpotCost float64 `json:"spotCost,omitempty"`
+
+	OnDemandCost float64 `json:"onDemandCost,omitempty"`
+
+	Savings float64 `json:"savings,omitempty"`
+
+	Instances int32 `json:"instances,omitempty"`
+
+	Interruptions int32 `json:"interruptions,omitempty"`
+
+	// False if some of the instance types could not be priced
+	Complete bool `json:"complete,omitempty"`
+}
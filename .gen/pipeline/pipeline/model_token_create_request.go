@@ -21,4 +21,6 @@ type TokenCreateRequest struct {
 	VirtualUser string `json:"virtualUser,omitempty"`
 
 	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	Scopes TokenScopes `json:"scopes,omitempty"`
 }
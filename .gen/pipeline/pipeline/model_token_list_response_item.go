@@ -17,4 +17,6 @@ type TokenListResponseItem struct {
 	CreatedAt string `json:"createdAt"`
 
 	Name string `json:"name"`
+
+	Scopes TokenScopes `json:"scopes,omitempty"`
 }
@@ -0,0 +1,24 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+// TokenScopes - Restrictions of a token. Empty fields do not restrict the token.
+type TokenScopes struct {
+
+	// ID of the organization the token can be used in
+	Organization int32 `json:"organization,omitempty"`
+
+	// BRN of the cluster the token can be used for
+	Cluster string `json:"cluster,omitempty"`
+
+	// Allowed actions in resource:verb form, where verb is one of read, write or *. Write access implies read access.
+	Actions []string `json:"actions,omitempty"`
+}
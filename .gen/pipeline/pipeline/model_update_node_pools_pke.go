@@ -35,4 +35,7 @@ type UpdateNodePoolsPke struct {
 
 	// user provided custom node labels to be placed onto the nodes of the node pool
 	Labels map[string]string `json:"labels,omitempty"`
+
+	// Node pool taints.
+	Taints []NodePoolTaint `json:"taints,omitempty"`
 }
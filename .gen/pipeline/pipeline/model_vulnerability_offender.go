@@ -0,0 +1,24 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type VulnerabilityOffender struct {
+
+	ClusterIds []int32 `json:"clusterIds,omitempty"`
+
+	Namespace string `json:"namespace,omitempty"`
+
+	Release string `json:"release,omitempty"`
+
+	Image VulnerabilityImage `json:"image,omitempty"`
+
+	Counts VulnerabilitySeverityCounts `json:"counts,omitempty"`
+}
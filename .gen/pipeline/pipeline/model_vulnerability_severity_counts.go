@@ -0,0 +1,26 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type VulnerabilitySeverityCounts struct {
+
+	Critical int32 `json:"critical,omitempty"`
+
+	High int32 `json:"high,omitempty"`
+
+	Medium int32 `json:"medium,omitempty"`
+
+	Low int32 `json:"low,omitempty"`
+
+	Negligible int32 `json:"negligible,omitempty"`
+
+	Unknown int32 `json:"unknown,omitempty"`
+}
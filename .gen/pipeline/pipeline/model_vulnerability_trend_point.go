@@ -0,0 +1,26 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+import (
+	"time"
+)
+
+type VulnerabilityTrendPoint struct {
+
+	// Start of the day (UTC)
+	Date time.Time `json:"date,omitempty"`
+
+	Counts VulnerabilitySeverityCounts `json:"counts,omitempty"`
+
+	// Number of clusters reported on the day
+	Clusters int32 `json:"clusters,omitempty"`
+}
@@ -0,0 +1,42 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+import (
+	"time"
+)
+
+type WebhookDelivery struct {
+
+	Id int32 `json:"id,omitempty"`
+
+	SubscriptionId int32 `json:"subscriptionId,omitempty"`
+
+	EventId string `json:"eventId,omitempty"`
+
+	EventType string `json:"eventType,omitempty"`
+
+	Payload map[string]interface{} `json:"payload,omitempty"`
+
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// Status code of the last attempt
+	StatusCode int32 `json:"statusCode,omitempty"`
+
+	// Error of the last attempt
+	Error string `json:"error,omitempty"`
+
+	Succeeded bool `json:"succeeded,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+
+	CompletedAt time.Time `json:"completedAt,omitempty"`
+}
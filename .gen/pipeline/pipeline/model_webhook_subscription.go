@@ -0,0 +1,33 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+import (
+	"time"
+)
+
+type WebhookSubscription struct {
+
+	Id int32 `json:"id,omitempty"`
+
+	OrganizationId int32 `json:"organizationId,omitempty"`
+
+	Name string `json:"name,omitempty"`
+
+	Url string `json:"url,omitempty"`
+
+	EventTypes []string `json:"eventTypes,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt,omitempty"`
+
+	// Secret used to sign the payloads (only returned on creation)
+	Secret string `json:"secret,omitempty"`
+}
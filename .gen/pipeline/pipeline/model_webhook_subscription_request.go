@@ -0,0 +1,25 @@
+/*
+ * Pipeline API
+ *
+ * Pipeline is a feature rich application platform, built for containers on top of Kubernetes to automate the DevOps experience, continuous application development and the lifecycle of deployments. 
+ *
+ * API version: latest
+ * Contact: info@banzaicloud.com
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package pipeline
+
+type WebhookSubscriptionRequest struct {
+
+	Name string `json:"name"`
+
+	// HTTP(S) endpoint receiving the events
+	Url string `json:"url"`
+
+	// Event types to receive (* subscribes to every event)
+	EventTypes []string `json:"eventTypes"`
+
+	// Secret used to sign the payloads (generated when empty)
+	Secret string `json:"secret,omitempty"`
+}
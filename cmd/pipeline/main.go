@@ -34,7 +34,6 @@ import (
 	"github.com/ThreeDotsLabs/watermill/components/cqrs"
 	"github.com/ThreeDotsLabs/watermill/message"
 	watermillMiddleware "github.com/ThreeDotsLabs/watermill/message/router/middleware"
-	evbus "github.com/asaskevich/EventBus"
 	bauth "github.com/banzaicloud/bank-vaults/pkg/sdk/auth"
 	"github.com/banzaicloud/bank-vaults/pkg/sdk/vault"
 	ginprometheus "github.com/banzaicloud/go-gin-prometheus"
@@ -60,6 +59,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	watermilllog "logur.dev/integration/watermill"
 	zaplog "logur.dev/integration/zap"
 	"logur.dev/logur"
 
@@ -78,16 +78,33 @@ import (
 	arkClusterManager "github.com/banzaicloud/pipeline/internal/ark/clustermanager"
 	arkEvents "github.com/banzaicloud/pipeline/internal/ark/events"
 	arkSync "github.com/banzaicloud/pipeline/internal/ark/sync"
+	intAudit "github.com/banzaicloud/pipeline/internal/audit"
+	"github.com/banzaicloud/pipeline/internal/audit/auditadapter"
+	"github.com/banzaicloud/pipeline/internal/audit/auditdriver"
 	intCluster "github.com/banzaicloud/pipeline/internal/cluster"
 	intClusterAuth "github.com/banzaicloud/pipeline/internal/cluster/auth"
 	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter"
 	"github.com/banzaicloud/pipeline/internal/cluster/clusterdriver"
 	"github.com/banzaicloud/pipeline/internal/cluster/clustersecret"
 	"github.com/banzaicloud/pipeline/internal/cluster/clustersecret/clustersecretadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+	"github.com/banzaicloud/pipeline/internal/cluster/cost/costadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/cost/costdriver"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksadapter"
 	eksDriver "github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/driver"
 	"github.com/banzaicloud/pipeline/internal/cluster/endpoints"
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig"
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig/kubeconfigadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig/kubeconfigdriver"
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeproxy"
 	prometheusMetrics "github.com/banzaicloud/pipeline/internal/cluster/metrics/adapters/prometheus"
+	"github.com/banzaicloud/pipeline/internal/cluster/networkpolicy/networkpolicyadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/recommender"
+	"github.com/banzaicloud/pipeline/internal/cluster/recommender/recommenderadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/recommender/recommenderdriver"
+	"github.com/banzaicloud/pipeline/internal/cluster/spot"
+	"github.com/banzaicloud/pipeline/internal/cluster/spot/spotadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/spot/spotdriver"
 	"github.com/banzaicloud/pipeline/internal/clustergroup"
 	cgroupAdapter "github.com/banzaicloud/pipeline/internal/clustergroup/adapter"
 	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
@@ -117,7 +134,10 @@ import (
 	integratedServiceVault "github.com/banzaicloud/pipeline/internal/integratedservices/services/vault"
 	cgFeatureIstio "github.com/banzaicloud/pipeline/internal/istio/istiofeature"
 	"github.com/banzaicloud/pipeline/internal/kubernetes"
+	"github.com/banzaicloud/pipeline/internal/kubernetes/kubernetesadapter"
 	"github.com/banzaicloud/pipeline/internal/monitor"
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage"
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage/bucketusageadapter"
 	intPKE "github.com/banzaicloud/pipeline/internal/pke"
 	"github.com/banzaicloud/pipeline/internal/platform/appkit"
 	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
@@ -134,6 +154,8 @@ import (
 	"github.com/banzaicloud/pipeline/internal/platform/watermill"
 	azurePKEAdapter "github.com/banzaicloud/pipeline/internal/providers/azure/pke/adapter"
 	azurePKEDriver "github.com/banzaicloud/pipeline/internal/providers/azure/pke/driver"
+	baremetalPKEAdapter "github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/adapter"
+	baremetalPKEDriver "github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/driver"
 	"github.com/banzaicloud/pipeline/internal/providers/google"
 	"github.com/banzaicloud/pipeline/internal/providers/google/googleadapter"
 	vspherePKEAdapter "github.com/banzaicloud/pipeline/internal/providers/vsphere/pke/adapter"
@@ -142,6 +164,14 @@ import (
 	"github.com/banzaicloud/pipeline/internal/secret/restricted"
 	"github.com/banzaicloud/pipeline/internal/secret/secretadapter"
 	"github.com/banzaicloud/pipeline/internal/secret/types"
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport"
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport/vulnreportadapter"
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport/vulnreportdriver"
+	"github.com/banzaicloud/pipeline/internal/security/whitelist"
+	"github.com/banzaicloud/pipeline/internal/security/whitelist/whitelistadapter"
+	"github.com/banzaicloud/pipeline/internal/webhook"
+	"github.com/banzaicloud/pipeline/internal/webhook/webhookadapter"
+	"github.com/banzaicloud/pipeline/internal/webhook/webhookdriver"
 	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
 	"github.com/banzaicloud/pipeline/pkg/cloudinfo"
 	"github.com/banzaicloud/pipeline/pkg/ctxutil"
@@ -285,9 +315,9 @@ func main() {
 	cicdDB, err := database.Connect(config.CICD.Database)
 	emperror.Panic(errors.WithMessage(err, "failed to initialize CICD db"))
 
-	publisher, subscriber := watermill.NewPubSub(logger)
+	publisher, subscriberFactory, err := watermill.NewPubSub(config.EventBus, db, logger)
+	emperror.Panic(errors.WithMessage(err, "failed to initialize event bus"))
 	defer publisher.Close()
-	defer subscriber.Close()
 
 	publisher, _ = message.MessageTransformPublisherDecorator(func(msg *message.Message) {
 		if cid, ok := correlation.FromContext(msg.Context()); ok {
@@ -295,15 +325,27 @@ func main() {
 		}
 	})(publisher)
 
-	subscriber, _ = message.MessageTransformSubscriberDecorator(func(msg *message.Message) {
-		if cid := watermillMiddleware.MessageCorrelationID(msg); cid != "" {
-			msg.SetContext(correlation.ToContext(msg.Context(), cid))
+	newSubscriber := func(consumerGroup string) (message.Subscriber, error) {
+		subscriber, err := subscriberFactory(consumerGroup)
+		if err != nil {
+			return nil, err
 		}
-	})(subscriber)
+
+		return message.MessageTransformSubscriberDecorator(func(msg *message.Message) {
+			if cid := watermillMiddleware.MessageCorrelationID(msg); cid != "" {
+				msg.SetContext(correlation.ToContext(msg.Context(), cid))
+			}
+		})(subscriber)
+	}
 
 	// Used internally to make sure every event/command bus uses the same one
 	eventMarshaler := cqrs.JSONMarshaler{GenerateName: cqrs.StructName}
 
+	const (
+		clusterTopic           = "cluster"
+		integratedServiceTopic = "integratedservice"
+	)
+
 	commonSecretStore := commonadapter.NewSecretStore(secret.Store, commonadapter.OrgIDContextExtractorFunc(auth.GetCurrentOrganizationID))
 
 	organizationStore := authadapter.NewGormOrganizationStore(db)
@@ -331,6 +373,17 @@ func main() {
 		)
 	}
 
+	webhookDispatcher := webhook.NewDispatcher(
+		webhookadapter.NewGormStore(db),
+		webhookadapter.NewHTTPSender(webhookadapter.NewHTTPClient(config.Webhook.Timeout), fmt.Sprintf("Pipeline/%s", version)),
+		webhook.DispatcherConfig{
+			MaxRetries: config.Webhook.MaxRetries,
+			RetryDelay: config.Webhook.RetryDelay,
+		},
+		commonLogger.WithFields(map[string]interface{}{"component": "webhook"}),
+		commonErrorHandler,
+	)
+
 	// Initialize auth
 	tokenStore := bauth.NewVaultTokenStore("pipeline")
 	tokenGenerator := pkgAuth.NewJWTTokenGenerator(
@@ -363,8 +416,12 @@ func main() {
 
 	prometheus.MustRegister(cluster.NewExporter())
 
-	clusterEventBus := evbus.New()
-	clusterEvents := cluster.NewClusterEvents(clusterEventBus)
+	clusterEventBus, _ := cqrs.NewEventBus(
+		publisher,
+		func(eventName string) string { return clusterTopic },
+		eventMarshaler,
+	)
+	clusterEvents := cluster.NewClusterEvents(intCluster.NewClusterEventDispatcher(clusterEventBus), errorHandler)
 	clusters := clusteradapter.NewClusters(db)
 	secretValidator := providers.NewSecretValidator(secret.Store)
 	statusChangeDurationMetric := prometheusMetrics.MakePrometheusClusterStatusChangeDurationMetric()
@@ -421,6 +478,7 @@ func main() {
 		exporter := monitor.NewSpotMetricsExporter(
 			ctx,
 			clusterManager,
+			spot.NewRecorder(spotadapter.NewGormStore(db)),
 			logrusLogger.WithField("subsystem", "spot-metrics-exporter"),
 		)
 
@@ -444,6 +502,7 @@ func main() {
 
 	azurePKEClusterStore := azurePKEAdapter.NewClusterStore(db, commonLogger)
 	gormVspherePKEClusterStore := vspherePKEAdapter.NewClusterStore(db)
+	gormBaremetalPKEClusterStore := baremetalPKEAdapter.NewClusterStore(db)
 	k8sPreparer := intPKE.MakeKubernetesPreparer(logrusLogger, "Kubernetes")
 	clusterCreators := api.ClusterCreators{
 		PKEOnAzure: azurePKEDriver.MakeClusterCreator(
@@ -480,16 +539,75 @@ func main() {
 			gormVspherePKEClusterStore,
 			workflowClient,
 		),
+		PKEOnBaremetal: baremetalPKEDriver.MakeBaremetalPKEClusterCreator(
+			commonLogger,
+			baremetalPKEDriver.ClusterCreatorConfig{
+				OIDCIssuerURL:               config.Auth.OIDC.Issuer,
+				PipelineExternalURL:         externalBaseURL,
+				PipelineExternalURLInsecure: externalURLInsecure,
+			},
+			k8sPreparer,
+			authdriver.NewOrganizationGetter(db),
+			secret.Store,
+			gormBaremetalPKEClusterStore,
+			workflowClient,
+		),
 	}
 
 	cgroupAdapter := cgroupAdapter.NewClusterGetter(clusterManager)
 	clusterGroupManager := clustergroup.NewManager(cgroupAdapter, clustergroup.NewClusterGroupRepository(db, logrusLogger), logrusLogger, errorHandler)
 	federationHandler := federation.NewFederationHandler(cgroupAdapter, config.Cluster.Namespace, logrusLogger, errorHandler, config.Cluster.Federation, config.Cluster.DNS.Config)
-	deploymentManager := deployment.NewCGDeploymentManager(db, cgroupAdapter, logrusLogger, errorHandler)
+	deploymentManager := deployment.NewCGDeploymentManager(db, cgroupAdapter, workflowClient, webhookadapter.NewDeploymentEventDispatcher(webhookDispatcher), logrusLogger, errorHandler)
 	serviceMeshFeatureHandler := cgFeatureIstio.NewServiceMeshFeatureHandler(cgroupAdapter, logrusLogger, errorHandler, config.Cluster.Backyards)
 	clusterGroupManager.RegisterFeatureHandler(federation.FeatureName, federationHandler)
 	clusterGroupManager.RegisterFeatureHandler(deployment.FeatureName, deploymentManager)
 	clusterGroupManager.RegisterFeatureHandler(cgFeatureIstio.FeatureName, serviceMeshFeatureHandler)
+
+	// Event handlers share the events with the handlers of other pipeline and worker instances (when the event bus is durable)
+	{
+		router, err := watermill.NewRouter(watermill.RouterConfig{CloseTimeout: 10 * time.Second}, logger)
+		emperror.Panic(err)
+
+		clusterStore := clusteradapter.NewStore(db, clusters)
+
+		eventHandlers := map[string][]cqrs.EventHandler{
+			organizationTopic: {
+				webhookadapter.NewOrganizationCreatedEventHandler(webhookDispatcher, commonErrorHandler),
+			},
+			clusterTopic: {
+				clustergroup.NewClusterCreatedEventHandler(clusterGroupManager),
+				clustergroup.NewClusterUpdatedEventHandler(clusterGroupManager),
+				arkEvents.NewClusterEventHandler(db, logrusLogger),
+				webhookadapter.NewClusterCreatedEventHandler(clusterStore, webhookDispatcher, commonErrorHandler),
+				webhookadapter.NewClusterUpdatedEventHandler(clusterStore, webhookDispatcher, commonErrorHandler),
+				webhookadapter.NewClusterDeletedEventHandler(webhookDispatcher, commonErrorHandler),
+			},
+			integratedServiceTopic: {
+				webhookadapter.NewIntegratedServiceStatusChangedEventHandler(clusterStore, webhookDispatcher, commonErrorHandler),
+			},
+		}
+
+		for topic, handlers := range eventHandlers {
+			topic := topic
+
+			eventProcessor, err := cqrs.NewEventProcessor(
+				handlers,
+				func(eventName string) string { return topic },
+				newSubscriber,
+				eventMarshaler,
+				watermilllog.New(logur.WithFields(logger, map[string]interface{}{"component": "watermill"})),
+			)
+			emperror.Panic(err)
+
+			err = eventProcessor.AddHandlersToRouter(router)
+			emperror.Panic(err)
+		}
+
+		group.Add(
+			func() error { return router.Run(context.Background()) },
+			func(err error) { _ = router.Close() },
+		)
+	}
 	clusterUpdaters := api.ClusterUpdaters{
 		PKEOnAzure: azurePKEDriver.MakeClusterUpdater(
 			logrusLogger,
@@ -503,6 +621,28 @@ func main() {
 			logrusLogger,
 			workflowClient,
 		),
+		PKEOnVsphere: vspherePKEDriver.MakeClusterUpdater(
+			commonLogger,
+			vspherePKEDriver.ClusterCreatorConfig{
+				OIDCIssuerURL:               config.Auth.OIDC.Issuer,
+				PipelineExternalURL:         externalBaseURL,
+				PipelineExternalURLInsecure: externalURLInsecure,
+			},
+			secret.Store,
+			gormVspherePKEClusterStore,
+			workflowClient,
+		),
+		PKEOnBaremetal: baremetalPKEDriver.MakeClusterUpdater(
+			commonLogger,
+			baremetalPKEDriver.ClusterCreatorConfig{
+				OIDCIssuerURL:               config.Auth.OIDC.Issuer,
+				PipelineExternalURL:         externalBaseURL,
+				PipelineExternalURLInsecure: externalURLInsecure,
+			},
+			secret.Store,
+			gormBaremetalPKEClusterStore,
+			workflowClient,
+		),
 	}
 
 	configFactory := kubernetes.NewConfigFactory(commonSecretStore)
@@ -520,6 +660,7 @@ func main() {
 		clusterCreators,
 		clusterUpdaters,
 		dynamicClientFactory,
+		kubeproxy.NewAccessController(config.Cluster.Proxy, organizationStore),
 	)
 
 	// Initialise Gin router
@@ -610,11 +751,13 @@ func main() {
 
 	enforcer := auth.NewRbacEnforcer(organizationStore, serviceAccountService, commonLogger)
 	authorizationMiddleware := ginauth.NewMiddleware(enforcer, basePath, errorHandler)
+	scopedTokenHandler := auth.NewScopedTokenHandler(basePath)
 
 	dashboardAPI := dashboard.NewDashboardAPI(clusterManager, clusterGroupManager, logrusLogger, errorHandler)
 	dgroup := base.Group(path.Join("dashboard", "orgs"))
 	dgroup.Use(auth.InternalHandler)
 	dgroup.Use(auth.Handler)
+	dgroup.Use(scopedTokenHandler)
 	dgroup.Use(api.OrganizationMiddleware)
 	dgroup.Use(authorizationMiddleware)
 	dgroup.GET("/:orgid/clusters", dashboardAPI.GetDashboard)
@@ -631,6 +774,12 @@ func main() {
 	organizationAPI := api.NewOrganizationAPI(organizationSyncer, auth.NewRefreshTokenStore(tokenStore))
 	userAPI := api.NewUserAPI(db, scmTokenStore, logrusLogger, errorHandler)
 	networkAPI := api.NewNetworkAPI(logrusLogger)
+	bucketUsageAPI := api.NewBucketUsageAPI(
+		bucketusage.NewService(bucketusageadapter.NewGormStore(db), bucketusageadapter.NewGormReferenceLister(db)),
+		logrusLogger,
+		errorHandler,
+	)
+	networkPolicyDefaultsAPI := api.NewNetworkPolicyDefaultsAPI(networkpolicyadapter.NewGormStore(db), logrusLogger, errorHandler)
 
 	var spotguideAPI *api.SpotguideAPI
 
@@ -686,6 +835,7 @@ func main() {
 
 		v1.Use(auth.InternalHandler)
 		v1.Use(auth.Handler)
+		v1.Use(scopedTokenHandler)
 		capdriver.RegisterHTTPHandler(mapCapabilities(config), commonErrorHandler, v1)
 		v1.GET("/me", userAPI.GetCurrentUser)
 		v1.PATCH("/me", userAPI.UpdateCurrentUser)
@@ -792,6 +942,7 @@ func main() {
 						clusteradapter.NewNodePoolStore(db, clusterStore),
 						intCluster.NodePoolValidators{
 							intCluster.NewCommonNodePoolValidator(labelValidator),
+							clusteradapter.NewCloudinfoInstanceTypeValidator(cloudinfoClient),
 							intCluster.NewDistributionNodePoolValidator(map[string]intCluster.NodePoolValidator{
 								"eks": eksadapter.NewNodePoolValidator(db),
 							}),
@@ -828,6 +979,9 @@ func main() {
 					cRouter.DELETE("", gin.WrapH(router))
 					cRouter.Any("/nodepools", gin.WrapH(router))
 					cRouter.Any("/nodepools/:nodePoolName", gin.WrapH(router))
+					cRouter.Any("/nodepools/:nodePoolName/replace", gin.WrapH(router))
+					cRouter.Any("/labels", gin.WrapH(router))
+					cRouter.Any("/unlink", gin.WrapH(router))
 				}
 			}
 
@@ -839,7 +993,16 @@ func main() {
 			// Cluster IntegratedService API
 			var integratedServicesService integratedservices.Service
 			{
-				featureRepository := integratedserviceadapter.NewGormIntegratedServiceRepository(db, commonLogger)
+				integratedServiceEventBus, _ := cqrs.NewEventBus(
+					publisher,
+					func(eventName string) string { return integratedServiceTopic },
+					eventMarshaler,
+				)
+				featureRepository := integratedservices.NewEventDispatchingIntegratedServiceRepository(
+					integratedserviceadapter.NewGormIntegratedServiceRepository(db, commonLogger),
+					integratedservices.NewIntegratedServiceEventDispatcher(integratedServiceEventBus),
+					commonErrorHandler,
+				)
 				clusterGetter := integratedserviceadapter.MakeClusterGetter(clusterManager)
 				clusterPropertyGetter := dnsadapter.NewClusterPropertyGetter(clusterManager)
 				endpointManager := endpoints.NewEndpointManager(commonLogger)
@@ -895,7 +1058,13 @@ func main() {
 						))
 					}
 
-					securityApiHandler := api.NewSecurityApiHandlers(commonClusterGetter, commonErrorHandler, commonLogger)
+					securityApiHandler := api.NewSecurityApiHandlers(
+						commonClusterGetter,
+						whitelistadapter.NewGormRequestStore(db),
+						whitelist.Config{ApprovalRequired: config.Cluster.SecurityScan.Whitelist.ApprovalRequired},
+						commonErrorHandler,
+						commonLogger,
+					)
 
 					anchoreProxy := api.NewAnchoreProxy(basePath, configProvider, commonErrorHandler, commonLogger)
 					proxyHandler := anchoreProxy.Proxy()
@@ -909,6 +1078,7 @@ func main() {
 
 					cRouter.GET("/whitelists", securityApiHandler.GetWhiteLists)
 					cRouter.POST("/whitelists", securityApiHandler.CreateWhiteList)
+					cRouter.POST("/whitelists/:name/approve", securityApiHandler.ApproveWhiteList)
 					cRouter.DELETE("/whitelists/:name", securityApiHandler.DeleteWhiteList)
 				}
 
@@ -963,7 +1133,12 @@ func main() {
 			cRouter.DELETE("/hpa", hpaApi.DeleteHpaResource)
 
 			// ClusterGroupAPI
-			cgroupsAPI := cgroupAPI.NewAPI(clusterGroupManager, deploymentManager, logrusLogger, errorHandler)
+			driftDetector := clustergroup.NewDriftDetector(
+				clusterGroupManager,
+				integratedserviceadapter.NewGormIntegratedServiceRepository(db, commonLogger),
+				logrusLogger,
+			)
+			cgroupsAPI := cgroupAPI.NewAPI(clusterGroupManager, deploymentManager, driftDetector, logrusLogger, errorHandler)
 			cgroupsAPI.AddRoutes(orgs.Group("/:orgid/clustergroups"))
 
 			namespaceAPI := namespace.NewAPI(commonClusterGetter, clientFactory, errorHandler)
@@ -1032,6 +1207,154 @@ func main() {
 				orgs.PUT("/:orgid/helm/repos/:name", gin.WrapH(router))
 				orgs.DELETE("/:orgid/helm/repos/:name", gin.WrapH(router))
 			}
+			{
+				service := intAudit.NewService(auditadapter.NewGormStore(db))
+				endpoints := auditdriver.MakeEndpoints(
+					service,
+					kitxendpoint.Combine(endpointMiddleware...),
+				)
+
+				auditdriver.RegisterHTTPHandlers(
+					endpoints,
+					orgRouter.PathPrefix("/audit").Subrouter(),
+					kitxhttp.ServerOptions(httpServerOptions),
+				)
+
+				orgs.GET("/:orgid/audit/*path", gin.WrapH(router))
+			}
+			{
+				service := vulnreport.NewService(vulnreportadapter.NewGormStore(db))
+				endpoints := vulnreportdriver.MakeEndpoints(
+					service,
+					kitxendpoint.Combine(endpointMiddleware...),
+				)
+
+				vulnreportdriver.RegisterHTTPHandlers(
+					endpoints,
+					orgRouter,
+					kitxhttp.ServerOptions(httpServerOptions),
+				)
+
+				orgs.GET("/:orgid/vulnerabilities/*path", gin.WrapH(router))
+				cRouter.GET("/vulnerabilities/*path", gin.WrapH(router))
+			}
+			{
+				logger := commonadapter.NewLogger(logger) // TODO: make this a context aware logger
+				clusters := clusteradapter.NewClusters(db)
+
+				service := kubeconfig.NewService(
+					kubeconfig.Config{
+						DefaultTTL:       config.Cluster.Kubeconfig.DefaultTTL,
+						MaxTTL:           config.Cluster.Kubeconfig.MaxTTL,
+						DefaultNamespace: config.Cluster.Kubeconfig.DefaultNamespace,
+						ClusterRoles:     config.Cluster.Kubeconfig.ClusterRoles,
+					},
+					auth.UserExtractor{},
+					organizationStore,
+					clusteradapter.NewStore(db, clusters),
+					kubernetes.NewService(
+						kubernetesadapter.NewConfigSecretGetter(clusters),
+						configFactory,
+						logger,
+					),
+					kubeconfigadapter.NewGormStore(db),
+					kubeconfigadapter.NewServiceAccountIssuer(
+						intCluster.NewClientFactory(clusteradapter.NewStore(db, clusters), clientFactory),
+						config.Cluster.Kubeconfig.Namespace,
+					),
+				)
+				endpoints := kubeconfigdriver.MakeEndpoints(
+					service,
+					kitxendpoint.Combine(endpointMiddleware...),
+				)
+
+				kubeconfigdriver.RegisterHTTPHandlers(
+					endpoints,
+					orgRouter,
+					kitxhttp.ServerOptions(httpServerOptions),
+				)
+
+				cRouter.POST("/kubeconfigs", gin.WrapH(router))
+				cRouter.GET("/kubeconfigs", gin.WrapH(router))
+				cRouter.DELETE("/kubeconfigs/:kubeconfigId", gin.WrapH(router))
+			}
+			{
+				service := recommender.NewService(recommenderadapter.NewCloudinfoProductSource(cloudinfoClient))
+				endpoints := recommenderdriver.MakeEndpoints(
+					service,
+					kitxendpoint.Combine(endpointMiddleware...),
+				)
+
+				recommenderdriver.RegisterHTTPHandlers(
+					endpoints,
+					orgRouter,
+					kitxhttp.ServerOptions(httpServerOptions),
+				)
+
+				orgs.POST("/:orgid/recommendations/nodepools", gin.WrapH(router))
+			}
+			{
+				service := cost.NewService(
+					cost.NewEstimator(config.Cluster.Cost, costadapter.NewCloudinfoPriceSource(cloudinfoClient)),
+					costadapter.NewClusterSource(clusterManager),
+				)
+				endpoints := costdriver.MakeEndpoints(
+					service,
+					kitxendpoint.Combine(endpointMiddleware...),
+				)
+
+				costdriver.RegisterHTTPHandlers(
+					endpoints,
+					orgRouter,
+					kitxhttp.ServerOptions(httpServerOptions),
+				)
+
+				orgs.POST("/:orgid/cost/estimates", gin.WrapH(router))
+				cRouter.GET("/cost", gin.WrapH(router))
+				cRouter.POST("/cost/estimates", gin.WrapH(router))
+			}
+			{
+				service := spot.NewService(
+					spotadapter.NewGormStore(db),
+					spotadapter.NewClusterSource(clusteradapter.NewStore(db, clusteradapter.NewClusters(db))),
+					costadapter.NewCloudinfoPriceSource(cloudinfoClient),
+				)
+				endpoints := spotdriver.MakeEndpoints(
+					service,
+					kitxendpoint.Combine(endpointMiddleware...),
+				)
+
+				spotdriver.RegisterHTTPHandlers(
+					endpoints,
+					orgRouter,
+					kitxhttp.ServerOptions(httpServerOptions),
+				)
+
+				orgs.GET("/:orgid/spot/events", gin.WrapH(router))
+				orgs.GET("/:orgid/spot/report", gin.WrapH(router))
+				cRouter.POST("/spot/events", gin.WrapH(router))
+			}
+			{
+				service := webhook.NewService(webhookadapter.NewGormStore(db), webhookDispatcher, webhookadapter.NewHostValidator(net.DefaultResolver))
+				endpoints := webhookdriver.MakeEndpoints(
+					service,
+					kitxendpoint.Combine(endpointMiddleware...),
+				)
+
+				webhookdriver.RegisterHTTPHandlers(
+					endpoints,
+					orgRouter.PathPrefix("/webhooks").Subrouter(),
+					kitxhttp.ServerOptions(httpServerOptions),
+				)
+
+				orgs.POST("/:orgid/webhooks", gin.WrapH(router))
+				orgs.GET("/:orgid/webhooks", gin.WrapH(router))
+				orgs.GET("/:orgid/webhooks/:subscriptionId", gin.WrapH(router))
+				orgs.DELETE("/:orgid/webhooks/:subscriptionId", gin.WrapH(router))
+				orgs.GET("/:orgid/webhooks/:subscriptionId/deliveries", gin.WrapH(router))
+				orgs.POST("/:orgid/webhooks/:subscriptionId/deliveries/:deliveryId/redeliver", gin.WrapH(router))
+			}
+
 			orgs.GET("/:orgid/secrets", api.ListSecrets)
 			orgs.GET("/:orgid/secrets/:id", api.GetSecret)
 			orgs.POST("/:orgid/secrets", api.AddSecrets)
@@ -1048,12 +1371,17 @@ func main() {
 			orgs.POST("/:orgid/buckets", api.CreateBucket)
 			orgs.HEAD("/:orgid/buckets/:name", api.CheckBucket)
 			orgs.GET("/:orgid/buckets/:name", api.GetBucket)
+			orgs.PUT("/:orgid/buckets/:name", api.UpdateBucket)
 			orgs.DELETE("/:orgid/buckets/:name", api.DeleteBucket)
+			orgs.GET("/:orgid/bucketusage", bucketUsageAPI.GetBucketUsage)
 
 			orgs.GET("/:orgid/networks", networkAPI.ListVPCNetworks)
 			orgs.GET("/:orgid/networks/:id/subnets", networkAPI.ListVPCSubnets)
 			orgs.GET("/:orgid/networks/:id/routeTables", networkAPI.ListRouteTables)
 
+			orgs.GET("/:orgid/networkpolicies/defaults", networkPolicyDefaultsAPI.GetDefaults)
+			orgs.PUT("/:orgid/networkpolicies/defaults", networkPolicyDefaultsAPI.SetDefaults)
+
 			orgs.GET("/:orgid/azure/resourcegroups", api.GetResourceGroups)
 			orgs.POST("/:orgid/azure/resourcegroups", api.AddResourceGroups)
 
@@ -1085,7 +1413,7 @@ func main() {
 		{
 			service := token.NewService(
 				auth.UserExtractor{},
-				tokenadapter.NewBankVaultsStore(tokenStore),
+				tokenadapter.NewBankVaultsStore(tokenStore, db),
 				tokenGenerator,
 			)
 			service = tokendriver.AuthorizationMiddleware(auth.NewAuthorizer(db, organizationStore))(service)
@@ -1130,7 +1458,6 @@ func main() {
 		backups.AddOrgRoutes(orgs.Group("/:orgid/backups"), clusterManager)
 	}
 
-	arkEvents.NewClusterEventHandler(arkEvents.NewClusterEvents(clusterEventBus), db, logrusLogger)
 	if config.Cluster.DisasterRecovery.Ark.SyncEnabled {
 		ctx, cancel := context.WithCancel(context.Background())
 
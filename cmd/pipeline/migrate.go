@@ -19,15 +19,23 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/banzaicloud/pipeline/internal/app/frontend/notification/notificationadapter"
+	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/token/tokenadapter"
 	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter/clustermodel"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksmodel"
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig/kubeconfigadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/networkpolicy/networkpolicyadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/spot/spotadapter"
 	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
 	"github.com/banzaicloud/pipeline/internal/common"
 	"github.com/banzaicloud/pipeline/internal/helm/helmadapter"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter"
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage/bucketusageadapter"
 	"github.com/banzaicloud/pipeline/internal/providers/alibaba/alibabaadapter"
 	"github.com/banzaicloud/pipeline/internal/providers/azure/azureadapter"
 	"github.com/banzaicloud/pipeline/internal/providers/kubernetes/kubernetesadapter"
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport/vulnreportadapter"
+	"github.com/banzaicloud/pipeline/internal/security/whitelist/whitelistadapter"
+	"github.com/banzaicloud/pipeline/internal/webhook/webhookadapter"
 	"github.com/banzaicloud/pipeline/src/model"
 
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/api/middleware/audit"
@@ -109,5 +117,37 @@ func Migrate(db *gorm.DB, logger logrus.FieldLogger, commonLogger common.Logger)
 		return err
 	}
 
+	if err := tokenadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := webhookadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := vulnreportadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := whitelistadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := networkpolicyadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := kubeconfigadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := spotadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
+	if err := bucketusageadapter.Migrate(db, commonLogger); err != nil {
+		return err
+	}
+
 	return nil
 }
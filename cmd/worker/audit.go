@@ -0,0 +1,56 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/audit"
+	"github.com/banzaicloud/pipeline/internal/audit/auditadapter"
+	"github.com/banzaicloud/pipeline/internal/audit/auditworkflow"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+func registerAuditWorkflows(config auditRetentionConfig, db *gorm.DB) {
+	var archiver audit.Archiver
+	if config.Archive.Enabled {
+		archiver = auditadapter.NewObjectStoreArchiver(config.Archive.ArchiveConfig, secret.Store)
+	}
+
+	workflow.RegisterWithOptions(auditworkflow.RetentionWorkflow, workflow.RegisterOptions{Name: auditworkflow.RetentionWorkflowName})
+
+	retentionActivity := auditworkflow.NewRetentionActivity(auditadapter.NewGormStore(db), archiver)
+	activity.RegisterWithOptions(retentionActivity.Execute, activity.RegisterOptions{Name: auditworkflow.RetentionActivityName})
+}
+
+// scheduleAuditRetention schedules the audit retention cron workflow.
+func scheduleAuditRetention(ctx context.Context, workflowClient client.Client, taskList string, config auditRetentionConfig) error {
+	return scheduleCronWorkflow(ctx, workflowClient, taskList, cronWorkflow{
+		Name:     auditworkflow.RetentionWorkflowName,
+		Enabled:  config.Enabled,
+		Schedule: config.Schedule,
+		Timeout:  3 * time.Hour,
+		Args: []interface{}{auditworkflow.RetentionWorkflowInput{
+			MaxAge:  config.MaxAge,
+			Archive: config.Archive.Enabled,
+		}},
+	})
+}
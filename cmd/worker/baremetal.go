@@ -0,0 +1,60 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke"
+	baremetalworkflow "github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/workflow"
+	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow"
+	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow/pkeworkflowadapter"
+)
+
+func registerBaremetalWorkflows(secretStore pkeworkflow.SecretStore, tokenGenerator pkeworkflowadapter.TokenGenerator, store pke.ClusterStore, hostKeyStore pke.HostKeyStore, clientFactory clusterworkflow.ClientFactory) {
+	workflow.RegisterWithOptions(baremetalworkflow.CreateClusterWorkflow, workflow.RegisterOptions{Name: baremetalworkflow.CreateClusterWorkflowName})
+
+	sshClientFactory := baremetalworkflow.NewSSHClientFactory(secretStore, hostKeyStore)
+
+	installNodeActivity := baremetalworkflow.MakeInstallNodeActivity(sshClientFactory, tokenGenerator)
+	activity.RegisterWithOptions(installNodeActivity.Execute, activity.RegisterOptions{Name: baremetalworkflow.InstallNodeActivityName})
+
+	waitForNodeActivity := baremetalworkflow.MakeWaitForNodeActivity(clientFactory)
+	activity.RegisterWithOptions(waitForNodeActivity.Execute, activity.RegisterOptions{Name: baremetalworkflow.WaitForNodeActivityName})
+
+	setClusterStatusActivity := baremetalworkflow.MakeSetClusterStatusActivity(store)
+	activity.RegisterWithOptions(setClusterStatusActivity.Execute, activity.RegisterOptions{Name: baremetalworkflow.SetClusterStatusActivityName})
+
+	workflow.RegisterWithOptions(baremetalworkflow.DeleteClusterWorkflow, workflow.RegisterOptions{Name: baremetalworkflow.DeleteClusterWorkflowName})
+
+	uninstallNodeActivity := baremetalworkflow.MakeUninstallNodeActivity(sshClientFactory)
+	activity.RegisterWithOptions(uninstallNodeActivity.Execute, activity.RegisterOptions{Name: baremetalworkflow.UninstallNodeActivityName})
+
+	deleteClusterFromStoreActivity := baremetalworkflow.MakeDeleteClusterFromStoreActivity(store)
+	activity.RegisterWithOptions(deleteClusterFromStoreActivity.Execute, activity.RegisterOptions{Name: baremetalworkflow.DeleteClusterFromStoreActivityName})
+
+	workflow.RegisterWithOptions(baremetalworkflow.UpdateClusterWorkflow, workflow.RegisterOptions{Name: baremetalworkflow.UpdateClusterWorkflowName})
+
+	deleteK8sNodeActivity := baremetalworkflow.MakeDeleteK8sNodeActivity(clientFactory)
+	activity.RegisterWithOptions(deleteK8sNodeActivity.Execute, activity.RegisterOptions{Name: baremetalworkflow.DeleteK8sNodeActivityName})
+
+	deleteNodePoolFromStoreActivity := baremetalworkflow.MakeDeleteNodePoolFromStoreActivity(store)
+	activity.RegisterWithOptions(deleteNodePoolFromStoreActivity.Execute, activity.RegisterOptions{Name: baremetalworkflow.DeleteNodePoolFromStoreActivityName})
+
+	setNodePoolHostsActivity := baremetalworkflow.MakeSetNodePoolHostsActivity(store)
+	activity.RegisterWithOptions(setNodePoolHostsActivity.Execute, activity.RegisterOptions{Name: baremetalworkflow.SetNodePoolHostsActivityName})
+}
@@ -0,0 +1,57 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/sirupsen/logrus"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage"
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage/bucketusageadapter"
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage/bucketusageworkflow"
+)
+
+func registerBucketUsageWorkflows(config bucketUsageConfig, db *gorm.DB, logger logrus.FieldLogger) {
+	bucketStore := bucketusageadapter.NewObjectStoreBucketStore(logger)
+
+	workflow.RegisterWithOptions(bucketusageworkflow.CollectWorkflow, workflow.RegisterOptions{Name: bucketusageworkflow.CollectWorkflowName})
+
+	listOrganizationsActivity := bucketusageworkflow.NewListOrganizationsActivity(bucketusageadapter.NewGormOrganizationLister(db))
+	activity.RegisterWithOptions(listOrganizationsActivity.Execute, activity.RegisterOptions{Name: bucketusageworkflow.ListOrganizationsActivityName})
+
+	collectActivity := bucketusageworkflow.NewCollectActivity(bucketusage.NewCollector(
+		bucketStore,
+		bucketStore,
+		bucketusageadapter.NewGormStore(db),
+		config.PrefixDepth,
+	))
+	activity.RegisterWithOptions(collectActivity.Execute, activity.RegisterOptions{Name: bucketusageworkflow.CollectActivityName})
+}
+
+// scheduleBucketUsage schedules the bucket usage cron workflow.
+func scheduleBucketUsage(ctx context.Context, workflowClient client.Client, taskList string, config bucketUsageConfig) error {
+	return scheduleCronWorkflow(ctx, workflowClient, taskList, cronWorkflow{
+		Name:     bucketusageworkflow.CollectWorkflowName,
+		Enabled:  config.Enabled,
+		Schedule: config.Schedule,
+		Timeout:  12 * time.Hour,
+	})
+}
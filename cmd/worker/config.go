@@ -24,7 +24,9 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"github.com/banzaicloud/pipeline/internal/audit/auditadapter"
 	"github.com/banzaicloud/pipeline/internal/cmd"
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage"
 	"github.com/banzaicloud/pipeline/src/auth"
 )
 
@@ -35,6 +37,14 @@ type configuration struct {
 
 	Auth authConfig
 
+	Audit struct {
+		Retention auditRetentionConfig
+	}
+
+	VulnerabilityReport vulnerabilityReportConfig
+
+	BucketUsage bucketUsageConfig
+
 	// Meaningful values are recommended (eg. production, development, staging, release/123, etc)
 	Environment string
 
@@ -69,6 +79,12 @@ func (c configuration) Validate() error {
 
 	errs = errors.Append(errs, c.Auth.Validate())
 
+	errs = errors.Append(errs, c.Audit.Retention.Validate())
+
+	errs = errors.Append(errs, c.VulnerabilityReport.Validate())
+
+	errs = errors.Append(errs, c.BucketUsage.Validate())
+
 	if c.CICD.Enabled {
 		if c.CICD.URL == "" {
 			errs = errors.Append(errs, errors.New("cicd url is required"))
@@ -142,6 +158,115 @@ func (c authConfig) Validate() error {
 	return errs
 }
 
+type auditRetentionConfig struct {
+	Enabled bool
+
+	// Events older than this are removed
+	MaxAge time.Duration
+
+	// Cron schedule of the retention workflow
+	Schedule string
+
+	// Archive events to a Pipeline managed bucket before removing them
+	Archive struct {
+		Enabled bool
+
+		auditadapter.ArchiveConfig `mapstructure:",squash"`
+	}
+}
+
+func (c auditRetentionConfig) Validate() error {
+	var errs error
+
+	if !c.Enabled {
+		return errs
+	}
+
+	if c.MaxAge <= 0 {
+		errs = errors.Append(errs, errors.New("audit retention max age must be positive"))
+	}
+
+	if c.Schedule == "" {
+		errs = errors.Append(errs, errors.New("audit retention schedule is required"))
+	}
+
+	if c.Archive.Enabled {
+		if c.Archive.OrganizationID == 0 {
+			errs = errors.Append(errs, errors.New("audit archive organization is required"))
+		}
+
+		if c.Archive.SecretID == "" {
+			errs = errors.Append(errs, errors.New("audit archive secret is required"))
+		}
+
+		if c.Archive.Provider == "" {
+			errs = errors.Append(errs, errors.New("audit archive provider is required"))
+		}
+
+		if c.Archive.Bucket == "" {
+			errs = errors.Append(errs, errors.New("audit archive bucket is required"))
+		}
+	}
+
+	return errs
+}
+
+type vulnerabilityReportConfig struct {
+	Enabled bool
+
+	// Cron schedule of the collect workflow
+	Schedule string
+
+	// Reports older than this are removed (zero keeps every report)
+	MaxAge time.Duration
+}
+
+func (c vulnerabilityReportConfig) Validate() error {
+	var errs error
+
+	if !c.Enabled {
+		return errs
+	}
+
+	if c.Schedule == "" {
+		errs = errors.Append(errs, errors.New("vulnerability report schedule is required"))
+	}
+
+	if c.MaxAge < 0 {
+		errs = errors.Append(errs, errors.New("vulnerability report max age cannot be negative"))
+	}
+
+	return errs
+}
+
+type bucketUsageConfig struct {
+	Enabled bool
+
+	// Cron schedule of the collect workflow
+	Schedule string
+
+	// Number of key segments objects are grouped by (the API can report the usage of shallower prefixes only)
+	PrefixDepth int
+}
+
+func (c bucketUsageConfig) Validate() error {
+	var errs error
+
+	if !c.Enabled {
+		return errs
+	}
+
+	if c.Schedule == "" {
+		errs = errors.Append(errs, errors.New("bucket usage schedule is required"))
+	}
+
+	if c.PrefixDepth < 0 || c.PrefixDepth > bucketusage.MaxPrefixDepth {
+		errs = errors.Append(errs, errors.NewWithDetails("bucket usage prefix depth is out of range", "min", 0, "max", bucketusage.MaxPrefixDepth))
+	}
+
+	return errs
+}
+
 // configure configures some defaults in the Viper instance.
 func configure(v *viper.Viper, p *pflag.FlagSet) {
 	v.AllowEmptyEnv(true)
@@ -175,6 +300,27 @@ func configure(v *viper.Viper, p *pflag.FlagSet) {
 	v.SetDefault("cadence::createNonexistentDomain", false)
 	v.SetDefault("cadence::workflowExecutionRetentionPeriodInDays", 3)
 
+	v.SetDefault("audit::retention::enabled", false)
+	v.SetDefault("audit::retention::maxAge", 90*24*time.Hour)
+	v.SetDefault("audit::retention::schedule", "0 3 * * *")
+	v.SetDefault("audit::retention::archive::enabled", false)
+	v.SetDefault("audit::retention::archive::organizationId", 0)
+	v.SetDefault("audit::retention::archive::secretId", "")
+	v.SetDefault("audit::retention::archive::provider", "")
+	v.SetDefault("audit::retention::archive::bucket", "")
+	v.SetDefault("audit::retention::archive::location", "")
+	v.SetDefault("audit::retention::archive::resourceGroup", "")
+	v.SetDefault("audit::retention::archive::storageAccount", "")
+	v.SetDefault("audit::retention::archive::prefix", "audit")
+
+	v.SetDefault("vulnerabilityReport::enabled", false)
+	v.SetDefault("vulnerabilityReport::schedule", "0 */6 * * *")
+	v.SetDefault("vulnerabilityReport::maxAge", 90*24*time.Hour)
+
+	v.SetDefault("bucketUsage::enabled", false)
+	v.SetDefault("bucketUsage::schedule", "0 3 * * *")
+	v.SetDefault("bucketUsage::prefixDepth", 2)
+
 	v.SetDefault("pipeline::uuid", "")
 	v.SetDefault("pipeline::external::url", "")
 }
@@ -0,0 +1,129 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/.gen/go/shared"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/encoded"
+)
+
+// cronWorkflow describes a workflow scheduled by the worker.
+// The workflow name is used as the workflow ID.
+type cronWorkflow struct {
+	Name     string
+	Enabled  bool
+	Schedule string
+	Timeout  time.Duration
+	Args     []interface{}
+}
+
+// scheduleCronWorkflow starts a cron workflow unless it is already running.
+//
+// A running execution is terminated (and restarted) only if the workflow is disabled
+// or its schedule or input differs from the configuration.
+func scheduleCronWorkflow(ctx context.Context, workflowClient client.Client, taskList string, w cronWorkflow) error {
+	workflowID := w.Name
+
+	upToDate, running, err := isCronWorkflowUpToDate(ctx, workflowClient, w)
+	if err != nil {
+		return err
+	}
+
+	if running && (!w.Enabled || !upToDate) {
+		err := workflowClient.TerminateWorkflow(ctx, workflowID, "", "cron workflow rescheduled", nil)
+		if err != nil {
+			var ene *shared.EntityNotExistsError
+			if !errors.As(err, &ene) {
+				return errors.WrapIfWithDetails(err, "failed to terminate cron workflow", "workflowId", workflowID)
+			}
+		}
+	}
+
+	if !w.Enabled {
+		return nil
+	}
+
+	options := client.StartWorkflowOptions{
+		ID:                           workflowID,
+		TaskList:                     taskList,
+		ExecutionStartToCloseTimeout: w.Timeout,
+		WorkflowIDReusePolicy:        client.WorkflowIDReusePolicyAllowDuplicate,
+		CronSchedule:                 w.Schedule,
+	}
+
+	_, err = workflowClient.StartWorkflow(ctx, options, w.Name, w.Args...)
+	if err != nil {
+		// the workflow is already running (or another worker instance scheduled it in the meantime)
+		var wes *shared.WorkflowExecutionAlreadyStartedError
+		if errors.As(err, &wes) {
+			return nil
+		}
+
+		return errors.WrapIfWithDetails(err, "failed to start cron workflow", "workflowId", workflowID)
+	}
+
+	return nil
+}
+
+// isCronWorkflowUpToDate checks whether a running execution of the workflow was started with the configured schedule and input.
+func isCronWorkflowUpToDate(ctx context.Context, workflowClient client.Client, w cronWorkflow) (upToDate bool, running bool, err error) {
+	resp, err := workflowClient.DescribeWorkflowExecution(ctx, w.Name, "")
+	if err != nil {
+		var ene *shared.EntityNotExistsError
+		if errors.As(err, &ene) {
+			return false, false, nil
+		}
+
+		return false, false, errors.WrapIfWithDetails(err, "failed to describe cron workflow", "workflowId", w.Name)
+	}
+
+	info := resp.WorkflowExecutionInfo
+	if info == nil || info.CloseStatus != nil {
+		return false, false, nil
+	}
+
+	var runID string
+	if info.Execution != nil {
+		runID = info.Execution.GetRunId()
+	}
+
+	iter := workflowClient.GetWorkflowHistory(ctx, w.Name, runID, false, shared.HistoryEventFilterTypeAllEvent)
+	if !iter.HasNext() {
+		return false, true, nil
+	}
+
+	event, err := iter.Next()
+	if err != nil {
+		return false, true, errors.WrapIfWithDetails(err, "failed to get cron workflow history", "workflowId", w.Name)
+	}
+
+	attributes := event.WorkflowExecutionStartedEventAttributes
+	if attributes == nil {
+		return false, true, nil
+	}
+
+	input, err := encoded.GetDefaultDataConverter().ToData(w.Args...)
+	if err != nil {
+		return false, true, errors.WrapIfWithDetails(err, "failed to encode cron workflow input", "workflowId", w.Name)
+	}
+
+	return attributes.GetCronSchedule() == w.Schedule && bytes.Equal(attributes.Input, input), true, nil
+}
@@ -0,0 +1,131 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/.gen/go/shared"
+	"go.uber.org/cadence/encoded"
+	"go.uber.org/cadence/mocks"
+)
+
+type testCronWorkflowInput struct {
+	MaxAge time.Duration
+}
+
+func mockRunningCronWorkflow(ctx context.Context, t *testing.T, client *mocks.Client, name string, schedule string, args ...interface{}) {
+	input, err := encoded.GetDefaultDataConverter().ToData(args...)
+	require.NoError(t, err)
+
+	runID := "run"
+
+	client.On("DescribeWorkflowExecution", ctx, name, "").Return(&shared.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &shared.WorkflowExecutionInfo{
+			Execution: &shared.WorkflowExecution{WorkflowId: &name, RunId: &runID},
+		},
+	}, nil)
+
+	iter := new(mocks.HistoryEventIterator)
+	iter.On("HasNext").Return(true)
+	iter.On("Next").Return(&shared.HistoryEvent{
+		WorkflowExecutionStartedEventAttributes: &shared.WorkflowExecutionStartedEventAttributes{
+			CronSchedule: &schedule,
+			Input:        input,
+		},
+	}, nil)
+
+	client.On("GetWorkflowHistory", ctx, name, runID, false, shared.HistoryEventFilterTypeAllEvent).Return(iter)
+}
+
+func TestScheduleCronWorkflow(t *testing.T) {
+	ctx := context.Background()
+	const name = "cron-workflow"
+
+	w := cronWorkflow{
+		Name:     name,
+		Enabled:  true,
+		Schedule: "@every 1h",
+		Timeout:  time.Hour,
+		Args:     []interface{}{testCronWorkflowInput{MaxAge: time.Hour}},
+	}
+
+	t.Run("NotRunning", func(t *testing.T) {
+		client := new(mocks.Client)
+		client.On("DescribeWorkflowExecution", ctx, name, "").Return(nil, &shared.EntityNotExistsError{})
+		client.On("StartWorkflow", ctx, mock.Anything, name, w.Args[0]).Return(nil, nil)
+
+		err := scheduleCronWorkflow(ctx, client, "tasklist", w)
+		require.NoError(t, err)
+
+		client.AssertExpectations(t)
+		client.AssertNotCalled(t, "TerminateWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("UpToDate", func(t *testing.T) {
+		client := new(mocks.Client)
+		mockRunningCronWorkflow(ctx, t, client, name, w.Schedule, w.Args...)
+		client.On("StartWorkflow", ctx, mock.Anything, name, w.Args[0]).Return(nil, &shared.WorkflowExecutionAlreadyStartedError{})
+
+		err := scheduleCronWorkflow(ctx, client, "tasklist", w)
+		require.NoError(t, err)
+
+		client.AssertExpectations(t)
+		client.AssertNotCalled(t, "TerminateWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ScheduleChanged", func(t *testing.T) {
+		client := new(mocks.Client)
+		mockRunningCronWorkflow(ctx, t, client, name, "@every 2h", w.Args...)
+		client.On("TerminateWorkflow", ctx, name, "", mock.Anything, []byte(nil)).Return(nil)
+		client.On("StartWorkflow", ctx, mock.Anything, name, w.Args[0]).Return(nil, nil)
+
+		err := scheduleCronWorkflow(ctx, client, "tasklist", w)
+		require.NoError(t, err)
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("InputChanged", func(t *testing.T) {
+		client := new(mocks.Client)
+		mockRunningCronWorkflow(ctx, t, client, name, w.Schedule, testCronWorkflowInput{MaxAge: 2 * time.Hour})
+		client.On("TerminateWorkflow", ctx, name, "", mock.Anything, []byte(nil)).Return(nil)
+		client.On("StartWorkflow", ctx, mock.Anything, name, w.Args[0]).Return(nil, nil)
+
+		err := scheduleCronWorkflow(ctx, client, "tasklist", w)
+		require.NoError(t, err)
+
+		client.AssertExpectations(t)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		w := w
+		w.Enabled = false
+
+		client := new(mocks.Client)
+		mockRunningCronWorkflow(ctx, t, client, name, w.Schedule, w.Args...)
+		client.On("TerminateWorkflow", ctx, name, "", mock.Anything, []byte(nil)).Return(nil)
+
+		err := scheduleCronWorkflow(ctx, client, "tasklist", w)
+		require.NoError(t, err)
+
+		client.AssertExpectations(t)
+		client.AssertNotCalled(t, "StartWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
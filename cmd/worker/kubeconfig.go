@@ -0,0 +1,61 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig"
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig/kubeconfigadapter"
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig/kubeconfigworkflow"
+	"github.com/banzaicloud/pipeline/internal/cmd"
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+func registerKubeconfigWorkflows(
+	db *gorm.DB,
+	clusters kubeconfig.ClusterStore,
+	clientFactory kubeconfigadapter.ClientFactory,
+	config cmd.ClusterKubeconfigConfig,
+	logger common.Logger,
+) {
+	workflow.RegisterWithOptions(kubeconfigworkflow.ExpiryWorkflow, workflow.RegisterOptions{Name: kubeconfigworkflow.ExpiryWorkflowName})
+
+	expirer := kubeconfig.NewExpirer(
+		clusters,
+		kubeconfigadapter.NewGormStore(db),
+		kubeconfigadapter.NewServiceAccountIssuer(clientFactory, config.Namespace),
+		logger,
+	)
+
+	revokeExpiredActivity := kubeconfigworkflow.NewRevokeExpiredActivity(expirer)
+	activity.RegisterWithOptions(revokeExpiredActivity.Execute, activity.RegisterOptions{Name: kubeconfigworkflow.RevokeExpiredActivityName})
+}
+
+// scheduleKubeconfigExpiry schedules the kube config expiry cron workflow.
+func scheduleKubeconfigExpiry(ctx context.Context, workflowClient client.Client, taskList string, config cmd.ClusterKubeconfigConfig) error {
+	return scheduleCronWorkflow(ctx, workflowClient, taskList, cronWorkflow{
+		Name:     kubeconfigworkflow.ExpiryWorkflowName,
+		Enabled:  true,
+		Schedule: config.ExpirySchedule,
+		Timeout:  time.Hour,
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/banzaicloud/pipeline/internal/cmd"
+	"github.com/banzaicloud/pipeline/internal/common"
+	kubernetesprovider "github.com/banzaicloud/pipeline/internal/providers/kubernetes"
+	"github.com/banzaicloud/pipeline/internal/providers/kubernetes/kubernetesadapter"
+	"github.com/banzaicloud/pipeline/internal/providers/kubernetes/kubernetesworkflow"
+	"github.com/banzaicloud/pipeline/pkg/k8sclient"
+)
+
+func registerKubernetesWorkflows(
+	db *gorm.DB,
+	statuses kubernetesadapter.ClusterStatusSetter,
+	configs kubernetesprovider.ConfigGetter,
+	config cmd.ClusterImportedConfig,
+	logger common.Logger,
+) {
+	workflow.RegisterWithOptions(kubernetesworkflow.HealthCheckWorkflow, workflow.RegisterOptions{Name: kubernetesworkflow.HealthCheckWorkflowName})
+
+	store := kubernetesadapter.NewGormClusterStore(db, statuses)
+
+	listClustersActivity := kubernetesworkflow.NewListClustersActivity(store)
+	activity.RegisterWithOptions(listClustersActivity.Execute, activity.RegisterOptions{Name: kubernetesworkflow.ListClustersActivityName})
+
+	clientFactory := kubernetesprovider.ClientFactoryFunc(func(config *rest.Config) (kubernetes.Interface, error) {
+		return k8sclient.NewClientFromConfig(config)
+	})
+
+	checkClusterHealthActivity := kubernetesworkflow.NewCheckClusterHealthActivity(
+		store,
+		kubernetesprovider.NewHealthChecker(store, configs, clientFactory, config.HealthCheck.CredentialExpiryWarning, logger),
+	)
+	activity.RegisterWithOptions(checkClusterHealthActivity.Execute, activity.RegisterOptions{Name: kubernetesworkflow.CheckClusterHealthActivityName})
+}
+
+// scheduleImportedClusterHealthCheck schedules the imported cluster health check cron workflow.
+func scheduleImportedClusterHealthCheck(ctx context.Context, workflowClient client.Client, taskList string, config cmd.ClusterImportedConfig) error {
+	return scheduleCronWorkflow(ctx, workflowClient, taskList, cronWorkflow{
+		Name:     kubernetesworkflow.HealthCheckWorkflowName,
+		Enabled:  config.HealthCheck.Enabled,
+		Schedule: config.HealthCheck.Schedule,
+		Timeout:  time.Hour,
+	})
+}
@@ -21,24 +21,31 @@ import (
 	"os"
 	"syscall"
 	"text/template"
+	"time"
 
 	"emperror.dev/emperror"
 	"emperror.dev/errors"
 	"emperror.dev/errors/match"
+	"github.com/ThreeDotsLabs/watermill/components/cqrs"
+	"github.com/ThreeDotsLabs/watermill/message"
+	watermillMiddleware "github.com/ThreeDotsLabs/watermill/message/router/middleware"
 	bauth "github.com/banzaicloud/bank-vaults/pkg/sdk/auth"
 	"github.com/banzaicloud/bank-vaults/pkg/sdk/vault"
 	"github.com/mitchellh/mapstructure"
 	"github.com/oklog/run"
 	appkitrun "github.com/sagikazarmark/appkit/run"
+	"github.com/sagikazarmark/kitx/correlation"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"go.uber.org/cadence/activity"
 	"go.uber.org/cadence/workflow"
+	watermilllog "logur.dev/integration/watermill"
 	zaplog "logur.dev/integration/zap"
 	"logur.dev/logur"
 
 	cloudinfoapi "github.com/banzaicloud/pipeline/.gen/cloudinfo"
 	anchore2 "github.com/banzaicloud/pipeline/internal/anchore"
+	arkEvents "github.com/banzaicloud/pipeline/internal/ark/events"
 	cluster2 "github.com/banzaicloud/pipeline/internal/cluster"
 	intClusterAuth "github.com/banzaicloud/pipeline/internal/cluster/auth"
 	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter"
@@ -53,6 +60,7 @@ import (
 	intClusterDNS "github.com/banzaicloud/pipeline/internal/cluster/dns"
 	"github.com/banzaicloud/pipeline/internal/cluster/endpoints"
 	intClusterK8s "github.com/banzaicloud/pipeline/internal/cluster/kubernetes"
+	"github.com/banzaicloud/pipeline/internal/cluster/networkpolicy/networkpolicyadapter"
 	intClusterWorkflow "github.com/banzaicloud/pipeline/internal/cluster/workflow"
 	"github.com/banzaicloud/pipeline/internal/clustergroup"
 	cgroupAdapter "github.com/banzaicloud/pipeline/internal/clustergroup/adapter"
@@ -60,6 +68,7 @@ import (
 	"github.com/banzaicloud/pipeline/internal/common/commonadapter"
 	"github.com/banzaicloud/pipeline/internal/federation"
 	"github.com/banzaicloud/pipeline/internal/global"
+	"github.com/banzaicloud/pipeline/internal/global/globalcluster"
 	"github.com/banzaicloud/pipeline/internal/helm2"
 	"github.com/banzaicloud/pipeline/internal/helm2/helmadapter"
 	"github.com/banzaicloud/pipeline/internal/integratedservices"
@@ -87,8 +96,11 @@ import (
 	"github.com/banzaicloud/pipeline/internal/platform/database"
 	"github.com/banzaicloud/pipeline/internal/platform/errorhandler"
 	"github.com/banzaicloud/pipeline/internal/platform/log"
+	"github.com/banzaicloud/pipeline/internal/platform/watermill"
 	azurePKEAdapter "github.com/banzaicloud/pipeline/internal/providers/azure/pke/adapter"
 	azurepkedriver "github.com/banzaicloud/pipeline/internal/providers/azure/pke/driver"
+	baremetaladapter "github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/adapter"
+	baremetaldriver "github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/driver"
 	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow"
 	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow/pkeworkflowadapter"
 	vsphereadapter "github.com/banzaicloud/pipeline/internal/providers/vsphere/pke/adapter"
@@ -99,6 +111,8 @@ import (
 	"github.com/banzaicloud/pipeline/internal/secret/secretadapter"
 	"github.com/banzaicloud/pipeline/internal/secret/types"
 	anchore "github.com/banzaicloud/pipeline/internal/security"
+	"github.com/banzaicloud/pipeline/internal/webhook"
+	"github.com/banzaicloud/pipeline/internal/webhook/webhookadapter"
 	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
 	"github.com/banzaicloud/pipeline/pkg/cloudinfo"
 	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
@@ -231,6 +245,50 @@ func main() {
 		}
 		global.SetDB(db)
 
+		publisher, subscriberFactory, err := watermill.NewPubSub(config.EventBus, db, logger)
+		emperror.Panic(errors.WithMessage(err, "failed to initialize event bus"))
+		defer publisher.Close()
+
+		publisher, _ = message.MessageTransformPublisherDecorator(func(msg *message.Message) {
+			if cid, ok := correlation.FromContext(msg.Context()); ok {
+				watermillMiddleware.SetCorrelationID(cid, msg)
+			}
+		})(publisher)
+
+		newSubscriber := func(consumerGroup string) (message.Subscriber, error) {
+			subscriber, err := subscriberFactory(consumerGroup)
+			if err != nil {
+				return nil, err
+			}
+
+			return message.MessageTransformSubscriberDecorator(func(msg *message.Message) {
+				if cid := watermillMiddleware.MessageCorrelationID(msg); cid != "" {
+					msg.SetContext(correlation.ToContext(msg.Context(), cid))
+				}
+			})(subscriber)
+		}
+
+		// Used internally to make sure every event bus uses the same one
+		eventMarshaler := cqrs.JSONMarshaler{GenerateName: cqrs.StructName}
+
+		const (
+			clusterTopic           = "cluster"
+			integratedServiceTopic = "integratedservice"
+		)
+
+		clusterEventBus, _ := cqrs.NewEventBus(
+			publisher,
+			func(eventName string) string { return clusterTopic },
+			eventMarshaler,
+		)
+		clusterEvents := cluster.NewClusterEvents(cluster2.NewClusterEventDispatcher(clusterEventBus), errorHandler)
+
+		integratedServiceEventBus, _ := cqrs.NewEventBus(
+			publisher,
+			func(eventName string) string { return integratedServiceTopic },
+			eventMarshaler,
+		)
+
 		workflowClient, err := cadence.NewClient(config.Cadence, zaplog.New(logur.WithFields(logger, map[string]interface{}{"component": "cadence-client"})))
 		if err != nil {
 			errorHandler.Handle(errors.WrapIf(err, "Failed to configure Cadence client"))
@@ -240,7 +298,7 @@ func main() {
 		clusterManager := cluster.NewManager(
 			clusterRepo,
 			nil,
-			nil,
+			clusterEvents,
 			nil,
 			nil,
 			workflowClient,
@@ -248,6 +306,18 @@ func main() {
 			errorHandler,
 			clusteradapter.NewStore(db, clusterRepo),
 		)
+
+		webhookDispatcher := webhook.NewDispatcher(
+			webhookadapter.NewGormStore(db),
+			webhookadapter.NewHTTPSender(webhookadapter.NewHTTPClient(config.Webhook.Timeout), fmt.Sprintf("Pipeline/%s", version)),
+			webhook.DispatcherConfig{
+				MaxRetries: config.Webhook.MaxRetries,
+				RetryDelay: config.Webhook.RetryDelay,
+			},
+			commonLogger.WithFields(map[string]interface{}{"component": "webhook"}),
+			errorHandler,
+		)
+
 		tokenStore := bauth.NewVaultTokenStore("pipeline")
 		tokenManager := pkgAuth.NewTokenManager(
 			pkgAuth.NewJWTTokenGenerator(
@@ -259,6 +329,9 @@ func main() {
 		)
 		tokenGenerator := auth.NewClusterTokenGenerator(tokenManager, tokenStore)
 
+		// Used by legacy cluster post hooks
+		globalcluster.SetTokenGenerator(tokenGenerator)
+
 		helmService := helm2.NewHelmService(helmadapter.NewClusterService(clusterManager), commonadapter.NewLogger(logger))
 
 		clusters := pkeworkflowadapter.NewClusterManagerAdapter(clusterManager)
@@ -351,6 +424,13 @@ func main() {
 				kubernetes.NewDynamicClientFactory(configFactory),
 			)
 			activity.RegisterWithOptions(configureNodePoolLabelsActivity.Execute, activity.RegisterOptions{Name: clustersetup.ConfigureNodePoolLabelsActivityName})
+
+			applyDefaultNetworkPoliciesActivity := clustersetup.NewApplyDefaultNetworkPoliciesActivity(
+				config.Cluster.Namespace,
+				networkpolicyadapter.NewGormStore(db),
+				kubernetes.NewClientFactory(configFactory),
+			)
+			activity.RegisterWithOptions(applyDefaultNetworkPoliciesActivity.Execute, activity.RegisterOptions{Name: clustersetup.ApplyDefaultNetworkPoliciesActivityName})
 		}
 
 		workflow.RegisterWithOptions(cluster.CreateClusterWorkflow, workflow.RegisterOptions{Name: cluster.CreateClusterWorkflowName})
@@ -399,6 +479,7 @@ func main() {
 
 		clusterStore := clusteradapter.NewStore(db, clusteradapter.NewClusters(db))
 		vsphereClusterStore := vsphereadapter.NewClusterStore(db)
+		baremetalClusterStore := baremetaladapter.NewClusterStore(db)
 
 		cgroupAdapter := cgroupAdapter.NewClusterGetter(clusterManager)
 		clusterGroupManager := clustergroup.NewManager(cgroupAdapter, clustergroup.NewClusterGroupRepository(db, logrusLogger), logrusLogger, errorHandler)
@@ -406,12 +487,23 @@ func main() {
 			workflow.RegisterWithOptions(clusterworkflow.DeleteClusterWorkflow, workflow.RegisterOptions{Name: clusterworkflow.DeleteClusterWorkflowName})
 
 			federationHandler := federation.NewFederationHandler(cgroupAdapter, config.Cluster.Namespace, logrusLogger, errorHandler, config.Cluster.Federation, config.Cluster.DNS.Config)
-			deploymentManager := deployment.NewCGDeploymentManager(db, cgroupAdapter, logrusLogger, errorHandler)
+			deploymentManager := deployment.NewCGDeploymentManager(db, cgroupAdapter, workflowClient, webhookadapter.NewDeploymentEventDispatcher(webhookDispatcher), logrusLogger, errorHandler)
 			serviceMeshFeatureHandler := cgFeatureIstio.NewServiceMeshFeatureHandler(cgroupAdapter, logrusLogger, errorHandler, config.Cluster.Backyards)
 			clusterGroupManager.RegisterFeatureHandler(federation.FeatureName, federationHandler)
 			clusterGroupManager.RegisterFeatureHandler(deployment.FeatureName, deploymentManager)
 			clusterGroupManager.RegisterFeatureHandler(cgFeatureIstio.FeatureName, serviceMeshFeatureHandler)
 
+			workflow.RegisterWithOptions(deployment.RolloutDeploymentWorkflow, workflow.RegisterOptions{Name: deployment.RolloutDeploymentWorkflowName})
+
+			deployToClusterActivity := deployment.NewDeployToClusterActivity(deploymentManager)
+			activity.RegisterWithOptions(deployToClusterActivity.Execute, activity.RegisterOptions{Name: deployment.DeployToClusterActivityName})
+
+			checkDeploymentHealthActivity := deployment.NewCheckDeploymentHealthActivity(deploymentManager)
+			activity.RegisterWithOptions(checkDeploymentHealthActivity.Execute, activity.RegisterOptions{Name: deployment.CheckDeploymentHealthActivityName})
+
+			setRolloutStatusActivity := deployment.NewSetRolloutStatusActivity(deploymentManager)
+			activity.RegisterWithOptions(setRolloutStatusActivity.Execute, activity.RegisterOptions{Name: deployment.SetRolloutStatusActivityName})
+
 			removeClusterFromGroupActivity := clusterworkflow.MakeRemoveClusterFromGroupActivity(clusterGroupManager)
 			activity.RegisterWithOptions(removeClusterFromGroupActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.RemoveClusterFromGroupActivityName})
 
@@ -429,7 +521,7 @@ func main() {
 					clusteradapter.ClusterDeleterEntry{
 						Key: clusteradapter.MakeClusterDeleterKey(pkgCluster.Amazon, pkgCluster.EKS),
 						Deleter: eksClusterDriver.NewEKSClusterDeleter(
-							nil,
+							clusterEvents,
 							clusterManager.GetKubeProxyCache(),
 							logrusLogger,
 							secret.Store,
@@ -449,7 +541,7 @@ func main() {
 					clusteradapter.ClusterDeleterEntry{
 						Key: clusteradapter.MakeClusterDeleterKey(pkgCluster.Azure, pkgCluster.PKE),
 						Deleter: azurepkedriver.MakeClusterDeleter(
-							nil,
+							clusterEvents,
 							clusterManager.GetKubeProxyCache(),
 							logrusLogger,
 							secret.Store,
@@ -473,7 +565,7 @@ func main() {
 					clusteradapter.ClusterDeleterEntry{
 						Key: clusteradapter.MakeClusterDeleterKey(pkgCluster.Vsphere, pkgCluster.PKE),
 						Deleter: vspheredriver.MakeClusterDeleter(
-							nil,
+							clusterEvents,
 							clusterManager.GetKubeProxyCache(),
 							commonLogger,
 							secret.Store,
@@ -482,6 +574,18 @@ func main() {
 							workflowClient,
 						),
 					},
+					clusteradapter.ClusterDeleterEntry{
+						Key: clusteradapter.MakeClusterDeleterKey(pkgCluster.Baremetal, pkgCluster.PKE),
+						Deleter: baremetaldriver.MakeClusterDeleter(
+							clusterEvents,
+							clusterManager.GetKubeProxyCache(),
+							commonLogger,
+							secret.Store,
+							nil,
+							baremetalClusterStore,
+							workflowClient,
+						),
+					},
 				),
 			)
 			activity.RegisterWithOptions(deleteClusterActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.DeleteClusterActivityName})
@@ -522,11 +626,55 @@ func main() {
 
 			setClusterStatusActivity := clusterworkflow.NewSetClusterStatusActivity(clusterStore)
 			activity.RegisterWithOptions(setClusterStatusActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.SetClusterStatusActivityName})
+
+			workflow.RegisterWithOptions(clusterworkflow.ReplaceNodePoolWorkflow, workflow.RegisterOptions{Name: clusterworkflow.ReplaceNodePoolWorkflowName})
+
+			clientFactory := cluster2.NewClientFactory(clusterStore, kubernetes.NewClientFactory(configFactory))
+			awsSessionFactory := eksworkflow.NewAWSSessionFactory(secret.Store)
+
+			listNodePoolNodesActivity := clusterworkflow.NewListNodePoolNodesActivity(clientFactory)
+			activity.RegisterWithOptions(listNodePoolNodesActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.ListNodePoolNodesActivityName})
+
+			updateNodePoolImageActivity := clusterworkflow.NewUpdateNodePoolImageActivity(clusterStore, db, awsSessionFactory)
+			activity.RegisterWithOptions(updateNodePoolImageActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.UpdateNodePoolImageActivityName})
+
+			getNodePoolCapacityActivity := clusterworkflow.NewGetNodePoolCapacityActivity(clusterStore, awsSessionFactory)
+			activity.RegisterWithOptions(getNodePoolCapacityActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.GetNodePoolCapacityActivityName})
+
+			resizeNodePoolActivity := clusterworkflow.NewResizeNodePoolActivity(clusterStore, awsSessionFactory)
+			activity.RegisterWithOptions(resizeNodePoolActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.ResizeNodePoolActivityName})
+
+			waitNodePoolNodesActivity := clusterworkflow.NewWaitNodePoolNodesActivity(clientFactory)
+			activity.RegisterWithOptions(waitNodePoolNodesActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.WaitNodePoolNodesActivityName})
+
+			drainNodeActivity := clusterworkflow.NewDrainNodeActivity(clientFactory)
+			activity.RegisterWithOptions(drainNodeActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.DrainNodeActivityName})
+
+			uncordonNodeActivity := clusterworkflow.NewUncordonNodeActivity(clientFactory)
+			activity.RegisterWithOptions(uncordonNodeActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.UncordonNodeActivityName})
+
+			terminateNodeActivity := clusterworkflow.NewTerminateNodeActivity(clusterStore, clientFactory, awsSessionFactory)
+			activity.RegisterWithOptions(terminateNodeActivity.Execute, activity.RegisterOptions{Name: clusterworkflow.TerminateNodeActivityName})
 		}
 
 		// Register vsphere specific workflows
 
-		registerVsphereWorkflows(secretStore, tokenGenerator, vsphereClusterStore)
+		registerVsphereWorkflows(
+			secretStore,
+			tokenGenerator,
+			vsphereClusterStore,
+			cluster2.NewClientFactory(clusterStore, kubernetes.NewClientFactory(configFactory)),
+		)
+
+		// Register bare metal specific workflows
+
+		registerBaremetalWorkflows(
+			secretStore,
+			tokenGenerator,
+			baremetalClusterStore,
+			baremetaladapter.NewHostKeyStore(db),
+			cluster2.NewClientFactory(clusterStore, kubernetes.NewClientFactory(configFactory)),
+		)
 
 		generateCertificatesActivity := pkeworkflow.NewGenerateCertificatesActivity(clusterSecretStore)
 		activity.RegisterWithOptions(generateCertificatesActivity.Execute, activity.RegisterOptions{Name: pkeworkflow.GenerateCertificatesActivityName})
@@ -587,7 +735,11 @@ func main() {
 			orgGetter := authdriver.NewOrganizationGetter(db)
 
 			logger := commonadapter.NewLogger(logger) // TODO: make this a context aware logger
-			featureRepository := integratedserviceadapter.NewGormIntegratedServiceRepository(db, logger)
+			featureRepository := integratedservices.NewEventDispatchingIntegratedServiceRepository(
+				integratedserviceadapter.NewGormIntegratedServiceRepository(db, logger),
+				integratedservices.NewIntegratedServiceEventDispatcher(integratedServiceEventBus),
+				errorHandler,
+			)
 			kubernetesService := kubernetes.NewService(
 				kubernetesadapter.NewConfigSecretGetter(clusteradapter.NewClusters(db)),
 				kubernetes.NewConfigFactory(commonSecretStore),
@@ -694,6 +846,107 @@ func main() {
 			})
 
 			registerClusterFeatureWorkflows(featureOperatorRegistry, featureRepository)
+
+			// vulnerability reports are read with the credentials of the per-cluster Anchore users
+			reportConfigProvider := anchore2.ConfigProviderChain{customAnchoreConfigProvider}
+
+			if config.Cluster.SecurityScan.Anchore.Enabled {
+				reportConfigProvider = append(reportConfigProvider, securityscan.NewClusterAnchoreConfigProvider(
+					config.Cluster.SecurityScan.Anchore.Endpoint,
+					securityscanadapter.NewUserNameGenerator(securityscanadapter.NewClusterService(clusterManager)),
+					securityscanadapter.NewUserSecretStore(commonSecretStore),
+				))
+			}
+
+			registerVulnerabilityReportWorkflows(db, kubernetesService, reportConfigProvider)
+
+			registerWhitelistWorkflows(
+				db,
+				clusterGetter,
+				anchore.NewSecurityResourceService(logger),
+				webhookadapter.NewWhitelistEventDispatcher(webhookDispatcher),
+			)
+
+			registerKubernetesWorkflows(db, clusterStore, kubernetesService, config.Cluster.Imported, logger)
+
+			registerKubeconfigWorkflows(db, clusterStore, cluster2.NewClientFactory(clusterStore, kubernetes.NewClientFactory(configFactory)), config.Cluster.Kubeconfig, logger)
+		}
+
+		registerAuditWorkflows(config.Audit.Retention, db)
+
+		registerBucketUsageWorkflows(config.BucketUsage, db, logrusLogger)
+
+		if workflowClient != nil {
+			err = scheduleAuditRetention(context.Background(), workflowClient, taskList, config.Audit.Retention)
+			if err != nil {
+				errorHandler.Handle(err)
+			}
+
+			err = scheduleVulnerabilityReports(context.Background(), workflowClient, taskList, config.VulnerabilityReport)
+			if err != nil {
+				errorHandler.Handle(err)
+			}
+
+			err = scheduleBucketUsage(context.Background(), workflowClient, taskList, config.BucketUsage)
+			if err != nil {
+				errorHandler.Handle(err)
+			}
+
+			err = scheduleWhitelistExpiry(context.Background(), workflowClient, taskList, config.Cluster.SecurityScan)
+			if err != nil {
+				errorHandler.Handle(err)
+			}
+
+			err = scheduleImportedClusterHealthCheck(context.Background(), workflowClient, taskList, config.Cluster.Imported)
+			if err != nil {
+				errorHandler.Handle(err)
+			}
+
+			err = scheduleKubeconfigExpiry(context.Background(), workflowClient, taskList, config.Cluster.Kubeconfig)
+			if err != nil {
+				errorHandler.Handle(err)
+			}
+		}
+
+		// Event handlers share the events with the handlers of other pipeline and worker instances (when the event bus is durable)
+		{
+			router, err := watermill.NewRouter(watermill.RouterConfig{CloseTimeout: 10 * time.Second}, logger)
+			emperror.Panic(err)
+
+			eventHandlers := map[string][]cqrs.EventHandler{
+				clusterTopic: {
+					clustergroup.NewClusterCreatedEventHandler(clusterGroupManager),
+					clustergroup.NewClusterUpdatedEventHandler(clusterGroupManager),
+					arkEvents.NewClusterEventHandler(db, logrusLogger),
+					webhookadapter.NewClusterCreatedEventHandler(clusterStore, webhookDispatcher, errorHandler),
+					webhookadapter.NewClusterUpdatedEventHandler(clusterStore, webhookDispatcher, errorHandler),
+					webhookadapter.NewClusterDeletedEventHandler(webhookDispatcher, errorHandler),
+				},
+				integratedServiceTopic: {
+					webhookadapter.NewIntegratedServiceStatusChangedEventHandler(clusterStore, webhookDispatcher, errorHandler),
+				},
+			}
+
+			for topic, handlers := range eventHandlers {
+				topic := topic
+
+				eventProcessor, err := cqrs.NewEventProcessor(
+					handlers,
+					func(eventName string) string { return topic },
+					newSubscriber,
+					eventMarshaler,
+					watermilllog.New(logur.WithFields(logger, map[string]interface{}{"component": "watermill"})),
+				)
+				emperror.Panic(err)
+
+				err = eventProcessor.AddHandlersToRouter(router)
+				emperror.Panic(err)
+			}
+
+			group.Add(
+				func() error { return router.Run(context.Background()) },
+				func(err error) { _ = router.Close() },
+			)
 		}
 
 		group.Add(appkitrun.CadenceWorkerRun(worker))
@@ -0,0 +1,62 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/anchore"
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport"
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport/vulnreportadapter"
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport/vulnreportworkflow"
+)
+
+func registerVulnerabilityReportWorkflows(db *gorm.DB, kubernetesService vulnreportadapter.KubernetesService, configProvider anchore.ConfigProvider) {
+	store := vulnreportadapter.NewGormStore(db)
+
+	workflow.RegisterWithOptions(vulnreportworkflow.CollectWorkflow, workflow.RegisterOptions{Name: vulnreportworkflow.CollectWorkflowName})
+
+	listClustersActivity := vulnreportworkflow.NewListClustersActivity(vulnreportadapter.NewGormClusterLister(db))
+	activity.RegisterWithOptions(listClustersActivity.Execute, activity.RegisterOptions{Name: vulnreportworkflow.ListClustersActivityName})
+
+	collectActivity := vulnreportworkflow.NewCollectActivity(vulnreport.NewCollector(
+		vulnreportadapter.NewImageLister(kubernetesService),
+		vulnreportadapter.NewAnchoreScanner(configProvider),
+		store,
+	))
+	activity.RegisterWithOptions(collectActivity.Execute, activity.RegisterOptions{Name: vulnreportworkflow.CollectActivityName})
+
+	retentionActivity := vulnreportworkflow.NewRetentionActivity(store)
+	activity.RegisterWithOptions(retentionActivity.Execute, activity.RegisterOptions{Name: vulnreportworkflow.RetentionActivityName})
+}
+
+// scheduleVulnerabilityReports schedules the vulnerability report cron workflow.
+func scheduleVulnerabilityReports(ctx context.Context, workflowClient client.Client, taskList string, config vulnerabilityReportConfig) error {
+	return scheduleCronWorkflow(ctx, workflowClient, taskList, cronWorkflow{
+		Name:     vulnreportworkflow.CollectWorkflowName,
+		Enabled:  config.Enabled,
+		Schedule: config.Schedule,
+		Timeout:  3 * time.Hour,
+		Args: []interface{}{vulnreportworkflow.CollectWorkflowInput{
+			MaxAge: config.MaxAge,
+		}},
+	})
+}
@@ -0,0 +1,60 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cmd"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter"
+	anchore "github.com/banzaicloud/pipeline/internal/security"
+	"github.com/banzaicloud/pipeline/internal/security/whitelist"
+	"github.com/banzaicloud/pipeline/internal/security/whitelist/whitelistadapter"
+	"github.com/banzaicloud/pipeline/internal/security/whitelist/whitelistworkflow"
+)
+
+func registerWhitelistWorkflows(
+	db *gorm.DB,
+	clusterGetter integratedserviceadapter.ClusterGetter,
+	resources anchore.WhitelistService,
+	events whitelist.EventDispatcher,
+) {
+	workflow.RegisterWithOptions(whitelistworkflow.ExpiryWorkflow, workflow.RegisterOptions{Name: whitelistworkflow.ExpiryWorkflowName})
+
+	listClustersActivity := whitelistworkflow.NewListClustersActivity(whitelistadapter.NewGormClusterLister(db))
+	activity.RegisterWithOptions(listClustersActivity.Execute, activity.RegisterOptions{Name: whitelistworkflow.ListClustersActivityName})
+
+	expireActivity := whitelistworkflow.NewExpireActivity(
+		clusterGetter,
+		whitelist.NewExpirer(resources, whitelistadapter.NewGormRequestStore(db), events),
+	)
+	activity.RegisterWithOptions(expireActivity.Execute, activity.RegisterOptions{Name: whitelistworkflow.ExpireActivityName})
+}
+
+// scheduleWhitelistExpiry schedules the whitelist expiry cron workflow.
+func scheduleWhitelistExpiry(ctx context.Context, workflowClient client.Client, taskList string, config cmd.ClusterSecurityScanConfig) error {
+	return scheduleCronWorkflow(ctx, workflowClient, taskList, cronWorkflow{
+		Name:     whitelistworkflow.ExpiryWorkflowName,
+		Enabled:  config.Enabled && config.Whitelist.ExpirySchedule != "",
+		Schedule: config.Whitelist.ExpirySchedule,
+		Timeout:  time.Hour,
+	})
+}
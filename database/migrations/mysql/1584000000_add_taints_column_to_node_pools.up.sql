@@ -0,0 +1,4 @@
+ALTER TABLE amazon_node_pools ADD COLUMN `taints` text COLLATE utf8mb4_unicode_ci;
+ALTER TABLE azure_aks_node_pools ADD COLUMN `taints` text COLLATE utf8mb4_unicode_ci;
+ALTER TABLE google_gke_node_pools ADD COLUMN `taints` text COLLATE utf8mb4_unicode_ci;
+ALTER TABLE topology_nodepools ADD COLUMN `taints` text COLLATE utf8mb4_unicode_ci;
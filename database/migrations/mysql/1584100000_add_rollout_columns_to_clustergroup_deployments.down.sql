@@ -0,0 +1,6 @@
+ALTER TABLE clustergroup_deployments DROP COLUMN `rollout_strategy`;
+ALTER TABLE clustergroup_deployments DROP COLUMN `rollout_batch_size`;
+ALTER TABLE clustergroup_deployments DROP COLUMN `rollout_health_check_timeout`;
+ALTER TABLE clustergroup_deployments DROP COLUMN `rollout_status`;
+ALTER TABLE clustergroup_deployments DROP COLUMN `rollout_message`;
+ALTER TABLE clustergroup_deployments DROP COLUMN `rollout_workflow_id`;
@@ -0,0 +1,6 @@
+ALTER TABLE clustergroup_deployments ADD COLUMN `rollout_strategy` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL;
+ALTER TABLE clustergroup_deployments ADD COLUMN `rollout_batch_size` int(11) DEFAULT NULL;
+ALTER TABLE clustergroup_deployments ADD COLUMN `rollout_health_check_timeout` int(11) DEFAULT NULL;
+ALTER TABLE clustergroup_deployments ADD COLUMN `rollout_status` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL;
+ALTER TABLE clustergroup_deployments ADD COLUMN `rollout_message` text COLLATE utf8mb4_unicode_ci;
+ALTER TABLE clustergroup_deployments ADD COLUMN `rollout_workflow_id` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE clusters DROP COLUMN `labels`;
+ALTER TABLE clustergroups DROP COLUMN `selector`;
+ALTER TABLE clustergroup_members DROP COLUMN `dynamic`;
@@ -0,0 +1,3 @@
+ALTER TABLE clusters ADD COLUMN `labels` text COLLATE utf8mb4_unicode_ci;
+ALTER TABLE clustergroups ADD COLUMN `selector` text COLLATE utf8mb4_unicode_ci;
+ALTER TABLE clustergroup_members ADD COLUMN `dynamic` tinyint(1) DEFAULT '0';
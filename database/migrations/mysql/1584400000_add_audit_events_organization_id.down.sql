@@ -0,0 +1,2 @@
+DROP INDEX `idx_audit_events_organization_id` ON audit_events;
+ALTER TABLE audit_events DROP COLUMN `organization_id`;
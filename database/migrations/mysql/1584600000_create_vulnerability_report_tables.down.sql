@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `vulnerability_report_images`;
+DROP TABLE IF EXISTS `vulnerability_reports`;
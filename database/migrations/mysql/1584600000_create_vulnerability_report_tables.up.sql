@@ -0,0 +1,33 @@
+CREATE TABLE `vulnerability_reports` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `created_at` timestamp NULL DEFAULT NULL,
+  `organization_id` int(10) unsigned DEFAULT NULL,
+  `cluster_id` int(10) unsigned DEFAULT NULL,
+  `pending_images` int(11) DEFAULT NULL,
+  `critical` int(11) DEFAULT NULL,
+  `high` int(11) DEFAULT NULL,
+  `medium` int(11) DEFAULT NULL,
+  `low` int(11) DEFAULT NULL,
+  `negligible` int(11) DEFAULT NULL,
+  `unknown` int(11) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_vulnerability_reports_org_cluster` (`organization_id`,`cluster_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE `vulnerability_report_images` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `report_id` int(10) unsigned DEFAULT NULL,
+  `namespace` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `release` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `image_name` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `image_tag` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `image_digest` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `critical` int(11) DEFAULT NULL,
+  `high` int(11) DEFAULT NULL,
+  `medium` int(11) DEFAULT NULL,
+  `low` int(11) DEFAULT NULL,
+  `negligible` int(11) DEFAULT NULL,
+  `unknown` int(11) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_vulnerability_report_images_report_id` (`report_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,5 @@
+ALTER TABLE `alibaba_buckets` DROP COLUMN `settings`;
+ALTER TABLE `amazon_buckets` DROP COLUMN `settings`;
+ALTER TABLE `azure_buckets` DROP COLUMN `settings`;
+ALTER TABLE `google_buckets` DROP COLUMN `settings`;
+ALTER TABLE `oracle_buckets` DROP COLUMN `settings`;
@@ -0,0 +1,5 @@
+ALTER TABLE `alibaba_buckets` ADD COLUMN `settings` json;
+ALTER TABLE `amazon_buckets` ADD COLUMN `settings` json;
+ALTER TABLE `azure_buckets` ADD COLUMN `settings` json;
+ALTER TABLE `google_buckets` ADD COLUMN `settings` json;
+ALTER TABLE `oracle_buckets` ADD COLUMN `settings` json;
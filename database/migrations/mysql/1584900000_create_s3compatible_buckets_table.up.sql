@@ -0,0 +1,14 @@
+CREATE TABLE `s3compatible_buckets` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `organization_id` int(10) unsigned NOT NULL,
+  `name` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `endpoint` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `region` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `secret_ref` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `status` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `status_msg` text COLLATE utf8mb4_unicode_ci,
+  `settings` json,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_s3compatible_bucket_name` (`name`,`endpoint`),
+  KEY `idx_s3compatible_buckets_organization_id` (`organization_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
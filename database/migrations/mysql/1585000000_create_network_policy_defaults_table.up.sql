@@ -0,0 +1,9 @@
+CREATE TABLE `network_policy_defaults` (
+  `organization_id` int(10) unsigned NOT NULL,
+  `created_at` timestamp NULL DEFAULT NULL,
+  `updated_at` timestamp NULL DEFAULT NULL,
+  `deny_all` tinyint(1) DEFAULT NULL,
+  `allow_dns` tinyint(1) DEFAULT NULL,
+  `excluded_namespaces` text COLLATE utf8mb4_unicode_ci,
+  PRIMARY KEY (`organization_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
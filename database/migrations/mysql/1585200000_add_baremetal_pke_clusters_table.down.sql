@@ -0,0 +1,2 @@
+DROP  TABLE `baremetal_pke_clusters`;
+DROP  TABLE `baremetal_pke_node_pools`;
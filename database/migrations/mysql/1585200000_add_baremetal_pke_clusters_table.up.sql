@@ -0,0 +1,19 @@
+CREATE TABLE `baremetal_pke_clusters` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `cluster_id` int(10) unsigned DEFAULT NULL,
+  `spec` json DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_baremetal_pke_cluster_id` (`cluster_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+
+CREATE TABLE `baremetal_pke_node_pools` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `cluster_id` int(10) unsigned DEFAULT NULL,
+  `created_by` int(10) unsigned DEFAULT NULL,
+  `name` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `roles` json DEFAULT NULL,
+  `hosts` json DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_baremetal_pke_np_cluster_id_name` (`cluster_id`,`name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,5 @@
+ALTER TABLE kubernetes_clusters DROP COLUMN `node_pools`;
+ALTER TABLE kubernetes_clusters DROP COLUMN `credential_expires_at`;
+ALTER TABLE kubernetes_clusters DROP COLUMN `health_checked_at`;
+ALTER TABLE kubernetes_clusters DROP COLUMN `reachable`;
+ALTER TABLE kubernetes_clusters DROP COLUMN `version`;
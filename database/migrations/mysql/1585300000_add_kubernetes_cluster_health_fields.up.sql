@@ -0,0 +1,5 @@
+ALTER TABLE kubernetes_clusters ADD COLUMN `version` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL;
+ALTER TABLE kubernetes_clusters ADD COLUMN `reachable` tinyint(1) DEFAULT NULL;
+ALTER TABLE kubernetes_clusters ADD COLUMN `health_checked_at` timestamp NULL DEFAULT NULL;
+ALTER TABLE kubernetes_clusters ADD COLUMN `credential_expires_at` timestamp NULL DEFAULT NULL;
+ALTER TABLE kubernetes_clusters ADD COLUMN `node_pools` text COLLATE utf8mb4_unicode_ci;
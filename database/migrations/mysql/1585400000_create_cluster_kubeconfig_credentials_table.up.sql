@@ -0,0 +1,16 @@
+CREATE TABLE `cluster_kubeconfig_credentials` (
+  `id` varchar(36) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `organization_id` int(10) unsigned DEFAULT NULL,
+  `cluster_id` int(10) unsigned DEFAULT NULL,
+  `user_id` int(10) unsigned DEFAULT NULL,
+  `user_login` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `role` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `namespace` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `cluster_role` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT NULL,
+  `expires_at` timestamp NULL DEFAULT NULL,
+  `revoked_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_kubeconfig_credentials_cluster_id` (`cluster_id`),
+  KEY `idx_kubeconfig_credentials_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,38 @@
+DROP TABLE IF EXISTS `spot_instances`;
+CREATE TABLE `spot_instances` (
+  `instance_id` varchar(64) COLLATE utf8mb4_unicode_ci NOT NULL,
+  `organization_id` int(10) unsigned DEFAULT NULL,
+  `cluster_id` int(10) unsigned DEFAULT NULL,
+  `node_pool` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `cloud` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `distribution` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `location` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `zone` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `instance_type` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `launched_at` timestamp NULL DEFAULT NULL,
+  `terminated_at` timestamp NULL DEFAULT NULL,
+  `interrupted` tinyint(1) DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT NULL,
+  `updated_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`instance_id`),
+  KEY `idx_spot_instances_organization_id_cluster_id` (`organization_id`,`cluster_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+DROP TABLE IF EXISTS `spot_events`;
+CREATE TABLE `spot_events` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `organization_id` int(10) unsigned DEFAULT NULL,
+  `cluster_id` int(10) unsigned DEFAULT NULL,
+  `node_pool` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `instance_id` varchar(64) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `instance_type` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `zone` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `type` varchar(32) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `source` varchar(32) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `reason` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `occurred_at` timestamp NULL DEFAULT NULL,
+  `created_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_spot_events_instance_id_type_source` (`instance_id`,`type`,`source`),
+  KEY `idx_spot_events_organization_id_occurred_at` (`organization_id`,`occurred_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,15 @@
+CREATE TABLE `bucket_usage_snapshots` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `organization_id` int(10) unsigned DEFAULT NULL,
+  `cloud` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `name` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `location` varchar(255) COLLATE utf8mb4_unicode_ci DEFAULT NULL,
+  `object_count` bigint(20) DEFAULT NULL,
+  `total_size` bigint(20) DEFAULT NULL,
+  `prefix_depth` int(11) DEFAULT NULL,
+  `prefixes` text COLLATE utf8mb4_unicode_ci,
+  `error` text COLLATE utf8mb4_unicode_ci,
+  `collected_at` timestamp NULL DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  KEY `idx_bucket_usage_snapshots_organization_id` (`organization_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+ALTER TABLE "amazon_node_pools" DROP COLUMN "taints";
+ALTER TABLE "azure_aks_node_pools" DROP COLUMN "taints";
+ALTER TABLE "google_gke_node_pools" DROP COLUMN "taints";
+ALTER TABLE "topology_nodepools" DROP COLUMN "taints";
@@ -0,0 +1,4 @@
+ALTER TABLE "amazon_node_pools" ADD COLUMN "taints" text;
+ALTER TABLE "azure_aks_node_pools" ADD COLUMN "taints" text;
+ALTER TABLE "google_gke_node_pools" ADD COLUMN "taints" text;
+ALTER TABLE "topology_nodepools" ADD COLUMN "taints" text;
@@ -0,0 +1,6 @@
+ALTER TABLE "clustergroup_deployments" ADD COLUMN "rollout_strategy" text;
+ALTER TABLE "clustergroup_deployments" ADD COLUMN "rollout_batch_size" integer;
+ALTER TABLE "clustergroup_deployments" ADD COLUMN "rollout_health_check_timeout" integer;
+ALTER TABLE "clustergroup_deployments" ADD COLUMN "rollout_status" text;
+ALTER TABLE "clustergroup_deployments" ADD COLUMN "rollout_message" text;
+ALTER TABLE "clustergroup_deployments" ADD COLUMN "rollout_workflow_id" text;
@@ -0,0 +1,3 @@
+ALTER TABLE "clusters" ADD COLUMN "labels" text;
+ALTER TABLE "clustergroups" ADD COLUMN "selector" text;
+ALTER TABLE "clustergroup_members" ADD COLUMN "dynamic" boolean DEFAULT false;
@@ -0,0 +1,10 @@
+CREATE TABLE "auth_token_scopes"
+(
+    "id"       serial,
+    "user_id"  text,
+    "token_id" text,
+    "scopes"   text,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_auth_token_scopes_user_id_token_id ON "auth_token_scopes" (user_id, token_id);
@@ -0,0 +1,32 @@
+CREATE TABLE "webhook_subscriptions"
+(
+    "id"              serial,
+    "created_at"      timestamp with time zone,
+    "updated_at"      timestamp with time zone,
+    "organization_id" integer,
+    "name"            text,
+    "url"             text,
+    "event_types"     text,
+    "secret"          text,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_webhook_subscriptions_organization_id ON "webhook_subscriptions" (organization_id);
+
+CREATE TABLE "webhook_deliveries"
+(
+    "id"              serial,
+    "created_at"      timestamp with time zone,
+    "subscription_id" integer,
+    "event_id"        text,
+    "event_type"      text,
+    "payload"         text,
+    "attempts"        integer,
+    "status_code"     integer,
+    "error"           text,
+    "succeeded"       boolean,
+    "completed_at"    timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_webhook_deliveries_subscription_id ON "webhook_deliveries" (subscription_id);
@@ -0,0 +1,37 @@
+CREATE TABLE "vulnerability_reports"
+(
+    "id"              serial,
+    "created_at"      timestamp with time zone,
+    "organization_id" integer,
+    "cluster_id"      integer,
+    "pending_images"  integer,
+    "critical"        integer,
+    "high"            integer,
+    "medium"          integer,
+    "low"             integer,
+    "negligible"      integer,
+    "unknown"         integer,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_vulnerability_reports_org_cluster ON "vulnerability_reports" (organization_id, cluster_id);
+
+CREATE TABLE "vulnerability_report_images"
+(
+    "id"           serial,
+    "report_id"    integer,
+    "namespace"    text,
+    "release"      text,
+    "image_name"   text,
+    "image_tag"    text,
+    "image_digest" text,
+    "critical"     integer,
+    "high"         integer,
+    "medium"       integer,
+    "low"          integer,
+    "negligible"   integer,
+    "unknown"      integer,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_vulnerability_report_images_report_id ON "vulnerability_report_images" (report_id);
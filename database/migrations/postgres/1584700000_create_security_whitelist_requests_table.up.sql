@@ -0,0 +1,15 @@
+CREATE TABLE "security_whitelist_requests"
+(
+    "id"           serial,
+    "created_at"   timestamp with time zone,
+    "cluster_id"   integer,
+    "name"         text,
+    "owner"        text,
+    "reason"       text,
+    "regexp"       text,
+    "expires_at"   timestamp with time zone,
+    "requested_by" integer,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_whitelist_requests_cluster_name ON "security_whitelist_requests" (cluster_id, name);
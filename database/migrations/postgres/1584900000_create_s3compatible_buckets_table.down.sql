@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "s3compatible_buckets";
@@ -0,0 +1,17 @@
+CREATE TABLE "s3compatible_buckets"
+(
+    "id"              serial,
+    "organization_id" integer NOT NULL,
+    "name"            text,
+    "endpoint"        text,
+    "region"          text,
+    "secret_ref"      text,
+    "status"          text,
+    "status_msg"      text,
+    "settings"        json,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_s3compatible_buckets_organization_id ON "s3compatible_buckets" (organization_id);
+
+CREATE UNIQUE INDEX idx_s3compatible_bucket_name ON "s3compatible_buckets" (name, endpoint);
@@ -0,0 +1,10 @@
+CREATE TABLE "network_policy_defaults"
+(
+    "organization_id"     integer NOT NULL,
+    "created_at"          timestamp with time zone,
+    "updated_at"          timestamp with time zone,
+    "deny_all"            boolean,
+    "allow_dns"           boolean,
+    "excluded_namespaces" text,
+    PRIMARY KEY ("organization_id")
+);
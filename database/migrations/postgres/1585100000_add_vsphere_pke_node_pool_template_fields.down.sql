@@ -0,0 +1,2 @@
+ALTER TABLE "vsphere_pke_node_pools" DROP COLUMN "template_name";
+ALTER TABLE "vsphere_pke_node_pools" DROP COLUMN "admin_username";
@@ -0,0 +1,2 @@
+ALTER TABLE "vsphere_pke_node_pools" ADD COLUMN "admin_username" text;
+ALTER TABLE "vsphere_pke_node_pools" ADD COLUMN "template_name" text;
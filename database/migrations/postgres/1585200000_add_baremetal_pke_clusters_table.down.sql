@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "baremetal_pke_clusters";
+DROP TABLE IF EXISTS "baremetal_pke_node_pools";
@@ -0,0 +1,20 @@
+CREATE TABLE "baremetal_pke_clusters" (
+  "id" serial,
+  "cluster_id" integer,
+  "spec" json,
+  PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_baremetal_pke_cluster_id ON "baremetal_pke_clusters"(cluster_id);
+
+CREATE TABLE "baremetal_pke_node_pools" (
+  "id" serial,
+  "cluster_id" integer,
+  "created_by" integer,
+  "name" text,
+  "roles" json,
+  "hosts" json,
+  PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_baremetal_pke_np_cluster_id_name ON "baremetal_pke_node_pools"(cluster_id, "name");
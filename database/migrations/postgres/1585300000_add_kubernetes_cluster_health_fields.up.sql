@@ -0,0 +1,5 @@
+ALTER TABLE "kubernetes_clusters" ADD COLUMN "version" text;
+ALTER TABLE "kubernetes_clusters" ADD COLUMN "reachable" boolean;
+ALTER TABLE "kubernetes_clusters" ADD COLUMN "health_checked_at" timestamp with time zone;
+ALTER TABLE "kubernetes_clusters" ADD COLUMN "credential_expires_at" timestamp with time zone;
+ALTER TABLE "kubernetes_clusters" ADD COLUMN "node_pools" text;
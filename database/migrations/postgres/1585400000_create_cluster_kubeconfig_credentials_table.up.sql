@@ -0,0 +1,18 @@
+CREATE TABLE "cluster_kubeconfig_credentials"
+(
+    "id"              varchar(36),
+    "organization_id" integer,
+    "cluster_id"      integer,
+    "user_id"         integer,
+    "user_login"      text,
+    "role"            text,
+    "namespace"       text,
+    "cluster_role"    text,
+    "created_at"      timestamp with time zone,
+    "expires_at"      timestamp with time zone,
+    "revoked_at"      timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_kubeconfig_credentials_cluster_id ON "cluster_kubeconfig_credentials" (cluster_id);
+CREATE INDEX idx_kubeconfig_credentials_expires_at ON "cluster_kubeconfig_credentials" (expires_at);
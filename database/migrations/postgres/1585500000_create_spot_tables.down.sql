@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "spot_events";
+DROP TABLE IF EXISTS "spot_instances";
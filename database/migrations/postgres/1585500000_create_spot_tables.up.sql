@@ -0,0 +1,42 @@
+DROP TABLE IF EXISTS "spot_instances";
+CREATE TABLE "spot_instances"
+(
+    "instance_id"     varchar(64),
+    "organization_id" integer,
+    "cluster_id"      integer,
+    "node_pool"       text,
+    "cloud"           text,
+    "distribution"    text,
+    "location"        text,
+    "zone"            text,
+    "instance_type"   text,
+    "launched_at"     timestamp with time zone,
+    "terminated_at"   timestamp with time zone,
+    "interrupted"     boolean,
+    "created_at"      timestamp with time zone,
+    "updated_at"      timestamp with time zone,
+    PRIMARY KEY ("instance_id")
+);
+
+CREATE INDEX idx_spot_instances_organization_id_cluster_id ON "spot_instances" (organization_id, cluster_id);
+
+DROP TABLE IF EXISTS "spot_events";
+CREATE TABLE "spot_events"
+(
+    "id"              serial,
+    "organization_id" integer,
+    "cluster_id"      integer,
+    "node_pool"       text,
+    "instance_id"     varchar(64),
+    "instance_type"   text,
+    "zone"            text,
+    "type"            varchar(32),
+    "source"          varchar(32),
+    "reason"          text,
+    "occurred_at"     timestamp with time zone,
+    "created_at"      timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_spot_events_instance_id_type_source ON "spot_events" (instance_id, type, source);
+CREATE INDEX idx_spot_events_organization_id_occurred_at ON "spot_events" (organization_id, occurred_at);
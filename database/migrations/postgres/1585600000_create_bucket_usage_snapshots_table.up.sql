@@ -0,0 +1,17 @@
+CREATE TABLE "bucket_usage_snapshots"
+(
+    "id"              serial,
+    "organization_id" integer,
+    "cloud"           text,
+    "name"            text,
+    "location"        text,
+    "object_count"    bigint,
+    "total_size"      bigint,
+    "prefix_depth"    integer,
+    "prefixes"        text,
+    "error"           text,
+    "collected_at"    timestamp with time zone,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX idx_bucket_usage_snapshots_organization_id ON "bucket_usage_snapshots" (organization_id);
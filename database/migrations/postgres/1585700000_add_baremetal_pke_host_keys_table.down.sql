@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "baremetal_pke_host_keys";
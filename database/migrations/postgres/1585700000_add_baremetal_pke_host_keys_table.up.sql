@@ -0,0 +1,9 @@
+CREATE TABLE "baremetal_pke_host_keys" (
+  "id" serial,
+  "cluster_id" integer,
+  "address" text,
+  "host_key" text,
+  PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX idx_baremetal_pke_hk_cluster_id_address ON "baremetal_pke_host_keys"(cluster_id, "address");
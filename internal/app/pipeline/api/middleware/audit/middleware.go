@@ -21,6 +21,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/textproto"
+	"regexp"
 	"strings"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/jinzhu/gorm"
 	"github.com/sirupsen/logrus"
 
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeproxy"
 	"github.com/banzaicloud/pipeline/internal/platform/gin/correlationid"
 	"github.com/banzaicloud/pipeline/pkg/common"
 	"github.com/banzaicloud/pipeline/src/auth"
@@ -35,6 +37,11 @@ import (
 	"github.com/banzaicloud/pipeline/src/spotguide"
 )
 
+// clusterProxyPathRegexp matches Kubernetes API proxy requests.
+// Their bodies are not recorded (they may be non-JSON and may contain Kubernetes secrets),
+// the Kubernetes attributes of the request are recorded instead.
+var clusterProxyPathRegexp = regexp.MustCompile(`/orgs/\d+/clusters/[^/]+/proxy(?:/|$)`)
+
 // LogWriter instance is a Gin Middleware which logs all request data into MySQL audit_events table.
 func LogWriter(
 	skipPaths []string,
@@ -58,21 +65,26 @@ func LogWriter(
 			return
 		}
 
+		isProxyRequest := clusterProxyPathRegexp.MatchString(path)
+
 		// Copy request body into a new buffer, so other handlers can use it safely
 		bodyBuffer := bytes.NewBuffer(nil)
 
-		if _, err := io.Copy(bodyBuffer, c.Request.Body); err != nil {
-			_ = c.AbortWithError(http.StatusInternalServerError, err)
-			logger.Errorf("audit: failed to copy body: %v", err)
+		if !isProxyRequest {
+			if _, err := io.Copy(bodyBuffer, c.Request.Body); err != nil {
+				_ = c.AbortWithError(http.StatusInternalServerError, err)
+				logger.Errorf("audit: failed to copy body: %v", err)
 
-			return
-		}
+				return
+			}
 
-		// We can close the old Body right now, it is fully read
-		_ = c.Request.Body.Close()
+			// We can close the old Body right now, it is fully read
+			_ = c.Request.Body.Close()
+
+			c.Request.Body = ioutil.NopCloser(bodyBuffer)
+		}
 
 		rawBody := bodyBuffer.Bytes()
-		c.Request.Body = ioutil.NopCloser(bodyBuffer)
 
 		// Filter out sensitive data from body
 		var body *string
@@ -184,11 +196,27 @@ func LogWriter(
 			userID = user.ID
 		}
 
+		// the organization is resolved by a later middleware in the chain
+		var organizationID uint
+		if organization := auth.GetCurrentOrganization(c.Request); organization != nil {
+			organizationID = organization.ID
+		}
+
 		responseEvent := AuditEvent{
-			UserID:       userID,
-			StatusCode:   c.Writer.Status(),
-			ResponseSize: c.Writer.Size(),
-			ResponseTime: int(time.Since(start).Nanoseconds() / 1000 / 1000), // ms
+			UserID:         userID,
+			OrganizationID: organizationID,
+			StatusCode:     c.Writer.Status(),
+			ResponseSize:   c.Writer.Size(),
+			ResponseTime:   int(time.Since(start).Nanoseconds() / 1000 / 1000), // ms
+		}
+
+		if record, ok := c.Get(kubeproxy.AuditRecordKey); ok {
+			if marshalled, err := json.Marshal(record); err != nil {
+				logger.Errorf("audit: failed to marshal proxy request: %v", err)
+			} else {
+				body := string(marshalled)
+				responseEvent.Body = &body
+			}
 		}
 
 		if c.IsAborted() {
@@ -20,14 +20,17 @@ import (
 	"time"
 
 	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/auth"
 )
 
 // Token represents an access token.
 type Token struct {
-	ID        string     `json:"id"`
-	Name      string     `json:"name"`
-	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
-	CreatedAt time.Time  `json:"createdAt,omitempty"`
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	ExpiresAt *time.Time        `json:"expiresAt,omitempty"`
+	CreatedAt time.Time         `json:"createdAt,omitempty"`
+	Scopes    *auth.TokenScopes `json:"scopes,omitempty"`
 }
 
 // +kit:endpoint:errorStrategy=service
@@ -50,9 +53,10 @@ type Service interface {
 
 // NewTokenRequest contains necessary information for generating a new token.
 type NewTokenRequest struct {
-	Name        string     `json:"name,omitempty"`
-	VirtualUser string     `json:"virtualUser,omitempty"`
-	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	VirtualUser string            `json:"virtualUser,omitempty"`
+	ExpiresAt   *time.Time        `json:"expiresAt,omitempty"`
+	Scopes      *auth.TokenScopes `json:"scopes,omitempty"`
 }
 
 // NewToken contains a generated token.
@@ -98,7 +102,7 @@ type UserExtractor interface {
 // Store persists access tokens in a secret store.
 type Store interface {
 	// Store stores a token in the persistent secret store.
-	Store(ctx context.Context, userID string, tokenID string, name string, expiresAt *time.Time) error
+	Store(ctx context.Context, userID string, tokenID string, name string, expiresAt *time.Time, scopes *auth.TokenScopes) error
 
 	// List lists the tokens in the store.
 	List(ctx context.Context, userID string) ([]Token, error)
@@ -137,12 +141,52 @@ func (NotFoundError) ServiceError() bool {
 	return true
 }
 
+// ValidationError is returned when a request is semantically invalid.
+type ValidationError struct {
+	message    string
+	violations []string
+}
+
+// NewValidationError returns a new ValidationError.
+func NewValidationError(message string, violations []string) ValidationError {
+	return ValidationError{
+		message:    message,
+		violations: violations,
+	}
+}
+
+// Error implements the error interface.
+func (e ValidationError) Error() string {
+	if e.message != "" {
+		return e.message
+	}
+
+	return "invalid request"
+}
+
+// Violations returns details of the failed validation.
+func (e ValidationError) Violations() []string {
+	return e.violations[:]
+}
+
+// Validation tells a client that this error is related to a semantic validation of the request.
+// Can be used to translate the error to status codes for example.
+func (ValidationError) Validation() bool {
+	return true
+}
+
+// ServiceError tells the consumer whether this error is caused by invalid input supplied by the client.
+// Client errors are usually returned to the consumer without retrying the operation.
+func (ValidationError) ServiceError() bool {
+	return true
+}
+
 // +testify:mock:testOnly=true
 
 // Generator generates a token.
 type Generator interface {
-	// GenerateToken generates a token.
-	GenerateToken(sub string, expiresAt int64, tokenType string, value string) (string, string, error)
+	// GenerateScopedToken generates a token with a custom scope claim.
+	GenerateScopedToken(sub string, expiresAt int64, tokenType string, value string, scope string) (string, string, error)
 }
 
 const (
@@ -168,6 +212,19 @@ func (s service) CreateToken(ctx context.Context, tokenRequest NewTokenRequest)
 		tokenRequest.Name = "generated"
 	}
 
+	if tokenRequest.Scopes != nil {
+		if tokenRequest.Scopes.IsEmpty() {
+			tokenRequest.Scopes = nil
+		} else if err := tokenRequest.Scopes.Validate(); err != nil {
+			var violations []string
+			for _, e := range errors.GetErrors(err) {
+				violations = append(violations, e.Error())
+			}
+
+			return NewToken{}, NewValidationError("invalid token scopes", violations)
+		}
+	}
+
 	sub := fmt.Sprint(userID)
 	tokenType := CICDUserTokenType
 
@@ -182,12 +239,12 @@ func (s service) CreateToken(ctx context.Context, tokenRequest NewTokenRequest)
 		expiresAt = tokenRequest.ExpiresAt.Unix()
 	}
 
-	tokenID, signedToken, err := s.generator.GenerateToken(sub, expiresAt, tokenType, userLogin)
+	tokenID, signedToken, err := s.generator.GenerateScopedToken(sub, expiresAt, tokenType, userLogin, auth.FormatTokenScopes(tokenRequest.Scopes))
 	if err != nil {
 		return NewToken{}, err
 	}
 
-	err = s.store.Store(ctx, sub, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt)
+	err = s.store.Store(ctx, sub, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt, tokenRequest.Scopes)
 	if err != nil {
 		return NewToken{}, err
 	}
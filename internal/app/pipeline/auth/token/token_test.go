@@ -23,6 +23,8 @@ import (
 	"emperror.dev/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/pkg/auth"
 )
 
 func TestService_CreateToken(t *testing.T) {
@@ -48,10 +50,10 @@ func TestService_CreateToken(t *testing.T) {
 	}
 
 	store := new(MockStore)
-	store.On("Store", ctx, userIDString, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt).Return(nil)
+	store.On("Store", ctx, userIDString, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt, tokenRequest.Scopes).Return(nil)
 
 	generator := new(MockGenerator)
-	generator.On("GenerateToken", userIDString, int64(0), CICDUserTokenType, userLogin).Return(tokenID, tokenValue, nil)
+	generator.On("GenerateScopedToken", userIDString, int64(0), CICDUserTokenType, userLogin, auth.DefaultTokenScope).Return(tokenID, tokenValue, nil)
 
 	service := NewService(userExtractor, store, generator)
 
@@ -88,10 +90,10 @@ func TestService_CreateToken_DefaultName(t *testing.T) {
 	}
 
 	store := new(MockStore)
-	store.On("Store", ctx, userIDString, tokenID, "generated", tokenRequest.ExpiresAt).Return(nil)
+	store.On("Store", ctx, userIDString, tokenID, "generated", tokenRequest.ExpiresAt, tokenRequest.Scopes).Return(nil)
 
 	generator := new(MockGenerator)
-	generator.On("GenerateToken", userIDString, int64(0), CICDUserTokenType, userLogin).Return(tokenID, tokenValue, nil)
+	generator.On("GenerateScopedToken", userIDString, int64(0), CICDUserTokenType, userLogin, auth.DefaultTokenScope).Return(tokenID, tokenValue, nil)
 
 	service := NewService(userExtractor, store, generator)
 
@@ -128,10 +130,61 @@ func TestService_VirtualUser(t *testing.T) {
 	}
 
 	store := new(MockStore)
-	store.On("Store", ctx, userID, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt).Return(nil)
+	store.On("Store", ctx, userID, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt, tokenRequest.Scopes).Return(nil)
+
+	generator := new(MockGenerator)
+	generator.On("GenerateScopedToken", "virtualUser", int64(0), CICDHookTokenType, "virtualUser", auth.DefaultTokenScope).Return(tokenID, tokenValue, nil)
+
+	service := NewService(userExtractor, store, generator)
+
+	newToken, err := service.CreateToken(ctx, tokenRequest)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedToken, newToken)
+
+	userExtractor.AssertExpectations(t)
+	store.AssertExpectations(t)
+	generator.AssertExpectations(t)
+}
+
+func TestService_CreateToken_Scoped(t *testing.T) {
+	ctx := context.Background()
+	userID := uint(1)
+	userIDString := fmt.Sprint(userID)
+	userLogin := "john.doe"
+	tokenID := "id"
+	tokenValue := "token"
+
+	tokenRequest := NewTokenRequest{
+		Name: "tokenName",
+		Scopes: &auth.TokenScopes{
+			Organization: 1,
+			Cluster:      "brn:1:cluster:2",
+			Actions:      []string{"deployments:write"},
+		},
+	}
+
+	userExtractor := new(MockUserExtractor)
+	userExtractor.On("GetUserID", ctx).Return(userID, true)
+	userExtractor.On("GetUserLogin", ctx).Return(userLogin, true)
+
+	expectedToken := NewToken{
+		ID:    tokenID,
+		Token: tokenValue,
+	}
+
+	store := new(MockStore)
+	store.On("Store", ctx, userIDString, tokenID, tokenRequest.Name, tokenRequest.ExpiresAt, tokenRequest.Scopes).Return(nil)
 
 	generator := new(MockGenerator)
-	generator.On("GenerateToken", "virtualUser", int64(0), CICDHookTokenType, "virtualUser").Return(tokenID, tokenValue, nil)
+	generator.On(
+		"GenerateScopedToken",
+		userIDString,
+		int64(0),
+		CICDUserTokenType,
+		userLogin,
+		"api:invoke org:1 cluster:brn:1:cluster:2 action:deployments:write",
+	).Return(tokenID, tokenValue, nil)
 
 	service := NewService(userExtractor, store, generator)
 
@@ -145,6 +198,38 @@ func TestService_VirtualUser(t *testing.T) {
 	generator.AssertExpectations(t)
 }
 
+func TestService_CreateToken_InvalidScopes(t *testing.T) {
+	ctx := context.Background()
+
+	tokenRequest := NewTokenRequest{
+		Name: "tokenName",
+		Scopes: &auth.TokenScopes{
+			Organization: 1,
+			Cluster:      "brn:2:cluster:2",
+			Actions:      []string{"deployments"},
+		},
+	}
+
+	userExtractor := new(MockUserExtractor)
+	userExtractor.On("GetUserID", ctx).Return(uint(1), true)
+	userExtractor.On("GetUserLogin", ctx).Return("john.doe", true)
+
+	store := new(MockStore)
+	generator := new(MockGenerator)
+
+	service := NewService(userExtractor, store, generator)
+
+	_, err := service.CreateToken(ctx, tokenRequest)
+	require.Error(t, err)
+
+	var validationErr ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Len(t, validationErr.Violations(), 2)
+
+	store.AssertExpectations(t)
+	generator.AssertExpectations(t)
+}
+
 func TestService_ListTokens(t *testing.T) {
 	ctx := context.Background()
 	userID := uint(1)
@@ -16,28 +16,69 @@ package tokenadapter
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"emperror.dev/errors"
 	"github.com/banzaicloud/bank-vaults/pkg/sdk/auth"
+	"github.com/jinzhu/gorm"
 
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/token"
+	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
 )
 
 // BankVaultsStore stores user tokens in a Bank-Vaults store.
+// Token scopes do not fit into the Bank-Vaults token format, so they are stored in the database.
 type BankVaultsStore struct {
 	store auth.TokenStore
+	db    *gorm.DB
 }
 
 // NewBankVaultsStore returns a new BankVaultsStore.
-func NewBankVaultsStore(store auth.TokenStore) BankVaultsStore {
+func NewBankVaultsStore(store auth.TokenStore, db *gorm.DB) BankVaultsStore {
 	return BankVaultsStore{
 		store: store,
+		db:    db,
 	}
 }
 
+// tokenScopesModel is the database model for storing the scopes of an access token.
+type tokenScopesModel struct {
+	ID      uint   `gorm:"primary_key"`
+	UserID  string `gorm:"unique_index:idx_auth_token_scopes_user_id_token_id"`
+	TokenID string `gorm:"unique_index:idx_auth_token_scopes_user_id_token_id"`
+	Scopes  string `sql:"type:text"`
+}
+
+// TableName changes the default table name.
+func (tokenScopesModel) TableName() string {
+	return "auth_token_scopes"
+}
+
 // Store stores a token in the persistent secret store.
-func (s BankVaultsStore) Store(ctx context.Context, userID string, tokenID string, name string, expiresAt *time.Time) error {
+func (s BankVaultsStore) Store(ctx context.Context, userID string, tokenID string, name string, expiresAt *time.Time, scopes *pkgAuth.TokenScopes) error {
+	if scopes != nil {
+		rawScopes, err := json.Marshal(scopes)
+		if err != nil {
+			return errors.WrapIf(err, "failed to marshal token scopes")
+		}
+
+		model := tokenScopesModel{
+			UserID:  userID,
+			TokenID: tokenID,
+			Scopes:  string(rawScopes),
+		}
+
+		err = s.db.Create(&model).Error
+		if err != nil {
+			return errors.WrapIfWithDetails(
+				err, "failed to save user access token scopes",
+				"userId", userID,
+				"tokenId", tokenID,
+			)
+		}
+	}
+
 	t := auth.NewToken(tokenID, name)
 	t.ExpiresAt = expiresAt
 
@@ -61,9 +102,27 @@ func (s BankVaultsStore) List(ctx context.Context, userID string) ([]token.Token
 		return nil, errors.WrapIfWithDetails(err, "failed to list user tokens", "userId", userID)
 	}
 
+	var models []tokenScopesModel
+
+	err = s.db.Where(tokenScopesModel{UserID: userID}).Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list user token scopes", "userId", userID)
+	}
+
+	scopes := make(map[string]*pkgAuth.TokenScopes, len(models))
+	for _, model := range models {
+		scopes[model.TokenID], err = s.mapScopes(model)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	tokens := make([]token.Token, 0, len(ts))
 	for _, t := range ts {
-		tokens = append(tokens, s.mapToken(t))
+		tt := s.mapToken(t)
+		tt.Scopes = scopes[t.ID]
+
+		tokens = append(tokens, tt)
 	}
 
 	return tokens, nil
@@ -84,7 +143,36 @@ func (s BankVaultsStore) Lookup(_ context.Context, userID string, tokenID string
 		return token.Token{}, errors.WithStack(token.NotFoundError{ID: tokenID})
 	}
 
-	return s.mapToken(t), nil
+	tt := s.mapToken(t)
+
+	var model tokenScopesModel
+
+	err = s.db.Where(tokenScopesModel{UserID: userID, TokenID: tokenID}).First(&model).Error
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return token.Token{}, errors.WrapIfWithDetails(
+			err, "failed to lookup user token scopes",
+			"userId", userID,
+			"tokenId", tokenID,
+		)
+	} else if err == nil {
+		tt.Scopes, err = s.mapScopes(model)
+		if err != nil {
+			return token.Token{}, err
+		}
+	}
+
+	return tt, nil
+}
+
+func (s BankVaultsStore) mapScopes(model tokenScopesModel) (*pkgAuth.TokenScopes, error) {
+	var scopes pkgAuth.TokenScopes
+
+	err := json.Unmarshal([]byte(model.Scopes), &scopes)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to unmarshal token scopes", "tokenId", model.TokenID)
+	}
+
+	return &scopes, nil
 }
 
 func (s BankVaultsStore) mapToken(t *auth.Token) token.Token {
@@ -112,5 +200,14 @@ func (s BankVaultsStore) Revoke(_ context.Context, userID string, tokenID string
 		)
 	}
 
+	err = s.db.Where(tokenScopesModel{UserID: userID, TokenID: tokenID}).Delete(tokenScopesModel{}).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(
+			err, "failed to delete user token scopes",
+			"userId", userID,
+			"tokenId", tokenID,
+		)
+	}
+
 	return nil
 }
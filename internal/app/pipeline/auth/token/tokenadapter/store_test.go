@@ -21,22 +21,36 @@ import (
 	"time"
 
 	"github.com/banzaicloud/bank-vaults/pkg/sdk/auth"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/banzaicloud/pipeline/internal/app/pipeline/auth/token"
+	"github.com/banzaicloud/pipeline/internal/common"
+	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
 )
 
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, common.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
 func TestBankVaultsStore_Store(t *testing.T) {
 	bstore := auth.NewInMemoryTokenStore()
-	store := NewBankVaultsStore(bstore)
+	store := NewBankVaultsStore(bstore, setUpDatabase(t))
 
 	userID := "1"
 	tokenID := "token"
 	tokenName := "name"
 	expiresAt := time.Date(2019, time.September, 30, 15, 15, 00, 00, time.UTC)
 
-	err := store.Store(context.Background(), userID, tokenID, tokenName, &expiresAt)
+	err := store.Store(context.Background(), userID, tokenID, tokenName, &expiresAt, nil)
 	require.NoError(t, err)
 
 	bt, err := bstore.Lookup(fmt.Sprint(userID), tokenID)
@@ -53,9 +67,52 @@ func TestBankVaultsStore_Store(t *testing.T) {
 	assert.Equal(t, expectedToken, bt)
 }
 
+func TestBankVaultsStore_Store_Scopes(t *testing.T) {
+	bstore := auth.NewInMemoryTokenStore()
+	store := NewBankVaultsStore(bstore, setUpDatabase(t))
+
+	userID := "1"
+	tokenID := "token"
+	tokenName := "name"
+	scopes := &pkgAuth.TokenScopes{
+		Organization: 1,
+		Cluster:      "brn:1:cluster:2",
+		Actions:      []string{"deployments:write", "secrets:read"},
+	}
+
+	err := store.Store(context.Background(), userID, tokenID, tokenName, nil, scopes)
+	require.NoError(t, err)
+
+	tokens, err := store.List(context.Background(), userID)
+	require.NoError(t, err)
+
+	expectedTokens := []token.Token{
+		{
+			ID:     tokenID,
+			Name:   tokenName,
+			Scopes: scopes,
+		},
+	}
+
+	assert.Equal(t, expectedTokens, tokens)
+
+	tt, err := store.Lookup(context.Background(), userID, tokenID)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedTokens[0], tt)
+
+	err = store.Revoke(context.Background(), userID, tokenID)
+	require.NoError(t, err)
+
+	tokens, err = store.List(context.Background(), userID)
+	require.NoError(t, err)
+
+	assert.Empty(t, tokens)
+}
+
 func TestBankVaultsStore_List(t *testing.T) {
 	bstore := auth.NewInMemoryTokenStore()
-	store := NewBankVaultsStore(bstore)
+	store := NewBankVaultsStore(bstore, setUpDatabase(t))
 
 	userID := "1"
 	tokenID := "token"
@@ -87,7 +144,7 @@ func TestBankVaultsStore_List(t *testing.T) {
 
 func TestBankVaultsStore_Lookup(t *testing.T) {
 	bstore := auth.NewInMemoryTokenStore()
-	store := NewBankVaultsStore(bstore)
+	store := NewBankVaultsStore(bstore, setUpDatabase(t))
 
 	userID := "1"
 	tokenID := "token"
@@ -117,7 +174,7 @@ func TestBankVaultsStore_Lookup(t *testing.T) {
 
 func TestBankVaultsStore_Revoke(t *testing.T) {
 	bstore := auth.NewInMemoryTokenStore()
-	store := NewBankVaultsStore(bstore)
+	store := NewBankVaultsStore(bstore, setUpDatabase(t))
 
 	userID := "1"
 	tokenID := "token"
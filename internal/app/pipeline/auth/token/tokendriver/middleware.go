@@ -62,7 +62,19 @@ func (e sentinel) ServiceError() bool {
 // CannotCreateVirtualUser is returned when a user does not have the right to create a virtual user token.
 const CannotCreateVirtualUser = sentinel("cannot create virtual user")
 
+// CannotCreateToken is returned when a user does not have the right to create a token (eg. when using a scoped token).
+const CannotCreateToken = sentinel("cannot create token")
+
 func (m authorizationMiddleware) CreateToken(ctx context.Context, tokenRequest token.NewTokenRequest) (token.NewToken, error) {
+	ok, err := m.authorizer.Authorize(ctx, "token.create", tokenRequest.Scopes)
+	if err != nil {
+		return token.NewToken{}, err
+	}
+
+	if !ok {
+		return token.NewToken{}, CannotCreateToken
+	}
+
 	if tokenRequest.VirtualUser != "" { // authorize creating a virtual user
 		orgName := strings.Split(tokenRequest.VirtualUser, "/")[0]
 
@@ -25,7 +25,7 @@ import (
 // IsProviderSupported checks whether the given provider is supported
 func IsProviderSupported(provider string) error {
 	switch provider {
-	case providers.Amazon, providers.Azure, providers.Google:
+	case providers.Amazon, providers.Azure, providers.Google, providers.S3Compatible:
 		return nil
 	default:
 		return pkgErrors.ErrorNotSupportedCloudType
@@ -15,12 +15,18 @@
 package ark
 
 import (
+	"strconv"
+
 	"github.com/banzaicloud/pipeline/internal/ark/providers/amazon"
 	"github.com/banzaicloud/pipeline/internal/ark/providers/azure"
 	"github.com/banzaicloud/pipeline/internal/ark/providers/google"
+	"github.com/banzaicloud/pipeline/internal/ark/providers/s3compatible"
 	"github.com/banzaicloud/pipeline/internal/global"
+	s3compatibleProvider "github.com/banzaicloud/pipeline/internal/providers/s3compatible"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
 	pkgErrors "github.com/banzaicloud/pipeline/pkg/errors"
 	"github.com/banzaicloud/pipeline/pkg/providers"
+	s3compatibleObjectstore "github.com/banzaicloud/pipeline/pkg/providers/s3compatible/objectstore"
 	"github.com/banzaicloud/pipeline/src/secret"
 )
 
@@ -62,9 +68,9 @@ type secretContents struct {
 }
 
 type configuration struct {
-	PersistentVolumeProvider persistentVolumeProvider `json:"persistentVolumeProvider"`
-	BackupStorageProvider    backupStorageProvider    `json:"backupStorageProvider"`
-	RestoreOnlyMode          bool                     `json:"restoreOnlyMode"`
+	PersistentVolumeProvider *persistentVolumeProvider `json:"persistentVolumeProvider,omitempty"`
+	BackupStorageProvider    backupStorageProvider     `json:"backupStorageProvider"`
+	RestoreOnlyMode          bool                      `json:"restoreOnlyMode"`
 }
 
 type persistentVolumeProvider struct {
@@ -171,8 +177,7 @@ func (req ConfigRequest) Get() (values ValueOverrides, err error) {
 	}, nil
 }
 
-func (req ConfigRequest) getPVPConfig() (persistentVolumeProvider, error) {
-	var config persistentVolumeProvider
+func (req ConfigRequest) getPVPConfig() (*persistentVolumeProvider, error) {
 	var pvc string
 
 	switch req.Cluster.Provider {
@@ -182,11 +187,14 @@ func (req ConfigRequest) getPVPConfig() (persistentVolumeProvider, error) {
 		pvc = azure.PersistentVolumeProvider
 	case providers.Google:
 		pvc = google.PersistentVolumeProvider
+	case pkgCluster.Vsphere:
+		// volume snapshots are not supported on-prem, only the cluster resources are backed up
+		return nil, nil
 	default:
-		return config, pkgErrors.ErrorNotSupportedCloudType
+		return nil, pkgErrors.ErrorNotSupportedCloudType
 	}
 
-	return persistentVolumeProvider{
+	return &persistentVolumeProvider{
 		Name: pvc,
 		Config: persistentVolumeProviderConfig{
 			Region:     req.Cluster.Location,
@@ -206,6 +214,8 @@ func (req ConfigRequest) getBSPConfig() (backupStorageProvider, error) {
 		bsp = azure.BackupStorageProvider
 	case providers.Google:
 		bsp = google.BackupStorageProvider
+	case providers.S3Compatible:
+		bsp = s3compatible.BackupStorageProvider
 	default:
 		return config, pkgErrors.ErrorNotSupportedCloudType
 	}
@@ -221,6 +231,24 @@ func (req ConfigRequest) getBSPConfig() (backupStorageProvider, error) {
 		}
 	}
 
+	if req.Bucket.Provider == providers.S3Compatible {
+		s3Config, _, err := s3compatibleProvider.GetConfig(req.BucketSecret)
+		if err != nil {
+			return config, err
+		}
+
+		region := s3Config.Region
+		if region == "" {
+			region = s3compatibleObjectstore.DefaultRegion
+		}
+
+		config.Config = backupStorageProviderConfig{
+			Region:           region,
+			S3Url:            s3Config.Endpoint,
+			S3ForcePathStyle: strconv.FormatBool(s3Config.ForcePathStyle),
+		}
+	}
+
 	return config, nil
 }
 
@@ -246,6 +274,8 @@ func (req ConfigRequest) getCredentials() (credentials, error) {
 		if err != nil {
 			return config, err
 		}
+	case pkgCluster.Vsphere:
+		// no cloud credentials are needed without volume snapshots
 	default:
 		return config, pkgErrors.ErrorNotSupportedCloudType
 	}
@@ -266,6 +296,11 @@ func (req ConfigRequest) getCredentials() (credentials, error) {
 		if err != nil {
 			return config, err
 		}
+	case providers.S3Compatible:
+		BucketSecretContents, err = s3compatible.GetSecret(req.BucketSecret)
+		if err != nil {
+			return config, err
+		}
 	default:
 		return config, pkgErrors.ErrorNotSupportedCloudType
 	}
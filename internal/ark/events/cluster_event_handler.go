@@ -15,33 +15,54 @@
 package events
 
 import (
+	"context"
+	"fmt"
+
 	"emperror.dev/errors"
 	"github.com/jinzhu/gorm"
 	"github.com/sirupsen/logrus"
 
 	"github.com/banzaicloud/pipeline/internal/ark"
+	"github.com/banzaicloud/pipeline/internal/cluster"
 )
 
-// ClusterEventHandler is for handling cluster events
+// ClusterEventHandler removes stale ARK deployment records when a cluster is deleted
 type ClusterEventHandler struct {
-	events clusterEvents
 	db     *gorm.DB
 	logger logrus.FieldLogger
 }
 
-// NewClusterEventHandler handles arriving cluster events such as 'cluster_deleted'
-func NewClusterEventHandler(events clusterEvents, db *gorm.DB, logger logrus.FieldLogger) *ClusterEventHandler {
-	eh := &ClusterEventHandler{
-		events: events,
+// NewClusterEventHandler returns a new ClusterEventHandler
+func NewClusterEventHandler(db *gorm.DB, logger logrus.FieldLogger) *ClusterEventHandler {
+	return &ClusterEventHandler{
 		db:     db,
 		logger: logger,
 	}
+}
+
+// HandlerName implements the cqrs.EventHandler interface.
+func (eh *ClusterEventHandler) HandlerName() string {
+	return "ark_cluster_deleted"
+}
 
-	eh.events.NotifyClusterDeleted(func(orgID uint, clusterName string) {
-		eh.DeleteStaleARKDeployments(orgID) // nolint: errcheck
-	})
+// NewEvent implements the cqrs.EventHandler interface.
+func (eh *ClusterEventHandler) NewEvent() interface{} {
+	return &cluster.ClusterDeleted{}
+}
 
-	return eh
+// Handle implements the cqrs.EventHandler interface.
+func (eh *ClusterEventHandler) Handle(ctx context.Context, event interface{}) error {
+	e, ok := event.(*cluster.ClusterDeleted)
+	if !ok {
+		return errors.NewWithDetails("unexpected event type", "type", fmt.Sprintf("%T", event))
+	}
+
+	err := eh.DeleteStaleARKDeployments(e.OrganizationID)
+	if err != nil {
+		eh.logger.Error(errors.WrapIf(err, "could not remove stale deployment records"))
+	}
+
+	return nil
 }
 
 // RemoveStaleDeployments deletes stale ARK deployment records from database
@@ -0,0 +1,57 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite" // SQLite driver used for integration test
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/ark"
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/src/model"
+)
+
+func TestClusterEventHandler_Handle(t *testing.T) {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, db.AutoMigrate(&model.ClusterModel{}, &ark.ClusterBackupDeploymentsModel{}).Error)
+
+	require.NoError(t, db.Create(&model.ClusterModel{ID: 1, Name: "existing", OrganizationId: 1}).Error)
+	require.NoError(t, db.Create(&ark.ClusterBackupDeploymentsModel{ID: 1, Name: "existing", OrganizationID: 1, ClusterID: 1}).Error)
+	require.NoError(t, db.Create(&ark.ClusterBackupDeploymentsModel{ID: 2, Name: "stale", OrganizationID: 1, ClusterID: 2}).Error)
+
+	logger, _ := logrustest.NewNullLogger()
+	handler := NewClusterEventHandler(db, logrus.NewEntry(logger))
+
+	assert.IsType(t, &cluster.ClusterDeleted{}, handler.NewEvent())
+
+	err = handler.Handle(context.Background(), &cluster.ClusterDeleted{OrganizationID: 1, ClusterName: "deleted"})
+	require.NoError(t, err)
+
+	var deployments []ark.ClusterBackupDeploymentsModel
+	require.NoError(t, db.Find(&deployments).Error)
+
+	require.Len(t, deployments, 1)
+	assert.Equal(t, "existing", deployments[0].Name)
+}
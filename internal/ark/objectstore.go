@@ -20,6 +20,7 @@ import (
 	"github.com/banzaicloud/pipeline/internal/ark/providers/amazon"
 	"github.com/banzaicloud/pipeline/internal/ark/providers/azure"
 	"github.com/banzaicloud/pipeline/internal/ark/providers/google"
+	"github.com/banzaicloud/pipeline/internal/ark/providers/s3compatible"
 	iProviders "github.com/banzaicloud/pipeline/internal/providers"
 	pkgErrors "github.com/banzaicloud/pipeline/pkg/errors"
 	"github.com/banzaicloud/pipeline/pkg/providers"
@@ -34,6 +35,8 @@ func NewObjectStore(ctx iProviders.ObjectStoreContext) (cloudprovider.ObjectStor
 		return amazon.NewObjectStore(ctx)
 	case providers.Azure:
 		return azure.NewObjectStore(ctx)
+	case providers.S3Compatible:
+		return s3compatible.NewObjectStore(ctx)
 	default:
 		return nil, pkgErrors.ErrorNotSupportedCloudType
 	}
@@ -1,4 +1,4 @@
-// Copyright © 2019 Banzai Cloud
+// Copyright © 2020 Banzai Cloud
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -12,20 +12,9 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package pke
+package s3compatible
 
-import (
-	"database/sql"
-	"database/sql/driver"
-	"testing"
-
-	"github.com/stretchr/testify/require"
+const (
+	// BackupStorageProvider is a config value for ARK
+	BackupStorageProvider = "aws"
 )
-
-func TestRuntime_ImplementsScanner(t *testing.T) {
-	require.Implements(t, (*sql.Scanner)(nil), new(Runtime))
-}
-
-func TestRuntime_ImplementsValuer(t *testing.T) {
-	require.Implements(t, (*driver.Valuer)(nil), new(Runtime))
-}
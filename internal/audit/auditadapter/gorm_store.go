@@ -0,0 +1,168 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditadapter
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	auditmiddleware "github.com/banzaicloud/pipeline/internal/app/pipeline/api/middleware/audit"
+	"github.com/banzaicloud/pipeline/internal/audit"
+)
+
+// likeEscapeChar is used to escape wildcards in LIKE patterns.
+// Backslash is avoided on purpose, because it needs escaping itself in MySQL string literals.
+const likeEscapeChar = "!"
+
+var likeEscaper = strings.NewReplacer(
+	likeEscapeChar, likeEscapeChar+likeEscapeChar,
+	"%", likeEscapeChar+"%",
+	"_", likeEscapeChar+"_",
+)
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a new audit.Store backed by the audit events table written by the audit middleware.
+func NewGormStore(db *gorm.DB) audit.Store {
+	return gormStore{
+		db: db,
+	}
+}
+
+func (s gormStore) Find(_ context.Context, organizationID uint, filter audit.Filter, beforeID uint, limit int) ([]audit.Event, error) {
+	query := s.db.Where("organization_id = ?", organizationID)
+
+	if beforeID != 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+
+	if !filter.From.IsZero() {
+		query = query.Where("time >= ?", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		query = query.Where("time < ?", filter.To)
+	}
+
+	if filter.Method != "" {
+		query = query.Where("method = ?", filter.Method)
+	}
+
+	if filter.Path != "" {
+		// the recorded path contains the query string as well
+		pattern := likeEscaper.Replace(filter.Path)
+
+		query = query.Where(
+			"(path = ? OR path LIKE ? ESCAPE '"+likeEscapeChar+"' OR path LIKE ? ESCAPE '"+likeEscapeChar+"')",
+			filter.Path,
+			pattern+"/%",
+			pattern+"?%",
+		)
+	}
+
+	if filter.StatusCode != 0 {
+		query = query.Where("status_code = ?", filter.StatusCode)
+	}
+
+	var models []auditmiddleware.AuditEvent
+
+	err := query.Order("id DESC").Limit(limit).Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to find audit events", "organizationId", organizationID)
+	}
+
+	return fromModels(models), nil
+}
+
+func (s gormStore) FindOlderThan(_ context.Context, t time.Time, limit int) ([]audit.Event, error) {
+	var models []auditmiddleware.AuditEvent
+
+	err := s.db.Where("time < ?", t).Order("id ASC").Limit(limit).Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to find audit events", "olderThan", t)
+	}
+
+	return fromModels(models), nil
+}
+
+func (s gormStore) Delete(_ context.Context, ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := s.db.Where("id IN (?)", ids).Delete(&auditmiddleware.AuditEvent{}).Error
+	if err != nil {
+		return errors.WrapIf(err, "failed to delete audit events")
+	}
+
+	return nil
+}
+
+func (s gormStore) DeleteOlderThan(_ context.Context, t time.Time) (int64, error) {
+	result := s.db.Where("time < ?", t).Delete(&auditmiddleware.AuditEvent{})
+	if result.Error != nil {
+		return 0, errors.WrapIfWithDetails(result.Error, "failed to delete audit events", "olderThan", t)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func fromModels(models []auditmiddleware.AuditEvent) []audit.Event {
+	events := make([]audit.Event, 0, len(models))
+
+	for _, model := range models {
+		event := audit.Event{
+			ID:             model.ID,
+			Time:           model.Time,
+			CorrelationID:  model.CorrelationID,
+			OrganizationID: model.OrganizationID,
+			UserID:         model.UserID,
+			ClientIP:       model.ClientIP,
+			UserAgent:      model.UserAgent,
+			Method:         model.Method,
+			Path:           model.Path,
+			StatusCode:     model.StatusCode,
+			ResponseTime:   model.ResponseTime,
+			ResponseSize:   model.ResponseSize,
+			Headers:        rawJSON(&model.Headers),
+			Body:           rawJSON(model.Body),
+			Errors:         rawJSON(model.Errors),
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+// rawJSON returns a JSON document stored in a column (or nil if it's empty).
+func rawJSON(value *string) json.RawMessage {
+	if value == nil || *value == "" || *value == "null" {
+		return nil
+	}
+
+	return json.RawMessage(*value)
+}
@@ -0,0 +1,171 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	auditmiddleware "github.com/banzaicloud/pipeline/internal/app/pipeline/api/middleware/audit"
+	"github.com/banzaicloud/pipeline/internal/audit"
+)
+
+var baseTime = time.Date(2020, 3, 16, 10, 0, 0, 0, time.UTC)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	err = auditmiddleware.Migrate(db, logger)
+	require.NoError(t, err)
+
+	body := `{"name":"cluster"}`
+
+	events := []auditmiddleware.AuditEvent{
+		{OrganizationID: 1, UserID: 1, Method: "GET", Path: "/api/v1/orgs/1/clusters", StatusCode: 200},
+		{OrganizationID: 1, UserID: 1, Method: "POST", Path: "/api/v1/orgs/1/clusters", StatusCode: 201, Body: &body},
+		{OrganizationID: 1, UserID: 2, Method: "GET", Path: "/api/v1/orgs/1/clusters/2", StatusCode: 200},
+		{OrganizationID: 1, UserID: 2, Method: "GET", Path: "/api/v1/orgs/1/clusters/2/nodepools?fields=name", StatusCode: 200},
+		{OrganizationID: 1, UserID: 2, Method: "GET", Path: "/api/v1/orgs/1/clusters/23", StatusCode: 404},
+		{OrganizationID: 2, UserID: 3, Method: "GET", Path: "/api/v1/orgs/2/clusters", StatusCode: 200},
+	}
+
+	for i, event := range events {
+		event.Time = baseTime.Add(time.Duration(i) * time.Hour)
+		event.Headers = "{}"
+
+		err := db.Save(&event).Error
+		require.NoError(t, err)
+	}
+
+	return db
+}
+
+func eventIDs(events []audit.Event) []uint {
+	ids := make([]uint, 0, len(events))
+
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+
+	return ids
+}
+
+func TestGormStore_Find(t *testing.T) {
+	store := NewGormStore(setUpDatabase(t))
+
+	tests := map[string]struct {
+		filter   audit.Filter
+		beforeID uint
+		limit    int
+		expected []uint
+	}{
+		"all": {
+			limit:    10,
+			expected: []uint{5, 4, 3, 2, 1},
+		},
+		"page": {
+			beforeID: 4,
+			limit:    2,
+			expected: []uint{3, 2},
+		},
+		"user": {
+			filter:   audit.Filter{UserID: 1},
+			limit:    10,
+			expected: []uint{2, 1},
+		},
+		"timeRange": {
+			filter:   audit.Filter{From: baseTime.Add(time.Hour), To: baseTime.Add(3 * time.Hour)},
+			limit:    10,
+			expected: []uint{3, 2},
+		},
+		"method": {
+			filter:   audit.Filter{Method: "POST"},
+			limit:    10,
+			expected: []uint{2},
+		},
+		"path": {
+			filter:   audit.Filter{Path: "/api/v1/orgs/1/clusters/2"},
+			limit:    10,
+			expected: []uint{4, 3},
+		},
+		"pathWildcard": {
+			filter:   audit.Filter{Path: "/api/v1/orgs/1/clusters/_"},
+			limit:    10,
+			expected: []uint{},
+		},
+		"statusCode": {
+			filter:   audit.Filter{StatusCode: 404},
+			limit:    10,
+			expected: []uint{5},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			events, err := store.Find(context.Background(), 1, test.filter, test.beforeID, test.limit)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, eventIDs(events))
+		})
+	}
+}
+
+func TestGormStore_Find_RawJSON(t *testing.T) {
+	store := NewGormStore(setUpDatabase(t))
+
+	events, err := store.Find(context.Background(), 1, audit.Filter{Method: "POST"}, 0, 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	assert.JSONEq(t, `{"name":"cluster"}`, string(events[0].Body))
+	assert.JSONEq(t, `{}`, string(events[0].Headers))
+	assert.Nil(t, events[0].Errors)
+}
+
+func TestGormStore_Retention(t *testing.T) {
+	store := NewGormStore(setUpDatabase(t))
+	ctx := context.Background()
+
+	events, err := store.FindOlderThan(ctx, baseTime.Add(2*time.Hour), 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, []uint{1, 2}, eventIDs(events))
+
+	err = store.Delete(ctx, eventIDs(events))
+	require.NoError(t, err)
+
+	deleted, err := store.DeleteOlderThan(ctx, baseTime.Add(4*time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), deleted)
+
+	events, err = store.FindOlderThan(ctx, baseTime.Add(24*time.Hour), 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, []uint{5, 6}, eventIDs(events))
+}
@@ -0,0 +1,118 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditadapter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/ark"
+	"github.com/banzaicloud/pipeline/internal/audit"
+	"github.com/banzaicloud/pipeline/internal/providers"
+	"github.com/banzaicloud/pipeline/src/auth"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+// ArchiveConfig describes the Pipeline managed bucket audit events are archived to.
+type ArchiveConfig struct {
+	// Organization and secret used to access the bucket
+	OrganizationID uint
+	SecretID       string
+
+	Provider string
+	Bucket   string
+	Location string
+
+	// Azure specific parameters
+	ResourceGroup  string
+	StorageAccount string
+
+	// Prefix of the archived objects in the bucket
+	Prefix string
+}
+
+// SecretStore returns secrets of an organization.
+type SecretStore interface {
+	Get(organizationID uint, secretID string) (*secret.SecretItemResponse, error)
+}
+
+// ObjectStore stores objects in a bucket.
+type ObjectStore interface {
+	PutObject(bucket string, key string, body io.Reader) error
+}
+
+// ObjectStoreFactory creates an ObjectStore.
+type ObjectStoreFactory func(ctx providers.ObjectStoreContext) (ObjectStore, error)
+
+type objectStoreArchiver struct {
+	config             ArchiveConfig
+	secrets            SecretStore
+	objectStoreFactory ObjectStoreFactory
+}
+
+// NewObjectStoreArchiver returns a new audit.Archiver that uploads events to a bucket as NDJSON documents.
+func NewObjectStoreArchiver(config ArchiveConfig, secrets SecretStore) audit.Archiver {
+	return objectStoreArchiver{
+		config:  config,
+		secrets: secrets,
+		objectStoreFactory: func(ctx providers.ObjectStoreContext) (ObjectStore, error) {
+			return ark.NewObjectStore(ctx)
+		},
+	}
+}
+
+func (a objectStoreArchiver) Archive(_ context.Context, name string, events []audit.Event) error {
+	// the secret is looked up every time in case it gets rotated
+	s, err := a.secrets.Get(a.config.OrganizationID, a.config.SecretID)
+	if err != nil {
+		return errors.WrapIfWithDetails(
+			err, "failed to get archive bucket secret",
+			"organizationId", a.config.OrganizationID,
+			"secretId", a.config.SecretID,
+		)
+	}
+
+	objectStore, err := a.objectStoreFactory(providers.ObjectStoreContext{
+		Provider:       a.config.Provider,
+		Secret:         s,
+		Organization:   &auth.Organization{ID: a.config.OrganizationID},
+		Location:       a.config.Location,
+		ResourceGroup:  a.config.ResourceGroup,
+		StorageAccount: a.config.StorageAccount,
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to create object store", "provider", a.config.Provider)
+	}
+
+	var buf bytes.Buffer
+
+	err = audit.NewNDJSONEventWriter(&buf).WriteEvents(events)
+	if err != nil {
+		return err
+	}
+
+	key := path.Join(a.config.Prefix, name)
+
+	err = objectStore.PutObject(a.config.Bucket, key, &buf)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to upload audit event archive", "bucket", a.config.Bucket, "key", key)
+	}
+
+	return nil
+}
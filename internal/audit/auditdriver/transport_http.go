@@ -0,0 +1,268 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditdriver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	"github.com/banzaicloud/pipeline/internal/audit"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+)
+
+type contextKey string
+
+const exportWriterContextKey contextKey = "exportWriter"
+
+// RegisterHTTPHandlers mounts all of the service endpoints into an http.Handler.
+func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
+
+	router.Methods(http.MethodGet).Path("/events").Handler(kithttp.NewServer(
+		endpoints.ListEvents,
+		decodeListEventsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListEventsHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	exportErrorEncoder := kitxhttp.NewJSONProblemErrorEncoder(apphttp.NewDefaultProblemConverter())
+
+	exportServer := kithttp.NewServer(
+		endpoints.ExportEvents,
+		decodeExportEventsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeExportEventsHTTPResponse, exportErrorResponseEncoder(errorEncoder)),
+		append(options, kithttp.ServerErrorEncoder(func(ctx context.Context, err error, w http.ResponseWriter) {
+			// Events are streamed to the client: once the response is started, the status can't be changed anymore.
+			if writer, ok := ctx.Value(exportWriterContextKey).(*httpEventWriter); ok && writer.started() {
+				return
+			}
+
+			exportErrorEncoder(ctx, err, w)
+		}))...,
+	)
+
+	router.Methods(http.MethodGet).Path("/events/export").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writer := &httpEventWriter{
+			w:      w,
+			format: exportFormat(r.URL.Query()),
+		}
+
+		exportServer.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), exportWriterContextKey, writer)))
+	})
+}
+
+func decodeListEventsHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractOrgID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.URL.Query()
+
+	filter, violations := decodeFilter(query)
+
+	options := audit.ListOptions{
+		Cursor: query.Get("cursor"),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		options.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("invalid limit: %s", limit))
+		}
+	}
+
+	if len(violations) > 0 {
+		return nil, audit.NewValidationError("invalid query parameters", violations)
+	}
+
+	return ListEventsRequest{OrganizationID: orgID, Filter: filter, Options: options}, nil
+}
+
+func encodeListEventsHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListEventsResponse)
+
+	if resp.Page.Events == nil {
+		resp.Page.Events = []audit.Event{}
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Page)
+}
+
+func decodeExportEventsHTTPRequest(ctx context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractOrgID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.URL.Query()
+
+	filter, violations := decodeFilter(query)
+
+	switch format := exportFormat(query); format {
+	case audit.NDJSONFormat, audit.CSVFormat:
+	default:
+		violations = append(violations, fmt.Sprintf("unsupported export format: %s", format))
+	}
+
+	if len(violations) > 0 {
+		return nil, audit.NewValidationError("invalid query parameters", violations)
+	}
+
+	writer, ok := ctx.Value(exportWriterContextKey).(*httpEventWriter)
+	if !ok {
+		return nil, errors.New("missing export writer")
+	}
+
+	return ExportEventsRequest{OrganizationID: orgID, Filter: filter, Writer: writer}, nil
+}
+
+func encodeExportEventsHTTPResponse(_ context.Context, _ http.ResponseWriter, _ interface{}) error {
+	// events are written to the response by the service
+	return nil
+}
+
+func exportErrorResponseEncoder(errorEncoder kitxhttp.EncodeErrorResponseFunc) kitxhttp.EncodeErrorResponseFunc {
+	return func(ctx context.Context, w http.ResponseWriter, err error) error {
+		// Let the server error handler deal with errors occurring after the response is started.
+		if writer, ok := ctx.Value(exportWriterContextKey).(*httpEventWriter); ok && writer.started() {
+			return err
+		}
+
+		return errorEncoder(ctx, w, err)
+	}
+}
+
+// httpEventWriter streams exported events to an HTTP response.
+// Headers are sent when the first batch is written.
+type httpEventWriter struct {
+	w      http.ResponseWriter
+	format string
+
+	writer audit.EventWriter
+}
+
+func (w *httpEventWriter) started() bool {
+	return w.writer != nil
+}
+
+func (w *httpEventWriter) WriteEvents(events []audit.Event) error {
+	if w.writer == nil {
+		switch w.format {
+		case audit.CSVFormat:
+			w.w.Header().Set("Content-Type", "text/csv")
+			w.w.Header().Set("Content-Disposition", `attachment; filename="audit-events.csv"`)
+
+			w.writer = audit.NewCSVEventWriter(w.w)
+
+		default:
+			w.w.Header().Set("Content-Type", "application/x-ndjson")
+			w.w.Header().Set("Content-Disposition", `attachment; filename="audit-events.ndjson"`)
+
+			w.writer = audit.NewNDJSONEventWriter(w.w)
+		}
+	}
+
+	if err := w.writer.WriteEvents(events); err != nil {
+		return err
+	}
+
+	if flusher, ok := w.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	return nil
+}
+
+func exportFormat(query url.Values) string {
+	if format := query.Get("format"); format != "" {
+		return format
+	}
+
+	return audit.NDJSONFormat
+}
+
+func decodeFilter(query url.Values) (audit.Filter, []string) {
+	var violations []string
+
+	filter := audit.Filter{
+		Method:   query.Get("method"),
+		Path:     query.Get("path"),
+		Resource: query.Get("resource"),
+	}
+
+	if userID := query.Get("userId"); userID != "" {
+		id, err := strconv.ParseUint(userID, 10, 32)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("invalid user ID: %s", userID))
+		}
+
+		filter.UserID = uint(id)
+	}
+
+	if from := query.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("invalid start time (RFC3339 expected): %s", from))
+		}
+
+		filter.From = t
+	}
+
+	if to := query.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("invalid end time (RFC3339 expected): %s", to))
+		}
+
+		filter.To = t
+	}
+
+	if statusCode := query.Get("statusCode"); statusCode != "" {
+		code, err := strconv.Atoi(statusCode)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("invalid status code: %s", statusCode))
+		}
+
+		filter.StatusCode = code
+	}
+
+	return filter, violations
+}
+
+func extractOrgID(r *http.Request) (uint, error) {
+	vars := mux.Vars(r)
+
+	id, ok := vars["orgId"]
+	if !ok || id == "" {
+		return 0, errors.NewWithDetails("missing path parameter", "param", "orgId")
+	}
+
+	orgID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "invalid path parameter", "param", "orgId", "value", id)
+	}
+
+	return uint(orgID), nil
+}
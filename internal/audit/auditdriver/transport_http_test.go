@@ -0,0 +1,166 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditdriver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/audit"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+)
+
+func newTestServer(endpoints Endpoints) *httptest.Server {
+	handler := mux.NewRouter()
+	RegisterHTTPHandlers(
+		endpoints,
+		handler.PathPrefix("/orgs/{orgId}/audit").Subrouter(),
+		kithttp.ServerErrorEncoder(kitxhttp.NewJSONProblemErrorEncoder(apphttp.NewDefaultProblemConverter())),
+	)
+
+	return httptest.NewServer(handler)
+}
+
+func TestRegisterHTTPHandlers_ListEvents(t *testing.T) {
+	from := time.Date(2020, 3, 16, 0, 0, 0, 0, time.UTC)
+
+	ts := newTestServer(Endpoints{
+		ListEvents: func(ctx context.Context, request interface{}) (interface{}, error) {
+			expected := ListEventsRequest{
+				OrganizationID: 1,
+				Filter: audit.Filter{
+					UserID:     2,
+					From:       from,
+					Method:     "GET",
+					Resource:   "brn:1:cluster:3",
+					StatusCode: 200,
+				},
+				Options: audit.ListOptions{Cursor: "100", Limit: 10},
+			}
+
+			if !assert.Equal(t, expected, request) {
+				return nil, nil
+			}
+
+			return ListEventsResponse{
+				Page: audit.EventPage{
+					Events:     []audit.Event{{ID: 99, OrganizationID: 1}},
+					NextCursor: "99",
+				},
+			}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := ts.Client().Get(ts.URL + "/orgs/1/audit/events?userId=2&from=2020-03-16T00:00:00Z&method=GET&resource=brn:1:cluster:3&statusCode=200&cursor=100&limit=10")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var page audit.EventPage
+
+	err = json.NewDecoder(resp.Body).Decode(&page)
+	require.NoError(t, err)
+
+	assert.Equal(t, "99", page.NextCursor)
+	require.Len(t, page.Events, 1)
+	assert.Equal(t, uint(99), page.Events[0].ID)
+}
+
+func TestRegisterHTTPHandlers_ListEvents_InvalidQuery(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		ListEvents: func(ctx context.Context, request interface{}) (interface{}, error) {
+			t.Error("endpoint should not be called")
+
+			return nil, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := ts.Client().Get(ts.URL + "/orgs/1/audit/events?from=yesterday")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestRegisterHTTPHandlers_ExportEvents(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		ExportEvents: func(ctx context.Context, request interface{}) (interface{}, error) {
+			writer := request.(ExportEventsRequest).Writer
+
+			if err := writer.WriteEvents([]audit.Event{{ID: 2, OrganizationID: 1, Method: "GET"}}); err != nil {
+				return nil, err
+			}
+
+			if err := writer.WriteEvents([]audit.Event{{ID: 1, OrganizationID: 1, Method: "POST"}}); err != nil {
+				return nil, err
+			}
+
+			return ExportEventsResponse{}, nil
+		},
+	})
+	defer ts.Close()
+
+	t.Run("ndjson", func(t *testing.T) {
+		resp, err := ts.Client().Get(ts.URL + "/orgs/1/audit/events/export")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		assert.Len(t, strings.Split(strings.TrimSpace(string(body)), "\n"), 2)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		resp, err := ts.Client().Get(ts.URL + "/orgs/1/audit/events/export?format=csv")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		// header + 2 records
+		assert.Len(t, strings.Split(strings.TrimSpace(string(body)), "\n"), 3)
+	})
+
+	t.Run("unsupportedFormat", func(t *testing.T) {
+		resp, err := ts.Client().Get(ts.URL + "/orgs/1/audit/events/export?format=xml")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
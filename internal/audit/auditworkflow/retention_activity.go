@@ -0,0 +1,123 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditworkflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/audit"
+)
+
+const RetentionActivityName = "audit-retention-activity"
+
+const retentionBatchSize = 1000
+
+type RetentionActivityInput struct {
+	// Events recorded before this time are removed
+	Before time.Time
+
+	// Archive events before removing them
+	Archive bool
+}
+
+type RetentionActivityOutput struct {
+	Removed int64
+}
+
+// RetentionActivity removes (and optionally archives) old audit events.
+type RetentionActivity struct {
+	store    audit.Store
+	archiver audit.Archiver
+}
+
+// NewRetentionActivity returns a new RetentionActivity.
+// The archiver may be nil if archiving is disabled.
+func NewRetentionActivity(store audit.Store, archiver audit.Archiver) RetentionActivity {
+	return RetentionActivity{
+		store:    store,
+		archiver: archiver,
+	}
+}
+
+func (a RetentionActivity) Execute(ctx context.Context, input RetentionActivityInput) (RetentionActivityOutput, error) {
+	if !input.Archive {
+		removed, err := a.store.DeleteOlderThan(ctx, input.Before)
+		if err != nil {
+			return RetentionActivityOutput{}, err
+		}
+
+		return RetentionActivityOutput{Removed: removed}, nil
+	}
+
+	if a.archiver == nil {
+		return RetentionActivityOutput{}, errors.New("audit event archiving is not configured")
+	}
+
+	var output RetentionActivityOutput
+
+	for {
+		events, err := a.store.FindOlderThan(ctx, input.Before, retentionBatchSize)
+		if err != nil {
+			return output, err
+		}
+
+		if len(events) == 0 {
+			break
+		}
+
+		err = a.archiver.Archive(ctx, archiveName(events), events)
+		if err != nil {
+			return output, err
+		}
+
+		ids := make([]uint, 0, len(events))
+		for _, event := range events {
+			ids = append(ids, event.ID)
+		}
+
+		err = a.store.Delete(ctx, ids)
+		if err != nil {
+			return output, err
+		}
+
+		output.Removed += int64(len(events))
+
+		activity.RecordHeartbeat(ctx, output.Removed)
+
+		if len(events) < retentionBatchSize {
+			break
+		}
+	}
+
+	return output, nil
+}
+
+// archiveName returns a deterministic name for a batch of events (ordered by ID),
+// so that a retried batch overwrites the previous attempt.
+func archiveName(events []audit.Event) string {
+	first, last := events[0], events[len(events)-1]
+
+	return fmt.Sprintf(
+		"%s/events-%d-%d.ndjson",
+		first.Time.UTC().Format("2006/01/02"),
+		first.ID,
+		last.ID,
+	)
+}
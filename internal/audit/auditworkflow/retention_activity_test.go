@@ -0,0 +1,159 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditworkflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+
+	"github.com/banzaicloud/pipeline/internal/audit"
+)
+
+type inMemoryStore struct {
+	audit.Store
+
+	events []audit.Event
+}
+
+func (s *inMemoryStore) FindOlderThan(_ context.Context, t time.Time, limit int) ([]audit.Event, error) {
+	var events []audit.Event
+
+	for _, event := range s.events {
+		if event.Time.Before(t) && len(events) < limit {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
+
+func (s *inMemoryStore) Delete(_ context.Context, ids []uint) error {
+	deleted := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		deleted[id] = true
+	}
+
+	var events []audit.Event
+
+	for _, event := range s.events {
+		if !deleted[event.ID] {
+			events = append(events, event)
+		}
+	}
+
+	s.events = events
+
+	return nil
+}
+
+func (s *inMemoryStore) DeleteOlderThan(ctx context.Context, t time.Time) (int64, error) {
+	events, _ := s.FindOlderThan(ctx, t, len(s.events))
+
+	var ids []uint
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+
+	return int64(len(ids)), s.Delete(ctx, ids)
+}
+
+type inMemoryArchiver struct {
+	archives map[string][]audit.Event
+}
+
+func (a *inMemoryArchiver) Archive(_ context.Context, name string, events []audit.Event) error {
+	a.archives[name] = events
+
+	return nil
+}
+
+// nolint: gochecknoglobals
+var (
+	testStore    *inMemoryStore
+	testArchiver *inMemoryArchiver
+)
+
+func testRetentionActivityExecute(ctx context.Context, input RetentionActivityInput) (RetentionActivityOutput, error) {
+	return NewRetentionActivity(testStore, testArchiver).Execute(ctx, input)
+}
+
+// nolint: gochecknoinits
+func init() {
+	activity.RegisterWithOptions(testRetentionActivityExecute, activity.RegisterOptions{Name: RetentionActivityName})
+}
+
+func setUpRetentionTest(count int) time.Time {
+	baseTime := time.Date(2020, 3, 16, 0, 0, 0, 0, time.UTC)
+
+	testStore = &inMemoryStore{}
+	testArchiver = &inMemoryArchiver{archives: map[string][]audit.Event{}}
+
+	for i := 0; i < count; i++ {
+		testStore.events = append(testStore.events, audit.Event{
+			ID:   uint(i + 1),
+			Time: baseTime.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	return baseTime
+}
+
+func TestRetentionActivity_Purge(t *testing.T) {
+	baseTime := setUpRetentionTest(10)
+
+	var testSuite testsuite.WorkflowTestSuite
+	env := testSuite.NewTestActivityEnvironment()
+
+	value, err := env.ExecuteActivity(RetentionActivityName, RetentionActivityInput{
+		Before: baseTime.Add(4 * time.Minute),
+	})
+	require.NoError(t, err)
+
+	var output RetentionActivityOutput
+	require.NoError(t, value.Get(&output))
+
+	assert.Equal(t, int64(4), output.Removed)
+	assert.Len(t, testStore.events, 6)
+	assert.Empty(t, testArchiver.archives)
+}
+
+func TestRetentionActivity_Archive(t *testing.T) {
+	baseTime := setUpRetentionTest(retentionBatchSize + 10)
+
+	var testSuite testsuite.WorkflowTestSuite
+	env := testSuite.NewTestActivityEnvironment()
+
+	value, err := env.ExecuteActivity(RetentionActivityName, RetentionActivityInput{
+		Before:  baseTime.Add(time.Duration(retentionBatchSize+5) * time.Minute),
+		Archive: true,
+	})
+	require.NoError(t, err)
+
+	var output RetentionActivityOutput
+	require.NoError(t, value.Get(&output))
+
+	assert.Equal(t, int64(retentionBatchSize+5), output.Removed)
+	assert.Len(t, testStore.events, 5)
+
+	require.Len(t, testArchiver.archives, 2)
+	assert.Len(t, testArchiver.archives["2020/03/16/events-1-1000.ndjson"], retentionBatchSize)
+	assert.Len(t, testArchiver.archives["2020/03/16/events-1001-1005.ndjson"], 5)
+}
@@ -0,0 +1,63 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditworkflow
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/workflow"
+)
+
+const RetentionWorkflowName = "audit-retention"
+
+// RetentionWorkflowInput defines the fixed inputs of the retention workflow.
+type RetentionWorkflowInput struct {
+	// Events older than this are removed
+	MaxAge time.Duration
+
+	// Archive events before removing them
+	Archive bool
+}
+
+// RetentionWorkflow removes (and optionally archives) audit events older than the configured age.
+// It is supposed to be scheduled as a cron workflow.
+func RetentionWorkflow(ctx workflow.Context, input RetentionWorkflowInput) error {
+	if input.MaxAge <= 0 {
+		return errors.New("audit event max age must be positive")
+	}
+
+	activityInput := RetentionActivityInput{
+		Before:  workflow.Now(ctx).Add(-input.MaxAge),
+		Archive: input.Archive,
+	}
+
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    2 * time.Hour,
+		HeartbeatTimeout:       5 * time.Minute,
+		WaitForCancellation:    true,
+	})
+
+	var output RetentionActivityOutput
+
+	if err := workflow.ExecuteActivity(activityCtx, RetentionActivityName, activityInput).Get(activityCtx, &output); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to execute activity", "activity", RetentionActivityName)
+	}
+
+	workflow.GetLogger(ctx).Sugar().Infow("audit events removed", "removed", output.Removed, "archived", input.Archive)
+
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// Export formats
+const (
+	NDJSONFormat = "ndjson"
+	CSVFormat    = "csv"
+)
+
+// NDJSONEventWriter writes events as newline delimited JSON documents.
+type NDJSONEventWriter struct {
+	encoder *json.Encoder
+}
+
+// NewNDJSONEventWriter returns a new NDJSONEventWriter.
+func NewNDJSONEventWriter(w io.Writer) *NDJSONEventWriter {
+	return &NDJSONEventWriter{
+		encoder: json.NewEncoder(w),
+	}
+}
+
+// WriteEvents implements the EventWriter interface.
+func (w *NDJSONEventWriter) WriteEvents(events []Event) error {
+	for _, event := range events {
+		if err := w.encoder.Encode(event); err != nil {
+			return errors.WrapIfWithDetails(err, "failed to encode audit event", "eventId", event.ID)
+		}
+	}
+
+	return nil
+}
+
+var csvHeader = []string{
+	"id",
+	"time",
+	"correlationId",
+	"organizationId",
+	"userId",
+	"clientIp",
+	"userAgent",
+	"method",
+	"path",
+	"statusCode",
+	"responseTime",
+	"responseSize",
+	"body",
+	"headers",
+	"errors",
+}
+
+// CSVEventWriter writes events as CSV records.
+// The header is written before the first batch.
+type CSVEventWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+// NewCSVEventWriter returns a new CSVEventWriter.
+func NewCSVEventWriter(w io.Writer) *CSVEventWriter {
+	return &CSVEventWriter{
+		writer: csv.NewWriter(w),
+	}
+}
+
+// WriteEvents implements the EventWriter interface.
+func (w *CSVEventWriter) WriteEvents(events []Event) error {
+	if !w.headerWritten {
+		if err := w.writer.Write(csvHeader); err != nil {
+			return errors.WrapIf(err, "failed to write CSV header")
+		}
+
+		w.headerWritten = true
+	}
+
+	for _, event := range events {
+		record := []string{
+			strconv.FormatUint(uint64(event.ID), 10),
+			event.Time.UTC().Format(time.RFC3339Nano),
+			event.CorrelationID,
+			strconv.FormatUint(uint64(event.OrganizationID), 10),
+			strconv.FormatUint(uint64(event.UserID), 10),
+			event.ClientIP,
+			event.UserAgent,
+			event.Method,
+			event.Path,
+			strconv.Itoa(event.StatusCode),
+			strconv.Itoa(event.ResponseTime),
+			strconv.Itoa(event.ResponseSize),
+			string(event.Body),
+			string(event.Headers),
+			string(event.Errors),
+		}
+
+		if err := w.writer.Write(record); err != nil {
+			return errors.WrapIfWithDetails(err, "failed to write CSV record", "eventId", event.ID)
+		}
+	}
+
+	w.writer.Flush()
+
+	return errors.WrapIf(w.writer.Error(), "failed to write CSV")
+}
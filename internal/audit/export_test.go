@@ -0,0 +1,104 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEvents() []Event {
+	return []Event{
+		{
+			ID:             2,
+			Time:           time.Date(2020, 3, 16, 10, 0, 0, 0, time.UTC),
+			OrganizationID: 1,
+			UserID:         3,
+			Method:         "POST",
+			Path:           "/api/v1/orgs/1/clusters",
+			StatusCode:     201,
+			Body:           json.RawMessage(`{"name":"cluster"}`),
+		},
+		{
+			ID:             1,
+			Time:           time.Date(2020, 3, 16, 9, 0, 0, 0, time.UTC),
+			OrganizationID: 1,
+			UserID:         3,
+			Method:         "GET",
+			Path:           "/api/v1/orgs/1/clusters?fields=name,id",
+			StatusCode:     200,
+		},
+	}
+}
+
+func TestNDJSONEventWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	writer := NewNDJSONEventWriter(&buf)
+
+	err := writer.WriteEvents(testEvents()[:1])
+	require.NoError(t, err)
+
+	err = writer.WriteEvents(testEvents()[1:])
+	require.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var event map[string]interface{}
+
+	err = json.Unmarshal(lines[0], &event)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{"name": "cluster"}, event["body"])
+	assert.Equal(t, "POST", event["method"])
+
+	err = json.Unmarshal(lines[1], &event)
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(1), event["id"])
+}
+
+func TestCSVEventWriter(t *testing.T) {
+	var buf bytes.Buffer
+
+	writer := NewCSVEventWriter(&buf)
+
+	err := writer.WriteEvents(testEvents()[:1])
+	require.NoError(t, err)
+
+	err = writer.WriteEvents(testEvents()[1:])
+	require.NoError(t, err)
+
+	expected := "id,time,correlationId,organizationId,userId,clientIp,userAgent,method,path,statusCode,responseTime,responseSize,body,headers,errors\n" +
+		"2,2020-03-16T10:00:00Z,,1,3,,,POST,/api/v1/orgs/1/clusters,201,0,0,\"{\"\"name\"\":\"\"cluster\"\"}\",,\n" +
+		"1,2020-03-16T09:00:00Z,,1,3,,,GET,\"/api/v1/orgs/1/clusters?fields=name,id\",200,0,0,,,\n"
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestCSVEventWriter_NoEvents(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := NewCSVEventWriter(&buf).WriteEvents(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "id,time,correlationId,organizationId,userId,clientIp,userAgent,method,path,statusCode,responseTime,responseSize,body,headers,errors\n", buf.String())
+}
@@ -0,0 +1,303 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/brn"
+)
+
+const (
+	// DefaultListLimit is the number of events returned on a page when no limit is specified.
+	DefaultListLimit = 50
+
+	// MaxListLimit is the maximum number of events returned on a single page.
+	MaxListLimit = 500
+
+	exportBatchSize = 1000
+)
+
+// Event represents a recorded API request.
+type Event struct {
+	ID             uint      `json:"id"`
+	Time           time.Time `json:"time"`
+	CorrelationID  string    `json:"correlationId"`
+	OrganizationID uint      `json:"organizationId"`
+	UserID         uint      `json:"userId"`
+	ClientIP       string    `json:"clientIp"`
+	UserAgent      string    `json:"userAgent"`
+	Method         string    `json:"method"`
+	Path           string    `json:"path"`
+	StatusCode     int       `json:"statusCode"`
+	ResponseTime   int       `json:"responseTime"`
+	ResponseSize   int       `json:"responseSize"`
+
+	// Body, Headers and Errors are raw JSON documents (if present).
+	Body    json.RawMessage `json:"body,omitempty"`
+	Headers json.RawMessage `json:"headers,omitempty"`
+	Errors  json.RawMessage `json:"errors,omitempty"`
+}
+
+// Filter narrows down the list of audit events.
+// Zero values are ignored.
+type Filter struct {
+	// UserID matches events recorded for a specific user.
+	UserID uint
+
+	// From and To limit events to a time range (From is inclusive, To is exclusive).
+	From time.Time
+	To   time.Time
+
+	// Method matches the HTTP method of the request.
+	Method string
+
+	// Path matches the request path and everything below it.
+	Path string
+
+	// Resource matches requests targeting a resource (and its subresources) identified by a BRN.
+	// Eg. brn:1:cluster:2
+	Resource string
+
+	// StatusCode matches the HTTP status code of the response.
+	StatusCode int
+}
+
+// ListOptions controls the pagination of audit events.
+type ListOptions struct {
+	// Cursor is the value of a NextCursor returned on a previous page.
+	Cursor string
+
+	// Limit is the maximum number of events on a page.
+	Limit int
+}
+
+// EventPage is a page of audit events, newest first.
+type EventPage struct {
+	Events []Event `json:"events"`
+
+	// NextCursor can be used to fetch the next page. It is empty on the last page.
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// +kit:endpoint:errorStrategy=service
+// +testify:mock:testOnly=true
+
+// Service provides access to the audit log.
+type Service interface {
+	// ListEvents lists audit events of an organization, newest first.
+	ListEvents(ctx context.Context, organizationID uint, filter Filter, options ListOptions) (page EventPage, err error)
+
+	// ExportEvents writes every audit event of an organization matching the filter to the writer, newest first.
+	ExportEvents(ctx context.Context, organizationID uint, filter Filter, writer EventWriter) (err error)
+}
+
+// EventWriter receives exported events in batches.
+type EventWriter interface {
+	// WriteEvents is called once for every batch of events.
+	// The first call may receive an empty batch if there are no matching events.
+	WriteEvents(events []Event) error
+}
+
+// +testify:mock:testOnly=true
+
+// Store persists audit events.
+type Store interface {
+	// Find returns events of an organization matching the filter, newest first.
+	// If beforeID is not zero, only events with a lower ID are returned.
+	// The Resource field of the filter is ignored, it should be resolved to a path by the caller.
+	Find(ctx context.Context, organizationID uint, filter Filter, beforeID uint, limit int) ([]Event, error)
+
+	// FindOlderThan returns the oldest events (of every organization) recorded before the given time.
+	FindOlderThan(ctx context.Context, t time.Time, limit int) ([]Event, error)
+
+	// Delete deletes events by ID.
+	Delete(ctx context.Context, ids []uint) error
+
+	// DeleteOlderThan deletes every event recorded before the given time.
+	DeleteOlderThan(ctx context.Context, t time.Time) (int64, error)
+}
+
+// NewService returns a new Service.
+func NewService(store Store) Service {
+	return service{
+		store: store,
+	}
+}
+
+type service struct {
+	store Store
+}
+
+func (s service) ListEvents(ctx context.Context, organizationID uint, filter Filter, options ListOptions) (EventPage, error) {
+	filter, err := normalizeFilter(organizationID, filter)
+	if err != nil {
+		return EventPage{}, err
+	}
+
+	var violations []string
+
+	limit := options.Limit
+	if limit == 0 {
+		limit = DefaultListLimit
+	} else if limit < 0 || limit > MaxListLimit {
+		violations = append(violations, fmt.Sprintf("limit must be between 1 and %d", MaxListLimit))
+	}
+
+	var beforeID uint
+	if options.Cursor != "" {
+		id, err := strconv.ParseUint(options.Cursor, 10, 64)
+		if err != nil || id == 0 {
+			violations = append(violations, "invalid cursor")
+		}
+
+		beforeID = uint(id)
+	}
+
+	if len(violations) > 0 {
+		return EventPage{}, NewValidationError("invalid list options", violations)
+	}
+
+	// fetch an extra event to find out whether there is a next page
+	events, err := s.store.Find(ctx, organizationID, filter, beforeID, limit+1)
+	if err != nil {
+		return EventPage{}, err
+	}
+
+	page := EventPage{Events: events}
+
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.NextCursor = strconv.FormatUint(uint64(page.Events[limit-1].ID), 10)
+	}
+
+	return page, nil
+}
+
+func (s service) ExportEvents(ctx context.Context, organizationID uint, filter Filter, writer EventWriter) error {
+	filter, err := normalizeFilter(organizationID, filter)
+	if err != nil {
+		return err
+	}
+
+	var beforeID uint
+
+	for {
+		batch, err := s.store.Find(ctx, organizationID, filter, beforeID, exportBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if err := writer.WriteEvents(batch); err != nil {
+			return err
+		}
+
+		if len(batch) < exportBatchSize {
+			return nil
+		}
+
+		beforeID = batch[len(batch)-1].ID
+	}
+}
+
+var httpMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// normalizeFilter validates a filter and resolves the resource BRN to a request path.
+func normalizeFilter(organizationID uint, filter Filter) (Filter, error) {
+	var violations []string
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		violations = append(violations, "the start of the time range must be before its end")
+	}
+
+	if filter.Method != "" {
+		filter.Method = strings.ToUpper(filter.Method)
+
+		if !httpMethods[filter.Method] {
+			violations = append(violations, fmt.Sprintf("unsupported method: %s", filter.Method))
+		}
+	}
+
+	if filter.StatusCode != 0 && (filter.StatusCode < 100 || filter.StatusCode > 599) {
+		violations = append(violations, "status code must be between 100 and 599")
+	}
+
+	if filter.Path != "" && !strings.HasPrefix(filter.Path, "/") {
+		violations = append(violations, "path must be absolute")
+	}
+
+	if filter.Resource != "" {
+		if filter.Path != "" {
+			violations = append(violations, "path and resource filters cannot be used together")
+		}
+
+		path, err := resourcePath(organizationID, filter.Resource)
+		if err != nil {
+			violations = append(violations, err.Error())
+		}
+
+		filter.Path = path
+		filter.Resource = ""
+	}
+
+	if len(violations) > 0 {
+		return Filter{}, NewValidationError("invalid audit event filter", violations)
+	}
+
+	return filter, nil
+}
+
+// resourcePath returns the API path of a resource identified by a BRN.
+func resourcePath(organizationID uint, resource string) (string, error) {
+	if !brn.IsBRN(resource) {
+		return "", errors.Errorf("invalid resource name: %s", resource)
+	}
+
+	rn, err := brn.Parse(resource)
+	if err != nil || rn.ResourceType == "" || rn.ResourceID == "" {
+		return "", errors.Errorf("invalid resource name: %s", resource)
+	}
+
+	if rn.OrganizationID != 0 && rn.OrganizationID != organizationID {
+		return "", errors.Errorf("resource belongs to a different organization: %s", resource)
+	}
+
+	return fmt.Sprintf("/api/v1/orgs/%d/%ss/%s", organizationID, rn.ResourceType, rn.ResourceID), nil
+}
+
+// +testify:mock:testOnly=true
+
+// Archiver stores audit events outside of the database before they are purged.
+type Archiver interface {
+	// Archive stores a batch of events under a unique name.
+	// Archiving the same batch again should overwrite the previous copy.
+	Archive(ctx context.Context, name string, events []Event) error
+}
@@ -0,0 +1,172 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeEvents(fromID uint, count int) []Event {
+	events := make([]Event, 0, count)
+
+	for i := 0; i < count; i++ {
+		events = append(events, Event{ID: fromID - uint(i), OrganizationID: 1})
+	}
+
+	return events
+}
+
+func TestService_ListEvents(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("Find", ctx, uint(1), Filter{Method: "GET"}, uint(0), 3).Return(makeEvents(10, 3), nil)
+
+	service := NewService(store)
+
+	page, err := service.ListEvents(ctx, 1, Filter{Method: "get"}, ListOptions{Limit: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, makeEvents(10, 2), page.Events)
+	assert.Equal(t, "9", page.NextCursor)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_ListEvents_LastPage(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("Find", ctx, uint(1), Filter{}, uint(9), DefaultListLimit+1).Return(makeEvents(8, 2), nil)
+
+	service := NewService(store)
+
+	page, err := service.ListEvents(ctx, 1, Filter{}, ListOptions{Cursor: "9"})
+	require.NoError(t, err)
+
+	assert.Equal(t, makeEvents(8, 2), page.Events)
+	assert.Empty(t, page.NextCursor)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_ListEvents_Resource(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("Find", ctx, uint(1), Filter{Path: "/api/v1/orgs/1/clusters/2"}, uint(0), DefaultListLimit+1).Return(nil, nil)
+
+	service := NewService(store)
+
+	_, err := service.ListEvents(ctx, 1, Filter{Resource: "brn:1:cluster:2"}, ListOptions{})
+	require.NoError(t, err)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_ListEvents_Invalid(t *testing.T) {
+	now := time.Now()
+
+	tests := map[string]struct {
+		filter  Filter
+		options ListOptions
+	}{
+		"limit": {
+			options: ListOptions{Limit: MaxListLimit + 1},
+		},
+		"cursor": {
+			options: ListOptions{Cursor: "abc"},
+		},
+		"timeRange": {
+			filter: Filter{From: now, To: now.Add(-time.Hour)},
+		},
+		"method": {
+			filter: Filter{Method: "FOO"},
+		},
+		"statusCode": {
+			filter: Filter{StatusCode: 1000},
+		},
+		"relativePath": {
+			filter: Filter{Path: "api/v1"},
+		},
+		"pathAndResource": {
+			filter: Filter{Path: "/api/v1/orgs/1/clusters", Resource: "brn:1:cluster:2"},
+		},
+		"invalidResource": {
+			filter: Filter{Resource: "cluster:2"},
+		},
+		"foreignResource": {
+			filter: Filter{Resource: "brn:2:cluster:2"},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			store := new(MockStore)
+
+			service := NewService(store)
+
+			_, err := service.ListEvents(context.Background(), 1, test.filter, test.options)
+			require.Error(t, err)
+
+			var verr ValidationError
+			assert.True(t, errors.As(err, &verr))
+
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_ExportEvents(t *testing.T) {
+	ctx := context.Background()
+	filter := Filter{StatusCode: 200}
+
+	store := new(MockStore)
+	store.On("Find", ctx, uint(1), filter, uint(0), exportBatchSize).Return(makeEvents(1500, exportBatchSize), nil)
+	store.On("Find", ctx, uint(1), filter, uint(501), exportBatchSize).Return(makeEvents(500, 500), nil)
+
+	service := NewService(store)
+
+	var writer batchCollector
+
+	err := service.ExportEvents(ctx, 1, filter, &writer)
+	require.NoError(t, err)
+
+	require.Len(t, writer.batches, 2)
+	assert.Len(t, writer.batches[0], exportBatchSize)
+	assert.Len(t, writer.batches[1], 500)
+	assert.Equal(t, uint(1500), writer.batches[0][0].ID)
+	assert.Equal(t, uint(1), writer.batches[1][499].ID)
+
+	store.AssertExpectations(t)
+}
+
+type batchCollector struct {
+	batches [][]Event
+}
+
+func (c *batchCollector) WriteEvents(events []Event) error {
+	c.batches = append(c.batches, events)
+
+	return nil
+}
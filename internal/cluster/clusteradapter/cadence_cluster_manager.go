@@ -42,8 +42,9 @@ func (m CadenceClusterManager) DeleteCluster(ctx context.Context, clusterID uint
 	}
 
 	input := clusterworkflow.DeleteClusterActivityInput{
-		ClusterID: clusterID,
-		Force:     options.Force,
+		ClusterID:     clusterID,
+		Force:         options.Force,
+		KeepResources: options.KeepResources,
 	}
 
 	_, err := m.workflowClient.StartWorkflow(ctx, workflowOptions, clusterworkflow.DeleteClusterWorkflowName, input)
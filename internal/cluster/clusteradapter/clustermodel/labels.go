@@ -0,0 +1,40 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustermodel
+
+import (
+	"database/sql/driver"
+
+	"github.com/banzaicloud/pipeline/internal/database/sql/json"
+)
+
+// Labels are user defined key-value pairs attached to a cluster, persisted in a single database column.
+type Labels map[string]string
+
+// Value implements the driver.Valuer interface
+func (l Labels) Value() (driver.Value, error) {
+	return json.Value(l)
+}
+
+// Scan implements the sql.Scanner interface
+func (l *Labels) Scan(src interface{}) error {
+	if src == nil {
+		*l = nil
+
+		return nil
+	}
+
+	return json.Scan(src, l)
+}
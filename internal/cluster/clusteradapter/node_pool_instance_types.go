@@ -0,0 +1,85 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusteradapter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	cloudinfoapi "github.com/banzaicloud/pipeline/.gen/cloudinfo"
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/pkg/cloudinfo"
+)
+
+// CloudinfoProductLister lists instance types from Cloudinfo.
+type CloudinfoProductLister interface {
+	// GetProducts returns the details of every product of a service available in a region.
+	GetProducts(ctx context.Context, cloud string, service string, region string) ([]cloudinfoapi.ProductDetails, error)
+}
+
+type cloudinfoInstanceTypeValidator struct {
+	products CloudinfoProductLister
+}
+
+// NewCloudinfoInstanceTypeValidator returns a new cluster.NodePoolValidator
+// that checks whether the instance type of a node pool is available in the cluster location.
+func NewCloudinfoInstanceTypeValidator(products CloudinfoProductLister) cluster.NodePoolValidator {
+	return cloudinfoInstanceTypeValidator{
+		products: products,
+	}
+}
+
+func (v cloudinfoInstanceTypeValidator) ValidateNew(
+	ctx context.Context,
+	c cluster.Cluster,
+	rawNodePool cluster.NewRawNodePool,
+) error {
+	instanceType := rawNodePool.GetInstanceType()
+
+	// required fields are checked by distribution specific validators
+	if instanceType == "" {
+		return nil
+	}
+
+	products, err := v.products.GetProducts(ctx, c.Cloud, c.Distribution, cloudinfo.RegionFromLocation(c.Cloud, c.Location))
+	if err != nil {
+		// Cloudinfo being unavailable should not block node pool creation:
+		// the instance type is validated by the cloud provider as well.
+		log.WithError(err).WithFields(logrus.Fields{
+			"cluster":      c.ID,
+			"instanceType": instanceType,
+		}).Warn("failed to list available instance types")
+
+		return nil
+	}
+
+	// the location is unknown to Cloudinfo
+	if len(products) == 0 {
+		return nil
+	}
+
+	for _, product := range products {
+		if product.Type == instanceType {
+			return nil
+		}
+	}
+
+	return cluster.NewValidationError(
+		"invalid node pool creation request",
+		[]string{fmt.Sprintf("instance type %q is not available in %s", instanceType, c.Location)},
+	)
+}
@@ -0,0 +1,111 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusteradapter
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cloudinfoapi "github.com/banzaicloud/pipeline/.gen/cloudinfo"
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+type productListerFunc func(cloud string, service string, region string) ([]cloudinfoapi.ProductDetails, error)
+
+func (f productListerFunc) GetProducts(_ context.Context, cloud string, service string, region string) ([]cloudinfoapi.ProductDetails, error) {
+	return f(cloud, service, region)
+}
+
+func TestCloudinfoInstanceTypeValidator_ValidateNew(t *testing.T) {
+	validator := NewCloudinfoInstanceTypeValidator(productListerFunc(
+		func(cloud string, service string, region string) ([]cloudinfoapi.ProductDetails, error) {
+			switch {
+			case cloud == "amazon" && service == "eks" && region == "eu-west-1":
+				return []cloudinfoapi.ProductDetails{{Type: "m5.large"}, {Type: "m5.xlarge"}}, nil
+			case cloud == "google" && service == "gke" && region == "europe-west1":
+				return []cloudinfoapi.ProductDetails{{Type: "n1-standard-1"}}, nil
+			case cloud == "azure":
+				return nil, errors.New("cloudinfo is unavailable")
+			}
+
+			return nil, nil
+		},
+	))
+
+	testCases := map[string]struct {
+		cluster      cluster.Cluster
+		instanceType string
+		valid        bool
+	}{
+		"available": {
+			cluster:      cluster.Cluster{Cloud: "amazon", Distribution: "eks", Location: "eu-west-1"},
+			instanceType: "m5.large",
+			valid:        true,
+		},
+		"unavailable": {
+			cluster:      cluster.Cluster{Cloud: "amazon", Distribution: "eks", Location: "eu-west-1"},
+			instanceType: "p3.16xlarge",
+			valid:        false,
+		},
+		"zonal cluster": {
+			cluster:      cluster.Cluster{Cloud: "google", Distribution: "gke", Location: "europe-west1-b"},
+			instanceType: "n1-standard-1",
+			valid:        true,
+		},
+		"missing instance type": {
+			cluster: cluster.Cluster{Cloud: "amazon", Distribution: "eks", Location: "eu-west-1"},
+			valid:   true,
+		},
+		"unknown location": {
+			cluster:      cluster.Cluster{Cloud: "amazon", Distribution: "eks", Location: "mars-north-1"},
+			instanceType: "m5.large",
+			valid:        true,
+		},
+		"cloudinfo error": {
+			cluster:      cluster.Cluster{Cloud: "azure", Distribution: "aks", Location: "westeurope"},
+			instanceType: "Standard_B2s",
+			valid:        true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+
+		t.Run(name, func(t *testing.T) {
+			rawNodePool := cluster.NewRawNodePool{"name": "pool0"}
+			if testCase.instanceType != "" {
+				rawNodePool["instanceType"] = testCase.instanceType
+			}
+
+			err := validator.ValidateNew(context.Background(), testCase.cluster, rawNodePool)
+
+			if testCase.valid {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+
+			var verr cluster.ValidationError
+			require.True(t, errors.As(err, &verr))
+			assert.Equal(t, []string{`instance type "p3.16xlarge" is not available in eu-west-1`}, verr.Violations())
+		})
+	}
+}
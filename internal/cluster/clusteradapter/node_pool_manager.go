@@ -16,6 +16,7 @@ package clusteradapter
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"emperror.dev/errors"
@@ -81,3 +82,32 @@ func (n nodePoolManager) DeleteNodePool(ctx context.Context, clusterID uint, nam
 
 	return nil
 }
+
+func (n nodePoolManager) ReplaceNodePool(
+	ctx context.Context,
+	clusterID uint,
+	name string,
+	options cluster.ReplaceNodePoolOptions,
+) error {
+	workflowOptions := client.StartWorkflowOptions{
+		// Using a deterministic ID prevents starting a second replacement for the same node pool
+		// while the first one is still running.
+		ID:                           fmt.Sprintf("%s-%d-%s", clusterworkflow.ReplaceNodePoolWorkflowName, clusterID, name),
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 30 * 24 * 60 * time.Minute,
+	}
+
+	input := clusterworkflow.ReplaceNodePoolWorkflowInput{
+		ClusterID:    clusterID,
+		NodePoolName: name,
+		Image:        options.Image,
+		MaxSurge:     options.MaxSurge,
+	}
+
+	_, err := n.workflowClient.StartWorkflow(ctx, workflowOptions, clusterworkflow.ReplaceNodePoolWorkflowName, input)
+	if err != nil {
+		return errors.WrapWithDetails(err, "failed to start workflow", "workflow", clusterworkflow.ReplaceNodePoolWorkflowName)
+	}
+
+	return nil
+}
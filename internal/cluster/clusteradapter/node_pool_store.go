@@ -22,6 +22,7 @@ import (
 
 	"github.com/banzaicloud/pipeline/internal/cluster"
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksmodel"
+	"github.com/banzaicloud/pipeline/internal/providers/pke"
 	"github.com/banzaicloud/pipeline/pkg/providers"
 )
 
@@ -71,6 +72,25 @@ func (s nodePoolStore) NodePoolExists(ctx context.Context, clusterID uint, name
 			return false, nil
 		}
 
+	case c.Cloud == providers.Amazon && c.Distribution == "pke":
+		var count int
+
+		err := s.db.
+			Model(pke.NodePool{}).
+			Where(pke.NodePool{ClusterID: clusterID, Name: name}).
+			Count(&count).Error
+		if err != nil {
+			return false, errors.WrapWithDetails(
+				err, "failed to check if node pool exists",
+				"clusterId", clusterID,
+				"nodePoolName", name,
+			)
+		}
+
+		if count == 0 {
+			return false, nil
+		}
+
 	default:
 		return false, errors.WithStack(cluster.NotSupportedDistributionError{
 			ID:           c.ID,
@@ -62,6 +62,7 @@ func clusterModelToEntity(m *model.ClusterModel) cluster.Cluster {
 		Cloud:          m.Cloud,
 		Distribution:   m.Distribution,
 		Location:       m.Location,
+		Labels:         m.Labels,
 		SecretID:       brn.New(m.OrganizationId, brn.SecretResourceType, m.SecretId),
 		ConfigSecretID: brn.New(m.OrganizationId, brn.SecretResourceType, m.ConfigSecretId),
 	}
@@ -155,3 +156,18 @@ func (s Store) SetStatus(ctx context.Context, id uint, status string, message st
 
 	return nil
 }
+
+// SetLabels replaces the user defined labels of a cluster.
+func (s Store) SetLabels(ctx context.Context, id uint, labels map[string]string) error {
+	clusterModel, err := s.findModel(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.Model(&clusterModel).Update("labels", clustermodel.Labels(labels)).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to update cluster labels", "cluster_id", id)
+	}
+
+	return nil
+}
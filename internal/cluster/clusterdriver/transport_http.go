@@ -17,6 +17,7 @@ package clusterdriver
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -55,6 +56,27 @@ func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...ki
 		kitxhttp.ErrorResponseEncoder(encodeDeleteNodePoolHTTPResponse, errorEncoder),
 		options...,
 	))
+
+	router.Methods(http.MethodPost).Path("/nodepools/{nodePoolName}/replace").Handler(kithttp.NewServer(
+		endpoints.ReplaceNodePool,
+		decodeReplaceNodePoolHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusAccepted), errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPut).Path("/labels").Handler(kithttp.NewServer(
+		endpoints.UpdateClusterLabels,
+		decodeUpdateClusterLabelsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusNoContent), errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/unlink").Handler(kithttp.NewServer(
+		endpoints.UnlinkCluster,
+		decodeUnlinkClusterHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusAccepted), errorEncoder),
+		options...,
+	))
 }
 
 func decodeDeleteClusterHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
@@ -208,3 +230,87 @@ func encodeDeleteNodePoolHTTPResponse(_ context.Context, w http.ResponseWriter,
 
 	return nil
 }
+
+func decodeReplaceNodePoolHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+
+	rawClusterID, ok := vars["clusterId"]
+	if !ok || rawClusterID == "" {
+		return nil, errors.NewWithDetails("missing parameter from the URL", "param", "clusterId")
+	}
+
+	clusterID, err := strconv.ParseUint(rawClusterID, 10, 32)
+	if err != nil {
+		return nil, errors.NewWithDetails("invalid cluster ID", "rawClusterId", rawClusterID)
+	}
+
+	nodePoolName, ok := vars["nodePoolName"]
+	if !ok || nodePoolName == "" {
+		return nil, errors.NewWithDetails("missing parameter from the URL", "param", "nodePoolName")
+	}
+
+	var request pipeline.ReplaceNodePoolRequest
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return ReplaceNodePoolRequest{
+		ClusterID: uint(clusterID),
+		Name:      nodePoolName,
+		Options: cluster.ReplaceNodePoolOptions{
+			Image:    request.Image,
+			MaxSurge: int(request.MaxSurge),
+		},
+	}, nil
+}
+
+func decodeUpdateClusterLabelsHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	vars := mux.Vars(r)
+
+	rawClusterID, ok := vars["clusterId"]
+	if !ok || rawClusterID == "" {
+		return nil, errors.NewWithDetails("missing parameter from the URL", "param", "clusterId")
+	}
+
+	clusterID, err := strconv.ParseUint(rawClusterID, 10, 32)
+	if err != nil {
+		return nil, errors.NewWithDetails("invalid cluster ID", "rawClusterId", rawClusterID)
+	}
+
+	var request pipeline.UpdateClusterLabelsRequest
+
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return UpdateClusterLabelsRequest{
+		ClusterID: uint(clusterID),
+		Labels:    request.Labels,
+	}, nil
+}
+
+func decodeUnlinkClusterHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	clusterID, err := getClusterID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var request pipeline.UnlinkClusterRequest
+
+	// the request body is optional
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return UnlinkClusterRequest{
+		ClusterID: clusterID,
+		Options: cluster.UnlinkClusterOptions{
+			Uninstall: request.Uninstall,
+			Force:     request.Force,
+		},
+	}, nil
+}
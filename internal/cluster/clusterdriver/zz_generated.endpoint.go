@@ -26,9 +26,12 @@ type serviceError interface {
 // meant to be used as a helper struct, to collect all of the endpoints into a
 // single parameter.
 type Endpoints struct {
-	CreateNodePool endpoint.Endpoint
-	DeleteCluster  endpoint.Endpoint
-	DeleteNodePool endpoint.Endpoint
+	CreateNodePool      endpoint.Endpoint
+	DeleteCluster       endpoint.Endpoint
+	DeleteNodePool      endpoint.Endpoint
+	ReplaceNodePool     endpoint.Endpoint
+	UnlinkCluster       endpoint.Endpoint
+	UpdateClusterLabels endpoint.Endpoint
 }
 
 // MakeEndpoints returns a(n) Endpoints struct where each endpoint invokes
@@ -37,9 +40,12 @@ func MakeEndpoints(service cluster.Service, middleware ...endpoint.Middleware) E
 	mw := kitxendpoint.Combine(middleware...)
 
 	return Endpoints{
-		CreateNodePool: kitxendpoint.OperationNameMiddleware("cluster.CreateNodePool")(mw(MakeCreateNodePoolEndpoint(service))),
-		DeleteCluster:  kitxendpoint.OperationNameMiddleware("cluster.DeleteCluster")(mw(MakeDeleteClusterEndpoint(service))),
-		DeleteNodePool: kitxendpoint.OperationNameMiddleware("cluster.DeleteNodePool")(mw(MakeDeleteNodePoolEndpoint(service))),
+		CreateNodePool:      kitxendpoint.OperationNameMiddleware("cluster.CreateNodePool")(mw(MakeCreateNodePoolEndpoint(service))),
+		DeleteCluster:       kitxendpoint.OperationNameMiddleware("cluster.DeleteCluster")(mw(MakeDeleteClusterEndpoint(service))),
+		DeleteNodePool:      kitxendpoint.OperationNameMiddleware("cluster.DeleteNodePool")(mw(MakeDeleteNodePoolEndpoint(service))),
+		ReplaceNodePool:     kitxendpoint.OperationNameMiddleware("cluster.ReplaceNodePool")(mw(MakeReplaceNodePoolEndpoint(service))),
+		UnlinkCluster:       kitxendpoint.OperationNameMiddleware("cluster.UnlinkCluster")(mw(MakeUnlinkClusterEndpoint(service))),
+		UpdateClusterLabels: kitxendpoint.OperationNameMiddleware("cluster.UpdateClusterLabels")(mw(MakeUpdateClusterLabelsEndpoint(service))),
 	}
 }
 
@@ -158,3 +164,106 @@ func MakeDeleteNodePoolEndpoint(service cluster.Service) endpoint.Endpoint {
 		return DeleteNodePoolResponse{Deleted: deleted}, nil
 	}
 }
+
+// ReplaceNodePoolRequest is a request struct for ReplaceNodePool endpoint.
+type ReplaceNodePoolRequest struct {
+	ClusterID uint
+	Name      string
+	Options   cluster.ReplaceNodePoolOptions
+}
+
+// ReplaceNodePoolResponse is a response struct for ReplaceNodePool endpoint.
+type ReplaceNodePoolResponse struct {
+	Err error
+}
+
+func (r ReplaceNodePoolResponse) Failed() error {
+	return r.Err
+}
+
+// MakeReplaceNodePoolEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeReplaceNodePoolEndpoint(service cluster.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ReplaceNodePoolRequest)
+
+		err := service.ReplaceNodePool(ctx, req.ClusterID, req.Name, req.Options)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return ReplaceNodePoolResponse{Err: err}, nil
+			}
+
+			return ReplaceNodePoolResponse{Err: err}, err
+		}
+
+		return ReplaceNodePoolResponse{}, nil
+	}
+}
+
+// UnlinkClusterRequest is a request struct for UnlinkCluster endpoint.
+type UnlinkClusterRequest struct {
+	ClusterID uint
+	Options   cluster.UnlinkClusterOptions
+}
+
+// UnlinkClusterResponse is a response struct for UnlinkCluster endpoint.
+type UnlinkClusterResponse struct {
+	Err error
+}
+
+func (r UnlinkClusterResponse) Failed() error {
+	return r.Err
+}
+
+// MakeUnlinkClusterEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeUnlinkClusterEndpoint(service cluster.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UnlinkClusterRequest)
+
+		err := service.UnlinkCluster(ctx, req.ClusterID, req.Options)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return UnlinkClusterResponse{Err: err}, nil
+			}
+
+			return UnlinkClusterResponse{Err: err}, err
+		}
+
+		return UnlinkClusterResponse{}, nil
+	}
+}
+
+// UpdateClusterLabelsRequest is a request struct for UpdateClusterLabels endpoint.
+type UpdateClusterLabelsRequest struct {
+	ClusterID uint
+	Labels    map[string]string
+}
+
+// UpdateClusterLabelsResponse is a response struct for UpdateClusterLabels endpoint.
+type UpdateClusterLabelsResponse struct {
+	Err error
+}
+
+func (r UpdateClusterLabelsResponse) Failed() error {
+	return r.Err
+}
+
+// MakeUpdateClusterLabelsEndpoint returns an endpoint for the matching method of the underlying service.
+func MakeUpdateClusterLabelsEndpoint(service cluster.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(UpdateClusterLabelsRequest)
+
+		err := service.UpdateClusterLabels(ctx, req.ClusterID, req.Labels)
+
+		if err != nil {
+			if serviceErr := serviceError(nil); errors.As(err, &serviceErr) && serviceErr.ServiceError() {
+				return UpdateClusterLabelsResponse{Err: err}, nil
+			}
+
+			return UpdateClusterLabelsResponse{Err: err}, err
+		}
+
+		return UpdateClusterLabelsResponse{}, nil
+	}
+}
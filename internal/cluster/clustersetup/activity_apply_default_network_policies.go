@@ -0,0 +1,103 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustersetup
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/networkpolicy"
+)
+
+const ApplyDefaultNetworkPoliciesActivityName = "apply-default-network-policies"
+
+// ApplyDefaultNetworkPoliciesActivity applies the default network policies of the organization
+// to the user namespaces of a new cluster.
+// Only the namespaces existing at the time of the cluster setup are covered:
+// namespaces created later are not watched, so they do not receive the policies.
+type ApplyDefaultNetworkPoliciesActivity struct {
+	namespace string
+
+	store         networkpolicy.Store
+	clientFactory ClientFactory
+}
+
+// NewApplyDefaultNetworkPoliciesActivity returns a new ApplyDefaultNetworkPoliciesActivity.
+func NewApplyDefaultNetworkPoliciesActivity(
+	namespace string,
+	store networkpolicy.Store,
+	clientFactory ClientFactory,
+) ApplyDefaultNetworkPoliciesActivity {
+	return ApplyDefaultNetworkPoliciesActivity{
+		namespace:     namespace,
+		store:         store,
+		clientFactory: clientFactory,
+	}
+}
+
+type ApplyDefaultNetworkPoliciesActivityInput struct {
+	// Kubernetes cluster config secret ID.
+	ConfigSecretID string
+
+	OrganizationID uint
+}
+
+func (a ApplyDefaultNetworkPoliciesActivity) Execute(ctx context.Context, input ApplyDefaultNetworkPoliciesActivityInput) error {
+	defaults, err := a.store.Get(ctx, input.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	if !defaults.Enabled() {
+		return nil
+	}
+
+	client, err := a.clientFactory.FromSecret(ctx, input.ConfigSecretID)
+	if err != nil {
+		return err
+	}
+
+	namespaces, err := client.CoreV1().Namespaces().List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrap(err, "failed to list namespaces")
+	}
+
+	for _, namespace := range namespaces.Items {
+		if !defaults.IsUserNamespace(namespace.Name, a.namespace) {
+			continue
+		}
+
+		for _, policy := range defaults.Policies(namespace.Name) {
+			policy := policy
+
+			_, err := client.NetworkingV1().NetworkPolicies(namespace.Name).Create(&policy)
+			if k8serrors.IsAlreadyExists(err) {
+				_, err = client.NetworkingV1().NetworkPolicies(namespace.Name).Update(&policy)
+			}
+			if err != nil {
+				return errors.WrapIfWithDetails(
+					err, "failed to apply network policy",
+					"namespace", namespace.Name,
+					"policy", policy.Name,
+				)
+			}
+		}
+	}
+
+	return nil
+}
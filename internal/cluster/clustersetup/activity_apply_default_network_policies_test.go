@@ -0,0 +1,146 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustersetup
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/testing_frameworks/integration"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/networkpolicy"
+	"github.com/banzaicloud/pipeline/pkg/k8sclient"
+)
+
+// nolint: gochecknoglobals
+var applyDefaultNetworkPoliciesTestActivity = ApplyDefaultNetworkPoliciesActivity{}
+
+func testApplyDefaultNetworkPoliciesActivityExecute(ctx context.Context, input ApplyDefaultNetworkPoliciesActivityInput) error {
+	return applyDefaultNetworkPoliciesTestActivity.Execute(ctx, input)
+}
+
+// nolint: gochecknoinits
+func init() {
+	activity.RegisterWithOptions(testApplyDefaultNetworkPoliciesActivityExecute, activity.RegisterOptions{Name: ApplyDefaultNetworkPoliciesActivityName})
+}
+
+type networkPolicyDefaultsStoreStub struct {
+	defaults networkpolicy.Defaults
+}
+
+func (s networkPolicyDefaultsStoreStub) Get(_ context.Context, _ uint) (networkpolicy.Defaults, error) {
+	return s.defaults, nil
+}
+
+func (s networkPolicyDefaultsStoreStub) Set(_ context.Context, _ uint, _ networkpolicy.Defaults) error {
+	return nil
+}
+
+type ApplyDefaultNetworkPoliciesActivityTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestActivityEnvironment
+
+	controlPlane *integration.ControlPlane
+
+	client kubernetes.Interface
+}
+
+func testApplyDefaultNetworkPoliciesActivity(t *testing.T) {
+	if os.Getenv("TEST_ASSET_KUBE_APISERVER") == "" || os.Getenv("TEST_ASSET_ETCD") == "" {
+		t.Skip("control plane binaries are missing")
+	}
+
+	suite.Run(t, new(ApplyDefaultNetworkPoliciesActivityTestSuite))
+}
+
+func (s *ApplyDefaultNetworkPoliciesActivityTestSuite) SetupSuite() {
+	s.controlPlane = &integration.ControlPlane{}
+
+	err := s.controlPlane.Start()
+	s.Require().NoError(err)
+}
+
+func (s *ApplyDefaultNetworkPoliciesActivityTestSuite) TearDownSuite() {
+	_ = s.controlPlane.Stop()
+}
+
+func (s *ApplyDefaultNetworkPoliciesActivityTestSuite) SetupTest() {
+	s.env = s.NewTestActivityEnvironment()
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{},
+		&clientcmd.ConfigOverrides{ClusterInfo: clientcmdapi.Cluster{Server: s.controlPlane.APIURL().String()}},
+	).ClientConfig()
+	s.Require().NoError(err)
+
+	client, err := k8sclient.NewClientFromConfig(config)
+	s.Require().NoError(err)
+
+	s.client = client
+}
+
+func (s *ApplyDefaultNetworkPoliciesActivityTestSuite) Test_Execute() {
+	for _, name := range []string{"pipeline-system", "app"} {
+		_, err := s.client.CoreV1().Namespaces().Create(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		s.Require().NoError(err)
+	}
+
+	clientFactory := new(MockClientFactory)
+	clientFactory.On("FromSecret", mock.Anything, "secret").Return(s.client, nil)
+
+	store := networkPolicyDefaultsStoreStub{
+		defaults: networkpolicy.Defaults{
+			DenyAll:  true,
+			AllowDNS: true,
+		},
+	}
+
+	applyDefaultNetworkPoliciesTestActivity = NewApplyDefaultNetworkPoliciesActivity("pipeline-system", store, clientFactory)
+
+	for i := 0; i < 2; i++ {
+		_, err := s.env.ExecuteActivity(
+			ApplyDefaultNetworkPoliciesActivityName,
+			ApplyDefaultNetworkPoliciesActivityInput{
+				ConfigSecretID: "secret",
+				OrganizationID: 1,
+			},
+		)
+		s.Require().NoError(err)
+	}
+
+	policies, err := s.client.NetworkingV1().NetworkPolicies("app").List(metav1.ListOptions{})
+	s.Require().NoError(err)
+	s.Assert().Len(policies.Items, 2)
+
+	for _, namespace := range []string{"pipeline-system", "kube-system"} {
+		policies, err := s.client.NetworkingV1().NetworkPolicies(namespace).List(metav1.ListOptions{})
+		s.Require().NoError(err)
+		s.Assert().Empty(policies.Items)
+	}
+
+	clientFactory.AssertExpectations(s.T())
+}
@@ -27,4 +27,5 @@ func TestIntegration(t *testing.T) {
 
 	t.Run("CreatePipelineNamespaceActivity", testCreatePipelineNamespaceActivity)
 	t.Run("LabelKubeSystemNamespaceActivity", testLabelKubeSystemNamespaceActivity)
+	t.Run("ApplyDefaultNetworkPoliciesActivity", testApplyDefaultNetworkPoliciesActivity)
 }
@@ -167,5 +167,17 @@ func (w Workflow) Execute(ctx workflow.Context, input WorkflowInput) error {
 		}
 	}
 
+	{
+		activityInput := ApplyDefaultNetworkPoliciesActivityInput{
+			ConfigSecretID: input.ConfigSecretID,
+			OrganizationID: input.Organization.ID,
+		}
+
+		err := workflow.ExecuteActivity(ctx, ApplyDefaultNetworkPoliciesActivityName, activityInput).Get(ctx, nil)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -106,6 +106,12 @@ func (s *WorkflowTestSuite) Test_Success() {
 		},
 	).Return(nil)
 
+	s.env.OnActivity(
+		ApplyDefaultNetworkPoliciesActivityName,
+		mock.Anything,
+		ApplyDefaultNetworkPoliciesActivityInput{ConfigSecretID: "secret", OrganizationID: 1},
+	).Return(nil)
+
 	workflowInput := WorkflowInput{
 		ConfigSecretID: "secret",
 		Cluster:        testCluster,
@@ -170,6 +176,12 @@ func (s *WorkflowTestSuite) Test_Success_InstallInitManifest() {
 		},
 	).Return(nil)
 
+	s.env.OnActivity(
+		ApplyDefaultNetworkPoliciesActivityName,
+		mock.Anything,
+		ApplyDefaultNetworkPoliciesActivityInput{ConfigSecretID: "secret", OrganizationID: 1},
+	).Return(nil)
+
 	workflowInput := WorkflowInput{
 		ConfigSecretID: "secret",
 		Cluster:        testCluster,
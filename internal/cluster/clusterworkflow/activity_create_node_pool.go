@@ -175,6 +175,7 @@ func (a CreateNodePoolActivity) Execute(ctx context.Context, input CreateNodePoo
 			NodeImage:        nodePool.Image,
 			NodeInstanceType: nodePool.InstanceType,
 			Labels:           nodePool.Labels,
+			Taints:           nodePool.Taints,
 		}
 
 		var eksConfig = global.Config.Distribution.EKS
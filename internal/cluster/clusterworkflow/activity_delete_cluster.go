@@ -33,10 +33,16 @@ func MakeDeleteClusterActivity(clusterDeleter cluster.Deleter) DeleteClusterActi
 }
 
 type DeleteClusterActivityInput struct {
-	ClusterID uint
-	Force     bool
+	ClusterID     uint
+	Force         bool
+	KeepResources bool
 }
 
 func (a DeleteClusterActivity) Execute(ctx context.Context, input DeleteClusterActivityInput) error {
-	return a.clusterDeleter.DeleteCluster(ctx, input.ClusterID, cluster.DeleteClusterOptions{Force: input.Force})
+	options := cluster.DeleteClusterOptions{
+		Force:         input.Force,
+		KeepResources: input.KeepResources,
+	}
+
+	return a.clusterDeleter.DeleteCluster(ctx, input.ClusterID, options)
 }
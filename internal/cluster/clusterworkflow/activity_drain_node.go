@@ -0,0 +1,140 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+
+	"github.com/banzaicloud/pipeline/pkg/cadence"
+)
+
+const DrainNodeActivityName = "drain-node"
+
+// DrainNodeActivity cordons a node and evicts every pod running on it.
+//
+// Pods are evicted through the eviction API, so pod disruption budgets are respected:
+// the activity fails while there are pods left on the node and relies on the retry policy
+// to try again until the budgets allow the remaining evictions.
+type DrainNodeActivity struct {
+	clientFactory ClientFactory
+}
+
+// NewDrainNodeActivity returns a new DrainNodeActivity.
+func NewDrainNodeActivity(clientFactory ClientFactory) DrainNodeActivity {
+	return DrainNodeActivity{
+		clientFactory: clientFactory,
+	}
+}
+
+type DrainNodeActivityInput struct {
+	ClusterID uint
+	NodeName  string
+}
+
+func (a DrainNodeActivity) Execute(ctx context.Context, input DrainNodeActivityInput) error {
+	client, err := a.clientFactory.FromClusterID(ctx, input.ClusterID)
+	if err != nil {
+		return cadence.WrapClientError(err)
+	}
+
+	node, err := client.CoreV1().Nodes().Get(input.NodeName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		// Node is already gone
+		return nil
+	} else if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to get node", "node", input.NodeName)
+	}
+
+	if !node.Spec.Unschedulable {
+		node.Spec.Unschedulable = true
+
+		_, err := client.CoreV1().Nodes().Update(node)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to cordon node", "node", input.NodeName)
+		}
+	}
+
+	pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", input.NodeName).String(),
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to list pods", "node", input.NodeName)
+	}
+
+	var remaining int
+
+	for _, pod := range pods.Items {
+		if !shouldEvictPod(pod) {
+			continue
+		}
+
+		remaining++
+
+		activity.RecordHeartbeat(ctx, pod.Namespace+"/"+pod.Name)
+
+		err := client.PolicyV1beta1().Evictions(pod.Namespace).Evict(&policyv1beta1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+			},
+		})
+		if k8serrors.IsNotFound(err) {
+			remaining--
+
+			continue
+		} else if k8serrors.IsTooManyRequests(err) {
+			// The eviction would violate a pod disruption budget: try again later.
+			continue
+		} else if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to evict pod", "node", input.NodeName, "namespace", pod.Namespace, "pod", pod.Name)
+		}
+	}
+
+	if remaining > 0 {
+		return errors.NewWithDetails("node is not drained yet", "node", input.NodeName, "remainingPods", remaining)
+	}
+
+	return nil
+}
+
+// shouldEvictPod decides whether a pod prevents a node from being considered drained.
+func shouldEvictPod(pod corev1.Pod) bool {
+	// Mirror pods are managed by the kubelet and cannot be evicted
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+
+	// Finished pods do not need to be evicted
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+
+	// DaemonSet pods would be rescheduled on the same node anyway
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+
+	return true
+}
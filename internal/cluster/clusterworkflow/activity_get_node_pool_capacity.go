@@ -0,0 +1,78 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/pkg/cadence"
+)
+
+const GetNodePoolCapacityActivityName = "get-node-pool-capacity"
+
+// GetNodePoolCapacityActivity returns the desired capacity of a node pool.
+type GetNodePoolCapacityActivity struct {
+	clusters          cluster.Store
+	awsSessionFactory AWSSessionFactory
+}
+
+// NewGetNodePoolCapacityActivity returns a new GetNodePoolCapacityActivity.
+func NewGetNodePoolCapacityActivity(clusters cluster.Store, awsSessionFactory AWSSessionFactory) GetNodePoolCapacityActivity {
+	return GetNodePoolCapacityActivity{
+		clusters:          clusters,
+		awsSessionFactory: awsSessionFactory,
+	}
+}
+
+type GetNodePoolCapacityActivityInput struct {
+	ClusterID    uint
+	NodePoolName string
+}
+
+type GetNodePoolCapacityActivityOutput struct {
+	DesiredCapacity int
+	MaxSize         int
+}
+
+func (a GetNodePoolCapacityActivity) Execute(ctx context.Context, input GetNodePoolCapacityActivityInput) (GetNodePoolCapacityActivityOutput, error) {
+	c, err := a.clusters.GetCluster(ctx, input.ClusterID)
+	if err != nil {
+		return GetNodePoolCapacityActivityOutput{}, cadence.WrapClientError(err)
+	}
+
+	stack, err := getAWSNodePoolStack(c, input.NodePoolName)
+	if err != nil {
+		return GetNodePoolCapacityActivityOutput{}, cadence.WrapClientError(err)
+	}
+
+	awsSession, err := a.awsSessionFactory.New(c.OrganizationID, c.SecretID.ResourceID, c.Location)
+	if err != nil {
+		return GetNodePoolCapacityActivityOutput{}, errors.WrapIf(err, "failed to create AWS session")
+	}
+
+	group, err := stack.getAutoScalingGroup(awsSession)
+	if err != nil {
+		return GetNodePoolCapacityActivityOutput{}, err
+	}
+
+	return GetNodePoolCapacityActivityOutput{
+		DesiredCapacity: int(aws.Int64Value(group.DesiredCapacity)),
+		MaxSize:         int(aws.Int64Value(group.MaxSize)),
+	}, nil
+}
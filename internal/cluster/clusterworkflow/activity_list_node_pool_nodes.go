@@ -0,0 +1,75 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterworkflow
+
+import (
+	"context"
+	"sort"
+
+	"emperror.dev/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/banzaicloud/pipeline/pkg/cadence"
+	"github.com/banzaicloud/pipeline/pkg/common"
+)
+
+const ListNodePoolNodesActivityName = "list-node-pool-nodes"
+
+type ListNodePoolNodesActivity struct {
+	clientFactory ClientFactory
+}
+
+// NewListNodePoolNodesActivity returns a new ListNodePoolNodesActivity.
+func NewListNodePoolNodesActivity(clientFactory ClientFactory) ListNodePoolNodesActivity {
+	return ListNodePoolNodesActivity{
+		clientFactory: clientFactory,
+	}
+}
+
+type ListNodePoolNodesActivityInput struct {
+	ClusterID    uint
+	NodePoolName string
+}
+
+type ListNodePoolNodesActivityOutput struct {
+	Nodes []string
+}
+
+func (a ListNodePoolNodesActivity) Execute(ctx context.Context, input ListNodePoolNodesActivityInput) (ListNodePoolNodesActivityOutput, error) {
+	client, err := a.clientFactory.FromClusterID(ctx, input.ClusterID)
+	if err != nil {
+		return ListNodePoolNodesActivityOutput{}, cadence.WrapClientError(err)
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+		LabelSelector: labels.Set{common.LabelKey: input.NodePoolName}.String(),
+	})
+	if err != nil {
+		return ListNodePoolNodesActivityOutput{}, errors.WrapIfWithDetails(err, "failed to list nodes", "nodePool", input.NodePoolName)
+	}
+
+	output := ListNodePoolNodesActivityOutput{
+		Nodes: make([]string, 0, len(nodes.Items)),
+	}
+
+	for _, node := range nodes.Items {
+		output.Nodes = append(output.Nodes, node.Name)
+	}
+
+	sort.Strings(output.Nodes)
+
+	return output, nil
+}
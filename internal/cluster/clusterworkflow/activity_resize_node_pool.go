@@ -0,0 +1,134 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterworkflow
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/pkg/cadence"
+)
+
+const ResizeNodePoolActivityName = "resize-node-pool"
+
+const resizeNodePoolWaitInterval = 20 * time.Second
+
+// ResizeNodePoolActivity sets the desired capacity of a node pool and waits for the new instances to start.
+type ResizeNodePoolActivity struct {
+	clusters          cluster.Store
+	awsSessionFactory AWSSessionFactory
+}
+
+// NewResizeNodePoolActivity returns a new ResizeNodePoolActivity.
+func NewResizeNodePoolActivity(clusters cluster.Store, awsSessionFactory AWSSessionFactory) ResizeNodePoolActivity {
+	return ResizeNodePoolActivity{
+		clusters:          clusters,
+		awsSessionFactory: awsSessionFactory,
+	}
+}
+
+type ResizeNodePoolActivityInput struct {
+	ClusterID       uint
+	NodePoolName    string
+	DesiredCapacity int
+
+	// MaxSize overrides the maximum size of the node pool when it is greater than zero.
+	MaxSize int
+}
+
+func (a ResizeNodePoolActivity) Execute(ctx context.Context, input ResizeNodePoolActivityInput) error {
+	c, err := a.clusters.GetCluster(ctx, input.ClusterID)
+	if err != nil {
+		return cadence.WrapClientError(err)
+	}
+
+	stack, err := getAWSNodePoolStack(c, input.NodePoolName)
+	if err != nil {
+		return cadence.WrapClientError(err)
+	}
+
+	awsSession, err := a.awsSessionFactory.New(c.OrganizationID, c.SecretID.ResourceID, c.Location)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create AWS session")
+	}
+
+	group, err := stack.getAutoScalingGroup(awsSession)
+	if err != nil {
+		return err
+	}
+
+	desiredCapacity := int64(input.DesiredCapacity)
+
+	maxSize := aws.Int64Value(group.MaxSize)
+	if input.MaxSize > 0 {
+		maxSize = int64(input.MaxSize)
+	}
+
+	// The surge may temporarily exceed the maximum size of the node pool
+	if maxSize < desiredCapacity {
+		maxSize = desiredCapacity
+	}
+
+	if aws.Int64Value(group.DesiredCapacity) != desiredCapacity || aws.Int64Value(group.MaxSize) != maxSize {
+		_, err := autoscaling.New(awsSession).UpdateAutoScalingGroup(&autoscaling.UpdateAutoScalingGroupInput{
+			AutoScalingGroupName: group.AutoScalingGroupName,
+			DesiredCapacity:      aws.Int64(desiredCapacity),
+			MaxSize:              aws.Int64(maxSize),
+		})
+		if err != nil {
+			return errors.WrapIfWithDetails(
+				err, "failed to update auto scaling group",
+				"autoScalingGroup", aws.StringValue(group.AutoScalingGroupName),
+				"desiredCapacity", input.DesiredCapacity,
+			)
+		}
+	}
+
+	ticker := time.NewTicker(resizeNodePoolWaitInterval)
+	defer ticker.Stop()
+
+	for {
+		group, err := stack.getAutoScalingGroup(awsSession)
+		if err != nil {
+			return err
+		}
+
+		var inService int
+		for _, instance := range group.Instances {
+			if aws.StringValue(instance.LifecycleState) == autoscaling.LifecycleStateInService &&
+				aws.StringValue(instance.HealthStatus) == "Healthy" {
+				inService++
+			}
+		}
+
+		if inService >= input.DesiredCapacity {
+			return nil
+		}
+
+		activity.RecordHeartbeat(ctx, inService)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
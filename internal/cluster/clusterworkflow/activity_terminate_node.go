@@ -0,0 +1,127 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterworkflow
+
+import (
+	"context"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/pkg/cadence"
+)
+
+const TerminateNodeActivityName = "terminate-node"
+
+// TerminateNodeActivity terminates the machine behind a node and decreases the size of its node pool.
+type TerminateNodeActivity struct {
+	clusters          cluster.Store
+	clientFactory     ClientFactory
+	awsSessionFactory AWSSessionFactory
+}
+
+// NewTerminateNodeActivity returns a new TerminateNodeActivity.
+func NewTerminateNodeActivity(
+	clusters cluster.Store,
+	clientFactory ClientFactory,
+	awsSessionFactory AWSSessionFactory,
+) TerminateNodeActivity {
+	return TerminateNodeActivity{
+		clusters:          clusters,
+		clientFactory:     clientFactory,
+		awsSessionFactory: awsSessionFactory,
+	}
+}
+
+type TerminateNodeActivityInput struct {
+	ClusterID    uint
+	NodePoolName string
+	NodeName     string
+}
+
+func (a TerminateNodeActivity) Execute(ctx context.Context, input TerminateNodeActivityInput) error {
+	c, err := a.clusters.GetCluster(ctx, input.ClusterID)
+	if err != nil {
+		return cadence.WrapClientError(err)
+	}
+
+	client, err := a.clientFactory.FromClusterID(ctx, input.ClusterID)
+	if err != nil {
+		return cadence.WrapClientError(err)
+	}
+
+	node, err := client.CoreV1().Nodes().Get(input.NodeName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		// Node is already gone
+		return nil
+	} else if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to get node", "node", input.NodeName)
+	}
+
+	if _, err := getAWSNodePoolStack(c, input.NodePoolName); err != nil {
+		return cadence.WrapClientError(err)
+	}
+
+	instanceID := getAWSInstanceID(node.Spec.ProviderID)
+	if instanceID == "" {
+		return cadence.WrapClientError(errors.NewWithDetails(
+			"cannot determine instance ID of node",
+			"node", input.NodeName,
+			"providerId", node.Spec.ProviderID,
+		))
+	}
+
+	awsSession, err := a.awsSessionFactory.New(c.OrganizationID, c.SecretID.ResourceID, c.Location)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create AWS session")
+	}
+
+	_, err = autoscaling.New(awsSession).TerminateInstanceInAutoScalingGroup(&autoscaling.TerminateInstanceInAutoScalingGroupInput{
+		InstanceId:                     aws.String(instanceID),
+		ShouldDecrementDesiredCapacity: aws.Bool(true),
+	})
+
+	// The instance is not part of the group anymore (eg. it was terminated by a previous attempt)
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && awsErr.Code() == "ValidationError" {
+		err = nil
+	}
+
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to terminate instance", "node", input.NodeName, "instanceId", instanceID)
+	}
+
+	err = client.CoreV1().Nodes().Delete(input.NodeName, &metav1.DeleteOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.WrapIfWithDetails(err, "failed to delete node", "node", input.NodeName)
+	}
+
+	return nil
+}
+
+// getAWSInstanceID extracts the EC2 instance ID from a node provider ID (aws:///<zone>/<instance ID>).
+func getAWSInstanceID(providerID string) string {
+	if !strings.HasPrefix(providerID, "aws://") {
+		return ""
+	}
+
+	return providerID[strings.LastIndex(providerID, "/")+1:]
+}
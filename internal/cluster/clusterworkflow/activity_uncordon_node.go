@@ -0,0 +1,73 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/pipeline/pkg/cadence"
+)
+
+const UncordonNodeActivityName = "uncordon-node"
+
+// UncordonNodeActivity marks a node schedulable again.
+// It is used to roll back the cordon of a drain that could not be completed.
+type UncordonNodeActivity struct {
+	clientFactory ClientFactory
+}
+
+// NewUncordonNodeActivity returns a new UncordonNodeActivity.
+func NewUncordonNodeActivity(clientFactory ClientFactory) UncordonNodeActivity {
+	return UncordonNodeActivity{
+		clientFactory: clientFactory,
+	}
+}
+
+type UncordonNodeActivityInput struct {
+	ClusterID uint
+	NodeName  string
+}
+
+func (a UncordonNodeActivity) Execute(ctx context.Context, input UncordonNodeActivityInput) error {
+	client, err := a.clientFactory.FromClusterID(ctx, input.ClusterID)
+	if err != nil {
+		return cadence.WrapClientError(err)
+	}
+
+	node, err := client.CoreV1().Nodes().Get(input.NodeName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		// Node is already gone
+		return nil
+	} else if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to get node", "node", input.NodeName)
+	}
+
+	if !node.Spec.Unschedulable {
+		return nil
+	}
+
+	node.Spec.Unschedulable = false
+
+	_, err = client.CoreV1().Nodes().Update(node)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to uncordon node", "node", input.NodeName)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterworkflow
+
+import (
+	"context"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/jinzhu/gorm"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksmodel"
+	eksworkflow "github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/workflow"
+	"github.com/banzaicloud/pipeline/internal/providers/pke"
+	"github.com/banzaicloud/pipeline/pkg/cadence"
+)
+
+const UpdateNodePoolImageActivityName = "update-node-pool-image"
+
+// UpdateNodePoolImageActivity changes the machine image new nodes of a node pool are started from.
+// Nodes that are already running are not affected.
+type UpdateNodePoolImageActivity struct {
+	clusters          cluster.Store
+	db                *gorm.DB
+	awsSessionFactory AWSSessionFactory
+}
+
+// NewUpdateNodePoolImageActivity returns a new UpdateNodePoolImageActivity.
+func NewUpdateNodePoolImageActivity(
+	clusters cluster.Store,
+	db *gorm.DB,
+	awsSessionFactory AWSSessionFactory,
+) UpdateNodePoolImageActivity {
+	return UpdateNodePoolImageActivity{
+		clusters:          clusters,
+		db:                db,
+		awsSessionFactory: awsSessionFactory,
+	}
+}
+
+type UpdateNodePoolImageActivityInput struct {
+	ClusterID    uint
+	NodePoolName string
+	Image        string
+}
+
+func (a UpdateNodePoolImageActivity) Execute(ctx context.Context, input UpdateNodePoolImageActivityInput) error {
+	c, err := a.clusters.GetCluster(ctx, input.ClusterID)
+	if err != nil {
+		return cadence.WrapClientError(err)
+	}
+
+	stack, err := getAWSNodePoolStack(c, input.NodePoolName)
+	if err != nil {
+		return cadence.WrapClientError(err)
+	}
+
+	awsSession, err := a.awsSessionFactory.New(c.OrganizationID, c.SecretID.ResourceID, c.Location)
+	if err != nil {
+		return errors.WrapIf(err, "failed to create AWS session")
+	}
+
+	cloudformationClient := cloudformation.New(awsSession)
+
+	describeStacksInput := &cloudformation.DescribeStacksInput{StackName: aws.String(stack.Name)}
+
+	describeStacksOutput, err := cloudformationClient.DescribeStacks(describeStacksInput)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to describe stack", "stackName", stack.Name)
+	}
+
+	if len(describeStacksOutput.Stacks) != 1 {
+		return errors.NewWithDetails("stack not found", "stackName", stack.Name)
+	}
+
+	var (
+		parameters   []*cloudformation.Parameter
+		imageChanged bool
+	)
+
+	for _, parameter := range describeStacksOutput.Stacks[0].Parameters {
+		if aws.StringValue(parameter.ParameterKey) == stack.ImageParameterKey {
+			imageChanged = aws.StringValue(parameter.ParameterValue) != input.Image
+
+			parameters = append(parameters, &cloudformation.Parameter{
+				ParameterKey:   parameter.ParameterKey,
+				ParameterValue: aws.String(input.Image),
+			})
+
+			continue
+		}
+
+		parameters = append(parameters, &cloudformation.Parameter{
+			ParameterKey:     parameter.ParameterKey,
+			UsePreviousValue: aws.Bool(true),
+		})
+	}
+
+	// Retried activities may find the stack already updated
+	if imageChanged {
+		_, err = cloudformationClient.UpdateStack(&cloudformation.UpdateStackInput{
+			StackName:           aws.String(stack.Name),
+			UsePreviousTemplate: aws.Bool(true),
+			Capabilities:        []*string{aws.String(cloudformation.CapabilityCapabilityIam)},
+			Parameters:          parameters,
+		})
+
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == "ValidationError" && strings.HasPrefix(awsErr.Message(), "No updates are to be performed.") {
+			err = nil
+		}
+
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to update stack", "stackName", stack.Name)
+		}
+	}
+
+	err = eksworkflow.WaitUntilStackUpdateCompleteWithContext(cloudformationClient, ctx, describeStacksInput)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "waiting for stack update to complete failed", "stackName", stack.Name)
+	}
+
+	return a.saveImage(c, input.NodePoolName, input.Image)
+}
+
+func (a UpdateNodePoolImageActivity) saveImage(c cluster.Cluster, nodePoolName string, image string) error {
+	switch c.Distribution {
+	case "eks":
+		var eksCluster eksmodel.EKSClusterModel
+
+		err := a.db.Where(eksmodel.EKSClusterModel{ClusterID: c.ID}).First(&eksCluster).Error
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to get cluster", "clusterId", c.ID)
+		}
+
+		err = a.db.
+			Model(eksmodel.AmazonNodePoolsModel{}).
+			Where(eksmodel.AmazonNodePoolsModel{ClusterID: eksCluster.ID, Name: nodePoolName}).
+			Update("node_image", image).Error
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to save node pool image", "clusterId", c.ID, "nodePool", nodePoolName)
+		}
+
+	case "pke":
+		var nodePool pke.NodePool
+
+		err := a.db.Where(pke.NodePool{ClusterID: c.ID, Name: nodePoolName}).First(&nodePool).Error
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to get node pool", "clusterId", c.ID, "nodePool", nodePoolName)
+		}
+
+		var providerConfig pke.NodePoolProviderConfigAmazon
+
+		err = mapstructure.Decode(nodePool.ProviderConfig, &providerConfig)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to decode node pool provider config", "clusterId", c.ID, "nodePool", nodePoolName)
+		}
+
+		providerConfig.AutoScalingGroup.Image = image
+
+		if nodePool.ProviderConfig == nil {
+			nodePool.ProviderConfig = pke.Config{}
+		}
+
+		nodePool.ProviderConfig["autoScalingGroup"] = providerConfig.AutoScalingGroup
+
+		err = a.db.Model(&nodePool).Update("provider_config", nodePool.ProviderConfig).Error
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to save node pool image", "clusterId", c.ID, "nodePool", nodePoolName)
+		}
+	}
+
+	return nil
+}
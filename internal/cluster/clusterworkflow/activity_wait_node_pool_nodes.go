@@ -0,0 +1,100 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterworkflow
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/banzaicloud/pipeline/pkg/cadence"
+	"github.com/banzaicloud/pipeline/pkg/common"
+)
+
+const WaitNodePoolNodesActivityName = "wait-node-pool-nodes"
+
+const waitNodePoolNodesInterval = 15 * time.Second
+
+type WaitNodePoolNodesActivity struct {
+	clientFactory ClientFactory
+}
+
+// NewWaitNodePoolNodesActivity returns a new WaitNodePoolNodesActivity.
+func NewWaitNodePoolNodesActivity(clientFactory ClientFactory) WaitNodePoolNodesActivity {
+	return WaitNodePoolNodesActivity{
+		clientFactory: clientFactory,
+	}
+}
+
+type WaitNodePoolNodesActivityInput struct {
+	ClusterID    uint
+	NodePoolName string
+
+	// ReadyCount is the number of ready nodes the node pool should have.
+	ReadyCount int
+}
+
+func (a WaitNodePoolNodesActivity) Execute(ctx context.Context, input WaitNodePoolNodesActivityInput) error {
+	client, err := a.clientFactory.FromClusterID(ctx, input.ClusterID)
+	if err != nil {
+		return cadence.WrapClientError(err)
+	}
+
+	ticker := time.NewTicker(waitNodePoolNodesInterval)
+	defer ticker.Stop()
+
+	for {
+		nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{
+			LabelSelector: labels.Set{common.LabelKey: input.NodePoolName}.String(),
+		})
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to list nodes", "nodePool", input.NodePoolName)
+		}
+
+		var ready int
+		for _, node := range nodes.Items {
+			if isNodeReady(node) && !node.Spec.Unschedulable {
+				ready++
+			}
+		}
+
+		if ready >= input.ReadyCount {
+			return nil
+		}
+
+		activity.RecordHeartbeat(ctx, ready)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func isNodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -15,9 +15,89 @@
 package clusterworkflow
 
 import (
+	"fmt"
+
+	"emperror.dev/errors"
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	eksworkflow "github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/workflow"
+	"github.com/banzaicloud/pipeline/pkg/providers"
 )
 
 type AWSSessionFactory interface {
 	New(organizationID uint, secretID string, region string) (*session.Session, error)
 }
+
+// awsNodePoolStack describes the CloudFormation stack behind a node pool of an AWS based cluster.
+type awsNodePoolStack struct {
+	// Name is the name of the CloudFormation stack.
+	Name string
+
+	// ImageParameterKey is the stack parameter holding the machine image of the nodes.
+	ImageParameterKey string
+
+	// AutoScalingGroupResourceID is the logical ID of the auto scaling group in the stack.
+	AutoScalingGroupResourceID string
+}
+
+func getAWSNodePoolStack(c cluster.Cluster, nodePoolName string) (awsNodePoolStack, error) {
+	switch {
+	case c.Cloud == providers.Amazon && c.Distribution == "eks":
+		return awsNodePoolStack{
+			Name:                       eksworkflow.GenerateNodePoolStackName(c.Name, nodePoolName),
+			ImageParameterKey:          "NodeImageId",
+			AutoScalingGroupResourceID: "NodeGroup",
+		}, nil
+
+	case c.Cloud == providers.Amazon && c.Distribution == "pke":
+		return awsNodePoolStack{
+			Name:                       fmt.Sprintf("pke-pool-%s-worker-%s", c.Name, nodePoolName),
+			ImageParameterKey:          "ImageId",
+			AutoScalingGroupResourceID: "AutoScalingGroup",
+		}, nil
+	}
+
+	return awsNodePoolStack{}, errors.WithStack(cluster.NotSupportedDistributionError{
+		ID:           c.ID,
+		Cloud:        c.Cloud,
+		Distribution: c.Distribution,
+
+		Message: "node pool replacement is not supported for this distribution yet",
+	})
+}
+
+// getAutoScalingGroup returns the auto scaling group created by a node pool stack.
+func (s awsNodePoolStack) getAutoScalingGroup(awsSession *session.Session) (*autoscaling.Group, error) {
+	resource, err := cloudformation.New(awsSession).DescribeStackResource(&cloudformation.DescribeStackResourceInput{
+		StackName:         aws.String(s.Name),
+		LogicalResourceId: aws.String(s.AutoScalingGroupResourceID),
+	})
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to get stack resource", "stackName", s.Name)
+	}
+
+	if resource.StackResourceDetail == nil || resource.StackResourceDetail.PhysicalResourceId == nil {
+		return nil, errors.NewWithDetails("auto scaling group not found in stack", "stackName", s.Name)
+	}
+
+	output, err := autoscaling.New(awsSession).DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{resource.StackResourceDetail.PhysicalResourceId},
+	})
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to describe auto scaling group", "stackName", s.Name)
+	}
+
+	if len(output.AutoScalingGroups) != 1 {
+		return nil, errors.NewWithDetails(
+			"auto scaling group not found",
+			"stackName", s.Name,
+			"autoScalingGroup", aws.StringValue(resource.StackResourceDetail.PhysicalResourceId),
+		)
+	}
+
+	return output.AutoScalingGroups[0], nil
+}
@@ -18,8 +18,15 @@ import (
 	"context"
 
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 )
 
+// ClientFactory returns a Kubernetes client.
+type ClientFactory interface {
+	// FromClusterID creates a Kubernetes client for a cluster from a cluster ID.
+	FromClusterID(ctx context.Context, clusterID uint) (kubernetes.Interface, error)
+}
+
 // DynamicClientFactory returns a dynamic Kubernetes client.
 type DynamicClientFactory interface {
 	// FromClusterID creates a dynamic Kubernetes client for a cluster from a cluster ID.
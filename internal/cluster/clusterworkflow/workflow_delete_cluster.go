@@ -25,8 +25,9 @@ import (
 const DeleteClusterWorkflowName = "delete-cluster"
 
 type DeleteClusterWorkflowInput struct {
-	ClusterID uint
-	Force     bool
+	ClusterID     uint
+	Force         bool
+	KeepResources bool
 }
 
 func DeleteClusterWorkflow(ctx workflow.Context, input DeleteClusterWorkflowInput) error {
@@ -53,8 +54,9 @@ func DeleteClusterWorkflow(ctx workflow.Context, input DeleteClusterWorkflowInpu
 		})
 
 		activityInput := DeleteClusterActivityInput{
-			ClusterID: input.ClusterID,
-			Force:     input.Force,
+			ClusterID:     input.ClusterID,
+			Force:         input.Force,
+			KeepResources: input.KeepResources,
 		}
 		err := workflow.ExecuteActivity(ctx, DeleteClusterActivityName, activityInput).Get(ctx, nil)
 		if err != nil {
@@ -0,0 +1,311 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterworkflow
+
+import (
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	_cadence "github.com/banzaicloud/pipeline/pkg/cadence"
+)
+
+const ReplaceNodePoolWorkflowName = "replace-node-pool"
+
+type ReplaceNodePoolWorkflowInput struct {
+	ClusterID    uint
+	NodePoolName string
+	Image        string
+	MaxSurge     int
+}
+
+// ReplaceNodePoolWorkflow replaces every node of a node pool without downtime:
+// it starts new nodes (from a new image if one is given), drains the old ones
+// respecting pod disruption budgets, then removes them, one batch at a time.
+func ReplaceNodePoolWorkflow(ctx workflow.Context, input ReplaceNodePoolWorkflowInput) error {
+	ao := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		WaitForCancellation:    true,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          15 * time.Second,
+			BackoffCoefficient:       1.0,
+			MaximumAttempts:          30,
+			NonRetriableErrorReasons: []string{_cadence.ClientErrorReason, "cadenceInternal:Panic"},
+		},
+	}
+	_ctx := ctx
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	// Long running activities waiting for cloud resources or the Kubernetes API report their progress through heartbeats.
+	longCtx := workflow.WithActivityOptions(_ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    time.Hour,
+		HeartbeatTimeout:       time.Minute,
+		WaitForCancellation:    true,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          30 * time.Second,
+			BackoffCoefficient:       1.0,
+			MaximumAttempts:          30,
+			NonRetriableErrorReasons: []string{_cadence.ClientErrorReason, "cadenceInternal:Panic"},
+		},
+	})
+
+	// Draining is retried until pod disruption budgets allow evicting every pod from the node.
+	drainCtx := workflow.WithActivityOptions(_ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		HeartbeatTimeout:       time.Minute,
+		WaitForCancellation:    true,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          30 * time.Second,
+			BackoffCoefficient:       1.0,
+			ExpirationInterval:       6 * time.Hour,
+			NonRetriableErrorReasons: []string{_cadence.ClientErrorReason, "cadenceInternal:Panic"},
+		},
+	})
+
+	var oldNodes []string
+	{
+		input := ListNodePoolNodesActivityInput{
+			ClusterID:    input.ClusterID,
+			NodePoolName: input.NodePoolName,
+		}
+
+		var output ListNodePoolNodesActivityOutput
+
+		err := workflow.ExecuteActivity(ctx, ListNodePoolNodesActivityName, input).Get(ctx, &output)
+		if err != nil {
+			_ = setClusterStatus(_ctx, input.ClusterID, cluster.Warning, err.Error())
+
+			return err
+		}
+
+		oldNodes = output.Nodes
+	}
+
+	if input.Image != "" {
+		input := UpdateNodePoolImageActivityInput{
+			ClusterID:    input.ClusterID,
+			NodePoolName: input.NodePoolName,
+			Image:        input.Image,
+		}
+
+		err := workflow.ExecuteActivity(longCtx, UpdateNodePoolImageActivityName, input).Get(ctx, nil)
+		if err != nil {
+			_ = setClusterStatus(_ctx, input.ClusterID, cluster.Warning, err.Error())
+
+			return err
+		}
+	}
+
+	// The original maximum size is restored after the last batch (surges may temporarily exceed it)
+	var capacity GetNodePoolCapacityActivityOutput
+	{
+		input := GetNodePoolCapacityActivityInput{
+			ClusterID:    input.ClusterID,
+			NodePoolName: input.NodePoolName,
+		}
+
+		err := workflow.ExecuteActivity(ctx, GetNodePoolCapacityActivityName, input).Get(ctx, &capacity)
+		if err != nil {
+			_ = setClusterStatus(_ctx, input.ClusterID, cluster.Warning, err.Error())
+
+			return err
+		}
+	}
+
+	maxSurge := input.MaxSurge
+	if maxSurge < 1 {
+		maxSurge = 1
+	}
+
+	for i := 0; i < len(oldNodes); i += maxSurge {
+		end := i + maxSurge
+		if end > len(oldNodes) {
+			end = len(oldNodes)
+		}
+
+		batch := oldNodes[i:end]
+
+		err := replaceNodePoolBatch(ctx, longCtx, drainCtx, input.ClusterID, input.NodePoolName, batch)
+		if err != nil {
+			// Nodes of the batch that could not be replaced should keep running workloads
+			uncordonNodes(_ctx, input.ClusterID, batch)
+
+			_ = setClusterStatus(_ctx, input.ClusterID, cluster.Warning, err.Error())
+
+			return err
+		}
+	}
+
+	// Restore the original size limits of the node pool
+	{
+		var current GetNodePoolCapacityActivityOutput
+		{
+			input := GetNodePoolCapacityActivityInput{
+				ClusterID:    input.ClusterID,
+				NodePoolName: input.NodePoolName,
+			}
+
+			err := workflow.ExecuteActivity(ctx, GetNodePoolCapacityActivityName, input).Get(ctx, &current)
+			if err != nil {
+				_ = setClusterStatus(_ctx, input.ClusterID, cluster.Warning, err.Error())
+
+				return err
+			}
+		}
+
+		input := ResizeNodePoolActivityInput{
+			ClusterID:       input.ClusterID,
+			NodePoolName:    input.NodePoolName,
+			DesiredCapacity: current.DesiredCapacity,
+			MaxSize:         capacity.MaxSize,
+		}
+
+		err := workflow.ExecuteActivity(longCtx, ResizeNodePoolActivityName, input).Get(ctx, nil)
+		if err != nil {
+			_ = setClusterStatus(_ctx, input.ClusterID, cluster.Warning, err.Error())
+
+			return err
+		}
+	}
+
+	{
+		input := SetClusterStatusActivityInput{
+			ClusterID:     input.ClusterID,
+			Status:        cluster.Running,
+			StatusMessage: cluster.RunningMessage,
+		}
+
+		err := workflow.ExecuteActivity(ctx, SetClusterStatusActivityName, input).Get(ctx, nil)
+		if err != nil {
+			_ = setClusterStatus(_ctx, input.ClusterID, cluster.Warning, err.Error())
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replaceNodePoolBatch starts a new node for every node of a batch, then drains and terminates the old ones.
+func replaceNodePoolBatch(
+	ctx workflow.Context,
+	longCtx workflow.Context,
+	drainCtx workflow.Context,
+	clusterID uint,
+	nodePoolName string,
+	batch []string,
+) error {
+	// The capacity is read for every batch: the node pool may have been resized (eg. by the autoscaler) in the meantime
+	var capacity GetNodePoolCapacityActivityOutput
+	{
+		input := GetNodePoolCapacityActivityInput{
+			ClusterID:    clusterID,
+			NodePoolName: nodePoolName,
+		}
+
+		err := workflow.ExecuteActivity(ctx, GetNodePoolCapacityActivityName, input).Get(ctx, &capacity)
+		if err != nil {
+			return err
+		}
+	}
+
+	desiredCapacity := capacity.DesiredCapacity + len(batch)
+
+	{
+		input := ResizeNodePoolActivityInput{
+			ClusterID:       clusterID,
+			NodePoolName:    nodePoolName,
+			DesiredCapacity: desiredCapacity,
+		}
+
+		err := workflow.ExecuteActivity(longCtx, ResizeNodePoolActivityName, input).Get(ctx, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	{
+		input := WaitNodePoolNodesActivityInput{
+			ClusterID:    clusterID,
+			NodePoolName: nodePoolName,
+			ReadyCount:   desiredCapacity,
+		}
+
+		err := workflow.ExecuteActivity(longCtx, WaitNodePoolNodesActivityName, input).Get(ctx, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, node := range batch {
+		input := DrainNodeActivityInput{
+			ClusterID: clusterID,
+			NodeName:  node,
+		}
+
+		err := workflow.ExecuteActivity(drainCtx, DrainNodeActivityName, input).Get(ctx, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, node := range batch {
+		input := TerminateNodeActivityInput{
+			ClusterID:    clusterID,
+			NodePoolName: nodePoolName,
+			NodeName:     node,
+		}
+
+		err := workflow.ExecuteActivity(ctx, TerminateNodeActivityName, input).Get(ctx, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uncordonNodes makes the nodes of a failed batch schedulable again (nodes already terminated are skipped).
+// It runs in a disconnected context, so that nodes are uncordoned even if the workflow is canceled.
+func uncordonNodes(ctx workflow.Context, clusterID uint, nodes []string) {
+	ctx, _ = workflow.NewDisconnectedContext(ctx)
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          15 * time.Second,
+			BackoffCoefficient:       1.0,
+			MaximumAttempts:          10,
+			NonRetriableErrorReasons: []string{_cadence.ClientErrorReason, "cadenceInternal:Panic"},
+		},
+	})
+
+	for _, node := range nodes {
+		input := UncordonNodeActivityInput{
+			ClusterID: clusterID,
+			NodeName:  node,
+		}
+
+		err := workflow.ExecuteActivity(ctx, UncordonNodeActivityName, input).Get(ctx, nil)
+		if err != nil {
+			workflow.GetLogger(ctx).Sugar().Warnw("failed to uncordon node", "node", node, "error", err.Error())
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clusterworkflow
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/pkg/cadence"
+)
+
+func testListNodePoolNodesActivityExecute(_ context.Context, _ ListNodePoolNodesActivityInput) (ListNodePoolNodesActivityOutput, error) {
+	return ListNodePoolNodesActivityOutput{}, nil
+}
+
+func testGetNodePoolCapacityActivityExecute(_ context.Context, _ GetNodePoolCapacityActivityInput) (GetNodePoolCapacityActivityOutput, error) {
+	return GetNodePoolCapacityActivityOutput{}, nil
+}
+
+func testResizeNodePoolActivityExecute(_ context.Context, _ ResizeNodePoolActivityInput) error {
+	return nil
+}
+
+func testWaitNodePoolNodesActivityExecute(_ context.Context, _ WaitNodePoolNodesActivityInput) error {
+	return nil
+}
+
+func testDrainNodeActivityExecute(_ context.Context, _ DrainNodeActivityInput) error {
+	return nil
+}
+
+func testTerminateNodeActivityExecute(_ context.Context, _ TerminateNodeActivityInput) error {
+	return nil
+}
+
+func testUncordonNodeActivityExecute(_ context.Context, _ UncordonNodeActivityInput) error {
+	return nil
+}
+
+func testSetClusterStatusActivityExecute(_ context.Context, _ SetClusterStatusActivityInput) error {
+	return nil
+}
+
+// nolint: gochecknoinits
+func init() {
+	workflow.RegisterWithOptions(ReplaceNodePoolWorkflow, workflow.RegisterOptions{Name: ReplaceNodePoolWorkflowName})
+
+	activity.RegisterWithOptions(testListNodePoolNodesActivityExecute, activity.RegisterOptions{Name: ListNodePoolNodesActivityName})
+	activity.RegisterWithOptions(testGetNodePoolCapacityActivityExecute, activity.RegisterOptions{Name: GetNodePoolCapacityActivityName})
+	activity.RegisterWithOptions(testResizeNodePoolActivityExecute, activity.RegisterOptions{Name: ResizeNodePoolActivityName})
+	activity.RegisterWithOptions(testWaitNodePoolNodesActivityExecute, activity.RegisterOptions{Name: WaitNodePoolNodesActivityName})
+	activity.RegisterWithOptions(testDrainNodeActivityExecute, activity.RegisterOptions{Name: DrainNodeActivityName})
+	activity.RegisterWithOptions(testTerminateNodeActivityExecute, activity.RegisterOptions{Name: TerminateNodeActivityName})
+	activity.RegisterWithOptions(testUncordonNodeActivityExecute, activity.RegisterOptions{Name: UncordonNodeActivityName})
+	activity.RegisterWithOptions(testSetClusterStatusActivityExecute, activity.RegisterOptions{Name: SetClusterStatusActivityName})
+}
+
+func TestReplaceNodePoolWorkflow(t *testing.T) {
+	const clusterID = uint(1)
+	const nodePoolName = "pool0"
+
+	nodes := []string{"node1", "node2", "node3", "node4", "node5"}
+
+	t.Run("Success", func(t *testing.T) {
+		var testSuite testsuite.WorkflowTestSuite
+		env := testSuite.NewTestWorkflowEnvironment()
+
+		env.OnActivity(ListNodePoolNodesActivityName, mock.Anything, ListNodePoolNodesActivityInput{
+			ClusterID:    clusterID,
+			NodePoolName: nodePoolName,
+		}).Return(ListNodePoolNodesActivityOutput{Nodes: nodes}, nil).Once()
+
+		env.OnActivity(GetNodePoolCapacityActivityName, mock.Anything, GetNodePoolCapacityActivityInput{
+			ClusterID:    clusterID,
+			NodePoolName: nodePoolName,
+		}).Return(GetNodePoolCapacityActivityOutput{DesiredCapacity: 5, MaxSize: 5}, nil)
+
+		// batches of two, two and one nodes
+		env.OnActivity(ResizeNodePoolActivityName, mock.Anything, ResizeNodePoolActivityInput{
+			ClusterID:       clusterID,
+			NodePoolName:    nodePoolName,
+			DesiredCapacity: 7,
+		}).Return(nil).Twice()
+		env.OnActivity(WaitNodePoolNodesActivityName, mock.Anything, WaitNodePoolNodesActivityInput{
+			ClusterID:    clusterID,
+			NodePoolName: nodePoolName,
+			ReadyCount:   7,
+		}).Return(nil).Twice()
+		env.OnActivity(ResizeNodePoolActivityName, mock.Anything, ResizeNodePoolActivityInput{
+			ClusterID:       clusterID,
+			NodePoolName:    nodePoolName,
+			DesiredCapacity: 6,
+		}).Return(nil).Once()
+		env.OnActivity(WaitNodePoolNodesActivityName, mock.Anything, WaitNodePoolNodesActivityInput{
+			ClusterID:    clusterID,
+			NodePoolName: nodePoolName,
+			ReadyCount:   6,
+		}).Return(nil).Once()
+
+		for _, node := range nodes {
+			env.OnActivity(DrainNodeActivityName, mock.Anything, DrainNodeActivityInput{
+				ClusterID: clusterID,
+				NodeName:  node,
+			}).Return(nil).Once()
+			env.OnActivity(TerminateNodeActivityName, mock.Anything, TerminateNodeActivityInput{
+				ClusterID:    clusterID,
+				NodePoolName: nodePoolName,
+				NodeName:     node,
+			}).Return(nil).Once()
+		}
+
+		// the original maximum size is restored after the last batch
+		env.OnActivity(ResizeNodePoolActivityName, mock.Anything, ResizeNodePoolActivityInput{
+			ClusterID:       clusterID,
+			NodePoolName:    nodePoolName,
+			DesiredCapacity: 5,
+			MaxSize:         5,
+		}).Return(nil).Once()
+
+		env.OnActivity(SetClusterStatusActivityName, mock.Anything, SetClusterStatusActivityInput{
+			ClusterID:     clusterID,
+			Status:        cluster.Running,
+			StatusMessage: cluster.RunningMessage,
+		}).Return(nil).Once()
+
+		env.ExecuteWorkflow(ReplaceNodePoolWorkflowName, ReplaceNodePoolWorkflowInput{
+			ClusterID:    clusterID,
+			NodePoolName: nodePoolName,
+			MaxSurge:     2,
+		})
+
+		require.True(t, env.IsWorkflowCompleted())
+		require.NoError(t, env.GetWorkflowError())
+
+		env.AssertExpectations(t)
+		env.AssertNotCalled(t, UncordonNodeActivityName, mock.Anything, mock.Anything)
+	})
+
+	t.Run("DrainFailure", func(t *testing.T) {
+		var testSuite testsuite.WorkflowTestSuite
+		env := testSuite.NewTestWorkflowEnvironment()
+
+		env.OnActivity(ListNodePoolNodesActivityName, mock.Anything, mock.Anything).
+			Return(ListNodePoolNodesActivityOutput{Nodes: nodes}, nil)
+		env.OnActivity(GetNodePoolCapacityActivityName, mock.Anything, mock.Anything).
+			Return(GetNodePoolCapacityActivityOutput{DesiredCapacity: 5, MaxSize: 5}, nil)
+		env.OnActivity(ResizeNodePoolActivityName, mock.Anything, mock.Anything).Return(nil)
+		env.OnActivity(WaitNodePoolNodesActivityName, mock.Anything, mock.Anything).Return(nil)
+		env.OnActivity(TerminateNodeActivityName, mock.Anything, mock.Anything).Return(nil)
+
+		env.OnActivity(DrainNodeActivityName, mock.Anything, DrainNodeActivityInput{ClusterID: clusterID, NodeName: "node1"}).Return(nil)
+		env.OnActivity(DrainNodeActivityName, mock.Anything, DrainNodeActivityInput{ClusterID: clusterID, NodeName: "node2"}).Return(nil)
+		env.OnActivity(DrainNodeActivityName, mock.Anything, DrainNodeActivityInput{ClusterID: clusterID, NodeName: "node3"}).
+			Return(cadence.NewClientError(errors.New("pod disruption budget violated")))
+
+		// every node of the failed batch is uncordoned
+		env.OnActivity(UncordonNodeActivityName, mock.Anything, UncordonNodeActivityInput{ClusterID: clusterID, NodeName: "node3"}).Return(nil).Once()
+		env.OnActivity(UncordonNodeActivityName, mock.Anything, UncordonNodeActivityInput{ClusterID: clusterID, NodeName: "node4"}).Return(nil).Once()
+
+		env.OnActivity(SetClusterStatusActivityName, mock.Anything, mock.MatchedBy(func(input SetClusterStatusActivityInput) bool {
+			return input.Status == cluster.Warning
+		})).Return(nil).Once()
+
+		env.ExecuteWorkflow(ReplaceNodePoolWorkflowName, ReplaceNodePoolWorkflowInput{
+			ClusterID:    clusterID,
+			NodePoolName: nodePoolName,
+			MaxSurge:     2,
+		})
+
+		require.True(t, env.IsWorkflowCompleted())
+		assert.Error(t, env.GetWorkflowError())
+
+		env.AssertExpectations(t)
+		env.AssertNotCalled(t, DrainNodeActivityName, mock.Anything, DrainNodeActivityInput{ClusterID: clusterID, NodeName: "node4"})
+		env.AssertNotCalled(t, UncordonNodeActivityName, mock.Anything, UncordonNodeActivityInput{ClusterID: clusterID, NodeName: "node1"})
+		env.AssertNotCalled(t, ResizeNodePoolActivityName, mock.Anything, ResizeNodePoolActivityInput{
+			ClusterID:       clusterID,
+			NodePoolName:    nodePoolName,
+			DesiredCapacity: 5,
+			MaxSize:         5,
+		})
+	})
+}
@@ -0,0 +1,278 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"context"
+	"math"
+
+	"emperror.dev/errors"
+)
+
+// HoursPerMonth is the average number of hours in a month used for monthly costs.
+const HoursPerMonth = 730
+
+// Currency is the currency of every cost (cloud provider prices are reported in USD).
+const Currency = "USD"
+
+// ClusterSpec describes the infrastructure of a (planned or existing) cluster.
+type ClusterSpec struct {
+	Cloud        string
+	Distribution string
+
+	// Location is the region (or zone) of the cluster.
+	Location string
+
+	NodePools []NodePoolSpec
+}
+
+// NodePoolSpec describes the nodes of a node pool.
+type NodePoolSpec struct {
+	Name         string
+	InstanceType string
+
+	// Master indicates that the node pool runs the control plane (self-managed distributions).
+	Master bool
+
+	Count       int
+	Autoscaling bool
+	MinCount    int
+	MaxCount    int
+
+	// Spot indicates that the nodes are spot (or preemptible) instances.
+	Spot bool
+}
+
+// Cost is the cost of running something for an hour and for a month.
+type Cost struct {
+	Hourly  float64 `json:"hourly"`
+	Monthly float64 `json:"monthly"`
+}
+
+func hourlyCost(hourly float64) Cost {
+	return Cost{
+		Hourly:  round(hourly),
+		Monthly: round(hourly * HoursPerMonth),
+	}
+}
+
+func (c Cost) add(o Cost) Cost {
+	return Cost{
+		Hourly:  round(c.Hourly + o.Hourly),
+		Monthly: round(c.Monthly + o.Monthly),
+	}
+}
+
+// round rounds a cost to a thousandth of a cent to hide floating point noise.
+func round(v float64) float64 {
+	return math.Round(v*100000) / 100000
+}
+
+// NodePoolCost is the cost of a node pool.
+type NodePoolCost struct {
+	Name         string `json:"name"`
+	InstanceType string `json:"instanceType,omitempty"`
+	ControlPlane bool   `json:"controlPlane,omitempty"`
+	Spot         bool   `json:"spot"`
+
+	Count    int `json:"count"`
+	MinCount int `json:"minCount"`
+	MaxCount int `json:"maxCount"`
+
+	// NodePrice is the hourly price of a single node.
+	NodePrice float64 `json:"nodePrice"`
+
+	Cost    Cost `json:"cost"`
+	MinCost Cost `json:"minCost"`
+	MaxCost Cost `json:"maxCost"`
+
+	// Priced is false if no price is available for the node pool (eg. on-premise clusters or unknown instance types).
+	Priced bool   `json:"priced"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ControlPlaneCost is the cost of the control plane of a cluster.
+type ControlPlaneCost struct {
+	// Fee is charged by the cloud provider for managed control planes.
+	Fee Cost `json:"fee"`
+
+	// Nodes is the cost of the control plane node pools of self-managed distributions.
+	Nodes Cost `json:"nodes"`
+
+	Cost Cost `json:"cost"`
+}
+
+// Estimate is the cost estimate of a cluster.
+type Estimate struct {
+	Currency string `json:"currency"`
+
+	ControlPlane ControlPlaneCost `json:"controlPlane"`
+	NodePools    []NodePoolCost   `json:"nodePools"`
+
+	// OnDemand and Spot break the total cost of the nodes down by purchase option.
+	OnDemand Cost `json:"onDemand"`
+	Spot     Cost `json:"spot"`
+
+	// Cost is the total cost at the current (or requested) node counts,
+	// MinCost and MaxCost are the bounds of the cost when autoscaling is enabled.
+	Cost    Cost `json:"cost"`
+	MinCost Cost `json:"minCost"`
+	MaxCost Cost `json:"maxCost"`
+
+	// Complete is false if some of the node pools could not be priced.
+	Complete bool `json:"complete"`
+}
+
+// Price is the hourly price of an instance type.
+type Price struct {
+	OnDemand float64
+
+	// Spot is the average spot price across the availability zones (zero if spot instances are not available).
+	Spot float64
+}
+
+// +testify:mock:testOnly=true
+
+// PriceSource returns instance type prices.
+type PriceSource interface {
+	// GetPrice returns the price of an instance type in a location.
+	GetPrice(ctx context.Context, cloud string, distribution string, location string, instanceType string) (Price, error)
+}
+
+// Config contains the configuration of cost estimation.
+type Config struct {
+	// ControlPlaneFees are the hourly fees of managed control planes by distribution.
+	ControlPlaneFees map[string]float64
+}
+
+// Validate validates the configuration.
+func (c Config) Validate() error {
+	var errs error
+
+	for distribution, fee := range c.ControlPlaneFees {
+		if fee < 0 {
+			errs = errors.Append(errs, errors.Errorf("control plane fee of %q clusters cannot be negative", distribution))
+		}
+	}
+
+	return errs
+}
+
+// Estimator estimates the cost of clusters.
+type Estimator struct {
+	config Config
+	prices PriceSource
+}
+
+// NewEstimator returns a new Estimator.
+func NewEstimator(config Config, prices PriceSource) Estimator {
+	return Estimator{
+		config: config,
+		prices: prices,
+	}
+}
+
+// Estimate estimates the cost of a cluster.
+// Node pools that cannot be priced are reported (and excluded from the totals) instead of failing the whole estimate.
+func (e Estimator) Estimate(ctx context.Context, spec ClusterSpec) Estimate {
+	estimate := Estimate{
+		Currency:  Currency,
+		NodePools: make([]NodePoolCost, 0, len(spec.NodePools)),
+		Complete:  true,
+	}
+
+	estimate.ControlPlane.Fee = hourlyCost(e.config.ControlPlaneFees[spec.Distribution])
+
+	for _, nodePool := range spec.NodePools {
+		nodePoolCost := e.estimateNodePool(ctx, spec, nodePool)
+		estimate.NodePools = append(estimate.NodePools, nodePoolCost)
+
+		if !nodePoolCost.Priced {
+			estimate.Complete = false
+
+			continue
+		}
+
+		if nodePool.Master {
+			estimate.ControlPlane.Nodes = estimate.ControlPlane.Nodes.add(nodePoolCost.Cost)
+		}
+
+		if nodePool.Spot {
+			estimate.Spot = estimate.Spot.add(nodePoolCost.Cost)
+		} else {
+			estimate.OnDemand = estimate.OnDemand.add(nodePoolCost.Cost)
+		}
+
+		estimate.MinCost = estimate.MinCost.add(nodePoolCost.MinCost)
+		estimate.MaxCost = estimate.MaxCost.add(nodePoolCost.MaxCost)
+	}
+
+	estimate.ControlPlane.Cost = estimate.ControlPlane.Fee.add(estimate.ControlPlane.Nodes)
+
+	estimate.Cost = estimate.OnDemand.add(estimate.Spot).add(estimate.ControlPlane.Fee)
+	estimate.MinCost = estimate.MinCost.add(estimate.ControlPlane.Fee)
+	estimate.MaxCost = estimate.MaxCost.add(estimate.ControlPlane.Fee)
+
+	return estimate
+}
+
+func (e Estimator) estimateNodePool(ctx context.Context, spec ClusterSpec, nodePool NodePoolSpec) NodePoolCost {
+	nodePoolCost := NodePoolCost{
+		Name:         nodePool.Name,
+		InstanceType: nodePool.InstanceType,
+		ControlPlane: nodePool.Master,
+		Spot:         nodePool.Spot,
+		Count:        nodePool.Count,
+		MinCount:     nodePool.Count,
+		MaxCount:     nodePool.Count,
+	}
+
+	if nodePool.Autoscaling {
+		nodePoolCost.MinCount = nodePool.MinCount
+		nodePoolCost.MaxCount = nodePool.MaxCount
+	}
+
+	if nodePool.InstanceType == "" {
+		nodePoolCost.Reason = "node pool has no instance type"
+
+		return nodePoolCost
+	}
+
+	price, err := e.prices.GetPrice(ctx, spec.Cloud, spec.Distribution, spec.Location, nodePool.InstanceType)
+	if err != nil {
+		nodePoolCost.Reason = err.Error()
+
+		return nodePoolCost
+	}
+
+	nodePrice := price.OnDemand
+	if nodePool.Spot {
+		if price.Spot == 0 {
+			nodePoolCost.Reason = "no spot price available for the instance type"
+
+			return nodePoolCost
+		}
+
+		nodePrice = price.Spot
+	}
+
+	nodePoolCost.Priced = true
+	nodePoolCost.NodePrice = round(nodePrice)
+	nodePoolCost.Cost = hourlyCost(nodePrice * float64(nodePoolCost.Count))
+	nodePoolCost.MinCost = hourlyCost(nodePrice * float64(nodePoolCost.MinCount))
+	nodePoolCost.MaxCost = hourlyCost(nodePrice * float64(nodePoolCost.MaxCount))
+
+	return nodePoolCost
+}
@@ -0,0 +1,139 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimator_Estimate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Managed", func(t *testing.T) {
+		prices := new(MockPriceSource)
+		prices.On("GetPrice", ctx, "amazon", "eks", "eu-west-1", "m5.large").Return(Price{OnDemand: 0.1, Spot: 0.04}, nil)
+
+		estimator := NewEstimator(Config{ControlPlaneFees: map[string]float64{"eks": 0.1}}, prices)
+
+		estimate := estimator.Estimate(ctx, ClusterSpec{
+			Cloud:        "amazon",
+			Distribution: "eks",
+			Location:     "eu-west-1",
+			NodePools: []NodePoolSpec{
+				{Name: "pool1", InstanceType: "m5.large", Count: 2},
+				{Name: "pool2", InstanceType: "m5.large", Count: 3, Autoscaling: true, MinCount: 1, MaxCount: 5, Spot: true},
+			},
+		})
+
+		assert.Equal(t, Estimate{
+			Currency: Currency,
+			ControlPlane: ControlPlaneCost{
+				Fee:  Cost{Hourly: 0.1, Monthly: 73},
+				Cost: Cost{Hourly: 0.1, Monthly: 73},
+			},
+			NodePools: []NodePoolCost{
+				{
+					Name:         "pool1",
+					InstanceType: "m5.large",
+					Count:        2,
+					MinCount:     2,
+					MaxCount:     2,
+					NodePrice:    0.1,
+					Cost:         Cost{Hourly: 0.2, Monthly: 146},
+					MinCost:      Cost{Hourly: 0.2, Monthly: 146},
+					MaxCost:      Cost{Hourly: 0.2, Monthly: 146},
+					Priced:       true,
+				},
+				{
+					Name:         "pool2",
+					InstanceType: "m5.large",
+					Spot:         true,
+					Count:        3,
+					MinCount:     1,
+					MaxCount:     5,
+					NodePrice:    0.04,
+					Cost:         Cost{Hourly: 0.12, Monthly: 87.6},
+					MinCost:      Cost{Hourly: 0.04, Monthly: 29.2},
+					MaxCost:      Cost{Hourly: 0.2, Monthly: 146},
+					Priced:       true,
+				},
+			},
+			OnDemand: Cost{Hourly: 0.2, Monthly: 146},
+			Spot:     Cost{Hourly: 0.12, Monthly: 87.6},
+			Cost:     Cost{Hourly: 0.42, Monthly: 306.6},
+			MinCost:  Cost{Hourly: 0.34, Monthly: 248.2},
+			MaxCost:  Cost{Hourly: 0.5, Monthly: 365},
+			Complete: true,
+		}, estimate)
+
+		prices.AssertExpectations(t)
+	})
+
+	t.Run("SelfManaged", func(t *testing.T) {
+		prices := new(MockPriceSource)
+		prices.On("GetPrice", ctx, "azure", "pke", "westeurope", "Standard_B2s").Return(Price{OnDemand: 0.05}, nil)
+		prices.On("GetPrice", ctx, "azure", "pke", "westeurope", "Standard_D4s_v3").Return(Price{OnDemand: 0.2}, nil)
+
+		estimator := NewEstimator(Config{ControlPlaneFees: map[string]float64{"eks": 0.1}}, prices)
+
+		estimate := estimator.Estimate(ctx, ClusterSpec{
+			Cloud:        "azure",
+			Distribution: "pke",
+			Location:     "westeurope",
+			NodePools: []NodePoolSpec{
+				{Name: "master", InstanceType: "Standard_B2s", Count: 1, Master: true},
+				{Name: "worker", InstanceType: "Standard_D4s_v3", Count: 2},
+			},
+		})
+
+		assert.Equal(t, ControlPlaneCost{
+			Nodes: Cost{Hourly: 0.05, Monthly: 36.5},
+			Cost:  Cost{Hourly: 0.05, Monthly: 36.5},
+		}, estimate.ControlPlane)
+		assert.Equal(t, Cost{Hourly: 0.45, Monthly: 328.5}, estimate.Cost)
+		assert.True(t, estimate.Complete)
+	})
+
+	t.Run("Unpriced", func(t *testing.T) {
+		prices := new(MockPriceSource)
+		prices.On("GetPrice", ctx, "google", "gke", "europe-west1-b", "n1-standard-2").Return(Price{}, errors.New("no product info found"))
+		prices.On("GetPrice", ctx, "google", "gke", "europe-west1-b", "n1-standard-1").Return(Price{OnDemand: 0.05}, nil)
+
+		estimator := NewEstimator(Config{}, prices)
+
+		estimate := estimator.Estimate(ctx, ClusterSpec{
+			Cloud:        "google",
+			Distribution: "gke",
+			Location:     "europe-west1-b",
+			NodePools: []NodePoolSpec{
+				{Name: "pool1", InstanceType: "n1-standard-2", Count: 1},
+				{Name: "pool2", InstanceType: "n1-standard-1", Count: 1, Spot: true},
+				{Name: "pool3", InstanceType: "n1-standard-1", Count: 2},
+				{Name: "pool4", Count: 1},
+			},
+		})
+
+		assert.False(t, estimate.Complete)
+		assert.Equal(t, "no product info found", estimate.NodePools[0].Reason)
+		assert.Equal(t, "no spot price available for the instance type", estimate.NodePools[1].Reason)
+		assert.True(t, estimate.NodePools[2].Priced)
+		assert.Equal(t, "node pool has no instance type", estimate.NodePools[3].Reason)
+		assert.Equal(t, Cost{Hourly: 0.1, Monthly: 73}, estimate.Cost)
+	})
+}
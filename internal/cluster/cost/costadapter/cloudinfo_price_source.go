@@ -0,0 +1,64 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package costadapter
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/.gen/cloudinfo"
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+	pkgCloudinfo "github.com/banzaicloud/pipeline/pkg/cloudinfo"
+)
+
+// ProductSource returns instance type details from Cloudinfo.
+type ProductSource interface {
+	// GetProductDetails returns details for a single product.
+	GetProductDetails(ctx context.Context, cloud string, service string, region string, productType string) (cloudinfo.ProductDetails, error)
+}
+
+// CloudinfoPriceSource returns instance type prices from Cloudinfo.
+type CloudinfoPriceSource struct {
+	products ProductSource
+}
+
+// NewCloudinfoPriceSource returns a new CloudinfoPriceSource.
+func NewCloudinfoPriceSource(products ProductSource) CloudinfoPriceSource {
+	return CloudinfoPriceSource{
+		products: products,
+	}
+}
+
+// GetPrice returns the price of an instance type in a location.
+func (s CloudinfoPriceSource) GetPrice(ctx context.Context, cloud string, distribution string, location string, instanceType string) (cost.Price, error) {
+	details, err := s.products.GetProductDetails(ctx, cloud, distribution, pkgCloudinfo.RegionFromLocation(cloud, location), instanceType)
+	if err != nil {
+		return cost.Price{}, err
+	}
+
+	price := cost.Price{
+		OnDemand: details.OnDemandPrice,
+	}
+
+	if len(details.SpotPrice) > 0 {
+		var sum float64
+		for _, zonePrice := range details.SpotPrice {
+			sum += zonePrice.Price
+		}
+
+		price.Spot = sum / float64(len(details.SpotPrice))
+	}
+
+	return price, nil
+}
@@ -0,0 +1,65 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package costadapter
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/.gen/cloudinfo"
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+)
+
+type productSourceFunc func(cloud string, service string, region string, productType string) (cloudinfo.ProductDetails, error)
+
+func (f productSourceFunc) GetProductDetails(_ context.Context, cloud string, service string, region string, productType string) (cloudinfo.ProductDetails, error) {
+	return f(cloud, service, region, productType)
+}
+
+func TestCloudinfoPriceSource_GetPrice(t *testing.T) {
+	products := productSourceFunc(func(cloud string, service string, region string, productType string) (cloudinfo.ProductDetails, error) {
+		switch {
+		case cloud == "google" && service == "gke" && region == "europe-west1" && productType == "n1-standard-1":
+			return cloudinfo.ProductDetails{
+				OnDemandPrice: 0.0475,
+				SpotPrice: []cloudinfo.ZonePrice{
+					{Zone: "europe-west1-b", Price: 0.01},
+					{Zone: "europe-west1-c", Price: 0.02},
+				},
+			}, nil
+		case cloud == "azure" && service == "aks" && region == "westeurope" && productType == "Standard_B2s":
+			return cloudinfo.ProductDetails{OnDemandPrice: 0.05}, nil
+		}
+
+		return cloudinfo.ProductDetails{}, errors.New("no product info found")
+	})
+
+	prices := NewCloudinfoPriceSource(products)
+
+	price, err := prices.GetPrice(context.Background(), "google", "gke", "europe-west1-b", "n1-standard-1")
+	require.NoError(t, err)
+	assert.Equal(t, cost.Price{OnDemand: 0.0475, Spot: 0.015}, price)
+
+	price, err = prices.GetPrice(context.Background(), "azure", "aks", "westeurope", "Standard_B2s")
+	require.NoError(t, err)
+	assert.Equal(t, cost.Price{OnDemand: 0.05}, price)
+
+	_, err = prices.GetPrice(context.Background(), "azure", "aks", "westeurope", "Standard_Unknown")
+	require.Error(t, err)
+}
@@ -0,0 +1,97 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package costadapter
+
+import (
+	"context"
+	"sort"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	"github.com/banzaicloud/pipeline/src/cluster"
+)
+
+// ClusterManager returns clusters of an organization.
+type ClusterManager interface {
+	// GetClusterByID returns the cluster instance for an organization ID by cluster ID.
+	GetClusterByID(ctx context.Context, organizationID uint, clusterID uint) (cluster.CommonCluster, error)
+}
+
+// ClusterSource returns the spec of existing clusters based on their status.
+type ClusterSource struct {
+	clusters ClusterManager
+}
+
+// NewClusterSource returns a new ClusterSource.
+func NewClusterSource(clusters ClusterManager) ClusterSource {
+	return ClusterSource{
+		clusters: clusters,
+	}
+}
+
+// GetClusterSpec returns the spec of a cluster based on its current node pool sizes.
+func (s ClusterSource) GetClusterSpec(ctx context.Context, organizationID uint, clusterID uint) (cost.ClusterSpec, error) {
+	c, err := s.clusters.GetClusterByID(ctx, organizationID, clusterID)
+	if err != nil {
+		return cost.ClusterSpec{}, err
+	}
+
+	status, err := c.GetStatus()
+	if err != nil {
+		return cost.ClusterSpec{}, errors.WrapIf(err, "failed to get cluster status")
+	}
+
+	return clusterSpecFromStatus(status), nil
+}
+
+// clusterSpecFromStatus builds a cluster spec from the status of an existing cluster.
+// Node pool roles are not part of the status, so control plane nodes are reported as regular node pools.
+func clusterSpecFromStatus(status *pkgCluster.GetClusterStatusResponse) cost.ClusterSpec {
+	location := status.Region
+	if location == "" {
+		location = status.Location
+	}
+
+	spec := cost.ClusterSpec{
+		Cloud:        status.Cloud,
+		Distribution: status.Distribution,
+		Location:     location,
+	}
+
+	names := make([]string, 0, len(status.NodePools))
+	for name := range status.NodePools {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		nodePool := status.NodePools[name]
+
+		spec.NodePools = append(spec.NodePools, cost.NodePoolSpec{
+			Name:         name,
+			InstanceType: nodePool.InstanceType,
+			Count:        nodePool.Count,
+			Autoscaling:  nodePool.Autoscaling,
+			MinCount:     nodePool.MinCount,
+			MaxCount:     nodePool.MaxCount,
+			Spot:         cost.IsSpotPrice(nodePool.SpotPrice) || nodePool.Preemptible,
+		})
+	}
+
+	return spec
+}
@@ -0,0 +1,47 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package costadapter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+func TestClusterSpecFromStatus(t *testing.T) {
+	spec := clusterSpecFromStatus(&pkgCluster.GetClusterStatusResponse{
+		Cloud:        pkgCluster.Google,
+		Distribution: pkgCluster.GKE,
+		Location:     "europe-west1-b",
+		Region:       "europe-west1",
+		NodePools: map[string]*pkgCluster.NodePoolStatus{
+			"pool2": {InstanceType: "n1-standard-4", Count: 1, Preemptible: true},
+			"pool1": {InstanceType: "n1-standard-2", Count: 2, Autoscaling: true, MinCount: 1, MaxCount: 3},
+		},
+	})
+
+	assert.Equal(t, cost.ClusterSpec{
+		Cloud:        pkgCluster.Google,
+		Distribution: pkgCluster.GKE,
+		Location:     "europe-west1",
+		NodePools: []cost.NodePoolSpec{
+			{Name: "pool1", InstanceType: "n1-standard-2", Count: 2, Autoscaling: true, MinCount: 1, MaxCount: 3},
+			{Name: "pool2", InstanceType: "n1-standard-4", Count: 1, Spot: true},
+		},
+	}, spec)
+}
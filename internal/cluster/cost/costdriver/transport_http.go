@@ -0,0 +1,577 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package costdriver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"emperror.dev/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	"github.com/mitchellh/mapstructure"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	"github.com/banzaicloud/pipeline/.gen/pipeline/pipeline"
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+	azurePke "github.com/banzaicloud/pipeline/internal/providers/azure/pke"
+	baremetalPke "github.com/banzaicloud/pipeline/internal/providers/baremetal/pke"
+	internalPke "github.com/banzaicloud/pipeline/internal/providers/pke"
+	vspherePke "github.com/banzaicloud/pipeline/internal/providers/vsphere/pke"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	"github.com/banzaicloud/pipeline/pkg/cluster/ack"
+	"github.com/banzaicloud/pipeline/pkg/cluster/pke"
+)
+
+// ackMasterCount is the number of dedicated master nodes of ACK clusters created by Pipeline.
+const ackMasterCount = 3
+
+// RegisterHTTPHandlers mounts all of the service endpoints into an http.Handler.
+// The router is expected to be an organization router (ie. /orgs/{orgId}).
+func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
+
+	router.Methods(http.MethodPost).Path("/cost/estimates").Handler(kithttp.NewServer(
+		endpoints.EstimateClusterCreation,
+		decodeEstimateClusterCreationHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeEstimateClusterCreationHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/clusters/{clusterId}/cost").Handler(kithttp.NewServer(
+		endpoints.GetClusterCost,
+		decodeGetClusterCostHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetClusterCostHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/clusters/{clusterId}/cost/estimates").Handler(kithttp.NewServer(
+		endpoints.EstimateClusterUpdate,
+		decodeEstimateClusterUpdateHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeEstimateClusterUpdateHTTPResponse, errorEncoder),
+		options...,
+	))
+}
+
+func decodeEstimateClusterCreationHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read request")
+	}
+
+	spec, err := clusterSpecFromCreateRequest(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return EstimateClusterCreationRequest{OrganizationID: orgID, Spec: spec}, nil
+}
+
+func encodeEstimateClusterCreationHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(EstimateClusterCreationResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Estimate)
+}
+
+func decodeGetClusterCostHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	clusterID, err := extractUintParam(r, "clusterId")
+	if err != nil {
+		return nil, err
+	}
+
+	return GetClusterCostRequest{OrganizationID: orgID, ClusterID: clusterID}, nil
+}
+
+func encodeGetClusterCostHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(GetClusterCostResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Estimate)
+}
+
+func decodeEstimateClusterUpdateHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	clusterID, err := extractUintParam(r, "clusterId")
+	if err != nil {
+		return nil, err
+	}
+
+	var body json.RawMessage
+
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return EstimateClusterUpdateRequest{OrganizationID: orgID, ClusterID: clusterID, Update: clusterUpdate(body)}, nil
+}
+
+func encodeEstimateClusterUpdateHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(EstimateClusterUpdateResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Estimate)
+}
+
+// clusterSpecFromCreateRequest builds a cluster spec from a (legacy or typed) cluster create request.
+func clusterSpecFromCreateRequest(body []byte) (cost.ClusterSpec, error) {
+	var base pipeline.CreateClusterRequestBase
+	if err := json.Unmarshal(body, &base); err != nil {
+		return cost.ClusterSpec{}, errors.Wrap(err, "failed to decode request")
+	}
+
+	switch base.Type {
+	case "":
+		var request pkgCluster.CreateClusterRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			return cost.ClusterSpec{}, errors.Wrap(err, "failed to decode request")
+		}
+
+		return clusterSpecFromLegacyCreateRequest(request)
+
+	case azurePke.PKEOnAzure:
+		var request pipeline.CreatePkeOnAzureClusterRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			return cost.ClusterSpec{}, errors.Wrap(err, "failed to decode request")
+		}
+
+		return cost.ClusterSpec{
+			Cloud:        pkgCluster.Azure,
+			Distribution: pkgCluster.PKE,
+			Location:     request.Location,
+			NodePools:    azurePKENodePoolSpecs(request.Nodepools),
+		}, nil
+
+	case vspherePke.PKEOnVsphere:
+		var request pipeline.CreatePkeOnVsphereClusterRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			return cost.ClusterSpec{}, errors.Wrap(err, "failed to decode request")
+		}
+
+		return cost.ClusterSpec{
+			Cloud:        pkgCluster.Vsphere,
+			Distribution: pkgCluster.PKE,
+			NodePools:    vspherePKENodePoolSpecs(request.NodePools),
+		}, nil
+
+	case baremetalPke.PKEOnBaremetal:
+		var request pipeline.CreatePkeOnBaremetalClusterRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			return cost.ClusterSpec{}, errors.Wrap(err, "failed to decode request")
+		}
+
+		return cost.ClusterSpec{
+			Cloud:        pkgCluster.Baremetal,
+			Distribution: pkgCluster.PKE,
+			NodePools:    baremetalPKENodePoolSpecs(request.NodePools),
+		}, nil
+
+	default:
+		return cost.ClusterSpec{}, cost.NewValidationError("unknown cluster type: "+base.Type, nil)
+	}
+}
+
+func clusterSpecFromLegacyCreateRequest(request pkgCluster.CreateClusterRequest) (cost.ClusterSpec, error) {
+	if request.Properties == nil {
+		return cost.ClusterSpec{}, cost.NewValidationError("cluster properties are required", nil)
+	}
+
+	spec := cost.ClusterSpec{
+		Cloud:    request.Cloud,
+		Location: request.Location,
+	}
+
+	properties := request.Properties
+
+	switch {
+	case request.Cloud == pkgCluster.Amazon && properties.CreateClusterEKS != nil:
+		spec.Distribution = pkgCluster.EKS
+
+		for _, name := range sortedNodePoolNames(properties.CreateClusterEKS.NodePools) {
+			nodePool := properties.CreateClusterEKS.NodePools[name]
+
+			spec.NodePools = append(spec.NodePools, cost.NodePoolSpec{
+				Name:         name,
+				InstanceType: nodePool.InstanceType,
+				Count:        nodePool.Count,
+				Autoscaling:  nodePool.Autoscaling,
+				MinCount:     nodePool.MinCount,
+				MaxCount:     nodePool.MaxCount,
+				Spot:         cost.IsSpotPrice(nodePool.SpotPrice),
+			})
+		}
+
+	case request.Cloud == pkgCluster.Amazon && properties.CreateClusterPKE != nil:
+		spec.Distribution = pkgCluster.PKE
+
+		for _, nodePool := range properties.CreateClusterPKE.NodePools {
+			var providerConfig internalPke.NodePoolProviderConfigAmazon
+			if err := mapstructure.Decode(nodePool.ProviderConfig, &providerConfig); err != nil {
+				return spec, cost.NewValidationError("invalid node pool provider config", []string{
+					errors.WrapIff(err, "node pool %q", nodePool.Name).Error(),
+				})
+			}
+
+			size := providerConfig.AutoScalingGroup.Size
+
+			spec.NodePools = append(spec.NodePools, cost.NodePoolSpec{
+				Name:         nodePool.Name,
+				InstanceType: providerConfig.AutoScalingGroup.InstanceType,
+				Master:       hasMasterRole(nodePool.Roles),
+				Count:        size.Desired,
+				Autoscaling:  nodePool.Autoscaling,
+				MinCount:     size.Min,
+				MaxCount:     size.Max,
+				Spot:         cost.IsSpotPrice(providerConfig.AutoScalingGroup.SpotPrice),
+			})
+		}
+
+	case request.Cloud == pkgCluster.Azure && properties.CreateClusterAKS != nil:
+		spec.Distribution = pkgCluster.AKS
+
+		for _, name := range sortedNodePoolNames(properties.CreateClusterAKS.NodePools) {
+			nodePool := properties.CreateClusterAKS.NodePools[name]
+
+			spec.NodePools = append(spec.NodePools, cost.NodePoolSpec{
+				Name:         name,
+				InstanceType: nodePool.NodeInstanceType,
+				Count:        nodePool.Count,
+				Autoscaling:  nodePool.Autoscaling,
+				MinCount:     nodePool.MinCount,
+				MaxCount:     nodePool.MaxCount,
+			})
+		}
+
+	case request.Cloud == pkgCluster.Google && properties.CreateClusterGKE != nil:
+		spec.Distribution = pkgCluster.GKE
+
+		for _, name := range sortedNodePoolNames(properties.CreateClusterGKE.NodePools) {
+			nodePool := properties.CreateClusterGKE.NodePools[name]
+
+			spec.NodePools = append(spec.NodePools, cost.NodePoolSpec{
+				Name:         name,
+				InstanceType: nodePool.NodeInstanceType,
+				Count:        nodePool.Count,
+				Autoscaling:  nodePool.Autoscaling,
+				MinCount:     nodePool.MinCount,
+				MaxCount:     nodePool.MaxCount,
+				Spot:         nodePool.Preemptible,
+			})
+		}
+
+	case request.Cloud == pkgCluster.Alibaba && properties.CreateClusterACK != nil:
+		spec.Distribution = pkgCluster.ACK
+
+		if spec.Location == "" {
+			spec.Location = properties.CreateClusterACK.RegionID
+		}
+
+		if err := properties.CreateClusterACK.AddDefaults(); err != nil {
+			return spec, cost.NewValidationError(err.Error(), nil)
+		}
+
+		spec.NodePools = append(spec.NodePools, cost.NodePoolSpec{
+			Name:         "master",
+			InstanceType: properties.CreateClusterACK.MasterInstanceType,
+			Master:       true,
+			Count:        ackMasterCount,
+		})
+
+		for _, name := range sortedNodePoolNames(properties.CreateClusterACK.NodePools) {
+			spec.NodePools = append(spec.NodePools, ackNodePoolSpec(name, properties.CreateClusterACK.NodePools[name]))
+		}
+
+	case request.Cloud == pkgCluster.Oracle && properties.CreateClusterOKE != nil:
+		spec.Distribution = pkgCluster.OKE
+
+		for _, name := range sortedNodePoolNames(properties.CreateClusterOKE.NodePools) {
+			nodePool := properties.CreateClusterOKE.NodePools[name]
+
+			spec.NodePools = append(spec.NodePools, cost.NodePoolSpec{
+				Name:         name,
+				InstanceType: nodePool.Shape,
+				Count:        int(nodePool.Count),
+			})
+		}
+
+	case request.Cloud == pkgCluster.Kubernetes && properties.CreateClusterKubernetes != nil:
+		// imported clusters have no node pool information before they are imported
+		spec.Distribution = pkgCluster.Unknown
+
+	default:
+		return spec, cost.NewValidationError("cost estimation is not supported for "+strconv.Quote(request.Cloud)+" clusters", nil)
+	}
+
+	return spec, nil
+}
+
+// clusterUpdate is a cluster update request decoded according to the distribution of the cluster.
+type clusterUpdate json.RawMessage
+
+// NodePools implements cost.ClusterUpdate.
+func (u clusterUpdate) NodePools(current cost.ClusterSpec) ([]cost.NodePoolSpec, bool, error) {
+	switch {
+	case current.Distribution == pkgCluster.PKE && current.Cloud == pkgCluster.Azure:
+		var request pipeline.UpdatePkeOnAzureClusterRequest
+		if err := json.Unmarshal(u, &request); err != nil {
+			return nil, false, errors.Wrap(err, "failed to decode request")
+		}
+
+		return azurePKENodePoolSpecs(request.Nodepools), true, nil
+
+	case current.Distribution == pkgCluster.PKE && current.Cloud == pkgCluster.Vsphere:
+		var request pipeline.UpdatePkeOnVsphereClusterRequest
+		if err := json.Unmarshal(u, &request); err != nil {
+			return nil, false, errors.Wrap(err, "failed to decode request")
+		}
+
+		return vspherePKENodePoolSpecs(request.Nodepools), true, nil
+
+	case current.Distribution == pkgCluster.PKE && current.Cloud == pkgCluster.Baremetal:
+		var request pipeline.UpdatePkeOnBaremetalClusterRequest
+		if err := json.Unmarshal(u, &request); err != nil {
+			return nil, false, errors.Wrap(err, "failed to decode request")
+		}
+
+		return baremetalPKENodePoolSpecs(request.Nodepools), true, nil
+	}
+
+	var request pkgCluster.UpdateClusterRequest
+	if err := json.Unmarshal(u, &request); err != nil {
+		return nil, false, errors.Wrap(err, "failed to decode request")
+	}
+
+	return nodePoolSpecsFromLegacyUpdateRequest(current, request)
+}
+
+// nodePoolSpecsFromLegacyUpdateRequest returns the node pools of a legacy cluster update request.
+// Most distributions remove the node pools missing from the update request, AKS can only change existing node pools.
+func nodePoolSpecsFromLegacyUpdateRequest(current cost.ClusterSpec, request pkgCluster.UpdateClusterRequest) ([]cost.NodePoolSpec, bool, error) {
+	var nodePools []cost.NodePoolSpec
+
+	replace := true
+	properties := request.UpdateProperties
+
+	switch {
+	case current.Distribution == pkgCluster.EKS && properties.EKS != nil:
+		for _, name := range sortedNodePoolNames(properties.EKS.NodePools) {
+			nodePool := properties.EKS.NodePools[name]
+
+			nodePools = append(nodePools, cost.NodePoolSpec{
+				Name:         name,
+				InstanceType: nodePool.InstanceType,
+				Count:        nodePool.Count,
+				Autoscaling:  nodePool.Autoscaling,
+				MinCount:     nodePool.MinCount,
+				MaxCount:     nodePool.MaxCount,
+				Spot:         cost.IsSpotPrice(nodePool.SpotPrice),
+			})
+		}
+
+	case current.Distribution == pkgCluster.PKE && properties.PKE != nil:
+		for _, name := range sortedNodePoolNames(properties.PKE.NodePools) {
+			nodePool := properties.PKE.NodePools[name]
+
+			nodePools = append(nodePools, cost.NodePoolSpec{
+				Name:         name,
+				InstanceType: nodePool.InstanceType,
+				Count:        nodePool.Count,
+				Autoscaling:  nodePool.Autoscaling,
+				MinCount:     nodePool.MinCount,
+				MaxCount:     nodePool.MaxCount,
+				Spot:         cost.IsSpotPrice(nodePool.SpotPrice),
+			})
+		}
+
+	case current.Distribution == pkgCluster.AKS && properties.AKS != nil:
+		replace = false
+
+		for _, name := range sortedNodePoolNames(properties.AKS.NodePools) {
+			nodePool := properties.AKS.NodePools[name]
+
+			nodePools = append(nodePools, cost.NodePoolSpec{
+				Name:        name,
+				Count:       nodePool.Count,
+				Autoscaling: nodePool.Autoscaling,
+				MinCount:    nodePool.MinCount,
+				MaxCount:    nodePool.MaxCount,
+			})
+		}
+
+	case current.Distribution == pkgCluster.GKE && properties.GKE != nil:
+		for _, name := range sortedNodePoolNames(properties.GKE.NodePools) {
+			nodePool := properties.GKE.NodePools[name]
+
+			nodePools = append(nodePools, cost.NodePoolSpec{
+				Name:         name,
+				InstanceType: nodePool.NodeInstanceType,
+				Count:        nodePool.Count,
+				Autoscaling:  nodePool.Autoscaling,
+				MinCount:     nodePool.MinCount,
+				MaxCount:     nodePool.MaxCount,
+				Spot:         nodePool.Preemptible,
+			})
+		}
+
+	case current.Distribution == pkgCluster.ACK && properties.ACK != nil:
+		for _, name := range sortedNodePoolNames(properties.ACK.NodePools) {
+			nodePools = append(nodePools, ackNodePoolSpec(name, properties.ACK.NodePools[name]))
+		}
+
+	case current.Distribution == pkgCluster.OKE && properties.OKE != nil:
+		for _, name := range sortedNodePoolNames(properties.OKE.NodePools) {
+			nodePool := properties.OKE.NodePools[name]
+
+			nodePools = append(nodePools, cost.NodePoolSpec{
+				Name:         name,
+				InstanceType: nodePool.Shape,
+				Count:        int(nodePool.Count),
+			})
+		}
+
+	default:
+		return nil, false, cost.NewValidationError("update request does not contain "+current.Distribution+" properties", nil)
+	}
+
+	return nodePools, replace, nil
+}
+
+func azurePKENodePoolSpecs(nodePools []pipeline.PkeOnAzureNodePool) []cost.NodePoolSpec {
+	specs := make([]cost.NodePoolSpec, 0, len(nodePools))
+
+	for _, nodePool := range nodePools {
+		specs = append(specs, cost.NodePoolSpec{
+			Name:         nodePool.Name,
+			InstanceType: nodePool.InstanceType,
+			Master:       hasMasterRoleName(nodePool.Roles),
+			Count:        int(nodePool.Count),
+			Autoscaling:  nodePool.Autoscaling,
+			MinCount:     int(nodePool.MinCount),
+			MaxCount:     int(nodePool.MaxCount),
+		})
+	}
+
+	return specs
+}
+
+// vspherePKENodePoolSpecs returns node pools without instance types (on-premise nodes cannot be priced).
+func vspherePKENodePoolSpecs(nodePools []pipeline.PkeOnVsphereNodePool) []cost.NodePoolSpec {
+	specs := make([]cost.NodePoolSpec, 0, len(nodePools))
+
+	for _, nodePool := range nodePools {
+		specs = append(specs, cost.NodePoolSpec{
+			Name:   nodePool.Name,
+			Master: hasMasterRoleName(nodePool.Roles),
+			Count:  int(nodePool.Size),
+		})
+	}
+
+	return specs
+}
+
+// baremetalPKENodePoolSpecs returns node pools without instance types (on-premise nodes cannot be priced).
+func baremetalPKENodePoolSpecs(nodePools []pipeline.PkeOnBaremetalNodePool) []cost.NodePoolSpec {
+	specs := make([]cost.NodePoolSpec, 0, len(nodePools))
+
+	for _, nodePool := range nodePools {
+		specs = append(specs, cost.NodePoolSpec{
+			Name:   nodePool.Name,
+			Master: hasMasterRoleName(nodePool.Roles),
+			Count:  len(nodePool.Hosts),
+		})
+	}
+
+	return specs
+}
+
+// ackNodePoolSpec returns the spec of an ACK node pool (ACK scaling groups start with the minimum number of nodes).
+func ackNodePoolSpec(name string, nodePool *ack.NodePool) cost.NodePoolSpec {
+	return cost.NodePoolSpec{
+		Name:         name,
+		InstanceType: nodePool.InstanceType,
+		Count:        nodePool.MinCount,
+		Autoscaling:  nodePool.MaxCount > nodePool.MinCount,
+		MinCount:     nodePool.MinCount,
+		MaxCount:     nodePool.MaxCount,
+	}
+}
+
+func hasMasterRole(roles pke.Roles) bool {
+	for _, role := range roles {
+		if role == pke.RoleMaster {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasMasterRoleName(roles []string) bool {
+	for _, role := range roles {
+		if role == string(pke.RoleMaster) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sortedNodePoolNames returns the keys of a node pool map in a stable order.
+func sortedNodePoolNames(nodePools interface{}) []string {
+	keys := reflect.ValueOf(nodePools).MapKeys()
+
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, key.String())
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func extractUintParam(r *http.Request, param string) (uint, error) {
+	vars := mux.Vars(r)
+
+	value, ok := vars[param]
+	if !ok || value == "" {
+		return 0, errors.NewWithDetails("missing path parameter", "param", param)
+	}
+
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "invalid path parameter", "param", param, "value", value)
+	}
+
+	return uint(id), nil
+}
@@ -0,0 +1,276 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package costdriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"emperror.dev/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+func newTestServer(endpoints Endpoints) *httptest.Server {
+	handler := mux.NewRouter()
+	RegisterHTTPHandlers(
+		endpoints,
+		handler.PathPrefix("/orgs/{orgId}").Subrouter(),
+		kithttp.ServerErrorEncoder(kitxhttp.NewJSONProblemErrorEncoder(apphttp.NewDefaultProblemConverter())),
+	)
+
+	return httptest.NewServer(handler)
+}
+
+func TestRegisterHTTPHandlers_EstimateClusterCreation(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		EstimateClusterCreation: func(ctx context.Context, request interface{}) (interface{}, error) {
+			expected := EstimateClusterCreationRequest{
+				OrganizationID: 1,
+				Spec: cost.ClusterSpec{
+					Cloud:        pkgCluster.Azure,
+					Distribution: pkgCluster.PKE,
+					Location:     "westeurope",
+					NodePools: []cost.NodePoolSpec{
+						{Name: "master", InstanceType: "Standard_B2s", Master: true, Count: 1},
+					},
+				},
+			}
+
+			if !assert.Equal(t, expected, request) {
+				return nil, nil
+			}
+
+			return EstimateClusterCreationResponse{Estimate: cost.Estimate{Currency: cost.Currency, Complete: true}}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := http.Post(
+		ts.URL+"/orgs/1/cost/estimates",
+		"application/json",
+		strings.NewReader(`{"name":"azure-pke","location":"westeurope","type":"pke-on-azure","nodepools":[{"name":"master","roles":["master"],"instanceType":"Standard_B2s","count":1}]}`),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var estimate cost.Estimate
+
+	err = json.NewDecoder(resp.Body).Decode(&estimate)
+	require.NoError(t, err)
+
+	assert.Equal(t, cost.Estimate{Currency: cost.Currency, Complete: true}, estimate)
+}
+
+func TestRegisterHTTPHandlers_EstimateClusterCreation_Unsupported(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		EstimateClusterCreation: func(ctx context.Context, request interface{}) (interface{}, error) {
+			t.Fatal("endpoint should not be called")
+
+			return nil, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := http.Post(
+		ts.URL+"/orgs/1/cost/estimates",
+		"application/json",
+		strings.NewReader(`{"name":"cluster","cloud":"unknown","properties":{}}`),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestRegisterHTTPHandlers_GetClusterCost(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		GetClusterCost: func(ctx context.Context, request interface{}) (interface{}, error) {
+			if !assert.Equal(t, GetClusterCostRequest{OrganizationID: 1, ClusterID: 2}, request) {
+				return nil, nil
+			}
+
+			return GetClusterCostResponse{Estimate: cost.Estimate{Currency: cost.Currency}}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/orgs/1/clusters/2/cost")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var estimate cost.Estimate
+
+	err = json.NewDecoder(resp.Body).Decode(&estimate)
+	require.NoError(t, err)
+
+	assert.Equal(t, cost.Estimate{Currency: cost.Currency}, estimate)
+}
+
+func TestRegisterHTTPHandlers_EstimateClusterUpdate(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		EstimateClusterUpdate: func(ctx context.Context, request interface{}) (interface{}, error) {
+			req := request.(EstimateClusterUpdateRequest)
+
+			assert.Equal(t, uint(1), req.OrganizationID)
+			assert.Equal(t, uint(2), req.ClusterID)
+
+			nodePools, replace, err := req.Update.NodePools(cost.ClusterSpec{Cloud: pkgCluster.Google, Distribution: pkgCluster.GKE})
+			require.NoError(t, err)
+
+			assert.True(t, replace)
+			assert.Equal(t, []cost.NodePoolSpec{{Name: "pool1", Count: 4}}, nodePools)
+
+			return EstimateClusterUpdateResponse{Estimate: cost.Estimate{Currency: cost.Currency}}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := http.Post(
+		ts.URL+"/orgs/1/clusters/2/cost/estimates",
+		"application/json",
+		strings.NewReader(`{"cloud":"google","properties":{"gke":{"nodePools":{"pool1":{"count":4}}}}}`),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestClusterSpecFromCreateRequest(t *testing.T) {
+	t.Run("EKS", func(t *testing.T) {
+		spec, err := clusterSpecFromCreateRequest([]byte(`{
+			"name": "eks-cluster",
+			"location": "us-east-1",
+			"cloud": "amazon",
+			"properties": {
+				"eks": {
+					"nodePools": {
+						"spot": {"instanceType": "m5.large", "spotPrice": "0.1", "autoscaling": true, "minCount": 1, "maxCount": 5, "count": 2},
+						"ondemand": {"instanceType": "m5.xlarge", "spotPrice": "", "count": 1}
+					}
+				}
+			}
+		}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, cost.ClusterSpec{
+			Cloud:        pkgCluster.Amazon,
+			Distribution: pkgCluster.EKS,
+			Location:     "us-east-1",
+			NodePools: []cost.NodePoolSpec{
+				{Name: "ondemand", InstanceType: "m5.xlarge", Count: 1},
+				{Name: "spot", InstanceType: "m5.large", Count: 2, Autoscaling: true, MinCount: 1, MaxCount: 5, Spot: true},
+			},
+		}, spec)
+	})
+
+	t.Run("PKE on Azure", func(t *testing.T) {
+		spec, err := clusterSpecFromCreateRequest([]byte(`{
+			"name": "azure-pke",
+			"location": "westeurope",
+			"type": "pke-on-azure",
+			"nodepools": [
+				{"name": "master", "roles": ["master"], "instanceType": "Standard_B2s", "count": 1},
+				{"name": "worker", "roles": ["worker"], "instanceType": "Standard_D2s_v3", "count": 3}
+			]
+		}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, cost.ClusterSpec{
+			Cloud:        pkgCluster.Azure,
+			Distribution: pkgCluster.PKE,
+			Location:     "westeurope",
+			NodePools: []cost.NodePoolSpec{
+				{Name: "master", InstanceType: "Standard_B2s", Master: true, Count: 1},
+				{Name: "worker", InstanceType: "Standard_D2s_v3", Count: 3},
+			},
+		}, spec)
+	})
+
+	t.Run("unsupported cloud", func(t *testing.T) {
+		_, err := clusterSpecFromCreateRequest([]byte(`{"name": "cluster", "cloud": "unknown", "properties": {}}`))
+		require.Error(t, err)
+
+		var verr cost.ValidationError
+		assert.True(t, errors.As(err, &verr))
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := clusterSpecFromCreateRequest([]byte(`{"name": "cluster", "type": "unknown"}`))
+		require.Error(t, err)
+	})
+}
+
+func TestClusterUpdate_NodePools(t *testing.T) {
+	t.Run("GKE replaces node pools", func(t *testing.T) {
+		nodePools, replace, err := clusterUpdate(`{
+			"cloud": "google",
+			"properties": {"gke": {"nodePools": {"pool1": {"count": 4}, "pool3": {"instanceType": "n1-standard-8", "count": 1}}}}
+		}`).NodePools(cost.ClusterSpec{Cloud: pkgCluster.Google, Distribution: pkgCluster.GKE})
+		require.NoError(t, err)
+
+		assert.True(t, replace)
+		assert.Equal(t, []cost.NodePoolSpec{
+			{Name: "pool1", Count: 4},
+			{Name: "pool3", InstanceType: "n1-standard-8", Count: 1},
+		}, nodePools)
+	})
+
+	t.Run("AKS keeps missing node pools", func(t *testing.T) {
+		nodePools, replace, err := clusterUpdate(`{
+			"cloud": "azure",
+			"properties": {"aks": {"nodePools": {"pool1": {"count": 3}}}}
+		}`).NodePools(cost.ClusterSpec{Cloud: pkgCluster.Azure, Distribution: pkgCluster.AKS})
+		require.NoError(t, err)
+
+		assert.False(t, replace)
+		assert.Equal(t, []cost.NodePoolSpec{{Name: "pool1", Count: 3}}, nodePools)
+	})
+
+	t.Run("PKE on vSphere", func(t *testing.T) {
+		nodePools, replace, err := clusterUpdate(`{
+			"nodepools": [{"name": "worker", "roles": ["worker"], "size": 3}]
+		}`).NodePools(cost.ClusterSpec{Cloud: pkgCluster.Vsphere, Distribution: pkgCluster.PKE})
+		require.NoError(t, err)
+
+		assert.True(t, replace)
+		assert.Equal(t, []cost.NodePoolSpec{{Name: "worker", Count: 3}}, nodePools)
+	})
+
+	t.Run("missing properties", func(t *testing.T) {
+		_, _, err := clusterUpdate(`{"cloud": "amazon", "properties": {}}`).
+			NodePools(cost.ClusterSpec{Cloud: pkgCluster.Amazon, Distribution: pkgCluster.EKS})
+		require.Error(t, err)
+
+		var verr cost.ValidationError
+		assert.True(t, errors.As(err, &verr))
+	})
+}
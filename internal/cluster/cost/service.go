@@ -0,0 +1,134 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"context"
+	"strconv"
+)
+
+// +testify:mock:testOnly=true
+
+// ClusterSource returns the spec of existing clusters.
+type ClusterSource interface {
+	// GetClusterSpec returns the spec of a cluster based on its current node pool sizes.
+	GetClusterSpec(ctx context.Context, organizationID uint, clusterID uint) (ClusterSpec, error)
+}
+
+// ClusterUpdate is a cluster update request of any distribution.
+type ClusterUpdate interface {
+	// NodePools returns the node pools of the update request for the spec of the updated cluster.
+	// Replace is true if the node pools missing from the request are removed by the update.
+	NodePools(current ClusterSpec) (nodePools []NodePoolSpec, replace bool, err error)
+}
+
+// +kit:endpoint:errorStrategy=service
+
+// Service estimates the cost of clusters.
+type Service interface {
+	// EstimateClusterCreation estimates the cost of a cluster described by a cluster create request.
+	EstimateClusterCreation(ctx context.Context, organizationID uint, spec ClusterSpec) (estimate Estimate, err error)
+
+	// EstimateClusterUpdate estimates the cost of a cluster after applying a cluster update request.
+	EstimateClusterUpdate(ctx context.Context, organizationID uint, clusterID uint, update ClusterUpdate) (estimate Estimate, err error)
+
+	// GetClusterCost returns the running cost of a cluster based on its current node pool sizes.
+	GetClusterCost(ctx context.Context, organizationID uint, clusterID uint) (estimate Estimate, err error)
+}
+
+// NewService returns a new Service.
+func NewService(estimator Estimator, clusters ClusterSource) Service {
+	return service{
+		estimator: estimator,
+		clusters:  clusters,
+	}
+}
+
+type service struct {
+	estimator Estimator
+	clusters  ClusterSource
+}
+
+func (s service) EstimateClusterCreation(ctx context.Context, _ uint, spec ClusterSpec) (Estimate, error) {
+	return s.estimator.Estimate(ctx, spec), nil
+}
+
+func (s service) EstimateClusterUpdate(ctx context.Context, organizationID uint, clusterID uint, update ClusterUpdate) (Estimate, error) {
+	spec, err := s.clusters.GetClusterSpec(ctx, organizationID, clusterID)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	nodePools, replace, err := update.NodePools(spec)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	spec.NodePools = mergeNodePoolSpecs(spec.NodePools, nodePools, replace)
+
+	return s.estimator.Estimate(ctx, spec), nil
+}
+
+func (s service) GetClusterCost(ctx context.Context, organizationID uint, clusterID uint) (Estimate, error) {
+	spec, err := s.clusters.GetClusterSpec(ctx, organizationID, clusterID)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	return s.estimator.Estimate(ctx, spec), nil
+}
+
+// mergeNodePoolSpecs applies updated node pools to the current ones.
+// Instance types missing from the update are taken from the current node pools.
+func mergeNodePoolSpecs(current []NodePoolSpec, updated []NodePoolSpec, replace bool) []NodePoolSpec {
+	currentByName := make(map[string]NodePoolSpec, len(current))
+	for _, nodePool := range current {
+		currentByName[nodePool.Name] = nodePool
+	}
+
+	updatedNames := make(map[string]bool, len(updated))
+	merged := make([]NodePoolSpec, 0, len(current)+len(updated))
+
+	for _, nodePool := range updated {
+		updatedNames[nodePool.Name] = true
+
+		if currentNodePool, ok := currentByName[nodePool.Name]; ok {
+			if nodePool.InstanceType == "" {
+				nodePool.InstanceType = currentNodePool.InstanceType
+			}
+
+			nodePool.Master = nodePool.Master || currentNodePool.Master
+		}
+
+		merged = append(merged, nodePool)
+	}
+
+	if !replace {
+		for _, nodePool := range current {
+			if !updatedNames[nodePool.Name] {
+				merged = append(merged, nodePool)
+			}
+		}
+	}
+
+	return merged
+}
+
+// IsSpotPrice checks whether a node pool spot price (bid) is set.
+func IsSpotPrice(spotPrice string) bool {
+	price, err := strconv.ParseFloat(spotPrice, 64)
+
+	return err == nil && price > 0
+}
@@ -0,0 +1,130 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cost
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type clusterUpdateStub struct {
+	nodePools []NodePoolSpec
+	replace   bool
+	err       error
+}
+
+func (u clusterUpdateStub) NodePools(_ ClusterSpec) ([]NodePoolSpec, bool, error) {
+	return u.nodePools, u.replace, u.err
+}
+
+func TestService_EstimateClusterUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	current := ClusterSpec{
+		Cloud:        "google",
+		Distribution: "gke",
+		Location:     "europe-west1",
+		NodePools: []NodePoolSpec{
+			{Name: "pool1", InstanceType: "n1-standard-2", Count: 2},
+			{Name: "pool2", InstanceType: "n1-standard-4", Count: 1},
+		},
+	}
+
+	t.Run("ReplaceNodePools", func(t *testing.T) {
+		clusters := new(MockClusterSource)
+		clusters.On("GetClusterSpec", ctx, uint(1), uint(2)).Return(current, nil)
+
+		prices := new(MockPriceSource)
+		prices.On("GetPrice", ctx, "google", "gke", "europe-west1", "n1-standard-2").Return(Price{OnDemand: 0.1}, nil)
+		prices.On("GetPrice", ctx, "google", "gke", "europe-west1", "n1-standard-8").Return(Price{OnDemand: 0.4}, nil)
+
+		service := NewService(NewEstimator(Config{}, prices), clusters)
+
+		estimate, err := service.EstimateClusterUpdate(ctx, 1, 2, clusterUpdateStub{
+			nodePools: []NodePoolSpec{
+				{Name: "pool1", Count: 4},
+				{Name: "pool3", InstanceType: "n1-standard-8", Count: 1},
+			},
+			replace: true,
+		})
+		require.NoError(t, err)
+
+		require.Len(t, estimate.NodePools, 2)
+		assert.Equal(t, "n1-standard-2", estimate.NodePools[0].InstanceType, "instance type is kept from the current node pool")
+		assert.Equal(t, "pool3", estimate.NodePools[1].Name)
+		assert.Equal(t, Cost{Hourly: 0.8, Monthly: 584}, estimate.Cost)
+
+		clusters.AssertExpectations(t)
+		prices.AssertExpectations(t)
+	})
+
+	t.Run("KeepNodePools", func(t *testing.T) {
+		clusters := new(MockClusterSource)
+		clusters.On("GetClusterSpec", ctx, uint(1), uint(2)).Return(current, nil)
+
+		prices := new(MockPriceSource)
+		prices.On("GetPrice", ctx, "google", "gke", "europe-west1", "n1-standard-2").Return(Price{OnDemand: 0.1}, nil)
+		prices.On("GetPrice", ctx, "google", "gke", "europe-west1", "n1-standard-4").Return(Price{OnDemand: 0.2}, nil)
+
+		service := NewService(NewEstimator(Config{}, prices), clusters)
+
+		estimate, err := service.EstimateClusterUpdate(ctx, 1, 2, clusterUpdateStub{
+			nodePools: []NodePoolSpec{{Name: "pool1", Count: 3}},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, estimate.NodePools, 2)
+		assert.Equal(t, 3, estimate.NodePools[0].Count)
+		assert.Equal(t, "pool2", estimate.NodePools[1].Name)
+	})
+
+	t.Run("InvalidUpdate", func(t *testing.T) {
+		clusters := new(MockClusterSource)
+		clusters.On("GetClusterSpec", ctx, uint(1), uint(2)).Return(current, nil)
+
+		service := NewService(NewEstimator(Config{}, new(MockPriceSource)), clusters)
+
+		_, err := service.EstimateClusterUpdate(ctx, 1, 2, clusterUpdateStub{err: NewValidationError("invalid", nil)})
+		require.Error(t, err)
+
+		var verr ValidationError
+		assert.True(t, errors.As(err, &verr))
+	})
+}
+
+func TestService_GetClusterCost(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ClusterNotFound", func(t *testing.T) {
+		clusters := new(MockClusterSource)
+		clusters.On("GetClusterSpec", ctx, uint(1), uint(2)).Return(ClusterSpec{}, errors.New("not found"))
+
+		service := NewService(NewEstimator(Config{}, new(MockPriceSource)), clusters)
+
+		_, err := service.GetClusterCost(ctx, 1, 2)
+		assert.EqualError(t, err, "not found")
+	})
+}
+
+func TestIsSpotPrice(t *testing.T) {
+	assert.True(t, IsSpotPrice("0.1"))
+	assert.False(t, IsSpotPrice(""))
+	assert.False(t, IsSpotPrice("0"))
+	assert.False(t, IsSpotPrice("invalid"))
+}
@@ -53,6 +53,7 @@ func (s nodePoolStore) CreateNodePool(
 		NodeMinCount:     nodePool.Autoscaling.MinSize,
 		NodeMaxCount:     nodePool.Autoscaling.MaxSize,
 		Count:            nodePool.Size,
+		Taints:           nodePool.Taints,
 	}
 
 	err := s.db.Save(nodePoolModel).Error
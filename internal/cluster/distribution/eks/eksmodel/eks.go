@@ -25,6 +25,7 @@ import (
 
 	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter/clustermodel"
 	"github.com/banzaicloud/pipeline/internal/global"
+	"github.com/banzaicloud/pipeline/pkg/common"
 )
 
 // EKSClusterModel describes the EKS cluster model
@@ -137,6 +138,7 @@ type AmazonNodePoolsModel struct {
 	NodeImage        string
 	NodeInstanceType string
 	Labels           map[string]string `gorm:"-"`
+	Taints           common.Taints     `gorm:"type:text"`
 	Delete           bool              `gorm:"-"`
 }
 
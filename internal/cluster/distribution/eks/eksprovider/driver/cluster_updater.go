@@ -75,6 +75,12 @@ func createNodePoolsFromUpdateRequest(eksCluster *cluster.EKSCluster, requestedN
 
 	for nodePoolName, nodePool := range requestedNodePools {
 		if currentNodePoolMap[nodePoolName] != nil {
+			// keep the current taints unless the request explicitly sets them
+			taints := nodePool.Taints
+			if taints == nil {
+				taints = currentNodePoolMap[nodePoolName].Taints
+			}
+
 			// update existing node pool
 			updatedNodePools = append(updatedNodePools, &eksmodel.AmazonNodePoolsModel{
 				ID:               currentNodePoolMap[nodePoolName].ID,
@@ -90,6 +96,7 @@ func createNodePoolsFromUpdateRequest(eksCluster *cluster.EKSCluster, requestedN
 				NodeMaxCount:     nodePool.MaxCount,
 				Count:            nodePool.Count,
 				Labels:           nodePool.Labels,
+				Taints:           taints,
 				Delete:           false,
 			})
 		} else {
@@ -124,6 +131,7 @@ func createNodePoolsFromUpdateRequest(eksCluster *cluster.EKSCluster, requestedN
 				Count:            nodePool.Count,
 				Delete:           false,
 				Labels:           nodePool.Labels,
+				Taints:           nodePool.Taints,
 			})
 		}
 	}
@@ -298,6 +306,7 @@ func (c *EksClusterUpdater) update(ctx context.Context, logger logrus.FieldLogge
 			NodeImage:        np.NodeImage,
 			NodeInstanceType: np.NodeInstanceType,
 			Labels:           np.Labels,
+			Taints:           np.Taints,
 			Delete:           np.Delete,
 			CreatedBy:        np.CreatedBy,
 		}
@@ -34,6 +34,7 @@ import (
 
 	"github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksmodel"
 	internalAmazon "github.com/banzaicloud/pipeline/internal/providers/amazon"
+	"github.com/banzaicloud/pipeline/pkg/common"
 	"github.com/banzaicloud/pipeline/pkg/providers/amazon/autoscaling"
 	pkgCloudformation "github.com/banzaicloud/pipeline/pkg/providers/amazon/cloudformation"
 	"github.com/banzaicloud/pipeline/src/secret"
@@ -182,6 +183,7 @@ type AutoscaleGroup struct {
 	NodeImage        string
 	NodeInstanceType string
 	Labels           map[string]string
+	Taints           common.Taints
 	Delete           bool
 	Create           bool
 	CreatedBy        uint
@@ -56,6 +56,7 @@ type CreateAsgActivityInput struct {
 	NodeImage        string
 	NodeInstanceType string
 	Labels           map[string]string
+	Taints           common.Taints
 
 	Subnets             []Subnet
 	VpcID               string
@@ -116,12 +117,6 @@ func (a *CreateAsgActivity) Execute(ctx context.Context, input CreateAsgActivity
 	tags := getNodePoolStackTags(input.ClusterName)
 	var stackParams []*cloudformation.Parameter
 
-	// do not update node labels via kubelet boostrap params as that induces node reboot or replacement
-	// we only add node pool name here, all other labels will be added by NodePoolLabelSet operator
-	nodeLabels := []string{
-		fmt.Sprintf("%v=%v", common.LabelKey, input.Name),
-	}
-
 	var subnetIDs []string
 
 	for _, subnet := range input.Subnets {
@@ -196,7 +191,7 @@ func (a *CreateAsgActivity) Execute(ctx context.Context, input CreateAsgActivity
 		},
 		{
 			ParameterKey:   aws.String("BootstrapArguments"),
-			ParameterValue: aws.String(fmt.Sprintf("--kubelet-extra-args '--node-labels %v'", strings.Join(nodeLabels, ","))),
+			ParameterValue: aws.String(generateBootstrapArguments(input.Name, input.Taints)),
 		},
 	}
 	clientRequestToken := generateRequestToken(input.AWSClientRequestTokenBase, CreateAsgActivityName)
@@ -231,3 +226,20 @@ func (a *CreateAsgActivity) Execute(ctx context.Context, input CreateAsgActivity
 	outParams := CreateAsgActivityOutput{}
 	return &outParams, nil
 }
+
+// generateBootstrapArguments returns the kubelet arguments for the nodes of a node pool.
+func generateBootstrapArguments(nodePoolName string, taints common.Taints) string {
+	// do not update node labels via kubelet boostrap params as that induces node reboot or replacement
+	// we only add node pool name here, all other labels will be added by NodePoolLabelSet operator
+	nodeLabels := []string{
+		fmt.Sprintf("%v=%v", common.LabelKey, nodePoolName),
+	}
+
+	kubeletArgs := fmt.Sprintf("--node-labels %v", strings.Join(nodeLabels, ","))
+
+	if len(taints) > 0 {
+		kubeletArgs += fmt.Sprintf(" --register-with-taints %v", strings.Join(taints.Strings(), ","))
+	}
+
+	return fmt.Sprintf("--kubelet-extra-args '%v'", kubeletArgs)
+}
@@ -0,0 +1,44 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/pipeline/pkg/common"
+)
+
+func TestGenerateBootstrapArguments(t *testing.T) {
+	t.Run("NoTaints", func(t *testing.T) {
+		args := generateBootstrapArguments("pool0", nil)
+
+		assert.Equal(t, "--kubelet-extra-args '--node-labels nodepool.banzaicloud.io/name=pool0'", args)
+	})
+
+	t.Run("Taints", func(t *testing.T) {
+		args := generateBootstrapArguments("pool0", common.Taints{
+			{Key: "dedicated", Value: "gpu", Effect: common.TaintEffectNoSchedule},
+			{Key: "batch", Effect: common.TaintEffectPreferNoSchedule},
+		})
+
+		assert.Equal(
+			t,
+			"--kubelet-extra-args '--node-labels nodepool.banzaicloud.io/name=pool0 --register-with-taints dedicated=gpu:NoSchedule,batch:PreferNoSchedule'",
+			args,
+		)
+	})
+}
@@ -65,6 +65,7 @@ func (a SaveNodePoolsActivity) Execute(ctx context.Context, input SaveNodePoolsA
 				np.NodeMinCount = asg.NodeMinCount
 				np.NodeMaxCount = asg.NodeMaxCount
 				np.Count = asg.Count
+				np.Taints = asg.Taints
 				updatedNodepools = append(updatedNodepools, np)
 			}
 		}
@@ -80,6 +81,7 @@ func (a SaveNodePoolsActivity) Execute(ctx context.Context, input SaveNodePoolsA
 				NodeMinCount:     asg.NodeMinCount,
 				NodeMaxCount:     asg.NodeMaxCount,
 				Count:            asg.Count,
+				Taints:           asg.Taints,
 				Delete:           false,
 			}
 			updatedNodepools = append(updatedNodepools, np)
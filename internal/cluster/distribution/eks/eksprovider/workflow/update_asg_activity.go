@@ -29,6 +29,7 @@ import (
 	"go.uber.org/cadence"
 	"go.uber.org/cadence/activity"
 
+	"github.com/banzaicloud/pipeline/pkg/common"
 	pkgCloudformation "github.com/banzaicloud/pipeline/pkg/providers/amazon/cloudformation"
 )
 
@@ -61,6 +62,7 @@ type UpdateAsgActivityInput struct {
 	NodeImage        string
 	NodeInstanceType string
 	Labels           map[string]string
+	Taints           common.Taints
 }
 
 // UpdateAsgActivityOutput holds the output data of the UpdateAsgActivityOutput
@@ -235,8 +237,8 @@ func (a *UpdateAsgActivity) Execute(ctx context.Context, input UpdateAsgActivity
 			ParameterValue: aws.String(fmt.Sprint(terminationDetachEnabled)),
 		},
 		{
-			ParameterKey:     aws.String("BootstrapArguments"),
-			UsePreviousValue: aws.Bool(true),
+			ParameterKey:   aws.String("BootstrapArguments"),
+			ParameterValue: aws.String(generateBootstrapArguments(input.Name, input.Taints)),
 		},
 	}
 
@@ -18,12 +18,14 @@ import (
 	"context"
 
 	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/pkg/common"
 )
 
 // NewNodePool describes new a Kubernetes node pool in an Amazon EKS cluster.
 type NewNodePool struct {
 	Name        string            `mapstructure:"name"`
 	Labels      map[string]string `mapstructure:"labels"`
+	Taints      common.Taints     `mapstructure:"taints"`
 	Size        int               `mapstructure:"size"`
 	Autoscaling struct {
 		Enabled bool `mapstructure:"enabled"`
@@ -0,0 +1,50 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+)
+
+// +mga:event:dispatcher
+
+// ClusterEvents dispatches cluster events.
+type ClusterEvents interface {
+	// ClusterCreated dispatches a ClusterCreated event.
+	ClusterCreated(ctx context.Context, event ClusterCreated) error
+
+	// ClusterUpdated dispatches a ClusterUpdated event.
+	ClusterUpdated(ctx context.Context, event ClusterUpdated) error
+
+	// ClusterDeleted dispatches a ClusterDeleted event.
+	ClusterDeleted(ctx context.Context, event ClusterDeleted) error
+}
+
+// ClusterCreated event is triggered when a cluster creation workflow finishes.
+type ClusterCreated struct {
+	ClusterID uint
+}
+
+// ClusterUpdated event is triggered when a cluster update workflow finishes
+// (regardless of whether the update succeeded or only partially succeeded).
+type ClusterUpdated struct {
+	ClusterID uint
+}
+
+// ClusterDeleted event is triggered when a cluster is completely deleted.
+type ClusterDeleted struct {
+	OrganizationID uint
+	ClusterName    string
+}
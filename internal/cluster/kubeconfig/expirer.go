@@ -0,0 +1,85 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+// Expirer revokes the expired credentials.
+type Expirer struct {
+	clusters ClusterStore
+	store    Store
+	issuer   Issuer
+	logger   common.Logger
+	now      func() time.Time
+}
+
+// NewExpirer returns a new Expirer.
+func NewExpirer(clusters ClusterStore, store Store, issuer Issuer, logger common.Logger) Expirer {
+	return Expirer{
+		clusters: clusters,
+		store:    store,
+		issuer:   issuer,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// RevokeExpired revokes every expired credential and returns the number of revoked credentials.
+// A failing cluster does not prevent revoking the credentials of the others.
+func (e Expirer) RevokeExpired(ctx context.Context) (int, error) {
+	now := e.now()
+
+	credentials, err := e.store.ListExpired(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	var revoked int
+	var errs []error
+
+	for _, credential := range credentials {
+		_, err := e.clusters.GetCluster(ctx, credential.ClusterID)
+		if errors.As(err, &cluster.NotFoundError{}) {
+			// the cluster is gone together with the credential
+			err = e.store.MarkRevoked(ctx, credential.ID, now.UTC())
+		} else if err == nil {
+			err = revoke(ctx, e.issuer, e.store, credential, now)
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		revoked++
+
+		e.logger.Info("expired kube config revoked", map[string]interface{}{
+			"clusterId":    credential.ClusterID,
+			"credentialId": credential.ID,
+			"userId":       credential.UserID,
+		})
+	}
+
+	return revoked, errors.Combine(errs...)
+}
@@ -0,0 +1,65 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+func TestExpirer_RevokeExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, time.March, 28, 12, 0, 0, 0, time.UTC)
+
+	live := Credential{ID: "1", ClusterID: 2}
+	deleted := Credential{ID: "2", ClusterID: 3}
+	failing := Credential{ID: "3", ClusterID: 4}
+
+	clusters := new(MockClusterStore)
+	clusters.On("GetCluster", ctx, uint(2)).Return(cluster.Cluster{ID: 2}, nil)
+	clusters.On("GetCluster", ctx, uint(3)).Return(cluster.Cluster{}, errors.WithStack(cluster.NotFoundError{ClusterID: 3}))
+	clusters.On("GetCluster", ctx, uint(4)).Return(cluster.Cluster{ID: 4}, nil)
+
+	store := new(MockStore)
+	store.On("ListExpired", ctx, now).Return([]Credential{live, deleted, failing}, nil)
+	store.On("MarkRevoked", ctx, "1", now).Return(nil)
+	store.On("MarkRevoked", ctx, "2", now).Return(nil)
+
+	issuer := new(MockIssuer)
+	issuer.On("Revoke", ctx, live).Return(nil)
+	issuer.On("Revoke", ctx, failing).Return(errors.New("cluster is unreachable"))
+
+	expirer := NewExpirer(clusters, store, issuer, common.NoopLogger{})
+	expirer.now = func() time.Time { return now }
+
+	revoked, err := expirer.RevokeExpired(ctx)
+	require.Error(t, err)
+
+	// the failing cluster does not prevent revoking the others
+	assert.Equal(t, 2, revoked)
+
+	store.AssertExpectations(t)
+	issuer.AssertExpectations(t)
+	store.AssertNotCalled(t, "MarkRevoked", ctx, "3", mock.Anything)
+}
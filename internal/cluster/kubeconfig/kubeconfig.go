@@ -0,0 +1,444 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/ghodss/yaml"
+	"github.com/gofrs/uuid"
+	"k8s.io/client-go/rest"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api/v1"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+// Config holds the settings of short-lived kube config issuance.
+type Config struct {
+	// DefaultTTL is the lifetime of a kube config when the request does not specify one
+	DefaultTTL time.Duration
+
+	// MaxTTL is the maximum lifetime of a kube config
+	MaxTTL time.Duration
+
+	// DefaultNamespace is used when the request does not specify a namespace
+	DefaultNamespace string
+
+	// ClusterRoles maps Pipeline organization roles to the Kubernetes cluster roles
+	// bound to the issued credentials in the requested namespace.
+	// Roles missing from the map cannot request kube configs.
+	ClusterRoles map[string]string
+}
+
+// Credential is a short-lived, namespace scoped cluster credential issued to a Pipeline user.
+type Credential struct {
+	ID             string `json:"id"`
+	OrganizationID uint   `json:"-"`
+	ClusterID      uint   `json:"clusterId"`
+
+	UserID    uint   `json:"userId"`
+	UserLogin string `json:"userLogin"`
+
+	// Role is the organization role of the user at the time of issuance
+	Role string `json:"role"`
+
+	// Namespace the credential is scoped to
+	Namespace string `json:"namespace"`
+
+	// ClusterRole is the Kubernetes cluster role bound to the credential in the namespace
+	ClusterRole string `json:"clusterRole"`
+
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	RevokedAt *time.Time `json:"revokedAt,omitempty"`
+}
+
+// Revoked tells whether the credential has been revoked.
+func (c Credential) Revoked() bool {
+	return c.RevokedAt != nil
+}
+
+// CreateKubeconfigOptions holds the optional parameters of an issued kube config.
+type CreateKubeconfigOptions struct {
+	// Namespace defaults to Config.DefaultNamespace
+	Namespace string
+
+	// TTL defaults to Config.DefaultTTL
+	TTL time.Duration
+}
+
+// Kubeconfig is an issued kube config.
+type Kubeconfig struct {
+	Credential Credential
+
+	// Data is the serialized kube config
+	Data []byte
+}
+
+// +kit:endpoint:errorStrategy=service
+// +testify:mock:testOnly=true
+
+// Service issues short-lived kube configs bound to the Pipeline identity of the caller.
+type Service interface {
+	// CreateKubeconfig issues a new kube config for the current user.
+	CreateKubeconfig(ctx context.Context, organizationID uint, clusterID uint, options CreateKubeconfigOptions) (kubeconfig Kubeconfig, err error)
+
+	// ListKubeconfigs lists the credentials issued for a cluster.
+	// Organization admins see every credential, members see only their own.
+	ListKubeconfigs(ctx context.Context, organizationID uint, clusterID uint) (credentials []Credential, err error)
+
+	// RevokeKubeconfig revokes an issued kube config.
+	// Organization admins can revoke any credential, members can revoke only their own.
+	RevokeKubeconfig(ctx context.Context, organizationID uint, clusterID uint, id string) (err error)
+}
+
+// +testify:mock:testOnly=true
+
+// Store persists issued credentials.
+type Store interface {
+	// Create saves a new credential.
+	Create(ctx context.Context, credential Credential) error
+
+	// Get returns a credential of a cluster.
+	// Returns a NotFoundError when the credential cannot be found.
+	Get(ctx context.Context, clusterID uint, id string) (Credential, error)
+
+	// List returns the credentials of a cluster that are neither revoked nor expired.
+	List(ctx context.Context, clusterID uint) ([]Credential, error)
+
+	// ListExpired returns the expired credentials that are not revoked yet.
+	ListExpired(ctx context.Context, now time.Time) ([]Credential, error)
+
+	// MarkRevoked records the revocation of a credential.
+	MarkRevoked(ctx context.Context, id string, revokedAt time.Time) error
+}
+
+// +testify:mock:testOnly=true
+
+// Issuer manages the credentials in the cluster.
+type Issuer interface {
+	// Issue creates the credential in the cluster and returns a bearer token for it.
+	Issue(ctx context.Context, credential Credential) (token string, err error)
+
+	// Revoke removes the credential from the cluster, invalidating its token.
+	Revoke(ctx context.Context, credential Credential) error
+}
+
+// +testify:mock:testOnly=true
+
+// ClusterStore returns clusters.
+type ClusterStore interface {
+	// GetCluster returns a generic Cluster.
+	// Returns a NotFoundError when the cluster cannot be found.
+	GetCluster(ctx context.Context, id uint) (cluster.Cluster, error)
+}
+
+// +testify:mock:testOnly=true
+
+// ConfigGetter returns the admin Kubernetes client configuration of a cluster.
+type ConfigGetter interface {
+	GetKubeConfig(ctx context.Context, clusterID uint) (*rest.Config, error)
+}
+
+// +testify:mock:testOnly=true
+
+// RoleSource returns the user's role in a given organization.
+type RoleSource interface {
+	// FindUserRole returns the user's role in a given organization.
+	// Returns false as the second parameter if the user is not a member of the organization.
+	FindUserRole(ctx context.Context, organizationID uint, userID uint) (string, bool, error)
+}
+
+// +testify:mock:testOnly=true
+
+// UserExtractor extracts user information from the context.
+type UserExtractor interface {
+	// GetUserID returns the ID of the currently authenticated user.
+	// If a user cannot be found in the context, it returns false as the second return value.
+	GetUserID(ctx context.Context) (uint, bool)
+
+	// GetUserLogin returns the login name of the currently authenticated user.
+	// If a user cannot be found in the context, it returns false as the second return value.
+	GetUserLogin(ctx context.Context) (string, bool)
+}
+
+// NewService returns a new Service.
+func NewService(
+	config Config,
+	users UserExtractor,
+	roles RoleSource,
+	clusters ClusterStore,
+	configs ConfigGetter,
+	store Store,
+	issuer Issuer,
+) Service {
+	return service{
+		config:   config,
+		users:    users,
+		roles:    roles,
+		clusters: clusters,
+		configs:  configs,
+		store:    store,
+		issuer:   issuer,
+		now:      time.Now,
+	}
+}
+
+type service struct {
+	config   Config
+	users    UserExtractor
+	roles    RoleSource
+	clusters ClusterStore
+	configs  ConfigGetter
+	store    Store
+	issuer   Issuer
+	now      func() time.Time
+}
+
+// caller is the authenticated user calling the service.
+type caller struct {
+	ID    uint
+	Login string
+	Role  string
+}
+
+func (s service) getCaller(ctx context.Context, organizationID uint) (caller, error) {
+	userID, ok := s.users.GetUserID(ctx)
+	if !ok || userID == 0 {
+		// virtual users (eg. cluster tokens) do not have a Pipeline identity to bind the credentials to
+		return caller{}, errors.WithStack(NotSupportedError{Reason: "kube configs can only be issued to users"})
+	}
+
+	login, _ := s.users.GetUserLogin(ctx)
+
+	role, member, err := s.roles.FindUserRole(ctx, organizationID, userID)
+	if err != nil {
+		return caller{}, errors.WrapIfWithDetails(err, "failed to get user role", "organizationId", organizationID, "userId", userID)
+	}
+
+	if !member {
+		return caller{}, errors.WithStack(NotSupportedError{Reason: "the user is not a member of the organization"})
+	}
+
+	return caller{ID: userID, Login: login, Role: role}, nil
+}
+
+func (s service) getCluster(ctx context.Context, organizationID uint, clusterID uint) (cluster.Cluster, error) {
+	c, err := s.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return c, err
+	}
+
+	if c.OrganizationID != organizationID {
+		return c, errors.WithStack(cluster.NotFoundError{OrganizationID: organizationID, ClusterID: clusterID})
+	}
+
+	return c, nil
+}
+
+func (s service) CreateKubeconfig(ctx context.Context, organizationID uint, clusterID uint, options CreateKubeconfigOptions) (Kubeconfig, error) {
+	user, err := s.getCaller(ctx, organizationID)
+	if err != nil {
+		return Kubeconfig{}, err
+	}
+
+	c, err := s.getCluster(ctx, organizationID, clusterID)
+	if err != nil {
+		return Kubeconfig{}, err
+	}
+
+	if options.Namespace == "" {
+		options.Namespace = s.config.DefaultNamespace
+	}
+
+	if options.TTL == 0 {
+		options.TTL = s.config.DefaultTTL
+	}
+
+	var violations []string
+
+	if options.TTL < 0 {
+		violations = append(violations, "ttl must be positive")
+	} else if s.config.MaxTTL > 0 && options.TTL > s.config.MaxTTL {
+		violations = append(violations, fmt.Sprintf("ttl must not be greater than %s", s.config.MaxTTL))
+	}
+
+	clusterRole, ok := s.config.ClusterRoles[user.Role]
+	if !ok || clusterRole == "" {
+		violations = append(violations, fmt.Sprintf("kube configs cannot be issued for the %q role", user.Role))
+	}
+
+	if len(violations) > 0 {
+		return Kubeconfig{}, errors.WithStack(NewValidationError("invalid kube config request", violations))
+	}
+
+	now := s.now().UTC()
+
+	credential := Credential{
+		ID:             uuid.Must(uuid.NewV4()).String(),
+		OrganizationID: organizationID,
+		ClusterID:      clusterID,
+		UserID:         user.ID,
+		UserLogin:      user.Login,
+		Role:           user.Role,
+		Namespace:      options.Namespace,
+		ClusterRole:    clusterRole,
+		CreatedAt:      now,
+		ExpiresAt:      now.Add(options.TTL),
+	}
+
+	config, err := s.configs.GetKubeConfig(ctx, clusterID)
+	if err != nil {
+		return Kubeconfig{}, errors.WrapIfWithDetails(err, "failed to get cluster config", "clusterId", clusterID)
+	}
+
+	// the credential is recorded first, so that it gets cleaned up by the expiry even if issuing fails halfway
+	if err := s.store.Create(ctx, credential); err != nil {
+		return Kubeconfig{}, err
+	}
+
+	token, err := s.issuer.Issue(ctx, credential)
+	if err != nil {
+		// clean up whatever has been created in the cluster, the expiry retries if this fails as well
+		_ = revoke(ctx, s.issuer, s.store, credential, s.now())
+
+		return Kubeconfig{}, errors.WrapIfWithDetails(err, "failed to issue cluster credential", "clusterId", clusterID, "credentialId", credential.ID)
+	}
+
+	data, err := renderKubeconfig(c.Name, user.Login, credential.Namespace, config, token)
+	if err != nil {
+		return Kubeconfig{}, err
+	}
+
+	return Kubeconfig{
+		Credential: credential,
+		Data:       data,
+	}, nil
+}
+
+func (s service) ListKubeconfigs(ctx context.Context, organizationID uint, clusterID uint) ([]Credential, error) {
+	user, err := s.getCaller(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getCluster(ctx, organizationID, clusterID); err != nil {
+		return nil, err
+	}
+
+	credentials, err := s.store.List(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == roleAdmin {
+		return credentials, nil
+	}
+
+	var owned []Credential
+
+	for _, credential := range credentials {
+		if credential.UserID == user.ID {
+			owned = append(owned, credential)
+		}
+	}
+
+	return owned, nil
+}
+
+func (s service) RevokeKubeconfig(ctx context.Context, organizationID uint, clusterID uint, id string) error {
+	user, err := s.getCaller(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.getCluster(ctx, organizationID, clusterID); err != nil {
+		return err
+	}
+
+	credential, err := s.store.Get(ctx, clusterID, id)
+	if err != nil {
+		return err
+	}
+
+	// members should not learn about the credentials of others
+	if user.Role != roleAdmin && credential.UserID != user.ID {
+		return errors.WithStack(NotFoundError{ClusterID: clusterID, ID: id})
+	}
+
+	if credential.Revoked() {
+		return nil
+	}
+
+	return revoke(ctx, s.issuer, s.store, credential, s.now())
+}
+
+func revoke(ctx context.Context, issuer Issuer, store Store, credential Credential, now time.Time) error {
+	if err := issuer.Revoke(ctx, credential); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to revoke cluster credential", "clusterId", credential.ClusterID, "credentialId", credential.ID)
+	}
+
+	return store.MarkRevoked(ctx, credential.ID, now.UTC())
+}
+
+// renderKubeconfig creates a kube config that connects to the same API server as the admin config using a bearer token.
+// The context defaults to the namespace the credential is scoped to.
+func renderKubeconfig(clusterName string, userLogin string, namespace string, config *rest.Config, token string) ([]byte, error) {
+	contextName := fmt.Sprintf("%s@%s", userLogin, clusterName)
+
+	kubeconfig := clientcmdapi.Config{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Clusters: []clientcmdapi.NamedCluster{
+			{
+				Name: clusterName,
+				Cluster: clientcmdapi.Cluster{
+					Server:                   config.Host,
+					CertificateAuthorityData: config.CAData,
+					InsecureSkipTLSVerify:    config.Insecure,
+				},
+			},
+		},
+		Contexts: []clientcmdapi.NamedContext{
+			{
+				Name: contextName,
+				Context: clientcmdapi.Context{
+					Cluster:   clusterName,
+					AuthInfo:  userLogin,
+					Namespace: namespace,
+				},
+			},
+		},
+		AuthInfos: []clientcmdapi.NamedAuthInfo{
+			{
+				Name: userLogin,
+				AuthInfo: clientcmdapi.AuthInfo{
+					Token: token,
+				},
+			},
+		},
+		CurrentContext: contextName,
+	}
+
+	data, err := yaml.Marshal(kubeconfig)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to serialize kube config")
+	}
+
+	return data, nil
+}
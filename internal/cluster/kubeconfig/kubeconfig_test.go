@@ -0,0 +1,237 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+func newTestConfig() Config {
+	return Config{
+		DefaultTTL:       8 * time.Hour,
+		MaxTTL:           24 * time.Hour,
+		DefaultNamespace: "default",
+		ClusterRoles: map[string]string{
+			"admin":  "admin",
+			"member": "view",
+		},
+	}
+}
+
+func newTestUsers(userID uint, login string) *MockUserExtractor {
+	users := new(MockUserExtractor)
+	users.On("GetUserID", mock.Anything).Return(userID, true)
+	users.On("GetUserLogin", mock.Anything).Return(login, true)
+
+	return users
+}
+
+func newTestClusters() *MockClusterStore {
+	clusters := new(MockClusterStore)
+	clusters.On("GetCluster", mock.Anything, uint(2)).Return(cluster.Cluster{ID: 2, Name: "cluster", OrganizationID: 1}, nil)
+
+	return clusters
+}
+
+func TestService_CreateKubeconfig(t *testing.T) {
+	t.Run("Member", func(t *testing.T) {
+		ctx := context.Background()
+
+		roles := new(MockRoleSource)
+		roles.On("FindUserRole", ctx, uint(1), uint(3)).Return("member", true, nil)
+
+		configs := new(MockConfigGetter)
+		configs.On("GetKubeConfig", ctx, uint(2)).Return(&rest.Config{Host: "https://127.0.0.1:6443", TLSClientConfig: rest.TLSClientConfig{CAData: []byte("ca")}}, nil)
+
+		matchCredential := mock.MatchedBy(func(c Credential) bool {
+			return c.ClusterID == 2 && c.OrganizationID == 1 && c.UserID == 3 && c.UserLogin == "john.doe" &&
+				c.Namespace == "default" && c.ClusterRole == "view" && c.ExpiresAt.Sub(c.CreatedAt) == 8*time.Hour
+		})
+
+		store := new(MockStore)
+		store.On("Create", ctx, matchCredential).Return(nil)
+
+		issuer := new(MockIssuer)
+		issuer.On("Issue", ctx, matchCredential).Return("token", nil)
+
+		service := NewService(newTestConfig(), newTestUsers(3, "john.doe"), roles, newTestClusters(), configs, store, issuer)
+
+		kubeconfig, err := service.CreateKubeconfig(ctx, 1, 2, CreateKubeconfigOptions{})
+		require.NoError(t, err)
+
+		config, err := clientcmd.Load(kubeconfig.Data)
+		require.NoError(t, err)
+
+		assert.Equal(t, "john.doe@cluster", config.CurrentContext)
+		assert.Equal(t, "https://127.0.0.1:6443", config.Clusters["cluster"].Server)
+		assert.Equal(t, []byte("ca"), config.Clusters["cluster"].CertificateAuthorityData)
+		assert.Equal(t, "token", config.AuthInfos["john.doe"].Token)
+		assert.Equal(t, "default", config.Contexts["john.doe@cluster"].Namespace)
+
+		store.AssertExpectations(t)
+		issuer.AssertExpectations(t)
+	})
+
+	t.Run("TTLTooLong", func(t *testing.T) {
+		ctx := context.Background()
+
+		roles := new(MockRoleSource)
+		roles.On("FindUserRole", ctx, uint(1), uint(3)).Return("admin", true, nil)
+
+		service := NewService(newTestConfig(), newTestUsers(3, "john.doe"), roles, newTestClusters(), new(MockConfigGetter), new(MockStore), new(MockIssuer))
+
+		_, err := service.CreateKubeconfig(ctx, 1, 2, CreateKubeconfigOptions{TTL: 48 * time.Hour})
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &ValidationError{}))
+	})
+
+	t.Run("RoleNotMapped", func(t *testing.T) {
+		ctx := context.Background()
+
+		config := newTestConfig()
+		delete(config.ClusterRoles, "member")
+
+		roles := new(MockRoleSource)
+		roles.On("FindUserRole", ctx, uint(1), uint(3)).Return("member", true, nil)
+
+		service := NewService(config, newTestUsers(3, "john.doe"), roles, newTestClusters(), new(MockConfigGetter), new(MockStore), new(MockIssuer))
+
+		_, err := service.CreateKubeconfig(ctx, 1, 2, CreateKubeconfigOptions{})
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &ValidationError{}))
+	})
+
+	t.Run("OtherOrganization", func(t *testing.T) {
+		ctx := context.Background()
+
+		roles := new(MockRoleSource)
+		roles.On("FindUserRole", ctx, uint(4), uint(3)).Return("admin", true, nil)
+
+		service := NewService(newTestConfig(), newTestUsers(3, "john.doe"), roles, newTestClusters(), new(MockConfigGetter), new(MockStore), new(MockIssuer))
+
+		_, err := service.CreateKubeconfig(ctx, 4, 2, CreateKubeconfigOptions{})
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &cluster.NotFoundError{}))
+	})
+
+	t.Run("VirtualUser", func(t *testing.T) {
+		ctx := context.Background()
+
+		service := NewService(newTestConfig(), newTestUsers(0, "clusters/1/2"), new(MockRoleSource), newTestClusters(), new(MockConfigGetter), new(MockStore), new(MockIssuer))
+
+		_, err := service.CreateKubeconfig(ctx, 1, 2, CreateKubeconfigOptions{})
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &NotSupportedError{}))
+	})
+}
+
+func TestService_ListKubeconfigs(t *testing.T) {
+	credentials := []Credential{
+		{ID: "1", ClusterID: 2, UserID: 3},
+		{ID: "2", ClusterID: 2, UserID: 4},
+	}
+
+	t.Run("Admin", func(t *testing.T) {
+		ctx := context.Background()
+
+		roles := new(MockRoleSource)
+		roles.On("FindUserRole", ctx, uint(1), uint(3)).Return("admin", true, nil)
+
+		store := new(MockStore)
+		store.On("List", ctx, uint(2)).Return(credentials, nil)
+
+		service := NewService(newTestConfig(), newTestUsers(3, "john.doe"), roles, newTestClusters(), new(MockConfigGetter), store, new(MockIssuer))
+
+		list, err := service.ListKubeconfigs(ctx, 1, 2)
+		require.NoError(t, err)
+
+		assert.Equal(t, credentials, list)
+	})
+
+	t.Run("Member", func(t *testing.T) {
+		ctx := context.Background()
+
+		roles := new(MockRoleSource)
+		roles.On("FindUserRole", ctx, uint(1), uint(3)).Return("member", true, nil)
+
+		store := new(MockStore)
+		store.On("List", ctx, uint(2)).Return(credentials, nil)
+
+		service := NewService(newTestConfig(), newTestUsers(3, "john.doe"), roles, newTestClusters(), new(MockConfigGetter), store, new(MockIssuer))
+
+		list, err := service.ListKubeconfigs(ctx, 1, 2)
+		require.NoError(t, err)
+
+		assert.Equal(t, credentials[:1], list)
+	})
+}
+
+func TestService_RevokeKubeconfig(t *testing.T) {
+	credential := Credential{ID: "1", ClusterID: 2, UserID: 4}
+
+	t.Run("Admin", func(t *testing.T) {
+		ctx := context.Background()
+
+		roles := new(MockRoleSource)
+		roles.On("FindUserRole", ctx, uint(1), uint(3)).Return("admin", true, nil)
+
+		store := new(MockStore)
+		store.On("Get", ctx, uint(2), "1").Return(credential, nil)
+		store.On("MarkRevoked", ctx, "1", mock.Anything).Return(nil)
+
+		issuer := new(MockIssuer)
+		issuer.On("Revoke", ctx, credential).Return(nil)
+
+		service := NewService(newTestConfig(), newTestUsers(3, "john.doe"), roles, newTestClusters(), new(MockConfigGetter), store, issuer)
+
+		err := service.RevokeKubeconfig(ctx, 1, 2, "1")
+		require.NoError(t, err)
+
+		store.AssertExpectations(t)
+		issuer.AssertExpectations(t)
+	})
+
+	t.Run("MemberNotOwner", func(t *testing.T) {
+		ctx := context.Background()
+
+		roles := new(MockRoleSource)
+		roles.On("FindUserRole", ctx, uint(1), uint(3)).Return("member", true, nil)
+
+		store := new(MockStore)
+		store.On("Get", ctx, uint(2), "1").Return(credential, nil)
+
+		service := NewService(newTestConfig(), newTestUsers(3, "john.doe"), roles, newTestClusters(), new(MockConfigGetter), store, new(MockIssuer))
+
+		err := service.RevokeKubeconfig(ctx, 1, 2, "1")
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &NotFoundError{}))
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfigadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig"
+)
+
+// credentialModel is the persisted form of an issued kube config credential.
+type credentialModel struct {
+	ID             string `gorm:"primary_key;size:36"`
+	OrganizationID uint
+	ClusterID      uint `gorm:"index:idx_kubeconfig_credentials_cluster_id"`
+	UserID         uint
+	UserLogin      string
+	Role           string
+	Namespace      string
+	ClusterRole    string
+	CreatedAt      time.Time
+	ExpiresAt      time.Time `gorm:"index:idx_kubeconfig_credentials_expires_at"`
+	RevokedAt      *time.Time
+}
+
+// TableName changes the default table name.
+func (credentialModel) TableName() string {
+	return "cluster_kubeconfig_credentials"
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a new kubeconfig.Store backed by a relational database.
+func NewGormStore(db *gorm.DB) kubeconfig.Store {
+	return gormStore{
+		db: db,
+	}
+}
+
+func (s gormStore) Create(_ context.Context, credential kubeconfig.Credential) error {
+	model := credentialModel{
+		ID:             credential.ID,
+		OrganizationID: credential.OrganizationID,
+		ClusterID:      credential.ClusterID,
+		UserID:         credential.UserID,
+		UserLogin:      credential.UserLogin,
+		Role:           credential.Role,
+		Namespace:      credential.Namespace,
+		ClusterRole:    credential.ClusterRole,
+		CreatedAt:      credential.CreatedAt,
+		ExpiresAt:      credential.ExpiresAt,
+	}
+
+	if err := s.db.Create(&model).Error; err != nil {
+		return errors.WrapIfWithDetails(
+			err, "failed to create kube config credential",
+			"clusterId", credential.ClusterID,
+			"credentialId", credential.ID,
+		)
+	}
+
+	return nil
+}
+
+func (s gormStore) Get(_ context.Context, clusterID uint, id string) (kubeconfig.Credential, error) {
+	var model credentialModel
+
+	err := s.db.Where(credentialModel{ClusterID: clusterID, ID: id}).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return kubeconfig.Credential{}, errors.WithStack(kubeconfig.NotFoundError{ClusterID: clusterID, ID: id})
+	} else if err != nil {
+		return kubeconfig.Credential{}, errors.WrapIfWithDetails(
+			err, "failed to get kube config credential",
+			"clusterId", clusterID,
+			"credentialId", id,
+		)
+	}
+
+	return toCredential(model), nil
+}
+
+func (s gormStore) List(_ context.Context, clusterID uint) ([]kubeconfig.Credential, error) {
+	var models []credentialModel
+
+	err := s.db.
+		Where("cluster_id = ? AND revoked_at IS NULL AND expires_at > ?", clusterID, time.Now()).
+		Order("created_at").
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list kube config credentials", "clusterId", clusterID)
+	}
+
+	return toCredentials(models), nil
+}
+
+func (s gormStore) ListExpired(_ context.Context, now time.Time) ([]kubeconfig.Credential, error) {
+	var models []credentialModel
+
+	err := s.db.
+		Where("revoked_at IS NULL AND expires_at <= ?", now).
+		Order("expires_at").
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list expired kube config credentials")
+	}
+
+	return toCredentials(models), nil
+}
+
+func (s gormStore) MarkRevoked(_ context.Context, id string, revokedAt time.Time) error {
+	err := s.db.Model(&credentialModel{ID: id}).Update("revoked_at", revokedAt).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to revoke kube config credential", "credentialId", id)
+	}
+
+	return nil
+}
+
+func toCredentials(models []credentialModel) []kubeconfig.Credential {
+	credentials := make([]kubeconfig.Credential, 0, len(models))
+
+	for _, model := range models {
+		credentials = append(credentials, toCredential(model))
+	}
+
+	return credentials
+}
+
+func toCredential(model credentialModel) kubeconfig.Credential {
+	return kubeconfig.Credential{
+		ID:             model.ID,
+		OrganizationID: model.OrganizationID,
+		ClusterID:      model.ClusterID,
+		UserID:         model.UserID,
+		UserLogin:      model.UserLogin,
+		Role:           model.Role,
+		Namespace:      model.Namespace,
+		ClusterRole:    model.ClusterRole,
+		CreatedAt:      model.CreatedAt,
+		ExpiresAt:      model.ExpiresAt,
+		RevokedAt:      model.RevokedAt,
+	}
+}
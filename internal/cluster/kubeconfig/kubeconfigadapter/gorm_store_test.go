@@ -0,0 +1,104 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfigadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig"
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, common.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore(t *testing.T) {
+	db := setUpDatabase(t)
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	active := kubeconfig.Credential{
+		ID:             "c7a5a3b4-1f0e-4a51-a0f6-3b0f3f1c2d01",
+		OrganizationID: 1,
+		ClusterID:      2,
+		UserID:         3,
+		UserLogin:      "john.doe",
+		Role:           "member",
+		Namespace:      "default",
+		ClusterRole:    "view",
+		CreatedAt:      now.Add(-time.Hour),
+		ExpiresAt:      now.Add(time.Hour),
+	}
+
+	expired := active
+	expired.ID = "c7a5a3b4-1f0e-4a51-a0f6-3b0f3f1c2d02"
+	expired.ExpiresAt = now.Add(-time.Minute)
+
+	require.NoError(t, store.Create(ctx, active))
+	require.NoError(t, store.Create(ctx, expired))
+
+	stored, err := store.Get(ctx, 2, active.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, active.UserLogin, stored.UserLogin)
+	assert.Equal(t, active.ClusterRole, stored.ClusterRole)
+	assert.True(t, active.ExpiresAt.Equal(stored.ExpiresAt))
+	assert.False(t, stored.Revoked())
+
+	_, err = store.Get(ctx, 3, active.ID)
+	assert.True(t, errors.As(err, &kubeconfig.NotFoundError{}))
+
+	credentials, err := store.List(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, credentials, 1)
+	assert.Equal(t, active.ID, credentials[0].ID)
+
+	credentials, err = store.ListExpired(ctx, now)
+	require.NoError(t, err)
+	require.Len(t, credentials, 1)
+	assert.Equal(t, expired.ID, credentials[0].ID)
+
+	require.NoError(t, store.MarkRevoked(ctx, expired.ID, now))
+	require.NoError(t, store.MarkRevoked(ctx, active.ID, now))
+
+	credentials, err = store.ListExpired(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, credentials)
+
+	credentials, err = store.List(ctx, 2)
+	require.NoError(t, err)
+	assert.Empty(t, credentials)
+
+	stored, err = store.Get(ctx, 2, active.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.Revoked())
+}
@@ -0,0 +1,195 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfigadapter
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+const (
+	credentialIDLabel = "kubeconfig.banzaicloud.io/credential-id"
+	userIDLabel       = "kubeconfig.banzaicloud.io/user-id"
+	userLoginKey      = "kubeconfig.banzaicloud.io/user-login"
+	expiresAtKey      = "kubeconfig.banzaicloud.io/expires-at"
+)
+
+// ClientFactory returns a Kubernetes client for a cluster.
+type ClientFactory interface {
+	// FromClusterID creates a Kubernetes client for a cluster from a cluster ID.
+	FromClusterID(ctx context.Context, clusterID uint) (kubernetes.Interface, error)
+}
+
+// ServiceAccountIssuer issues credentials as service account tokens bound to a cluster role in the requested namespace.
+// Deleting the service account invalidates its token, so credentials can be revoked any time.
+type ServiceAccountIssuer struct {
+	clientFactory ClientFactory
+
+	// namespace the service accounts are created in
+	namespace string
+
+	// tokenTimeout is the time to wait for the token controller to populate the token secret
+	tokenTimeout time.Duration
+}
+
+// NewServiceAccountIssuer returns a new ServiceAccountIssuer.
+func NewServiceAccountIssuer(clientFactory ClientFactory, namespace string) ServiceAccountIssuer {
+	return ServiceAccountIssuer{
+		clientFactory: clientFactory,
+		namespace:     namespace,
+		tokenTimeout:  30 * time.Second,
+	}
+}
+
+func (i ServiceAccountIssuer) newClient(ctx context.Context, credential kubeconfig.Credential) (kubernetes.Interface, error) {
+	// the cluster config is stored as a secret of the organization
+	ctx = auth.SetCurrentOrganizationID(ctx, credential.OrganizationID)
+
+	client, err := i.clientFactory.FromClusterID(ctx, credential.ClusterID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to create Kubernetes client", "clusterId", credential.ClusterID)
+	}
+
+	return client, nil
+}
+
+// Issue creates a service account, binds the cluster role of the credential to it and returns its token.
+func (i ServiceAccountIssuer) Issue(ctx context.Context, credential kubeconfig.Credential) (string, error) {
+	client, err := i.newClient(ctx, credential)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = client.CoreV1().Namespaces().Create(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: i.namespace}})
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", errors.WrapIfWithDetails(err, "failed to create namespace", "namespace", i.namespace)
+	}
+
+	name := resourceName(credential)
+	meta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: i.namespace,
+		Labels: map[string]string{
+			"app.kubernetes.io/managed-by": "pipeline",
+			credentialIDLabel:              credential.ID,
+			userIDLabel:                    strconv.FormatUint(uint64(credential.UserID), 10),
+		},
+		Annotations: map[string]string{
+			userLoginKey: credential.UserLogin,
+			expiresAtKey: credential.ExpiresAt.Format(time.RFC3339),
+		},
+	}
+
+	_, err = client.CoreV1().ServiceAccounts(i.namespace).Create(&corev1.ServiceAccount{ObjectMeta: meta})
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "failed to create service account", "serviceAccount", name)
+	}
+
+	secretMeta := *meta.DeepCopy()
+	secretMeta.Annotations[corev1.ServiceAccountNameKey] = name
+
+	_, err = client.CoreV1().Secrets(i.namespace).Create(&corev1.Secret{
+		ObjectMeta: secretMeta,
+		Type:       corev1.SecretTypeServiceAccountToken,
+	})
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "failed to create service account token", "serviceAccount", name)
+	}
+
+	bindingMeta := *meta.DeepCopy()
+	bindingMeta.Namespace = credential.Namespace
+
+	_, err = client.RbacV1().RoleBindings(credential.Namespace).Create(&rbacv1.RoleBinding{
+		ObjectMeta: bindingMeta,
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     credential.ClusterRole,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      name,
+				Namespace: i.namespace,
+			},
+		},
+	})
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "failed to create role binding", "namespace", credential.Namespace, "roleBinding", name)
+	}
+
+	var token string
+
+	err = wait.PollImmediate(500*time.Millisecond, i.tokenTimeout, func() (bool, error) {
+		secret, err := client.CoreV1().Secrets(i.namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+
+		token = string(secret.Data[corev1.ServiceAccountTokenKey])
+
+		return token != "", nil
+	})
+	if err != nil {
+		return "", errors.WrapIfWithDetails(err, "failed to get service account token", "serviceAccount", name)
+	}
+
+	return token, nil
+}
+
+// Revoke deletes the service account, its token and role binding.
+func (i ServiceAccountIssuer) Revoke(ctx context.Context, credential kubeconfig.Credential) error {
+	client, err := i.newClient(ctx, credential)
+	if err != nil {
+		return err
+	}
+
+	name := resourceName(credential)
+
+	err = client.RbacV1().RoleBindings(credential.Namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.WrapIfWithDetails(err, "failed to delete role binding", "namespace", credential.Namespace, "roleBinding", name)
+	}
+
+	err = client.CoreV1().ServiceAccounts(i.namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.WrapIfWithDetails(err, "failed to delete service account", "serviceAccount", name)
+	}
+
+	// the token controller deletes the token of a deleted service account as well, this is just making sure
+	err = client.CoreV1().Secrets(i.namespace).Delete(name, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.WrapIfWithDetails(err, "failed to delete service account token", "serviceAccount", name)
+	}
+
+	return nil
+}
+
+// resourceName returns the name of the Kubernetes resources created for a credential.
+func resourceName(credential kubeconfig.Credential) string {
+	return "pipeline-kubeconfig-" + credential.ID
+}
@@ -0,0 +1,99 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfigadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig"
+)
+
+type fakeClientFactory struct {
+	client kubernetes.Interface
+}
+
+func (f fakeClientFactory) FromClusterID(_ context.Context, _ uint) (kubernetes.Interface, error) {
+	return f.client, nil
+}
+
+func TestServiceAccountIssuer(t *testing.T) {
+	ctx := context.Background()
+
+	client := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev"}})
+
+	// the token controller populates service account tokens
+	client.PrependReactor("create", "secrets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		secret := action.(k8stesting.CreateAction).GetObject().(*corev1.Secret)
+		if secret.Type == corev1.SecretTypeServiceAccountToken {
+			secret.Data = map[string][]byte{corev1.ServiceAccountTokenKey: []byte("token")}
+		}
+
+		return false, nil, nil
+	})
+
+	issuer := NewServiceAccountIssuer(fakeClientFactory{client: client}, "pipeline-system")
+	issuer.tokenTimeout = time.Second
+
+	credential := kubeconfig.Credential{
+		ID:          "c7a5a3b4-1f0e-4a51-a0f6-3b0f3f1c2d01",
+		ClusterID:   2,
+		UserID:      3,
+		UserLogin:   "john.doe",
+		Namespace:   "dev",
+		ClusterRole: "edit",
+		ExpiresAt:   time.Date(2020, time.March, 28, 12, 0, 0, 0, time.UTC),
+	}
+	name := "pipeline-kubeconfig-" + credential.ID
+
+	token, err := issuer.Issue(ctx, credential)
+	require.NoError(t, err)
+
+	assert.Equal(t, "token", token)
+
+	serviceAccount, err := client.CoreV1().ServiceAccounts("pipeline-system").Get(name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "3", serviceAccount.Labels[userIDLabel])
+	assert.Equal(t, "2020-03-28T12:00:00Z", serviceAccount.Annotations[expiresAtKey])
+
+	binding, err := client.RbacV1().RoleBindings("dev").Get(name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "edit", binding.RoleRef.Name)
+	require.Len(t, binding.Subjects, 1)
+	assert.Equal(t, name, binding.Subjects[0].Name)
+	assert.Equal(t, "pipeline-system", binding.Subjects[0].Namespace)
+
+	require.NoError(t, issuer.Revoke(ctx, credential))
+
+	_, err = client.CoreV1().ServiceAccounts("pipeline-system").Get(name, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	_, err = client.RbacV1().RoleBindings("dev").Get(name, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// revoking is idempotent
+	require.NoError(t, issuer.Revoke(ctx, credential))
+}
@@ -0,0 +1,177 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfigdriver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+)
+
+// RegisterHTTPHandlers mounts all of the service endpoints into an http.Handler.
+// The router is expected to be an organization router (ie. /orgs/{orgId}).
+func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
+
+	router.Methods(http.MethodPost).Path("/clusters/{clusterId}/kubeconfigs").Handler(kithttp.NewServer(
+		endpoints.CreateKubeconfig,
+		decodeCreateKubeconfigHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeCreateKubeconfigHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/clusters/{clusterId}/kubeconfigs").Handler(kithttp.NewServer(
+		endpoints.ListKubeconfigs,
+		decodeListKubeconfigsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListKubeconfigsHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodDelete).Path("/clusters/{clusterId}/kubeconfigs/{kubeconfigId}").Handler(kithttp.NewServer(
+		endpoints.RevokeKubeconfig,
+		decodeRevokeKubeconfigHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusNoContent), errorEncoder),
+		options...,
+	))
+}
+
+// createKubeconfigRequest is the body of a kube config request.
+type createKubeconfigRequest struct {
+	Namespace string `json:"namespace"`
+
+	// TTL is a duration string (eg. 8h)
+	TTL string `json:"ttl"`
+}
+
+// kubeconfigResponse is an issued kube config along with its credential details.
+type kubeconfigResponse struct {
+	kubeconfig.Credential
+
+	Kubeconfig string `json:"kubeconfig"`
+}
+
+func decodeCreateKubeconfigHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	clusterID, err := extractUintParam(r, "clusterId")
+	if err != nil {
+		return nil, err
+	}
+
+	var request createKubeconfigRequest
+
+	// every field is optional, so is the body
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	options := kubeconfig.CreateKubeconfigOptions{
+		Namespace: request.Namespace,
+	}
+
+	if request.TTL != "" {
+		options.TTL, err = time.ParseDuration(request.TTL)
+		if err != nil {
+			return nil, kubeconfig.NewValidationError("invalid kube config request", []string{"invalid ttl: " + request.TTL})
+		}
+	}
+
+	return CreateKubeconfigRequest{OrganizationID: orgID, ClusterID: clusterID, Options: options}, nil
+}
+
+func encodeCreateKubeconfigHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(CreateKubeconfigResponse)
+
+	body := kubeconfigResponse{
+		Credential: resp.Kubeconfig.Credential,
+		Kubeconfig: string(resp.Kubeconfig.Data),
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, kitxhttp.WithStatusCode(body, http.StatusCreated))
+}
+
+func decodeListKubeconfigsHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	clusterID, err := extractUintParam(r, "clusterId")
+	if err != nil {
+		return nil, err
+	}
+
+	return ListKubeconfigsRequest{OrganizationID: orgID, ClusterID: clusterID}, nil
+}
+
+func encodeListKubeconfigsHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListKubeconfigsResponse)
+
+	if resp.Credentials == nil {
+		resp.Credentials = []kubeconfig.Credential{}
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Credentials)
+}
+
+func decodeRevokeKubeconfigHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	clusterID, err := extractUintParam(r, "clusterId")
+	if err != nil {
+		return nil, err
+	}
+
+	id := mux.Vars(r)["kubeconfigId"]
+	if id == "" {
+		return nil, errors.NewWithDetails("missing path parameter", "param", "kubeconfigId")
+	}
+
+	return RevokeKubeconfigRequest{OrganizationID: orgID, ClusterID: clusterID, Id: id}, nil
+}
+
+func extractUintParam(r *http.Request, param string) (uint, error) {
+	vars := mux.Vars(r)
+
+	value, ok := vars[param]
+	if !ok || value == "" {
+		return 0, errors.NewWithDetails("missing path parameter", "param", param)
+	}
+
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "invalid path parameter", "param", param, "value", value)
+	}
+
+	return uint(id), nil
+}
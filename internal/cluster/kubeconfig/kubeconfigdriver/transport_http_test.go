@@ -0,0 +1,119 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfigdriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+)
+
+func newTestServer(endpoints Endpoints) *httptest.Server {
+	handler := mux.NewRouter()
+	RegisterHTTPHandlers(
+		endpoints,
+		handler.PathPrefix("/orgs/{orgId}").Subrouter(),
+		kithttp.ServerErrorEncoder(kitxhttp.NewJSONProblemErrorEncoder(apphttp.NewDefaultProblemConverter())),
+	)
+
+	return httptest.NewServer(handler)
+}
+
+func TestRegisterHTTPHandlers_CreateKubeconfig(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		CreateKubeconfig: func(ctx context.Context, request interface{}) (interface{}, error) {
+			expected := CreateKubeconfigRequest{
+				OrganizationID: 1,
+				ClusterID:      2,
+				Options:        kubeconfig.CreateKubeconfigOptions{Namespace: "dev", TTL: 2 * time.Hour},
+			}
+
+			if !assert.Equal(t, expected, request) {
+				return nil, nil
+			}
+
+			return CreateKubeconfigResponse{
+				Kubeconfig: kubeconfig.Kubeconfig{
+					Credential: kubeconfig.Credential{ID: "id", ClusterID: 2, Namespace: "dev", ClusterRole: "edit"},
+					Data:       []byte("apiVersion: v1"),
+				},
+			}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/orgs/1/clusters/2/kubeconfigs", "application/json", strings.NewReader(`{"namespace":"dev","ttl":"2h"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	assert.Equal(t, "id", body["id"])
+	assert.Equal(t, "edit", body["clusterRole"])
+	assert.Equal(t, "apiVersion: v1", body["kubeconfig"])
+}
+
+func TestRegisterHTTPHandlers_CreateKubeconfig_InvalidTTL(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		CreateKubeconfig: func(ctx context.Context, request interface{}) (interface{}, error) {
+			t.Error("the endpoint should not be called")
+
+			return nil, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/orgs/1/clusters/2/kubeconfigs", "application/json", strings.NewReader(`{"ttl":"forever"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestRegisterHTTPHandlers_RevokeKubeconfig(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		RevokeKubeconfig: func(ctx context.Context, request interface{}) (interface{}, error) {
+			assert.Equal(t, RevokeKubeconfigRequest{OrganizationID: 1, ClusterID: 2, Id: "id"}, request)
+
+			return RevokeKubeconfigResponse{}, nil
+		},
+	})
+	defer ts.Close()
+
+	req, err := http.NewRequest(http.MethodDelete, ts.URL+"/orgs/1/clusters/2/kubeconfigs/id", nil)
+	require.NoError(t, err)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
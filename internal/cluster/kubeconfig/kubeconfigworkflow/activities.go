@@ -0,0 +1,47 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfigworkflow
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeconfig"
+)
+
+const RevokeExpiredActivityName = "cluster-kubeconfig-revoke-expired-activity"
+
+type RevokeExpiredActivityInput struct{}
+
+type RevokeExpiredActivityOutput struct {
+	Revoked int
+}
+
+// RevokeExpiredActivity revokes the expired kube configs.
+type RevokeExpiredActivity struct {
+	expirer kubeconfig.Expirer
+}
+
+// NewRevokeExpiredActivity returns a new RevokeExpiredActivity.
+func NewRevokeExpiredActivity(expirer kubeconfig.Expirer) RevokeExpiredActivity {
+	return RevokeExpiredActivity{
+		expirer: expirer,
+	}
+}
+
+func (a RevokeExpiredActivity) Execute(ctx context.Context, _ RevokeExpiredActivityInput) (RevokeExpiredActivityOutput, error) {
+	revoked, err := a.expirer.RevokeExpired(ctx)
+
+	return RevokeExpiredActivityOutput{Revoked: revoked}, err
+}
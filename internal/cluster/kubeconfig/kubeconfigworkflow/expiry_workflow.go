@@ -0,0 +1,49 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfigworkflow
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/workflow"
+)
+
+const ExpiryWorkflowName = "cluster-kubeconfig-expiry"
+
+// ExpiryWorkflow revokes the expired kube configs of every cluster.
+// It is supposed to be scheduled as a cron workflow.
+func ExpiryWorkflow(ctx workflow.Context) error {
+	logger := workflow.GetLogger(ctx).Sugar()
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		WaitForCancellation:    true,
+	})
+
+	var output RevokeExpiredActivityOutput
+
+	err := workflow.ExecuteActivity(ctx, RevokeExpiredActivityName, RevokeExpiredActivityInput{}).Get(ctx, &output)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to execute activity", "activity", RevokeExpiredActivityName)
+	}
+
+	if output.Revoked > 0 {
+		logger.Infow("expired kube configs revoked", "revoked", output.Revoked)
+	}
+
+	return nil
+}
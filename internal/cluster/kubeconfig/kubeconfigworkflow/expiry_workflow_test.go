@@ -0,0 +1,69 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfigworkflow
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+)
+
+func testRevokeExpiredActivityExecute(_ context.Context, _ RevokeExpiredActivityInput) (RevokeExpiredActivityOutput, error) {
+	return RevokeExpiredActivityOutput{}, nil
+}
+
+// nolint: gochecknoinits
+func init() {
+	workflow.RegisterWithOptions(ExpiryWorkflow, workflow.RegisterOptions{Name: ExpiryWorkflowName})
+
+	activity.RegisterWithOptions(testRevokeExpiredActivityExecute, activity.RegisterOptions{Name: RevokeExpiredActivityName})
+}
+
+func TestExpiryWorkflow(t *testing.T) {
+	var testSuite testsuite.WorkflowTestSuite
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	env.OnActivity(RevokeExpiredActivityName, mock.Anything, RevokeExpiredActivityInput{}).
+		Return(RevokeExpiredActivityOutput{Revoked: 2}, nil)
+
+	env.ExecuteWorkflow(ExpiryWorkflowName)
+
+	require.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+
+	env.AssertExpectations(t)
+}
+
+func TestExpiryWorkflow_Error(t *testing.T) {
+	var testSuite testsuite.WorkflowTestSuite
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	env.OnActivity(RevokeExpiredActivityName, mock.Anything, RevokeExpiredActivityInput{}).
+		Return(RevokeExpiredActivityOutput{}, errors.New("cluster is unreachable"))
+
+	env.ExecuteWorkflow(ExpiryWorkflowName)
+
+	require.True(t, env.IsWorkflowCompleted())
+	assert.Error(t, env.GetWorkflowError())
+
+	env.AssertExpectations(t)
+}
@@ -0,0 +1,301 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeproxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"emperror.dev/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AuditRecordKey is the key of the AuditRecord of a proxied request in the request (Gin) context.
+const AuditRecordKey = "kubeProxyAuditRecord"
+
+const (
+	roleAdmin = "admin"
+
+	impersonateUserHeader        = "Impersonate-User"
+	impersonateGroupHeader       = "Impersonate-Group"
+	impersonateUIDHeader         = "Impersonate-Uid"
+	impersonateExtraHeaderPrefix = "Impersonate-Extra-"
+)
+
+// Config contains the access control configuration of the Kubernetes API proxy.
+type Config struct {
+	// UserPrefix is prepended to the Pipeline user login to form the impersonated Kubernetes user name.
+	UserPrefix string
+
+	// Roles configures the impersonated groups and the allowed requests of each Pipeline organization role.
+	Roles map[string]RoleConfig
+}
+
+// RoleConfig configures the proxy access of a Pipeline organization role.
+type RoleConfig struct {
+	// Groups are the Kubernetes groups impersonated for users of the role.
+	Groups []string
+
+	// Rules is the allow-list of requests. Requests not matching any of the rules are rejected by the proxy.
+	Rules []Rule
+}
+
+// Rule allows requests based on their Kubernetes API attributes (similarly to Kubernetes RBAC policy rules).
+// A "*" element matches everything.
+type Rule struct {
+	Verbs     []string
+	APIGroups []string
+
+	// Resources may contain subresources in the "resource/subresource" form.
+	Resources []string
+
+	// NonResourceURLs may end with "*" to match every path with the given prefix.
+	NonResourceURLs []string
+}
+
+// Validate validates the configuration.
+func (c Config) Validate() error {
+	var errs error
+
+	for role, roleConfig := range c.Roles {
+		for i, rule := range roleConfig.Rules {
+			if len(rule.Verbs) == 0 {
+				errs = errors.Append(errs, errors.Errorf("cluster proxy rule %d of role %q must have at least one verb", i, role))
+			}
+
+			if len(rule.Resources) == 0 && len(rule.NonResourceURLs) == 0 {
+				errs = errors.Append(errs, errors.Errorf("cluster proxy rule %d of role %q must have resources or non-resource URLs", i, role))
+			}
+		}
+	}
+
+	return errs
+}
+
+// Matches checks whether the rule allows a request.
+func (r Rule) Matches(info RequestInfo) bool {
+	if !matchesAny(r.Verbs, info.Verb) {
+		return false
+	}
+
+	if !info.IsResourceRequest {
+		for _, url := range r.NonResourceURLs {
+			if url == "*" || url == info.Path || (strings.HasSuffix(url, "*") && strings.HasPrefix(info.Path, strings.TrimSuffix(url, "*"))) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if !matchesAny(r.APIGroups, info.APIGroup) {
+		return false
+	}
+
+	resource := info.Resource
+	if info.Subresource != "" {
+		resource = info.Resource + "/" + info.Subresource
+	}
+
+	return matchesAny(r.Resources, resource)
+}
+
+func matchesAny(values []string, value string) bool {
+	for _, v := range values {
+		if v == "*" || v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// User is a Pipeline user accessing the proxy.
+type User struct {
+	ID    uint
+	Login string
+}
+
+// Identity is the Kubernetes identity a proxied request is sent with.
+type Identity struct {
+	User   string
+	Groups []string
+}
+
+// Decision is the outcome of authorizing a proxied request.
+type Decision struct {
+	Allowed  bool
+	Identity Identity
+	Request  RequestInfo
+
+	// Role is the Pipeline organization role of the user.
+	Role string
+
+	// Reason explains why the request is denied.
+	Reason string
+}
+
+// AuditRecord returns the audit log representation of the decision.
+func (d Decision) AuditRecord() AuditRecord {
+	return AuditRecord{
+		Kubernetes: KubernetesAuditRecord{
+			RequestInfo: d.Request,
+			User:        d.Identity.User,
+			Groups:      d.Identity.Groups,
+			Role:        d.Role,
+			Allowed:     d.Allowed,
+			Reason:      d.Reason,
+		},
+	}
+}
+
+// AuditRecord is recorded in the audit log (in place of the request body) for every proxied request.
+type AuditRecord struct {
+	Kubernetes KubernetesAuditRecord `json:"kubernetes"`
+}
+
+// KubernetesAuditRecord holds the Kubernetes attributes of a proxied request.
+type KubernetesAuditRecord struct {
+	RequestInfo
+
+	User    string   `json:"user,omitempty"`
+	Groups  []string `json:"groups,omitempty"`
+	Role    string   `json:"role,omitempty"`
+	Allowed bool     `json:"allowed"`
+	Reason  string   `json:"reason,omitempty"`
+}
+
+// +testify:mock:testOnly=true
+
+// RoleSource returns the user's role in a given organization.
+type RoleSource interface {
+	// FindUserRole returns the user's role in a given organization.
+	// Returns false as the second parameter if the user is not a member of the organization.
+	FindUserRole(ctx context.Context, organizationID uint, userID uint) (string, bool, error)
+}
+
+// AccessController authorizes proxied requests and determines the identity they are sent with.
+type AccessController struct {
+	config Config
+	roles  RoleSource
+}
+
+// NewAccessController returns a new AccessController.
+func NewAccessController(config Config, roles RoleSource) AccessController {
+	return AccessController{
+		config: config,
+		roles:  roles,
+	}
+}
+
+// Authorize decides whether a user can send a request to a cluster of an organization through the proxy.
+// Virtual users (eg. cluster and service account tokens) are authorized by Pipeline as organization admins.
+func (a AccessController) Authorize(ctx context.Context, organizationID uint, user User, info RequestInfo) (Decision, error) {
+	decision := Decision{
+		Request: info,
+		Identity: Identity{
+			User: a.config.UserPrefix + user.Login,
+		},
+	}
+
+	role := roleAdmin
+	if user.ID != 0 {
+		r, member, err := a.roles.FindUserRole(ctx, organizationID, user.ID)
+		if err != nil {
+			return decision, errors.WrapIfWithDetails(err, "failed to find user role", "organizationId", organizationID, "userId", user.ID)
+		}
+
+		if !member {
+			decision.Reason = "user is not a member of the organization"
+
+			return decision, nil
+		}
+
+		role = r
+	}
+
+	decision.Role = role
+
+	roleConfig, ok := a.config.Roles[role]
+	if !ok {
+		decision.Reason = fmt.Sprintf("proxy access is not configured for role %q", role)
+
+		return decision, nil
+	}
+
+	decision.Identity.Groups = roleConfig.Groups
+
+	for _, rule := range roleConfig.Rules {
+		if rule.Matches(info) {
+			decision.Allowed = true
+
+			return decision, nil
+		}
+	}
+
+	decision.Reason = fmt.Sprintf("request is not allowed for role %q", role)
+
+	return decision, nil
+}
+
+// Impersonate replaces the impersonation headers of a request with the identity.
+// Impersonation headers set by the client are always removed,
+// otherwise clients could act as any user with the cluster credentials of Pipeline.
+func Impersonate(header http.Header, identity Identity) {
+	for key := range header {
+		if strings.HasPrefix(http.CanonicalHeaderKey(key), impersonateExtraHeaderPrefix) {
+			header.Del(key)
+		}
+	}
+
+	header.Del(impersonateUserHeader)
+	header.Del(impersonateGroupHeader)
+	header.Del(impersonateUIDHeader)
+
+	header.Set(impersonateUserHeader, identity.User)
+	for _, group := range identity.Groups {
+		header.Add(impersonateGroupHeader, group)
+	}
+}
+
+// ForbiddenStatus returns a Kubernetes API status object for a denied request, so that Kubernetes clients can display the reason.
+func ForbiddenStatus(decision Decision) metav1.Status {
+	target := decision.Request.Path
+	if decision.Request.IsResourceRequest {
+		target = decision.Request.Resource
+		if decision.Request.Subresource != "" {
+			target += "/" + decision.Request.Subresource
+		}
+		if decision.Request.APIGroup != "" {
+			target += "." + decision.Request.APIGroup
+		}
+		if decision.Request.Name != "" {
+			target += " " + decision.Request.Name
+		}
+	}
+
+	return metav1.Status{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Status",
+			APIVersion: "v1",
+		},
+		Status:  metav1.StatusFailure,
+		Message: fmt.Sprintf("%s %s is forbidden by the Pipeline cluster proxy: %s", decision.Request.Verb, target, decision.Reason),
+		Reason:  metav1.StatusReasonForbidden,
+		Code:    http.StatusForbidden,
+	}
+}
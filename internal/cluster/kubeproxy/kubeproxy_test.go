@@ -0,0 +1,170 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeproxy
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessController_Authorize(t *testing.T) {
+	config := Config{
+		UserPrefix: "pipeline:",
+		Roles: map[string]RoleConfig{
+			"admin": {
+				Groups: []string{"system:masters"},
+				Rules: []Rule{
+					{Verbs: []string{"*"}, APIGroups: []string{"*"}, Resources: []string{"*"}, NonResourceURLs: []string{"*"}},
+				},
+			},
+			"member": {
+				Groups: []string{"pipeline:members"},
+				Rules: []Rule{
+					{Verbs: []string{"get", "list", "watch"}, APIGroups: []string{"", "apps"}, Resources: []string{"pods", "pods/log", "deployments"}},
+					{Verbs: []string{"get"}, NonResourceURLs: []string{"/version", "/apis/*"}},
+				},
+			},
+		},
+	}
+
+	const organizationID = 1
+
+	listPods := NewRequestInfo(http.MethodGet, "/api/v1/namespaces/default/pods", nil)
+
+	t.Run("Admin", func(t *testing.T) {
+		roles := new(MockRoleSource)
+		roles.On("FindUserRole", context.Background(), uint(organizationID), uint(1)).Return("admin", true, nil)
+
+		info := NewRequestInfo(http.MethodDelete, "/api/v1/namespaces/default/secrets/token", nil)
+
+		decision, err := NewAccessController(config, roles).Authorize(context.Background(), organizationID, User{ID: 1, Login: "john"}, info)
+		require.NoError(t, err)
+
+		assert.Equal(t, Decision{
+			Allowed:  true,
+			Identity: Identity{User: "pipeline:john", Groups: []string{"system:masters"}},
+			Request:  info,
+			Role:     "admin",
+		}, decision)
+
+		roles.AssertExpectations(t)
+	})
+
+	t.Run("MemberAllowed", func(t *testing.T) {
+		roles := new(MockRoleSource)
+		roles.On("FindUserRole", context.Background(), uint(organizationID), uint(2)).Return("member", true, nil)
+
+		decision, err := NewAccessController(config, roles).Authorize(context.Background(), organizationID, User{ID: 2, Login: "jane"}, listPods)
+		require.NoError(t, err)
+
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, Identity{User: "pipeline:jane", Groups: []string{"pipeline:members"}}, decision.Identity)
+
+		roles.AssertExpectations(t)
+	})
+
+	t.Run("MemberDenied", func(t *testing.T) {
+		roles := new(MockRoleSource)
+		roles.On("FindUserRole", context.Background(), uint(organizationID), uint(2)).Return("member", true, nil)
+
+		requests := []RequestInfo{
+			NewRequestInfo(http.MethodDelete, "/api/v1/namespaces/default/pods/nginx", nil),
+			NewRequestInfo(http.MethodPost, "/api/v1/namespaces/default/pods/nginx/exec", nil),
+			NewRequestInfo(http.MethodGet, "/api/v1/namespaces/default/secrets", nil),
+			NewRequestInfo(http.MethodGet, "/apis/batch/v1/jobs", nil),
+			NewRequestInfo(http.MethodGet, "/healthz", nil),
+		}
+
+		for _, info := range requests {
+			decision, err := NewAccessController(config, roles).Authorize(context.Background(), organizationID, User{ID: 2, Login: "jane"}, info)
+			require.NoError(t, err)
+
+			assert.False(t, decision.Allowed, info.Path)
+			assert.Equal(t, `request is not allowed for role "member"`, decision.Reason)
+		}
+
+		for _, path := range []string{"/version", "/apis/apps/v1"} {
+			decision, err := NewAccessController(config, roles).Authorize(context.Background(), organizationID, User{ID: 2, Login: "jane"}, NewRequestInfo(http.MethodGet, path, nil))
+			require.NoError(t, err)
+
+			assert.True(t, decision.Allowed, path)
+		}
+	})
+
+	t.Run("NotMember", func(t *testing.T) {
+		roles := new(MockRoleSource)
+		roles.On("FindUserRole", context.Background(), uint(organizationID), uint(3)).Return("", false, nil)
+
+		decision, err := NewAccessController(config, roles).Authorize(context.Background(), organizationID, User{ID: 3, Login: "joe"}, listPods)
+		require.NoError(t, err)
+
+		assert.False(t, decision.Allowed)
+		assert.Equal(t, "user is not a member of the organization", decision.Reason)
+	})
+
+	t.Run("RoleNotConfigured", func(t *testing.T) {
+		roles := new(MockRoleSource)
+		roles.On("FindUserRole", context.Background(), uint(organizationID), uint(4)).Return("auditor", true, nil)
+
+		decision, err := NewAccessController(config, roles).Authorize(context.Background(), organizationID, User{ID: 4, Login: "jim"}, listPods)
+		require.NoError(t, err)
+
+		assert.False(t, decision.Allowed)
+		assert.Empty(t, decision.Identity.Groups)
+	})
+
+	t.Run("VirtualUser", func(t *testing.T) {
+		roles := new(MockRoleSource)
+
+		decision, err := NewAccessController(config, roles).Authorize(context.Background(), organizationID, User{Login: "clusters/1/2"}, listPods)
+		require.NoError(t, err)
+
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, "admin", decision.Role)
+
+		roles.AssertExpectations(t)
+	})
+}
+
+func TestImpersonate(t *testing.T) {
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	header.Set("Impersonate-User", "system:admin")
+	header.Add("Impersonate-Group", "system:masters")
+	header.Set("Impersonate-Uid", "1234")
+	header.Set("Impersonate-Extra-Scopes", "all")
+
+	Impersonate(header, Identity{User: "pipeline:jane", Groups: []string{"pipeline:members", "developers"}})
+
+	assert.Equal(t, http.Header{
+		"Accept":            []string{"application/json"},
+		"Impersonate-User":  []string{"pipeline:jane"},
+		"Impersonate-Group": []string{"pipeline:members", "developers"},
+	}, header)
+}
+
+func TestForbiddenStatus(t *testing.T) {
+	status := ForbiddenStatus(Decision{
+		Request: NewRequestInfo(http.MethodDelete, "/apis/apps/v1/namespaces/default/deployments/nginx", nil),
+		Reason:  `request is not allowed for role "member"`,
+	})
+
+	assert.Equal(t, int32(http.StatusForbidden), status.Code)
+	assert.Equal(t, `delete deployments.apps nginx is forbidden by the Pipeline cluster proxy: request is not allowed for role "member"`, status.Message)
+}
@@ -0,0 +1,146 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeproxy
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RequestInfo holds the Kubernetes API attributes of a proxied request.
+// It follows the semantics of the API server's own request info resolver.
+type RequestInfo struct {
+	// IsResourceRequest indicates whether the request targets an API resource (as opposed to eg. discovery or health endpoints).
+	IsResourceRequest bool `json:"-"`
+
+	// Path is the request path relative to the API server root.
+	Path string `json:"path"`
+
+	Verb        string `json:"verb"`
+	APIGroup    string `json:"apiGroup,omitempty"`
+	APIVersion  string `json:"apiVersion,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Resource    string `json:"resource,omitempty"`
+	Subresource string `json:"subresource,omitempty"`
+	Name        string `json:"name,omitempty"`
+}
+
+// namespaceSubresources are subresources of namespaces (as opposed to resources in a namespace).
+var namespaceSubresources = map[string]bool{
+	"status":   true,
+	"finalize": true,
+}
+
+// NewRequestInfo resolves the Kubernetes API attributes of a request.
+// The path must be relative to the API server root.
+func NewRequestInfo(method string, path string, query url.Values) RequestInfo {
+	info := RequestInfo{
+		Path: "/" + strings.Trim(path, "/"),
+		Verb: strings.ToLower(method),
+	}
+
+	parts := splitPath(path)
+
+	if len(parts) < 1 || (parts[0] != "api" && parts[0] != "apis") {
+		return info
+	}
+
+	if parts[0] == "apis" {
+		if len(parts) < 2 {
+			return info
+		}
+
+		info.APIGroup = parts[1]
+		parts = parts[2:]
+	} else {
+		parts = parts[1:]
+	}
+
+	// discovery requests
+	if len(parts) < 2 {
+		info.APIGroup = ""
+
+		return info
+	}
+
+	info.IsResourceRequest = true
+	info.APIVersion = parts[0]
+	parts = parts[1:]
+
+	switch method {
+	case http.MethodPost:
+		info.Verb = "create"
+	case http.MethodGet, http.MethodHead:
+		info.Verb = "get"
+	case http.MethodPut:
+		info.Verb = "update"
+	case http.MethodPatch:
+		info.Verb = "patch"
+	case http.MethodDelete:
+		info.Verb = "delete"
+	default:
+		info.Verb = ""
+	}
+
+	// deprecated watch paths (eg. /api/v1/watch/namespaces/default/pods)
+	if parts[0] == "watch" && len(parts) > 1 {
+		info.Verb = "watch"
+		parts = parts[1:]
+	}
+
+	if parts[0] == "namespaces" && len(parts) > 1 {
+		info.Namespace = parts[1]
+
+		// resources in a namespace
+		if len(parts) > 2 && !namespaceSubresources[parts[2]] {
+			parts = parts[2:]
+		}
+	}
+
+	switch {
+	case len(parts) >= 3:
+		info.Subresource = parts[2]
+		fallthrough
+	case len(parts) == 2:
+		info.Name = parts[1]
+		fallthrough
+	case len(parts) == 1:
+		info.Resource = parts[0]
+	}
+
+	if info.Name == "" && info.Verb == "get" {
+		if watch := strings.ToLower(query.Get("watch")); watch == "true" || watch == "1" {
+			info.Verb = "watch"
+		} else {
+			info.Verb = "list"
+		}
+	}
+
+	if info.Name == "" && info.Verb == "delete" {
+		info.Verb = "deletecollection"
+	}
+
+	return info
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+
+	return strings.Split(path, "/")
+}
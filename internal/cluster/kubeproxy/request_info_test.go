@@ -0,0 +1,149 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeproxy
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRequestInfo(t *testing.T) {
+	tests := map[string]struct {
+		method   string
+		path     string
+		query    url.Values
+		expected RequestInfo
+	}{
+		"discovery": {
+			method: http.MethodGet,
+			path:   "/apis/apps/v1",
+			expected: RequestInfo{
+				Path: "/apis/apps/v1",
+				Verb: "get",
+			},
+		},
+		"version": {
+			method: http.MethodGet,
+			path:   "/version/",
+			expected: RequestInfo{
+				Path: "/version",
+				Verb: "get",
+			},
+		},
+		"list": {
+			method: http.MethodGet,
+			path:   "/api/v1/namespaces/default/pods",
+			expected: RequestInfo{
+				IsResourceRequest: true,
+				Path:              "/api/v1/namespaces/default/pods",
+				Verb:              "list",
+				APIVersion:        "v1",
+				Namespace:         "default",
+				Resource:          "pods",
+			},
+		},
+		"watch": {
+			method: http.MethodGet,
+			path:   "/apis/apps/v1/deployments",
+			query:  url.Values{"watch": []string{"true"}},
+			expected: RequestInfo{
+				IsResourceRequest: true,
+				Path:              "/apis/apps/v1/deployments",
+				Verb:              "watch",
+				APIGroup:          "apps",
+				APIVersion:        "v1",
+				Resource:          "deployments",
+			},
+		},
+		"subresource": {
+			method: http.MethodPost,
+			path:   "/api/v1/namespaces/default/pods/nginx/exec",
+			expected: RequestInfo{
+				IsResourceRequest: true,
+				Path:              "/api/v1/namespaces/default/pods/nginx/exec",
+				Verb:              "create",
+				APIVersion:        "v1",
+				Namespace:         "default",
+				Resource:          "pods",
+				Name:              "nginx",
+				Subresource:       "exec",
+			},
+		},
+		"namespace": {
+			method: http.MethodDelete,
+			path:   "/api/v1/namespaces/default",
+			expected: RequestInfo{
+				IsResourceRequest: true,
+				Path:              "/api/v1/namespaces/default",
+				Verb:              "delete",
+				APIVersion:        "v1",
+				Namespace:         "default",
+				Resource:          "namespaces",
+				Name:              "default",
+			},
+		},
+		"namespaceSubresource": {
+			method: http.MethodPut,
+			path:   "/api/v1/namespaces/default/finalize",
+			expected: RequestInfo{
+				IsResourceRequest: true,
+				Path:              "/api/v1/namespaces/default/finalize",
+				Verb:              "update",
+				APIVersion:        "v1",
+				Namespace:         "default",
+				Resource:          "namespaces",
+				Name:              "default",
+				Subresource:       "finalize",
+			},
+		},
+		"deleteCollection": {
+			method: http.MethodDelete,
+			path:   "/apis/batch/v1/namespaces/default/jobs",
+			expected: RequestInfo{
+				IsResourceRequest: true,
+				Path:              "/apis/batch/v1/namespaces/default/jobs",
+				Verb:              "deletecollection",
+				APIGroup:          "batch",
+				APIVersion:        "v1",
+				Namespace:         "default",
+				Resource:          "jobs",
+			},
+		},
+		"deprecatedWatch": {
+			method: http.MethodGet,
+			path:   "/api/v1/watch/namespaces/default/pods/nginx",
+			expected: RequestInfo{
+				IsResourceRequest: true,
+				Path:              "/api/v1/watch/namespaces/default/pods/nginx",
+				Verb:              "watch",
+				APIVersion:        "v1",
+				Namespace:         "default",
+				Resource:          "pods",
+				Name:              "nginx",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, NewRequestInfo(test.method, test.path, test.query))
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Policy names
+const (
+	DenyAllPolicyName  = "default-deny-all"
+	AllowDNSPolicyName = "allow-dns"
+)
+
+// Defaults is the default network policy bundle of an organization applied to new clusters.
+// The policies are applied once, to the user namespaces existing when the cluster is set up.
+type Defaults struct {
+	// DenyAll denies every ingress and egress traffic in user namespaces.
+	DenyAll bool `json:"denyAll"`
+
+	// AllowDNS allows egress DNS traffic to the kube-system namespace from user namespaces.
+	AllowDNS bool `json:"allowDns"`
+
+	// ExcludedNamespaces are not considered to be user namespaces.
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+}
+
+// Enabled checks whether any of the policies is enabled.
+func (d Defaults) Enabled() bool {
+	return d.DenyAll || d.AllowDNS
+}
+
+// Store persists the default network policies of organizations.
+type Store interface {
+	// Get returns the default network policies of an organization.
+	// The zero value is returned when the organization has none.
+	Get(ctx context.Context, organizationID uint) (Defaults, error)
+
+	// Set stores the default network policies of an organization.
+	Set(ctx context.Context, organizationID uint, defaults Defaults) error
+}
+
+// nolint: gochecknoglobals
+var systemNamespaces = map[string]bool{
+	"kube-system":     true,
+	"kube-public":     true,
+	"kube-node-lease": true,
+}
+
+// IsUserNamespace checks whether the default policies should be applied to a namespace.
+// The Kubernetes system namespaces, the given system namespaces (eg. the Pipeline namespace)
+// and the excluded namespaces are not user namespaces.
+func (d Defaults) IsUserNamespace(namespace string, pipelineNamespaces ...string) bool {
+	if systemNamespaces[namespace] {
+		return false
+	}
+
+	for _, ns := range pipelineNamespaces {
+		if ns == namespace {
+			return false
+		}
+	}
+
+	for _, ns := range d.ExcludedNamespaces {
+		if ns == namespace {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Policies returns the enabled default network policies of a namespace.
+func (d Defaults) Policies(namespace string) []networkingv1.NetworkPolicy {
+	var policies []networkingv1.NetworkPolicy
+
+	labels := map[string]string{
+		"owner": "pipeline",
+	}
+
+	if d.DenyAll {
+		policies = append(policies, networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      DenyAllPolicyName,
+				Namespace: namespace,
+				Labels:    labels,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{},
+				PolicyTypes: []networkingv1.PolicyType{
+					networkingv1.PolicyTypeIngress,
+					networkingv1.PolicyTypeEgress,
+				},
+			},
+		})
+	}
+
+	if d.AllowDNS {
+		udp := corev1.ProtocolUDP
+		tcp := corev1.ProtocolTCP
+		port := intstr.FromInt(53)
+
+		policies = append(policies, networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      AllowDNSPolicyName,
+				Namespace: namespace,
+				Labels:    labels,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{},
+				PolicyTypes: []networkingv1.PolicyType{
+					networkingv1.PolicyTypeEgress,
+				},
+				Egress: []networkingv1.NetworkPolicyEgressRule{
+					{
+						To: []networkingv1.NetworkPolicyPeer{
+							{
+								// the kube-system namespace is labeled during the cluster setup
+								NamespaceSelector: &metav1.LabelSelector{
+									MatchLabels: map[string]string{"name": "kube-system"},
+								},
+							},
+						},
+						Ports: []networkingv1.NetworkPolicyPort{
+							{Protocol: &udp, Port: &port},
+							{Protocol: &tcp, Port: &port},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return policies
+}
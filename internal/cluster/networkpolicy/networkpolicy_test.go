@@ -0,0 +1,62 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+func TestDefaults_IsUserNamespace(t *testing.T) {
+	defaults := Defaults{ExcludedNamespaces: []string{"monitoring"}}
+
+	assert.True(t, defaults.IsUserNamespace("default", "pipeline-system"))
+	assert.False(t, defaults.IsUserNamespace("kube-system", "pipeline-system"))
+	assert.False(t, defaults.IsUserNamespace("pipeline-system", "pipeline-system"))
+	assert.False(t, defaults.IsUserNamespace("monitoring", "pipeline-system"))
+}
+
+func TestDefaults_Policies(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		defaults := Defaults{}
+
+		assert.False(t, defaults.Enabled())
+		assert.Empty(t, defaults.Policies("default"))
+	})
+
+	t.Run("deny all and allow dns", func(t *testing.T) {
+		defaults := Defaults{DenyAll: true, AllowDNS: true}
+
+		policies := defaults.Policies("default")
+
+		assert.True(t, defaults.Enabled())
+		assert.Len(t, policies, 2)
+
+		denyAll := policies[0]
+		assert.Equal(t, DenyAllPolicyName, denyAll.Name)
+		assert.Equal(t, "default", denyAll.Namespace)
+		assert.Empty(t, denyAll.Spec.Ingress)
+		assert.Empty(t, denyAll.Spec.Egress)
+		assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress}, denyAll.Spec.PolicyTypes)
+
+		allowDNS := policies[1]
+		assert.Equal(t, AllowDNSPolicyName, allowDNS.Name)
+		assert.Len(t, allowDNS.Spec.Egress, 1)
+		assert.Len(t, allowDNS.Spec.Egress[0].Ports, 2)
+		assert.Equal(t, 53, allowDNS.Spec.Egress[0].Ports[0].Port.IntValue())
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicyadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/networkpolicy"
+	"github.com/banzaicloud/pipeline/internal/database/sql/json"
+)
+
+// defaultsModel is the persisted form of the default network policies of an organization.
+type defaultsModel struct {
+	OrganizationID     uint `gorm:"primary_key;auto_increment:false"`
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	DenyAll            bool
+	AllowDNS           bool   `gorm:"column:allow_dns"`
+	ExcludedNamespaces string `gorm:"type:text"`
+}
+
+// TableName changes the default table name.
+func (defaultsModel) TableName() string {
+	return "network_policy_defaults"
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a new networkpolicy.Store backed by a relational database.
+func NewGormStore(db *gorm.DB) networkpolicy.Store {
+	return gormStore{
+		db: db,
+	}
+}
+
+func (s gormStore) Get(_ context.Context, organizationID uint) (networkpolicy.Defaults, error) {
+	var model defaultsModel
+
+	err := s.db.Where(defaultsModel{OrganizationID: organizationID}).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return networkpolicy.Defaults{}, nil
+	} else if err != nil {
+		return networkpolicy.Defaults{}, errors.WrapIfWithDetails(err, "failed to get default network policies", "organizationId", organizationID)
+	}
+
+	defaults := networkpolicy.Defaults{
+		DenyAll:  model.DenyAll,
+		AllowDNS: model.AllowDNS,
+	}
+
+	if model.ExcludedNamespaces != "" {
+		if err := json.Scan(model.ExcludedNamespaces, &defaults.ExcludedNamespaces); err != nil {
+			return networkpolicy.Defaults{}, errors.WrapIfWithDetails(err, "failed to decode excluded namespaces", "organizationId", organizationID)
+		}
+	}
+
+	return defaults, nil
+}
+
+func (s gormStore) Set(_ context.Context, organizationID uint, defaults networkpolicy.Defaults) error {
+	model := defaultsModel{
+		OrganizationID: organizationID,
+		DenyAll:        defaults.DenyAll,
+		AllowDNS:       defaults.AllowDNS,
+	}
+
+	if len(defaults.ExcludedNamespaces) > 0 {
+		value, err := json.Value(defaults.ExcludedNamespaces)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to encode excluded namespaces", "organizationId", organizationID)
+		}
+
+		model.ExcludedNamespaces = string(value.([]byte))
+	}
+
+	if err := s.db.Save(&model).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to save default network policies", "organizationId", organizationID)
+	}
+
+	return nil
+}
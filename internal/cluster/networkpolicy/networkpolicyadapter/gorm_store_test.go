@@ -0,0 +1,74 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicyadapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/networkpolicy"
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, common.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore(t *testing.T) {
+	db := setUpDatabase(t)
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	defaults, err := store.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, networkpolicy.Defaults{}, defaults)
+
+	expected := networkpolicy.Defaults{
+		DenyAll:            true,
+		AllowDNS:           true,
+		ExcludedNamespaces: []string{"monitoring"},
+	}
+
+	err = store.Set(ctx, 1, expected)
+	require.NoError(t, err)
+
+	defaults, err = store.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, expected, defaults)
+
+	expected = networkpolicy.Defaults{AllowDNS: true}
+
+	err = store.Set(ctx, 1, expected)
+	require.NoError(t, err)
+
+	defaults, err = store.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, expected, defaults)
+
+	defaults, err = store.Get(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, networkpolicy.Defaults{}, defaults)
+}
@@ -18,6 +18,8 @@ import (
 	"context"
 
 	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/common"
 )
 
 // NodePoolValidators combines different node pool validators into one.
@@ -84,6 +86,13 @@ func (v commonNodePoolValidator) ValidateNew(_ context.Context, _ Cluster, rawNo
 		}
 	}
 
+	taints, err := rawNodePool.GetTaints()
+	if err != nil {
+		violations = append(violations, err.Error())
+	} else {
+		violations = append(violations, common.TaintViolations(taints, v.labelValidator)...)
+	}
+
 	if len(violations) > 0 {
 		return errors.WithStack(ValidationError{
 			message:    "invalid node pool",
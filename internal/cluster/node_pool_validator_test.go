@@ -146,6 +146,66 @@ func TestNewCommonNodePoolValidator_ValidateNew(t *testing.T) {
 
 		labelValidator.AssertExpectations(t)
 	})
+
+	t.Run("InvalidTaints", func(t *testing.T) {
+		labelValidator := new(MockLabelValidator)
+
+		labelValidator.On("ValidateKey", "dedicated").Return(nil)
+		labelValidator.On("ValidateValue", "gpu").Return(nil)
+		labelValidator.On("ValidateKey", "invalid key").Return(errors.New("invalid key"))
+
+		nodePool := NewRawNodePool{
+			"name": "pool0",
+			"taints": []map[string]interface{}{
+				{"key": "dedicated", "value": "gpu", "effect": "NoSchedule"},
+				{"key": "dedicated", "value": "gpu", "effect": "NoSchedule"},
+				{"key": "invalid key", "effect": "NoSchedule"},
+				{"key": "dedicated", "effect": "Never"},
+			},
+		}
+
+		validator := NewCommonNodePoolValidator(labelValidator)
+
+		err := validator.ValidateNew(context.Background(), Cluster{}, nodePool)
+		require.Error(t, err)
+
+		var verr ValidationError
+
+		assert.True(t, errors.As(err, &verr))
+		assert.Equal(
+			t,
+			[]string{
+				"duplicate taint \"dedicated:NoSchedule\"",
+				"invalid key",
+				"invalid taint effect \"Never\" for key \"dedicated\"",
+			},
+			verr.Violations(),
+		)
+
+		labelValidator.AssertExpectations(t)
+	})
+
+	t.Run("MalformedTaints", func(t *testing.T) {
+		labelValidator := new(MockLabelValidator)
+
+		nodePool := NewRawNodePool{
+			"name":   "pool0",
+			"taints": "dedicated=gpu:NoSchedule",
+		}
+
+		validator := NewCommonNodePoolValidator(labelValidator)
+
+		err := validator.ValidateNew(context.Background(), Cluster{}, nodePool)
+		require.Error(t, err)
+
+		var verr ValidationError
+
+		require.True(t, errors.As(err, &verr))
+		require.Len(t, verr.Violations(), 1)
+		assert.Contains(t, verr.Violations()[0], "malformed taints")
+
+		labelValidator.AssertExpectations(t)
+	})
 }
 
 func TestNewDistributionNodePoolValidator_ValidateNew(t *testing.T) {
@@ -0,0 +1,375 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+)
+
+const (
+	// defaultLimit is the number of layouts returned when the request does not specify a limit.
+	defaultLimit = 5
+
+	// maxLimit is the maximum number of layouts returned.
+	maxLimit = 50
+)
+
+// Request describes the resource requirements of a node pool layout.
+type Request struct {
+	Cloud        string `json:"cloud"`
+	Distribution string `json:"distribution"`
+	Location     string `json:"location"`
+
+	// Zones the nodes are placed in (every zone must offer the instance type).
+	Zones []string `json:"zones,omitempty"`
+
+	// CPU is the total number of vCPUs required.
+	CPU float64 `json:"cpu"`
+
+	// Memory is the total memory required in GB.
+	Memory float64 `json:"memory"`
+
+	// GPU is the total number of GPUs required (GPU instance types are only recommended if it is positive).
+	GPU float64 `json:"gpu,omitempty"`
+
+	MinNodes int `json:"minNodes,omitempty"`
+	MaxNodes int `json:"maxNodes,omitempty"`
+
+	// SpotRatio is the maximum ratio of nodes that may run on spot (preemptible) instances (0 disables spot instances).
+	SpotRatio float64 `json:"spotRatio,omitempty"`
+
+	// Limit is the maximum number of layouts returned.
+	Limit int `json:"limit,omitempty"`
+}
+
+// Validate validates the request.
+func (r Request) Validate() error {
+	var violations []string
+
+	if r.Cloud == "" {
+		violations = append(violations, "cloud is required")
+	}
+
+	if r.Distribution == "" {
+		violations = append(violations, "distribution is required")
+	}
+
+	if r.Location == "" {
+		violations = append(violations, "location is required")
+	}
+
+	if r.CPU < 0 || r.Memory < 0 || r.GPU < 0 {
+		violations = append(violations, "resource requirements cannot be negative")
+	}
+
+	if r.CPU == 0 && r.Memory == 0 && r.GPU == 0 {
+		violations = append(violations, "at least one of cpu, memory or gpu must be requested")
+	}
+
+	if r.MinNodes < 0 || r.MaxNodes < 0 {
+		violations = append(violations, "node counts cannot be negative")
+	}
+
+	if r.MaxNodes > 0 && r.MaxNodes < r.MinNodes {
+		violations = append(violations, "maxNodes cannot be lower than minNodes")
+	}
+
+	if r.SpotRatio < 0 || r.SpotRatio > 1 {
+		violations = append(violations, "spotRatio must be between 0 and 1")
+	}
+
+	if r.Limit < 0 || r.Limit > maxLimit {
+		violations = append(violations, fmt.Sprintf("limit must be between 0 and %d", maxLimit))
+	}
+
+	if len(violations) > 0 {
+		return NewValidationError("invalid recommendation request", violations)
+	}
+
+	return nil
+}
+
+// Layout is a recommended set of node pools.
+type Layout struct {
+	NodePools []NodePool `json:"nodePools"`
+
+	// CPU, Memory and GPU are the total resources of the layout.
+	CPU    float64 `json:"cpu"`
+	Memory float64 `json:"memory"`
+	GPU    float64 `json:"gpu"`
+
+	Cost cost.Cost `json:"cost"`
+}
+
+// NodePool is a node pool of a recommended layout.
+type NodePool struct {
+	InstanceType string `json:"instanceType"`
+	Count        int    `json:"count"`
+	Spot         bool   `json:"spot"`
+
+	// CPU, Memory and GPU are the resources of a single node.
+	CPU    float64 `json:"cpu"`
+	Memory float64 `json:"memory"`
+	GPU    float64 `json:"gpu"`
+
+	// NodePrice is the hourly price of a single node.
+	NodePrice float64 `json:"nodePrice"`
+}
+
+// Product is an instance type available in a location.
+type Product struct {
+	InstanceType string
+
+	CPU    float64
+	Memory float64
+	GPU    float64
+
+	OnDemandPrice float64
+
+	// SpotPrices are the spot prices by availability zone.
+	SpotPrices map[string]float64
+
+	// Zones are the availability zones offering the instance type (empty if unknown).
+	Zones []string
+
+	// CurrentGeneration is false for instance types superseded by a newer generation.
+	CurrentGeneration bool
+}
+
+// +testify:mock:testOnly=true
+
+// ProductSource lists the instance types available in a location.
+type ProductSource interface {
+	// GetProducts returns the instance types available for a distribution in a location.
+	GetProducts(ctx context.Context, cloud string, distribution string, location string) ([]Product, error)
+}
+
+// +kit:endpoint:errorStrategy=service
+
+// Service recommends node pool layouts.
+type Service interface {
+	// RecommendNodePools returns node pool layouts satisfying the requirements ranked by price.
+	RecommendNodePools(ctx context.Context, request Request) (layouts []Layout, err error)
+}
+
+// NewService returns a new Service.
+func NewService(products ProductSource) Service {
+	return service{
+		products: products,
+	}
+}
+
+type service struct {
+	products ProductSource
+}
+
+func (s service) RecommendNodePools(ctx context.Context, request Request) ([]Layout, error) {
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	products, err := s.products.GetProducts(ctx, request.Cloud, request.Distribution, request.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	layouts := make([]Layout, 0, len(products))
+
+	for _, product := range products {
+		if layout, ok := recommendLayout(request, product); ok {
+			layouts = append(layouts, layout)
+		}
+	}
+
+	sort.SliceStable(layouts, func(i, j int) bool {
+		if layouts[i].Cost.Hourly != layouts[j].Cost.Hourly {
+			return layouts[i].Cost.Hourly < layouts[j].Cost.Hourly
+		}
+
+		// prefer the layout wasting less resources
+		if layouts[i].CPU != layouts[j].CPU {
+			return layouts[i].CPU < layouts[j].CPU
+		}
+
+		return layouts[i].NodePools[0].InstanceType < layouts[j].NodePools[0].InstanceType
+	})
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+
+	if len(layouts) > limit {
+		layouts = layouts[:limit]
+	}
+
+	return layouts, nil
+}
+
+// recommendLayout returns the cheapest layout satisfying the request using a single instance type.
+func recommendLayout(request Request, product Product) (Layout, bool) {
+	if product.OnDemandPrice <= 0 || product.CPU <= 0 || product.Memory <= 0 || !product.CurrentGeneration {
+		return Layout{}, false
+	}
+
+	// GPU instances are oversized for workloads not requesting GPUs
+	if (request.GPU > 0) != (product.GPU > 0) {
+		return Layout{}, false
+	}
+
+	if !offeredInZones(product, request.Zones) {
+		return Layout{}, false
+	}
+
+	count := maxInt(
+		nodesFor(request.CPU, product.CPU),
+		nodesFor(request.Memory, product.Memory),
+		nodesFor(request.GPU, product.GPU),
+		request.MinNodes,
+		1,
+	)
+
+	if request.MaxNodes > 0 && count > request.MaxNodes {
+		return Layout{}, false
+	}
+
+	node := NodePool{
+		InstanceType: product.InstanceType,
+		CPU:          product.CPU,
+		Memory:       product.Memory,
+		GPU:          product.GPU,
+	}
+
+	var spotCount int
+	spotPrice := averageSpotPrice(product, request.Zones)
+
+	// spot instances are only worth it if they are cheaper
+	if request.SpotRatio > 0 && spotPrice > 0 && spotPrice < product.OnDemandPrice {
+		spotCount = int(math.Floor(float64(count) * request.SpotRatio))
+	}
+
+	layout := Layout{
+		CPU:    float64(count) * product.CPU,
+		Memory: float64(count) * product.Memory,
+		GPU:    float64(count) * product.GPU,
+	}
+
+	var hourly float64
+
+	if onDemandCount := count - spotCount; onDemandCount > 0 {
+		nodePool := node
+		nodePool.Count = onDemandCount
+		nodePool.NodePrice = product.OnDemandPrice
+
+		layout.NodePools = append(layout.NodePools, nodePool)
+		hourly += float64(onDemandCount) * product.OnDemandPrice
+	}
+
+	if spotCount > 0 {
+		nodePool := node
+		nodePool.Count = spotCount
+		nodePool.Spot = true
+		nodePool.NodePrice = spotPrice
+
+		layout.NodePools = append(layout.NodePools, nodePool)
+		hourly += float64(spotCount) * spotPrice
+	}
+
+	layout.Cost = cost.Cost{
+		Hourly:  round(hourly),
+		Monthly: round(hourly * cost.HoursPerMonth),
+	}
+
+	return layout, true
+}
+
+// nodesFor returns the number of nodes needed to provide a resource.
+func nodesFor(required float64, perNode float64) int {
+	if required <= 0 || perNode <= 0 {
+		return 0
+	}
+
+	return int(math.Ceil(required / perNode))
+}
+
+// offeredInZones checks whether an instance type is offered in every requested zone.
+func offeredInZones(product Product, zones []string) bool {
+	// availability cannot be checked without zone information
+	if len(product.Zones) == 0 {
+		return true
+	}
+
+	offered := make(map[string]bool, len(product.Zones))
+	for _, zone := range product.Zones {
+		offered[zone] = true
+	}
+
+	for _, zone := range zones {
+		if !offered[zone] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// averageSpotPrice returns the average spot price in the requested zones (or every zone if none is requested).
+// It returns zero if spot instances are not available in one of the requested zones.
+func averageSpotPrice(product Product, zones []string) float64 {
+	if len(zones) == 0 {
+		for zone := range product.SpotPrices {
+			zones = append(zones, zone)
+		}
+	}
+
+	if len(zones) == 0 {
+		return 0
+	}
+
+	var sum float64
+
+	for _, zone := range zones {
+		price, ok := product.SpotPrices[zone]
+		if !ok || price <= 0 {
+			return 0
+		}
+
+		sum += price
+	}
+
+	return sum / float64(len(zones))
+}
+
+func maxInt(values ...int) int {
+	var max int
+
+	for _, value := range values {
+		if value > max {
+			max = value
+		}
+	}
+
+	return max
+}
+
+// round rounds a cost to a thousandth of a cent to hide floating point noise.
+func round(v float64) float64 {
+	return math.Round(v*100000) / 100000
+}
@@ -0,0 +1,170 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommender
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+)
+
+func TestService_RecommendNodePools(t *testing.T) {
+	ctx := context.Background()
+
+	products := []Product{
+		{
+			InstanceType:      "m5.large",
+			CPU:               2,
+			Memory:            8,
+			OnDemandPrice:     0.096,
+			SpotPrices:        map[string]float64{"eu-west-1a": 0.03, "eu-west-1b": 0.05},
+			Zones:             []string{"eu-west-1a", "eu-west-1b"},
+			CurrentGeneration: true,
+		},
+		{
+			InstanceType:      "m5.xlarge",
+			CPU:               4,
+			Memory:            16,
+			OnDemandPrice:     0.192,
+			SpotPrices:        map[string]float64{"eu-west-1a": 0.07},
+			Zones:             []string{"eu-west-1a", "eu-west-1b"},
+			CurrentGeneration: true,
+		},
+		{
+			InstanceType:  "m4.large",
+			CPU:           2,
+			Memory:        8,
+			OnDemandPrice: 0.1,
+		},
+		{
+			InstanceType:      "c5.large",
+			CPU:               2,
+			Memory:            4,
+			OnDemandPrice:     0.085,
+			Zones:             []string{"eu-west-1a"},
+			CurrentGeneration: true,
+		},
+		{
+			InstanceType:      "p3.2xlarge",
+			CPU:               8,
+			Memory:            61,
+			GPU:               1,
+			OnDemandPrice:     3.06,
+			CurrentGeneration: true,
+		},
+	}
+
+	newService := func() Service {
+		productSource := new(MockProductSource)
+		productSource.On("GetProducts", ctx, "amazon", "eks", "eu-west-1").Return(products, nil)
+
+		return NewService(productSource)
+	}
+
+	request := Request{
+		Cloud:        "amazon",
+		Distribution: "eks",
+		Location:     "eu-west-1",
+		Zones:        []string{"eu-west-1a", "eu-west-1b"},
+		CPU:          8,
+		Memory:       32,
+	}
+
+	t.Run("OnDemand", func(t *testing.T) {
+		layouts, err := newService().RecommendNodePools(ctx, request)
+		require.NoError(t, err)
+
+		assert.Equal(t, []Layout{
+			{
+				NodePools: []NodePool{{InstanceType: "m5.large", Count: 4, CPU: 2, Memory: 8, NodePrice: 0.096}},
+				CPU:       8,
+				Memory:    32,
+				Cost:      cost.Cost{Hourly: 0.384, Monthly: 280.32},
+			},
+			{
+				NodePools: []NodePool{{InstanceType: "m5.xlarge", Count: 2, CPU: 4, Memory: 16, NodePrice: 0.192}},
+				CPU:       8,
+				Memory:    32,
+				Cost:      cost.Cost{Hourly: 0.384, Monthly: 280.32},
+			},
+		}, layouts)
+	})
+
+	t.Run("Spot", func(t *testing.T) {
+		request := request
+		request.SpotRatio = 0.5
+
+		layouts, err := newService().RecommendNodePools(ctx, request)
+		require.NoError(t, err)
+		require.Len(t, layouts, 2)
+
+		assert.Equal(t, Layout{
+			NodePools: []NodePool{
+				{InstanceType: "m5.large", Count: 2, CPU: 2, Memory: 8, NodePrice: 0.096},
+				{InstanceType: "m5.large", Count: 2, Spot: true, CPU: 2, Memory: 8, NodePrice: 0.04},
+			},
+			CPU:    8,
+			Memory: 32,
+			Cost:   cost.Cost{Hourly: 0.272, Monthly: 198.56},
+		}, layouts[0])
+
+		// no spot price in every requested zone
+		assert.Len(t, layouts[1].NodePools, 1)
+		assert.False(t, layouts[1].NodePools[0].Spot)
+	})
+
+	t.Run("MaxNodes", func(t *testing.T) {
+		request := request
+		request.MaxNodes = 3
+
+		layouts, err := newService().RecommendNodePools(ctx, request)
+		require.NoError(t, err)
+		require.Len(t, layouts, 1)
+
+		assert.Equal(t, "m5.xlarge", layouts[0].NodePools[0].InstanceType)
+	})
+
+	t.Run("GPU", func(t *testing.T) {
+		request := request
+		request.GPU = 2
+
+		layouts, err := newService().RecommendNodePools(ctx, request)
+		require.NoError(t, err)
+
+		assert.Equal(t, []Layout{
+			{
+				NodePools: []NodePool{{InstanceType: "p3.2xlarge", Count: 2, CPU: 8, Memory: 61, GPU: 1, NodePrice: 3.06}},
+				CPU:       16,
+				Memory:    122,
+				GPU:       2,
+				Cost:      cost.Cost{Hourly: 6.12, Monthly: 4467.6},
+			},
+		}, layouts)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := NewService(new(MockProductSource)).RecommendNodePools(ctx, Request{Cloud: "amazon", SpotRatio: 2})
+		require.Error(t, err)
+
+		var verr ValidationError
+		require.True(t, errors.As(err, &verr))
+		assert.Len(t, verr.Violations(), 4)
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommenderadapter
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/.gen/cloudinfo"
+	"github.com/banzaicloud/pipeline/internal/cluster/recommender"
+	pkgCloudinfo "github.com/banzaicloud/pipeline/pkg/cloudinfo"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+// ProductLister lists instance types from Cloudinfo.
+type ProductLister interface {
+	// GetProducts returns the details of every product of a service available in a region.
+	GetProducts(ctx context.Context, cloud string, service string, region string) ([]cloudinfo.ProductDetails, error)
+}
+
+// CloudinfoProductSource lists the instance types available in a location from Cloudinfo.
+type CloudinfoProductSource struct {
+	products ProductLister
+}
+
+// NewCloudinfoProductSource returns a new CloudinfoProductSource.
+func NewCloudinfoProductSource(products ProductLister) CloudinfoProductSource {
+	return CloudinfoProductSource{
+		products: products,
+	}
+}
+
+// GetProducts returns the instance types available for a distribution in a location.
+func (s CloudinfoProductSource) GetProducts(ctx context.Context, cloud string, distribution string, location string) ([]recommender.Product, error) {
+	details, err := s.products.GetProducts(ctx, cloud, distribution, pkgCloudinfo.RegionFromLocation(cloud, location))
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]recommender.Product, 0, len(details))
+
+	for _, detail := range details {
+		product := recommender.Product{
+			InstanceType:  detail.Type,
+			CPU:           detail.CpusPerVm,
+			Memory:        detail.MemPerVm,
+			GPU:           detail.GpusPerVm,
+			OnDemandPrice: detail.OnDemandPrice,
+			SpotPrices:    make(map[string]float64, len(detail.SpotPrice)),
+			Zones:         detail.Zones,
+
+			// Cloudinfo only reports instance type generations for Amazon
+			CurrentGeneration: detail.CurrentGen || cloud != pkgCluster.Amazon,
+		}
+
+		for _, zonePrice := range detail.SpotPrice {
+			product.SpotPrices[zonePrice.Zone] = zonePrice.Price
+		}
+
+		products = append(products, product)
+	}
+
+	return products, nil
+}
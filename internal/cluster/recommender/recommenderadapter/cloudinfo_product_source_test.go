@@ -0,0 +1,101 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommenderadapter
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/.gen/cloudinfo"
+	"github.com/banzaicloud/pipeline/internal/cluster/recommender"
+)
+
+type productListerFunc func(cloud string, service string, region string) ([]cloudinfo.ProductDetails, error)
+
+func (f productListerFunc) GetProducts(_ context.Context, cloud string, service string, region string) ([]cloudinfo.ProductDetails, error) {
+	return f(cloud, service, region)
+}
+
+func TestCloudinfoProductSource_GetProducts(t *testing.T) {
+	lister := productListerFunc(func(cloud string, service string, region string) ([]cloudinfo.ProductDetails, error) {
+		switch {
+		case cloud == "amazon" && service == "eks" && region == "eu-west-1":
+			return []cloudinfo.ProductDetails{
+				{
+					Type:          "m5.large",
+					CpusPerVm:     2,
+					MemPerVm:      8,
+					OnDemandPrice: 0.096,
+					SpotPrice:     []cloudinfo.ZonePrice{{Zone: "eu-west-1a", Price: 0.03}},
+					Zones:         []string{"eu-west-1a"},
+					CurrentGen:    true,
+				},
+				{
+					Type:          "m4.large",
+					CpusPerVm:     2,
+					MemPerVm:      8,
+					OnDemandPrice: 0.1,
+				},
+			}, nil
+		case cloud == "google" && service == "gke" && region == "europe-west1":
+			return []cloudinfo.ProductDetails{
+				{
+					Type:          "n1-standard-1",
+					CpusPerVm:     1,
+					MemPerVm:      3.75,
+					OnDemandPrice: 0.0475,
+				},
+			}, nil
+		}
+
+		return nil, errors.New("no products found")
+	})
+
+	source := NewCloudinfoProductSource(lister)
+
+	products, err := source.GetProducts(context.Background(), "amazon", "eks", "eu-west-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []recommender.Product{
+		{
+			InstanceType:      "m5.large",
+			CPU:               2,
+			Memory:            8,
+			OnDemandPrice:     0.096,
+			SpotPrices:        map[string]float64{"eu-west-1a": 0.03},
+			Zones:             []string{"eu-west-1a"},
+			CurrentGeneration: true,
+		},
+		{
+			InstanceType:  "m4.large",
+			CPU:           2,
+			Memory:        8,
+			OnDemandPrice: 0.1,
+			SpotPrices:    map[string]float64{},
+		},
+	}, products)
+
+	products, err = source.GetProducts(context.Background(), "google", "gke", "europe-west1-b")
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.True(t, products[0].CurrentGeneration)
+
+	_, err = source.GetProducts(context.Background(), "azure", "aks", "westeurope")
+	require.Error(t, err)
+}
@@ -0,0 +1,63 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommenderdriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"emperror.dev/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/recommender"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+)
+
+// RegisterHTTPHandlers mounts all of the service endpoints into an http.Handler.
+// The router is expected to be an organization router (ie. /orgs/{orgId}).
+func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
+
+	router.Methods(http.MethodPost).Path("/recommendations/nodepools").Handler(kithttp.NewServer(
+		endpoints.RecommendNodePools,
+		decodeRecommendNodePoolsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeRecommendNodePoolsHTTPResponse, errorEncoder),
+		options...,
+	))
+}
+
+func decodeRecommendNodePoolsHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	var request recommender.Request
+
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return RecommendNodePoolsRequest{Request: request}, nil
+}
+
+func encodeRecommendNodePoolsHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(RecommendNodePoolsResponse)
+
+	if resp.Layouts == nil {
+		resp.Layouts = []recommender.Layout{}
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Layouts)
+}
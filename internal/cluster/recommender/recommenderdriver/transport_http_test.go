@@ -0,0 +1,112 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recommenderdriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+	"github.com/banzaicloud/pipeline/internal/cluster/recommender"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+)
+
+func newTestServer(endpoints Endpoints) *httptest.Server {
+	handler := mux.NewRouter()
+	RegisterHTTPHandlers(
+		endpoints,
+		handler.PathPrefix("/orgs/{orgId}").Subrouter(),
+		kithttp.ServerErrorEncoder(kitxhttp.NewJSONProblemErrorEncoder(apphttp.NewDefaultProblemConverter())),
+	)
+
+	return httptest.NewServer(handler)
+}
+
+func TestRegisterHTTPHandlers_RecommendNodePools(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		RecommendNodePools: func(ctx context.Context, request interface{}) (interface{}, error) {
+			expected := RecommendNodePoolsRequest{
+				Request: recommender.Request{
+					Cloud:        "amazon",
+					Distribution: "eks",
+					Location:     "eu-west-1",
+					Zones:        []string{"eu-west-1a"},
+					CPU:          8,
+					Memory:       32,
+					SpotRatio:    0.5,
+				},
+			}
+
+			if !assert.Equal(t, expected, request) {
+				return nil, nil
+			}
+
+			return RecommendNodePoolsResponse{
+				Layouts: []recommender.Layout{
+					{
+						NodePools: []recommender.NodePool{{InstanceType: "m5.large", Count: 4, CPU: 2, Memory: 8, NodePrice: 0.096}},
+						CPU:       8,
+						Memory:    32,
+						Cost:      cost.Cost{Hourly: 0.384, Monthly: 280.32},
+					},
+				},
+			}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := http.Post(
+		ts.URL+"/orgs/1/recommendations/nodepools",
+		"application/json",
+		strings.NewReader(`{"cloud":"amazon","distribution":"eks","location":"eu-west-1","zones":["eu-west-1a"],"cpu":8,"memory":32,"spotRatio":0.5}`),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body []map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	require.Len(t, body, 1)
+	assert.Equal(t, 0.384, body[0]["cost"].(map[string]interface{})["hourly"])
+}
+
+func TestRegisterHTTPHandlers_RecommendNodePools_Invalid(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		RecommendNodePools: func(ctx context.Context, request interface{}) (interface{}, error) {
+			return RecommendNodePoolsResponse{
+				Err: recommender.NewValidationError("invalid recommendation request", []string{"cloud is required"}),
+			}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/orgs/1/recommendations/nodepools", "application/json", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
@@ -20,6 +20,7 @@ import (
 	"emperror.dev/errors"
 
 	"github.com/banzaicloud/pipeline/pkg/brn"
+	"github.com/banzaicloud/pipeline/pkg/kubernetes"
 )
 
 // Cluster status constants
@@ -31,12 +32,16 @@ const (
 	Warning  = "WARNING"
 	Error    = "ERROR"
 
-	CreatingMessage = "Cluster creation is in progress"
-	RunningMessage  = "Cluster is running"
-	UpdatingMessage = "Update is in progress"
-	DeletingMessage = "Termination is in progress"
+	CreatingMessage  = "Cluster creation is in progress"
+	RunningMessage   = "Cluster is running"
+	UpdatingMessage  = "Update is in progress"
+	DeletingMessage  = "Termination is in progress"
+	UnlinkingMessage = "Unlinking is in progress"
 )
 
+// importedClusterCloud is the cloud of clusters imported into Pipeline with a kubeconfig.
+const importedClusterCloud = "kubernetes"
+
 // Cluster represents a generic, provider agnostic Kubernetes cluster structure.
 type Cluster struct {
 	ID   uint
@@ -52,6 +57,8 @@ type Cluster struct {
 	Distribution string
 	Location     string
 
+	Labels map[string]string
+
 	SecretID       brn.ResourceName
 	ConfigSecretID brn.ResourceName
 }
@@ -76,11 +83,17 @@ type Store interface {
 
 	// SetStatus sets the cluster status.
 	SetStatus(ctx context.Context, id uint, status string, statusMessage string) error
+
+	// SetLabels replaces the user defined labels of a cluster.
+	SetLabels(ctx context.Context, id uint, labels map[string]string) error
 }
 
 // +testify:mock:testOnly=true
 type ClusterGroupManager interface {
 	ValidateClusterRemoval(ctx context.Context, clusterID uint) error
+
+	// ReconcileClusterMembership re-evaluates the dynamic cluster group memberships of a cluster.
+	ReconcileClusterMembership(ctx context.Context, clusterID uint) error
 }
 
 // ClusterDeleteNotPermittedError is returned if a cluster cannot be deleted.
@@ -240,11 +253,32 @@ type Service interface {
 
 	// DeleteNodePool deletes a node pool from a cluster.
 	DeleteNodePool(ctx context.Context, clusterID uint, name string) (deleted bool, err error)
+
+	// ReplaceNodePool replaces the nodes of a node pool one batch at a time.
+	ReplaceNodePool(ctx context.Context, clusterID uint, name string, options ReplaceNodePoolOptions) error
+
+	// UpdateClusterLabels replaces the user defined labels of a cluster.
+	UpdateClusterLabels(ctx context.Context, clusterID uint, labels map[string]string) error
+
+	// UnlinkCluster removes an imported cluster from Pipeline without deleting the cluster itself.
+	UnlinkCluster(ctx context.Context, clusterID uint, options UnlinkClusterOptions) error
 }
 
 // DeleteClusterOptions represents cluster deletion options.
 type DeleteClusterOptions struct {
 	Force bool
+
+	// KeepResources leaves every resource in the cluster untouched.
+	// Only imported clusters support it.
+	KeepResources bool
+}
+
+// UnlinkClusterOptions represents cluster unlinking options.
+type UnlinkClusterOptions struct {
+	// Uninstall removes the resources installed by Pipeline from the cluster before unlinking it.
+	Uninstall bool
+
+	Force bool
 }
 
 type service struct {
@@ -258,6 +292,8 @@ type service struct {
 	nodePoolManager   NodePoolManager
 }
 
+// +testify:mock:testOnly=true
+
 // Manager provides lower level cluster operations for Service.
 type Manager interface {
 	Deleter
@@ -331,3 +367,75 @@ func (s service) DeleteCluster(ctx context.Context, clusterIdentifier Identifier
 
 	return false, nil
 }
+
+// UnlinkCluster removes an imported cluster from Pipeline without deleting the cluster itself.
+func (s service) UnlinkCluster(ctx context.Context, clusterID uint, options UnlinkClusterOptions) error {
+	c, err := s.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	if c.Cloud != importedClusterCloud {
+		return errors.WithStack(NotSupportedDistributionError{
+			ID:           c.ID,
+			Cloud:        c.Cloud,
+			Distribution: c.Distribution,
+
+			Message: "only imported clusters can be unlinked",
+		})
+	}
+
+	err = s.clusterGroupManager.ValidateClusterRemoval(ctx, clusterID)
+	if err != nil {
+		return ClusterDeleteNotPermittedError{
+			OrganizationID: c.OrganizationID,
+			ClusterName:    c.Name,
+			ClusterID:      c.ID,
+			Msg:            err.Error(),
+		}
+	}
+
+	if err := s.clusters.SetStatus(ctx, c.ID, Deleting, UnlinkingMessage); err != nil {
+		return err
+	}
+
+	deleteOptions := DeleteClusterOptions{
+		Force:         options.Force,
+		KeepResources: !options.Uninstall,
+	}
+
+	return s.clusterManager.DeleteCluster(ctx, c.ID, deleteOptions)
+}
+
+// UpdateClusterLabels replaces the user defined labels of a cluster.
+func (s service) UpdateClusterLabels(ctx context.Context, clusterID uint, labels map[string]string) error {
+	_, err := s.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	err = kubernetes.LabelValidator{}.ValidateLabels(labels)
+	if err != nil {
+		var verr interface {
+			Violations() []string
+		}
+		var violations []string
+		if errors.As(err, &verr) {
+			violations = verr.Violations()
+		}
+
+		return errors.WithStack(NewValidationError("invalid cluster labels", violations))
+	}
+
+	err = s.clusters.SetLabels(ctx, clusterID, labels)
+	if err != nil {
+		return err
+	}
+
+	err = s.clusterGroupManager.ReconcileClusterMembership(ctx, clusterID)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to reconcile cluster group membership", "clusterId", clusterID)
+	}
+
+	return nil
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/banzaicloud/pipeline/pkg/cloud"
+	"github.com/banzaicloud/pipeline/pkg/common"
 )
 
 // NodePool is a common interface for all distribution node pools.
@@ -94,6 +95,24 @@ func (n NewRawNodePool) GetLabels() map[string]string {
 	return labels
 }
 
+// GetTaints returns taints that are/should be applied to every node in the pool.
+// It returns an error if the taints are malformed.
+func (n NewRawNodePool) GetTaints() ([]common.Taint, error) {
+	var taints []common.Taint
+
+	t, ok := n["taints"]
+	if !ok {
+		return nil, nil
+	}
+
+	err := mapstructure.Decode(t, &taints)
+	if err != nil {
+		return nil, errors.WrapIf(err, "malformed taints")
+	}
+
+	return taints, nil
+}
+
 // NodePoolAlreadyExistsError is returned when a node pool already exists.
 type NodePoolAlreadyExistsError struct {
 	ClusterID uint
@@ -122,6 +141,45 @@ func (NodePoolAlreadyExistsError) ServiceError() bool {
 	return true
 }
 
+// NodePoolNotFoundError is returned when a node pool cannot be found.
+type NodePoolNotFoundError struct {
+	ClusterID uint
+	NodePool  string
+}
+
+// Error implements the error interface.
+func (NodePoolNotFoundError) Error() string {
+	return "node pool not found"
+}
+
+// Details returns error details.
+func (e NodePoolNotFoundError) Details() []interface{} {
+	return []interface{}{"clusterId", e.ClusterID, "nodePool", e.NodePool}
+}
+
+// NotFound tells a client that this error is related to a resource being not found.
+// Can be used to translate the error to status codes for example.
+func (NodePoolNotFoundError) NotFound() bool {
+	return true
+}
+
+// ServiceError tells the consumer whether this error is caused by invalid input supplied by the client.
+// Client errors are usually returned to the consumer without retrying the operation.
+func (NodePoolNotFoundError) ServiceError() bool {
+	return true
+}
+
+// ReplaceNodePoolOptions represents node pool replacement options.
+type ReplaceNodePoolOptions struct {
+	// Image is the machine image new nodes are started from.
+	// The current image is kept when it is empty.
+	Image string
+
+	// MaxSurge is the number of extra nodes started (and old nodes drained) at a time.
+	// Defaults to 1.
+	MaxSurge int
+}
+
 // +testify:mock:testOnly=true
 
 // NodePoolStore provides an interface to node pool persistence.
@@ -158,6 +216,9 @@ type NodePoolManager interface {
 
 	// DeleteNodePool deletes a node pool from a cluster.
 	DeleteNodePool(ctx context.Context, clusterID uint, name string) error
+
+	// ReplaceNodePool replaces the nodes of a node pool in a cluster.
+	ReplaceNodePool(ctx context.Context, clusterID uint, name string, options ReplaceNodePoolOptions) error
 }
 
 func (s service) CreateNodePool(
@@ -241,11 +302,70 @@ func (s service) DeleteNodePool(ctx context.Context, clusterID uint, name string
 	return false, nil
 }
 
+func (s service) ReplaceNodePool(ctx context.Context, clusterID uint, name string, options ReplaceNodePoolOptions) error {
+	cluster, err := s.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.nodePoolReplaceSupported(cluster); err != nil {
+		return err
+	}
+
+	if err := s.checkClusterReady(cluster); err != nil {
+		return err
+	}
+
+	if options.MaxSurge < 0 {
+		return errors.WithStack(NewValidationError(
+			"invalid node pool replace options",
+			[]string{"maxSurge must be a non-negative number"},
+		))
+	}
+
+	if options.MaxSurge == 0 {
+		options.MaxSurge = 1
+	}
+
+	exists, err := s.nodePools.NodePoolExists(ctx, clusterID, name)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return errors.WithStack(NodePoolNotFoundError{
+			ClusterID: clusterID,
+			NodePool:  name,
+		})
+	}
+
+	err = s.clusters.SetStatus(ctx, clusterID, Updating, "replacing node pool")
+	if err != nil {
+		return err
+	}
+
+	err = s.nodePoolManager.ReplaceNodePool(ctx, clusterID, name, options)
+	if err != nil {
+		// The replacement has not started: restore the previous cluster status
+		if serr := s.clusters.SetStatus(ctx, clusterID, cluster.Status, cluster.StatusMessage); serr != nil {
+			return errors.Combine(err, serr)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
 func (s service) checkCluster(cluster Cluster) error {
 	if err := s.nodePoolSupported(cluster); err != nil {
 		return err
 	}
 
+	return s.checkClusterReady(cluster)
+}
+
+func (s service) checkClusterReady(cluster Cluster) error {
 	if cluster.Status != Running && cluster.Status != Warning {
 		return errors.WithStack(NotReadyError{ID: cluster.ID})
 	}
@@ -267,3 +387,20 @@ func (s service) nodePoolSupported(cluster Cluster) error {
 		Message: "the node pool API does not support this distribution yet",
 	})
 }
+
+func (s service) nodePoolReplaceSupported(cluster Cluster) error {
+	switch {
+	case cluster.Cloud == cloud.Amazon && cluster.Distribution == "eks":
+		return nil
+	case cluster.Cloud == cloud.Amazon && cluster.Distribution == "pke":
+		return nil
+	}
+
+	return errors.WithStack(NotSupportedDistributionError{
+		ID:           cluster.ID,
+		Cloud:        cluster.Cloud,
+		Distribution: cluster.Distribution,
+
+		Message: "node pool replacement is not supported for this distribution yet",
+	})
+}
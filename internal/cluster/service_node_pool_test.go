@@ -495,3 +495,177 @@ func TestNodePoolService_DeleteNodePool(t *testing.T) {
 		manager.AssertExpectations(t)
 	})
 }
+
+func TestNodePoolService_ReplaceNodePool(t *testing.T) {
+	t.Run("DistributionNotSupported", func(t *testing.T) {
+		ctx := context.Background()
+
+		clusterStore := new(MockStore)
+
+		cluster := Cluster{
+			ID:            1,
+			UID:           "1",
+			Name:          "cluster",
+			Status:        Running,
+			StatusMessage: RunningMessage,
+			Cloud:         cloud.Google,
+			Distribution:  "gke",
+		}
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+
+		nodePoolStore := new(MockNodePoolStore)
+		manager := new(MockNodePoolManager)
+
+		nodePoolService := NewService(clusterStore, nil, nil, nodePoolStore, nil, nil, manager)
+
+		err := nodePoolService.ReplaceNodePool(ctx, 1, "pool0", ReplaceNodePoolOptions{})
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &NotSupportedDistributionError{}))
+
+		clusterStore.AssertExpectations(t)
+		nodePoolStore.AssertExpectations(t)
+		manager.AssertExpectations(t)
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		ctx := context.Background()
+
+		clusterStore := new(MockStore)
+
+		cluster := Cluster{
+			ID:            1,
+			UID:           "1",
+			Name:          "cluster",
+			Status:        Running,
+			StatusMessage: RunningMessage,
+			Cloud:         cloud.Amazon,
+			Distribution:  "pke",
+		}
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+
+		nodePoolStore := new(MockNodePoolStore)
+		manager := new(MockNodePoolManager)
+
+		nodePoolService := NewService(clusterStore, nil, nil, nodePoolStore, nil, nil, manager)
+
+		err := nodePoolService.ReplaceNodePool(ctx, 1, "pool0", ReplaceNodePoolOptions{MaxSurge: -1})
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &ValidationError{}))
+
+		clusterStore.AssertExpectations(t)
+		nodePoolStore.AssertExpectations(t)
+		manager.AssertExpectations(t)
+	})
+
+	t.Run("NodePoolNotFound", func(t *testing.T) {
+		ctx := context.Background()
+
+		clusterStore := new(MockStore)
+
+		cluster := Cluster{
+			ID:            1,
+			UID:           "1",
+			Name:          "cluster",
+			Status:        Running,
+			StatusMessage: RunningMessage,
+			Cloud:         cloud.Amazon,
+			Distribution:  "eks",
+		}
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+
+		const nodePoolName = "pool0"
+
+		nodePoolStore := new(MockNodePoolStore)
+		nodePoolStore.On("NodePoolExists", ctx, cluster.ID, nodePoolName).Return(false, nil)
+
+		manager := new(MockNodePoolManager)
+
+		nodePoolService := NewService(clusterStore, nil, nil, nodePoolStore, nil, nil, manager)
+
+		err := nodePoolService.ReplaceNodePool(ctx, 1, nodePoolName, ReplaceNodePoolOptions{})
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &NodePoolNotFoundError{}))
+
+		clusterStore.AssertExpectations(t)
+		nodePoolStore.AssertExpectations(t)
+		manager.AssertExpectations(t)
+	})
+
+	t.Run("StartFailed", func(t *testing.T) {
+		ctx := context.Background()
+
+		clusterStore := new(MockStore)
+
+		cluster := Cluster{
+			ID:            1,
+			UID:           "1",
+			Name:          "cluster",
+			Status:        Warning,
+			StatusMessage: "previous warning",
+			Cloud:         cloud.Amazon,
+			Distribution:  "eks",
+		}
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+		clusterStore.On("SetStatus", ctx, cluster.ID, Updating, "replacing node pool").Return(nil)
+		clusterStore.On("SetStatus", ctx, cluster.ID, Warning, "previous warning").Return(nil)
+
+		const nodePoolName = "pool0"
+
+		nodePoolStore := new(MockNodePoolStore)
+		nodePoolStore.On("NodePoolExists", ctx, cluster.ID, nodePoolName).Return(true, nil)
+
+		startErr := errors.New("failed to start workflow")
+
+		manager := new(MockNodePoolManager)
+		manager.On("ReplaceNodePool", ctx, cluster.ID, nodePoolName, ReplaceNodePoolOptions{MaxSurge: 1}).Return(startErr)
+
+		nodePoolService := NewService(clusterStore, nil, nil, nodePoolStore, nil, nil, manager)
+
+		err := nodePoolService.ReplaceNodePool(ctx, 1, nodePoolName, ReplaceNodePoolOptions{})
+		require.Error(t, err)
+
+		assert.Equal(t, startErr, err)
+
+		clusterStore.AssertExpectations(t)
+		nodePoolStore.AssertExpectations(t)
+		manager.AssertExpectations(t)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ctx := context.Background()
+
+		clusterStore := new(MockStore)
+
+		cluster := Cluster{
+			ID:            1,
+			UID:           "1",
+			Name:          "cluster",
+			Status:        Running,
+			StatusMessage: RunningMessage,
+			Cloud:         cloud.Amazon,
+			Distribution:  "eks",
+		}
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+		clusterStore.On("SetStatus", ctx, cluster.ID, Updating, "replacing node pool").Return(nil)
+
+		const nodePoolName = "pool0"
+
+		nodePoolStore := new(MockNodePoolStore)
+		nodePoolStore.On("NodePoolExists", ctx, cluster.ID, nodePoolName).Return(true, nil)
+
+		manager := new(MockNodePoolManager)
+		manager.On("ReplaceNodePool", ctx, cluster.ID, nodePoolName, ReplaceNodePoolOptions{Image: "ami-0123", MaxSurge: 1}).Return(nil)
+
+		nodePoolService := NewService(clusterStore, nil, nil, nodePoolStore, nil, nil, manager)
+
+		err := nodePoolService.ReplaceNodePool(ctx, 1, nodePoolName, ReplaceNodePoolOptions{Image: "ami-0123"})
+		require.NoError(t, err)
+
+		clusterStore.AssertExpectations(t)
+		nodePoolStore.AssertExpectations(t)
+		manager.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,207 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/pkg/cloud"
+)
+
+func TestService_UpdateClusterLabels(t *testing.T) {
+	cluster := Cluster{
+		ID:            1,
+		UID:           "1",
+		Name:          "cluster",
+		Status:        Running,
+		StatusMessage: RunningMessage,
+		Cloud:         cloud.Amazon,
+		Distribution:  "eks",
+	}
+
+	t.Run("ClusterNotFound", func(t *testing.T) {
+		ctx := context.Background()
+
+		clusterStore := new(MockStore)
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(Cluster{}, NotFoundError{ClusterID: cluster.ID})
+
+		clusterGroupManager := new(MockClusterGroupManager)
+
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nil, nil, nil)
+
+		err := service.UpdateClusterLabels(ctx, cluster.ID, map[string]string{"env": "prod"})
+		require.Error(t, err)
+
+		assert.True(t, IsNotFoundError(err))
+
+		clusterStore.AssertExpectations(t)
+		clusterGroupManager.AssertExpectations(t)
+	})
+
+	t.Run("InvalidLabels", func(t *testing.T) {
+		ctx := context.Background()
+
+		clusterStore := new(MockStore)
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+
+		clusterGroupManager := new(MockClusterGroupManager)
+
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nil, nil, nil)
+
+		err := service.UpdateClusterLabels(ctx, cluster.ID, map[string]string{"env": "not valid!"})
+		require.Error(t, err)
+
+		var validationErr ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.NotEmpty(t, validationErr.Violations())
+
+		clusterStore.AssertExpectations(t)
+		clusterGroupManager.AssertExpectations(t)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		ctx := context.Background()
+
+		labels := map[string]string{"env": "prod"}
+
+		clusterStore := new(MockStore)
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+		clusterStore.On("SetLabels", ctx, cluster.ID, labels).Return(nil)
+
+		clusterGroupManager := new(MockClusterGroupManager)
+		clusterGroupManager.On("ReconcileClusterMembership", ctx, cluster.ID).Return(nil)
+
+		service := NewService(clusterStore, nil, clusterGroupManager, nil, nil, nil, nil)
+
+		err := service.UpdateClusterLabels(ctx, cluster.ID, labels)
+		require.NoError(t, err)
+
+		clusterStore.AssertExpectations(t)
+		clusterGroupManager.AssertExpectations(t)
+	})
+}
+
+func TestService_UnlinkCluster(t *testing.T) {
+	cluster := Cluster{
+		ID:            1,
+		UID:           "1",
+		Name:          "cluster",
+		Status:        Running,
+		StatusMessage: RunningMessage,
+		Cloud:         "kubernetes",
+		Distribution:  "unknown",
+	}
+
+	t.Run("NotImported", func(t *testing.T) {
+		ctx := context.Background()
+
+		eksCluster := cluster
+		eksCluster.Cloud = cloud.Amazon
+		eksCluster.Distribution = "eks"
+
+		clusterStore := new(MockStore)
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(eksCluster, nil)
+
+		clusterManager := new(MockManager)
+		clusterGroupManager := new(MockClusterGroupManager)
+
+		service := NewService(clusterStore, clusterManager, clusterGroupManager, nil, nil, nil, nil)
+
+		err := service.UnlinkCluster(ctx, cluster.ID, UnlinkClusterOptions{})
+		require.Error(t, err)
+
+		var notSupportedErr NotSupportedDistributionError
+		assert.True(t, errors.As(err, &notSupportedErr))
+
+		clusterStore.AssertExpectations(t)
+		clusterManager.AssertExpectations(t)
+		clusterGroupManager.AssertExpectations(t)
+	})
+
+	t.Run("ClusterGroupMember", func(t *testing.T) {
+		ctx := context.Background()
+
+		clusterStore := new(MockStore)
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+
+		clusterManager := new(MockManager)
+
+		clusterGroupManager := new(MockClusterGroupManager)
+		clusterGroupManager.On("ValidateClusterRemoval", ctx, cluster.ID).Return(errors.New("cluster is a member of a cluster group"))
+
+		service := NewService(clusterStore, clusterManager, clusterGroupManager, nil, nil, nil, nil)
+
+		err := service.UnlinkCluster(ctx, cluster.ID, UnlinkClusterOptions{})
+		require.Error(t, err)
+
+		var notPermittedErr ClusterDeleteNotPermittedError
+		assert.True(t, errors.As(err, &notPermittedErr))
+
+		clusterStore.AssertExpectations(t)
+		clusterManager.AssertExpectations(t)
+		clusterGroupManager.AssertExpectations(t)
+	})
+
+	t.Run("KeepResources", func(t *testing.T) {
+		ctx := context.Background()
+
+		clusterStore := new(MockStore)
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+		clusterStore.On("SetStatus", ctx, cluster.ID, Deleting, UnlinkingMessage).Return(nil)
+
+		clusterManager := new(MockManager)
+		clusterManager.On("DeleteCluster", ctx, cluster.ID, DeleteClusterOptions{KeepResources: true}).Return(nil)
+
+		clusterGroupManager := new(MockClusterGroupManager)
+		clusterGroupManager.On("ValidateClusterRemoval", ctx, cluster.ID).Return(nil)
+
+		service := NewService(clusterStore, clusterManager, clusterGroupManager, nil, nil, nil, nil)
+
+		err := service.UnlinkCluster(ctx, cluster.ID, UnlinkClusterOptions{})
+		require.NoError(t, err)
+
+		clusterStore.AssertExpectations(t)
+		clusterManager.AssertExpectations(t)
+		clusterGroupManager.AssertExpectations(t)
+	})
+
+	t.Run("Uninstall", func(t *testing.T) {
+		ctx := context.Background()
+
+		clusterStore := new(MockStore)
+		clusterStore.On("GetCluster", ctx, cluster.ID).Return(cluster, nil)
+		clusterStore.On("SetStatus", ctx, cluster.ID, Deleting, UnlinkingMessage).Return(nil)
+
+		clusterManager := new(MockManager)
+		clusterManager.On("DeleteCluster", ctx, cluster.ID, DeleteClusterOptions{Force: true}).Return(nil)
+
+		clusterGroupManager := new(MockClusterGroupManager)
+		clusterGroupManager.On("ValidateClusterRemoval", ctx, cluster.ID).Return(nil)
+
+		service := NewService(clusterStore, clusterManager, clusterGroupManager, nil, nil, nil, nil)
+
+		err := service.UnlinkCluster(ctx, cluster.ID, UnlinkClusterOptions{Uninstall: true, Force: true})
+		require.NoError(t, err)
+
+		clusterStore.AssertExpectations(t)
+		clusterManager.AssertExpectations(t)
+		clusterGroupManager.AssertExpectations(t)
+	})
+}
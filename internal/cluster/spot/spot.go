@@ -0,0 +1,544 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+)
+
+// Event types.
+const (
+	// EventTypeInterruptionNotice is recorded when the cloud provider announces the interruption of an instance.
+	EventTypeInterruptionNotice = "interruption-notice"
+
+	// EventTypeInterruption is recorded when the cloud provider stops or terminates an instance.
+	EventTypeInterruption = "interruption"
+
+	// EventTypeTermination is recorded when an instance is stopped or terminated by its user (eg. scale down).
+	EventTypeTermination = "termination"
+)
+
+// Event sources.
+const (
+	// EventSourceTerminationHandler events are reported by the instance termination handler running in the cluster.
+	EventSourceTerminationHandler = "termination-handler"
+
+	// EventSourceSpotRequest events are observed in the state of EC2 spot requests.
+	EventSourceSpotRequest = "ec2-spot-request"
+)
+
+const (
+	// defaultReportPeriod is the period covered by a report when the request does not specify one.
+	defaultReportPeriod = 30 * 24 * time.Hour
+
+	// maxReportPeriod is the longest period covered by a report.
+	maxReportPeriod = 366 * 24 * time.Hour
+)
+
+// Instance is a spot (or preemptible) instance of a cluster node pool.
+type Instance struct {
+	InstanceID     string
+	OrganizationID uint
+	ClusterID      uint
+	NodePool       string
+
+	Cloud        string
+	Distribution string
+
+	// Location is the region of the instance.
+	Location string
+	Zone     string
+
+	InstanceType string
+
+	LaunchedAt   time.Time
+	TerminatedAt *time.Time
+
+	// Interrupted is true if the instance has been interrupted by the cloud provider.
+	Interrupted bool
+}
+
+// Event is a spot interruption or termination event.
+type Event struct {
+	OrganizationID uint      `json:"-"`
+	ClusterID      uint      `json:"clusterId"`
+	NodePool       string    `json:"nodePool,omitempty"`
+	InstanceID     string    `json:"instanceId"`
+	InstanceType   string    `json:"instanceType,omitempty"`
+	Zone           string    `json:"zone,omitempty"`
+	Type           string    `json:"type"`
+	Source         string    `json:"source"`
+	Reason         string    `json:"reason,omitempty"`
+	OccurredAt     time.Time `json:"occurredAt"`
+}
+
+// IsInterruption returns true if the event is caused by the cloud provider.
+func (e Event) IsInterruption() bool {
+	return e.Type == EventTypeInterruptionNotice || e.Type == EventTypeInterruption
+}
+
+// Notice is an interruption notice reported by a cluster.
+type Notice struct {
+	InstanceID   string
+	InstanceType string
+	Zone         string
+	NodePool     string
+	Reason       string
+	OccurredAt   time.Time
+}
+
+// ReportOptions filters the data a report is calculated from.
+type ReportOptions struct {
+	// ClusterID limits the report to a single cluster (every cluster of the organization if zero).
+	ClusterID uint
+
+	From time.Time
+	To   time.Time
+}
+
+// Report is the spot savings and interruption report of an organization.
+type Report struct {
+	Currency string    `json:"currency"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+
+	InstanceTypes []InstanceTypeReport `json:"instanceTypes"`
+
+	// SpotCost is the estimated cost of the spot instances, OnDemandCost is the cost of the same capacity on demand.
+	SpotCost     float64 `json:"spotCost"`
+	OnDemandCost float64 `json:"onDemandCost"`
+	Savings      float64 `json:"savings"`
+
+	Instances     int `json:"instances"`
+	Interruptions int `json:"interruptions"`
+
+	// Complete is false if some of the instance types could not be priced.
+	Complete bool `json:"complete"`
+}
+
+// InstanceTypeReport is the spot savings and interruption report of an instance type in a location.
+type InstanceTypeReport struct {
+	Cloud        string `json:"cloud"`
+	Distribution string `json:"distribution"`
+	Location     string `json:"location"`
+	InstanceType string `json:"instanceType"`
+
+	Instances     int `json:"instances"`
+	Interruptions int `json:"interruptions"`
+
+	// InterruptionRate is the ratio of the instances interrupted in the period.
+	InterruptionRate float64 `json:"interruptionRate"`
+
+	Hours float64 `json:"hours"`
+
+	SpotCost     float64 `json:"spotCost"`
+	OnDemandCost float64 `json:"onDemandCost"`
+	Savings      float64 `json:"savings"`
+
+	// Priced is false if no price is available for the instance type.
+	Priced bool   `json:"priced"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// +kit:endpoint:errorStrategy=service
+
+// Service records spot interruptions and reports spot savings.
+type Service interface {
+	// RecordNotices records interruption notices reported by a cluster.
+	RecordNotices(ctx context.Context, organizationID uint, clusterID uint, notices []Notice) (err error)
+
+	// ListEvents returns the spot interruption and termination events of an organization.
+	ListEvents(ctx context.Context, organizationID uint, options ReportOptions) (events []Event, err error)
+
+	// GetReport returns the spot savings and interruption report of an organization.
+	GetReport(ctx context.Context, organizationID uint, options ReportOptions) (report Report, err error)
+}
+
+// +testify:mock:testOnly=true
+
+// Store persists spot instances and events.
+type Store interface {
+	// GetInstance returns a spot instance.
+	// It returns false if the instance is not known.
+	GetInstance(ctx context.Context, instanceID string) (Instance, bool, error)
+
+	// SaveInstance creates or updates a spot instance.
+	SaveInstance(ctx context.Context, instance Instance) error
+
+	// CreateEvent persists an event. Events already recorded
+	// for the same instance, type and source are ignored.
+	CreateEvent(ctx context.Context, event Event) error
+
+	// ListInstances returns the spot instances running at any time in a period.
+	ListInstances(ctx context.Context, organizationID uint, clusterID uint, from time.Time, to time.Time) ([]Instance, error)
+
+	// ListEvents returns the events occurred in a period.
+	ListEvents(ctx context.Context, organizationID uint, clusterID uint, from time.Time, to time.Time) ([]Event, error)
+}
+
+// +testify:mock:testOnly=true
+
+// ClusterSource returns the details of clusters.
+type ClusterSource interface {
+	// GetCluster returns the cloud, distribution and location of a cluster.
+	GetCluster(ctx context.Context, organizationID uint, clusterID uint) (cloud string, distribution string, location string, err error)
+}
+
+// Recorder records spot instances and their interruptions.
+type Recorder struct {
+	store Store
+}
+
+// NewRecorder returns a new Recorder.
+func NewRecorder(store Store) Recorder {
+	return Recorder{
+		store: store,
+	}
+}
+
+// RecordInstance records a running (or terminated) spot instance.
+func (r Recorder) RecordInstance(ctx context.Context, instance Instance) error {
+	current, ok, err := r.store.GetInstance(ctx, instance.InstanceID)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		// interruptions and terminations are only recorded by events
+		instance.Interrupted = current.Interrupted
+
+		if instance.TerminatedAt == nil {
+			instance.TerminatedAt = current.TerminatedAt
+		}
+
+		if instance.LaunchedAt.IsZero() || (!current.LaunchedAt.IsZero() && current.LaunchedAt.Before(instance.LaunchedAt)) {
+			instance.LaunchedAt = current.LaunchedAt
+		}
+	}
+
+	return r.store.SaveInstance(ctx, instance)
+}
+
+// RecordEvent records an interruption or termination event and updates the affected instance.
+func (r Recorder) RecordEvent(ctx context.Context, event Event) error {
+	instance, ok, err := r.store.GetInstance(ctx, event.InstanceID)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		if event.NodePool == "" {
+			event.NodePool = instance.NodePool
+		}
+
+		if event.InstanceType == "" {
+			event.InstanceType = instance.InstanceType
+		}
+
+		if event.Zone == "" {
+			event.Zone = instance.Zone
+		}
+
+		if event.IsInterruption() {
+			instance.Interrupted = true
+		}
+
+		if event.Type != EventTypeInterruptionNotice && instance.TerminatedAt == nil {
+			terminatedAt := event.OccurredAt
+			instance.TerminatedAt = &terminatedAt
+		}
+
+		if err := r.store.SaveInstance(ctx, instance); err != nil {
+			return err
+		}
+	}
+
+	return r.store.CreateEvent(ctx, event)
+}
+
+// NewService returns a new Service.
+func NewService(store Store, clusters ClusterSource, prices cost.PriceSource) Service {
+	return service{
+		store:    store,
+		recorder: NewRecorder(store),
+		clusters: clusters,
+		prices:   prices,
+	}
+}
+
+type service struct {
+	store    Store
+	recorder Recorder
+	clusters ClusterSource
+	prices   cost.PriceSource
+}
+
+func (s service) RecordNotices(ctx context.Context, organizationID uint, clusterID uint, notices []Notice) error {
+	var violations []string
+
+	for i, notice := range notices {
+		if notice.InstanceID == "" {
+			violations = append(violations, fmt.Sprintf("notice %d: instance ID is required", i))
+		}
+	}
+
+	if len(violations) > 0 {
+		return NewValidationError("invalid interruption notices", violations)
+	}
+
+	cloud, distribution, location, err := s.clusters.GetCluster(ctx, organizationID, clusterID)
+	if err != nil {
+		return err
+	}
+
+	for _, notice := range notices {
+		occurredAt := notice.OccurredAt
+		if occurredAt.IsZero() {
+			occurredAt = time.Now()
+		}
+
+		// instances of clusters without spot request tracking are only known from their notices:
+		// their running time is unknown, so they only count towards interruption rates
+		if _, ok, err := s.store.GetInstance(ctx, notice.InstanceID); err != nil {
+			return err
+		} else if !ok {
+			err := s.store.SaveInstance(ctx, Instance{
+				InstanceID:     notice.InstanceID,
+				OrganizationID: organizationID,
+				ClusterID:      clusterID,
+				NodePool:       notice.NodePool,
+				Cloud:          cloud,
+				Distribution:   distribution,
+				Location:       location,
+				Zone:           notice.Zone,
+				InstanceType:   notice.InstanceType,
+				LaunchedAt:     occurredAt,
+				TerminatedAt:   &occurredAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		err := s.recorder.RecordEvent(ctx, Event{
+			OrganizationID: organizationID,
+			ClusterID:      clusterID,
+			NodePool:       notice.NodePool,
+			InstanceID:     notice.InstanceID,
+			InstanceType:   notice.InstanceType,
+			Zone:           notice.Zone,
+			Type:           EventTypeInterruptionNotice,
+			Source:         EventSourceTerminationHandler,
+			Reason:         notice.Reason,
+			OccurredAt:     occurredAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s service) ListEvents(ctx context.Context, organizationID uint, options ReportOptions) ([]Event, error) {
+	options, err := s.processOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.ListEvents(ctx, organizationID, options.ClusterID, options.From, options.To)
+}
+
+func (s service) GetReport(ctx context.Context, organizationID uint, options ReportOptions) (Report, error) {
+	options, err := s.processOptions(options)
+	if err != nil {
+		return Report{}, err
+	}
+
+	instances, err := s.store.ListInstances(ctx, organizationID, options.ClusterID, options.From, options.To)
+	if err != nil {
+		return Report{}, err
+	}
+
+	events, err := s.store.ListEvents(ctx, organizationID, options.ClusterID, options.From, options.To)
+	if err != nil {
+		return Report{}, err
+	}
+
+	// an instance counts as interrupted once, regardless of the number of its events
+	interrupted := make(map[string]bool, len(events))
+	for _, event := range events {
+		if event.IsInterruption() {
+			interrupted[event.InstanceID] = true
+		}
+	}
+
+	type instanceTypeKey struct {
+		cloud        string
+		distribution string
+		location     string
+		instanceType string
+	}
+
+	reports := make(map[instanceTypeKey]*InstanceTypeReport)
+
+	for _, instance := range instances {
+		key := instanceTypeKey{
+			cloud:        instance.Cloud,
+			distribution: instance.Distribution,
+			location:     instance.Location,
+			instanceType: instance.InstanceType,
+		}
+
+		report, ok := reports[key]
+		if !ok {
+			report = &InstanceTypeReport{
+				Cloud:        instance.Cloud,
+				Distribution: instance.Distribution,
+				Location:     instance.Location,
+				InstanceType: instance.InstanceType,
+			}
+
+			reports[key] = report
+		}
+
+		report.Instances++
+		report.Hours += runningHours(instance, options.From, options.To)
+
+		if interrupted[instance.InstanceID] {
+			report.Interruptions++
+		}
+	}
+
+	result := Report{
+		Currency:      cost.Currency,
+		From:          options.From,
+		To:            options.To,
+		InstanceTypes: make([]InstanceTypeReport, 0, len(reports)),
+		Complete:      true,
+	}
+
+	for _, report := range reports {
+		report.InterruptionRate = round(float64(report.Interruptions) / float64(report.Instances))
+		report.Hours = round(report.Hours)
+
+		s.priceInstanceType(ctx, report)
+
+		result.SpotCost += report.SpotCost
+		result.OnDemandCost += report.OnDemandCost
+		result.Savings += report.Savings
+		result.Instances += report.Instances
+		result.Interruptions += report.Interruptions
+		result.Complete = result.Complete && report.Priced
+
+		result.InstanceTypes = append(result.InstanceTypes, *report)
+	}
+
+	result.SpotCost = round(result.SpotCost)
+	result.OnDemandCost = round(result.OnDemandCost)
+	result.Savings = round(result.Savings)
+
+	sort.Slice(result.InstanceTypes, func(i, j int) bool {
+		a, b := result.InstanceTypes[i], result.InstanceTypes[j]
+
+		if a.Savings != b.Savings {
+			return a.Savings > b.Savings
+		}
+
+		if a.Location != b.Location {
+			return a.Location < b.Location
+		}
+
+		return a.InstanceType < b.InstanceType
+	})
+
+	return result, nil
+}
+
+// priceInstanceType calculates the costs of an instance type using current prices.
+func (s service) priceInstanceType(ctx context.Context, report *InstanceTypeReport) {
+	price, err := s.prices.GetPrice(ctx, report.Cloud, report.Distribution, report.Location, report.InstanceType)
+	if err != nil {
+		report.Reason = err.Error()
+
+		return
+	}
+
+	if price.Spot <= 0 {
+		report.Reason = "no spot price available"
+
+		return
+	}
+
+	report.Priced = true
+	report.SpotCost = round(report.Hours * price.Spot)
+	report.OnDemandCost = round(report.Hours * price.OnDemand)
+	report.Savings = round(report.OnDemandCost - report.SpotCost)
+}
+
+func (s service) processOptions(options ReportOptions) (ReportOptions, error) {
+	if options.To.IsZero() {
+		options.To = time.Now()
+	}
+
+	if options.From.IsZero() {
+		options.From = options.To.Add(-defaultReportPeriod)
+	}
+
+	var violations []string
+
+	if !options.From.Before(options.To) {
+		violations = append(violations, "from must be before to")
+	}
+
+	if options.To.Sub(options.From) > maxReportPeriod {
+		violations = append(violations, fmt.Sprintf("the report period cannot be longer than %d days", maxReportPeriod/(24*time.Hour)))
+	}
+
+	if len(violations) > 0 {
+		return options, NewValidationError("invalid report options", violations)
+	}
+
+	return options, nil
+}
+
+// runningHours returns the number of hours an instance was running in a period.
+func runningHours(instance Instance, from time.Time, to time.Time) float64 {
+	start := instance.LaunchedAt
+	if start.Before(from) {
+		start = from
+	}
+
+	end := to
+	if instance.TerminatedAt != nil && instance.TerminatedAt.Before(end) {
+		end = *instance.TerminatedAt
+	}
+
+	if !start.Before(end) {
+		return 0
+	}
+
+	return end.Sub(start).Hours()
+}
+
+// round rounds an amount to a thousandth of a cent to hide floating point noise.
+func round(v float64) float64 {
+	return float64(int64(v*100000+0.5)) / 100000
+}
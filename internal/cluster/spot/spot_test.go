@@ -0,0 +1,322 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+)
+
+type priceSourceStub map[string]cost.Price
+
+func (s priceSourceStub) GetPrice(_ context.Context, _ string, _ string, _ string, instanceType string) (cost.Price, error) {
+	price, ok := s[instanceType]
+	if !ok {
+		return cost.Price{}, errors.Errorf("no price for %s", instanceType)
+	}
+
+	return price, nil
+}
+
+func TestRecorder_RecordEvent(t *testing.T) {
+	ctx := context.Background()
+	occurredAt := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	instance := Instance{
+		InstanceID:     "i-1",
+		OrganizationID: 1,
+		ClusterID:      2,
+		NodePool:       "pool1",
+		Zone:           "eu-west-1a",
+		InstanceType:   "m5.large",
+		LaunchedAt:     occurredAt.Add(-time.Hour),
+	}
+
+	event := Event{
+		OrganizationID: 1,
+		ClusterID:      2,
+		InstanceID:     "i-1",
+		Type:           EventTypeInterruption,
+		Source:         EventSourceSpotRequest,
+		Reason:         "instance-terminated-by-price",
+		OccurredAt:     occurredAt,
+	}
+
+	updatedInstance := instance
+	updatedInstance.Interrupted = true
+	updatedInstance.TerminatedAt = &occurredAt
+
+	completeEvent := event
+	completeEvent.NodePool = "pool1"
+	completeEvent.Zone = "eu-west-1a"
+	completeEvent.InstanceType = "m5.large"
+
+	store := new(MockStore)
+	store.On("GetInstance", ctx, "i-1").Return(instance, true, nil)
+	store.On("SaveInstance", ctx, updatedInstance).Return(nil)
+	store.On("CreateEvent", ctx, completeEvent).Return(nil)
+
+	err := NewRecorder(store).RecordEvent(ctx, event)
+	require.NoError(t, err)
+
+	store.AssertExpectations(t)
+}
+
+func TestRecorder_RecordEvent_Termination(t *testing.T) {
+	ctx := context.Background()
+	occurredAt := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	instance := Instance{
+		InstanceID:   "i-1",
+		InstanceType: "m5.large",
+		LaunchedAt:   occurredAt.Add(-time.Hour),
+	}
+
+	event := Event{
+		InstanceID:   "i-1",
+		InstanceType: "m5.large",
+		Type:         EventTypeTermination,
+		Source:       EventSourceSpotRequest,
+		Reason:       "instance-terminated-by-user",
+		OccurredAt:   occurredAt,
+	}
+
+	// terminations by the user are not interruptions
+	terminatedInstance := instance
+	terminatedInstance.TerminatedAt = &occurredAt
+
+	store := new(MockStore)
+	store.On("GetInstance", ctx, "i-1").Return(instance, true, nil)
+	store.On("SaveInstance", ctx, terminatedInstance).Return(nil)
+	store.On("CreateEvent", ctx, event).Return(nil)
+
+	err := NewRecorder(store).RecordEvent(ctx, event)
+	require.NoError(t, err)
+
+	store.AssertExpectations(t)
+}
+
+func TestRecorder_RecordInstance(t *testing.T) {
+	ctx := context.Background()
+	launchedAt := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	terminatedAt := launchedAt.Add(2 * time.Hour)
+
+	current := Instance{
+		InstanceID:   "i-1",
+		InstanceType: "m5.large",
+		LaunchedAt:   launchedAt,
+		TerminatedAt: &terminatedAt,
+		Interrupted:  true,
+	}
+
+	store := new(MockStore)
+	store.On("GetInstance", ctx, "i-1").Return(current, true, nil)
+	store.On("SaveInstance", ctx, current).Return(nil)
+
+	// a later observation of the instance keeps its recorded history
+	err := NewRecorder(store).RecordInstance(ctx, Instance{
+		InstanceID:   "i-1",
+		InstanceType: "m5.large",
+		LaunchedAt:   launchedAt.Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_RecordNotices(t *testing.T) {
+	ctx := context.Background()
+	occurredAt := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("UnknownInstance", func(t *testing.T) {
+		clusters := new(MockClusterSource)
+		clusters.On("GetCluster", ctx, uint(1), uint(2)).Return("amazon", "pke", "eu-west-1", nil)
+
+		instance := Instance{
+			InstanceID:     "i-1",
+			OrganizationID: 1,
+			ClusterID:      2,
+			NodePool:       "pool1",
+			Cloud:          "amazon",
+			Distribution:   "pke",
+			Location:       "eu-west-1",
+			Zone:           "eu-west-1a",
+			InstanceType:   "m5.large",
+			LaunchedAt:     occurredAt,
+			TerminatedAt:   &occurredAt,
+		}
+
+		interruptedInstance := instance
+		interruptedInstance.Interrupted = true
+
+		store := new(MockStore)
+		store.On("GetInstance", ctx, "i-1").Return(Instance{}, false, nil).Once()
+		store.On("SaveInstance", ctx, instance).Return(nil).Once()
+		store.On("GetInstance", ctx, "i-1").Return(instance, true, nil).Once()
+		store.On("SaveInstance", ctx, interruptedInstance).Return(nil).Once()
+		store.On("CreateEvent", ctx, Event{
+			OrganizationID: 1,
+			ClusterID:      2,
+			NodePool:       "pool1",
+			InstanceID:     "i-1",
+			InstanceType:   "m5.large",
+			Zone:           "eu-west-1a",
+			Type:           EventTypeInterruptionNotice,
+			Source:         EventSourceTerminationHandler,
+			OccurredAt:     occurredAt,
+		}).Return(nil)
+
+		service := NewService(store, clusters, priceSourceStub{})
+
+		err := service.RecordNotices(ctx, 1, 2, []Notice{
+			{
+				InstanceID:   "i-1",
+				InstanceType: "m5.large",
+				Zone:         "eu-west-1a",
+				NodePool:     "pool1",
+				OccurredAt:   occurredAt,
+			},
+		})
+		require.NoError(t, err)
+
+		store.AssertExpectations(t)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		service := NewService(new(MockStore), new(MockClusterSource), priceSourceStub{})
+
+		err := service.RecordNotices(ctx, 1, 2, []Notice{{}})
+		require.Error(t, err)
+
+		var verr ValidationError
+		require.True(t, errors.As(err, &verr))
+		assert.Len(t, verr.Violations(), 1)
+	})
+}
+
+func TestService_GetReport(t *testing.T) {
+	ctx := context.Background()
+	to := time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC)
+	from := to.Add(-24 * time.Hour)
+	terminatedAt := from.Add(10 * time.Hour)
+
+	instances := []Instance{
+		{
+			InstanceID:   "i-1",
+			Cloud:        "amazon",
+			Distribution: "eks",
+			Location:     "eu-west-1",
+			InstanceType: "m5.large",
+			LaunchedAt:   from.Add(-time.Hour),
+		},
+		{
+			InstanceID:   "i-2",
+			Cloud:        "amazon",
+			Distribution: "eks",
+			Location:     "eu-west-1",
+			InstanceType: "m5.large",
+			LaunchedAt:   from.Add(2 * time.Hour),
+			TerminatedAt: &terminatedAt,
+			Interrupted:  true,
+		},
+		{
+			InstanceID:   "i-3",
+			Cloud:        "amazon",
+			Distribution: "eks",
+			Location:     "eu-west-1",
+			InstanceType: "x1.16xlarge",
+			LaunchedAt:   from,
+		},
+	}
+
+	events := []Event{
+		{InstanceID: "i-2", Type: EventTypeInterruptionNotice, Source: EventSourceTerminationHandler},
+		{InstanceID: "i-2", Type: EventTypeInterruption, Source: EventSourceSpotRequest},
+		{InstanceID: "i-1", Type: EventTypeTermination, Source: EventSourceSpotRequest},
+	}
+
+	store := new(MockStore)
+	store.On("ListInstances", ctx, uint(1), uint(0), from, to).Return(instances, nil)
+	store.On("ListEvents", ctx, uint(1), uint(0), from, to).Return(events, nil)
+
+	prices := priceSourceStub{
+		"m5.large": {OnDemand: 0.1, Spot: 0.03},
+	}
+
+	report, err := NewService(store, new(MockClusterSource), prices).GetReport(ctx, 1, ReportOptions{From: from, To: to})
+	require.NoError(t, err)
+
+	assert.Equal(t, Report{
+		Currency: cost.Currency,
+		From:     from,
+		To:       to,
+		InstanceTypes: []InstanceTypeReport{
+			{
+				Cloud:            "amazon",
+				Distribution:     "eks",
+				Location:         "eu-west-1",
+				InstanceType:     "m5.large",
+				Instances:        2,
+				Interruptions:    1,
+				InterruptionRate: 0.5,
+				Hours:            32,
+				SpotCost:         0.96,
+				OnDemandCost:     3.2,
+				Savings:          2.24,
+				Priced:           true,
+			},
+			{
+				Cloud:        "amazon",
+				Distribution: "eks",
+				Location:     "eu-west-1",
+				InstanceType: "x1.16xlarge",
+				Instances:    1,
+				Hours:        24,
+				Reason:       "no price for x1.16xlarge",
+			},
+		},
+		SpotCost:      0.96,
+		OnDemandCost:  3.2,
+		Savings:       2.24,
+		Instances:     3,
+		Interruptions: 1,
+		Complete:      false,
+	}, report)
+}
+
+func TestService_GetReport_Invalid(t *testing.T) {
+	ctx := context.Background()
+	to := time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	service := NewService(new(MockStore), new(MockClusterSource), priceSourceStub{})
+
+	_, err := service.GetReport(ctx, 1, ReportOptions{From: to, To: to.Add(-time.Hour)})
+	require.Error(t, err)
+
+	var verr ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.Len(t, verr.Violations(), 1)
+
+	_, err = service.ListEvents(ctx, 1, ReportOptions{From: to.Add(-400 * 24 * time.Hour), To: to})
+	require.Error(t, err)
+	assert.True(t, errors.As(err, &verr))
+}
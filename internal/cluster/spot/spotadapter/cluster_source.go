@@ -0,0 +1,50 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/spot"
+	"github.com/banzaicloud/pipeline/pkg/cloudinfo"
+)
+
+type clusterSource struct {
+	store cluster.Store
+}
+
+// NewClusterSource returns a new spot.ClusterSource backed by the cluster store.
+func NewClusterSource(store cluster.Store) spot.ClusterSource {
+	return clusterSource{
+		store: store,
+	}
+}
+
+func (s clusterSource) GetCluster(ctx context.Context, organizationID uint, clusterID uint) (string, string, string, error) {
+	c, err := s.store.GetCluster(ctx, clusterID)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if c.OrganizationID != organizationID {
+		return "", "", "", errors.WithStack(cluster.NotFoundError{OrganizationID: organizationID, ClusterID: clusterID})
+	}
+
+	// spot instances are recorded per region
+	return c.Cloud, c.Distribution, cloudinfo.RegionFromLocation(c.Cloud, c.Location), nil
+}
@@ -0,0 +1,229 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/spot"
+)
+
+// instanceModel is the persisted form of a spot instance.
+type instanceModel struct {
+	InstanceID     string `gorm:"primary_key;size:64"`
+	OrganizationID uint   `gorm:"index:idx_spot_instances_organization_id_cluster_id"`
+	ClusterID      uint   `gorm:"index:idx_spot_instances_organization_id_cluster_id"`
+	NodePool       string
+	Cloud          string
+	Distribution   string
+	Location       string
+	Zone           string
+	InstanceType   string
+	LaunchedAt     time.Time
+	TerminatedAt   *time.Time
+	Interrupted    bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// TableName changes the default table name.
+func (instanceModel) TableName() string {
+	return "spot_instances"
+}
+
+// eventModel is the persisted form of a spot interruption or termination event.
+type eventModel struct {
+	ID             uint `gorm:"primary_key"`
+	OrganizationID uint `gorm:"index:idx_spot_events_organization_id_occurred_at"`
+	ClusterID      uint
+	NodePool       string
+	InstanceID     string `gorm:"unique_index:idx_spot_events_instance_id_type_source;size:64"`
+	InstanceType   string
+	Zone           string
+	Type           string `gorm:"unique_index:idx_spot_events_instance_id_type_source;size:32"`
+	Source         string `gorm:"unique_index:idx_spot_events_instance_id_type_source;size:32"`
+	Reason         string
+	OccurredAt     time.Time `gorm:"index:idx_spot_events_organization_id_occurred_at"`
+	CreatedAt      time.Time
+}
+
+// TableName changes the default table name.
+func (eventModel) TableName() string {
+	return "spot_events"
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a new spot.Store backed by a relational database.
+func NewGormStore(db *gorm.DB) spot.Store {
+	return gormStore{
+		db: db,
+	}
+}
+
+func (s gormStore) GetInstance(_ context.Context, instanceID string) (spot.Instance, bool, error) {
+	var model instanceModel
+
+	err := s.db.Where(instanceModel{InstanceID: instanceID}).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return spot.Instance{}, false, nil
+	} else if err != nil {
+		return spot.Instance{}, false, errors.WrapIfWithDetails(err, "failed to get spot instance", "instanceId", instanceID)
+	}
+
+	return toInstance(model), true, nil
+}
+
+func (s gormStore) SaveInstance(_ context.Context, instance spot.Instance) error {
+	model := instanceModel{
+		InstanceID:     instance.InstanceID,
+		OrganizationID: instance.OrganizationID,
+		ClusterID:      instance.ClusterID,
+		NodePool:       instance.NodePool,
+		Cloud:          instance.Cloud,
+		Distribution:   instance.Distribution,
+		Location:       instance.Location,
+		Zone:           instance.Zone,
+		InstanceType:   instance.InstanceType,
+		LaunchedAt:     instance.LaunchedAt,
+		TerminatedAt:   instance.TerminatedAt,
+		Interrupted:    instance.Interrupted,
+	}
+
+	if err := s.db.Save(&model).Error; err != nil {
+		return errors.WrapIfWithDetails(
+			err, "failed to save spot instance",
+			"clusterId", instance.ClusterID,
+			"instanceId", instance.InstanceID,
+		)
+	}
+
+	return nil
+}
+
+func (s gormStore) CreateEvent(_ context.Context, event spot.Event) error {
+	model := eventModel{
+		OrganizationID: event.OrganizationID,
+		ClusterID:      event.ClusterID,
+		NodePool:       event.NodePool,
+		InstanceID:     event.InstanceID,
+		InstanceType:   event.InstanceType,
+		Zone:           event.Zone,
+		Type:           event.Type,
+		Source:         event.Source,
+		Reason:         event.Reason,
+		OccurredAt:     event.OccurredAt,
+	}
+
+	err := s.db.
+		Where(eventModel{InstanceID: event.InstanceID, Type: event.Type, Source: event.Source}).
+		Attrs(model).
+		FirstOrCreate(&model).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(
+			err, "failed to create spot event",
+			"clusterId", event.ClusterID,
+			"instanceId", event.InstanceID,
+			"type", event.Type,
+		)
+	}
+
+	return nil
+}
+
+func (s gormStore) ListInstances(_ context.Context, organizationID uint, clusterID uint, from time.Time, to time.Time) ([]spot.Instance, error) {
+	var models []instanceModel
+
+	query := s.db.Where(
+		"organization_id = ? AND launched_at < ? AND (terminated_at IS NULL OR terminated_at >= ?)",
+		organizationID, to, from,
+	)
+	if clusterID != 0 {
+		query = query.Where("cluster_id = ?", clusterID)
+	}
+
+	if err := query.Order("launched_at").Find(&models).Error; err != nil {
+		return nil, errors.WrapIfWithDetails(
+			err, "failed to list spot instances",
+			"organizationId", organizationID,
+			"clusterId", clusterID,
+		)
+	}
+
+	instances := make([]spot.Instance, 0, len(models))
+	for _, model := range models {
+		instances = append(instances, toInstance(model))
+	}
+
+	return instances, nil
+}
+
+func (s gormStore) ListEvents(_ context.Context, organizationID uint, clusterID uint, from time.Time, to time.Time) ([]spot.Event, error) {
+	var models []eventModel
+
+	query := s.db.Where("organization_id = ? AND occurred_at >= ? AND occurred_at < ?", organizationID, from, to)
+	if clusterID != 0 {
+		query = query.Where("cluster_id = ?", clusterID)
+	}
+
+	if err := query.Order("occurred_at").Find(&models).Error; err != nil {
+		return nil, errors.WrapIfWithDetails(
+			err, "failed to list spot events",
+			"organizationId", organizationID,
+			"clusterId", clusterID,
+		)
+	}
+
+	events := make([]spot.Event, 0, len(models))
+	for _, model := range models {
+		events = append(events, spot.Event{
+			OrganizationID: model.OrganizationID,
+			ClusterID:      model.ClusterID,
+			NodePool:       model.NodePool,
+			InstanceID:     model.InstanceID,
+			InstanceType:   model.InstanceType,
+			Zone:           model.Zone,
+			Type:           model.Type,
+			Source:         model.Source,
+			Reason:         model.Reason,
+			OccurredAt:     model.OccurredAt,
+		})
+	}
+
+	return events, nil
+}
+
+func toInstance(model instanceModel) spot.Instance {
+	return spot.Instance{
+		InstanceID:     model.InstanceID,
+		OrganizationID: model.OrganizationID,
+		ClusterID:      model.ClusterID,
+		NodePool:       model.NodePool,
+		Cloud:          model.Cloud,
+		Distribution:   model.Distribution,
+		Location:       model.Location,
+		Zone:           model.Zone,
+		InstanceType:   model.InstanceType,
+		LaunchedAt:     model.LaunchedAt,
+		TerminatedAt:   model.TerminatedAt,
+		Interrupted:    model.Interrupted,
+	}
+}
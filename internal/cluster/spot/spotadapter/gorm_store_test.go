@@ -0,0 +1,123 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/spot"
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, common.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore(t *testing.T) {
+	db := setUpDatabase(t)
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	terminatedAt := now.Add(-2 * time.Hour)
+
+	running := spot.Instance{
+		InstanceID:     "i-1",
+		OrganizationID: 1,
+		ClusterID:      2,
+		NodePool:       "pool1",
+		Cloud:          "amazon",
+		Distribution:   "eks",
+		Location:       "eu-west-1",
+		Zone:           "eu-west-1a",
+		InstanceType:   "m5.large",
+		LaunchedAt:     now.Add(-10 * time.Hour),
+	}
+
+	terminated := running
+	terminated.InstanceID = "i-2"
+	terminated.ClusterID = 3
+	terminated.TerminatedAt = &terminatedAt
+	terminated.Interrupted = true
+
+	_, ok, err := store.GetInstance(ctx, "i-1")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, store.SaveInstance(ctx, running))
+	require.NoError(t, store.SaveInstance(ctx, terminated))
+
+	running.NodePool = "pool2"
+	require.NoError(t, store.SaveInstance(ctx, running))
+
+	stored, ok, err := store.GetInstance(ctx, "i-1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "pool2", stored.NodePool)
+	assert.True(t, running.LaunchedAt.Equal(stored.LaunchedAt))
+
+	instances, err := store.ListInstances(ctx, 1, 0, now.Add(-time.Hour), now)
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, "i-1", instances[0].InstanceID)
+
+	instances, err = store.ListInstances(ctx, 1, 3, now.Add(-24*time.Hour), now)
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	assert.Equal(t, "i-2", instances[0].InstanceID)
+	assert.True(t, instances[0].Interrupted)
+
+	event := spot.Event{
+		OrganizationID: 1,
+		ClusterID:      3,
+		NodePool:       "pool1",
+		InstanceID:     "i-2",
+		InstanceType:   "m5.large",
+		Type:           spot.EventTypeInterruption,
+		Source:         spot.EventSourceSpotRequest,
+		Reason:         "instance-terminated-by-price",
+		OccurredAt:     terminatedAt,
+	}
+
+	require.NoError(t, store.CreateEvent(ctx, event))
+
+	// recording the same event again is a no-op
+	duplicate := event
+	duplicate.OccurredAt = now
+	require.NoError(t, store.CreateEvent(ctx, duplicate))
+
+	events, err := store.ListEvents(ctx, 1, 0, now.Add(-24*time.Hour), now)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, event.Reason, events[0].Reason)
+	assert.True(t, event.OccurredAt.Equal(events[0].OccurredAt))
+
+	events, err = store.ListEvents(ctx, 1, 2, now.Add(-24*time.Hour), now)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
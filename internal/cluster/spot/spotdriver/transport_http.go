@@ -0,0 +1,213 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotdriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/spot"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+)
+
+// RegisterHTTPHandlers mounts all of the service endpoints into an http.Handler.
+// The router is expected to be an organization router (ie. /orgs/{orgId}).
+func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
+
+	router.Methods(http.MethodPost).Path("/clusters/{clusterId}/spot/events").Handler(kithttp.NewServer(
+		endpoints.RecordNotices,
+		decodeRecordNoticesHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusAccepted), errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/spot/events").Handler(kithttp.NewServer(
+		endpoints.ListEvents,
+		decodeListEventsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListEventsHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/spot/report").Handler(kithttp.NewServer(
+		endpoints.GetReport,
+		decodeGetReportHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetReportHTTPResponse, errorEncoder),
+		options...,
+	))
+}
+
+// alert is an interruption notice sent by the instance termination handler (in Alertmanager alert format).
+type alert struct {
+	Labels   map[string]string `json:"labels"`
+	StartsAt time.Time         `json:"startsAt"`
+}
+
+func decodeRecordNoticesHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	clusterID, err := extractUintParam(r, "clusterId")
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts []alert
+
+	err = json.NewDecoder(r.Body).Decode(&alerts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	notices := make([]spot.Notice, 0, len(alerts))
+	for _, alert := range alerts {
+		notices = append(notices, spot.Notice{
+			InstanceID:   firstLabel(alert.Labels, "instance_id", "instance"),
+			InstanceType: firstLabel(alert.Labels, "instance_type"),
+			Zone:         firstLabel(alert.Labels, "zone", "availability_zone"),
+			NodePool:     firstLabel(alert.Labels, "nodepool", "node_pool"),
+			Reason:       firstLabel(alert.Labels, "alertname"),
+			OccurredAt:   alert.StartsAt,
+		})
+	}
+
+	return RecordNoticesRequest{OrganizationID: orgID, ClusterID: clusterID, Notices: notices}, nil
+}
+
+// firstLabel returns the value of the first label set from a list of label names.
+func firstLabel(labels map[string]string, names ...string) string {
+	for _, name := range names {
+		if value := labels[name]; value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+func decodeListEventsHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := decodeReportOptions(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListEventsRequest{OrganizationID: orgID, Options: options}, nil
+}
+
+func encodeListEventsHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListEventsResponse)
+
+	if resp.Events == nil {
+		resp.Events = []spot.Event{}
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Events)
+}
+
+func decodeGetReportHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := decodeReportOptions(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetReportRequest{OrganizationID: orgID, Options: options}, nil
+}
+
+func encodeGetReportHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(GetReportResponse)
+
+	if resp.Report.InstanceTypes == nil {
+		resp.Report.InstanceTypes = []spot.InstanceTypeReport{}
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Report)
+}
+
+// decodeReportOptions decodes the clusterId, from and to (RFC3339) query parameters.
+func decodeReportOptions(r *http.Request) (spot.ReportOptions, error) {
+	var options spot.ReportOptions
+	var violations []string
+
+	query := r.URL.Query()
+
+	if value := query.Get("clusterId"); value != "" {
+		clusterID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			violations = append(violations, "invalid clusterId: "+value)
+		}
+
+		options.ClusterID = uint(clusterID)
+	}
+
+	if value := query.Get("from"); value != "" {
+		from, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			violations = append(violations, "invalid from: "+value)
+		}
+
+		options.From = from
+	}
+
+	if value := query.Get("to"); value != "" {
+		to, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			violations = append(violations, "invalid to: "+value)
+		}
+
+		options.To = to
+	}
+
+	if len(violations) > 0 {
+		return options, spot.NewValidationError("invalid report options", violations)
+	}
+
+	return options, nil
+}
+
+func extractUintParam(r *http.Request, param string) (uint, error) {
+	vars := mux.Vars(r)
+
+	value, ok := vars[param]
+	if !ok || value == "" {
+		return 0, errors.NewWithDetails("missing path parameter", "param", param)
+	}
+
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "invalid path parameter", "param", param, "value", value)
+	}
+
+	return uint(id), nil
+}
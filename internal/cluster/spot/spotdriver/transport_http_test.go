@@ -0,0 +1,136 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spotdriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/spot"
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+)
+
+func newTestServer(endpoints Endpoints) *httptest.Server {
+	handler := mux.NewRouter()
+	RegisterHTTPHandlers(
+		endpoints,
+		handler.PathPrefix("/orgs/{orgId}").Subrouter(),
+		kithttp.ServerErrorEncoder(kitxhttp.NewJSONProblemErrorEncoder(apphttp.NewDefaultProblemConverter())),
+	)
+
+	return httptest.NewServer(handler)
+}
+
+func TestRegisterHTTPHandlers_RecordNotices(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		RecordNotices: func(ctx context.Context, request interface{}) (interface{}, error) {
+			expected := RecordNoticesRequest{
+				OrganizationID: 1,
+				ClusterID:      2,
+				Notices: []spot.Notice{
+					{
+						InstanceID:   "i-1",
+						InstanceType: "m5.large",
+						Zone:         "eu-west-1a",
+						NodePool:     "pool1",
+						Reason:       "spot_termination_notice",
+						OccurredAt:   time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC),
+					},
+				},
+			}
+
+			if !assert.Equal(t, expected, request) {
+				return nil, nil
+			}
+
+			return RecordNoticesResponse{}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := http.Post(
+		ts.URL+"/orgs/1/clusters/2/spot/events",
+		"application/json",
+		strings.NewReader(`[{"labels":{"alertname":"spot_termination_notice","instance_id":"i-1","instance_type":"m5.large","availability_zone":"eu-west-1a","nodepool":"pool1"},"startsAt":"2020-03-01T12:00:00Z"}]`),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+}
+
+func TestRegisterHTTPHandlers_GetReport(t *testing.T) {
+	from := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	ts := newTestServer(Endpoints{
+		GetReport: func(ctx context.Context, request interface{}) (interface{}, error) {
+			expected := GetReportRequest{
+				OrganizationID: 1,
+				Options: spot.ReportOptions{
+					ClusterID: 2,
+					From:      from,
+				},
+			}
+
+			if !assert.Equal(t, expected, request) {
+				return nil, nil
+			}
+
+			return GetReportResponse{
+				Report: spot.Report{
+					Currency: "USD",
+					From:     from,
+					To:       from.Add(24 * time.Hour),
+					Savings:  2.24,
+				},
+			}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/orgs/1/spot/report?clusterId=2&from=2020-03-01T00:00:00Z")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+
+	assert.Equal(t, 2.24, body["savings"])
+	assert.Equal(t, []interface{}{}, body["instanceTypes"])
+}
+
+func TestRegisterHTTPHandlers_GetReport_Invalid(t *testing.T) {
+	ts := newTestServer(Endpoints{})
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/orgs/1/spot/report?from=yesterday")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
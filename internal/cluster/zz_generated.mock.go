@@ -69,3 +69,45 @@ func (_m *MockService) DeleteNodePool(ctx context.Context, clusterID uint, name
 
 	return r0, r1
 }
+
+// ReplaceNodePool provides a mock function.
+func (_m *MockService) ReplaceNodePool(ctx context.Context, clusterID uint, name string, options ReplaceNodePoolOptions) error {
+	ret := _m.Called(ctx, clusterID, name, options)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, ReplaceNodePoolOptions) error); ok {
+		r0 = rf(ctx, clusterID, name, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateClusterLabels provides a mock function.
+func (_m *MockService) UpdateClusterLabels(ctx context.Context, clusterID uint, labels map[string]string) error {
+	ret := _m.Called(ctx, clusterID, labels)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, map[string]string) error); ok {
+		r0 = rf(ctx, clusterID, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlinkCluster provides a mock function.
+func (_m *MockService) UnlinkCluster(ctx context.Context, clusterID uint, options UnlinkClusterOptions) error {
+	ret := _m.Called(ctx, clusterID, options)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, UnlinkClusterOptions) error); ok {
+		r0 = rf(ctx, clusterID, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -131,6 +131,20 @@ func (_m *MockStore) SetStatus(ctx context.Context, id uint, status string, stat
 	return r0
 }
 
+// SetLabels provides a mock function.
+func (_m *MockStore) SetLabels(ctx context.Context, id uint, labels map[string]string) error {
+	ret := _m.Called(ctx, id, labels)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, map[string]string) error); ok {
+		r0 = rf(ctx, id, labels)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // MockClusterGroupManager is an autogenerated mock for the ClusterGroupManager type.
 type MockClusterGroupManager struct {
 	mock.Mock
@@ -150,6 +164,39 @@ func (_m *MockClusterGroupManager) ValidateClusterRemoval(ctx context.Context, c
 	return r0
 }
 
+// ReconcileClusterMembership provides a mock function.
+func (_m *MockClusterGroupManager) ReconcileClusterMembership(ctx context.Context, clusterID uint) error {
+	ret := _m.Called(ctx, clusterID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, clusterID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager is an autogenerated mock for the Manager type.
+type MockManager struct {
+	mock.Mock
+}
+
+// DeleteCluster provides a mock function.
+func (_m *MockManager) DeleteCluster(ctx context.Context, clusterID uint, options DeleteClusterOptions) error {
+	ret := _m.Called(ctx, clusterID, options)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, DeleteClusterOptions) error); ok {
+		r0 = rf(ctx, clusterID, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // MockNodePoolStore is an autogenerated mock for the NodePoolStore type.
 type MockNodePoolStore struct {
 	mock.Mock
@@ -269,3 +316,17 @@ func (_m *MockNodePoolManager) DeleteNodePool(ctx context.Context, clusterID uin
 
 	return r0
 }
+
+// ReplaceNodePool provides a mock function.
+func (_m *MockNodePoolManager) ReplaceNodePool(ctx context.Context, clusterID uint, name string, options ReplaceNodePoolOptions) error {
+	ret := _m.Called(ctx, clusterID, name, options)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string, ReplaceNodePoolOptions) error); ok {
+		r0 = rf(ctx, clusterID, name, options)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
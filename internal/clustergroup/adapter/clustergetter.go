@@ -75,3 +75,20 @@ func (m *clusterGetter) GetClusterByIDOnly(ctx context.Context, clusterID uint)
 
 	return nil, errors.New("could not assert to Cluster")
 }
+
+// GetClusters returns the cluster instances of an organization.
+func (m *clusterGetter) GetClusters(ctx context.Context, organizationID uint) ([]api.Cluster, error) {
+	commonClusters, err := m.clusterManager.GetClusters(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]api.Cluster, 0, len(commonClusters))
+	for _, c := range commonClusters {
+		if cluster, ok := c.(api.Cluster); ok {
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	return clusters, nil
+}
@@ -21,6 +21,7 @@ import (
 // Cluster
 type Cluster interface {
 	GetID() uint
+	GetOrganizationId() uint
 	GetCloud() string
 	GetDistribution() string
 	GetName() string
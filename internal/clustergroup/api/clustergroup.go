@@ -20,8 +20,9 @@ import (
 
 // CreateRequest describes fields of a create cluster group request
 type CreateRequest struct {
-	Name    string `json:"name" yaml:"name" example:"cluster_group_name"`
-	Members []uint `json:"members" yaml:"members"`
+	Name     string           `json:"name" yaml:"name" example:"cluster_group_name"`
+	Members  []uint           `json:"members" yaml:"members"`
+	Selector *ClusterSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
 }
 
 // Validate validates CreateRequest
@@ -30,8 +31,12 @@ func (g *CreateRequest) Validate() error {
 		return errors.New("cluster group name is empty")
 	}
 
+	if g.Selector != nil {
+		return g.Selector.Validate()
+	}
+
 	if len(g.Members) == 0 {
-		return errors.New("there should be at least one cluster member")
+		return errors.New("there should be at least one cluster member or a cluster selector")
 	}
 	return nil
 }
@@ -44,8 +49,9 @@ type CreateResponse struct {
 
 // UpdateRequest describes fields of a update cluster group request
 type UpdateRequest struct {
-	Name    string `json:"name" yaml:"name" example:"cluster_group_name"`
-	Members []uint `json:"members,omitempty" yaml:"members"`
+	Name     string           `json:"name" yaml:"name" example:"cluster_group_name"`
+	Members  []uint           `json:"members,omitempty" yaml:"members"`
+	Selector *ClusterSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
 }
 
 // Validate validates UpdateRequest
@@ -54,8 +60,12 @@ func (g *UpdateRequest) Validate() error {
 		return errors.New("cluster group name is empty")
 	}
 
+	if g.Selector != nil {
+		return g.Selector.Validate()
+	}
+
 	if len(g.Members) == 0 {
-		return errors.New("there should be at least one cluster member")
+		return errors.New("there should be at least one cluster member or a cluster selector")
 	}
 	return nil
 }
@@ -73,6 +83,7 @@ type Member struct {
 	Distribution string `json:"distribution,omitempty" yaml:"distribution" example:"gke"`
 	Name         string `json:"name" yaml:"name" example:"clusterName"`
 	Status       string `json:"status,omitempty" yaml:"status,omitempty"`
+	Dynamic      bool   `json:"dynamic,omitempty" yaml:"dynamic,omitempty"`
 }
 
 // ClusterGroup
@@ -82,6 +93,7 @@ type ClusterGroup struct {
 	Name            string           `json:"name" yaml:"name"`
 	OrganizationID  uint             `json:"organizationId" yaml:"organizationId"`
 	Members         []Member         `json:"members,omitempty" yaml:"members"`
+	Selector        *ClusterSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
 	EnabledFeatures []string         `json:"enabledFeatures,omitempty" yaml:"enabledFeatures"`
 	Clusters        map[uint]Cluster `json:"-" yaml:"-"`
 }
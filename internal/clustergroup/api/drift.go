@@ -0,0 +1,59 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"time"
+)
+
+// Drift kinds
+const (
+	KubernetesVersionDrift = "kubernetesVersion"
+	DeploymentDrift        = "deployment"
+	IntegratedServiceDrift = "integratedService"
+)
+
+// DriftReport describes how consistent the member clusters of a cluster group are
+type DriftReport struct {
+	ClusterGroupID uint                `json:"clusterGroupId" yaml:"clusterGroupId"`
+	Name           string              `json:"name" yaml:"name"`
+	Consistent     bool                `json:"consistent" yaml:"consistent"`
+	GeneratedAt    time.Time           `json:"generatedAt" yaml:"generatedAt"`
+	Members        []MemberDriftReport `json:"members" yaml:"members"`
+}
+
+// MemberDriftReport lists the differences of a member cluster from the desired state and from the other members
+type MemberDriftReport struct {
+	ClusterID         uint    `json:"clusterId" yaml:"clusterId"`
+	ClusterName       string  `json:"clusterName" yaml:"clusterName"`
+	KubernetesVersion string  `json:"kubernetesVersion,omitempty" yaml:"kubernetesVersion,omitempty"`
+	Drifted           bool    `json:"drifted" yaml:"drifted"`
+	Drifts            []Drift `json:"drifts,omitempty" yaml:"drifts,omitempty"`
+	Error             string  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Drift describes a single difference of a member cluster
+type Drift struct {
+	Kind     string `json:"kind" yaml:"kind" example:"deployment"`
+	Name     string `json:"name,omitempty" yaml:"name,omitempty"`
+	Expected string `json:"expected,omitempty" yaml:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty" yaml:"actual,omitempty"`
+	Message  string `json:"message" yaml:"message"`
+}
+
+// DriftReporter is implemented by feature handlers able to compare the member clusters against the desired state of the feature
+type DriftReporter interface {
+	GetMembersDrift(featureState Feature) (map[uint][]Drift, error)
+}
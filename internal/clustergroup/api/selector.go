@@ -0,0 +1,81 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/cluster"
+	"github.com/banzaicloud/pipeline/pkg/kubernetes"
+)
+
+// ClusterSelector describes which clusters of an organization belong to a cluster group.
+// Every non-empty criteria must match for a cluster to be selected.
+type ClusterSelector struct {
+	MatchLabels  map[string]string `json:"matchLabels,omitempty" yaml:"matchLabels,omitempty"`
+	Cloud        string            `json:"cloud,omitempty" yaml:"cloud,omitempty" example:"amazon"`
+	Distribution string            `json:"distribution,omitempty" yaml:"distribution,omitempty" example:"eks"`
+	Location     string            `json:"location,omitempty" yaml:"location,omitempty" example:"eu-west-1"`
+}
+
+// Validate validates ClusterSelector
+func (s *ClusterSelector) Validate() error {
+	if len(s.MatchLabels) == 0 && s.Cloud == "" && s.Distribution == "" && s.Location == "" {
+		return errors.New("cluster selector should contain at least one criteria")
+	}
+
+	err := kubernetes.LabelValidator{}.ValidateLabels(s.MatchLabels)
+	if err != nil {
+		var verr interface {
+			Violations() []string
+		}
+		if errors.As(err, &verr) {
+			return errors.Errorf("invalid cluster selector: %s", strings.Join(verr.Violations(), ", "))
+		}
+
+		return errors.WithMessage(err, "invalid cluster selector")
+	}
+
+	return nil
+}
+
+// Matches returns true if the cluster described by the status satisfies every criteria of the selector.
+func (s *ClusterSelector) Matches(status *cluster.GetClusterStatusResponse) bool {
+	if s == nil || status == nil {
+		return false
+	}
+
+	if s.Cloud != "" && s.Cloud != status.Cloud {
+		return false
+	}
+
+	if s.Distribution != "" && s.Distribution != status.Distribution {
+		return false
+	}
+
+	if s.Location != "" && s.Location != status.Location && s.Location != status.Region {
+		return false
+	}
+
+	for key, value := range s.MatchLabels {
+		if v, ok := status.Labels[key]; !ok || v != value {
+			return false
+		}
+	}
+
+	return true
+}
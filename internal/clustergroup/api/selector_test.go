@@ -0,0 +1,89 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+func TestClusterSelector_Matches(t *testing.T) {
+	status := &cluster.GetClusterStatusResponse{
+		Cloud:        "amazon",
+		Distribution: "eks",
+		Location:     "eu-west-1",
+		Labels: map[string]string{
+			"env":  "prod",
+			"team": "backend",
+		},
+	}
+
+	tests := map[string]struct {
+		selector *ClusterSelector
+		matches  bool
+	}{
+		"labels": {
+			selector: &ClusterSelector{MatchLabels: map[string]string{"env": "prod"}},
+			matches:  true,
+		},
+		"labelValueMismatch": {
+			selector: &ClusterSelector{MatchLabels: map[string]string{"env": "dev"}},
+			matches:  false,
+		},
+		"missingLabel": {
+			selector: &ClusterSelector{MatchLabels: map[string]string{"tier": "gold"}},
+			matches:  false,
+		},
+		"allCriteria": {
+			selector: &ClusterSelector{
+				MatchLabels:  map[string]string{"env": "prod", "team": "backend"},
+				Cloud:        "amazon",
+				Distribution: "eks",
+				Location:     "eu-west-1",
+			},
+			matches: true,
+		},
+		"cloudMismatch": {
+			selector: &ClusterSelector{MatchLabels: map[string]string{"env": "prod"}, Cloud: "google"},
+			matches:  false,
+		},
+		"locationMismatch": {
+			selector: &ClusterSelector{Location: "us-east-1"},
+			matches:  false,
+		},
+		"nilSelector": {
+			selector: nil,
+			matches:  false,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.matches, test.selector.Matches(status))
+		})
+	}
+}
+
+func TestClusterSelector_Validate(t *testing.T) {
+	assert.Error(t, (&ClusterSelector{}).Validate())
+	assert.Error(t, (&ClusterSelector{MatchLabels: map[string]string{"env": "not valid!"}}).Validate())
+	assert.NoError(t, (&ClusterSelector{MatchLabels: map[string]string{"env": "prod"}}).Validate())
+	assert.NoError(t, (&ClusterSelector{Distribution: "pke"}).Validate())
+}
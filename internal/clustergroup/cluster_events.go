@@ -0,0 +1,101 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustergroup
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
+// ClusterCreatedEventHandler re-evaluates the dynamic cluster group memberships of created clusters.
+type ClusterCreatedEventHandler struct {
+	manager *Manager
+}
+
+// NewClusterCreatedEventHandler returns a new ClusterCreatedEventHandler.
+func NewClusterCreatedEventHandler(manager *Manager) ClusterCreatedEventHandler {
+	return ClusterCreatedEventHandler{
+		manager: manager,
+	}
+}
+
+// HandlerName implements the cqrs.EventHandler interface.
+func (ClusterCreatedEventHandler) HandlerName() string {
+	return "clustergroup_cluster_created"
+}
+
+// NewEvent implements the cqrs.EventHandler interface.
+func (ClusterCreatedEventHandler) NewEvent() interface{} {
+	return &cluster.ClusterCreated{}
+}
+
+// Handle implements the cqrs.EventHandler interface.
+func (h ClusterCreatedEventHandler) Handle(ctx context.Context, event interface{}) error {
+	e, ok := event.(*cluster.ClusterCreated)
+	if !ok {
+		return errors.NewWithDetails("unexpected event type", "type", fmt.Sprintf("%T", event))
+	}
+
+	h.manager.reconcileClusterMembership(ctx, e.ClusterID)
+
+	return nil
+}
+
+// ClusterUpdatedEventHandler re-evaluates the dynamic cluster group memberships of updated clusters.
+type ClusterUpdatedEventHandler struct {
+	manager *Manager
+}
+
+// NewClusterUpdatedEventHandler returns a new ClusterUpdatedEventHandler.
+func NewClusterUpdatedEventHandler(manager *Manager) ClusterUpdatedEventHandler {
+	return ClusterUpdatedEventHandler{
+		manager: manager,
+	}
+}
+
+// HandlerName implements the cqrs.EventHandler interface.
+func (ClusterUpdatedEventHandler) HandlerName() string {
+	return "clustergroup_cluster_updated"
+}
+
+// NewEvent implements the cqrs.EventHandler interface.
+func (ClusterUpdatedEventHandler) NewEvent() interface{} {
+	return &cluster.ClusterUpdated{}
+}
+
+// Handle implements the cqrs.EventHandler interface.
+func (h ClusterUpdatedEventHandler) Handle(ctx context.Context, event interface{}) error {
+	e, ok := event.(*cluster.ClusterUpdated)
+	if !ok {
+		return errors.NewWithDetails("unexpected event type", "type", fmt.Sprintf("%T", event))
+	}
+
+	h.manager.reconcileClusterMembership(ctx, e.ClusterID)
+
+	return nil
+}
+
+// reconcileClusterMembership reports reconciliation errors instead of returning them:
+// redelivering the event would not help, and reconciliation is idempotent anyway.
+func (g *Manager) reconcileClusterMembership(ctx context.Context, clusterID uint) {
+	err := g.ReconcileClusterMembership(ctx, clusterID)
+	if err != nil {
+		g.errorHandler.Handle(errors.WrapIfWithDetails(err, "failed to reconcile cluster group membership", "clusterID", clusterID))
+	}
+}
@@ -37,6 +37,7 @@ type ClusterGroupDeployment struct {
 	ValueOverrides map[string]map[string]interface{} `json:"valueOverrides,omitempty" yaml:"valueOverrides,omitempty"`
 	RollingMode    bool                              `json:"rollingMode,omitempty" yaml:"rollingMode,omitempty"`
 	Atomic         bool                              `json:"atomic,omitempty" yaml:"atomic,omitempty"`
+	Rollout        *RolloutStrategy                  `json:"rollout,omitempty" yaml:"rollout,omitempty"`
 }
 
 // DeploymentInfo describes the details of a helm deployment
@@ -54,6 +55,7 @@ type DeploymentInfo struct {
 	ValueOverrides       map[string]map[string]interface{} `json:"valueOverrides,omitempty" yaml:"valueOverrides,omitempty"`
 	TargetClusters       map[uint]bool                     `json:"-" yaml:"-"`
 	TargetClustersStatus []TargetClusterStatus             `json:"targetClusters"`
+	Rollout              *RolloutStatus                    `json:"rollout,omitempty"`
 }
 
 func (c *DeploymentInfo) GetValuesForCluster(clusterName string) ([]byte, error) {
@@ -0,0 +1,77 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"fmt"
+
+	hapi_release5 "k8s.io/helm/pkg/proto/hapi/release"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/api"
+)
+
+// GetMembersDrift compares the releases installed on the member clusters against the cluster group deployments
+func (m *CGDeploymentManager) GetMembersDrift(featureState api.Feature) (map[uint][]api.Drift, error) {
+	drifts := make(map[uint][]api.Drift, 0)
+
+	deploymentModels, err := m.repository.FindAll(featureState.ClusterGroup.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, deploymentModel := range deploymentModels {
+		depInfo, err := m.getDeploymentFromModel(deploymentModel)
+		if err != nil {
+			return nil, err
+		}
+
+		expected := fmt.Sprintf("%s-%s", depInfo.ChartName, depInfo.ChartVersion)
+
+		for clusterID, apiCluster := range featureState.ClusterGroup.Clusters {
+			// only clusters the deployment is targeted to are expected to have the release
+			if _, ok := depInfo.TargetClusters[clusterID]; !ok {
+				continue
+			}
+
+			status, err := m.getClusterDeploymentStatus(apiCluster, depInfo.ReleaseName, depInfo)
+
+			drift := api.Drift{
+				Kind:     api.DeploymentDrift,
+				Name:     depInfo.ReleaseName,
+				Expected: expected,
+			}
+			if status.Version != "" {
+				drift.Actual = fmt.Sprintf("%s-%s", depInfo.ChartName, status.Version)
+			}
+
+			switch {
+			case err != nil:
+				drift.Message = fmt.Sprintf("failed to get release: %s", err.Error())
+			case status.Status == NotInstalledStatus:
+				drift.Message = "release is not installed"
+			case status.Stale:
+				drift.Message = "chart version or values differ from the cluster group deployment"
+			case status.Status != hapi_release5.Status_DEPLOYED.String():
+				drift.Message = fmt.Sprintf("release status is %s", status.Status)
+			default:
+				continue
+			}
+
+			drifts[clusterID] = append(drifts[clusterID], drift)
+		}
+	}
+
+	return drifts, nil
+}
@@ -90,3 +90,46 @@ func IsDeploymentAlreadyExistsError(err error) bool {
 
 	return ok
 }
+
+type invalidRolloutStrategyError struct {
+	message string
+}
+
+func (e *invalidRolloutStrategyError) Error() string {
+	return "invalid rollout strategy: " + e.message
+}
+
+// IsInvalidRolloutStrategyError returns true if the passed in error designates an invalid rollout strategy error
+func IsInvalidRolloutStrategyError(err error) bool {
+	_, ok := errors.Cause(err).(*invalidRolloutStrategyError)
+
+	return ok
+}
+
+type rolloutStateError struct {
+	releaseName string
+	status      string
+	operation   string
+}
+
+func (e *rolloutStateError) Error() string {
+	if e.status == "" {
+		return fmt.Sprintf("cannot %s rollout: deployment has no progressive rollout", e.operation)
+	}
+
+	return fmt.Sprintf("cannot %s rollout in %s state", e.operation, e.status)
+}
+
+func (e *rolloutStateError) Context() []interface{} {
+	return []interface{}{
+		"releaseName", e.releaseName,
+		"rolloutStatus", e.status,
+	}
+}
+
+// IsRolloutStateError returns true if the passed in error designates an operation not allowed in the current rollout state
+func IsRolloutStateError(err error) bool {
+	_, ok := errors.Cause(err).(*rolloutStateError)
+
+	return ok
+}
@@ -0,0 +1,82 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+)
+
+// DeploymentFinished event is emitted when a cluster group deployment finished on its target clusters.
+type DeploymentFinished struct {
+	OrganizationID uint
+	ClusterGroupID uint
+	ReleaseName    string
+
+	// Status is OperationSucceededStatus or OperationFailedStatus for direct deployments
+	// and RolloutSucceededStatus or RolloutAbortedStatus for progressive rollouts.
+	Status  string
+	Message string
+
+	// Targets contains the result of a direct deployment on each target cluster.
+	Targets []TargetClusterStatus
+}
+
+// DeploymentEventDispatcher dispatches cluster group deployment events.
+type DeploymentEventDispatcher interface {
+	// DeploymentFinished dispatches a DeploymentFinished event.
+	DeploymentFinished(ctx context.Context, event DeploymentFinished) error
+}
+
+type nopDeploymentEventDispatcher struct{}
+
+// NewNopDeploymentEventDispatcher returns a DeploymentEventDispatcher that discards every event.
+func NewNopDeploymentEventDispatcher() DeploymentEventDispatcher {
+	return nopDeploymentEventDispatcher{}
+}
+
+func (nopDeploymentEventDispatcher) DeploymentFinished(_ context.Context, _ DeploymentFinished) error {
+	return nil
+}
+
+// deploymentFinished dispatches a DeploymentFinished event for a direct deployment.
+func (m CGDeploymentManager) deploymentFinished(organizationID uint, clusterGroupID uint, releaseName string, targets []TargetClusterStatus) {
+	status := OperationSucceededStatus
+	for _, target := range targets {
+		if target.Status == OperationFailedStatus {
+			status = OperationFailedStatus
+			break
+		}
+	}
+
+	m.dispatchDeploymentFinished(context.Background(), DeploymentFinished{
+		OrganizationID: organizationID,
+		ClusterGroupID: clusterGroupID,
+		ReleaseName:    releaseName,
+		Status:         status,
+		Targets:        targets,
+	})
+}
+
+func (m CGDeploymentManager) dispatchDeploymentFinished(ctx context.Context, event DeploymentFinished) {
+	if err := m.events.DeploymentFinished(ctx, event); err != nil {
+		m.errorHandler.Handle(errors.WithDetails(
+			err,
+			"clusterGroupId", event.ClusterGroupID,
+			"releaseName", event.ReleaseName,
+		))
+	}
+}
@@ -19,12 +19,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"emperror.dev/emperror"
 	"emperror.dev/errors"
+	"github.com/gofrs/uuid"
 	"github.com/jinzhu/gorm"
 	"github.com/sirupsen/logrus"
 	"github.com/technosophos/moniker"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/.gen/go/shared"
+	"go.uber.org/cadence/client"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sHelm "k8s.io/helm/pkg/helm"
 	helm_env "k8s.io/helm/pkg/helm/environment"
 	"k8s.io/helm/pkg/proto/hapi/chart"
@@ -32,15 +39,19 @@ import (
 
 	"github.com/banzaicloud/pipeline/internal/clustergroup/api"
 	pkgHelm "github.com/banzaicloud/pipeline/pkg/helm"
+	"github.com/banzaicloud/pipeline/pkg/k8sclient"
+	"github.com/banzaicloud/pipeline/pkg/k8sutil"
 	"github.com/banzaicloud/pipeline/src/helm"
 )
 
 // CGDeploymentManager
 type CGDeploymentManager struct {
-	clusterGetter api.ClusterGetter
-	repository    *CGDeploymentRepository
-	logger        logrus.FieldLogger
-	errorHandler  emperror.Handler
+	clusterGetter  api.ClusterGetter
+	repository     *CGDeploymentRepository
+	workflowClient client.Client
+	events         DeploymentEventDispatcher
+	logger         logrus.FieldLogger
+	errorHandler   emperror.Handler
 }
 
 const OperationSucceededStatus = "SUCCEEDED"
@@ -58,6 +69,8 @@ const FeatureName = "deployment"
 func NewCGDeploymentManager(
 	db *gorm.DB,
 	clusterGetter api.ClusterGetter,
+	workflowClient client.Client,
+	events DeploymentEventDispatcher,
 	logger logrus.FieldLogger,
 	errorHandler emperror.Handler,
 ) *CGDeploymentManager {
@@ -66,9 +79,11 @@ func NewCGDeploymentManager(
 			db:     db,
 			logger: logger,
 		},
-		clusterGetter: clusterGetter,
-		logger:        logger,
-		errorHandler:  errorHandler,
+		clusterGetter:  clusterGetter,
+		workflowClient: workflowClient,
+		events:         events,
+		logger:         logger,
+		errorHandler:   errorHandler,
 	}
 }
 
@@ -293,6 +308,7 @@ func (m CGDeploymentManager) createDeploymentModel(clusterGroup *api.ClusterGrou
 		Namespace:             cgDeployment.Namespace,
 		OrganizationName:      orgName,
 	}
+	setRolloutStrategy(deploymentModel, cgDeployment.Rollout)
 	if cgDeployment.Values == nil {
 		cgDeployment.Values = make(map[string]interface{})
 	}
@@ -324,6 +340,9 @@ func (m CGDeploymentManager) updateDeploymentModel(clusterGroup *api.ClusterGrou
 	deploymentModel.DeploymentVersion = cgDeployment.Version
 	deploymentModel.Description = requestedChart.Metadata.Description
 	deploymentModel.ChartName = requestedChart.Metadata.Name
+	if cgDeployment.Rollout != nil {
+		setRolloutStrategy(deploymentModel, cgDeployment.Rollout)
+	}
 
 	// ReUseValues = true - merge current values with request values
 	// ReUseValues = true - override current values with request values
@@ -401,6 +420,13 @@ func (m CGDeploymentManager) getDeploymentFromModel(deploymentModel *ClusterGrou
 	if deploymentModel.UpdatedAt != nil {
 		deployment.UpdatedAt = *deploymentModel.UpdatedAt
 	}
+	if deploymentModel.RolloutStrategy != "" {
+		deployment.Rollout = &RolloutStatus{
+			Strategy: getRolloutStrategy(deploymentModel),
+			Status:   deploymentModel.RolloutStatus,
+			Message:  deploymentModel.RolloutMessage,
+		}
+	}
 	values := make(map[string]interface{})
 	err := json.Unmarshal(deploymentModel.Values, &values)
 	if err != nil {
@@ -562,6 +588,13 @@ func (m CGDeploymentManager) DeleteDeployment(clusterGroup *api.ClusterGroup, re
 		return nil, err
 	}
 
+	if isRolloutInProgress(deploymentModel) {
+		err := m.workflowClient.TerminateWorkflow(context.Background(), deploymentModel.RolloutWorkflowID, "", "deployment deleted", nil)
+		if _, ok := err.(*shared.EntityNotExistsError); err != nil && !ok {
+			return nil, errors.WrapIfWithDetails(err, "failed to terminate rollout workflow", "workflowID", deploymentModel.RolloutWorkflowID)
+		}
+	}
+
 	targetClustersStatus, err := m.deleteDeploymentFromTargetClusters(clusterGroup, releaseName, deploymentModel, true, forceDelete)
 	if err != nil {
 		return nil, err
@@ -577,6 +610,14 @@ func (m CGDeploymentManager) SyncDeployment(clusterGroup *api.ClusterGroup, orgN
 		return nil, err
 	}
 
+	if isRolloutInProgress(deploymentModel) {
+		return nil, errors.WithStack(&rolloutStateError{
+			releaseName: deploymentModel.DeploymentReleaseName,
+			status:      deploymentModel.RolloutStatus,
+			operation:   "start",
+		})
+	}
+
 	depInfo, err := m.getDeploymentFromModel(deploymentModel)
 	if err != nil {
 		return nil, err
@@ -590,7 +631,10 @@ func (m CGDeploymentManager) SyncDeployment(clusterGroup *api.ClusterGroup, orgN
 	if err != nil {
 		return nil, fmt.Errorf("error loading chart: %v", err)
 	}
-	targetClustersStatus := m.upgradeOrInstallDeploymentToTargetClusters(clusterGroup, orgName, env, depInfo, requestedChart, false)
+	targetClustersStatus, err := m.rolloutDeployment(clusterGroup, orgName, env, deploymentModel, depInfo, requestedChart, false)
+	if err != nil {
+		return nil, err
+	}
 	response = append(response, targetClustersStatus...)
 
 	targetClustersStatus, err = m.deleteDeploymentFromTargetClusters(clusterGroup, releaseName, deploymentModel, false, false)
@@ -701,6 +745,10 @@ func (m CGDeploymentManager) CreateDeployment(clusterGroup *api.ClusterGroup, or
 		return nil, errors.Errorf("release name is mandatory")
 	}
 
+	if err := cgDeployment.Rollout.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	deploymentModel, err := m.repository.FindByName(clusterGroup.Id, cgDeployment.ReleaseName)
 	if err != nil && !IsDeploymentNotFoundError(err) {
 		return nil, err
@@ -744,13 +792,16 @@ func (m CGDeploymentManager) CreateDeployment(clusterGroup *api.ClusterGroup, or
 		return nil, err
 	}
 
-	targetClusterStatus := m.upgradeOrInstallDeploymentToTargetClusters(clusterGroup, orgName, env, depInfo, requestedChart, cgDeployment.DryRun)
-	return targetClusterStatus, nil
+	return m.rolloutDeployment(clusterGroup, orgName, env, deploymentModel, depInfo, requestedChart, cgDeployment.DryRun)
 }
 
 // UpdateDeployment upgrades deployment using provided values or using already provided values if ReUseValues = true.
 // The deployment is installed on a member cluster in case it's was not installed previously.
 func (m CGDeploymentManager) UpdateDeployment(clusterGroup *api.ClusterGroup, orgName string, cgDeployment *ClusterGroupDeployment) ([]TargetClusterStatus, error) {
+	if err := cgDeployment.Rollout.Validate(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	env := helm.GenerateHelmRepoEnv(orgName)
 	requestedChart, err := helm.GetRequestedChart(cgDeployment.ReleaseName, cgDeployment.Name, cgDeployment.Version, cgDeployment.Package, env)
 	if err != nil {
@@ -772,6 +823,14 @@ func (m CGDeploymentManager) UpdateDeployment(clusterGroup *api.ClusterGroup, or
 		return nil, err
 	}
 
+	if isRolloutInProgress(deploymentModel) {
+		return nil, errors.WithStack(&rolloutStateError{
+			releaseName: deploymentModel.DeploymentReleaseName,
+			status:      deploymentModel.RolloutStatus,
+			operation:   "start",
+		})
+	}
+
 	// if reUseValues = false update values / valueOverrides from request
 	err = m.updateDeploymentModel(clusterGroup, deploymentModel, cgDeployment, requestedChart)
 	if err != nil {
@@ -789,8 +848,7 @@ func (m CGDeploymentManager) UpdateDeployment(clusterGroup *api.ClusterGroup, or
 		return nil, err
 	}
 
-	targetClusterStatus := m.upgradeOrInstallDeploymentToTargetClusters(clusterGroup, orgName, env, depInfo, requestedChart, cgDeployment.DryRun)
-	return targetClusterStatus, nil
+	return m.rolloutDeployment(clusterGroup, orgName, env, deploymentModel, depInfo, requestedChart, cgDeployment.DryRun)
 }
 
 func (m *CGDeploymentManager) IsReleaseNameAvailable(clusterGroup *api.ClusterGroup, releaseName string) bool {
@@ -820,3 +878,219 @@ func (m *CGDeploymentManager) IsReleaseNameAvailable(clusterGroup *api.ClusterGr
 	}
 	return releaseNameAvailable
 }
+
+// rolloutDeployment deploys to the target clusters according to the rollout strategy of the deployment.
+// Progressive rollouts are started as a workflow, in which case the target clusters are reported as pending.
+func (m CGDeploymentManager) rolloutDeployment(clusterGroup *api.ClusterGroup, orgName string, env helm_env.EnvSettings, deploymentModel *ClusterGroupDeploymentModel, depInfo *DeploymentInfo, requestedChart *chart.Chart, dryRun bool) ([]TargetClusterStatus, error) {
+	strategy := getRolloutStrategy(deploymentModel)
+	if dryRun || !strategy.IsProgressive() {
+		targetClusterStatus := m.upgradeOrInstallDeploymentToTargetClusters(clusterGroup, orgName, env, depInfo, requestedChart, dryRun)
+		if !dryRun {
+			m.deploymentFinished(clusterGroup.OrganizationID, clusterGroup.Id, deploymentModel.DeploymentReleaseName, targetClusterStatus)
+		}
+
+		return targetClusterStatus, nil
+	}
+
+	targetClusterStatus := make([]TargetClusterStatus, 0)
+	clusterIDs := make([]uint, 0)
+	for _, apiCluster := range clusterGroup.Clusters {
+		if _, ok := depInfo.TargetClusters[apiCluster.GetID()]; ok {
+			clusterIDs = append(clusterIDs, apiCluster.GetID())
+			targetClusterStatus = append(targetClusterStatus, TargetClusterStatus{
+				ClusterId:    apiCluster.GetID(),
+				ClusterName:  apiCluster.GetName(),
+				Cloud:        apiCluster.GetCloud(),
+				Distribution: apiCluster.GetDistribution(),
+				Status:       PendingStatus,
+			})
+		}
+	}
+
+	input := RolloutDeploymentWorkflowInput{
+		ClusterGroupID:     clusterGroup.Id,
+		OrganizationID:     clusterGroup.OrganizationID,
+		OrganizationName:   orgName,
+		ReleaseName:        deploymentModel.DeploymentReleaseName,
+		Batches:            strategy.Batches(clusterIDs),
+		HealthCheckTimeout: strategy.GetHealthCheckTimeout(),
+	}
+
+	workflowOptions := client.StartWorkflowOptions{
+		ID: fmt.Sprintf(
+			"%s-%d-%s-%s",
+			RolloutDeploymentWorkflowName,
+			clusterGroup.Id,
+			deploymentModel.DeploymentReleaseName,
+			uuid.Must(uuid.NewV4()).String(),
+		),
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 30 * 24 * time.Hour,
+	}
+
+	// The rollout state is persisted before the workflow starts: once started, the workflow owns it
+	err := m.repository.StartRollout(clusterGroup.Id, deploymentModel.DeploymentReleaseName, workflowOptions.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = m.workflowClient.StartWorkflow(context.Background(), workflowOptions, RolloutDeploymentWorkflowName, input)
+	if err != nil {
+		err = errors.WrapIfWithDetails(err, "failed to start workflow", "workflow", RolloutDeploymentWorkflowName)
+		_ = m.repository.UpdateRolloutStatus(clusterGroup.Id, deploymentModel.DeploymentReleaseName, RolloutAbortedStatus, err.Error())
+		return nil, err
+	}
+
+	return targetClusterStatus, nil
+}
+
+// ResumeRollout resumes a paused progressive rollout of a cluster group deployment
+func (m CGDeploymentManager) ResumeRollout(clusterGroup *api.ClusterGroup, releaseName string) error {
+	return m.signalRollout(clusterGroup, releaseName, RolloutResumeSignalName, RolloutPausedStatus)
+}
+
+// AbortRollout aborts a running or paused progressive rollout of a cluster group deployment.
+// Target clusters already deployed to are left as they are.
+func (m CGDeploymentManager) AbortRollout(clusterGroup *api.ClusterGroup, releaseName string) error {
+	return m.signalRollout(clusterGroup, releaseName, RolloutAbortSignalName, RolloutRunningStatus, RolloutPausedStatus)
+}
+
+func (m CGDeploymentManager) signalRollout(clusterGroup *api.ClusterGroup, releaseName string, signalName string, allowedStatuses ...string) error {
+	deploymentModel, err := m.repository.FindByName(clusterGroup.Id, releaseName)
+	if err != nil {
+		return err
+	}
+
+	allowed := false
+	for _, status := range allowedStatuses {
+		if deploymentModel.RolloutWorkflowID != "" && deploymentModel.RolloutStatus == status {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return errors.WithStack(&rolloutStateError{
+			releaseName: releaseName,
+			status:      deploymentModel.RolloutStatus,
+			operation:   signalName,
+		})
+	}
+
+	err = m.workflowClient.SignalWorkflow(context.Background(), deploymentModel.RolloutWorkflowID, "", signalName, nil)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to signal rollout workflow",
+			"workflowID", deploymentModel.RolloutWorkflowID,
+			"signal", signalName,
+		)
+	}
+
+	return nil
+}
+
+// deployToCluster installs or upgrades a cluster group deployment on a single target cluster
+func (m CGDeploymentManager) deployToCluster(ctx context.Context, clusterGroupID uint, orgName string, releaseName string, clusterID uint) error {
+	deploymentModel, err := m.repository.FindByName(clusterGroupID, releaseName)
+	if err != nil {
+		return err
+	}
+
+	depInfo, err := m.getDeploymentFromModel(deploymentModel)
+	if err != nil {
+		return err
+	}
+
+	apiCluster, err := m.clusterGetter.GetClusterByIDOnly(ctx, clusterID)
+	if err != nil {
+		return errors.WithStack(&memberClusterNotFoundError{
+			clusterID: clusterID,
+		})
+	}
+
+	env := helm.GenerateHelmRepoEnv(orgName)
+	requestedChart, err := helm.GetRequestedChart(depInfo.ReleaseName, depInfo.Chart, depInfo.ChartVersion, deploymentModel.DeploymentPackage, env)
+	if err != nil {
+		return fmt.Errorf("error loading chart: %v", err)
+	}
+
+	return m.upgradeOrInstallDeploymentOnCluster(apiCluster, orgName, env, depInfo, requestedChart, false)
+}
+
+// checkDeploymentHealth returns an error unless the release is deployed and every pod of the release is ready on the target cluster
+func (m CGDeploymentManager) checkDeploymentHealth(ctx context.Context, releaseName string, clusterID uint) error {
+	apiCluster, err := m.clusterGetter.GetClusterByIDOnly(ctx, clusterID)
+	if err != nil {
+		return errors.WithStack(&memberClusterNotFoundError{
+			clusterID: clusterID,
+		})
+	}
+
+	release, err := m.findRelease(apiCluster, releaseName)
+	if err != nil {
+		return err
+	}
+	if release == nil {
+		return errors.Errorf("release %s not found", releaseName)
+	}
+
+	switch status := release.Info.Status.Code; status {
+	case hapi_release5.Status_DEPLOYED:
+	case hapi_release5.Status_FAILED:
+		return cadence.NewCustomError(ErrReasonReleaseFailed, fmt.Sprintf("release %s failed: %s", releaseName, release.Info.Description))
+	default:
+		return errors.Errorf("release %s is in %s state", releaseName, status.String())
+	}
+
+	k8sConfig, err := apiCluster.GetK8sConfig()
+	if err != nil {
+		return err
+	}
+
+	k8sClient, err := k8sclient.NewClientFromKubeConfig(k8sConfig)
+	if err != nil {
+		return err
+	}
+
+	// charts label their pods with either the legacy or the recommended release label
+	pods := make(map[string]corev1.Pod)
+	for _, selector := range []string{"release=" + releaseName, "app.kubernetes.io/instance=" + releaseName} {
+		podList, err := k8sClient.CoreV1().Pods(release.Namespace).List(metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to list pods", "namespace", release.Namespace, "selector", selector)
+		}
+		for _, pod := range podList.Items {
+			pods[pod.Name] = pod
+		}
+	}
+
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		if !k8sutil.IsPodReady(&pod) {
+			return errors.Errorf("pod %s/%s is not ready", pod.Namespace, pod.Name)
+		}
+	}
+
+	return nil
+}
+
+func isRolloutInProgress(deploymentModel *ClusterGroupDeploymentModel) bool {
+	return deploymentModel.RolloutStatus == RolloutRunningStatus || deploymentModel.RolloutStatus == RolloutPausedStatus
+}
+
+func setRolloutStrategy(deploymentModel *ClusterGroupDeploymentModel, strategy *RolloutStrategy) {
+	if strategy == nil {
+		strategy = &RolloutStrategy{}
+	}
+
+	deploymentModel.RolloutStrategy = strategy.Type
+	deploymentModel.RolloutBatchSize = strategy.BatchSize
+	deploymentModel.RolloutHealthCheckTimeout = strategy.HealthCheckTimeout
+}
+
+func getRolloutStrategy(deploymentModel *ClusterGroupDeploymentModel) RolloutStrategy {
+	return RolloutStrategy{
+		Type:               deploymentModel.RolloutStrategy,
+		BatchSize:          deploymentModel.RolloutBatchSize,
+		HealthCheckTimeout: deploymentModel.RolloutHealthCheckTimeout,
+	}
+}
@@ -52,6 +52,13 @@ type ClusterGroupDeploymentModel struct {
 	OrganizationName      string
 	Values                []byte           `sql:"type:text;"`
 	TargetClusters        []*TargetCluster `gorm:"foreignkey:ClusterGroupDeploymentID"`
+
+	RolloutStrategy           string
+	RolloutBatchSize          int
+	RolloutHealthCheckTimeout int
+	RolloutStatus             string
+	RolloutMessage            string `sql:"type:text;"`
+	RolloutWorkflowID         string
 }
 
 // TargetCluster describes cluster specific values for a cluster group deployment
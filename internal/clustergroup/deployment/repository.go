@@ -70,8 +70,19 @@ func (g *CGDeploymentRepository) FindAll(clusterGroupID uint) ([]*ClusterGroupDe
 	return deployments, nil
 }
 
+// rolloutStateColumns are owned by the rollout workflow once a deployment exists
+// nolint: gochecknoglobals
+var rolloutStateColumns = []string{"rollout_status", "rollout_message", "rollout_workflow_id"}
+
+// Save persists a cluster group deployment.
+// The rollout state of an existing deployment is never overwritten: it is changed by StartRollout and UpdateRolloutStatus only.
 func (g *CGDeploymentRepository) Save(model *ClusterGroupDeploymentModel) error {
-	return g.db.Save(model).Error
+	db := g.db
+	if model.ID != 0 {
+		db = db.Omit(rolloutStateColumns...)
+	}
+
+	return db.Save(model).Error
 }
 
 // Delete deletes a target cluster from deployment
@@ -110,3 +121,42 @@ func (g *CGDeploymentRepository) Delete(model *ClusterGroupDeploymentModel, targ
 
 	return nil
 }
+
+// UpdateRolloutStatus updates the progressive rollout status of a cluster group deployment
+func (g *CGDeploymentRepository) UpdateRolloutStatus(clusterGroupID uint, releaseName string, status string, message string) error {
+	err := g.db.Model(&ClusterGroupDeploymentModel{}).Where(ClusterGroupDeploymentModel{
+		ClusterGroupID:        clusterGroupID,
+		DeploymentReleaseName: releaseName,
+	}).Updates(map[string]interface{}{
+		"rollout_status":  status,
+		"rollout_message": message,
+	}).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to update rollout status",
+			"clusterGroupID", clusterGroupID,
+			"releaseName", releaseName,
+		)
+	}
+
+	return nil
+}
+
+// StartRollout marks a progressive rollout of a cluster group deployment running with the given workflow
+func (g *CGDeploymentRepository) StartRollout(clusterGroupID uint, releaseName string, workflowID string) error {
+	err := g.db.Model(&ClusterGroupDeploymentModel{}).Where(ClusterGroupDeploymentModel{
+		ClusterGroupID:        clusterGroupID,
+		DeploymentReleaseName: releaseName,
+	}).Updates(map[string]interface{}{
+		"rollout_status":      RolloutRunningStatus,
+		"rollout_message":     "",
+		"rollout_workflow_id": workflowID,
+	}).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to start rollout",
+			"clusterGroupID", clusterGroupID,
+			"releaseName", releaseName,
+		)
+	}
+
+	return nil
+}
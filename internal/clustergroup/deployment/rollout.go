@@ -0,0 +1,140 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// RolloutStrategyParallel deploys to every target cluster at once (default)
+	RolloutStrategyParallel = "parallel"
+	// RolloutStrategySequential deploys to one target cluster at a time
+	RolloutStrategySequential = "sequential"
+	// RolloutStrategyBatch deploys to batchSize target clusters at a time
+	RolloutStrategyBatch = "batch"
+	// RolloutStrategyCanary deploys to the first target cluster, then to the rest of them
+	RolloutStrategyCanary = "canary"
+)
+
+const (
+	RolloutRunningStatus   = "RUNNING"
+	RolloutPausedStatus    = "PAUSED"
+	RolloutSucceededStatus = "SUCCEEDED"
+	RolloutAbortedStatus   = "ABORTED"
+)
+
+// PendingStatus is the status of target clusters waiting for a progressive rollout to reach them
+const PendingStatus = "PENDING"
+
+const defaultHealthCheckTimeout = 5 * time.Minute
+
+// RolloutStrategy describes how a cluster group deployment is rolled out to the target clusters
+type RolloutStrategy struct {
+	Type string `json:"type" yaml:"type"`
+	// BatchSize is the number of clusters deployed to at the same time by the batch strategy
+	// and by the canary strategy after the canary cluster became healthy
+	BatchSize int `json:"batchSize,omitempty" yaml:"batchSize,omitempty"`
+	// HealthCheckTimeout is the number of seconds a batch has to become healthy before the rollout is paused
+	HealthCheckTimeout int `json:"healthCheckTimeout,omitempty" yaml:"healthCheckTimeout,omitempty"`
+}
+
+// RolloutStatus describes the state of a progressive rollout
+type RolloutStatus struct {
+	Strategy RolloutStrategy `json:"strategy"`
+	Status   string          `json:"status"`
+	Message  string          `json:"message,omitempty"`
+}
+
+// IsProgressive returns true if the strategy deploys to the target clusters step by step
+func (s *RolloutStrategy) IsProgressive() bool {
+	return s != nil && s.Type != "" && s.Type != RolloutStrategyParallel
+}
+
+// Validate validates the rollout strategy
+func (s *RolloutStrategy) Validate() error {
+	if s == nil {
+		return nil
+	}
+
+	switch s.Type {
+	case "", RolloutStrategyParallel, RolloutStrategySequential, RolloutStrategyCanary:
+	case RolloutStrategyBatch:
+		if s.BatchSize < 1 {
+			return &invalidRolloutStrategyError{message: "batch size must be at least 1"}
+		}
+	default:
+		return &invalidRolloutStrategyError{message: fmt.Sprintf("unsupported rollout strategy type: %s", s.Type)}
+	}
+
+	if s.BatchSize < 0 {
+		return &invalidRolloutStrategyError{message: "batch size must not be negative"}
+	}
+
+	if s.HealthCheckTimeout < 0 {
+		return &invalidRolloutStrategyError{message: "health check timeout must not be negative"}
+	}
+
+	return nil
+}
+
+// GetHealthCheckTimeout returns the health check timeout of a batch
+func (s RolloutStrategy) GetHealthCheckTimeout() time.Duration {
+	if s.HealthCheckTimeout == 0 {
+		return defaultHealthCheckTimeout
+	}
+
+	return time.Duration(s.HealthCheckTimeout) * time.Second
+}
+
+// Batches splits the target clusters into the ordered batches they are deployed to
+func (s RolloutStrategy) Batches(clusterIDs []uint) [][]uint {
+	ids := make([]uint, len(clusterIDs))
+	copy(ids, clusterIDs)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	switch s.Type {
+	case RolloutStrategySequential:
+		return chunkClusterIDs(ids, 1)
+	case RolloutStrategyBatch:
+		return chunkClusterIDs(ids, s.BatchSize)
+	case RolloutStrategyCanary:
+		batchSize := s.BatchSize
+		if batchSize == 0 {
+			batchSize = len(ids)
+		}
+		return append([][]uint{ids[:1]}, chunkClusterIDs(ids[1:], batchSize)...)
+	default:
+		return [][]uint{ids}
+	}
+}
+
+func chunkClusterIDs(ids []uint, size int) [][]uint {
+	batches := make([][]uint, 0, (len(ids)+size-1)/size)
+	for size < len(ids) {
+		ids, batches = ids[size:], append(batches, ids[:size])
+	}
+	if len(ids) > 0 {
+		batches = append(batches, ids)
+	}
+
+	return batches
+}
@@ -0,0 +1,111 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+)
+
+const DeployToClusterActivityName = "clustergroup-deploy-to-cluster"
+
+// DeployToClusterActivity installs or upgrades a cluster group deployment on a single target cluster
+type DeployToClusterActivity struct {
+	manager *CGDeploymentManager
+}
+
+func NewDeployToClusterActivity(manager *CGDeploymentManager) DeployToClusterActivity {
+	return DeployToClusterActivity{
+		manager: manager,
+	}
+}
+
+type DeployToClusterActivityInput struct {
+	ClusterGroupID   uint
+	OrganizationName string
+	ReleaseName      string
+	ClusterID        uint
+}
+
+func (a DeployToClusterActivity) Execute(ctx context.Context, input DeployToClusterActivityInput) error {
+	return a.manager.deployToCluster(ctx, input.ClusterGroupID, input.OrganizationName, input.ReleaseName, input.ClusterID)
+}
+
+const CheckDeploymentHealthActivityName = "clustergroup-check-deployment-health"
+
+// CheckDeploymentHealthActivity checks whether the release is deployed and its pods are ready on a target cluster
+type CheckDeploymentHealthActivity struct {
+	manager *CGDeploymentManager
+}
+
+func NewCheckDeploymentHealthActivity(manager *CGDeploymentManager) CheckDeploymentHealthActivity {
+	return CheckDeploymentHealthActivity{
+		manager: manager,
+	}
+}
+
+type CheckDeploymentHealthActivityInput struct {
+	ReleaseName string
+	ClusterID   uint
+}
+
+func (a CheckDeploymentHealthActivity) Execute(ctx context.Context, input CheckDeploymentHealthActivityInput) error {
+	return a.manager.checkDeploymentHealth(ctx, input.ReleaseName, input.ClusterID)
+}
+
+const SetRolloutStatusActivityName = "clustergroup-set-rollout-status"
+
+// SetRolloutStatusActivity persists the status of a progressive rollout
+type SetRolloutStatusActivity struct {
+	manager *CGDeploymentManager
+}
+
+func NewSetRolloutStatusActivity(manager *CGDeploymentManager) SetRolloutStatusActivity {
+	return SetRolloutStatusActivity{
+		manager: manager,
+	}
+}
+
+type SetRolloutStatusActivityInput struct {
+	ClusterGroupID uint
+	OrganizationID uint
+	ReleaseName    string
+	Status         string
+	Message        string
+}
+
+func (a SetRolloutStatusActivity) Execute(ctx context.Context, input SetRolloutStatusActivityInput) error {
+	if input.OrganizationID == 0 {
+		return errors.New("organization ID is required")
+	}
+
+	err := a.manager.repository.UpdateRolloutStatus(input.ClusterGroupID, input.ReleaseName, input.Status, input.Message)
+	if err != nil {
+		return err
+	}
+
+	if input.Status == RolloutSucceededStatus || input.Status == RolloutAbortedStatus {
+		a.manager.dispatchDeploymentFinished(ctx, DeploymentFinished{
+			OrganizationID: input.OrganizationID,
+			ClusterGroupID: input.ClusterGroupID,
+			ReleaseName:    input.ReleaseName,
+			Status:         input.Status,
+			Message:        input.Message,
+		})
+	}
+
+	return nil
+}
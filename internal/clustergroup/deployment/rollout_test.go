@@ -0,0 +1,86 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolloutStrategy_Batches(t *testing.T) {
+	clusterIDs := []uint{5, 3, 1, 4, 2}
+
+	tests := map[string]struct {
+		strategy RolloutStrategy
+		expected [][]uint
+	}{
+		"parallel": {
+			strategy: RolloutStrategy{Type: RolloutStrategyParallel},
+			expected: [][]uint{{1, 2, 3, 4, 5}},
+		},
+		"sequential": {
+			strategy: RolloutStrategy{Type: RolloutStrategySequential},
+			expected: [][]uint{{1}, {2}, {3}, {4}, {5}},
+		},
+		"batch": {
+			strategy: RolloutStrategy{Type: RolloutStrategyBatch, BatchSize: 2},
+			expected: [][]uint{{1, 2}, {3, 4}, {5}},
+		},
+		"canary": {
+			strategy: RolloutStrategy{Type: RolloutStrategyCanary},
+			expected: [][]uint{{1}, {2, 3, 4, 5}},
+		},
+		"canary with batches": {
+			strategy: RolloutStrategy{Type: RolloutStrategyCanary, BatchSize: 3},
+			expected: [][]uint{{1}, {2, 3, 4}, {5}},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.strategy.Batches(clusterIDs))
+		})
+	}
+}
+
+func TestRolloutStrategy_Validate(t *testing.T) {
+	tests := map[string]struct {
+		strategy *RolloutStrategy
+		valid    bool
+	}{
+		"nil":           {strategy: nil, valid: true},
+		"sequential":    {strategy: &RolloutStrategy{Type: RolloutStrategySequential}, valid: true},
+		"batch":         {strategy: &RolloutStrategy{Type: RolloutStrategyBatch, BatchSize: 2}, valid: true},
+		"batch no size": {strategy: &RolloutStrategy{Type: RolloutStrategyBatch}, valid: false},
+		"unknown":       {strategy: &RolloutStrategy{Type: "bluegreen"}, valid: false},
+		"bad timeout":   {strategy: &RolloutStrategy{Type: RolloutStrategyCanary, HealthCheckTimeout: -1}, valid: false},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			err := test.strategy.Validate()
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, IsInvalidRolloutStrategyError(err))
+			}
+		})
+	}
+}
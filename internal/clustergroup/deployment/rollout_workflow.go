@@ -0,0 +1,197 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"fmt"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
+)
+
+// RolloutDeploymentWorkflowName is the name the RolloutDeploymentWorkflow is registered under
+const RolloutDeploymentWorkflowName = "clustergroup-rollout-deployment"
+
+const (
+	// RolloutResumeSignalName is the name of the signal which resumes a paused rollout
+	RolloutResumeSignalName = "resume"
+	// RolloutAbortSignalName is the name of the signal which aborts a running or paused rollout
+	RolloutAbortSignalName = "abort"
+)
+
+const healthCheckInterval = 15 * time.Second
+
+// ErrReasonReleaseFailed is the reason of the error returned when the release is in a final failed state
+const ErrReasonReleaseFailed = "RELEASE_FAILED"
+
+// RolloutDeploymentWorkflowInput defines the inputs of the RolloutDeploymentWorkflow
+type RolloutDeploymentWorkflowInput struct {
+	ClusterGroupID     uint
+	OrganizationID     uint
+	OrganizationName   string
+	ReleaseName        string
+	Batches            [][]uint
+	HealthCheckTimeout time.Duration
+}
+
+// RolloutDeploymentWorkflow deploys a cluster group deployment to the target clusters batch by batch.
+// A batch is deployed only after every cluster of the previous batch became healthy. The rollout is paused
+// when a batch fails, and continues with the failed batch on resume.
+func RolloutDeploymentWorkflow(ctx workflow.Context, input RolloutDeploymentWorkflowInput) error {
+	if input.OrganizationID == 0 {
+		return errors.New("organization ID is required")
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    20 * time.Minute,
+		WaitForCancellation:    true,
+	})
+
+	logger := workflow.GetLogger(ctx).Sugar().With(
+		"clusterGroupID", input.ClusterGroupID,
+		"releaseName", input.ReleaseName,
+	)
+
+	resumeChannel := workflow.GetSignalChannel(ctx, RolloutResumeSignalName)
+	abortChannel := workflow.GetSignalChannel(ctx, RolloutAbortSignalName)
+
+	for i := 0; i < len(input.Batches); {
+		if abortChannel.ReceiveAsync(nil) {
+			return setRolloutStatus(ctx, input, RolloutAbortedStatus, fmt.Sprintf("rollout aborted before batch %d", i+1))
+		}
+
+		err := rolloutBatch(ctx, input, input.Batches[i])
+		if err == nil {
+			i++
+			continue
+		}
+
+		logger.Warnw("rollout paused", "batch", i+1, "error", err.Error())
+
+		message := fmt.Sprintf("batch %d of %d failed: %s", i+1, len(input.Batches), err.Error())
+		if err := setRolloutStatus(ctx, input, RolloutPausedStatus, message); err != nil {
+			return err
+		}
+
+		aborted := false
+		selector := workflow.NewSelector(ctx)
+		selector.AddReceive(resumeChannel, func(c workflow.Channel, more bool) {
+			c.Receive(ctx, nil)
+		})
+		selector.AddReceive(abortChannel, func(c workflow.Channel, more bool) {
+			c.Receive(ctx, nil)
+			aborted = true
+		})
+		selector.Select(ctx)
+
+		if aborted {
+			return setRolloutStatus(ctx, input, RolloutAbortedStatus, message)
+		}
+
+		logger.Infow("rollout resumed", "batch", i+1)
+
+		if err := setRolloutStatus(ctx, input, RolloutRunningStatus, ""); err != nil {
+			return err
+		}
+	}
+
+	return setRolloutStatus(ctx, input, RolloutSucceededStatus, "")
+}
+
+// rolloutBatch deploys to every cluster of a batch at once, then waits for all of them to become healthy
+func rolloutBatch(ctx workflow.Context, input RolloutDeploymentWorkflowInput, clusterIDs []uint) error {
+	deployFutures := make([]workflow.Future, 0, len(clusterIDs))
+	for _, clusterID := range clusterIDs {
+		activityInput := DeployToClusterActivityInput{
+			ClusterGroupID:   input.ClusterGroupID,
+			OrganizationName: input.OrganizationName,
+			ReleaseName:      input.ReleaseName,
+			ClusterID:        clusterID,
+		}
+		deployFutures = append(deployFutures, workflow.ExecuteActivity(ctx, DeployToClusterActivityName, activityInput))
+	}
+
+	var errs []error
+	for i, future := range deployFutures {
+		if err := future.Get(ctx, nil); err != nil {
+			errs = append(errs, errors.WrapIff(unwrapActivityError(err), "cluster %d", clusterIDs[i]))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Combine(errs...)
+	}
+
+	healthCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    2 * time.Minute,
+		WaitForCancellation:    true,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          healthCheckInterval,
+			BackoffCoefficient:       1,
+			ExpirationInterval:       input.HealthCheckTimeout,
+			NonRetriableErrorReasons: []string{ErrReasonReleaseFailed},
+		},
+	})
+
+	healthFutures := make([]workflow.Future, 0, len(clusterIDs))
+	for _, clusterID := range clusterIDs {
+		activityInput := CheckDeploymentHealthActivityInput{
+			ReleaseName: input.ReleaseName,
+			ClusterID:   clusterID,
+		}
+		healthFutures = append(healthFutures, workflow.ExecuteActivity(healthCtx, CheckDeploymentHealthActivityName, activityInput))
+	}
+
+	for i, future := range healthFutures {
+		if err := future.Get(ctx, nil); err != nil {
+			errs = append(errs, errors.WrapIff(unwrapActivityError(err), "cluster %d is not healthy", clusterIDs[i]))
+		}
+	}
+
+	return errors.Combine(errs...)
+}
+
+func unwrapActivityError(err error) error {
+	var customErr *cadence.CustomError
+	if errors.As(err, &customErr) && customErr.HasDetails() {
+		var details string
+		if customErr.Details(&details) == nil && details != "" {
+			return errors.New(details)
+		}
+	}
+
+	return err
+}
+
+func setRolloutStatus(ctx workflow.Context, input RolloutDeploymentWorkflowInput, status string, message string) error {
+	activityInput := SetRolloutStatusActivityInput{
+		ClusterGroupID: input.ClusterGroupID,
+		OrganizationID: input.OrganizationID,
+		ReleaseName:    input.ReleaseName,
+		Status:         status,
+		Message:        message,
+	}
+
+	err := workflow.ExecuteActivity(ctx, SetRolloutStatusActivityName, activityInput).Get(ctx, nil)
+	if err != nil {
+		workflow.GetLogger(ctx).Error("failed to set rollout status", zap.String("status", status), zap.Error(err))
+	}
+
+	return err
+}
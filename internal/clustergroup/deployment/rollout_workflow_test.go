@@ -0,0 +1,150 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+)
+
+func testDeployToClusterActivityExecute(ctx context.Context, input DeployToClusterActivityInput) error {
+	return nil
+}
+
+func testCheckDeploymentHealthActivityExecute(ctx context.Context, input CheckDeploymentHealthActivityInput) error {
+	return nil
+}
+
+func testSetRolloutStatusActivityExecute(ctx context.Context, input SetRolloutStatusActivityInput) error {
+	return nil
+}
+
+func init() {
+	workflow.RegisterWithOptions(RolloutDeploymentWorkflow, workflow.RegisterOptions{Name: RolloutDeploymentWorkflowName})
+	activity.RegisterWithOptions(testDeployToClusterActivityExecute, activity.RegisterOptions{Name: DeployToClusterActivityName})
+	activity.RegisterWithOptions(testCheckDeploymentHealthActivityExecute, activity.RegisterOptions{Name: CheckDeploymentHealthActivityName})
+	activity.RegisterWithOptions(testSetRolloutStatusActivityExecute, activity.RegisterOptions{Name: SetRolloutStatusActivityName})
+}
+
+type RolloutWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestRolloutWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(RolloutWorkflowTestSuite))
+}
+
+func (s *RolloutWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+}
+
+func (s *RolloutWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+func (s *RolloutWorkflowTestSuite) rolloutInput() RolloutDeploymentWorkflowInput {
+	return RolloutDeploymentWorkflowInput{
+		ClusterGroupID:     1,
+		OrganizationID:     2,
+		OrganizationName:   "example-organization",
+		ReleaseName:        "example-release",
+		Batches:            [][]uint{{1}, {2, 3}},
+		HealthCheckTimeout: time.Minute,
+	}
+}
+
+func (s *RolloutWorkflowTestSuite) statusInput(status string, message string) SetRolloutStatusActivityInput {
+	return SetRolloutStatusActivityInput{
+		ClusterGroupID: 1,
+		OrganizationID: 2,
+		ReleaseName:    "example-release",
+		Status:         status,
+		Message:        message,
+	}
+}
+
+func (s *RolloutWorkflowTestSuite) Test_Success() {
+	s.env.OnActivity(DeployToClusterActivityName, mock.Anything, mock.Anything).Return(nil).Times(3)
+	s.env.OnActivity(CheckDeploymentHealthActivityName, mock.Anything, mock.Anything).Return(nil).Times(3)
+	s.env.OnActivity(SetRolloutStatusActivityName, mock.Anything, s.statusInput(RolloutSucceededStatus, "")).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(RolloutDeploymentWorkflowName, s.rolloutInput())
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
+
+func (s *RolloutWorkflowTestSuite) Test_MissingOrganizationID() {
+	input := s.rolloutInput()
+	input.OrganizationID = 0
+
+	s.env.ExecuteWorkflow(RolloutDeploymentWorkflowName, input)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.Error(s.env.GetWorkflowError())
+}
+
+func (s *RolloutWorkflowTestSuite) Test_PauseAndResume() {
+	canary := DeployToClusterActivityInput{ClusterGroupID: 1, OrganizationName: "example-organization", ReleaseName: "example-release", ClusterID: 1}
+
+	s.env.OnActivity(DeployToClusterActivityName, mock.Anything, canary).Return(errors.New("chart error")).Once()
+	s.env.OnActivity(DeployToClusterActivityName, mock.Anything, mock.Anything).Return(nil).Times(3)
+	s.env.OnActivity(CheckDeploymentHealthActivityName, mock.Anything, mock.Anything).Return(nil).Times(3)
+	s.env.OnActivity(SetRolloutStatusActivityName, mock.Anything, mock.MatchedBy(func(input SetRolloutStatusActivityInput) bool {
+		return input.Status == RolloutPausedStatus
+	})).Return(nil).Once()
+	s.env.OnActivity(SetRolloutStatusActivityName, mock.Anything, s.statusInput(RolloutRunningStatus, "")).Return(nil).Once()
+	s.env.OnActivity(SetRolloutStatusActivityName, mock.Anything, s.statusInput(RolloutSucceededStatus, "")).Return(nil).Once()
+
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(RolloutResumeSignalName, nil)
+	}, time.Hour)
+
+	s.env.ExecuteWorkflow(RolloutDeploymentWorkflowName, s.rolloutInput())
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
+
+func (s *RolloutWorkflowTestSuite) Test_PauseAndAbort() {
+	s.env.OnActivity(DeployToClusterActivityName, mock.Anything, mock.Anything).Return(nil).Once()
+	s.env.OnActivity(CheckDeploymentHealthActivityName, mock.Anything, mock.Anything).Return(errors.New("pod not ready"))
+	s.env.OnActivity(SetRolloutStatusActivityName, mock.Anything, mock.MatchedBy(func(input SetRolloutStatusActivityInput) bool {
+		return input.Status == RolloutPausedStatus
+	})).Return(nil).Once()
+	s.env.OnActivity(SetRolloutStatusActivityName, mock.Anything, mock.MatchedBy(func(input SetRolloutStatusActivityInput) bool {
+		return input.Status == RolloutAbortedStatus
+	})).Return(nil).Once()
+
+	s.env.RegisterDelayedCallback(func() {
+		s.env.SignalWorkflow(RolloutAbortSignalName, nil)
+	}, time.Hour)
+
+	s.env.ExecuteWorkflow(RolloutDeploymentWorkflowName, s.rolloutInput())
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
@@ -0,0 +1,294 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustergroup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/api"
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+// IntegratedServiceRepository returns the integrated services of a cluster.
+type IntegratedServiceRepository interface {
+	GetIntegratedServices(ctx context.Context, clusterID uint) ([]integratedservices.IntegratedService, error)
+}
+
+// DriftDetector compares the member clusters of a cluster group against the desired state of the group and against each other.
+type DriftDetector struct {
+	manager            *Manager
+	integratedServices IntegratedServiceRepository
+	logger             logrus.FieldLogger
+}
+
+// NewDriftDetector returns a new DriftDetector instance.
+func NewDriftDetector(manager *Manager, integratedServices IntegratedServiceRepository, logger logrus.FieldLogger) *DriftDetector {
+	return &DriftDetector{
+		manager:            manager,
+		integratedServices: integratedServices,
+		logger:             logger,
+	}
+}
+
+type integratedServiceState struct {
+	status string
+	spec   string
+}
+
+// GetDriftReport generates a drift report for a cluster group.
+// The group is consistent if no drift is found and every member could be inspected.
+func (d *DriftDetector) GetDriftReport(ctx context.Context, clusterGroupID uint, orgID uint) (*api.DriftReport, error) {
+	clusterGroup, err := d.manager.GetClusterGroupByID(ctx, clusterGroupID, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	log := d.logger.WithFields(logrus.Fields{"clusterGroupId": clusterGroup.Id, "clusterGroupName": clusterGroup.Name})
+
+	members := make(map[uint]*api.MemberDriftReport, len(clusterGroup.Members))
+	versions := make(map[uint]string)
+	services := make(map[uint]map[string]integratedServiceState)
+
+	for _, member := range clusterGroup.Members {
+		memberReport := &api.MemberDriftReport{
+			ClusterID:   member.ID,
+			ClusterName: member.Name,
+		}
+		members[member.ID] = memberReport
+
+		cluster, ok := clusterGroup.Clusters[member.ID]
+		if !ok {
+			memberReport.Error = member.Status
+			continue
+		}
+
+		clusterStatus, err := cluster.GetStatus()
+		if err != nil {
+			log.WithField("clusterId", member.ID).Warn(errors.WithMessage(err, "failed to get cluster status"))
+			memberReport.Error = err.Error()
+		} else if clusterStatus.Version != "" {
+			memberReport.KubernetesVersion = clusterStatus.Version
+			versions[member.ID] = clusterStatus.Version
+		}
+
+		clusterServices, err := d.integratedServices.GetIntegratedServices(ctx, member.ID)
+		if err != nil {
+			log.WithField("clusterId", member.ID).Warn(errors.WithMessage(err, "failed to get integrated services"))
+			if memberReport.Error == "" {
+				memberReport.Error = err.Error()
+			}
+			continue
+		}
+
+		services[member.ID], err = getIntegratedServiceStates(clusterServices)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to process integrated services", "clusterId", member.ID)
+		}
+	}
+
+	addDrifts(members, compareKubernetesVersions(versions))
+	addDrifts(members, compareIntegratedServices(services))
+
+	features, err := d.manager.GetEnabledFeatures(*clusterGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, feature := range features {
+		handler, err := d.manager.GetFeatureHandler(name)
+		if err != nil {
+			log.WithField("feature", name).Warn(err.Error())
+			continue
+		}
+
+		reporter, ok := handler.(api.DriftReporter)
+		if !ok {
+			continue
+		}
+
+		drifts, err := reporter.GetMembersDrift(feature)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to get members drift", "feature", name)
+		}
+		addDrifts(members, drifts)
+	}
+
+	report := &api.DriftReport{
+		ClusterGroupID: clusterGroup.Id,
+		Name:           clusterGroup.Name,
+		Consistent:     true,
+		GeneratedAt:    time.Now().UTC(),
+		Members:        make([]api.MemberDriftReport, 0, len(members)),
+	}
+
+	for _, member := range members {
+		sort.Slice(member.Drifts, func(i, j int) bool {
+			if member.Drifts[i].Kind != member.Drifts[j].Kind {
+				return member.Drifts[i].Kind < member.Drifts[j].Kind
+			}
+			return member.Drifts[i].Name < member.Drifts[j].Name
+		})
+		member.Drifted = len(member.Drifts) > 0
+		if member.Drifted || member.Error != "" {
+			report.Consistent = false
+		}
+		report.Members = append(report.Members, *member)
+	}
+
+	sort.Slice(report.Members, func(i, j int) bool {
+		return report.Members[i].ClusterID < report.Members[j].ClusterID
+	})
+
+	return report, nil
+}
+
+func addDrifts(members map[uint]*api.MemberDriftReport, drifts map[uint][]api.Drift) {
+	for clusterID, clusterDrifts := range drifts {
+		if member, ok := members[clusterID]; ok {
+			member.Drifts = append(member.Drifts, clusterDrifts...)
+		}
+	}
+}
+
+// getIntegratedServiceStates returns the status and the canonical spec of the integrated services not inactive on a cluster
+func getIntegratedServiceStates(services []integratedservices.IntegratedService) (map[string]integratedServiceState, error) {
+	states := make(map[string]integratedServiceState, len(services))
+
+	for _, service := range services {
+		if service.Status == integratedservices.IntegratedServiceStatusInactive {
+			continue
+		}
+
+		// maps are marshalled with sorted keys, so equal specs are encoded identically
+		spec, err := json.Marshal(service.Spec)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to marshal integrated service spec", "integratedService", service.Name)
+		}
+
+		states[service.Name] = integratedServiceState{
+			status: service.Status,
+			spec:   string(spec),
+		}
+	}
+
+	return states, nil
+}
+
+// mostCommon returns the most frequent value, ties are broken alphabetically
+func mostCommon(values map[uint]string) string {
+	counts := make(map[string]int)
+	for _, value := range values {
+		counts[value]++
+	}
+
+	var result string
+	var max int
+	for value, count := range counts {
+		if count > max || (count == max && value < result) {
+			result = value
+			max = count
+		}
+	}
+
+	return result
+}
+
+// compareKubernetesVersions reports members running a different Kubernetes version than most of the members
+func compareKubernetesVersions(versions map[uint]string) map[uint][]api.Drift {
+	drifts := make(map[uint][]api.Drift)
+
+	expected := mostCommon(versions)
+	for clusterID, version := range versions {
+		if version != expected {
+			drifts[clusterID] = append(drifts[clusterID], api.Drift{
+				Kind:     api.KubernetesVersionDrift,
+				Expected: expected,
+				Actual:   version,
+				Message:  "Kubernetes version differs from the version running on most of the members",
+			})
+		}
+	}
+
+	return drifts
+}
+
+// compareIntegratedServices reports members where the set of enabled integrated services,
+// their specs or their status differ from the rest of the group
+func compareIntegratedServices(services map[uint]map[string]integratedServiceState) map[uint][]api.Drift {
+	drifts := make(map[uint][]api.Drift)
+
+	names := make(map[string]bool)
+	for _, states := range services {
+		for name := range states {
+			names[name] = true
+		}
+	}
+
+	for name := range names {
+		specs := make(map[uint]string)
+		for clusterID, states := range services {
+			if state, ok := states[name]; ok {
+				specs[clusterID] = state.spec
+			}
+		}
+
+		expectedEnabled := len(specs)*2 >= len(services)
+		expectedSpec := mostCommon(specs)
+
+		for clusterID, states := range services {
+			state, enabled := states[name]
+
+			switch {
+			case enabled != expectedEnabled:
+				drift := api.Drift{
+					Kind:     api.IntegratedServiceDrift,
+					Name:     name,
+					Expected: fmt.Sprintf("enabled=%t", expectedEnabled),
+					Actual:   fmt.Sprintf("enabled=%t", enabled),
+					Message:  "integrated service is enabled only on a minority of the members",
+				}
+				if expectedEnabled {
+					drift.Message = "integrated service is not enabled, although it is enabled on most of the members"
+				}
+				drifts[clusterID] = append(drifts[clusterID], drift)
+
+			case enabled && state.spec != expectedSpec:
+				drifts[clusterID] = append(drifts[clusterID], api.Drift{
+					Kind:    api.IntegratedServiceDrift,
+					Name:    name,
+					Message: "integrated service spec differs from the spec used on most of the members",
+				})
+
+			case enabled && state.status == integratedservices.IntegratedServiceStatusError:
+				drifts[clusterID] = append(drifts[clusterID], api.Drift{
+					Kind:     api.IntegratedServiceDrift,
+					Name:     name,
+					Expected: integratedservices.IntegratedServiceStatusActive,
+					Actual:   state.status,
+					Message:  "integrated service is in error state",
+				})
+			}
+		}
+	}
+
+	return drifts
+}
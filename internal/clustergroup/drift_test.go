@@ -0,0 +1,143 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustergroup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/api"
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+func TestCompareKubernetesVersions(t *testing.T) {
+	drifts := compareKubernetesVersions(map[uint]string{
+		1: "1.15.3",
+		2: "1.15.3",
+		3: "1.14.7",
+	})
+
+	assert.Equal(t, map[uint][]api.Drift{
+		3: {
+			{
+				Kind:     api.KubernetesVersionDrift,
+				Expected: "1.15.3",
+				Actual:   "1.14.7",
+				Message:  "Kubernetes version differs from the version running on most of the members",
+			},
+		},
+	}, drifts)
+}
+
+func TestCompareKubernetesVersions_Tie(t *testing.T) {
+	drifts := compareKubernetesVersions(map[uint]string{
+		1: "1.15.3",
+		2: "1.14.7",
+	})
+
+	require.Len(t, drifts, 1)
+	assert.Equal(t, "1.14.7", drifts[1][0].Expected)
+}
+
+func TestGetIntegratedServiceStates(t *testing.T) {
+	states, err := getIntegratedServiceStates([]integratedservices.IntegratedService{
+		{
+			Name:   "dns",
+			Spec:   integratedservices.IntegratedServiceSpec{"b": 1, "a": "x"},
+			Status: integratedservices.IntegratedServiceStatusActive,
+		},
+		{
+			Name:   "logging",
+			Status: integratedservices.IntegratedServiceStatusInactive,
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]integratedServiceState{
+		"dns": {
+			status: integratedservices.IntegratedServiceStatusActive,
+			spec:   `{"a":"x","b":1}`,
+		},
+	}, states)
+}
+
+func TestCompareIntegratedServices(t *testing.T) {
+	active := integratedservices.IntegratedServiceStatusActive
+
+	drifts := compareIntegratedServices(map[uint]map[string]integratedServiceState{
+		1: {
+			"dns":     {status: active, spec: `{"provider":"route53"}`},
+			"logging": {status: active, spec: `{}`},
+		},
+		2: {
+			"dns": {status: active, spec: `{"provider":"route53"}`},
+		},
+		3: {
+			"dns": {status: active, spec: `{"provider":"google"}`},
+		},
+		4: {},
+	})
+
+	assert.Equal(t, map[uint][]api.Drift{
+		1: {
+			{
+				Kind:     api.IntegratedServiceDrift,
+				Name:     "logging",
+				Expected: "enabled=false",
+				Actual:   "enabled=true",
+				Message:  "integrated service is enabled only on a minority of the members",
+			},
+		},
+		3: {
+			{
+				Kind:    api.IntegratedServiceDrift,
+				Name:    "dns",
+				Message: "integrated service spec differs from the spec used on most of the members",
+			},
+		},
+		4: {
+			{
+				Kind:     api.IntegratedServiceDrift,
+				Name:     "dns",
+				Expected: "enabled=true",
+				Actual:   "enabled=false",
+				Message:  "integrated service is not enabled, although it is enabled on most of the members",
+			},
+		},
+	}, drifts)
+}
+
+func TestCompareIntegratedServices_Error(t *testing.T) {
+	spec := `{"provider":"route53"}`
+
+	drifts := compareIntegratedServices(map[uint]map[string]integratedServiceState{
+		1: {"dns": {status: integratedservices.IntegratedServiceStatusActive, spec: spec}},
+		2: {"dns": {status: integratedservices.IntegratedServiceStatusError, spec: spec}},
+	})
+
+	assert.Equal(t, map[uint][]api.Drift{
+		2: {
+			{
+				Kind:     api.IntegratedServiceDrift,
+				Name:     "dns",
+				Expected: integratedservices.IntegratedServiceStatusActive,
+				Actual:   integratedservices.IntegratedServiceStatusError,
+				Message:  "integrated service is in error state",
+			},
+		},
+	}, drifts)
+}
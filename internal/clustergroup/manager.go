@@ -16,6 +16,7 @@ package clustergroup
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	"emperror.dev/emperror"
@@ -63,7 +64,7 @@ func isValidClusterStatus(clusterStatus *cluster.GetClusterStatusResponse) bool
 }
 
 // CreateClusterGroup creates a cluster group
-func (g *Manager) CreateClusterGroup(ctx context.Context, name string, orgID uint, members []uint) (*uint, error) {
+func (g *Manager) CreateClusterGroup(ctx context.Context, name string, orgID uint, members []uint, selector *api.ClusterSelector) (*uint, error) {
 	cgModel, err := g.cgRepo.FindOne(ClusterGroupModel{
 		OrganizationID: orgID,
 		Name:           name,
@@ -119,7 +120,36 @@ func (g *Manager) CreateClusterGroup(ctx context.Context, name string, orgID uin
 		}
 	}
 
-	cgId, err := g.cgRepo.Create(name, orgID, memberClusterModels)
+	var rawSelector string
+	if selector != nil {
+		staticMembers := make(map[uint]api.Cluster, len(memberClusterModels))
+		for _, m := range memberClusterModels {
+			staticMembers[m.ClusterID] = nil
+		}
+
+		selectedClusters, err := g.selectClusters(ctx, orgID, 0, selector, staticMembers)
+		if err != nil {
+			return nil, err
+		}
+		for clusterID, cluster := range selectedClusters {
+			memberClusterModels = append(memberClusterModels, MemberClusterModel{
+				ClusterID: clusterID,
+				Dynamic:   true,
+			})
+			g.logger.WithFields(logrus.Fields{
+				"clusterName":      cluster.GetName(),
+				"clusterGroupName": name,
+			}).Info("Join cluster to group by selector")
+		}
+
+		selectorJSON, err := json.Marshal(selector)
+		if err != nil {
+			return nil, errors.WrapIf(err, "could not marshal cluster selector")
+		}
+		rawSelector = string(selectorJSON)
+	}
+
+	cgId, err := g.cgRepo.Create(name, orgID, rawSelector, memberClusterModels)
 	if err != nil {
 		return nil, err
 	}
@@ -138,7 +168,7 @@ func (g *Manager) CreateClusterGroup(ctx context.Context, name string, orgID uin
 }
 
 // UpdateClusterGroup updates a cluster group
-func (g *Manager) UpdateClusterGroup(ctx context.Context, clusterGroupID uint, orgID uint, name string, members []uint) error {
+func (g *Manager) UpdateClusterGroup(ctx context.Context, clusterGroupID uint, orgID uint, name string, members []uint, selector *api.ClusterSelector) error {
 	cgModel, err := g.cgRepo.FindOne(ClusterGroupModel{
 		ID:             clusterGroupID,
 		OrganizationID: orgID,
@@ -188,12 +218,36 @@ func (g *Manager) UpdateClusterGroup(ctx context.Context, clusterGroupID uint, o
 		}
 	}
 
+	dynamicMembers := make(map[uint]bool, 0)
+	var rawSelector string
+	if selector != nil {
+		selectedClusters, err := g.selectClusters(ctx, orgID, existingClusterGroup.Id, selector, newMembers)
+		if err != nil {
+			return err
+		}
+		for clusterID, cluster := range selectedClusters {
+			newMembers[clusterID] = cluster
+			dynamicMembers[clusterID] = true
+		}
+
+		selectorJSON, err := json.Marshal(selector)
+		if err != nil {
+			return errors.WrapIf(err, "could not marshal cluster selector")
+		}
+		rawSelector = string(selectorJSON)
+	}
+
 	err = g.validateBeforeClusterGroupUpdate(*existingClusterGroup, newMembers)
 	if err != nil {
 		return errors.WrapIf(err, "updating cluster group is not allowed")
 	}
 
-	err = g.cgRepo.UpdateMembers(existingClusterGroup, newMembers)
+	err = g.cgRepo.UpdateMembers(existingClusterGroup, newMembers, dynamicMembers)
+	if err != nil {
+		return err
+	}
+
+	err = g.cgRepo.UpdateSelector(existingClusterGroup.Id, rawSelector)
 	if err != nil {
 		return err
 	}
@@ -290,7 +344,8 @@ func (g *Manager) RemoveClusterFromGroup(ctx context.Context, clusterID uint) er
 		}
 	}
 
-	if len(newMembers) == 0 {
+	// cluster groups with a selector are kept even without members, as clusters may join them later
+	if len(newMembers) == 0 && existingClusterGroup.Selector == nil {
 		g.logger.Debug("delete cluster group before deleting it's last member")
 		err := g.DeleteClusterGroupByID(ctx, existingClusterGroup.OrganizationID, existingClusterGroup.Id)
 		if err != nil {
@@ -304,7 +359,7 @@ func (g *Manager) RemoveClusterFromGroup(ctx context.Context, clusterID uint) er
 		return errors.WrapIf(err, "removing cluster from group is not allowed")
 	}
 
-	err = g.cgRepo.UpdateMembers(existingClusterGroup, newMembers)
+	err = g.cgRepo.UpdateMembers(existingClusterGroup, newMembers, getDynamicMembers(*existingClusterGroup))
 	if err != nil {
 		return err
 	}
@@ -333,6 +388,15 @@ func (g *Manager) GetClusterGroupFromModel(ctx context.Context, cg *ClusterGroup
 	clusterGroup.Members = make([]api.Member, 0)
 	clusterGroup.Clusters = make(map[uint]api.Cluster, 0)
 
+	if len(cg.Selector) > 0 {
+		var selector api.ClusterSelector
+		if err := json.Unmarshal([]byte(cg.Selector), &selector); err != nil {
+			g.errorHandler.Handle(errors.WrapIfWithDetails(err, "could not unmarshal cluster selector", "clusterGroupID", cg.ID))
+		} else {
+			clusterGroup.Selector = &selector
+		}
+	}
+
 	enabledFeatures := make([]string, 0)
 	clusterGroup.EnabledFeatures = enabledFeatures
 	for _, feature := range cg.FeatureParams {
@@ -347,8 +411,9 @@ func (g *Manager) GetClusterGroupFromModel(ctx context.Context, cg *ClusterGroup
 		cluster, err := g.clusterGetter.GetClusterByIDOnly(ctx, m.ClusterID)
 		if err != nil {
 			clusterGroup.Members = append(clusterGroup.Members, api.Member{
-				ID:     m.ClusterID,
-				Status: "cluster not found",
+				ID:      m.ClusterID,
+				Status:  "cluster not found",
+				Dynamic: m.Dynamic,
 			})
 			continue
 		}
@@ -357,6 +422,7 @@ func (g *Manager) GetClusterGroupFromModel(ctx context.Context, cg *ClusterGroup
 			Cloud:        cluster.GetCloud(),
 			Distribution: cluster.GetDistribution(),
 			Name:         cluster.GetName(),
+			Dynamic:      m.Dynamic,
 		}
 		if withStatus {
 			clusterStatus, err := cluster.GetStatus()
@@ -548,3 +614,171 @@ func (g *Manager) reconcileFeature(clusterGroup api.ClusterGroup, featureModel C
 	}
 	return nil
 }
+
+// ReconcileClusterMembership re-evaluates cluster selectors for a cluster:
+// a dynamic member no longer matching the selector of its cluster group leaves the group,
+// a cluster not being member of any cluster group joins the first cluster group with a matching selector.
+func (g *Manager) ReconcileClusterMembership(ctx context.Context, clusterID uint) error {
+	cluster, err := g.clusterGetter.GetClusterByIDOnly(ctx, clusterID)
+	if err != nil {
+		return err
+	}
+
+	clusterStatus, err := cluster.GetStatus()
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not check cluster state", "clusterID", clusterID)
+	}
+
+	member, err := g.cgRepo.FindMemberClusterByID(clusterID)
+	if err != nil && !IsRecordNotFoundError(err) {
+		return err
+	}
+
+	if err == nil {
+		// static members are managed explicitly
+		if !member.Dynamic {
+			return nil
+		}
+
+		cgModel, err := g.cgRepo.FindOne(ClusterGroupModel{
+			ID: member.ClusterGroupID,
+		})
+		if err != nil {
+			return err
+		}
+
+		clusterGroup := g.GetClusterGroupFromModel(ctx, cgModel, false)
+		if clusterGroup.Selector.Matches(clusterStatus) {
+			return nil
+		}
+
+		newMembers := make(map[uint]api.Cluster, 0)
+		for id, c := range clusterGroup.Clusters {
+			if id != clusterID {
+				newMembers[id] = c
+			}
+		}
+
+		g.logger.WithFields(logrus.Fields{
+			"clusterName":      cluster.GetName(),
+			"clusterGroupName": clusterGroup.Name,
+		}).Info("Remove cluster from group as it does not match the selector anymore")
+
+		err = g.updateMembers(ctx, *clusterGroup, newMembers, getDynamicMembers(*clusterGroup))
+		if err != nil {
+			return err
+		}
+	}
+
+	if !isValidClusterStatus(clusterStatus) {
+		return nil
+	}
+
+	cgModels, err := g.cgRepo.FindAll(cluster.GetOrganizationId())
+	if err != nil {
+		return err
+	}
+
+	for _, cgModel := range cgModels {
+		if len(cgModel.Selector) == 0 {
+			continue
+		}
+
+		clusterGroup := g.GetClusterGroupFromModel(ctx, cgModel, false)
+		if !clusterGroup.Selector.Matches(clusterStatus) {
+			continue
+		}
+
+		newMembers := make(map[uint]api.Cluster, len(clusterGroup.Clusters)+1)
+		for id, c := range clusterGroup.Clusters {
+			newMembers[id] = c
+		}
+		newMembers[clusterID] = cluster
+
+		dynamicMembers := getDynamicMembers(*clusterGroup)
+		dynamicMembers[clusterID] = true
+
+		g.logger.WithFields(logrus.Fields{
+			"clusterName":      cluster.GetName(),
+			"clusterGroupName": clusterGroup.Name,
+		}).Info("Join cluster to group by selector")
+
+		return g.updateMembers(ctx, *clusterGroup, newMembers, dynamicMembers)
+	}
+
+	return nil
+}
+
+// selectClusters returns the running clusters of an organization matching the selector,
+// which are not members of another cluster group and not listed in excludedClusters.
+func (g *Manager) selectClusters(
+	ctx context.Context,
+	orgID uint,
+	clusterGroupID uint,
+	selector *api.ClusterSelector,
+	excludedClusters map[uint]api.Cluster,
+) (map[uint]api.Cluster, error) {
+	clusters, err := g.clusterGetter.GetClusters(ctx, orgID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "could not list clusters", "orgID", orgID)
+	}
+
+	selectedClusters := make(map[uint]api.Cluster, 0)
+	for _, cluster := range clusters {
+		if _, ok := excludedClusters[cluster.GetID()]; ok {
+			continue
+		}
+
+		clusterStatus, err := cluster.GetStatus()
+		if err != nil {
+			g.errorHandler.Handle(errors.WrapIfWithDetails(err, "could not check cluster state", "clusterID", cluster.GetID()))
+			continue
+		}
+		if !isValidClusterStatus(clusterStatus) || !selector.Matches(clusterStatus) {
+			continue
+		}
+
+		if ok, err := g.isClusterMemberOfAClusterGroup(cluster.GetID(), clusterGroupID); ok {
+			g.logger.WithField("clusterName", cluster.GetName()).Debug("cluster is already member of a cluster group")
+			continue
+		} else if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		selectedClusters[cluster.GetID()] = cluster
+	}
+
+	return selectedClusters, nil
+}
+
+// updateMembers validates and persists the new members of a cluster group, then reconciles its features.
+func (g *Manager) updateMembers(ctx context.Context, clusterGroup api.ClusterGroup, newMembers map[uint]api.Cluster, dynamicMembers map[uint]bool) error {
+	err := g.validateBeforeClusterGroupUpdate(clusterGroup, newMembers)
+	if err != nil {
+		return errors.WrapIf(err, "updating cluster group members is not allowed")
+	}
+
+	err = g.cgRepo.UpdateMembers(&clusterGroup, newMembers, dynamicMembers)
+	if err != nil {
+		return err
+	}
+
+	updatedClusterGroup, err := g.GetClusterGroupByID(ctx, clusterGroup.Id, clusterGroup.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	// call feature handlers on members update
+	return g.ReconcileFeatures(*updatedClusterGroup, true)
+}
+
+func getDynamicMembers(clusterGroup api.ClusterGroup) map[uint]bool {
+	dynamicMembers := make(map[uint]bool, 0)
+	for _, member := range clusterGroup.Members {
+		if member.Dynamic {
+			dynamicMembers[member.ID] = true
+		}
+	}
+
+	return dynamicMembers
+}
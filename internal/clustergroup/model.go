@@ -41,6 +41,7 @@ type ClusterGroupModel struct {
 	CreatedBy      uint
 	Name           string                     `gorm:"unique_index:idx_unique_id"`
 	OrganizationID uint                       `gorm:"unique_index:idx_unique_id"`
+	Selector       string                     `sql:"type:text"`
 	Members        []MemberClusterModel       `gorm:"foreignkey:ClusterGroupID"`
 	FeatureParams  []ClusterGroupFeatureModel `gorm:"foreignkey:ClusterGroupID"`
 }
@@ -50,6 +51,7 @@ type MemberClusterModel struct {
 	ID             uint `gorm:"primary_key"`
 	ClusterGroupID uint
 	ClusterID      uint
+	Dynamic        bool
 }
 
 // ClusterGroupFeature describes a feature of a cluster group.
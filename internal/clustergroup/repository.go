@@ -75,10 +75,11 @@ func (g *ClusterGroupRepository) FindAll(orgID uint) ([]*ClusterGroupModel, erro
 }
 
 // Create persists a cluster group
-func (g *ClusterGroupRepository) Create(name string, orgID uint, memberClusterModels []MemberClusterModel) (*uint, error) {
+func (g *ClusterGroupRepository) Create(name string, orgID uint, selector string, memberClusterModels []MemberClusterModel) (*uint, error) {
 	clusterGroupModel := &ClusterGroupModel{
 		Name:           name,
 		OrganizationID: orgID,
+		Selector:       selector,
 		Members:        memberClusterModels,
 	}
 
@@ -89,8 +90,8 @@ func (g *ClusterGroupRepository) Create(name string, orgID uint, memberClusterMo
 	return &clusterGroupModel.ID, nil
 }
 
-// UpdateMembers updates cluster group members
-func (g *ClusterGroupRepository) UpdateMembers(cgroup *api.ClusterGroup, newMembers map[uint]api.Cluster) error {
+// UpdateMembers updates cluster group members, dynamicMembers marks the members joined by the cluster selector
+func (g *ClusterGroupRepository) UpdateMembers(cgroup *api.ClusterGroup, newMembers map[uint]api.Cluster, dynamicMembers map[uint]bool) error {
 	cgModel, err := g.FindOne(ClusterGroupModel{
 		ID: cgroup.Id,
 	})
@@ -107,6 +108,7 @@ func (g *ClusterGroupRepository) UpdateMembers(cgroup *api.ClusterGroup, newMemb
 				return errors.WrapIfWithDetails(err, "could not delete member cluster", "clusterGroupID", cgroup.Id, "clusterID", member.ClusterID)
 			}
 		} else {
+			member.Dynamic = dynamicMembers[member.ClusterID]
 			updatedMembers = append(updatedMembers, member)
 		}
 		delete(newMembers, member.ClusterID)
@@ -116,6 +118,7 @@ func (g *ClusterGroupRepository) UpdateMembers(cgroup *api.ClusterGroup, newMemb
 	for _, member := range newMembers {
 		updatedMembers = append(updatedMembers, MemberClusterModel{
 			ClusterID: member.GetID(),
+			Dynamic:   dynamicMembers[member.GetID()],
 		})
 	}
 
@@ -127,6 +130,15 @@ func (g *ClusterGroupRepository) UpdateMembers(cgroup *api.ClusterGroup, newMemb
 	return nil
 }
 
+// UpdateSelector updates the cluster selector of a cluster group
+func (g *ClusterGroupRepository) UpdateSelector(clusterGroupID uint, selector string) error {
+	err := g.db.Model(&ClusterGroupModel{ID: clusterGroupID}).Update("selector", selector).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "could not update cluster selector", "clusterGroupID", clusterGroupID)
+	}
+	return nil
+}
+
 // Delete deletes a cluster group
 func (g *ClusterGroupRepository) Delete(cgroup *ClusterGroupModel) error {
 	for _, fp := range cgroup.FeatureParams {
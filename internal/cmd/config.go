@@ -23,6 +23,8 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/banzaicloud/pipeline/internal/cluster/clusterconfig"
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeproxy"
 	"github.com/banzaicloud/pipeline/internal/federation"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/dns"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/ingress"
@@ -35,6 +37,7 @@ import (
 	"github.com/banzaicloud/pipeline/internal/platform/database"
 	"github.com/banzaicloud/pipeline/internal/platform/errorhandler"
 	"github.com/banzaicloud/pipeline/internal/platform/log"
+	"github.com/banzaicloud/pipeline/internal/platform/watermill"
 	"github.com/banzaicloud/pipeline/pkg/cluster"
 	"github.com/banzaicloud/pipeline/pkg/values"
 )
@@ -137,6 +140,12 @@ type Config struct {
 
 	// Telemetry configuration
 	Telemetry TelemetryConfig
+
+	// Webhook configuration
+	Webhook WebhookConfig
+
+	// Event bus configuration
+	EventBus watermill.PubSubConfig
 }
 
 func (c Config) Validate() error {
@@ -154,6 +163,10 @@ func (c Config) Validate() error {
 
 	err = errors.Append(err, c.Telemetry.Validate())
 
+	err = errors.Append(err, c.Webhook.Validate())
+
+	err = errors.Append(err, c.EventBus.Validate())
+
 	return err
 }
 
@@ -185,6 +198,9 @@ type ClusterConfig struct {
 
 	Backyards istiofeature.StaticConfig
 
+	// Cost estimation
+	Cost cost.Config
+
 	DisasterRecovery ClusterDisasterRecoveryConfig
 
 	DNS ClusterDNSConfig
@@ -193,8 +209,12 @@ type ClusterConfig struct {
 
 	Federation federation.StaticConfig
 
+	Imported ClusterImportedConfig
+
 	Ingress ClusterIngressConfig
 
+	Kubeconfig ClusterKubeconfigConfig
+
 	Labels clusterconfig.LabelConfig
 
 	// Initial manifest
@@ -210,6 +230,9 @@ type ClusterConfig struct {
 	// Posthook configs
 	PostHook cluster.PostHookConfig
 
+	// Kubernetes API proxy access control
+	Proxy kubeproxy.Config
+
 	SecurityScan ClusterSecurityScanConfig
 
 	Vault ClusterVaultConfig
@@ -219,10 +242,16 @@ type ClusterConfig struct {
 func (c ClusterConfig) Validate() error {
 	var errs error
 
+	errs = errors.Append(errs, c.Cost.Validate())
+
 	errs = errors.Append(errs, c.DNS.Validate())
 
+	errs = errors.Append(errs, c.Imported.Validate())
+
 	errs = errors.Append(errs, c.Ingress.Validate())
 
+	errs = errors.Append(errs, c.Kubeconfig.Validate())
+
 	errs = errors.Append(errs, c.Labels.Validate())
 
 	errs = errors.Append(errs, c.Logging.Validate())
@@ -241,6 +270,8 @@ func (c ClusterConfig) Validate() error {
 		errs = errors.Append(errs, errors.New("cluster namespace is required"))
 	}
 
+	errs = errors.Append(errs, c.Proxy.Validate())
+
 	errs = errors.Append(errs, c.SecurityScan.Validate())
 
 	errs = errors.Append(errs, c.Vault.Validate())
@@ -266,6 +297,10 @@ func (c *ClusterConfig) Process() error {
 		c.Ingress.Namespace = c.Namespace
 	}
 
+	if c.Kubeconfig.Namespace == "" {
+		c.Kubeconfig.Namespace = c.Namespace
+	}
+
 	if c.Labels.Namespace == "" {
 		c.Labels.Namespace = c.Namespace
 	}
@@ -366,6 +401,72 @@ type ClusterExpiryConfig struct {
 	Enabled bool
 }
 
+// ClusterImportedConfig contains configuration for clusters imported with a kubeconfig.
+type ClusterImportedConfig struct {
+	HealthCheck struct {
+		Enabled  bool
+		Schedule string
+
+		// Clusters are marked with a warning this long before their kubeconfig credentials expire
+		CredentialExpiryWarning time.Duration
+	}
+}
+
+func (c ClusterImportedConfig) Validate() error {
+	var errs error
+
+	if c.HealthCheck.Enabled {
+		if c.HealthCheck.Schedule == "" {
+			errs = errors.Append(errs, errors.New("cluster imported health check schedule is required"))
+		}
+
+		if c.HealthCheck.CredentialExpiryWarning < 0 {
+			errs = errors.Append(errs, errors.New("cluster imported credential expiry warning must not be negative"))
+		}
+	}
+
+	return errs
+}
+
+// ClusterKubeconfigConfig contains configuration for short-lived, per-user kube configs.
+type ClusterKubeconfigConfig struct {
+	DefaultTTL time.Duration
+	MaxTTL     time.Duration
+
+	// Namespace the kube configs are scoped to when the request does not specify one
+	DefaultNamespace string
+
+	// Namespace to create the service accounts of the kube configs in
+	Namespace string
+
+	// Kubernetes cluster roles bound to the kube configs of each Pipeline organization role
+	ClusterRoles map[string]string
+
+	ExpirySchedule string
+}
+
+func (c ClusterKubeconfigConfig) Validate() error {
+	var errs error
+
+	if c.DefaultTTL <= 0 {
+		errs = errors.Append(errs, errors.New("cluster kubeconfig default ttl must be positive"))
+	}
+
+	if c.MaxTTL > 0 && c.DefaultTTL > c.MaxTTL {
+		errs = errors.Append(errs, errors.New("cluster kubeconfig default ttl must not be greater than the max ttl"))
+	}
+
+	if c.DefaultNamespace == "" {
+		errs = errors.Append(errs, errors.New("cluster kubeconfig default namespace is required"))
+	}
+
+	if c.ExpirySchedule == "" {
+		errs = errors.Append(errs, errors.New("cluster kubeconfig expiry schedule is required"))
+	}
+
+	return errs
+}
+
 type ClusterIngressConfig struct {
 	Enabled bool
 
@@ -475,6 +576,33 @@ func (c TelemetryConfig) Validate() error {
 	return err
 }
 
+// WebhookConfig contains outbound webhook configuration.
+type WebhookConfig struct {
+	// Timeout of a single webhook request
+	Timeout time.Duration
+
+	// Number of retries after a failed delivery attempt
+	MaxRetries int
+
+	// Time waited between two delivery attempts
+	RetryDelay time.Duration
+}
+
+// Validate validates the configuration.
+func (c WebhookConfig) Validate() error {
+	var err error
+
+	if c.Timeout <= 0 {
+		err = errors.Append(err, errors.New("webhook timeout must be greater than zero"))
+	}
+
+	if c.MaxRetries < 0 {
+		err = errors.Append(err, errors.New("webhook max retries cannot be negative"))
+	}
+
+	return err
+}
+
 // Configure configures some defaults in the Viper instance.
 func Configure(v *viper.Viper, p *pflag.FlagSet) {
 	// Log configuration
@@ -702,10 +830,18 @@ ssl:
 	v.SetDefault("cluster::securityScan::anchore::endpoint", "")
 	v.SetDefault("cluster::securityScan::anchore::user", "")
 	v.SetDefault("cluster::securityScan::anchore::password", "")
+	v.SetDefault("cluster::securityScan::trivy::chart", "aquasecurity/trivy")
+	v.SetDefault("cluster::securityScan::trivy::version", "0.4.2")
+	v.SetDefault("cluster::securityScan::trivy::release", "trivy")
+	v.SetDefault("cluster::securityScan::trivy::namespace", "pipeline-system")
+	v.SetDefault("cluster::securityScan::trivy::values", map[string]interface{}{})
+	v.SetDefault("cluster::securityScan::trivy::port", 4954)
 	v.SetDefault("cluster::securityScan::webhook::chart", "banzaicloud-stable/anchore-policy-validator")
 	v.SetDefault("cluster::securityScan::webhook::version", "0.5.6")
 	v.SetDefault("cluster::securityScan::webhook::release", "anchore")
 	v.SetDefault("cluster::securityScan::webhook::namespace", "pipeline-system")
+	v.SetDefault("cluster::securityScan::whitelist::approvalRequired", false)
+	v.SetDefault("cluster::securityScan::whitelist::expirySchedule", "*/15 * * * *")
 	// v.SetDefault("cluster::securityScan::webhook::values", map[string]interface{}{
 	//	"image": map[string]interface{}{
 	//		"repository": "banzaicloud/ark",
@@ -716,6 +852,58 @@ ssl:
 
 	v.SetDefault("cluster::expiry::enabled", true)
 
+	v.SetDefault("cluster::imported::healthCheck::enabled", true)
+	v.SetDefault("cluster::imported::healthCheck::schedule", "*/10 * * * *")
+	v.SetDefault("cluster::imported::healthCheck::credentialExpiryWarning", 7*24*time.Hour)
+
+	v.SetDefault("cluster::kubeconfig::defaultTTL", 8*time.Hour)
+	v.SetDefault("cluster::kubeconfig::maxTTL", 24*time.Hour)
+	v.SetDefault("cluster::kubeconfig::defaultNamespace", "default")
+	v.SetDefault("cluster::kubeconfig::namespace", "")
+	v.SetDefault("cluster::kubeconfig::clusterRoles", map[string]string{
+		"admin":  "admin",
+		"member": "view",
+	})
+	v.SetDefault("cluster::kubeconfig::expirySchedule", "*/5 * * * *")
+
+	v.SetDefault("cluster::cost::controlPlaneFees", map[string]float64{
+		"eks": 0.10,
+		"gke": 0.10,
+	})
+
+	v.SetDefault("cluster::proxy::userPrefix", "pipeline:")
+	v.SetDefault("cluster::proxy::roles", map[string]interface{}{
+		"admin": map[string]interface{}{
+			"groups": []string{"system:masters"},
+			"rules": []map[string]interface{}{
+				{
+					"verbs":           []string{"*"},
+					"apiGroups":       []string{"*"},
+					"resources":       []string{"*"},
+					"nonResourceURLs": []string{"*"},
+				},
+			},
+		},
+		"member": map[string]interface{}{
+			"groups": []string{"pipeline:members"},
+			"rules": []map[string]interface{}{
+				{
+					"verbs":     []string{"get", "list", "watch"},
+					"apiGroups": []string{"", "apps", "batch", "extensions", "networking.k8s.io", "autoscaling"},
+					"resources": []string{
+						"pods", "pods/log", "services", "endpoints", "configmaps", "events", "namespaces", "nodes",
+						"persistentvolumeclaims", "deployments", "replicasets", "statefulsets", "daemonsets",
+						"jobs", "cronjobs", "ingresses", "horizontalpodautoscalers",
+					},
+				},
+				{
+					"verbs":           []string{"get"},
+					"nonResourceURLs": []string{"/api", "/api/*", "/apis", "/apis/*", "/version", "/openapi/*"},
+				},
+			},
+		},
+	})
+
 	// ingress controller config
 	v.SetDefault("cluster::posthook::ingress::enabled", true)
 	v.SetDefault("cluster::posthook::ingress::chart", "banzaicloud-stable/pipeline-cluster-ingress")
@@ -823,6 +1011,7 @@ traefik:
 	v.SetDefault("helm::repositories::stable", "https://kubernetes-charts.storage.googleapis.com")
 	v.SetDefault("helm::repositories::banzaicloud-stable", "https://kubernetes-charts.banzaicloud.com")
 	v.SetDefault("helm::repositories::loki", "https://grafana.github.io/loki/charts")
+	v.SetDefault("helm::repositories::aquasecurity", "https://aquasecurity.github.io/helm-charts")
 
 	// Cloud configuration
 	v.SetDefault("cloud::amazon::defaultRegion", "us-west-1")
@@ -863,4 +1052,13 @@ traefik:
 	_ = v.BindPFlag("telemetry::addr", p.Lookup("telemetry-addr"))
 	v.SetDefault("telemetry::addr", "127.0.0.1:9900")
 	v.SetDefault("telemetry::debug", true)
+
+	// Webhook configuration
+	v.SetDefault("webhook::timeout", 10*time.Second)
+	v.SetDefault("webhook::maxRetries", 5)
+	v.SetDefault("webhook::retryDelay", 30*time.Second)
+
+	// Event bus configuration
+	v.SetDefault("eventBus::backend", watermill.MemoryBackend)
+	v.SetDefault("eventBus::sql::pollInterval", time.Second)
 }
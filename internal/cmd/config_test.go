@@ -21,6 +21,8 @@ import (
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
+	"github.com/banzaicloud/pipeline/internal/cluster/cost"
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeproxy"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/dns"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services/ingress"
 	"github.com/banzaicloud/pipeline/pkg/hook"
@@ -97,6 +99,29 @@ func TestConfigure_DefaultValueBinding(t *testing.T) {
 				},
 			},
 		},
+		"cluster cost": {
+			Subtree: config.Cluster.Cost,
+			Expected: cost.Config{
+				ControlPlaneFees: map[string]float64{
+					"eks": 0.10,
+					"gke": 0.10,
+				},
+			},
+		},
+		"cluster proxy admin role": {
+			Subtree: config.Cluster.Proxy.Roles["admin"],
+			Expected: kubeproxy.RoleConfig{
+				Groups: []string{"system:masters"},
+				Rules: []kubeproxy.Rule{
+					{
+						Verbs:           []string{"*"},
+						APIGroups:       []string{"*"},
+						Resources:       []string{"*"},
+						NonResourceURLs: []string{"*"},
+					},
+				},
+			},
+		},
 	}
 
 	for name, testCase := range testCases {
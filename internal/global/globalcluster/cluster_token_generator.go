@@ -0,0 +1,46 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalcluster
+
+import (
+	"sync"
+)
+
+// nolint: gochecknoglobals
+var ctGenerator ClusterTokenGenerator
+
+// nolint: gochecknoglobals
+var ctGeneratorMu sync.Mutex
+
+// ClusterTokenGenerator generates API tokens for clusters.
+type ClusterTokenGenerator interface {
+	GenerateClusterToken(orgID uint, clusterID uint) (string, string, error)
+}
+
+// TokenGenerator returns an initialized cluster token generator.
+func TokenGenerator() ClusterTokenGenerator {
+	ctGeneratorMu.Lock()
+	defer ctGeneratorMu.Unlock()
+
+	return ctGenerator
+}
+
+// SetTokenGenerator configures a cluster token generator.
+func SetTokenGenerator(g ClusterTokenGenerator) {
+	ctGeneratorMu.Lock()
+	defer ctGeneratorMu.Unlock()
+
+	ctGenerator = g
+}
@@ -26,3 +26,6 @@ type Logger = common.Logger
 
 // NoopLogger is a logger that discards every log event.
 type NoopLogger = common.NoopLogger
+
+// ErrorHandler handles an error.
+type ErrorHandler = common.ErrorHandler
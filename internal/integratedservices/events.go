@@ -0,0 +1,100 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integratedservices
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+)
+
+// +mga:event:dispatcher
+
+// IntegratedServiceEvents dispatches integrated service events.
+type IntegratedServiceEvents interface {
+	// IntegratedServiceStatusChanged dispatches an IntegratedServiceStatusChanged event.
+	IntegratedServiceStatusChanged(ctx context.Context, event IntegratedServiceStatusChanged) error
+}
+
+// IntegratedServiceStatusChanged event is triggered when the status of an integrated service changes.
+type IntegratedServiceStatusChanged struct {
+	ClusterID             uint
+	IntegratedServiceName string
+	Status                string
+
+	// PreviousStatus is empty when the integrated service did not exist before.
+	PreviousStatus string
+}
+
+// NewEventDispatchingIntegratedServiceRepository decorates an integrated service repository
+// to dispatch an event whenever the status of an integrated service changes.
+func NewEventDispatchingIntegratedServiceRepository(
+	repository IntegratedServiceRepository,
+	events IntegratedServiceEvents,
+	errorHandler ErrorHandler,
+) IntegratedServiceRepository {
+	return eventDispatchingIntegratedServiceRepository{
+		IntegratedServiceRepository: repository,
+
+		events:       events,
+		errorHandler: errorHandler,
+	}
+}
+
+type eventDispatchingIntegratedServiceRepository struct {
+	IntegratedServiceRepository
+
+	events       IntegratedServiceEvents
+	errorHandler ErrorHandler
+}
+
+func (r eventDispatchingIntegratedServiceRepository) UpdateIntegratedServiceStatus(
+	ctx context.Context,
+	clusterID uint,
+	integratedServiceName string,
+	status string,
+) error {
+	previous, err := r.IntegratedServiceRepository.GetIntegratedService(ctx, clusterID, integratedServiceName)
+	if err != nil && !IsIntegratedServiceNotFoundError(err) {
+		return err
+	}
+
+	err = r.IntegratedServiceRepository.UpdateIntegratedServiceStatus(ctx, clusterID, integratedServiceName, status)
+	if err != nil {
+		return err
+	}
+
+	if previous.Status == status {
+		return nil
+	}
+
+	err = r.events.IntegratedServiceStatusChanged(ctx, IntegratedServiceStatusChanged{
+		ClusterID:             clusterID,
+		IntegratedServiceName: integratedServiceName,
+		Status:                status,
+		PreviousStatus:        previous.Status,
+	})
+	if err != nil {
+		// the status is already updated, so the error is only reported
+		r.errorHandler.HandleContext(ctx, errors.WithDetails(
+			err,
+			"clusterId", clusterID,
+			"integratedService", integratedServiceName,
+			"status", status,
+		))
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integratedservices
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+type integratedServiceEventRecorder struct {
+	events []IntegratedServiceStatusChanged
+}
+
+func (r *integratedServiceEventRecorder) IntegratedServiceStatusChanged(_ context.Context, event IntegratedServiceStatusChanged) error {
+	r.events = append(r.events, event)
+
+	return nil
+}
+
+func TestEventDispatchingIntegratedServiceRepository_UpdateIntegratedServiceStatus(t *testing.T) {
+	clusterID := uint(1)
+	integratedServiceName := "myIntegratedService"
+
+	repository := NewInMemoryIntegratedServiceRepository(map[uint][]IntegratedService{
+		clusterID: {
+			{
+				Name:   integratedServiceName,
+				Status: IntegratedServiceStatusPending,
+			},
+		},
+	})
+	events := &integratedServiceEventRecorder{}
+
+	decorated := NewEventDispatchingIntegratedServiceRepository(repository, events, common.NoopErrorHandler{})
+
+	ctx := context.Background()
+
+	require.NoError(t, decorated.UpdateIntegratedServiceStatus(ctx, clusterID, integratedServiceName, IntegratedServiceStatusActive))
+
+	// same status again: no event
+	require.NoError(t, decorated.UpdateIntegratedServiceStatus(ctx, clusterID, integratedServiceName, IntegratedServiceStatusActive))
+
+	integratedService, err := repository.GetIntegratedService(ctx, clusterID, integratedServiceName)
+	require.NoError(t, err)
+
+	assert.Equal(t, IntegratedServiceStatusActive, integratedService.Status)
+	assert.Equal(
+		t,
+		[]IntegratedServiceStatusChanged{
+			{
+				ClusterID:             clusterID,
+				IntegratedServiceName: integratedServiceName,
+				Status:                IntegratedServiceStatusActive,
+				PreviousStatus:        IntegratedServiceStatusPending,
+			},
+		},
+		events.events,
+	)
+}
@@ -0,0 +1,52 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/pkg/providers"
+)
+
+// OutputBucket returns the cloud provider and the name of the bucket the cluster logs are written to.
+// It returns false when the cluster output is disabled or the spec is invalid.
+func OutputBucket(spec integratedservices.IntegratedServiceSpec) (cloud string, bucketName string, ok bool) {
+	boundSpec, err := bindIntegratedServiceSpec(spec)
+	if err != nil || !boundSpec.ClusterOutput.Enabled {
+		return "", "", false
+	}
+
+	provider := boundSpec.ClusterOutput.Provider
+
+	switch provider.Name {
+	case providerAmazonS3:
+		cloud = providers.Amazon
+	case providerGoogleGCS:
+		cloud = providers.Google
+	case providerAlibabaOSS:
+		cloud = providers.Alibaba
+	case providerAzure:
+		cloud = providers.Azure
+	case providerS3Compatible:
+		cloud = providers.S3Compatible
+	default:
+		return "", "", false
+	}
+
+	if provider.Bucket.Name == "" {
+		return "", "", false
+	}
+
+	return cloud, provider.Bucket.Name, true
+}
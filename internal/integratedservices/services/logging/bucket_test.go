@@ -0,0 +1,82 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+)
+
+func TestOutputBucket(t *testing.T) {
+	tests := map[string]struct {
+		spec       integratedservices.IntegratedServiceSpec
+		cloud      string
+		bucketName string
+		ok         bool
+	}{
+		"s3 output": {
+			spec: integratedservices.IntegratedServiceSpec{
+				"clusterOutput": map[string]interface{}{
+					"enabled": true,
+					"provider": map[string]interface{}{
+						"name":     "s3",
+						"secretId": "secret",
+						"bucket": map[string]interface{}{
+							"name": "logs",
+						},
+					},
+				},
+			},
+			cloud:      "amazon",
+			bucketName: "logs",
+			ok:         true,
+		},
+		"disabled output": {
+			spec: integratedservices.IntegratedServiceSpec{
+				"clusterOutput": map[string]interface{}{
+					"enabled": false,
+					"provider": map[string]interface{}{
+						"name": "gcs",
+						"bucket": map[string]interface{}{
+							"name": "logs",
+						},
+					},
+				},
+			},
+		},
+		"loki only": {
+			spec: integratedservices.IntegratedServiceSpec{
+				"loki": map[string]interface{}{
+					"enabled": true,
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			cloud, bucketName, ok := OutputBucket(test.spec)
+
+			assert.Equal(t, test.cloud, cloud)
+			assert.Equal(t, test.bucketName, bucketName)
+			assert.Equal(t, test.ok, ok)
+		})
+	}
+}
@@ -18,14 +18,16 @@ import (
 	"fmt"
 )
 
-const (
-	integratedServiceName = "logging"
+// IntegratedServiceName is the name of the logging integrated service.
+const IntegratedServiceName = "logging"
 
-	providerAmazonS3   = "s3"
-	providerGoogleGCS  = "gcs"
-	providerAlibabaOSS = "oss"
-	providerAzure      = "azure"
-	providerLoki       = "loki"
+const (
+	providerAmazonS3     = "s3"
+	providerGoogleGCS    = "gcs"
+	providerAlibabaOSS   = "oss"
+	providerAzure        = "azure"
+	providerS3Compatible = "s3compatible"
+	providerLoki         = "loki"
 
 	tlsSecretName              = "logging-tls-secret"
 	loggingOperatorReleaseName = "logging-operator"
@@ -37,13 +39,15 @@ const (
 	generatedSecretUsername    = "admin"
 	fluentSharedSecretName     = "logging-operator-fluent-shared-secret"
 
-	outputDefinitionSecretKeyOSSAccessKeyID      = "accessKeyId"
-	outputDefinitionSecretKeyOSSAccessKey        = "accessKeySecret"
-	outputDefinitionSecretKeyS3AccessKeyID       = "awsAccessKeyId"
-	outputDefinitionSecretKeyS3AccessKey         = "awsSecretAccessKey"
-	outputDefinitionSecretKeyGCS                 = "credentials.json"
-	outputDefinitionSecretKeyAzureStorageAccount = "azureStorageAccount"
-	outputDefinitionSecretKeyAzureStorageAccess  = "azureStorageAccessKey"
+	outputDefinitionSecretKeyOSSAccessKeyID          = "accessKeyId"
+	outputDefinitionSecretKeyOSSAccessKey            = "accessKeySecret"
+	outputDefinitionSecretKeyS3AccessKeyID           = "awsAccessKeyId"
+	outputDefinitionSecretKeyS3AccessKey             = "awsSecretAccessKey"
+	outputDefinitionSecretKeyGCS                     = "credentials.json"
+	outputDefinitionSecretKeyAzureStorageAccount     = "azureStorageAccount"
+	outputDefinitionSecretKeyAzureStorageAccess      = "azureStorageAccessKey"
+	outputDefinitionSecretKeyS3CompatibleAccessKeyID = "accessKeyId"
+	outputDefinitionSecretKeyS3CompatibleAccessKey   = "secretAccessKey"
 
 	lokiOutputDefinitionName = "loki-output"
 	flowResourceName         = "banzai-logging-flow"
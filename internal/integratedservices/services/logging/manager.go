@@ -57,14 +57,14 @@ func MakeIntegratedServiceManager(
 
 // Name returns the integrated service' name
 func (IntegratedServicesManager) Name() string {
-	return integratedServiceName
+	return IntegratedServiceName
 }
 
 func (m IntegratedServicesManager) GetOutput(ctx context.Context, clusterID uint, spec integratedservices.IntegratedServiceSpec) (integratedservices.IntegratedServiceOutput, error) {
 	boundSpec, err := bindIntegratedServiceSpec(spec)
 	if err != nil {
 		return nil, integratedservices.InvalidIntegratedServiceSpecError{
-			IntegratedServiceName: integratedServiceName,
+			IntegratedServiceName: IntegratedServiceName,
 			Problem:               err.Error(),
 		}
 	}
@@ -175,7 +175,7 @@ func (IntegratedServicesManager) ValidateSpec(ctx context.Context, spec integrat
 
 	if err := vaultSpec.Validate(); err != nil {
 		return integratedservices.InvalidIntegratedServiceSpecError{
-			IntegratedServiceName: integratedServiceName,
+			IntegratedServiceName: IntegratedServiceName,
 			Problem:               err.Error(),
 		}
 	}
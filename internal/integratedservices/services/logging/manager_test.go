@@ -165,6 +165,21 @@ func TestIntegratedServiceManager_ValidateSpec(t *testing.T) {
 			},
 			Error: false,
 		},
+		"valid s3 compatible spec": {
+			Spec: integratedservices.IntegratedServiceSpec{
+				"clusterOutput": obj{
+					"enabled": true,
+					"provider": obj{
+						"name":     "s3compatible",
+						"secretId": "asdasd",
+						"bucket": obj{
+							"name": "testbucket",
+						},
+					},
+				},
+			},
+			Error: false,
+		},
 		"required bucket secret": {
 			Spec: integratedservices.IntegratedServiceSpec{
 				"loki": obj{
@@ -76,7 +76,7 @@ func MakeIntegratedServicesOperator(
 
 // Name returns the name of the Logging integrated service
 func (IntegratedServiceOperator) Name() string {
-	return integratedServiceName
+	return IntegratedServiceName
 }
 
 // Apply applies the provided specification to the integrated service
@@ -93,7 +93,7 @@ func (op IntegratedServiceOperator) Apply(ctx context.Context, clusterID uint, s
 	boundSpec, err := bindIntegratedServiceSpec(spec)
 	if err != nil {
 		return integratedservices.InvalidIntegratedServiceSpecError{
-			IntegratedServiceName: integratedServiceName,
+			IntegratedServiceName: IntegratedServiceName,
 			Problem:               err.Error(),
 		}
 	}
@@ -446,7 +446,7 @@ func (op IntegratedServiceOperator) createLoggingResource(ctx context.Context, c
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      loggingResourceName,
 			Namespace: op.config.Namespace,
-			Labels:    map[string]string{resourceLabelKey: integratedServiceName},
+			Labels:    map[string]string{resourceLabelKey: IntegratedServiceName},
 		},
 		Spec: v1beta1.LoggingSpec{
 			FluentbitSpec: &v1beta1.FluentbitSpec{
@@ -59,7 +59,7 @@ func (op IntegratedServiceOperator) generateFlowResource(definitions []outputDef
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      flowResourceName,
 			Namespace: op.config.Namespace,
-			Labels:    map[string]string{resourceLabelKey: integratedServiceName},
+			Labels:    map[string]string{resourceLabelKey: IntegratedServiceName},
 		},
 		Spec: v1beta1.FlowSpec{
 			Selectors:  map[string]string{},
@@ -57,7 +57,7 @@ func (op IntegratedServiceOperator) createClusterOutputDefinitions(ctx context.C
 
 	// remove old output definitions with integrated service labels
 	var outputList v1beta1.ClusterOutputList
-	if err := op.kubernetesService.List(ctx, cl.GetID(), map[string]string{resourceLabelKey: integratedServiceName}, &outputList); err != nil {
+	if err := op.kubernetesService.List(ctx, cl.GetID(), map[string]string{resourceLabelKey: IntegratedServiceName}, &outputList); err != nil {
 		return nil, errors.WrapIf(err, "failed to list output definitions")
 	}
 
@@ -52,6 +52,8 @@ func newOutputDefinitionManager(creators []outputManagerCreator) (managers []out
 			managers = append(managers, outputDefinitionManagerAzure{baseOutputManager: baseManager})
 		case providerAlibabaOSS:
 			managers = append(managers, outputDefinitionManagerOSS{baseOutputManager: baseManager})
+		case providerS3Compatible:
+			managers = append(managers, outputDefinitionManagerS3Compatible{baseOutputManager: baseManager})
 		case providerLoki:
 			managers = append(managers, outputDefinitionManagerLoki{serviceURL: creator.serviceURL})
 		}
@@ -85,7 +87,7 @@ func generateOutputDefinition(
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      m.getName(),
 			Namespace: namespace,
-			Labels:    map[string]string{resourceLabelKey: integratedServiceName},
+			Labels:    map[string]string{resourceLabelKey: IntegratedServiceName},
 		},
 		Spec: m.getOutputSpec(spec.Bucket, *bucketOptions),
 	}, nil
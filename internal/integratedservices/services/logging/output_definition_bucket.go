@@ -18,6 +18,7 @@ import (
 	"emperror.dev/errors"
 
 	"github.com/banzaicloud/pipeline/internal/providers"
+	"github.com/banzaicloud/pipeline/internal/providers/s3compatible"
 	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
 	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
 	"github.com/banzaicloud/pipeline/src/secret"
@@ -33,6 +34,11 @@ type bucketOptions struct {
 	gcs *struct {
 		project string
 	}
+	s3compatible *struct {
+		endpoint       string
+		region         string
+		forcePathStyle bool
+	}
 }
 
 func generateBucketOptions(spec providerSpec, secretValues map[string]string, orgID uint) (*bucketOptions, error) {
@@ -46,6 +52,8 @@ func generateBucketOptions(spec providerSpec, secretValues map[string]string, or
 		return generateGCSBucketOptions(secretValues), nil
 	case providerAlibabaOSS:
 		return generateOSSBucketOptions(spec, secretItems, orgID)
+	case providerS3Compatible:
+		return generateS3CompatibleBucketOptions(secretItems)
 	default:
 		return &bucketOptions{}, nil
 	}
@@ -79,6 +87,25 @@ func generateOSSBucketOptions(spec providerSpec, secretItems *secret.SecretItemR
 	}, nil
 }
 
+func generateS3CompatibleBucketOptions(secretItems *secret.SecretItemResponse) (*bucketOptions, error) {
+	config, _, err := s3compatible.GetConfig(secretItems)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to get S3 compatible object store config")
+	}
+
+	return &bucketOptions{
+		s3compatible: &struct {
+			endpoint       string
+			region         string
+			forcePathStyle bool
+		}{
+			endpoint:       config.Endpoint,
+			region:         config.Region,
+			forcePathStyle: config.ForcePathStyle,
+		},
+	}, nil
+}
+
 func generateGCSBucketOptions(secretValues map[string]string) *bucketOptions {
 	return &bucketOptions{
 		gcs: &struct {
@@ -0,0 +1,72 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"strconv"
+
+	"github.com/banzaicloud/logging-operator/pkg/sdk/api/v1beta1"
+	"github.com/banzaicloud/logging-operator/pkg/sdk/model/output"
+	loggingSecret "github.com/banzaicloud/logging-operator/pkg/sdk/model/secret"
+)
+
+type outputDefinitionManagerS3Compatible struct {
+	baseOutputManager
+}
+
+func (outputDefinitionManagerS3Compatible) getName() string {
+	return "s3compatible-output"
+}
+
+func (m outputDefinitionManagerS3Compatible) getOutputSpec(spec bucketSpec, op bucketOptions) v1beta1.ClusterOutputSpec {
+	var endpoint, region, forcePathStyle string
+	if op.s3compatible != nil {
+		endpoint = op.s3compatible.endpoint
+		region = op.s3compatible.region
+		forcePathStyle = strconv.FormatBool(op.s3compatible.forcePathStyle)
+	}
+
+	return v1beta1.ClusterOutputSpec{
+		OutputSpec: v1beta1.OutputSpec{
+			S3OutputConfig: &output.S3OutputConfig{
+				AwsAccessKey: &loggingSecret.Secret{
+					ValueFrom: &loggingSecret.ValueFrom{
+						SecretKeyRef: &loggingSecret.KubernetesSecret{
+							Name: m.sourceSecretName,
+							Key:  outputDefinitionSecretKeyS3CompatibleAccessKeyID,
+						},
+					},
+				},
+				AwsSecretKey: &loggingSecret.Secret{
+					ValueFrom: &loggingSecret.ValueFrom{
+						SecretKeyRef: &loggingSecret.KubernetesSecret{
+							Name: m.sourceSecretName,
+							Key:  outputDefinitionSecretKeyS3CompatibleAccessKey,
+						},
+					},
+				},
+				Path:           m.getPathSpec(),
+				S3Endpoint:     endpoint,
+				S3Region:       region,
+				ForcePathStyle: forcePathStyle,
+				S3Bucket:       spec.Name,
+				Buffer:         m.getBufferSpec(),
+				Format: &output.Format{
+					Type: "json",
+				},
+			},
+		},
+	}
+}
@@ -51,6 +51,11 @@ func newOutputSecretInstallManager(providerName, sourceSecretName, namespace str
 			sourceSecretName: sourceSecretName,
 			namespace:        namespace,
 		}}, nil
+	case providerS3Compatible:
+		return outputSecretInstallManagerS3Compatible{baseOutputSecretInstallManager{
+			sourceSecretName: sourceSecretName,
+			namespace:        namespace,
+		}}, nil
 	default:
 		return nil, errors.NewWithDetails("unsupported provider", "provider", providerName)
 	}
@@ -66,7 +66,7 @@ func bindIntegratedServiceSpec(spec integratedservices.IntegratedServiceSpec) (i
 	var integratedServiceSpec integratedServiceSpec
 	if err := mapstructure.Decode(spec, &integratedServiceSpec); err != nil {
 		return integratedServiceSpec, integratedservices.InvalidIntegratedServiceSpecError{
-			IntegratedServiceName: integratedServiceName,
+			IntegratedServiceName: IntegratedServiceName,
 			Problem:               "failed to bind integrated service spec",
 		}
 	}
@@ -133,7 +133,7 @@ func (s providerSpec) Validate() error {
 	}
 
 	switch s.Name {
-	case providerAmazonS3, providerAzure, providerAlibabaOSS, providerGoogleGCS:
+	case providerAmazonS3, providerAzure, providerAlibabaOSS, providerGoogleGCS, providerS3Compatible:
 	default:
 		return errors.New("invalid provider name")
 	}
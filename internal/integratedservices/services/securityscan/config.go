@@ -16,25 +16,39 @@ package securityscan
 
 import (
 	"context"
+	"fmt"
 	"net/url"
 
 	"emperror.dev/errors"
+	"github.com/Masterminds/semver/v3"
 
 	"github.com/banzaicloud/pipeline/internal/anchore"
 	"github.com/banzaicloud/pipeline/internal/common"
 	"github.com/banzaicloud/pipeline/internal/integratedservices"
 	"github.com/banzaicloud/pipeline/internal/integratedservices/services"
 	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	"github.com/banzaicloud/pipeline/pkg/security"
 )
 
 type Config struct {
 	Anchore           AnchoreConfig
+	Trivy             TrivyConfig
 	PipelineNamespace string
 	Webhook           WebhookConfig
+	Whitelist         WhitelistConfig
 }
 
 func (c Config) Validate() error {
-	return errors.Combine(c.Anchore.Validate())
+	return errors.Combine(c.Anchore.Validate(), c.Trivy.Validate())
+}
+
+// WhitelistConfig contains settings of whitelist items created through the API.
+type WhitelistConfig struct {
+	// Whitelist items become active after another organization admin approves them
+	ApprovalRequired bool
+
+	// Cron schedule of the workflow removing expired whitelist items (worker)
+	ExpirySchedule string
 }
 
 type AnchoreConfig struct {
@@ -170,6 +184,42 @@ func (p CustomAnchoreConfigProvider) GetConfiguration(ctx context.Context, clust
 	}, nil
 }
 
+// TrivyConfig encapsulates configuration of the Trivy server installed into clusters
+// using Trivy as image scanner without a custom server.
+type TrivyConfig struct {
+	Chart     string
+	Version   string
+	Release   string
+	Namespace string
+	Values    map[string]interface{}
+
+	// Port of the Trivy server service
+	Port int
+}
+
+func (c TrivyConfig) Validate() error {
+	var err error
+
+	if c.Chart == "" {
+		err = errors.Append(err, errors.New("trivy chart is required"))
+	}
+
+	if c.Release == "" {
+		err = errors.Append(err, errors.New("trivy release is required"))
+	}
+
+	if c.Port <= 0 {
+		err = errors.Append(err, errors.New("trivy port must be a positive number"))
+	}
+
+	return err
+}
+
+// host returns the address of the Trivy server installed into a cluster.
+func (c TrivyConfig) host() string {
+	return fmt.Sprintf("http://%s.%s:%d", c.Release, c.Namespace, c.Port)
+}
+
 // WebhookConfig encapsulates configuration of the image validator webhook
 // sensitive defaults provided through env vars
 type WebhookConfig struct {
@@ -179,3 +229,25 @@ type WebhookConfig struct {
 	Namespace string
 	Values    map[string]interface{}
 }
+
+// trivyWebhookChartConstraint matches the image validator chart versions that can use a Trivy server.
+const trivyWebhookChartConstraint = ">= 0.6.0"
+
+// SupportsScanner returns whether the configured image validator chart can use the given image scanner.
+func (c WebhookConfig) SupportsScanner(scanner string) bool {
+	if scanner != security.ScannerTrivy {
+		return true
+	}
+
+	version, err := semver.NewVersion(c.Version)
+	if err != nil {
+		return false
+	}
+
+	constraint, err := semver.NewConstraint(trivyWebhookChartConstraint)
+	if err != nil {
+		return false
+	}
+
+	return constraint.Check(version)
+}
@@ -16,9 +16,11 @@ package securityscan
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/banzaicloud/pipeline/internal/common"
 	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/pkg/security"
 )
 
 type IntegratedServiceManager struct {
@@ -57,6 +59,24 @@ func (f IntegratedServiceManager) ValidateSpec(ctx context.Context, spec integra
 		}
 	}
 
+	if scanner := securityScanSpec.Scanner.scannerType(); !f.config.Webhook.SupportsScanner(scanner) {
+		return integratedservices.InvalidIntegratedServiceSpecError{
+			IntegratedServiceName: IntegratedServiceName,
+			Problem: fmt.Sprintf(
+				"the %s scanner requires image validator chart version %s (configured version: %s)",
+				scanner, trivyWebhookChartConstraint, f.config.Webhook.Version,
+			),
+		}
+	}
+
+	// whitelist items of the spec would be installed without approval
+	if f.config.Whitelist.ApprovalRequired && len(securityScanSpec.ReleaseWhiteList) > 0 {
+		return integratedservices.InvalidIntegratedServiceSpecError{
+			IntegratedServiceName: IntegratedServiceName,
+			Problem:               "releaseWhiteList is not allowed when whitelist items require approval: use the whitelist API instead",
+		}
+	}
+
 	return nil
 }
 
@@ -73,5 +93,25 @@ func (f IntegratedServiceManager) GetOutput(ctx context.Context, clusterID uint,
 		},
 	}
 
+	securityScanSpec, err := bindIntegratedServiceSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	out["scanner"] = securityScanSpec.Scanner.scannerType()
+
+	if securityScanSpec.Scanner.scannerType() == security.ScannerTrivy {
+		trivyOutput := map[string]interface{}{
+			"url": securityScanSpec.Scanner.Trivy.Url,
+		}
+
+		if !securityScanSpec.Scanner.Trivy.custom() {
+			trivyOutput["url"] = f.config.Trivy.host()
+			trivyOutput["version"] = f.config.Trivy.Version
+		}
+
+		out["trivy"] = trivyOutput
+	}
+
 	return out, nil
 }
@@ -18,7 +18,9 @@ import (
 	"context"
 	"testing"
 
+	"emperror.dev/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/banzaicloud/pipeline/internal/integratedservices"
 )
@@ -89,3 +91,51 @@ func TestIntegratedServiceManager_ValidateSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestIntegratedServiceManager_ValidateSpec_WhitelistApprovalRequired(t *testing.T) {
+	spec := integratedservices.IntegratedServiceSpec{
+		"policy": obj{
+			"policyId": "policy",
+		},
+		"releaseWhiteList": []obj{
+			{
+				"name":   "release",
+				"reason": "reason of whitelisting",
+			},
+		},
+	}
+
+	integratedServiceManager := MakeIntegratedServiceManager(nil, Config{Whitelist: WhitelistConfig{ApprovalRequired: true}})
+
+	err := integratedServiceManager.ValidateSpec(context.Background(), spec)
+	require.Error(t, err)
+
+	assert.True(t, errors.As(err, &integratedservices.InvalidIntegratedServiceSpecError{}))
+}
+
+func TestIntegratedServiceManager_ValidateSpec_TrivyChartVersion(t *testing.T) {
+	spec := integratedservices.IntegratedServiceSpec{
+		"scanner": obj{
+			"type": "trivy",
+		},
+		"policy": obj{
+			"policyId": "policy",
+		},
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		integratedServiceManager := MakeIntegratedServiceManager(nil, Config{Webhook: WebhookConfig{Version: "0.5.6"}})
+
+		err := integratedServiceManager.ValidateSpec(context.Background(), spec)
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &integratedservices.InvalidIntegratedServiceSpecError{}))
+	})
+
+	t.Run("supported", func(t *testing.T) {
+		integratedServiceManager := MakeIntegratedServiceManager(nil, Config{Webhook: WebhookConfig{Version: "0.6.0"}})
+
+		err := integratedServiceManager.ValidateSpec(context.Background(), spec)
+		require.NoError(t, err)
+	})
+}
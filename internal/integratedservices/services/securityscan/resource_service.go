@@ -78,8 +78,15 @@ func (wls *integratedServiceWhiteListService) EnsureReleaseWhiteList(ctx context
 
 	var toBeAdded []releaseSpec
 
+	now := time.Now()
+
 	// find items to be installed
 	for _, releaseItem := range items {
+		// expired items are treated as removed from the spec
+		if expiresAt := releaseItem.expiry(); expiresAt != nil && !expiresAt.After(now) {
+			continue
+		}
+
 		installed, ok := installedItemsMap[releaseItem.Name]
 		if !ok {
 			// the release is not installed
@@ -121,10 +128,15 @@ func (wls *integratedServiceWhiteListService) installItems(ctx context.Context,
 	var collectedErrors error
 	for _, item := range items {
 		wlItem := security.ReleaseWhiteListItem{
-			Name:   item.Name,
-			Owner:  "pipeline",
-			Reason: item.Reason,
-			Regexp: item.Regexp,
+			Name:      item.Name,
+			Owner:     item.Owner,
+			Reason:    item.Reason,
+			Regexp:    item.Regexp,
+			ExpiresAt: item.expiry(),
+		}
+
+		if wlItem.Owner == "" {
+			wlItem.Owner = "pipeline"
 		}
 
 		if _, err := wls.whiteListService.CreateWhitelist(ctx, cluster, wlItem); err != nil {
@@ -0,0 +1,223 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securityscan
+
+import (
+	"context"
+	"encoding/json"
+
+	"emperror.dev/errors"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services"
+	"github.com/banzaicloud/pipeline/pkg/security"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+// imageScanner is an image scanner backend of the image validator webhook.
+type imageScanner interface {
+	// Setup prepares the scanner for a cluster and points the image validator webhook to it.
+	Setup(ctx context.Context, clusterID uint, values *ImageValidatorChartValues) error
+
+	// Teardown removes everything created for a cluster by Setup.
+	Teardown(ctx context.Context, clusterID uint) error
+}
+
+// imageScanner returns the image scanner backend selected by the spec.
+func (op IntegratedServiceOperator) imageScanner(spec integratedServiceSpec) imageScanner {
+	switch spec.Scanner.scannerType() {
+	case security.ScannerTrivy:
+		return trivyScanner{
+			spec:          spec.Scanner.Trivy,
+			config:        op.config.Trivy,
+			webhookConfig: op.config.Webhook,
+			helmService:   op.helmService,
+		}
+
+	default:
+		return anchoreScanner{
+			spec:           spec.CustomAnchore,
+			config:         op.config.Anchore,
+			clusterGetter:  op.clusterGetter,
+			secretStore:    op.secretStore,
+			anchoreService: op.anchoreService,
+			logger:         op.logger,
+		}
+	}
+}
+
+// anchoreScanner uses either the Anchore instance configured for Pipeline (with a user generated for the cluster)
+// or a custom Anchore instance.
+type anchoreScanner struct {
+	spec           anchoreSpec
+	config         AnchoreConfig
+	clusterGetter  integratedserviceadapter.ClusterGetter
+	secretStore    services.SecretStore
+	anchoreService IntegratedServiceAnchoreService
+	logger         common.Logger
+}
+
+func (s anchoreScanner) Setup(ctx context.Context, clusterID uint, values *ImageValidatorChartValues) error {
+	var anchoreValues AnchoreValues
+	var err error
+
+	if s.spec.Enabled {
+		anchoreValues, err = s.getCustomAnchoreValues(ctx)
+		if err != nil {
+			return errors.WrapIf(err, "failed to get custom anchore values")
+		}
+	} else {
+		anchoreValues, err = s.getDefaultAnchoreValues(ctx, clusterID)
+		if err != nil {
+			return errors.WrapIf(err, "failed to get default anchore values")
+		}
+	}
+
+	values.ExternalAnchore = &anchoreValues
+
+	return nil
+}
+
+func (s anchoreScanner) Teardown(ctx context.Context, clusterID uint) error {
+	if s.spec.Enabled {
+		return nil
+	}
+
+	cl, err := s.clusterGetter.GetClusterByIDOnly(ctx, clusterID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to get cluster by ID")
+	}
+
+	if err = s.anchoreService.DeleteUser(ctx, cl.GetOrganizationId(), clusterID); err != nil {
+		// deactivation succeeds even in case the generated anchore user is not deleted!
+		s.logger.Warn("failed to delete the anchore user generated for the cluster", map[string]interface{}{"clusterID": clusterID})
+	}
+
+	return nil
+}
+
+func (s anchoreScanner) getCustomAnchoreValues(ctx context.Context) (AnchoreValues, error) {
+	if !s.spec.Enabled { // this is already checked
+		return AnchoreValues{}, errors.NewWithDetails("custom anchore disabled")
+	}
+
+	anchoreUserSecret, err := s.secretStore.GetSecretValues(ctx, s.spec.SecretID)
+	if err != nil {
+		return AnchoreValues{}, errors.WrapWithDetails(err, "failed to get anchore secret", "secretId", s.spec.SecretID)
+	}
+
+	var anchoreValues AnchoreValues
+	if err := mapstructure.Decode(anchoreUserSecret, &anchoreValues); err != nil {
+		return AnchoreValues{}, errors.WrapIf(err, "failed to extract anchore secret values")
+	}
+
+	anchoreValues.Host = s.spec.Url
+
+	return anchoreValues, nil
+}
+
+func (s anchoreScanner) getDefaultAnchoreValues(ctx context.Context, clusterID uint) (AnchoreValues, error) {
+	// default (pipeline hosted) anchore
+	if !s.config.Enabled {
+		return AnchoreValues{}, errors.NewWithDetails("default anchore is not enabled")
+	}
+
+	secretName, err := s.createAnchoreUserForCluster(ctx, clusterID)
+	if err != nil {
+		return AnchoreValues{}, errors.WrapIf(err, "failed to create anchore user")
+	}
+
+	anchoreSecretID := secret.GenerateSecretIDFromName(secretName)
+	anchoreUserSecret, err := s.secretStore.GetSecretValues(ctx, anchoreSecretID)
+	if err != nil {
+		return AnchoreValues{}, errors.WrapWithDetails(err, "failed to get anchore secret", "secretId", anchoreSecretID)
+	}
+
+	var anchoreValues AnchoreValues
+	if err := mapstructure.Decode(anchoreUserSecret, &anchoreValues); err != nil {
+		return AnchoreValues{}, errors.WrapIf(err, "failed to extract anchore secret values")
+	}
+
+	anchoreValues.Host = s.config.Endpoint
+
+	return anchoreValues, nil
+}
+
+func (s anchoreScanner) createAnchoreUserForCluster(ctx context.Context, clusterID uint) (string, error) {
+	cl, err := s.clusterGetter.GetClusterByIDOnly(ctx, clusterID)
+	if err != nil {
+		return "", errors.WrapIf(err, "error retrieving cluster")
+	}
+
+	userName, err := s.anchoreService.GenerateUser(ctx, cl.GetOrganizationId(), clusterID)
+	if err != nil {
+		return "", errors.WrapIf(err, "error creating anchore user")
+	}
+
+	return userName, nil
+}
+
+// trivyScanner uses a Trivy server installed into the cluster or a custom one.
+type trivyScanner struct {
+	spec          trivySpec
+	config        TrivyConfig
+	webhookConfig WebhookConfig
+	helmService   services.HelmService
+}
+
+func (s trivyScanner) Setup(ctx context.Context, clusterID uint, values *ImageValidatorChartValues) error {
+	if !s.webhookConfig.SupportsScanner(security.ScannerTrivy) {
+		return errors.NewWithDetails(
+			"the configured image validator chart does not support the trivy scanner",
+			"version", s.webhookConfig.Version,
+			"constraint", trivyWebhookChartConstraint,
+		)
+	}
+
+	host := s.spec.Url
+
+	if !s.spec.custom() {
+		trivyValues, err := json.Marshal(s.config.Values)
+		if err != nil {
+			return errors.WrapIf(err, "failed to marshal trivy chart values")
+		}
+
+		if err := s.helmService.ApplyDeployment(ctx, clusterID, s.config.Namespace, s.config.Chart, s.config.Release,
+			trivyValues, s.config.Version); err != nil {
+			return errors.WrapIf(err, "failed to deploy trivy server")
+		}
+
+		host = s.config.host()
+	}
+
+	values.Scanner = security.ScannerTrivy
+	values.Trivy = &TrivyValues{Host: host}
+
+	return nil
+}
+
+func (s trivyScanner) Teardown(ctx context.Context, clusterID uint) error {
+	if s.spec.custom() {
+		return nil
+	}
+
+	if err := s.helmService.DeleteDeployment(ctx, clusterID, s.config.Release); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to uninstall trivy server", "clusterID", clusterID)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package securityscan
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/pkg/security"
+)
+
+func TestWebhookConfig_SupportsScanner(t *testing.T) {
+	tests := []struct {
+		version string
+		scanner string
+		want    bool
+	}{
+		{version: "0.5.6", scanner: security.ScannerAnchore, want: true},
+		{version: "0.5.6", scanner: security.ScannerTrivy, want: false},
+		{version: "0.6.0", scanner: security.ScannerTrivy, want: true},
+		{version: "1.0.0", scanner: security.ScannerTrivy, want: true},
+		{version: "", scanner: security.ScannerTrivy, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.scanner+"@"+tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, WebhookConfig{Version: tt.version}.SupportsScanner(tt.scanner))
+		})
+	}
+}
+
+func TestTrivyScanner_Setup(t *testing.T) {
+	t.Run("custom server", func(t *testing.T) {
+		scanner := trivyScanner{
+			spec:          trivySpec{Url: "http://trivy.example.com:4954"},
+			webhookConfig: WebhookConfig{Version: "0.6.0"},
+		}
+
+		values := webHookConfigSpec{}.GetValues()
+
+		err := scanner.Setup(context.Background(), 1, &values)
+		require.NoError(t, err)
+
+		valuesJSON, err := json.Marshal(values)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{"scanner":"trivy","trivy":{"host":"http://trivy.example.com:4954"}}`, string(valuesJSON))
+	})
+
+	t.Run("unsupported chart version", func(t *testing.T) {
+		scanner := trivyScanner{
+			spec:          trivySpec{Url: "http://trivy.example.com:4954"},
+			webhookConfig: WebhookConfig{Version: "0.5.6"},
+		}
+
+		values := webHookConfigSpec{}.GetValues()
+
+		err := scanner.Setup(context.Background(), 1, &values)
+		require.Error(t, err)
+
+		assert.Nil(t, values.Trivy)
+	})
+}
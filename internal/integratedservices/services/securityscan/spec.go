@@ -15,14 +15,19 @@
 package securityscan
 
 import (
+	"net/url"
+	"time"
+
 	"emperror.dev/errors"
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/pkg/security"
 )
 
 //integratedServiceSpec security scan cluster integrated service specific specification
 type integratedServiceSpec struct {
+	Scanner          scannerSpec       `json:"scanner" mapstructure:"scanner"`
 	CustomAnchore    anchoreSpec       `json:"customAnchore" mapstructure:"customAnchore"`
 	Policy           policySpec        `json:"policy" mapstructure:"policy"`
 	ReleaseWhiteList []releaseSpec     `json:"releaseWhiteList,omitempty" mapstructure:"releaseWhiteList"`
@@ -33,8 +38,14 @@ type integratedServiceSpec struct {
 func (s integratedServiceSpec) Validate(pipelineNamespace string) error {
 	var validationErrors error
 
+	validationErrors = s.Scanner.Validate()
+
 	if s.CustomAnchore.Enabled {
-		validationErrors = s.CustomAnchore.Validate()
+		if s.Scanner.scannerType() != security.ScannerAnchore {
+			validationErrors = errors.Combine(validationErrors, errors.New("custom anchore can only be used with the anchore scanner"))
+		}
+
+		validationErrors = errors.Combine(validationErrors, s.CustomAnchore.Validate())
 	}
 
 	if !s.Policy.CustomPolicy.Enabled && s.Policy.PolicyID == "" {
@@ -50,6 +61,53 @@ func (s integratedServiceSpec) Validate(pipelineNamespace string) error {
 	return validationErrors
 }
 
+type scannerSpec struct {
+	// Type is the image scanner backend (anchore by default)
+	Type  string    `json:"type,omitempty" mapstructure:"type"`
+	Trivy trivySpec `json:"trivy,omitempty" mapstructure:"trivy"`
+}
+
+func (s scannerSpec) Validate() error {
+	switch s.scannerType() {
+	case security.ScannerAnchore:
+		return nil
+
+	case security.ScannerTrivy:
+		return s.Trivy.Validate()
+
+	default:
+		return errors.Errorf("unsupported scanner type: %s", s.Type)
+	}
+}
+
+func (s scannerSpec) scannerType() string {
+	if s.Type == "" {
+		return security.ScannerAnchore
+	}
+
+	return s.Type
+}
+
+type trivySpec struct {
+	// Url of a Trivy server, a server is installed into the cluster when empty
+	Url string `json:"url,omitempty" mapstructure:"url"`
+}
+
+func (t trivySpec) Validate() error {
+	if t.Url != "" {
+		if u, err := url.Parse(t.Url); err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.Errorf("invalid trivy server url: %s", t.Url)
+		}
+	}
+
+	return nil
+}
+
+// custom returns whether the Trivy server is not managed by Pipeline
+func (t trivySpec) custom() bool {
+	return t.Url != ""
+}
+
 type anchoreSpec struct {
 	Enabled  bool   `json:"enabled" mapstructure:"enabled"`
 	Url      string `json:"url" mapstructure:"url"`
@@ -77,9 +135,11 @@ type customPolicySpec struct {
 }
 
 type releaseSpec struct {
-	Name   string `json:"name" mapstructure:"name"`
-	Reason string `json:"reason" mapstructure:"reason"`
-	Regexp string `json:"regexp,omitempty" mapstructure:"regexp"`
+	Name      string `json:"name" mapstructure:"name"`
+	Reason    string `json:"reason" mapstructure:"reason"`
+	Regexp    string `json:"regexp,omitempty" mapstructure:"regexp"`
+	Owner     string `json:"owner,omitempty" mapstructure:"owner"`
+	ExpiresAt string `json:"expiresAt,omitempty" mapstructure:"expiresAt"`
 }
 
 func (r releaseSpec) Validate() error {
@@ -87,9 +147,30 @@ func (r releaseSpec) Validate() error {
 		return errors.NewPlain("both name and reason must be specified")
 	}
 
+	if r.ExpiresAt != "" {
+		if _, err := time.Parse(time.RFC3339, r.ExpiresAt); err != nil {
+			return errors.Errorf("invalid expiry of whitelist item %s (RFC3339 expected): %s", r.Name, r.ExpiresAt)
+		}
+	}
+
 	return nil
 }
 
+// expiry returns the expiry of the whitelist item (if any).
+// The spec is expected to be validated.
+func (r releaseSpec) expiry() *time.Time {
+	if r.ExpiresAt == "" {
+		return nil
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, r.ExpiresAt)
+	if err != nil {
+		return nil
+	}
+
+	return &expiresAt
+}
+
 type webHookConfigSpec struct {
 	Enabled    bool     `json:"enabled" mapstructure:"enabled"`
 	Selector   string   `json:"selector" mapstructure:"selector"`
@@ -120,3 +120,90 @@ func TestMarshalValues(t *testing.T) {
 		})
 	}
 }
+
+func Test_releaseSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    releaseSpec
+		wantErr bool
+	}{
+		{
+			name: "without expiry",
+			spec: releaseSpec{Name: "release", Reason: "reason"},
+		},
+		{
+			name: "with expiry",
+			spec: releaseSpec{Name: "release", Reason: "reason", Owner: "john", ExpiresAt: "2020-04-01T00:00:00Z"},
+		},
+		{
+			name:    "invalid expiry",
+			spec:    releaseSpec{Name: "release", Reason: "reason", ExpiresAt: "next week"},
+			wantErr: true,
+		},
+		{
+			name:    "missing reason",
+			spec:    releaseSpec{Name: "release"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.spec.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("releaseSpec.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_integratedServiceSpec_Validate_Scanner(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    integratedServiceSpec
+		wantErr bool
+	}{
+		{
+			name: "default scanner",
+			spec: integratedServiceSpec{Policy: policySpec{PolicyID: "policy"}},
+		},
+		{
+			name: "in-cluster trivy",
+			spec: integratedServiceSpec{Scanner: scannerSpec{Type: "trivy"}, Policy: policySpec{PolicyID: "policy"}},
+		},
+		{
+			name: "custom trivy",
+			spec: integratedServiceSpec{
+				Scanner: scannerSpec{Type: "trivy", Trivy: trivySpec{Url: "http://trivy.example.com:4954"}},
+				Policy:  policySpec{PolicyID: "policy"},
+			},
+		},
+		{
+			name: "invalid trivy url",
+			spec: integratedServiceSpec{
+				Scanner: scannerSpec{Type: "trivy", Trivy: trivySpec{Url: "trivy"}},
+				Policy:  policySpec{PolicyID: "policy"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "custom anchore with trivy",
+			spec: integratedServiceSpec{
+				Scanner:       scannerSpec{Type: "trivy"},
+				CustomAnchore: anchoreSpec{Enabled: true, Url: "http://anchore.example.com", SecretID: "secret"},
+				Policy:        policySpec{PolicyID: "policy"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "unknown scanner",
+			spec:    integratedServiceSpec{Scanner: scannerSpec{Type: "clair"}, Policy: policySpec{PolicyID: "policy"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.spec.Validate("pipeline-system"); (err != nil) != tt.wantErr {
+				t.Errorf("integratedServiceSpec.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
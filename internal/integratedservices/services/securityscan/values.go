@@ -16,7 +16,9 @@ package securityscan
 
 // represents a values yaml to be passed to the anchore image validator webhook chart
 type ImageValidatorChartValues struct {
+	Scanner           string            `json:"scanner,omitempty" mapstructure:"scanner"`
 	ExternalAnchore   *AnchoreValues    `json:"externalAnchore,omitempty" mapstructure:"externalAnchore"`
+	Trivy             *TrivyValues      `json:"trivy,omitempty" mapstructure:"trivy"`
 	NamespaceSelector *SetBasedSelector `json:"namespaceSelector,omitempty" mapstructure:"namespaceSelector"`
 	ObjectSelector    *SetBasedSelector `json:"objectSelector,omitempty" mapstructure:"objectSelector"`
 }
@@ -28,6 +30,11 @@ type AnchoreValues struct {
 	Password string `json:"anchorePass" mapstructure:"password"`
 }
 
+// TrivyValues struct used to point the webhook to a Trivy server
+type TrivyValues struct {
+	Host string `json:"host" mapstructure:"host"`
+}
+
 type MatchExpression struct {
 	Key      string   `json:"key" mapstructure:"key"`
 	Operator string   `json:"operator" mapstructure:"operator"`
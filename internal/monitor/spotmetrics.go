@@ -16,6 +16,7 @@ package monitor
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"emperror.dev/emperror"
@@ -25,6 +26,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/sirupsen/logrus"
 
+	"github.com/banzaicloud/pipeline/internal/cluster/spot"
 	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
 	"github.com/banzaicloud/pipeline/pkg/providers/amazon"
 	pkgEC2 "github.com/banzaicloud/pipeline/pkg/providers/amazon/ec2"
@@ -33,9 +35,19 @@ import (
 
 const metricsNamesapce = "pipeline"
 
+// SpotRecorder records spot instances and their interruptions.
+type SpotRecorder interface {
+	// RecordInstance records a running (or terminated) spot instance.
+	RecordInstance(ctx context.Context, instance spot.Instance) error
+
+	// RecordEvent records an interruption or termination event.
+	RecordEvent(ctx context.Context, event spot.Event) error
+}
+
 type spotMetricsExporter struct {
 	ctx          context.Context
 	manager      *cluster.Manager
+	recorder     SpotRecorder
 	logger       logrus.FieldLogger
 	errorHandler emperror.Handler
 
@@ -44,10 +56,12 @@ type spotMetricsExporter struct {
 }
 
 // NewSpotMetricsExporter gives back an initialized spotMetricsExporter
-func NewSpotMetricsExporter(ctx context.Context, manager *cluster.Manager, logger logrus.FieldLogger) *spotMetricsExporter {
+// Collected spot requests are also recorded in the spot interruption history.
+func NewSpotMetricsExporter(ctx context.Context, manager *cluster.Manager, recorder SpotRecorder, logger logrus.FieldLogger) *spotMetricsExporter {
 	return &spotMetricsExporter{
 		ctx:          ctx,
 		manager:      manager,
+		recorder:     recorder,
 		logger:       logger,
 		errorHandler: NewSpotMetricsErrorHandler(logger),
 		exporter:     pkgEC2.NewSpotMetricsExporter(logger, metricsNamesapce),
@@ -126,6 +140,8 @@ func (e *spotMetricsExporter) collectMetrics() error {
 				requests[key] = request
 			}
 		}
+
+		e.recordSpotRequests(cluster, srs)
 	}
 
 	e.exporter.SetSpotRequestMetrics(requests)
@@ -133,6 +149,84 @@ func (e *spotMetricsExporter) collectMetrics() error {
 	return nil
 }
 
+// recordSpotRequests records the spot instances of a cluster and their interruptions
+func (e *spotMetricsExporter) recordSpotRequests(c cluster.CommonCluster, requests map[string]*pkgEC2.SpotInstanceRequest) {
+	clusterName := c.GetName()
+	clusterTag := "kubernetes.io/cluster/" + clusterName
+
+	for _, request := range requests {
+		// requests are listed per account: only instances of the cluster are recorded
+		if request.Instance == nil || request.Instance.InstanceId == nil || getTagValue(request.Instance.Tags, clusterTag) == "" {
+			continue
+		}
+
+		instance := spot.Instance{
+			InstanceID:     aws.StringValue(request.Instance.InstanceId),
+			OrganizationID: c.GetOrganizationId(),
+			ClusterID:      c.GetID(),
+			// node pool instances are named <cluster name>-<node pool name>
+			NodePool:     strings.TrimPrefix(getTagValue(request.Instance.Tags, "Name"), clusterName+"-"),
+			Cloud:        c.GetCloud(),
+			Distribution: c.GetDistribution(),
+			Location:     c.GetLocation(),
+			InstanceType: aws.StringValue(request.Instance.InstanceType),
+			LaunchedAt:   aws.TimeValue(request.Instance.LaunchTime),
+		}
+
+		if request.Instance.Placement != nil {
+			instance.Zone = aws.StringValue(request.Instance.Placement.AvailabilityZone)
+		}
+
+		if err := e.recorder.RecordInstance(e.ctx, instance); err != nil {
+			e.errorHandler.Handle(errors.WrapIfWithDetails(err, "could not record spot instance", "clusterID", c.GetID(), "instanceID", instance.InstanceID))
+			continue
+		}
+
+		var eventType string
+		switch {
+		case request.IsInterruptionNotice():
+			eventType = spot.EventTypeInterruptionNotice
+		case request.IsInterrupted():
+			eventType = spot.EventTypeInterruption
+		case request.IsInstanceTerminated():
+			eventType = spot.EventTypeTermination
+		default:
+			continue
+		}
+
+		occurredAt := time.Now()
+		if request.Status != nil && request.Status.UpdateTime != nil {
+			occurredAt = *request.Status.UpdateTime
+		}
+
+		err := e.recorder.RecordEvent(e.ctx, spot.Event{
+			OrganizationID: instance.OrganizationID,
+			ClusterID:      instance.ClusterID,
+			NodePool:       instance.NodePool,
+			InstanceID:     instance.InstanceID,
+			InstanceType:   instance.InstanceType,
+			Zone:           instance.Zone,
+			Type:           eventType,
+			Source:         spot.EventSourceSpotRequest,
+			Reason:         request.GetStatusCode(),
+			OccurredAt:     occurredAt,
+		})
+		if err != nil {
+			e.errorHandler.Handle(errors.WrapIfWithDetails(err, "could not record spot event", "clusterID", c.GetID(), "instanceID", instance.InstanceID))
+		}
+	}
+}
+
+func getTagValue(tags []*ec2.Tag, key string) string {
+	for _, tag := range tags {
+		if tag.Key != nil && tag.Value != nil && *tag.Key == key {
+			return *tag.Value
+		}
+	}
+
+	return ""
+}
+
 func (e *spotMetricsExporter) getEC2Client(config aws.Config) (*ec2.EC2, error) {
 	credentials, err := config.Credentials.Get()
 	if err != nil {
@@ -0,0 +1,111 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketusageadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage"
+	"github.com/banzaicloud/pipeline/internal/providers"
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+	pkgProviders "github.com/banzaicloud/pipeline/pkg/providers"
+	"github.com/banzaicloud/pipeline/src/auth"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+// nolint: gochecknoglobals
+var managedBucketProviders = []string{
+	pkgProviders.Alibaba,
+	pkgProviders.Amazon,
+	pkgProviders.Azure,
+	pkgProviders.Google,
+	pkgProviders.Oracle,
+	pkgProviders.S3Compatible,
+}
+
+// ObjectStoreBucketStore lists managed buckets and their objects using the cloud specific object stores.
+type ObjectStoreBucketStore struct {
+	logger logrus.FieldLogger
+}
+
+// NewObjectStoreBucketStore returns a new ObjectStoreBucketStore.
+func NewObjectStoreBucketStore(logger logrus.FieldLogger) ObjectStoreBucketStore {
+	return ObjectStoreBucketStore{
+		logger: logger,
+	}
+}
+
+// ListManagedBuckets lists the managed buckets of an organization.
+func (s ObjectStoreBucketStore) ListManagedBuckets(ctx context.Context, organizationID uint) ([]bucketusage.Bucket, error) {
+	var buckets []bucketusage.Bucket
+
+	for _, provider := range managedBucketProviders {
+		objectStore, err := providers.NewObjectStore(&providers.ObjectStoreContext{
+			Provider:     provider,
+			Organization: &auth.Organization{ID: organizationID},
+		}, s.logger)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to create object store", "provider", provider)
+		}
+
+		bucketInfos, err := objectStore.ListManagedBuckets()
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to list managed buckets", "provider", provider)
+		}
+
+		for _, bucketInfo := range bucketInfos {
+			bucket := bucketusage.Bucket{
+				Cloud:    provider,
+				Name:     bucketInfo.Name,
+				Location: bucketInfo.Location,
+				SecretID: bucketInfo.SecretRef,
+			}
+
+			if bucketInfo.Azure != nil {
+				bucket.ResourceGroup = bucketInfo.Azure.ResourceGroup
+				bucket.StorageAccount = bucketInfo.Azure.StorageAccount
+			}
+
+			buckets = append(buckets, bucket)
+		}
+	}
+
+	return buckets, nil
+}
+
+// WalkObjects calls fn for every object (including its size) stored in a managed bucket.
+func (s ObjectStoreBucketStore) WalkObjects(ctx context.Context, organizationID uint, bucket bucketusage.Bucket, fn func(objectstore.ObjectInfo) error) error {
+	secretItem, err := secret.Store.Get(organizationID, bucket.SecretID)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to get bucket secret", "bucket", bucket.Name, "secretId", bucket.SecretID)
+	}
+
+	objectStore, err := providers.NewObjectStore(&providers.ObjectStoreContext{
+		Provider:       bucket.Cloud,
+		Secret:         secretItem,
+		Organization:   &auth.Organization{ID: organizationID},
+		Location:       bucket.Location,
+		ResourceGroup:  bucket.ResourceGroup,
+		StorageAccount: bucket.StorageAccount,
+	}, s.logger)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to create object store", "bucket", bucket.Name)
+	}
+
+	return objectStore.WalkBucketObjects(bucket.Name, fn)
+}
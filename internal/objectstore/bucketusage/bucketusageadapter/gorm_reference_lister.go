@@ -0,0 +1,163 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketusageadapter
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/logging"
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage"
+)
+
+// GormReferenceLister lists bucket references stored in the database.
+type GormReferenceLister struct {
+	db *gorm.DB
+}
+
+// NewGormReferenceLister returns a new GormReferenceLister.
+func NewGormReferenceLister(db *gorm.DB) GormReferenceLister {
+	return GormReferenceLister{
+		db: db,
+	}
+}
+
+type backupReferenceRow struct {
+	Cloud               string
+	BucketName          string
+	ClusterID           uint
+	ClusterName         string
+	ClusterDeletedAt    *time.Time
+	DeploymentDeletedAt *time.Time
+}
+
+type integratedServiceReferenceRow struct {
+	Name             string
+	Spec             string
+	ClusterID        uint
+	ClusterName      string
+	ClusterDeletedAt *time.Time
+}
+
+// ListReferences lists the bucket references of the (current and deleted) clusters of an organization.
+func (l GormReferenceLister) ListReferences(ctx context.Context, organizationID uint) ([]bucketusage.Reference, error) {
+	backupReferences, err := l.listBackupReferences(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	integratedServiceReferences, err := l.listIntegratedServiceReferences(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(backupReferences, integratedServiceReferences...), nil
+}
+
+// listBackupReferences lists the clusters backed up to buckets.
+// Clusters can be backed up to the same bucket multiple times: only one reference is returned for each of them.
+func (l GormReferenceLister) listBackupReferences(organizationID uint) ([]bucketusage.Reference, error) {
+	var rows []backupReferenceRow
+
+	err := l.db.
+		Table("ark_deployments AS d").
+		Select("b.cloud, b.bucket_name, d.cluster_id, c.name AS cluster_name, c.deleted_at AS cluster_deleted_at, d.deleted_at AS deployment_deleted_at").
+		Joins("JOIN ark_backup_buckets AS b ON b.id = d.bucket_id").
+		Joins("LEFT JOIN clusters AS c ON c.id = d.cluster_id").
+		Where("d.organization_id = ?", organizationID).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list backup deployments", "organizationId", organizationID)
+	}
+
+	type referenceKey struct {
+		cloud      string
+		bucketName string
+		clusterID  uint
+	}
+
+	indexes := make(map[referenceKey]int)
+
+	var references []bucketusage.Reference
+
+	for _, row := range rows {
+		active := row.ClusterDeletedAt == nil && row.DeploymentDeletedAt == nil && row.ClusterName != ""
+
+		key := referenceKey{cloud: row.Cloud, bucketName: row.BucketName, clusterID: row.ClusterID}
+		if i, ok := indexes[key]; ok {
+			references[i].Active = references[i].Active || active
+
+			continue
+		}
+
+		indexes[key] = len(references)
+		references = append(references, bucketusage.Reference{
+			Cloud:       row.Cloud,
+			BucketName:  row.BucketName,
+			ClusterID:   row.ClusterID,
+			ClusterName: row.ClusterName,
+			Kind:        bucketusage.ReferenceKindBackup,
+			Active:      active,
+		})
+	}
+
+	return references, nil
+}
+
+// listIntegratedServiceReferences lists the clusters with integrated services writing to buckets.
+func (l GormReferenceLister) listIntegratedServiceReferences(organizationID uint) ([]bucketusage.Reference, error) {
+	var rows []integratedServiceReferenceRow
+
+	err := l.db.
+		Table("cluster_features AS f").
+		Select("f.name, f.spec, f.cluster_id, c.name AS cluster_name, c.deleted_at AS cluster_deleted_at").
+		Joins("JOIN clusters AS c ON c.id = f.cluster_id").
+		Where("c.organization_id = ? AND f.name = ?", organizationID, logging.IntegratedServiceName).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list integrated services", "organizationId", organizationID)
+	}
+
+	var references []bucketusage.Reference
+
+	for _, row := range rows {
+		var spec integratedservices.IntegratedServiceSpec
+		if err := json.Unmarshal([]byte(row.Spec), &spec); err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to decode integrated service spec", "clusterId", row.ClusterID, "integratedService", row.Name)
+		}
+
+		cloud, bucketName, ok := logging.OutputBucket(spec)
+		if !ok {
+			continue
+		}
+
+		references = append(references, bucketusage.Reference{
+			Cloud:             cloud,
+			BucketName:        bucketName,
+			ClusterID:         row.ClusterID,
+			ClusterName:       row.ClusterName,
+			Kind:              bucketusage.ReferenceKindIntegratedService,
+			IntegratedService: row.Name,
+			Active:            row.ClusterDeletedAt == nil,
+		})
+	}
+
+	return references, nil
+}
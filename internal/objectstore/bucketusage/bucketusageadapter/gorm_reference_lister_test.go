@@ -0,0 +1,71 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketusageadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	// minimal versions of tables owned by other modules
+	for _, stmt := range []string{
+		"CREATE TABLE clusters (id integer primary key, organization_id integer, name varchar(255), deleted_at datetime)",
+		"CREATE TABLE cluster_features (id integer primary key, cluster_id integer, name varchar(255), spec text)",
+		"CREATE TABLE ark_backup_buckets (id integer primary key, cloud varchar(255), bucket_name varchar(255))",
+		"CREATE TABLE ark_deployments (id integer primary key, organization_id integer, cluster_id integer, bucket_id integer, deleted_at datetime)",
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+
+	return db
+}
+
+func TestGormReferenceLister_ListReferences(t *testing.T) {
+	db := setUpDatabase(t)
+
+	require.NoError(t, db.Exec("INSERT INTO clusters (id, organization_id, name) VALUES (1, 1, 'active'), (2, 1, 'deleted'), (3, 2, 'other')").Error)
+	require.NoError(t, db.Exec("UPDATE clusters SET deleted_at = ? WHERE id = 2", time.Now()).Error)
+
+	require.NoError(t, db.Exec("INSERT INTO ark_backup_buckets (id, cloud, bucket_name) VALUES (1, 'amazon', 'backups')").Error)
+	require.NoError(t, db.Exec("INSERT INTO ark_deployments (id, organization_id, cluster_id, bucket_id, deleted_at) VALUES (1, 1, 1, 1, ?), (2, 1, 1, 1, NULL), (3, 1, 2, 1, ?)", time.Now(), time.Now()).Error)
+
+	require.NoError(t, db.Exec(
+		"INSERT INTO cluster_features (id, cluster_id, name, spec) VALUES (1, 2, 'logging', ?), (2, 3, 'logging', ?), (3, 1, 'logging', ?)",
+		`{"clusterOutput":{"enabled":true,"provider":{"name":"gcs","secretId":"secret","bucket":{"name":"logs"}}}}`,
+		`{"clusterOutput":{"enabled":true,"provider":{"name":"gcs","secretId":"secret","bucket":{"name":"other"}}}}`,
+		`{"loki":{"enabled":true}}`,
+	).Error)
+
+	references, err := NewGormReferenceLister(db).ListReferences(context.Background(), 1)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []bucketusage.Reference{
+		{Cloud: "amazon", BucketName: "backups", ClusterID: 1, ClusterName: "active", Kind: bucketusage.ReferenceKindBackup, Active: true},
+		{Cloud: "amazon", BucketName: "backups", ClusterID: 2, ClusterName: "deleted", Kind: bucketusage.ReferenceKindBackup, Active: false},
+		{Cloud: "google", BucketName: "logs", ClusterID: 2, ClusterName: "deleted", Kind: bucketusage.ReferenceKindIntegratedService, IntegratedService: "logging", Active: false},
+	}, references)
+}
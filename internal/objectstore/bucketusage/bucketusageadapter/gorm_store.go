@@ -0,0 +1,142 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketusageadapter
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage"
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+// snapshotModel is the persisted form of a bucket usage snapshot.
+type snapshotModel struct {
+	ID             uint `gorm:"primary_key"`
+	OrganizationID uint `gorm:"index"`
+	Cloud          string
+	Name           string
+	Location       string
+	ObjectCount    int64
+	TotalSize      int64
+	PrefixDepth    int
+	Prefixes       string `gorm:"type:text"`
+	Error          string `gorm:"type:text"`
+	CollectedAt    time.Time
+}
+
+// TableName changes the default table name.
+func (snapshotModel) TableName() string {
+	return "bucket_usage_snapshots"
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a new bucketusage.Store backed by a relational database.
+func NewGormStore(db *gorm.DB) bucketusage.Store {
+	return gormStore{
+		db: db,
+	}
+}
+
+func (s gormStore) ReplaceSnapshots(_ context.Context, organizationID uint, snapshots []bucketusage.Snapshot) error {
+	tx := s.db.Begin()
+	if err := tx.Error; err != nil {
+		return errors.WrapIf(err, "failed to begin transaction")
+	}
+
+	if err := tx.Where(snapshotModel{OrganizationID: organizationID}).Delete(snapshotModel{}).Error; err != nil {
+		tx.Rollback()
+
+		return errors.WrapIfWithDetails(err, "failed to delete bucket usage snapshots", "organizationId", organizationID)
+	}
+
+	for _, snapshot := range snapshots {
+		prefixes, err := json.Marshal(snapshot.Prefixes)
+		if err != nil {
+			tx.Rollback()
+
+			return errors.WrapIfWithDetails(err, "failed to marshal bucket prefixes", "bucket", snapshot.Name)
+		}
+
+		model := snapshotModel{
+			OrganizationID: organizationID,
+			Cloud:          snapshot.Cloud,
+			Name:           snapshot.Name,
+			Location:       snapshot.Location,
+			ObjectCount:    snapshot.ObjectCount,
+			TotalSize:      snapshot.TotalSize,
+			PrefixDepth:    snapshot.PrefixDepth,
+			Prefixes:       string(prefixes),
+			Error:          snapshot.Error,
+			CollectedAt:    snapshot.CollectedAt,
+		}
+
+		if err := tx.Create(&model).Error; err != nil {
+			tx.Rollback()
+
+			return errors.WrapIfWithDetails(err, "failed to create bucket usage snapshot", "organizationId", organizationID, "bucket", snapshot.Name)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to commit bucket usage snapshots", "organizationId", organizationID)
+	}
+
+	return nil
+}
+
+func (s gormStore) ListSnapshots(_ context.Context, organizationID uint) ([]bucketusage.Snapshot, error) {
+	var models []snapshotModel
+
+	err := s.db.Where(snapshotModel{OrganizationID: organizationID}).Order("id").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list bucket usage snapshots", "organizationId", organizationID)
+	}
+
+	snapshots := make([]bucketusage.Snapshot, 0, len(models))
+
+	for _, model := range models {
+		snapshot := bucketusage.Snapshot{
+			OrganizationID: model.OrganizationID,
+			Cloud:          model.Cloud,
+			Name:           model.Name,
+			Location:       model.Location,
+			Usage: objectstore.Usage{
+				ObjectCount: model.ObjectCount,
+				TotalSize:   model.TotalSize,
+			},
+			PrefixDepth: model.PrefixDepth,
+			Error:       model.Error,
+			CollectedAt: model.CollectedAt,
+		}
+
+		if model.Prefixes != "" {
+			if err := json.Unmarshal([]byte(model.Prefixes), &snapshot.Prefixes); err != nil {
+				return nil, errors.WrapIfWithDetails(err, "failed to unmarshal bucket prefixes", "bucket", model.Name)
+			}
+		}
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, nil
+}
@@ -0,0 +1,81 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketusageadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage"
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+func TestGormStore(t *testing.T) {
+	db := setUpDatabase(t)
+	require.NoError(t, Migrate(db, common.NoopLogger{}))
+
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	collectedAt := time.Date(2020, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, store.ReplaceSnapshots(ctx, 1, []bucketusage.Snapshot{{OrganizationID: 1, Cloud: "amazon", Name: "deleted"}}))
+	require.NoError(t, store.ReplaceSnapshots(ctx, 2, []bucketusage.Snapshot{{OrganizationID: 2, Cloud: "amazon", Name: "other"}}))
+
+	snapshots := []bucketusage.Snapshot{
+		{
+			OrganizationID: 1,
+			Cloud:          "google",
+			Name:           "logs",
+			Location:       "europe",
+			Usage:          objectstore.Usage{ObjectCount: 3, TotalSize: 35},
+			PrefixDepth:    1,
+			Prefixes: []objectstore.PrefixUsage{
+				{Prefix: "logs", Usage: objectstore.Usage{ObjectCount: 3, TotalSize: 35}},
+			},
+			CollectedAt: collectedAt,
+		},
+		{
+			OrganizationID: 1,
+			Cloud:          "amazon",
+			Name:           "forbidden",
+			Location:       "eu-west-1",
+			Error:          "access denied",
+			CollectedAt:    collectedAt,
+		},
+	}
+
+	require.NoError(t, store.ReplaceSnapshots(ctx, 1, snapshots))
+
+	stored, err := store.ListSnapshots(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+
+	for i := range stored {
+		assert.True(t, collectedAt.Equal(stored[i].CollectedAt))
+		stored[i].CollectedAt = collectedAt
+	}
+
+	assert.Equal(t, snapshots, stored)
+
+	other, err := store.ListSnapshots(ctx, 2)
+	require.NoError(t, err)
+	assert.Len(t, other, 1)
+}
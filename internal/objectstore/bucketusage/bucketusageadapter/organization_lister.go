@@ -0,0 +1,46 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketusageadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage"
+)
+
+type gormOrganizationLister struct {
+	db *gorm.DB
+}
+
+// NewGormOrganizationLister returns a new bucketusage.OrganizationLister listing organizations stored in the database.
+func NewGormOrganizationLister(db *gorm.DB) bucketusage.OrganizationLister {
+	return gormOrganizationLister{
+		db: db,
+	}
+}
+
+func (l gormOrganizationLister) ListOrganizations(_ context.Context) ([]uint, error) {
+	var organizationIDs []uint
+
+	err := l.db.Table("organizations").Order("id").Pluck("id", &organizationIDs).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list organizations")
+	}
+
+	return organizationIDs, nil
+}
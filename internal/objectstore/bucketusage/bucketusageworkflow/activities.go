@@ -0,0 +1,92 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketusageworkflow
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage"
+)
+
+const ListOrganizationsActivityName = "bucket-usage-list-organizations-activity"
+
+type ListOrganizationsActivityInput struct{}
+
+type ListOrganizationsActivityOutput struct {
+	OrganizationIDs []uint
+}
+
+// ListOrganizationsActivity lists organizations bucket usage should be collected for.
+type ListOrganizationsActivity struct {
+	organizations bucketusage.OrganizationLister
+}
+
+// NewListOrganizationsActivity returns a new ListOrganizationsActivity.
+func NewListOrganizationsActivity(organizations bucketusage.OrganizationLister) ListOrganizationsActivity {
+	return ListOrganizationsActivity{
+		organizations: organizations,
+	}
+}
+
+func (a ListOrganizationsActivity) Execute(ctx context.Context, _ ListOrganizationsActivityInput) (ListOrganizationsActivityOutput, error) {
+	organizationIDs, err := a.organizations.ListOrganizations(ctx)
+	if err != nil {
+		return ListOrganizationsActivityOutput{}, err
+	}
+
+	return ListOrganizationsActivityOutput{OrganizationIDs: organizationIDs}, nil
+}
+
+const CollectActivityName = "bucket-usage-collect-activity"
+
+type CollectActivityInput struct {
+	OrganizationID uint
+}
+
+type CollectActivityOutput struct {
+	Buckets int
+	Failed  int
+}
+
+// CollectActivity collects the usage of the managed buckets of an organization.
+type CollectActivity struct {
+	collector bucketusage.Collector
+}
+
+// NewCollectActivity returns a new CollectActivity.
+func NewCollectActivity(collector bucketusage.Collector) CollectActivity {
+	return CollectActivity{
+		collector: collector,
+	}
+}
+
+func (a CollectActivity) Execute(ctx context.Context, input CollectActivityInput) (CollectActivityOutput, error) {
+	snapshots, err := a.collector.Collect(ctx, input.OrganizationID)
+	if err != nil {
+		return CollectActivityOutput{}, err
+	}
+
+	output := CollectActivityOutput{
+		Buckets: len(snapshots),
+	}
+
+	for _, snapshot := range snapshots {
+		if snapshot.Error != "" {
+			output.Failed++
+		}
+	}
+
+	return output, nil
+}
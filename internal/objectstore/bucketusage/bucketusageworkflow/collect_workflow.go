@@ -0,0 +1,78 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketusageworkflow
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/workflow"
+)
+
+const CollectWorkflowName = "bucket-usage-collect"
+
+// CollectWorkflow collects the usage of the managed buckets of every organization.
+// It is supposed to be scheduled as a cron workflow.
+func CollectWorkflow(ctx workflow.Context) error {
+	logger := workflow.GetLogger(ctx).Sugar()
+
+	listCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		WaitForCancellation:    true,
+	})
+
+	var organizations ListOrganizationsActivityOutput
+
+	if err := workflow.ExecuteActivity(listCtx, ListOrganizationsActivityName, ListOrganizationsActivityInput{}).Get(ctx, &organizations); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to execute activity", "activity", ListOrganizationsActivityName)
+	}
+
+	// listing the objects of large buckets takes a while
+	collectCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    time.Hour,
+		WaitForCancellation:    true,
+	})
+
+	var failed int
+
+	// a failing organization (eg. invalid credentials) should not block the collection of the others
+	for _, organizationID := range organizations.OrganizationIDs {
+		var output CollectActivityOutput
+
+		err := workflow.ExecuteActivity(collectCtx, CollectActivityName, CollectActivityInput{OrganizationID: organizationID}).Get(ctx, &output)
+		if err != nil {
+			failed++
+
+			logger.Warnw("failed to collect bucket usage", "organizationId", organizationID, "error", err.Error())
+
+			continue
+		}
+
+		logger.Infow(
+			"bucket usage collected",
+			"organizationId", organizationID,
+			"buckets", output.Buckets,
+			"failedBuckets", output.Failed,
+		)
+	}
+
+	if failed > 0 {
+		return errors.NewWithDetails("failed to collect bucket usage of some organizations", "failed", failed, "organizations", len(organizations.OrganizationIDs))
+	}
+
+	return nil
+}
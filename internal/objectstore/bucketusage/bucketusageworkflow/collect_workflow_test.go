@@ -0,0 +1,67 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketusageworkflow
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+)
+
+func testListOrganizationsActivityExecute(_ context.Context, _ ListOrganizationsActivityInput) (ListOrganizationsActivityOutput, error) {
+	return ListOrganizationsActivityOutput{}, nil
+}
+
+func testCollectActivityExecute(_ context.Context, _ CollectActivityInput) (CollectActivityOutput, error) {
+	return CollectActivityOutput{}, nil
+}
+
+// nolint: gochecknoinits
+func init() {
+	workflow.RegisterWithOptions(CollectWorkflow, workflow.RegisterOptions{Name: CollectWorkflowName})
+
+	activity.RegisterWithOptions(testListOrganizationsActivityExecute, activity.RegisterOptions{Name: ListOrganizationsActivityName})
+	activity.RegisterWithOptions(testCollectActivityExecute, activity.RegisterOptions{Name: CollectActivityName})
+}
+
+func TestCollectWorkflow(t *testing.T) {
+	var testSuite testsuite.WorkflowTestSuite
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	env.OnActivity(ListOrganizationsActivityName, mock.Anything, ListOrganizationsActivityInput{}).
+		Return(ListOrganizationsActivityOutput{OrganizationIDs: []uint{1, 2, 3}}, nil)
+	env.OnActivity(CollectActivityName, mock.Anything, CollectActivityInput{OrganizationID: 1}).
+		Return(CollectActivityOutput{Buckets: 2}, nil)
+	env.OnActivity(CollectActivityName, mock.Anything, CollectActivityInput{OrganizationID: 2}).
+		Return(CollectActivityOutput{}, errors.New("invalid credentials"))
+	env.OnActivity(CollectActivityName, mock.Anything, CollectActivityInput{OrganizationID: 3}).
+		Return(CollectActivityOutput{Buckets: 1, Failed: 1}, nil)
+
+	env.ExecuteWorkflow(CollectWorkflowName)
+
+	require.True(t, env.IsWorkflowCompleted())
+
+	// the failed organization is reported, but it does not prevent the collection of the others
+	assert.Error(t, env.GetWorkflowError())
+
+	env.AssertExpectations(t)
+}
@@ -0,0 +1,205 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketusage
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+const (
+	// MaxPrefixDepth is the maximum number of key segments objects can be grouped by.
+	MaxPrefixDepth = 5
+
+	// prefixDelimiter separates the segments of object keys.
+	prefixDelimiter = "/"
+)
+
+// Reference kinds.
+const (
+	ReferenceKindBackup            = "backup"
+	ReferenceKindIntegratedService = "integratedService"
+)
+
+// Bucket is a bucket managed by Pipeline.
+type Bucket struct {
+	Cloud    string
+	Name     string
+	Location string
+	SecretID string
+
+	// Azure specific properties
+	ResourceGroup  string
+	StorageAccount string
+}
+
+// Reference links a bucket to a cluster using it.
+type Reference struct {
+	Cloud      string `json:"-"`
+	BucketName string `json:"-"`
+
+	ClusterID   uint   `json:"clusterId"`
+	ClusterName string `json:"clusterName"`
+
+	// Kind is either a backup deployment or an integrated service writing to the bucket.
+	Kind              string `json:"kind"`
+	IntegratedService string `json:"integratedService,omitempty"`
+
+	// Active is false when the cluster or the backup deployment has been deleted.
+	Active bool `json:"active"`
+}
+
+// BucketUsage is the storage used by a managed bucket and the clusters referencing it.
+type BucketUsage struct {
+	Cloud    string `json:"cloud"`
+	Name     string `json:"name"`
+	Location string `json:"location,omitempty"`
+
+	objectstore.Usage
+
+	// Prefixes holds the storage used under each key prefix (when grouping is requested).
+	Prefixes []objectstore.PrefixUsage `json:"prefixes,omitempty"`
+
+	References []Reference `json:"references"`
+
+	// Orphaned is true when the bucket is only referenced by deleted clusters or backup deployments.
+	Orphaned bool `json:"orphaned"`
+
+	// Error is set when the objects of the bucket could not be listed.
+	Error string `json:"error,omitempty"`
+
+	// CollectedAt is the time the usage was collected.
+	CollectedAt time.Time `json:"collectedAt"`
+}
+
+// Snapshot is the storage used by a managed bucket at the time of the collection.
+type Snapshot struct {
+	OrganizationID uint
+	Cloud          string
+	Name           string
+	Location       string
+
+	objectstore.Usage
+
+	// PrefixDepth is the number of key segments the objects were grouped by.
+	PrefixDepth int
+	Prefixes    []objectstore.PrefixUsage
+
+	// Error is set when the objects of the bucket could not be listed.
+	Error string
+
+	CollectedAt time.Time
+}
+
+// BucketLister lists the buckets managed by Pipeline.
+type BucketLister interface {
+	// ListManagedBuckets lists the managed buckets of an organization.
+	ListManagedBuckets(ctx context.Context, organizationID uint) ([]Bucket, error)
+}
+
+// ObjectWalker walks the objects stored in a bucket.
+type ObjectWalker interface {
+	// WalkObjects calls fn for every object (including its size) stored in a managed bucket.
+	WalkObjects(ctx context.Context, organizationID uint, bucket Bucket, fn func(objectstore.ObjectInfo) error) error
+}
+
+// Store persists the latest usage snapshots of managed buckets.
+type Store interface {
+	// ReplaceSnapshots replaces the snapshots of an organization.
+	ReplaceSnapshots(ctx context.Context, organizationID uint, snapshots []Snapshot) error
+
+	// ListSnapshots lists the snapshots of an organization.
+	ListSnapshots(ctx context.Context, organizationID uint) ([]Snapshot, error)
+}
+
+// OrganizationLister lists the organizations whose bucket usage is collected.
+type OrganizationLister interface {
+	// ListOrganizations lists the IDs of every organization.
+	ListOrganizations(ctx context.Context) ([]uint, error)
+}
+
+// Collector computes the storage used by managed buckets and stores it as snapshots.
+type Collector struct {
+	buckets     BucketLister
+	objects     ObjectWalker
+	store       Store
+	prefixDepth int
+
+	now func() time.Time
+}
+
+// NewCollector returns a new Collector.
+// Objects are grouped by the first prefixDepth segments of their keys.
+func NewCollector(buckets BucketLister, objects ObjectWalker, store Store, prefixDepth int) Collector {
+	return Collector{
+		buckets:     buckets,
+		objects:     objects,
+		store:       store,
+		prefixDepth: prefixDepth,
+
+		now: time.Now,
+	}
+}
+
+// Collect computes the storage used by the managed buckets of an organization and replaces its stored snapshots.
+// Objects are aggregated while they are listed, so buckets of any size can be processed.
+// Failing to list the objects of a bucket does not fail the whole collection:
+// the error is recorded on the snapshot of the bucket instead.
+func (c Collector) Collect(ctx context.Context, organizationID uint) ([]Snapshot, error) {
+	buckets, err := c.buckets.ListManagedBuckets(ctx, organizationID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list managed buckets", "organizationId", organizationID)
+	}
+
+	snapshots := make([]Snapshot, 0, len(buckets))
+
+	for _, bucket := range buckets {
+		aggregator := objectstore.NewUsageAggregator(prefixDelimiter, c.prefixDepth)
+
+		snapshot := Snapshot{
+			OrganizationID: organizationID,
+			Cloud:          bucket.Cloud,
+			Name:           bucket.Name,
+			Location:       bucket.Location,
+			PrefixDepth:    c.prefixDepth,
+		}
+
+		err := c.objects.WalkObjects(ctx, organizationID, bucket, func(object objectstore.ObjectInfo) error {
+			aggregator.Add(object)
+
+			return ctx.Err()
+		})
+		if err != nil {
+			snapshot.Error = err.Error()
+		} else {
+			snapshot.Usage = aggregator.Usage
+			snapshot.Prefixes = aggregator.Prefixes()
+		}
+
+		snapshot.CollectedAt = c.now()
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := c.store.ReplaceSnapshots(ctx, organizationID, snapshots); err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
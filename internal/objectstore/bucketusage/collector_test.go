@@ -0,0 +1,128 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketusage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+type bucketListerStub []Bucket
+
+func (s bucketListerStub) ListManagedBuckets(_ context.Context, _ uint) ([]Bucket, error) {
+	return s, nil
+}
+
+type objectWalkerStub map[string][]objectstore.ObjectInfo
+
+func (s objectWalkerStub) WalkObjects(_ context.Context, _ uint, bucket Bucket, fn func(objectstore.ObjectInfo) error) error {
+	objects, ok := s[bucket.Name]
+	if !ok {
+		return errors.New("access denied")
+	}
+
+	for _, object := range objects {
+		if err := fn(object); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type storeStub map[uint][]Snapshot
+
+func (s storeStub) ReplaceSnapshots(_ context.Context, organizationID uint, snapshots []Snapshot) error {
+	s[organizationID] = snapshots
+
+	return nil
+}
+
+func (s storeStub) ListSnapshots(_ context.Context, organizationID uint) ([]Snapshot, error) {
+	return s[organizationID], nil
+}
+
+func TestCollector_Collect(t *testing.T) {
+	buckets := bucketListerStub{
+		{Cloud: "google", Name: "logs", Location: "europe"},
+		{Cloud: "amazon", Name: "unused", Location: "eu-west-1"},
+		{Cloud: "amazon", Name: "forbidden", Location: "eu-west-1"},
+	}
+
+	objects := objectWalkerStub{
+		"logs": {
+			{Key: "logs/app/1.gz", Size: 10},
+			{Key: "logs/app/2.gz", Size: 20},
+			{Key: "logs/system/1.gz", Size: 5},
+		},
+		"unused": nil,
+	}
+
+	store := storeStub{
+		1: {{OrganizationID: 1, Cloud: "amazon", Name: "deleted"}},
+	}
+
+	collectedAt := time.Date(2020, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	collector := NewCollector(buckets, objects, store, 2)
+	collector.now = func() time.Time { return collectedAt }
+
+	snapshots, err := collector.Collect(context.Background(), 1)
+	require.NoError(t, err)
+
+	// the snapshots of the previous collection are replaced
+	assert.Equal(t, snapshots, store[1])
+
+	assert.Equal(t, []Snapshot{
+		{
+			OrganizationID: 1,
+			Cloud:          "google",
+			Name:           "logs",
+			Location:       "europe",
+			Usage:          objectstore.Usage{ObjectCount: 3, TotalSize: 35},
+			PrefixDepth:    2,
+			Prefixes: []objectstore.PrefixUsage{
+				{Prefix: "logs/app", Usage: objectstore.Usage{ObjectCount: 2, TotalSize: 30}},
+				{Prefix: "logs/system", Usage: objectstore.Usage{ObjectCount: 1, TotalSize: 5}},
+			},
+			CollectedAt: collectedAt,
+		},
+		{
+			OrganizationID: 1,
+			Cloud:          "amazon",
+			Name:           "unused",
+			Location:       "eu-west-1",
+			PrefixDepth:    2,
+			Prefixes:       []objectstore.PrefixUsage{},
+			CollectedAt:    collectedAt,
+		},
+		{
+			OrganizationID: 1,
+			Cloud:          "amazon",
+			Name:           "forbidden",
+			Location:       "eu-west-1",
+			PrefixDepth:    2,
+			Error:          "access denied",
+			CollectedAt:    collectedAt,
+		},
+	}, snapshots)
+}
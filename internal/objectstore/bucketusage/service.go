@@ -0,0 +1,139 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketusage
+
+import (
+	"context"
+	"sort"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+// Options controls how the usage is reported.
+type Options struct {
+	// PrefixDepth is the number of key segments objects are grouped by (eg. 2 for logs/<tag>).
+	// Objects are not grouped when zero. Prefixes cannot be deeper than the ones collected.
+	PrefixDepth int
+}
+
+// Validate validates the options.
+func (o Options) Validate() error {
+	if o.PrefixDepth < 0 || o.PrefixDepth > MaxPrefixDepth {
+		return errors.NewWithDetails("prefix depth is out of range", "min", 0, "max", MaxPrefixDepth)
+	}
+
+	return nil
+}
+
+// ReferenceLister lists the clusters referencing buckets.
+type ReferenceLister interface {
+	// ListReferences lists the bucket references of the (current and deleted) clusters of an organization.
+	ListReferences(ctx context.Context, organizationID uint) ([]Reference, error)
+}
+
+// Service reports the storage used by managed buckets.
+type Service struct {
+	store      Store
+	references ReferenceLister
+}
+
+// NewService returns a new Service.
+func NewService(store Store, references ReferenceLister) Service {
+	return Service{
+		store:      store,
+		references: references,
+	}
+}
+
+type bucketKey struct {
+	cloud string
+	name  string
+}
+
+// GetBucketUsage returns the latest collected usage of the managed buckets of an organization
+// along with the clusters currently referencing them.
+// Buckets created since the last collection are not reported until the next one.
+func (s Service) GetBucketUsage(ctx context.Context, organizationID uint, options Options) ([]BucketUsage, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	snapshots, err := s.store.ListSnapshots(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	references, err := s.references.ListReferences(ctx, organizationID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list bucket references", "organizationId", organizationID)
+	}
+
+	bucketReferences := make(map[bucketKey][]Reference)
+	for _, reference := range references {
+		key := bucketKey{cloud: reference.Cloud, name: reference.BucketName}
+		bucketReferences[key] = append(bucketReferences[key], reference)
+	}
+
+	usages := make([]BucketUsage, 0, len(snapshots))
+
+	for _, snapshot := range snapshots {
+		usage := BucketUsage{
+			Cloud:       snapshot.Cloud,
+			Name:        snapshot.Name,
+			Location:    snapshot.Location,
+			Usage:       snapshot.Usage,
+			Prefixes:    objectstore.RollUpPrefixes(snapshot.Prefixes, prefixDelimiter, options.PrefixDepth),
+			References:  bucketReferences[bucketKey{cloud: snapshot.Cloud, name: snapshot.Name}],
+			Error:       snapshot.Error,
+			CollectedAt: snapshot.CollectedAt,
+		}
+
+		if usage.References == nil {
+			usage.References = []Reference{}
+		}
+
+		usage.Orphaned = isOrphaned(usage.References)
+
+		usages = append(usages, usage)
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Cloud != usages[j].Cloud {
+			return usages[i].Cloud < usages[j].Cloud
+		}
+
+		return usages[i].Name < usages[j].Name
+	})
+
+	return usages, nil
+}
+
+// isOrphaned checks whether every cluster referencing a bucket has been deleted.
+// Buckets without references are not considered orphaned: they might be used outside of Pipeline.
+func isOrphaned(references []Reference) bool {
+	if len(references) == 0 {
+		return false
+	}
+
+	for _, reference := range references {
+		if reference.Active {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,101 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bucketusage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+type referenceListerStub []Reference
+
+func (s referenceListerStub) ListReferences(_ context.Context, _ uint) ([]Reference, error) {
+	return s, nil
+}
+
+func TestService_GetBucketUsage(t *testing.T) {
+	collectedAt := time.Date(2020, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	store := storeStub{
+		1: {
+			{
+				Cloud:       "google",
+				Name:        "logs",
+				Location:    "europe",
+				Usage:       objectstore.Usage{ObjectCount: 3, TotalSize: 35},
+				PrefixDepth: 2,
+				Prefixes: []objectstore.PrefixUsage{
+					{Prefix: "logs/app", Usage: objectstore.Usage{ObjectCount: 2, TotalSize: 30}},
+					{Prefix: "logs/system", Usage: objectstore.Usage{ObjectCount: 1, TotalSize: 5}},
+				},
+				CollectedAt: collectedAt,
+			},
+			{Cloud: "amazon", Name: "backups", Location: "eu-west-1", Usage: objectstore.Usage{ObjectCount: 1, TotalSize: 100}, CollectedAt: collectedAt},
+			{Cloud: "amazon", Name: "unused", Location: "eu-west-1", CollectedAt: collectedAt},
+			{Cloud: "amazon", Name: "forbidden", Location: "eu-west-1", Error: "access denied", CollectedAt: collectedAt},
+		},
+	}
+
+	references := referenceListerStub{
+		{Cloud: "google", BucketName: "logs", ClusterID: 1, ClusterName: "cluster-1", Kind: ReferenceKindIntegratedService, IntegratedService: "logging", Active: true},
+		{Cloud: "google", BucketName: "logs", ClusterID: 2, ClusterName: "cluster-2", Kind: ReferenceKindIntegratedService, IntegratedService: "logging", Active: false},
+		{Cloud: "amazon", BucketName: "backups", ClusterID: 2, ClusterName: "cluster-2", Kind: ReferenceKindBackup, Active: false},
+		{Cloud: "google", BucketName: "backups", ClusterID: 1, ClusterName: "cluster-1", Kind: ReferenceKindBackup, Active: true},
+	}
+
+	service := NewService(store, references)
+
+	usages, err := service.GetBucketUsage(context.Background(), 1, Options{PrefixDepth: 1})
+	require.NoError(t, err)
+	require.Len(t, usages, 4)
+
+	backups, forbidden, unused, logs := usages[0], usages[1], usages[2], usages[3]
+
+	assert.Equal(t, "backups", backups.Name)
+	assert.Equal(t, objectstore.Usage{ObjectCount: 1, TotalSize: 100}, backups.Usage)
+	assert.True(t, backups.Orphaned)
+	assert.Len(t, backups.References, 1)
+	assert.Equal(t, collectedAt, backups.CollectedAt)
+
+	assert.Equal(t, "forbidden", forbidden.Name)
+	assert.Equal(t, "access denied", forbidden.Error)
+	assert.False(t, forbidden.Orphaned)
+
+	assert.Equal(t, "unused", unused.Name)
+	assert.Equal(t, objectstore.Usage{}, unused.Usage)
+	assert.Empty(t, unused.References)
+	assert.False(t, unused.Orphaned)
+
+	assert.Equal(t, "logs", logs.Name)
+	assert.Equal(t, objectstore.Usage{ObjectCount: 3, TotalSize: 35}, logs.Usage)
+	assert.Equal(t, []objectstore.PrefixUsage{
+		{Prefix: "logs", Usage: objectstore.Usage{ObjectCount: 3, TotalSize: 35}},
+	}, logs.Prefixes)
+	assert.Len(t, logs.References, 2)
+	assert.False(t, logs.Orphaned)
+}
+
+func TestService_GetBucketUsage_InvalidOptions(t *testing.T) {
+	service := NewService(storeStub{}, referenceListerStub{})
+
+	_, err := service.GetBucketUsage(context.Background(), 1, Options{PrefixDepth: MaxPrefixDepth + 1})
+	assert.Error(t, err)
+}
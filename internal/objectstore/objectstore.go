@@ -14,28 +14,42 @@
 
 package objectstore
 
+import (
+	commonObjectstore "github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
 // ObjectStoreService is the interface that cloud specific object store implementation
 // must implement
 type ObjectStoreService interface {
-	CreateBucket(string) error
+	// CreateBucket creates a managed bucket and applies the optional settings to it.
+	CreateBucket(string, *commonObjectstore.BucketSettings) error
 	ListBuckets() ([]*BucketInfo, error)
 	ListManagedBuckets() ([]*BucketInfo, error)
 	DeleteBucket(string) error
 	CheckBucket(string) error
+
+	// UpdateBucket applies lifecycle, versioning and encryption settings to a managed bucket.
+	UpdateBucket(string, commonObjectstore.BucketSettings) error
+
+	// WalkBucketObjects calls a function for every object (including its size) stored in a managed bucket.
+	WalkBucketObjects(string, func(commonObjectstore.ObjectInfo) error) error
 }
 
 // BucketInfo describes a storage bucket
 type BucketInfo struct {
-	Name            string                     `json:"name"  binding:"required"`
-	Managed         bool                       `json:"managed" binding:"required"`
-	Location        string                     `json:"location,omitempty"`
-	SecretRef       string                     `json:"secretId,omitempty"`
-	Cloud           string                     `json:"cloud,omitempty"`
-	Azure           *BlobStoragePropsForAzure  `json:"aks,omitempty"`
-	Oracle          *BlobStoragePropsForOracle `json:"oracle,omitempty"`
-	Status          string                     `json:"status,omitempty"`
-	StatusMsg       string                     `json:"statusMsg,omitempty"`
-	AccessSecretRef string                     `json:"accessSecretId,omitempty"`
+	Name            string                           `json:"name"  binding:"required"`
+	Managed         bool                             `json:"managed" binding:"required"`
+	Location        string                           `json:"location,omitempty"`
+	SecretRef       string                           `json:"secretId,omitempty"`
+	Cloud           string                           `json:"cloud,omitempty"`
+	Azure           *BlobStoragePropsForAzure        `json:"aks,omitempty"`
+	Oracle          *BlobStoragePropsForOracle       `json:"oracle,omitempty"`
+	S3Compatible    *BlobStoragePropsForS3Compatible `json:"s3compatible,omitempty"`
+	Status          string                           `json:"status,omitempty"`
+	StatusMsg       string                           `json:"statusMsg,omitempty"`
+	AccessSecretRef string                           `json:"accessSecretId,omitempty"`
+
+	Settings *commonObjectstore.BucketSettings `json:"settings,omitempty"`
 }
 
 // BlobStoragePropsForAzure describes the Azure specific properties
@@ -48,3 +62,8 @@ type BlobStoragePropsForAzure struct {
 type BlobStoragePropsForOracle struct {
 	Namespace string `json:"namespace"`
 }
+
+// BlobStoragePropsForS3Compatible describes the S3 compatible object store specific properties
+type BlobStoragePropsForS3Compatible struct {
+	Endpoint string `json:"endpoint"`
+}
@@ -19,6 +19,8 @@ import (
 	"strings"
 
 	"github.com/sirupsen/logrus"
+
+	pkgPke "github.com/banzaicloud/pipeline/pkg/cluster/pke"
 )
 
 const (
@@ -106,7 +108,10 @@ func MakeCRIPreparer(logger logrus.FieldLogger, namespace string) CRIPreparer {
 
 // Prepare validates and provides defaults for CRI fields
 func (p CRIPreparer) Prepare(c *CRI) error {
-	// TODO: implement CRI preparation
+	if err := pkgPke.Runtime(c.Runtime).Validate(); err != nil {
+		p.logger.Errorf("%s is invalid: %s", p.namespace, err)
+		return validationErrorf("invalid container runtime config: %s", err)
+	}
 	return nil
 }
 
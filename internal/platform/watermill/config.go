@@ -0,0 +1,59 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watermill
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// Supported pub/sub backends.
+const (
+	// MemoryBackend delivers messages in memory: they are lost on restart and never leave the process.
+	MemoryBackend = "memory"
+
+	// SQLBackend stores messages in the main database, so they survive restarts
+	// and can be consumed by any Pipeline process connected to the same database.
+	SQLBackend = "sql"
+)
+
+// PubSubConfig holds information necessary for creating publishers and subscribers.
+type PubSubConfig struct {
+	Backend string
+
+	SQL SQLConfig
+}
+
+// SQLConfig holds information for the SQL backend.
+type SQLConfig struct {
+	// Time to wait between two queries when a topic has no new messages
+	PollInterval time.Duration
+}
+
+// Validate checks that the configuration is valid.
+func (c PubSubConfig) Validate() error {
+	switch c.Backend {
+	case MemoryBackend:
+	case SQLBackend:
+		if c.SQL.PollInterval <= 0 {
+			return errors.New("event bus sql poll interval must be greater than zero")
+		}
+	default:
+		return errors.Errorf("unsupported event bus backend: %q", c.Backend)
+	}
+
+	return nil
+}
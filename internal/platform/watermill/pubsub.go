@@ -15,18 +15,88 @@
 package watermill
 
 import (
+	"emperror.dev/errors"
+	"github.com/ThreeDotsLabs/watermill"
+	watermillsql "github.com/ThreeDotsLabs/watermill-sql/pkg/sql"
 	"github.com/ThreeDotsLabs/watermill/message"
 	"github.com/ThreeDotsLabs/watermill/pubsub/gochannel"
+	"github.com/jinzhu/gorm"
 	watermilllog "logur.dev/integration/watermill"
 	"logur.dev/logur"
 )
 
-// NewPubSub returns a new PubSub.
-func NewPubSub(logger logur.Logger) (message.Publisher, message.Subscriber) {
-	pubsub := gochannel.NewGoChannel(
-		gochannel.Config{},
-		watermilllog.New(logur.WithFields(logger, map[string]interface{}{"component": "watermill"})),
+// SubscriberFactory returns a subscriber for a consumer group.
+//
+// Subscribers of the same consumer group share the messages of a topic:
+// every message is processed by only one of them (even across processes when the backend is durable).
+type SubscriberFactory func(consumerGroup string) (message.Subscriber, error)
+
+// NewPubSub returns a publisher and a subscriber factory for the configured backend.
+// The SQL backend stores messages in the database db is connected to.
+func NewPubSub(config PubSubConfig, db *gorm.DB, logger logur.Logger) (message.Publisher, SubscriberFactory, error) {
+	wlogger := watermilllog.New(logur.WithFields(logger, map[string]interface{}{"component": "watermill"}))
+
+	switch config.Backend {
+	case MemoryBackend:
+		pubsub := gochannel.NewGoChannel(gochannel.Config{}, wlogger)
+
+		return pubsub, func(string) (message.Subscriber, error) { return pubsub, nil }, nil
+
+	case SQLBackend:
+		return newSQLPubSub(config.SQL, db, wlogger)
+
+	default:
+		return nil, nil, errors.Errorf("unsupported event bus backend: %q", config.Backend)
+	}
+}
+
+func newSQLPubSub(config SQLConfig, db *gorm.DB, logger watermill.LoggerAdapter) (message.Publisher, SubscriberFactory, error) {
+	var schemaAdapter watermillsql.SchemaAdapter
+	var offsetsAdapter watermillsql.OffsetsAdapter
+
+	switch dialect := db.Dialect().GetName(); dialect {
+	case "mysql":
+		schemaAdapter = watermillsql.DefaultMySQLSchema{}
+		offsetsAdapter = watermillsql.DefaultMySQLOffsetsAdapter{}
+
+	case "postgres":
+		schemaAdapter = watermillsql.DefaultPostgreSQLSchema{}
+		offsetsAdapter = watermillsql.DefaultPostgreSQLOffsetsAdapter{}
+
+	default:
+		return nil, nil, errors.Errorf("unsupported event bus database dialect: %s", dialect)
+	}
+
+	publisher, err := watermillsql.NewPublisher(
+		db.DB(),
+		watermillsql.PublisherConfig{
+			SchemaAdapter:        schemaAdapter,
+			AutoInitializeSchema: true,
+		},
+		logger,
 	)
+	if err != nil {
+		return nil, nil, errors.WrapIf(err, "failed to create sql publisher")
+	}
+
+	subscriberFactory := func(consumerGroup string) (message.Subscriber, error) {
+		subscriber, err := watermillsql.NewSubscriber(
+			db.DB(),
+			watermillsql.SubscriberConfig{
+				ConsumerGroup:    consumerGroup,
+				PollInterval:     config.PollInterval,
+				SchemaAdapter:    schemaAdapter,
+				OffsetsAdapter:   offsetsAdapter,
+				InitializeSchema: true,
+			},
+			logger,
+		)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to create sql subscriber", "consumerGroup", consumerGroup)
+		}
+
+		return subscriber, nil
+	}
 
-	return pubsub, pubsub
+	return publisher, subscriberFactory, nil
 }
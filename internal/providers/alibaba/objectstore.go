@@ -42,6 +42,8 @@ func (bucketNotFoundError) NotFound() bool { return true }
 
 type alibabaObjectStore interface {
 	commonObjectstore.ObjectStore
+	commonObjectstore.BucketSettingsManager
+	commonObjectstore.ObjectInfoWalker
 	GetLocation(bucket string) (string, error)
 }
 
@@ -113,7 +115,7 @@ func (os *objectStore) getLogger() logrus.FieldLogger {
 	})
 }
 
-func (os *objectStore) CreateBucket(bucketName string) error {
+func (os *objectStore) CreateBucket(bucketName string, settings *commonObjectstore.BucketSettings) error {
 	logger := os.getLogger().WithField("bucket", bucketName)
 
 	bucket := &ObjectStoreBucketModel{}
@@ -146,6 +148,14 @@ func (os *objectStore) CreateBucket(bucketName string) error {
 		return os.createFailed(bucket, errors.WrapIf(err, "failed to create the bucket"))
 	}
 
+	if settings != nil {
+		if err := os.objectStore.SetBucketSettings(bucketName, *settings); err != nil {
+			return os.createFailed(bucket, errors.WrapIf(err, "failed to apply bucket settings"))
+		}
+
+		bucket.Settings = *settings
+	}
+
 	bucket.Status = providers.BucketCreated
 	bucket.StatusMsg = "bucket successfully created"
 	if err := os.db.Save(bucket).Error; err != nil {
@@ -213,6 +223,7 @@ func (os *objectStore) ListManagedBuckets() ([]*objectstore.BucketInfo, error) {
 
 	bucketList := make([]*objectstore.BucketInfo, 0)
 	for _, bucket := range alibabaBuckets {
+		settings := bucket.Settings
 		bucketList = append(bucketList, &objectstore.BucketInfo{
 			Cloud:     providers.Alibaba,
 			Managed:   true,
@@ -221,6 +232,7 @@ func (os *objectStore) ListManagedBuckets() ([]*objectstore.BucketInfo, error) {
 			SecretRef: bucket.SecretRef,
 			Status:    bucket.Status,
 			StatusMsg: bucket.StatusMsg,
+			Settings:  &settings,
 		})
 	}
 
@@ -284,6 +296,79 @@ func (os *objectStore) deleteFromProvider(bucket *ObjectStoreBucketModel) error
 	return nil
 }
 
+// UpdateBucket applies the given settings to the OSS bucket identified by the specified name
+// provided the storage container is of 'managed' type.
+func (os *objectStore) UpdateBucket(bucketName string, settings commonObjectstore.BucketSettings) error {
+	logger := os.getLogger().WithField("bucket", bucketName)
+
+	bucket := &ObjectStoreBucketModel{}
+	searchCriteria := os.newBucketSearchCriteria(bucketName)
+
+	logger.Info("looking up the bucket...")
+
+	if err := os.db.Where(searchCriteria).Find(bucket).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return bucketNotFoundError{}
+		}
+		return errors.WrapIfWithDetails(err, "failed to lookup", "bucket", bucketName)
+	}
+
+	if bucket.Status != providers.BucketCreated {
+		return errors.WithDetails(errors.New("bucket is not in a state that allows updating it"), "bucket", bucketName, "status", bucket.Status)
+	}
+
+	objectStore, err := getProviderObjectStore(os.secret, bucket.Region)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to create object store", "bucket", bucketName)
+	}
+
+	logger.Info("updating bucket settings...")
+
+	if err := objectStore.SetBucketSettings(bucketName, settings); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to update bucket settings on provider", "bucket", bucketName)
+	}
+
+	bucket.Settings = settings
+	if err := os.db.Save(bucket).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to save bucket", "bucket", bucketName)
+	}
+	logger.Info("bucket settings updated")
+
+	return nil
+}
+
+// WalkBucketObjects calls fn for every object (including its size) stored in the managed OSS bucket.
+func (os *objectStore) WalkBucketObjects(bucketName string, fn func(commonObjectstore.ObjectInfo) error) error {
+	logger := os.getLogger().WithField("bucket", bucketName)
+
+	bucket := &ObjectStoreBucketModel{}
+	searchCriteria := os.newBucketSearchCriteria(bucketName)
+
+	logger.Info("looking up the bucket...")
+
+	if err := os.db.Where(searchCriteria).Find(bucket).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return bucketNotFoundError{}
+		}
+		return errors.WrapIfWithDetails(err, "failed to lookup", "bucket", bucketName)
+	}
+
+	if bucket.Status != providers.BucketCreated {
+		return errors.WithDetails(errors.New("bucket is not in a state that allows listing its objects"), "bucket", bucketName, "status", bucket.Status)
+	}
+
+	objectStore, err := getProviderObjectStore(os.secret, bucket.Region)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to create object store", "bucket", bucketName)
+	}
+
+	if err := objectStore.WalkObjectInfos(bucketName, "", fn); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to list bucket objects on provider", "bucket", bucketName)
+	}
+
+	return nil
+}
+
 func (os *objectStore) CheckBucket(bucketName string) error {
 	logger := os.getLogger().WithField("bucket", bucketName)
 	logger.Info("looking up the bucket...")
@@ -15,6 +15,7 @@
 package alibaba
 
 import (
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
 	"github.com/banzaicloud/pipeline/src/auth"
 )
 
@@ -34,6 +35,8 @@ type ObjectStoreBucketModel struct {
 
 	Status    string
 	StatusMsg string `sql:"type:text;"`
+
+	Settings objectstore.BucketSettings `gorm:"type:json"`
 }
 
 // TableName changes the default table name.
@@ -38,6 +38,8 @@ func (bucketNotFoundError) NotFound() bool { return true }
 
 type amazonObjectStore interface {
 	commonObjectstore.ObjectStore
+	commonObjectstore.BucketSettingsManager
+	commonObjectstore.ObjectInfoWalker
 	GetRegion(bucket string) (string, error)
 }
 
@@ -122,7 +124,7 @@ func (s *objectStore) getLogger() logrus.FieldLogger {
 }
 
 // CreateBucket creates an S3 bucket with the provided name.
-func (s *objectStore) CreateBucket(bucketName string) error {
+func (s *objectStore) CreateBucket(bucketName string, settings *commonObjectstore.BucketSettings) error {
 	logger := s.getLogger().WithField("bucket", bucketName)
 
 	bucket := &ObjectStoreBucketModel{}
@@ -156,6 +158,14 @@ func (s *objectStore) CreateBucket(bucketName string) error {
 		return s.createFailed(bucket, errors.WrapIf(err, "failed to create the bucket"))
 	}
 
+	if settings != nil {
+		if err := s.objectStore.SetBucketSettings(bucketName, *settings); err != nil {
+			return s.createFailed(bucket, errors.WrapIf(err, "failed to apply bucket settings"))
+		}
+
+		bucket.Settings = *settings
+	}
+
 	bucket.Status = providers.BucketCreated
 	bucket.StatusMsg = "bucket successfully created"
 	if err := s.db.Save(bucket).Error; err != nil {
@@ -245,6 +255,79 @@ func (s *objectStore) deleteFailed(bucket *ObjectStoreBucketModel, reason error)
 	return reason
 }
 
+// UpdateBucket applies the given settings to the S3 bucket identified by the specified name
+// provided the storage container is of 'managed' type.
+func (s *objectStore) UpdateBucket(bucketName string, settings commonObjectstore.BucketSettings) error {
+	logger := s.getLogger().WithField("bucket", bucketName)
+
+	bucket := &ObjectStoreBucketModel{}
+	searchCriteria := s.searchCriteria(bucketName)
+
+	logger.Info("looking up the bucket...")
+
+	if err := s.db.Where(searchCriteria).Find(bucket).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return bucketNotFoundError{}
+		}
+		return errors.WrapIfWithDetails(err, "failed to lookup", "bucket", bucketName)
+	}
+
+	if bucket.Status != providers.BucketCreated {
+		return errors.WithDetails(errors.New("bucket is not in a state that allows updating it"), "bucket", bucketName, "status", bucket.Status)
+	}
+
+	objectStore, err := getProviderObjectStore(s.secret, bucket.Region)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to create object store", "bucket", bucketName)
+	}
+
+	logger.Info("updating bucket settings...")
+
+	if err := objectStore.SetBucketSettings(bucketName, settings); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to update bucket settings on provider", "bucket", bucketName)
+	}
+
+	bucket.Settings = settings
+	if err := s.db.Save(bucket).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to save bucket", "bucket", bucketName)
+	}
+	logger.Info("bucket settings updated")
+
+	return nil
+}
+
+// WalkBucketObjects calls fn for every object (including its size) stored in the managed S3 bucket.
+func (s *objectStore) WalkBucketObjects(bucketName string, fn func(commonObjectstore.ObjectInfo) error) error {
+	logger := s.getLogger().WithField("bucket", bucketName)
+
+	bucket := &ObjectStoreBucketModel{}
+	searchCriteria := s.searchCriteria(bucketName)
+
+	logger.Info("looking up the bucket...")
+
+	if err := s.db.Where(searchCriteria).Find(bucket).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return bucketNotFoundError{}
+		}
+		return errors.WrapIfWithDetails(err, "failed to lookup", "bucket", bucketName)
+	}
+
+	if bucket.Status != providers.BucketCreated {
+		return errors.WithDetails(errors.New("bucket is not in a state that allows listing its objects"), "bucket", bucketName, "status", bucket.Status)
+	}
+
+	objectStore, err := getProviderObjectStore(s.secret, bucket.Region)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to create object store", "bucket", bucketName)
+	}
+
+	if err := objectStore.WalkObjectInfos(bucketName, "", fn); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to list bucket objects on provider", "bucket", bucketName)
+	}
+
+	return nil
+}
+
 // CheckBucket checks the status of the given S3 bucket.
 func (s *objectStore) CheckBucket(bucketName string) error {
 	logger := s.getLogger().WithField("bucket", bucketName)
@@ -306,6 +389,7 @@ func (s *objectStore) ListManagedBuckets() ([]*objectstore.BucketInfo, error) {
 
 	bucketList := make([]*objectstore.BucketInfo, 0)
 	for _, bucket := range amazonBuckets {
+		settings := bucket.Settings
 		bucketList = append(bucketList, &objectstore.BucketInfo{
 			Name:      bucket.Name,
 			Managed:   true,
@@ -314,6 +398,7 @@ func (s *objectStore) ListManagedBuckets() ([]*objectstore.BucketInfo, error) {
 			Cloud:     providers.Amazon,
 			Status:    bucket.Status,
 			StatusMsg: bucket.StatusMsg,
+			Settings:  &settings,
 		})
 	}
 
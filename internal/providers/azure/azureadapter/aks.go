@@ -16,6 +16,8 @@ package azureadapter
 
 import (
 	"time"
+
+	"github.com/banzaicloud/pipeline/pkg/common"
 )
 
 // AKSClusterModel describes the aks cluster model
@@ -45,6 +47,7 @@ type AKSNodePoolModel struct {
 	NodeInstanceType string
 	VNetSubnetID     string
 	Labels           map[string]string `gorm:"-"`
+	Taints           common.Taints     `gorm:"type:text"`
 }
 
 // TableName sets AzureNodePoolModel's table name
@@ -47,6 +47,8 @@ const defaultResourceGroupName = "pipelinegenresourcegroup"
 
 type azureObjectStore interface {
 	commonObjectstore.ObjectStore
+	commonObjectstore.BucketSettingsManager
+	commonObjectstore.ObjectInfoWalker
 }
 
 type bucketNotFoundError struct{}
@@ -182,7 +184,7 @@ func getCredentials(secret *secret.SecretItemResponse) azure.Credentials {
 
 // CreateBucket creates an Azure Object Store Blob with the provided name
 // within a generated/provided ResourceGroup and StorageAccount
-func (s *ObjectStore) CreateBucket(bucketName string) error {
+func (s *ObjectStore) CreateBucket(bucketName string, settings *commonObjectstore.BucketSettings) error {
 	logger := s.getLogger().WithField("bucket", bucketName)
 
 	bucket := &ObjectStoreBucketModel{}
@@ -238,6 +240,14 @@ func (s *ObjectStore) CreateBucket(bucketName string) error {
 	}
 	logger.WithField("acc-secret-name", accSecretName).Info("secret created/updated")
 
+	if settings != nil {
+		if err := s.objectStore.SetBucketSettings(bucketName, *settings); err != nil {
+			return s.createFailed(bucket, errors.WrapIf(err, "failed to apply bucket settings"))
+		}
+
+		bucket.Settings = *settings
+	}
+
 	bucket.Status = providers.BucketCreated
 	bucket.AccessSecretRef = accSecretId
 	bucket.StatusMsg = "bucket successfully created"
@@ -347,6 +357,69 @@ func (s *ObjectStore) deleteFromProvider(bucket *ObjectStoreBucketModel) error {
 	return nil
 }
 
+// UpdateBucket applies the given settings to the Azure storage container identified by the specified name
+// under the current resource group, storage account provided the storage container is of 'managed' type.
+func (s *ObjectStore) UpdateBucket(bucketName string, settings commonObjectstore.BucketSettings) error {
+	logger := s.getLogger().WithField("bucket", bucketName)
+
+	bucket := &ObjectStoreBucketModel{}
+	searchCriteria := s.searchCriteria(bucketName)
+
+	logger.Info("looking up the bucket...")
+
+	if err := s.db.Where(searchCriteria).Find(bucket).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return bucketNotFoundError{}
+		}
+		return errors.WrapIfWithDetails(err, "failed to lookup", "bucket", bucketName)
+	}
+
+	if bucket.Status != providers.BucketCreated {
+		return errors.WithDetails(errors.New("bucket is not in a state that allows updating it"), "bucket", bucketName, "status", bucket.Status)
+	}
+
+	logger.Info("updating bucket settings...")
+
+	if err := s.objectStore.SetBucketSettings(bucketName, settings); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to update bucket settings on provider", "bucket", bucketName)
+	}
+
+	bucket.Settings = settings
+	if err := s.db.Save(bucket).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to save bucket", "bucket", bucketName)
+	}
+	logger.Info("bucket settings updated")
+
+	return nil
+}
+
+// WalkBucketObjects calls fn for every object (including its size) stored in the managed Azure storage container.
+func (s *ObjectStore) WalkBucketObjects(bucketName string, fn func(commonObjectstore.ObjectInfo) error) error {
+	logger := s.getLogger().WithField("bucket", bucketName)
+
+	bucket := &ObjectStoreBucketModel{}
+	searchCriteria := s.searchCriteria(bucketName)
+
+	logger.Info("looking up the bucket...")
+
+	if err := s.db.Where(searchCriteria).Find(bucket).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return bucketNotFoundError{}
+		}
+		return errors.WrapIfWithDetails(err, "failed to lookup", "bucket", bucketName)
+	}
+
+	if bucket.Status != providers.BucketCreated {
+		return errors.WithDetails(errors.New("bucket is not in a state that allows listing its objects"), "bucket", bucketName, "status", bucket.Status)
+	}
+
+	if err := s.objectStore.WalkObjectInfos(bucketName, "", fn); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to list bucket objects on provider", "bucket", bucketName)
+	}
+
+	return nil
+}
+
 // CheckBucket checks the status of the given Azure blob.
 func (s *ObjectStore) CheckBucket(bucketName string) error {
 	logger := s.getLogger().WithField("bucket", bucketName)
@@ -498,6 +571,7 @@ func (s *ObjectStore) ListManagedBuckets() ([]*objectstore.BucketInfo, error) {
 
 	bucketList := make([]*objectstore.BucketInfo, 0)
 	for _, bucket := range azureBuckets {
+		settings := bucket.Settings
 		bucketList = append(bucketList, &objectstore.BucketInfo{
 			Name:            bucket.Name,
 			Managed:         true,
@@ -511,6 +585,7 @@ func (s *ObjectStore) ListManagedBuckets() ([]*objectstore.BucketInfo, error) {
 				ResourceGroup:  bucket.ResourceGroup,
 				StorageAccount: bucket.StorageAccount,
 			},
+			Settings: &settings,
 		})
 	}
 
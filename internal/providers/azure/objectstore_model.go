@@ -15,6 +15,7 @@
 package azure
 
 import (
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
 	"github.com/banzaicloud/pipeline/src/auth"
 )
 
@@ -39,6 +40,8 @@ type ObjectStoreBucketModel struct {
 	Status          string
 	StatusMsg       string `sql:"type:text;"`
 	AccessSecretRef string
+
+	Settings objectstore.BucketSettings `gorm:"type:json"`
 }
 
 // TableName changes the default table name.
@@ -27,6 +27,7 @@ import (
 	"github.com/banzaicloud/pipeline/internal/database/sql/json"
 	intPKE "github.com/banzaicloud/pipeline/internal/pke"
 	"github.com/banzaicloud/pipeline/internal/providers/azure/pke"
+	providerPKE "github.com/banzaicloud/pipeline/internal/providers/pke"
 	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
 )
 
@@ -61,6 +62,7 @@ type clusterModel struct {
 
 	Cluster   clustermodel.ClusterModel `gorm:"foreignkey:ClusterID"`
 	NodePools []nodePoolModel           `gorm:"foreignkey:ClusterID;association_foreignkey:ClusterID"`
+	CRI       providerPKE.CRI           `gorm:"foreignkey:ClusterID;association_foreignkey:ClusterID"`
 
 	AccessPoints          accessPointsModel          `gorm:"type:json"`
 	ApiServerAccessPoints apiServerAccessPointsModel `gorm:"type:json"`
@@ -276,6 +278,9 @@ func (m clusterModel) intoEntity(e *pke.Cluster) {
 	e.Kubernetes.Version = m.KubernetesVersion
 	e.ActiveWorkflowID = m.ActiveWorkflowID
 
+	e.Kubernetes.CRI.Runtime = string(m.CRI.Runtime)
+	e.Kubernetes.CRI.RuntimeConfig = m.CRI.RuntimeConfig
+
 	e.HTTPProxy = m.HTTPProxy.toEntity()
 	e.AccessPoints = m.AccessPoints.toEntity()
 	e.APIServerAccessPoints = m.ApiServerAccessPoints.toEntity()
@@ -383,6 +388,12 @@ func (s ClusterStore) Create(params pke.CreateParams) (pke.Cluster, error) {
 	model.AccessPoints.fromEntity(params.AccessPoints)
 	model.ApiServerAccessPoints.fromEntity(params.APIServerAccessPoints)
 
+	model.CRI = providerPKE.CRI{
+		Runtime:       providerPKE.Runtime(params.CRI.Runtime),
+		RuntimeConfig: params.CRI.RuntimeConfig,
+	}
+	model.CRI.CreatedBy = params.CreatedBy
+
 	if err := getError(s.db.Preload("Cluster").Preload("NodePools").Create(&model), "failed to create cluster model"); err != nil {
 		return pke.Cluster{}, err
 	}
@@ -433,7 +444,7 @@ func (s ClusterStore) GetByID(clusterID uint) (pke.Cluster, error) {
 		ClusterID: clusterID,
 	}
 
-	if err := getError(s.db.Preload("Cluster").Preload("NodePools").Where(&model).First(&model), "failed to load model from database"); err != nil {
+	if err := getError(s.db.Preload("Cluster").Preload("NodePools").Preload("CRI").Where(&model).First(&model), "failed to load model from database"); err != nil {
 		return pke.Cluster{}, err
 	}
 
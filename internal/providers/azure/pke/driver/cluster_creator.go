@@ -223,6 +223,7 @@ func (cc ClusterCreator) Create(ctx context.Context, params ClusterCreationParam
 		HTTPProxy:             params.HTTPProxy,
 		AccessPoints:          params.AccessPoints,
 		APIServerAccessPoints: params.APIServerAccessPoints,
+		CRI:                   params.Kubernetes.CRI,
 	}
 	cl, err = cc.store.Create(createParams)
 	if err != nil {
@@ -268,6 +269,7 @@ func (cc ClusterCreator) Create(ctx context.Context, params ClusterCreationParam
 		ClusterName:                 cl.Name,
 		KubernetesVersion:           cl.Kubernetes.Version,
 		KubernetesNetworkProvider:   params.Kubernetes.Network.Provider,
+		CRI:                         cl.Kubernetes.CRI,
 		Location:                    cl.Location,
 		NoProxy:                     strings.Join(cl.HTTPProxy.Exceptions, ","),
 		OrganizationID:              cl.OrganizationID,
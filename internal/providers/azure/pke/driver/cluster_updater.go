@@ -152,6 +152,7 @@ func (cu ClusterUpdater) Update(ctx context.Context, params ClusterUpdateParams)
 			SSHPublicKey:                sshKeyPair.PublicKeyData,
 			TenantID:                    tenantID,
 			VirtualNetworkName:          cluster.VirtualNetwork.Name,
+			CRI:                         cluster.Kubernetes.CRI,
 		}
 
 		for i, np := range nodePoolsToCreate {
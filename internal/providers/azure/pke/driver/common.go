@@ -26,6 +26,7 @@ import (
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 
+	intPKE "github.com/banzaicloud/pipeline/internal/pke"
 	"github.com/banzaicloud/pipeline/internal/providers/azure/pke"
 	"github.com/banzaicloud/pipeline/internal/providers/azure/pke/workflow"
 	pkgPKE "github.com/banzaicloud/pipeline/pkg/cluster/pke"
@@ -51,6 +52,7 @@ type nodePoolTemplateFactory struct {
 	OIDCIssuerURL               string
 	NoProxy                     string
 	KubernetesNetworkProvider   string
+	CRI                         intPKE.CRI
 }
 
 func (f nodePoolTemplateFactory) getTemplates(np NodePool) (workflow.VirtualMachineScaleSetTemplate, workflow.SubnetTemplate, []workflow.RoleAssignmentTemplate) {
@@ -99,6 +101,11 @@ func (f nodePoolTemplateFactory) getTemplates(np NodePool) (workflow.VirtualMach
 		}
 	}
 
+	if f.CRI.Runtime != "" {
+		userDataScriptTemplate += ` \
+--kubernetes-container-runtime={{ .ContainerRuntime }}`
+	}
+
 	if np.hasRole(pkgPKE.RolePipelineSystem) {
 		if !f.SingleNodePool {
 			taints = fmt.Sprintf("%s=%s:%s", pkgCommon.NodePoolNameTaintKey, np.Name, corev1.TaintEffectPreferNoSchedule)
@@ -158,6 +165,7 @@ func (f nodePoolTemplateFactory) getTemplates(np NodePool) (workflow.VirtualMach
 				"HttpsProxy":                "<not yet set>",
 				"NoProxy":                   f.NoProxy,
 				"KubernetesNetworkProvider": f.KubernetesNetworkProvider,
+				"ContainerRuntime":          f.CRI.Runtime,
 			},
 			UserDataScriptTemplate: userDataScriptTemplate,
 			Zones:                  np.Zones,
@@ -38,6 +38,7 @@ type CreateParams struct {
 	HTTPProxy             intPKE.HTTPProxy
 	AccessPoints          AccessPoints
 	APIServerAccessPoints APIServerAccessPoints
+	CRI                   intPKE.CRI
 }
 
 // ClusterStore defines behaviors of Cluster persistent storage
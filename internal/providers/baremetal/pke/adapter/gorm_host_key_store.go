@@ -0,0 +1,83 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke"
+)
+
+const HostKeysTableName = "baremetal_pke_host_keys"
+
+type hostKeyModel struct {
+	ID        uint   `gorm:"primary_key"`
+	ClusterID uint   `gorm:"unique_index:idx_baremetal_pke_hk_cluster_id_address"`
+	Address   string `gorm:"unique_index:idx_baremetal_pke_hk_cluster_id_address"`
+	HostKey   string `gorm:"type:text"`
+}
+
+func (hostKeyModel) TableName() string {
+	return HostKeysTableName
+}
+
+type gormHostKeyStore struct {
+	db *gorm.DB
+}
+
+// NewHostKeyStore returns a new HostKeyStore backed by the database
+func NewHostKeyStore(db *gorm.DB) pke.HostKeyStore {
+	return gormHostKeyStore{
+		db: db,
+	}
+}
+
+func (s gormHostKeyStore) PinHostKey(clusterID uint, address string, hostKey string) (string, error) {
+	if err := validateClusterID(clusterID); err != nil {
+		return "", errors.WrapIf(err, "invalid cluster ID")
+	}
+
+	model := hostKeyModel{
+		ClusterID: clusterID,
+		Address:   address,
+	}
+	err := s.db.Where(model).Attrs(hostKeyModel{HostKey: hostKey}).FirstOrCreate(&model).Error
+	if err != nil {
+		// a concurrent connection may have pinned a key in the meantime
+		model = hostKeyModel{
+			ClusterID: clusterID,
+			Address:   address,
+		}
+		if err := getError(s.db.Where(model).First(&model), "failed to pin host key"); err != nil {
+			return "", err
+		}
+	}
+
+	return model.HostKey, nil
+}
+
+func (s gormHostKeyStore) DeleteHostKey(clusterID uint, address string) error {
+	if err := validateClusterID(clusterID); err != nil {
+		return errors.WrapIf(err, "invalid cluster ID")
+	}
+
+	model := hostKeyModel{
+		ClusterID: clusterID,
+		Address:   address,
+	}
+
+	return getError(s.db.Where(model).Delete(hostKeyModel{}), "failed to delete host key")
+}
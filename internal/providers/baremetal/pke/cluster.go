@@ -0,0 +1,52 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pke
+
+import (
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterbase"
+	intPKE "github.com/banzaicloud/pipeline/internal/pke"
+)
+
+const PKEOnBaremetal = "pke-on-baremetal"
+
+// Host represents a pre-provisioned machine reachable over SSH
+type Host struct {
+	// Name is the hostname of the machine, it becomes the name of the Kubernetes node
+	Name string
+	// Address is the host[:port] the machine can be reached on over SSH
+	Address   string
+	PrivateIP string
+}
+
+type NodePool struct {
+	CreatedBy uint
+	Name      string
+	Roles     []string
+	Hosts     []Host
+}
+
+type PKEOnBaremetalCluster struct {
+	clusterbase.ClusterBase
+
+	NodePools        []NodePool
+	APIServerAddress string
+	Kubernetes       intPKE.Kubernetes
+	ActiveWorkflowID string
+	HTTPProxy        intPKE.HTTPProxy
+}
+
+func (c PKEOnBaremetalCluster) HasActiveWorkflow() bool {
+	return c.ActiveWorkflowID != ""
+}
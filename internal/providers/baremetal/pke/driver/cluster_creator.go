@@ -0,0 +1,332 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/client"
+	corev1 "k8s.io/api/core/v1"
+
+	intPKE "github.com/banzaicloud/pipeline/internal/pke"
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke"
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/driver/commoncluster"
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/workflow"
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	pkgPKE "github.com/banzaicloud/pipeline/pkg/cluster/pke"
+	"github.com/banzaicloud/pipeline/src/auth"
+	"github.com/banzaicloud/pipeline/src/cluster"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+const pkeVersion = "0.4.14"
+const MasterNodeTaint = pkgPKE.TaintKeyMaster + ":" + string(corev1.TaintEffectNoSchedule)
+
+func MakeBaremetalPKEClusterCreator(
+	logger Logger,
+	config ClusterCreatorConfig,
+	k8sPreparer intPKE.KubernetesPreparer,
+	organizations OrganizationStore,
+	secrets ClusterCreatorSecretStore,
+	store pke.ClusterStore,
+	workflowClient client.Client,
+) BaremetalPKEClusterCreator {
+	return BaremetalPKEClusterCreator{
+		logger:           logger,
+		config:           config,
+		creationPreparer: MakeBaremetalPKEClusterCreationParamsPreparer(logger, k8sPreparer),
+		organizations:    organizations,
+		secrets:          secrets,
+		store:            store,
+		workflowClient:   workflowClient,
+	}
+}
+
+// BaremetalPKEClusterCreator creates new PKE clusters on existing hosts
+type BaremetalPKEClusterCreator struct {
+	logger           Logger
+	config           ClusterCreatorConfig
+	creationPreparer BaremetalPKEClusterCreationParamsPreparer
+	organizations    OrganizationStore
+	secrets          ClusterCreatorSecretStore
+	store            pke.ClusterStore
+	workflowClient   client.Client
+}
+
+type OrganizationStore interface {
+	Get(ctx context.Context, id uint) (auth.Organization, error)
+}
+
+type ClusterCreatorSecretStore interface {
+	Get(organizationID uint, secretID string) (*secret.SecretItemResponse, error)
+	GetByName(organizationID uint, secretName string) (*secret.SecretItemResponse, error)
+}
+
+type ClusterCreatorConfig struct {
+	OIDCIssuerURL               string
+	PipelineExternalURL         string
+	PipelineExternalURLInsecure bool
+}
+
+type NodePool struct {
+	CreatedBy uint
+	Name      string
+	Roles     []string
+	Labels    map[string]string
+	Hosts     []pke.Host
+}
+
+func (np NodePool) hasRole(role pkgPKE.Role) bool {
+	return hasRole(np.Roles, role)
+}
+
+func hasRole(roles []string, role pkgPKE.Role) bool {
+	for _, r := range roles {
+		if r == string(role) {
+			return true
+		}
+	}
+	return false
+}
+
+func (np NodePool) toPke() (pnp pke.NodePool) {
+	pnp.CreatedBy = np.CreatedBy
+	pnp.Name = np.Name
+	pnp.Roles = np.Roles
+	pnp.Hosts = np.Hosts
+	return
+}
+
+// BaremetalPKEClusterCreationParams defines parameters for PKE-on-Baremetal cluster creation
+type BaremetalPKEClusterCreationParams struct {
+	CreatedBy        uint
+	Name             string
+	NodePools        []NodePool
+	OrganizationID   uint
+	ScaleOptions     pkgCluster.ScaleOptions
+	SecretID         string
+	HTTPProxy        intPKE.HTTPProxy
+	APIServerAddress string
+	Kubernetes       intPKE.Kubernetes
+}
+
+// Create
+func (cc BaremetalPKEClusterCreator) Create(ctx context.Context, params BaremetalPKEClusterCreationParams) (cl pke.PKEOnBaremetalCluster, err error) {
+	sshSecret, err := cc.secrets.Get(params.OrganizationID, params.SecretID)
+	if err = errors.WrapIf(err, "failed to get secret"); err != nil {
+		return
+	}
+	if err = secret.ValidateSecretType(sshSecret, secrettype.SSHSecretType); err != nil {
+		return
+	}
+
+	if err = cc.creationPreparer.Prepare(ctx, &params); err != nil {
+		return
+	}
+
+	nodePools := make([]pke.NodePool, len(params.NodePools))
+	for i, np := range params.NodePools {
+		nodePools[i] = np.toPke()
+	}
+	createParams := pke.CreateParams{
+		Name:             params.Name,
+		OrganizationID:   params.OrganizationID,
+		CreatedBy:        params.CreatedBy,
+		SecretID:         params.SecretID,
+		RBAC:             params.Kubernetes.RBAC,
+		OIDC:             params.Kubernetes.OIDC.Enabled,
+		ScaleOptions:     params.ScaleOptions,
+		NodePools:        nodePools,
+		HTTPProxy:        params.HTTPProxy,
+		APIServerAddress: params.APIServerAddress,
+		Kubernetes:       params.Kubernetes,
+	}
+	cl, err = cc.store.Create(createParams)
+	if err != nil {
+		return
+	}
+
+	tf := nodeTemplateFactory{
+		ClusterID:                   cl.ID,
+		ClusterName:                 cl.Name,
+		KubernetesVersion:           cl.Kubernetes.Version,
+		NoProxy:                     strings.Join(cl.HTTPProxy.Exceptions, ","),
+		OrganizationID:              cl.OrganizationID,
+		PipelineExternalURL:         cc.config.PipelineExternalURL,
+		PipelineExternalURLInsecure: cc.config.PipelineExternalURLInsecure,
+		SingleNodePool:              len(cl.NodePools) == 1,
+		APIServerAddress:            cl.APIServerAddress,
+		CRI:                         cl.Kubernetes.CRI,
+		MasterCount:                 getMasterCount(params.NodePools),
+	}
+
+	if cl.Kubernetes.OIDC.Enabled {
+		tf.OIDCIssuerURL = cc.config.OIDCIssuerURL
+		tf.OIDCClientID = cl.UID
+	}
+
+	var nodes []workflow.Node
+	for _, np := range params.NodePools {
+		for _, host := range np.Hosts {
+			nodes = append(nodes, tf.getNode(np, host))
+		}
+	}
+
+	org, err := cc.organizations.Get(ctx, params.OrganizationID)
+	if err != nil {
+		return cl, errors.WrapIf(err, "failed to get organization")
+	}
+
+	var labelsMap map[string]map[string]string
+	{
+		var commonCluster cluster.CommonCluster
+		commonCluster, err = commoncluster.MakeCommonClusterGetter(cc.secrets, cc.store).GetByID(cl.ID)
+		if err != nil {
+			_ = cc.handleError(cl.ID, err)
+			return
+		}
+
+		nodePoolLabels := make([]cluster.NodePoolLabels, 0)
+		for _, np := range params.NodePools {
+			nodePoolLabels = append(nodePoolLabels, cluster.NodePoolLabels{
+				NodePoolName: np.Name,
+				Existing:     false,
+				CustomLabels: np.Labels,
+			})
+		}
+
+		labelsMap, err = cluster.GetDesiredLabelsForCluster(ctx, commonCluster, nodePoolLabels)
+		if err != nil {
+			_ = cc.handleError(cl.ID, err)
+			return
+		}
+	}
+
+	input := workflow.CreateClusterWorkflowInput{
+		ClusterID:        cl.ID,
+		ClusterName:      cl.Name,
+		ClusterUID:       cl.UID,
+		OrganizationID:   org.ID,
+		OrganizationName: org.Name,
+		SecretID:         params.SecretID,
+		OIDCEnabled:      cl.Kubernetes.OIDC.Enabled,
+		Nodes:            nodes,
+		HTTPProxy:        cl.HTTPProxy,
+		NodePoolLabels:   labelsMap,
+	}
+	workflowOptions := client.StartWorkflowOptions{
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 2 * time.Hour, // installation over SSH is slower than booting prepared images
+	}
+
+	wfexec, err := cc.workflowClient.StartWorkflow(ctx, workflowOptions, workflow.CreateClusterWorkflowName, input)
+	if err != nil {
+		_ = cc.handleError(cl.ID, err)
+		return
+	}
+
+	if err = cc.store.SetActiveWorkflowID(cl.ID, wfexec.ID); err != nil {
+		_ = cc.handleError(cl.ID, err)
+		return
+	}
+
+	return
+}
+
+func (cc BaremetalPKEClusterCreator) handleError(clusterID uint, err error) error {
+	return handleClusterError(cc.logger, cc.store, pkgCluster.Error, clusterID, err)
+}
+
+// BaremetalPKEClusterCreationParamsPreparer implements BaremetalPKEClusterCreationParams preparation
+type BaremetalPKEClusterCreationParamsPreparer struct {
+	k8sPreparer intPKE.KubernetesPreparer
+	logger      Logger
+}
+
+// MakeBaremetalPKEClusterCreationParamsPreparer returns an instance of BaremetalPKEClusterCreationParamsPreparer
+func MakeBaremetalPKEClusterCreationParamsPreparer(logger Logger, k8sPreparer intPKE.KubernetesPreparer) BaremetalPKEClusterCreationParamsPreparer {
+	return BaremetalPKEClusterCreationParamsPreparer{
+		k8sPreparer: k8sPreparer,
+		logger:      logger,
+	}
+}
+
+// Prepare validates and provides defaults for BaremetalPKEClusterCreationParams fields
+func (p BaremetalPKEClusterCreationParamsPreparer) Prepare(ctx context.Context, params *BaremetalPKEClusterCreationParams) error {
+	if params.Name == "" {
+		return validationErrorf("Name cannot be empty")
+	}
+	if params.OrganizationID == 0 {
+		return validationErrorf("OrganizationID cannot be 0")
+	}
+
+	_, err := auth.GetOrganizationById(params.OrganizationID)
+	if err != nil {
+		return validationErrorf("OrganizationID cannot be found %s", err.Error())
+	}
+
+	// TODO check creator user exists if present
+	if params.SecretID == "" {
+		return validationErrorf("SecretID cannot be empty")
+	}
+
+	if err := p.k8sPreparer.Prepare(&params.Kubernetes); err != nil {
+		return errors.WrapIf(err, "failed to prepare k8s network")
+	}
+
+	nodePoolsPreparer := NodePoolsPreparer{
+		logger:       p.logger,
+		namespace:    "NodePools",
+		dataProvider: clusterCreatorNodePoolPreparerDataProvider{},
+	}
+	if err := nodePoolsPreparer.Prepare(ctx, params.NodePools); err != nil {
+		return errors.WrapIf(err, "failed to prepare node pools")
+	}
+
+	var masters []pke.Host
+	for _, np := range params.NodePools {
+		if np.hasRole(pkgPKE.RoleMaster) {
+			masters = append(masters, np.Hosts...)
+		}
+	}
+
+	switch {
+	case len(masters) == 0:
+		return validationErrorf("NodePools must contain a master node pool")
+	case params.APIServerAddress != "":
+	case len(masters) > 1:
+		return validationErrorf("APIServerAddress must be specified for clusters with multiple master hosts")
+	default:
+		params.APIServerAddress = masters[0].PrivateIP
+		p.logger.Debug("APIServerAddress not specified, defaulting to " + params.APIServerAddress)
+	}
+
+	return nil
+}
+
+type clusterCreatorNodePoolPreparerDataProvider struct {
+}
+
+func (p clusterCreatorNodePoolPreparerDataProvider) getExistingNodePools(ctx context.Context) ([]pke.NodePool, error) {
+	return nil, nil
+}
+
+func (p clusterCreatorNodePoolPreparerDataProvider) getExistingNodePoolByName(ctx context.Context, nodePoolName string) (pke.NodePool, error) {
+	return pke.NodePool{}, notExistsYetError{}
+}
@@ -0,0 +1,333 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/client"
+
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke"
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/driver/commoncluster"
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/workflow"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	pkgPKE "github.com/banzaicloud/pipeline/pkg/cluster/pke"
+	"github.com/banzaicloud/pipeline/src/cluster"
+)
+
+// ClusterUpdater adds and removes hosts and node pools of PKE-on-Baremetal clusters
+type ClusterUpdater struct {
+	logger         Logger
+	config         ClusterCreatorConfig
+	paramsPreparer ClusterUpdateParamsPreparer
+	secrets        ClusterCreatorSecretStore
+	store          pke.ClusterStore
+	workflowClient client.Client
+}
+
+// MakeClusterUpdater returns a new ClusterUpdater
+func MakeClusterUpdater(
+	logger Logger,
+	config ClusterCreatorConfig,
+	secrets ClusterCreatorSecretStore,
+	store pke.ClusterStore,
+	workflowClient client.Client,
+) ClusterUpdater {
+	return ClusterUpdater{
+		logger: logger,
+		config: config,
+		paramsPreparer: ClusterUpdateParamsPreparer{
+			logger: logger,
+			store:  store,
+		},
+		secrets:        secrets,
+		store:          store,
+		workflowClient: workflowClient,
+	}
+}
+
+// ClusterUpdateParams defines parameters for PKE-on-Baremetal cluster update
+type ClusterUpdateParams struct {
+	ClusterID uint
+	NodePools []NodePool
+}
+
+// Update adds and removes the hosts and node pools of a cluster
+func (cu ClusterUpdater) Update(ctx context.Context, params ClusterUpdateParams) error {
+	logger := cu.logger.WithFields(map[string]interface{}{"clusterID": params.ClusterID})
+
+	logger.Info("updating cluster")
+
+	if err := cu.paramsPreparer.Prepare(ctx, &params); err != nil {
+		return errors.WrapIf(err, "params preparation failed")
+	}
+
+	cl, err := cu.store.GetByID(params.ClusterID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to get cluster by ID")
+	}
+
+	nodePoolsToCreate, nodePoolsToUpdate, nodePoolsToDelete := sortNodePools(params.NodePools, cl.NodePools)
+
+	commonCluster, err := commoncluster.MakeCommonClusterGetter(cu.secrets, cu.store).GetByID(cl.ID)
+	if err != nil {
+		return errors.WrapIf(err, "failed to get baremetal PKE common cluster by ID")
+	}
+
+	existingNodePools := make(map[string]pke.NodePool, len(cl.NodePools))
+	for _, np := range cl.NodePools {
+		existingNodePools[np.Name] = np
+	}
+
+	tf := nodeTemplateFactory{
+		ClusterID:                   cl.ID,
+		ClusterName:                 cl.Name,
+		KubernetesVersion:           cl.Kubernetes.Version,
+		NoProxy:                     strings.Join(cl.HTTPProxy.Exceptions, ","),
+		OrganizationID:              cl.OrganizationID,
+		PipelineExternalURL:         cu.config.PipelineExternalURL,
+		PipelineExternalURLInsecure: cu.config.PipelineExternalURLInsecure,
+		SingleNodePool:              len(params.NodePools) == 1,
+		APIServerAddress:            cl.APIServerAddress,
+		CRI:                         cl.Kubernetes.CRI,
+	}
+
+	var nodesToCreate []workflow.Node
+	var nodesToDelete []workflow.Node
+	nodePoolLabels := make([]cluster.NodePoolLabels, 0)
+	nodePoolHosts := make(map[string][]pke.Host)
+
+	for _, np := range nodePoolsToCreate {
+		if err := cu.store.CreateNodePool(cl.ID, np.toPke()); err != nil {
+			return errors.WrapIfWithDetails(err, "failed to store new node pool", "clusterID", cl.ID, "nodepool", np.Name)
+		}
+
+		for _, host := range np.Hosts {
+			nodesToCreate = append(nodesToCreate, tf.getNode(np, host))
+		}
+
+		nodePoolLabels = append(nodePoolLabels, cluster.NodePoolLabels{
+			NodePoolName: np.Name,
+			Existing:     false,
+			CustomLabels: np.Labels,
+		})
+	}
+
+	for _, np := range nodePoolsToUpdate {
+		addedHosts, removedHosts := sortHosts(np.Hosts, existingNodePools[np.Name].Hosts)
+
+		if len(addedHosts) > 0 {
+			// added hosts are persisted right away, so that a failed update leaves no untracked nodes behind
+			hosts := append(append([]pke.Host{}, existingNodePools[np.Name].Hosts...), addedHosts...)
+			if err := cu.store.SetNodePoolHosts(cl.ID, np.Name, hosts); err != nil {
+				return errors.WrapIfWithDetails(err, "failed to store updated node pool", "clusterID", cl.ID, "nodepool", np.Name)
+			}
+
+			for _, host := range addedHosts {
+				nodesToCreate = append(nodesToCreate, tf.getNode(np, host))
+			}
+		}
+
+		if len(removedHosts) > 0 {
+			// removed hosts will only be persisted by the workflow once they are uninstalled
+			nodePoolHosts[np.Name] = np.Hosts
+
+			for _, host := range removedHosts {
+				nodesToDelete = append(nodesToDelete, getRemovedNode(np.Name, host))
+			}
+		}
+
+		nodePoolLabels = append(nodePoolLabels, cluster.NodePoolLabels{
+			NodePoolName: np.Name,
+			Existing:     true,
+			CustomLabels: np.Labels,
+		})
+	}
+
+	nodePoolNamesToDelete := make([]string, len(nodePoolsToDelete))
+	for i, np := range nodePoolsToDelete {
+		nodePoolNamesToDelete[i] = np.Name
+
+		for _, host := range np.Hosts {
+			nodesToDelete = append(nodesToDelete, getRemovedNode(np.Name, host))
+		}
+		// will only be persisted by the successful workflow
+	}
+
+	labels, err := cluster.GetDesiredLabelsForCluster(ctx, commonCluster, nodePoolLabels)
+	if err != nil {
+		return errors.WrapIf(err, "failed to get desired labels for cluster")
+	}
+
+	input := workflow.UpdateClusterWorkflowInput{
+		ClusterID:        cl.ID,
+		ClusterName:      cl.Name,
+		OrganizationID:   cl.OrganizationID,
+		SecretID:         cl.SecretID,
+		ConfigSecretID:   cl.K8sSecretID,
+		HTTPProxy:        cl.HTTPProxy,
+
+		NodesToCreate:     nodesToCreate,
+		NodesToDelete:     nodesToDelete,
+		NodePoolsToDelete: nodePoolNamesToDelete,
+		NodePoolHosts:     nodePoolHosts,
+
+		Labels: labels,
+	}
+
+	if err := cu.store.SetStatus(cl.ID, pkgCluster.Updating, pkgCluster.UpdatingMessage); err != nil {
+		return errors.WrapIf(err, "failed to set cluster status")
+	}
+
+	workflowOptions := client.StartWorkflowOptions{
+		TaskList:                     "pipeline",
+		ExecutionStartToCloseTimeout: 2 * time.Hour, // installation over SSH is slower than booting prepared images
+	}
+
+	wfexec, err := cu.workflowClient.StartWorkflow(ctx, workflowOptions, workflow.UpdateClusterWorkflowName, input)
+	if err := errors.WrapIfWithDetails(err, "failed to start workflow", "workflow", workflow.UpdateClusterWorkflowName); err != nil {
+		_ = cu.handleError(cl.ID, err)
+		return err
+	}
+
+	if err := cu.store.SetActiveWorkflowID(cl.ID, wfexec.ID); err != nil {
+		err = errors.WrapIfWithDetails(err, "failed to set active workflow ID", "clusterID", cl.ID, "workflowID", wfexec.ID)
+		_ = cu.handleError(cl.ID, err)
+		return err
+	}
+
+	return nil
+}
+
+func (cu ClusterUpdater) handleError(clusterID uint, err error) error {
+	return handleClusterError(cu.logger, cu.store, pkgCluster.Warning, clusterID, err)
+}
+
+func getRemovedNode(nodePoolName string, host pke.Host) workflow.Node {
+	return workflow.Node{
+		Name:         host.Name,
+		Address:      host.Address,
+		NodePoolName: nodePoolName,
+	}
+}
+
+func sortNodePools(incoming []NodePool, existing []pke.NodePool) (toCreate, toUpdate []NodePool, toDelete []pke.NodePool) {
+	existingSet := make(map[string]pke.NodePool)
+	for _, np := range existing {
+		existingSet[np.Name] = np
+	}
+	for _, np := range incoming {
+		if _, ok := existingSet[np.Name]; ok {
+			delete(existingSet, np.Name)
+			toUpdate = append(toUpdate, np)
+		} else {
+			toCreate = append(toCreate, np)
+		}
+	}
+	toDelete = make([]pke.NodePool, 0, len(existingSet))
+	for _, np := range existingSet {
+		toDelete = append(toDelete, np)
+	}
+	return
+}
+
+func sortHosts(incoming []pke.Host, existing []pke.Host) (added, removed []pke.Host) {
+	existingSet := make(map[string]bool, len(existing))
+	for _, host := range existing {
+		existingSet[host.Name] = true
+	}
+	incomingSet := make(map[string]bool, len(incoming))
+	for _, host := range incoming {
+		incomingSet[host.Name] = true
+		if !existingSet[host.Name] {
+			added = append(added, host)
+		}
+	}
+	for _, host := range existing {
+		if !incomingSet[host.Name] {
+			removed = append(removed, host)
+		}
+	}
+	return
+}
+
+// ClusterUpdateParamsPreparer implements ClusterUpdateParams preparation
+type ClusterUpdateParamsPreparer struct {
+	logger Logger
+	store  pke.ClusterStore
+}
+
+// Prepare validates and provides defaults for ClusterUpdateParams fields
+func (p ClusterUpdateParamsPreparer) Prepare(ctx context.Context, params *ClusterUpdateParams) error {
+	if params.ClusterID == 0 {
+		return validationErrorf("ClusterID cannot be 0")
+	}
+	cl, err := p.store.GetByID(params.ClusterID)
+	if pke.IsNotFound(err) {
+		return validationErrorf("ClusterID must refer to an existing cluster")
+	} else if err != nil {
+		return errors.WrapIf(err, "failed to get cluster by ID")
+	}
+
+	dataProvider := clusterUpdaterNodePoolPreparerDataProvider{
+		cluster: cl,
+	}
+
+	nodePoolsPreparer := NodePoolsPreparer{
+		logger:       p.logger,
+		namespace:    "NodePools",
+		dataProvider: dataProvider,
+	}
+	if err := nodePoolsPreparer.Prepare(ctx, params.NodePools); err != nil {
+		return errors.WrapIf(err, "failed to prepare node pools")
+	}
+
+	incoming := make(map[string]bool, len(params.NodePools))
+	for _, np := range params.NodePools {
+		incoming[np.Name] = true
+
+		if _, err := dataProvider.getExistingNodePoolByName(ctx, np.Name); pke.IsNotFound(err) && np.hasRole(pkgPKE.RoleMaster) {
+			return validationErrorf("new node pool %q cannot have the master role", np.Name)
+		}
+	}
+
+	for _, np := range cl.NodePools {
+		if !incoming[np.Name] && hasRole(np.Roles, pkgPKE.RoleMaster) {
+			return validationErrorf("master node pool %q cannot be removed", np.Name)
+		}
+	}
+
+	return nil
+}
+
+type clusterUpdaterNodePoolPreparerDataProvider struct {
+	cluster pke.PKEOnBaremetalCluster
+}
+
+func (p clusterUpdaterNodePoolPreparerDataProvider) getExistingNodePools(ctx context.Context) ([]pke.NodePool, error) {
+	return p.cluster.NodePools, nil
+}
+
+func (p clusterUpdaterNodePoolPreparerDataProvider) getExistingNodePoolByName(ctx context.Context, nodePoolName string) (pke.NodePool, error) {
+	for _, np := range p.cluster.NodePools {
+		if np.Name == nodePoolName {
+			return np, nil
+		}
+	}
+	return pke.NodePool{}, notExistsYetError{}
+}
@@ -0,0 +1,192 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	intPKE "github.com/banzaicloud/pipeline/internal/pke"
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke"
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/workflow"
+	pkgPKE "github.com/banzaicloud/pipeline/pkg/cluster/pke"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+)
+
+type Logger = common.Logger
+
+type nodeTemplateFactory struct {
+	ClusterID                   uint
+	ClusterName                 string
+	KubernetesVersion           string
+	OrganizationID              uint
+	PipelineExternalURL         string
+	PipelineExternalURLInsecure bool
+	SingleNodePool              bool
+	APIServerAddress            string
+	OIDCClientID                string
+	OIDCIssuerURL               string
+	NoProxy                     string
+	CRI                         intPKE.CRI
+	MasterCount                 int
+}
+
+func (f nodeTemplateFactory) getNode(np NodePool, host pke.Host) workflow.Node {
+	node := workflow.Node{
+		Name:                  host.Name,
+		Address:               host.Address,
+		NodePoolName:          np.Name,
+		Master:                np.hasRole(pkgPKE.RoleMaster),
+		InstallScriptTemplate: workerInstallScriptTemplate,
+	}
+
+	k8sMasterMode := "default"
+	taints := ""
+
+	if np.hasRole(pkgPKE.RoleMaster) {
+		if f.SingleNodePool {
+			taints = "," // do not taint single node pool cluster's master node
+		} else {
+			taints = MasterNodeTaint
+		}
+
+		node.InstallScriptTemplate = masterInstallScriptTemplate
+
+		// TODO use templating
+		if f.OIDCIssuerURL != "" {
+			node.InstallScriptTemplate += fmt.Sprintf(` \
+--kubernetes-oidc-issuer-url=%q \
+--kubernetes-oidc-client-id=%q`,
+				f.OIDCIssuerURL,
+				f.OIDCClientID,
+			)
+		}
+
+		if f.MasterCount > 1 {
+			k8sMasterMode = "ha"
+		}
+	}
+
+	if f.CRI.Runtime != "" {
+		node.InstallScriptTemplate += ` \
+--kubernetes-container-runtime={{ .ContainerRuntime }}`
+	}
+
+	if np.hasRole(pkgPKE.RolePipelineSystem) {
+		if !f.SingleNodePool {
+			taints = fmt.Sprintf("%s=%s:%s", pkgCommon.NodePoolNameTaintKey, np.Name, corev1.TaintEffectPreferNoSchedule)
+		}
+	}
+
+	// HttpProxy settings will be set in workflow
+	node.InstallScriptParams = map[string]string{
+		"ClusterID":            strconv.FormatUint(uint64(f.ClusterID), 10),
+		"ClusterName":          f.ClusterName,
+		"NodePoolName":         np.Name,
+		"Taints":               taints,
+		"OrgID":                strconv.FormatUint(uint64(f.OrganizationID), 10),
+		"PipelineURL":          f.PipelineExternalURL,
+		"PipelineURLInsecure":  strconv.FormatBool(f.PipelineExternalURLInsecure),
+		"PipelineToken":        "<not yet set>",
+		"PKEVersion":           pkeVersion,
+		"KubernetesVersion":    f.KubernetesVersion,
+		"KubernetesMasterMode": k8sMasterMode,
+		"NoProxy":              f.NoProxy,
+		"ContainerRuntime":     f.CRI.Runtime,
+		"PrivateIP":            host.PrivateIP,
+		"PublicAddress":        f.APIServerAddress,
+	}
+	return node
+}
+
+func getMasterCount(nodePools []NodePool) int {
+	var count int
+	for _, np := range nodePools {
+		if np.hasRole(pkgPKE.RoleMaster) {
+			count += len(np.Hosts)
+		}
+	}
+	return count
+}
+
+func handleClusterError(logger Logger, store pke.ClusterStore, status string, clusterID uint, err error) error {
+	if clusterID != 0 && err != nil {
+		if err := store.SetStatus(clusterID, status, err.Error()); err != nil {
+			logger.Error("failed to set cluster error status: " + err.Error())
+		}
+	}
+	return err
+}
+
+type notExistsYetError struct{}
+
+func (notExistsYetError) Error() string {
+	return "this resource does not exist yet"
+}
+
+func (notExistsYetError) NotFound() bool {
+	return true
+}
+
+const masterInstallScriptTemplate = `#!/bin/sh
+set -e
+
+export HTTP_PROXY="{{ .HttpProxy }}"
+export HTTPS_PROXY="{{ .HttpsProxy }}"
+export NO_PROXY="{{ .NoProxy }}"
+
+curl -fsSL --retry 10 --retry-delay 10 https://banzaicloud.com/downloads/pke/pke-{{ .PKEVersion }} -o /usr/local/bin/pke
+chmod +x /usr/local/bin/pke
+export PATH=$PATH:/usr/local/bin/
+
+pke install master --pipeline-url="{{ .PipelineURL }}" \
+--pipeline-insecure="{{ .PipelineURLInsecure }}" \
+--pipeline-token="{{ .PipelineToken }}" \
+--pipeline-org-id={{ .OrgID }} \
+--pipeline-cluster-id={{ .ClusterID}} \
+--kubernetes-cluster-name={{ .ClusterName }} \
+--pipeline-nodepool={{ .NodePoolName }} \
+--taints={{ .Taints }} \
+--kubernetes-advertise-address={{ .PrivateIP }}:6443 \
+--kubernetes-api-server={{ .PublicAddress }}:6443 \
+--kubernetes-infrastructure-cidr={{ .PrivateIP }}/32 \
+--kubernetes-version={{ .KubernetesVersion }} \
+--kubernetes-master-mode={{ .KubernetesMasterMode }} \
+--kubernetes-api-server-cert-sans="{{ .PublicAddress }}"`
+
+const workerInstallScriptTemplate = `#!/bin/sh
+set -e
+
+export HTTP_PROXY="{{ .HttpProxy }}"
+export HTTPS_PROXY="{{ .HttpsProxy }}"
+export NO_PROXY="{{ .NoProxy }}"
+
+curl -fsSL --retry 10 --retry-delay 10 https://banzaicloud.com/downloads/pke/pke-{{ .PKEVersion }} -o /usr/local/bin/pke
+chmod +x /usr/local/bin/pke
+export PATH=$PATH:/usr/local/bin/
+
+pke install worker --pipeline-url="{{ .PipelineURL }}" \
+--pipeline-insecure="{{ .PipelineURLInsecure }}" \
+--pipeline-token="{{ .PipelineToken }}" \
+--pipeline-org-id={{ .OrgID }} \
+--pipeline-cluster-id={{ .ClusterID}} \
+--pipeline-nodepool={{ .NodePoolName }} \
+--taints={{ .Taints }} \
+--kubernetes-api-server={{ .PublicAddress }}:6443 \
+--kubernetes-infrastructure-cidr={{ .PrivateIP }}/32 \
+--kubernetes-version={{ .KubernetesVersion }}`
@@ -0,0 +1,297 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package commoncluster
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke"
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	"github.com/banzaicloud/pipeline/src/auth"
+	"github.com/banzaicloud/pipeline/src/secret"
+)
+
+type BaremetalPkeCluster struct {
+	model   pke.PKEOnBaremetalCluster
+	secrets SecretStore
+	store   pke.ClusterStore
+}
+
+type SecretStore interface {
+	Get(organizationID uint, secretID string) (*secret.SecretItemResponse, error)
+	GetByName(organizationID uint, secretName string) (*secret.SecretItemResponse, error)
+}
+
+type CommonClusterGetter struct {
+	secrets SecretStore
+	store   pke.ClusterStore
+}
+
+func MakeCommonClusterGetter(secrets SecretStore, store pke.ClusterStore) CommonClusterGetter {
+	return CommonClusterGetter{
+		secrets: secrets,
+		store:   store,
+	}
+}
+
+func (g CommonClusterGetter) GetByID(clusterID uint) (*BaremetalPkeCluster, error) {
+	model, err := g.store.GetByID(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := BaremetalPkeCluster{
+		model:   model,
+		secrets: g.secrets,
+		store:   g.store,
+	}
+
+	return &cluster, nil
+}
+
+func (a *BaremetalPkeCluster) GetID() uint {
+	return a.model.ID
+}
+
+func (a *BaremetalPkeCluster) GetUID() string {
+	return a.model.UID
+}
+
+func (a *BaremetalPkeCluster) GetOrganizationId() uint {
+	return a.model.OrganizationID
+}
+
+func (a *BaremetalPkeCluster) GetName() string {
+	return a.model.Name
+}
+
+func (a *BaremetalPkeCluster) GetCloud() string {
+	return pkgCluster.Baremetal
+}
+
+func (a *BaremetalPkeCluster) GetDistribution() string {
+	return pkgCluster.PKE
+}
+
+func (a *BaremetalPkeCluster) GetLocation() string {
+	return "n/a"
+}
+
+func (a *BaremetalPkeCluster) GetCreatedBy() uint {
+	return a.model.CreatedBy
+}
+
+func (a *BaremetalPkeCluster) GetSecretId() string {
+	return a.model.SecretID
+}
+
+func (a *BaremetalPkeCluster) GetSshSecretId() string {
+	return a.model.SSHSecretID
+}
+
+func (a *BaremetalPkeCluster) SaveSshSecretId(string) error {
+	return errors.New("BaremetalPkeCluster.SaveSshSecretId is not implemented")
+}
+
+func (a *BaremetalPkeCluster) SaveConfigSecretId(secretID string) error {
+	a.model.K8sSecretID = secretID
+	return a.store.SetConfigSecretID(a.model.ID, secretID)
+}
+
+func (a *BaremetalPkeCluster) GetConfigSecretId() string {
+	return a.model.K8sSecretID
+}
+
+func (a *BaremetalPkeCluster) GetSecretWithValidation() (*secret.SecretItemResponse, error) {
+	return a.secrets.Get(a.model.OrganizationID, a.model.SecretID)
+}
+
+func (a *BaremetalPkeCluster) Persist() error {
+	return errors.New("BaremetalPkeCluster.Persist is not implemented")
+}
+
+func (a *BaremetalPkeCluster) DeleteFromDatabase() error {
+	return errors.New("BaremetalPkeCluster.DeleteFromDatabase is not implemented")
+}
+
+func (a *BaremetalPkeCluster) CreateCluster() error {
+	return errors.New("BaremetalPkeCluster.CreateCluster is not implemented")
+}
+
+func (a *BaremetalPkeCluster) ValidateCreationFields(r *pkgCluster.CreateClusterRequest) error {
+	return errors.New("BaremetalPkeCluster.ValidateCreationFields is not implemented")
+}
+
+func (a *BaremetalPkeCluster) UpdateCluster(*pkgCluster.UpdateClusterRequest, uint) error {
+	return errors.New("BaremetalPkeCluster.UpdateCluster is not implemented")
+}
+
+func (a *BaremetalPkeCluster) UpdateNodePools(*pkgCluster.UpdateNodePoolsRequest, uint) error {
+	return errors.New("BaremetalPkeCluster.UpdateNodePools is not implemented")
+}
+
+func (a *BaremetalPkeCluster) CheckEqualityToUpdate(*pkgCluster.UpdateClusterRequest) error {
+	return errors.New("BaremetalPkeCluster.CheckEqualityToUpdate is not implemented")
+}
+
+func (a *BaremetalPkeCluster) AddDefaultsToUpdate(*pkgCluster.UpdateClusterRequest) {
+}
+
+func (a *BaremetalPkeCluster) DeleteCluster() error {
+	return errors.New("BaremetalPkeCluster.DeleteCluster is not implemented")
+}
+
+func (a *BaremetalPkeCluster) GetScaleOptions() *pkgCluster.ScaleOptions {
+	return nil
+}
+
+func (a *BaremetalPkeCluster) SetScaleOptions(*pkgCluster.ScaleOptions) {
+}
+
+func (a *BaremetalPkeCluster) GetAPIEndpoint() (string, error) {
+	config, err := a.GetK8sConfig()
+	if err != nil {
+		return "", errors.WrapIf(err, "failed to get cluster's Kubeconfig")
+	}
+
+	return pkgCluster.GetAPIEndpointFromKubeconfig(config)
+}
+
+func (a *BaremetalPkeCluster) GetK8sConfig() ([]byte, error) {
+	if a.model.K8sSecretID == "" {
+		return nil, errors.New("there is no K8s config for the cluster")
+	}
+	configSecret, err := a.secrets.Get(a.model.OrganizationID, a.model.K8sSecretID)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get config from Vault")
+	}
+	configStr, err := base64.StdEncoding.DecodeString(configSecret.Values[secrettype.K8SConfig])
+	if err != nil {
+		return nil, errors.Wrap(err, "can't decode Kubernetes config")
+	}
+	return configStr, nil
+}
+
+func (a *BaremetalPkeCluster) GetK8sUserConfig() ([]byte, error) {
+	return a.GetK8sConfig()
+}
+
+func (a *BaremetalPkeCluster) RequiresSshPublicKey() bool {
+	return false
+}
+
+func (a *BaremetalPkeCluster) RbacEnabled() bool {
+	return a.model.Kubernetes.RBAC
+}
+
+func (a *BaremetalPkeCluster) NeedAdminRights() bool {
+	return false
+}
+
+func (a *BaremetalPkeCluster) GetKubernetesUserName() (string, error) {
+	return "", errors.New("BaremetalPkeCluster.GetKubernetesUserName is not implemented")
+}
+
+func (a *BaremetalPkeCluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
+	nodePools := make(map[string]*pkgCluster.NodePoolStatus)
+	for _, np := range a.model.NodePools {
+		nodePools[np.Name] = &pkgCluster.NodePoolStatus{
+			Count: len(np.Hosts),
+		}
+	}
+
+	return &pkgCluster.GetClusterStatusResponse{
+		Status:        a.model.Status,
+		StatusMessage: a.model.StatusMessage,
+		Name:          a.model.Name,
+		Location:      a.GetLocation(),
+		Region:        a.GetLocation(),
+		Cloud:         a.GetCloud(),
+		Distribution:  a.GetDistribution(),
+		ResourceID:    a.model.ID,
+		Version:       a.model.Kubernetes.Version,
+		NodePools:     nodePools,
+		CreatorBaseFields: pkgCommon.CreatorBaseFields{
+			CreatedAt:   a.model.CreationTime,
+			CreatorName: auth.GetUserNickNameById(a.model.CreatedBy),
+			CreatorId:   a.model.CreatedBy,
+		}}, nil
+}
+
+func (a *BaremetalPkeCluster) IsReady() (bool, error) {
+	if a.model.SecretID == "" {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (a *BaremetalPkeCluster) NodePoolExists(nodePoolName string) bool {
+	for _, np := range a.model.NodePools {
+		if np.Name == nodePoolName {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *BaremetalPkeCluster) SetStatus(status string, statusMessage string) error {
+	return a.store.SetStatus(a.model.ID, status, statusMessage)
+}
+
+// non-commoncluster methods
+
+// HasK8sConfig returns true if the cluster's k8s config is available
+func (a *BaremetalPkeCluster) HasK8sConfig() (bool, error) {
+	config, err := a.GetK8sConfig()
+	return len(config) > 0, err
+}
+
+func (a *BaremetalPkeCluster) IsMasterReady() (bool, error) {
+	return a.HasK8sConfig()
+}
+
+func (a *BaremetalPkeCluster) GetCurrentWorkflowID() string {
+	return a.model.ActiveWorkflowID
+}
+
+func (a *BaremetalPkeCluster) GetCAHash() (string, error) {
+	secret, err := a.secrets.GetByName(a.GetOrganizationId(), fmt.Sprintf("cluster-%d-ca", a.GetID()))
+	if err != nil {
+		return "", err
+	}
+	crt := secret.Values[secrettype.KubernetesCACert]
+	block, _ := pem.Decode([]byte(crt))
+	if block == nil {
+		return "", errors.New("failed to parse certificate")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", errors.WrapIff(err, "failed to parse certificate")
+	}
+	h := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return fmt.Sprintf("sha256:%s", hex.EncodeToString(h[:])), nil
+}
+
+func (a *BaremetalPkeCluster) GetPKEOnBaremetalCluster() pke.PKEOnBaremetalCluster {
+	return a.model
+}
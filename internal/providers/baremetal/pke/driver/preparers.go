@@ -0,0 +1,221 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driver
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke"
+	pkgPKE "github.com/banzaicloud/pipeline/pkg/cluster/pke"
+)
+
+// NodePoolsPreparer implements []NodePool preparation
+type NodePoolsPreparer struct {
+	logger       Logger
+	namespace    string
+	dataProvider nodePoolsDataProvider
+}
+
+type nodePoolsDataProvider interface {
+	getExistingNodePools(ctx context.Context) ([]pke.NodePool, error)
+	getExistingNodePoolByName(ctx context.Context, nodePoolName string) (pke.NodePool, error)
+}
+
+func (p NodePoolsPreparer) getNodePoolPreparer(i int) NodePoolPreparer {
+	return NodePoolPreparer{
+		logger:       p.logger,
+		namespace:    fmt.Sprintf("%s[%d]", p.namespace, i),
+		dataProvider: p.dataProvider,
+	}
+}
+
+// Prepare validates and provides defaults for a set of NodePools
+func (p NodePoolsPreparer) Prepare(ctx context.Context, nodePools []NodePool) error {
+	// check incoming node pool list item uniqueness
+	{
+		names := make(map[string]bool)
+		for _, np := range nodePools {
+			if names[np.Name] {
+				return validationErrorf("multiple node pools named %q", np.Name)
+			}
+			names[np.Name] = true
+		}
+	}
+
+	for i := range nodePools {
+		np := &nodePools[i]
+
+		if err := p.getNodePoolPreparer(i).Prepare(ctx, np); err != nil {
+			return errors.WrapIf(err, "failed to prepare node pool")
+		}
+	}
+
+	// a host can only be part of the cluster once
+	{
+		names := make(map[string]bool)
+		addresses := make(map[string]bool)
+		for _, np := range nodePools {
+			for _, host := range np.Hosts {
+				if names[host.Name] {
+					return validationErrorf("multiple hosts named %q", host.Name)
+				}
+				names[host.Name] = true
+
+				address := getHostAddress(host.Address)
+				if addresses[address] {
+					return validationErrorf("multiple hosts with address %q", host.Address)
+				}
+				addresses[address] = true
+			}
+		}
+	}
+
+	return nil
+}
+
+// NodePoolPreparer implements NodePool preparation
+type NodePoolPreparer struct {
+	logger       Logger
+	namespace    string
+	dataProvider interface {
+		getExistingNodePoolByName(ctx context.Context, nodePoolName string) (pke.NodePool, error)
+	}
+}
+
+// Prepare validates and provides defaults for NodePool fields
+func (p NodePoolPreparer) Prepare(ctx context.Context, nodePool *NodePool) error {
+	if nodePool == nil {
+		return nil
+	}
+
+	if nodePool.Name == "" {
+		return validationErrorf("%s.Name must be specified", p.namespace)
+	}
+
+	if len(nodePool.Hosts) == 0 {
+		return validationErrorf("%s.Hosts must contain at least one host", p.namespace)
+	}
+
+	for i := range nodePool.Hosts {
+		if err := p.prepareHost(fmt.Sprintf("%s.Hosts[%d]", p.namespace, i), &nodePool.Hosts[i]); err != nil {
+			return err
+		}
+	}
+
+	np, err := p.dataProvider.getExistingNodePoolByName(ctx, nodePool.Name)
+	if pke.IsNotFound(err) {
+		return p.prepareNewNodePool(ctx, nodePool)
+	} else if err != nil {
+		return errors.WrapIf(err, "failed to get node pool by name")
+	}
+
+	return p.prepareExistingNodePool(ctx, nodePool, np)
+}
+
+func (p NodePoolPreparer) prepareHost(namespace string, host *pke.Host) error {
+	if host.Name == "" {
+		return validationErrorf("%s.Name must be specified", namespace)
+	}
+
+	if host.Address == "" {
+		return validationErrorf("%s.Address must be specified", namespace)
+	}
+
+	if host.PrivateIP == "" {
+		address := getHostAddress(host.Address)
+		if net.ParseIP(address) == nil {
+			return validationErrorf("%s.PrivateIP must be specified if the address is not an IP address", namespace)
+		}
+
+		host.PrivateIP = address
+		p.logger.Debug(fmt.Sprintf("%s.PrivateIP not specified, defaulting to %s", namespace, host.PrivateIP))
+	} else if net.ParseIP(host.PrivateIP) == nil {
+		return validationErrorf("%s.PrivateIP must be a valid IP address", namespace)
+	}
+
+	return nil
+}
+
+func (p NodePoolPreparer) prepareNewNodePool(ctx context.Context, nodePool *NodePool) error {
+	if len(nodePool.Roles) == 0 {
+		nodePool.Roles = []string{"worker"}
+		p.logger.Debug(fmt.Sprintf("%s.Roles not specified, defaulting to %v", p.namespace, nodePool.Roles))
+	}
+
+	return nil
+}
+
+func (p NodePoolPreparer) prepareExistingNodePool(ctx context.Context, nodePool *NodePool, existing pke.NodePool) error {
+	nodePool.CreatedBy = existing.CreatedBy
+	nodePool.Roles = existing.Roles
+
+	existingHosts := make(map[string]pke.Host, len(existing.Hosts))
+	for _, host := range existing.Hosts {
+		existingHosts[host.Name] = host
+	}
+
+	for _, host := range nodePool.Hosts {
+		existingHost, ok := existingHosts[host.Name]
+		if !ok {
+			if nodePool.hasRole(pkgPKE.RoleMaster) {
+				return validationErrorf("%s.Hosts cannot be changed for the master node pool", p.namespace)
+			}
+			continue
+		}
+
+		if host.Address != existingHost.Address || host.PrivateIP != existingHost.PrivateIP {
+			return validationErrorf("%s.Hosts cannot change the address of host %q", p.namespace, host.Name)
+		}
+	}
+
+	if nodePool.hasRole(pkgPKE.RoleMaster) && len(nodePool.Hosts) != len(existing.Hosts) {
+		return validationErrorf("%s.Hosts cannot be changed for the master node pool", p.namespace)
+	}
+
+	return nil
+}
+
+// getHostAddress returns the host part of an SSH address
+func getHostAddress(address string) string {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+	return address
+}
+
+type validationError struct {
+	msg string
+}
+
+func validationErrorf(msg string, args ...interface{}) validationError {
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	return validationError{
+		msg: msg,
+	}
+}
+
+func (e validationError) Error() string {
+	return e.msg
+}
+
+func (e validationError) InputValidationError() bool {
+	return true
+}
@@ -0,0 +1,68 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pke
+
+import (
+	"emperror.dev/errors"
+
+	intPKE "github.com/banzaicloud/pipeline/internal/pke"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+type CreateParams struct {
+	Name              string
+	OrganizationID    uint
+	CreatedBy         uint
+	SecretID          string
+	RBAC              bool
+	OIDC              bool
+	KubernetesVersion string
+	ScaleOptions      pkgCluster.ScaleOptions
+	NodePools         []NodePool
+	HTTPProxy         intPKE.HTTPProxy
+
+	APIServerAddress string
+	Kubernetes       intPKE.Kubernetes
+}
+
+// ClusterStore defines behaviors of PKEOnBaremetalCluster persistent storage
+type ClusterStore interface {
+	Create(params CreateParams) (PKEOnBaremetalCluster, error)
+	CreateNodePool(clusterID uint, nodePool NodePool) error
+	Delete(clusterID uint) error
+	DeleteNodePool(clusterID uint, nodePoolName string) error
+	GetByID(clusterID uint) (PKEOnBaremetalCluster, error)
+	SetStatus(clusterID uint, status, message string) error
+	SetActiveWorkflowID(clusterID uint, workflowID string) error
+	SetConfigSecretID(clusterID uint, secretID string) error
+	SetNodePoolHosts(clusterID uint, nodePoolName string, hosts []Host) error
+}
+
+// HostKeyStore defines behaviors of the persistent storage of SSH host keys pinned on first use
+type HostKeyStore interface {
+	// PinHostKey stores the host key for the address unless one is pinned already, and returns the pinned host key
+	PinHostKey(clusterID uint, address string, hostKey string) (string, error)
+	// DeleteHostKey removes the host key pinned for the address
+	DeleteHostKey(clusterID uint, address string) error
+}
+
+// IsNotFound returns true if the error is about a resource not being found
+func IsNotFound(err error) bool {
+	var notFoundErr interface {
+		NotFound() bool
+	}
+
+	return errors.As(err, &notFoundErr) && notFoundErr.NotFound()
+}
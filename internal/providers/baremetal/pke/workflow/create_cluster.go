@@ -0,0 +1,307 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clustersetup"
+	intPKE "github.com/banzaicloud/pipeline/internal/pke"
+	intPKEWorkflow "github.com/banzaicloud/pipeline/internal/pke/workflow"
+	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow"
+	"github.com/banzaicloud/pipeline/pkg/brn"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+	"github.com/banzaicloud/pipeline/src/cluster"
+)
+
+const CreateClusterWorkflowName = "pke-baremetal-create-cluster"
+
+// CreateClusterWorkflowInput
+type CreateClusterWorkflowInput struct {
+	ClusterID        uint
+	ClusterName      string
+	ClusterUID       string
+	OrganizationID   uint
+	OrganizationName string
+	SecretID         string
+	OIDCEnabled      bool
+	Nodes            []Node
+	HTTPProxy        intPKE.HTTPProxy
+	NodePoolLabels   map[string]map[string]string
+}
+
+func CreateClusterWorkflow(ctx workflow.Context, input CreateClusterWorkflowInput) error {
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions())
+
+	// Generate CA certificates
+	{
+		activityInput := pkeworkflow.GenerateCertificatesActivityInput{ClusterID: input.ClusterID}
+
+		err := workflow.ExecuteActivity(ctx, pkeworkflow.GenerateCertificatesActivityName, activityInput).Get(ctx, nil)
+		if err != nil {
+			_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+			return err
+		}
+	}
+
+	// Create dex client for the cluster
+	if input.OIDCEnabled {
+		activityInput := pkeworkflow.CreateDexClientActivityInput{
+			ClusterID: input.ClusterID,
+		}
+		err := workflow.ExecuteActivity(ctx, pkeworkflow.CreateDexClientActivityName, activityInput).Get(ctx, nil)
+		if err != nil {
+			_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+			return err
+		}
+	}
+
+	nodeParams, err := assembleNodeParams(ctx, input.OrganizationID, input.HTTPProxy)
+	if err != nil {
+		_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+		return err
+	}
+
+	var masters, workers []Node
+	for _, node := range input.Nodes {
+		if node.Master {
+			masters = append(masters, node)
+		} else {
+			workers = append(workers, node)
+		}
+	}
+
+	// Install master nodes
+	if err := installNodes(ctx, input.OrganizationID, input.ClusterID, input.ClusterName, input.SecretID, masters, nodeParams); err != nil {
+		_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+		return err
+	}
+
+	setClusterStatus(ctx, input.ClusterID, pkgCluster.Creating, "waiting for Kubernetes master") // nolint: errcheck
+
+	if err := waitForMasterReadySignal(ctx, 1*time.Hour); err != nil {
+		_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+		return err
+	}
+
+	var configSecretID string
+	{
+		activityInput := cluster.DownloadK8sConfigActivityInput{
+			ClusterID: input.ClusterID,
+		}
+		future := workflow.ExecuteActivity(ctx, cluster.DownloadK8sConfigActivityName, activityInput)
+		if err := future.Get(ctx, &configSecretID); err != nil {
+			_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+			return err
+		}
+	}
+
+	// Install worker nodes
+	if err := installNodes(ctx, input.OrganizationID, input.ClusterID, input.ClusterName, input.SecretID, workers, nodeParams); err != nil {
+		_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+		return err
+	}
+
+	if err := waitForNodes(ctx, input.ClusterID, input.Nodes); err != nil {
+		_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+		return err
+	}
+
+	{
+		workflowInput := clustersetup.WorkflowInput{
+			ConfigSecretID: brn.New(input.OrganizationID, brn.SecretResourceType, configSecretID).String(),
+			Cluster: clustersetup.Cluster{
+				ID:   input.ClusterID,
+				UID:  input.ClusterUID,
+				Name: input.ClusterName,
+			},
+			Organization: clustersetup.Organization{
+				ID:   input.OrganizationID,
+				Name: input.OrganizationName,
+			},
+			NodePoolLabels: input.NodePoolLabels,
+		}
+
+		future := workflow.ExecuteChildWorkflow(ctx, clustersetup.WorkflowName, workflowInput)
+		if err := future.Get(ctx, nil); err != nil {
+			_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+			return err
+		}
+	}
+
+	postHookWorkflowInput := cluster.RunPostHooksWorkflowInput{
+		ClusterID: input.ClusterID,
+		PostHooks: cluster.BuildWorkflowPostHookFunctions(nil, true),
+	}
+
+	err = workflow.ExecuteChildWorkflow(ctx, cluster.RunPostHooksWorkflowName, postHookWorkflowInput).Get(ctx, nil)
+	if err != nil {
+		_ = setClusterErrorStatus(ctx, input.ClusterID, err)
+		return err
+	}
+
+	return nil
+}
+
+func defaultActivityOptions() workflow.ActivityOptions {
+	return workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		ScheduleToCloseTimeout: 15 * time.Minute,
+		WaitForCancellation:    true,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          2 * time.Second,
+			BackoffCoefficient:       1.5,
+			MaximumInterval:          30 * time.Second,
+			MaximumAttempts:          5,
+			NonRetriableErrorReasons: []string{"cadenceInternal:Panic"},
+		},
+	}
+}
+
+// assembleNodeParams resolves the install script parameters shared by every node
+func assembleNodeParams(ctx workflow.Context, organizationID uint, httpProxy intPKE.HTTPProxy) (map[string]string, error) {
+	params := make(map[string]string)
+
+	{
+		activityInput := intPKEWorkflow.AssembleHTTPProxySettingsActivityInput{
+			OrganizationID:     organizationID,
+			HTTPProxyHostPort:  getHostPort(httpProxy.HTTP),
+			HTTPProxySecretID:  httpProxy.HTTP.SecretID,
+			HTTPSProxyHostPort: getHostPort(httpProxy.HTTPS),
+			HTTPSProxySecretID: httpProxy.HTTPS.SecretID,
+		}
+		var output intPKEWorkflow.AssembleHTTPProxySettingsActivityOutput
+		if err := workflow.ExecuteActivity(ctx, intPKEWorkflow.AssembleHTTPProxySettingsActivityName, activityInput).Get(ctx, &output); err != nil {
+			return nil, err
+		}
+		params["HttpProxy"] = output.Settings.HTTPProxyURL
+		params["HttpsProxy"] = output.Settings.HTTPSProxyURL
+	}
+
+	return params, nil
+}
+
+// installNodes installs PKE on the given hosts in parallel
+func installNodes(ctx workflow.Context, organizationID uint, clusterID uint, clusterName string, secretID string, nodes []Node, nodeParams map[string]string) error {
+	// installation is not idempotent, so a failed attempt is not retried
+	installCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    30 * time.Minute,
+		WaitForCancellation:    true,
+	})
+
+	futures := make(map[string]workflow.Future)
+
+	for _, node := range nodes {
+		params := make(map[string]string, len(node.InstallScriptParams)+len(nodeParams))
+		for k, v := range node.InstallScriptParams {
+			params[k] = v
+		}
+		for k, v := range nodeParams {
+			params[k] = v
+		}
+		node.InstallScriptParams = params
+
+		activityInput := InstallNodeActivityInput{
+			OrganizationID: organizationID,
+			ClusterID:      clusterID,
+			SecretID:       secretID,
+			ClusterName:    clusterName,
+			Node:           node,
+		}
+		futures[node.Name] = workflow.ExecuteActivity(installCtx, InstallNodeActivityName, activityInput)
+	}
+
+	errs := []error{}
+
+	for _, node := range nodes {
+		errs = append(errs, errors.WrapIff(futures[node.Name].Get(ctx, nil), "installing node %q", node.Name))
+	}
+
+	return errors.Combine(errs...)
+}
+
+// waitForNodes waits until every given host registers as a ready Kubernetes node
+func waitForNodes(ctx workflow.Context, clusterID uint, nodes []Node) error {
+	waitCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    time.Minute,
+		WaitForCancellation:    true,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          10 * time.Second,
+			BackoffCoefficient:       1.0,
+			ExpirationInterval:       15 * time.Minute,
+			NonRetriableErrorReasons: []string{"cadenceInternal:Panic"},
+		},
+	})
+
+	futures := make(map[string]workflow.Future)
+
+	for _, node := range nodes {
+		activityInput := WaitForNodeActivityInput{
+			ClusterID: clusterID,
+			NodeName:  node.Name,
+		}
+		futures[node.Name] = workflow.ExecuteActivity(waitCtx, WaitForNodeActivityName, activityInput)
+	}
+
+	errs := []error{}
+
+	for _, node := range nodes {
+		errs = append(errs, errors.WrapIff(futures[node.Name].Get(ctx, nil), "waiting for node %q", node.Name))
+	}
+
+	return errors.Combine(errs...)
+}
+
+func getHostPort(o intPKE.HTTPProxyOptions) string {
+	if o.Host == "" {
+		return ""
+	}
+	if o.Port == 0 {
+		return o.Host
+	}
+	return net.JoinHostPort(o.Host, strconv.FormatUint(uint64(o.Port), 10))
+}
+
+func waitForMasterReadySignal(ctx workflow.Context, timeout time.Duration) error {
+	signalName := "master-ready"
+	signalChan := workflow.GetSignalChannel(ctx, signalName)
+	signalTimeoutTimer := workflow.NewTimer(ctx, timeout)
+	signalTimeout := false
+
+	signalSelector := workflow.NewSelector(ctx).AddReceive(signalChan, func(c workflow.Channel, more bool) {
+		c.Receive(ctx, nil)
+		workflow.GetLogger(ctx).Info("Received signal!", zap.String("signal", signalName))
+	}).AddFuture(signalTimeoutTimer, func(workflow.Future) {
+		signalTimeout = true
+	})
+
+	signalSelector.Select(ctx) // wait for signal
+
+	if signalTimeout {
+		return fmt.Errorf("timeout while waiting for %q signal", signalName)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	"github.com/banzaicloud/pipeline/pkg/cadence"
+)
+
+// DeleteK8sNodeActivityName is the default registration name of the activity
+const DeleteK8sNodeActivityName = "pke-baremetal-delete-k8s-node"
+
+// DeleteK8sNodeActivity removes the Kubernetes node object of a removed host
+type DeleteK8sNodeActivity struct {
+	clientFactory clusterworkflow.ClientFactory
+}
+
+// MakeDeleteK8sNodeActivity returns a new DeleteK8sNodeActivity
+func MakeDeleteK8sNodeActivity(clientFactory clusterworkflow.ClientFactory) DeleteK8sNodeActivity {
+	return DeleteK8sNodeActivity{
+		clientFactory: clientFactory,
+	}
+}
+
+// DeleteK8sNodeActivityInput represents the input needed for executing a DeleteK8sNodeActivity
+type DeleteK8sNodeActivityInput struct {
+	ClusterID uint
+	NodeName  string
+}
+
+// Execute performs the activity
+func (a DeleteK8sNodeActivity) Execute(ctx context.Context, input DeleteK8sNodeActivityInput) error {
+	client, err := a.clientFactory.FromClusterID(ctx, input.ClusterID)
+	if err != nil {
+		return cadence.WrapClientError(err)
+	}
+
+	err = client.CoreV1().Nodes().Delete(input.NodeName, &metav1.DeleteOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+
+	return errors.WrapIfWithDetails(err, "failed to delete node", "node", input.NodeName)
+}
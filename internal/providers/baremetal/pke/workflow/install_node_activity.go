@@ -0,0 +1,116 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"strings"
+	"text/template"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+
+	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow/pkeworkflowadapter"
+)
+
+// InstallNodeActivityName is the default registration name of the activity
+const InstallNodeActivityName = "pke-baremetal-install-node"
+
+// InstallNodeActivity represents an activity for installing PKE on a host over SSH
+type InstallNodeActivity struct {
+	sshClientFactory *SSHClientFactory
+	tokenGenerator   pkeworkflowadapter.TokenGenerator
+}
+
+// MakeInstallNodeActivity returns a new InstallNodeActivity
+func MakeInstallNodeActivity(sshClientFactory *SSHClientFactory, tokenGenerator pkeworkflowadapter.TokenGenerator) InstallNodeActivity {
+	return InstallNodeActivity{
+		sshClientFactory: sshClientFactory,
+		tokenGenerator:   tokenGenerator,
+	}
+}
+
+// InstallNodeActivityInput represents the input needed for executing an InstallNodeActivity
+type InstallNodeActivityInput struct {
+	OrganizationID uint
+	ClusterID      uint
+	SecretID       string
+	ClusterName    string
+	Node
+}
+
+// Node represents a host of the cluster
+type Node struct {
+	Name                  string
+	Address               string
+	NodePoolName          string
+	Master                bool
+	InstallScriptParams   map[string]string
+	InstallScriptTemplate string
+}
+
+func generateInstallScript(node Node) (string, error) {
+	scriptTemplate, err := template.New(node.Name + "InstallScript").Parse(node.InstallScriptTemplate)
+	if err != nil {
+		return "", errors.WrapIf(err, "failed to parse install script template")
+	}
+
+	var script strings.Builder
+	err = scriptTemplate.Execute(&script, node.InstallScriptParams)
+	return script.String(), errors.WrapIf(err, "failed to execute install script template")
+}
+
+// Execute performs the activity
+func (a InstallNodeActivity) Execute(ctx context.Context, input InstallNodeActivityInput) error {
+	logger := activity.GetLogger(ctx).Sugar().With(
+		"organization", input.OrganizationID,
+		"cluster", input.ClusterName,
+		"node", input.Name,
+		"address", input.Address,
+	)
+
+	logger.Info("install node")
+
+	_, token, err := a.tokenGenerator.GenerateClusterToken(input.OrganizationID, input.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	params := make(map[string]string, len(input.InstallScriptParams)+1)
+	for k, v := range input.InstallScriptParams {
+		params[k] = v
+	}
+	params["PipelineToken"] = token
+	input.InstallScriptParams = params
+
+	script, err := generateInstallScript(input.Node)
+	if err != nil {
+		return err
+	}
+
+	client, err := a.sshClientFactory.New(input.OrganizationID, input.ClusterID, input.SecretID, input.Address)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := runScript(client, script); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to install PKE", "node", input.Name)
+	}
+
+	logger.Info("node installed")
+
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateInstallScript(t *testing.T) {
+	node := Node{
+		Name:                  "node1",
+		InstallScriptTemplate: `pke install worker --pipeline-token="{{ .PipelineToken }}" --kubernetes-api-server={{ .PublicAddress }}:6443`,
+		InstallScriptParams: map[string]string{
+			"PipelineToken": "token",
+			"PublicAddress": "10.0.0.1",
+		},
+	}
+
+	script, err := generateInstallScript(node)
+	require.NoError(t, err)
+
+	assert.Equal(t, `pke install worker --pipeline-token="token" --kubernetes-api-server=10.0.0.1:6443`, script)
+}
+
+func TestGetSSHAddress(t *testing.T) {
+	assert.Equal(t, "10.0.0.1:22", getSSHAddress("10.0.0.1"))
+	assert.Equal(t, "10.0.0.1:2222", getSSHAddress("10.0.0.1:2222"))
+	assert.Equal(t, "node1.example.com:22", getSSHAddress("node1.example.com"))
+}
@@ -0,0 +1,50 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke"
+)
+
+const SetNodePoolHostsActivityName = "pke-baremetal-set-node-pool-hosts"
+
+type SetNodePoolHostsActivity struct {
+	store pke.ClusterStore
+}
+
+func MakeSetNodePoolHostsActivity(store pke.ClusterStore) SetNodePoolHostsActivity {
+	return SetNodePoolHostsActivity{
+		store: store,
+	}
+}
+
+type SetNodePoolHostsActivityInput struct {
+	ClusterID     uint
+	NodePoolHosts map[string][]pke.Host
+}
+
+func (a SetNodePoolHostsActivity) Execute(ctx context.Context, input SetNodePoolHostsActivityInput) error {
+	for name, hosts := range input.NodePoolHosts {
+		err := a.store.SetNodePoolHosts(input.ClusterID, name, hosts)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to set nodepool hosts in store", "nodepool", name, "cluster", input.ClusterID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke"
+	"github.com/banzaicloud/pipeline/internal/providers/pke/pkeworkflow"
+	"github.com/banzaicloud/pipeline/internal/secret/secrettype"
+	"github.com/banzaicloud/pipeline/internal/secret/ssh/sshadapter"
+)
+
+const defaultSSHPort = "22"
+
+// scriptOutputLimit is the number of trailing bytes of a failed script's output kept in errors
+const scriptOutputLimit = 4096
+
+// SSHClientFactory creates SSH connections to cluster hosts using the cluster's SSH secret
+type SSHClientFactory struct {
+	secretStore  pkeworkflow.SecretStore
+	hostKeyStore pke.HostKeyStore
+}
+
+func NewSSHClientFactory(secretStore pkeworkflow.SecretStore, hostKeyStore pke.HostKeyStore) *SSHClientFactory {
+	return &SSHClientFactory{
+		secretStore:  secretStore,
+		hostKeyStore: hostKeyStore,
+	}
+}
+
+// New connects to the host on the given address with the credentials stored in the SSH secret
+//
+// The host key is verified against the known hosts stored in the secret when present,
+// otherwise the key presented on the first connection to the host is pinned and required afterwards.
+func (f *SSHClientFactory) New(organizationID uint, clusterID uint, secretID string, address string) (*ssh.Client, error) {
+	s, err := f.secretStore.GetSecret(organizationID, secretID)
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to get secret")
+	}
+
+	if err := s.ValidateSecretType(secrettype.SSHSecretType); err != nil {
+		return nil, err
+	}
+
+	values := s.GetValues()
+	keyPair := sshadapter.KeyPairFromValues(values)
+
+	signer, err := keyPair.Signer()
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback, err := f.hostKeyCallback(clusterID, values[secrettype.KnownHosts])
+	if err != nil {
+		return nil, err
+	}
+
+	config := &ssh.ClientConfig{
+		User: keyPair.User,
+		Auth: []ssh.AuthMethod{
+			ssh.PublicKeys(signer),
+		},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         30 * time.Second,
+	}
+
+	client, err := ssh.Dial("tcp", getSSHAddress(address), config)
+	return client, errors.WrapIfWithDetails(err, "failed to connect to host", "address", address)
+}
+
+// ForgetHost removes the host key pinned for the host on the given address
+func (f *SSHClientFactory) ForgetHost(clusterID uint, address string) error {
+	return f.hostKeyStore.DeleteHostKey(clusterID, getSSHAddress(address))
+}
+
+func (f *SSHClientFactory) hostKeyCallback(clusterID uint, knownHosts string) (ssh.HostKeyCallback, error) {
+	if strings.TrimSpace(knownHosts) != "" {
+		return knownHostsCallback(knownHosts)
+	}
+
+	return pinnedHostKeyCallback(func(address string, hostKey string) (string, error) {
+		return f.hostKeyStore.PinHostKey(clusterID, address, hostKey)
+	}), nil
+}
+
+// knownHostsCallback verifies host keys against the given content in OpenSSH known_hosts format
+func knownHostsCallback(knownHosts string) (ssh.HostKeyCallback, error) {
+	file, err := ioutil.TempFile("", "known_hosts")
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to create known hosts file")
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	if _, err := file.WriteString(knownHosts); err != nil {
+		return nil, errors.WrapIf(err, "failed to write known hosts file")
+	}
+
+	callback, err := knownhosts.New(file.Name())
+	return callback, errors.WrapIf(err, "failed to parse known hosts")
+}
+
+// pinnedHostKeyCallback accepts the first host key seen for an address and rejects any other key afterwards
+func pinnedHostKeyCallback(pin func(address string, hostKey string) (string, error)) ssh.HostKeyCallback {
+	return func(hostname string, _ net.Addr, key ssh.PublicKey) error {
+		hostKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+
+		pinnedHostKey, err := pin(hostname, hostKey)
+		if err != nil {
+			return errors.WrapIf(err, "failed to pin host key")
+		}
+
+		if pinnedHostKey != hostKey {
+			return errors.NewWithDetails(
+				"host key mismatch",
+				"address", hostname,
+				"fingerprint", ssh.FingerprintSHA256(key),
+			)
+		}
+
+		return nil
+	}
+}
+
+func getSSHAddress(address string) string {
+	if _, _, err := net.SplitHostPort(address); err == nil {
+		return address
+	}
+	return net.JoinHostPort(address, defaultSSHPort)
+}
+
+// runScript executes a shell script on the remote host with root privileges
+func runScript(client *ssh.Client, script string) error {
+	session, err := client.NewSession()
+	if err != nil {
+		return errors.WrapIf(err, "failed to open SSH session")
+	}
+	defer session.Close()
+
+	var output bytes.Buffer
+	session.Stdin = strings.NewReader(script)
+	session.Stdout = &output
+	session.Stderr = &output
+
+	err = session.Run(`if [ "$(id -u)" -eq 0 ]; then sh -s; else sudo -n sh -s; fi`)
+	if err != nil {
+		out := output.String()
+		if len(out) > scriptOutputLimit {
+			out = out[len(out)-scriptOutputLimit:]
+		}
+		return errors.WrapIfWithDetails(err, "failed to run script", "output", out)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func newTestHostKey(t *testing.T) ssh.PublicKey {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	return publicKey
+}
+
+func TestKnownHostsCallback(t *testing.T) {
+	hostKey := newTestHostKey(t)
+	otherHostKey := newTestHostKey(t)
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+
+	callback, err := knownHostsCallback(knownhosts.Line([]string{"node1.example.com"}, hostKey) + "\n")
+	require.NoError(t, err)
+
+	assert.NoError(t, callback("node1.example.com:22", remote, hostKey))
+	assert.Error(t, callback("node1.example.com:22", remote, otherHostKey))
+	assert.Error(t, callback("node2.example.com:22", remote, hostKey))
+}
+
+func TestKnownHostsCallback_Invalid(t *testing.T) {
+	_, err := knownHostsCallback("node1.example.com ssh-rsa invalid")
+	assert.Error(t, err)
+}
+
+func TestPinnedHostKeyCallback(t *testing.T) {
+	hostKey := newTestHostKey(t)
+	otherHostKey := newTestHostKey(t)
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 22}
+
+	pinned := make(map[string]string)
+	callback := pinnedHostKeyCallback(func(address string, hostKey string) (string, error) {
+		if pinnedHostKey, ok := pinned[address]; ok {
+			return pinnedHostKey, nil
+		}
+		pinned[address] = hostKey
+		return hostKey, nil
+	})
+
+	assert.NoError(t, callback("node1.example.com:22", remote, hostKey), "first connection pins the key")
+	assert.NoError(t, callback("node1.example.com:22", remote, hostKey))
+	assert.Error(t, callback("node1.example.com:22", remote, otherHostKey))
+	assert.NoError(t, callback("node2.example.com:22", remote, otherHostKey))
+}
@@ -0,0 +1,86 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/activity"
+)
+
+// UninstallNodeActivityName is the default registration name of the activity
+const UninstallNodeActivityName = "pke-baremetal-uninstall-node"
+
+// uninstallScript removes the Kubernetes components installed by PKE from the host
+const uninstallScript = `#!/bin/sh
+export PATH=$PATH:/usr/local/bin/
+
+if command -v kubeadm >/dev/null 2>&1; then
+  kubeadm reset --force
+fi
+
+systemctl stop kubelet || true
+rm -rf /etc/kubernetes /var/lib/kubelet /var/lib/etcd /etc/cni/net.d
+`
+
+// UninstallNodeActivity represents an activity for removing PKE from a host over SSH
+type UninstallNodeActivity struct {
+	sshClientFactory *SSHClientFactory
+}
+
+// MakeUninstallNodeActivity returns a new UninstallNodeActivity
+func MakeUninstallNodeActivity(sshClientFactory *SSHClientFactory) UninstallNodeActivity {
+	return UninstallNodeActivity{
+		sshClientFactory: sshClientFactory,
+	}
+}
+
+// UninstallNodeActivityInput represents the input needed for executing an UninstallNodeActivity
+type UninstallNodeActivityInput struct {
+	OrganizationID uint
+	ClusterID      uint
+	SecretID       string
+	ClusterName    string
+	Node
+}
+
+// Execute performs the activity
+func (a UninstallNodeActivity) Execute(ctx context.Context, input UninstallNodeActivityInput) error {
+	logger := activity.GetLogger(ctx).Sugar().With(
+		"organization", input.OrganizationID,
+		"cluster", input.ClusterName,
+		"node", input.Name,
+		"address", input.Address,
+	)
+
+	logger.Info("uninstall node")
+
+	client, err := a.sshClientFactory.New(input.OrganizationID, input.ClusterID, input.SecretID, input.Address)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := runScript(client, uninstallScript); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to uninstall PKE", "node", input.Name)
+	}
+
+	// the host may be reinstalled before it is added to a cluster again
+	return errors.WrapIfWithDetails(
+		a.sshClientFactory.ForgetHost(input.ClusterID, input.Address),
+		"failed to delete pinned host key", "node", input.Name,
+	)
+}
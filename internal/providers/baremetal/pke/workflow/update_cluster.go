@@ -0,0 +1,185 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clustersetup"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	intPKE "github.com/banzaicloud/pipeline/internal/pke"
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke"
+	"github.com/banzaicloud/pipeline/pkg/brn"
+	pkgCadence "github.com/banzaicloud/pipeline/pkg/cadence"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+const UpdateClusterWorkflowName = "pke-baremetal-update-cluster"
+
+// UpdateClusterWorkflowInput
+type UpdateClusterWorkflowInput struct {
+	ClusterID        uint
+	ClusterName      string
+	OrganizationID   uint
+	SecretID         string
+	ConfigSecretID   string
+	HTTPProxy        intPKE.HTTPProxy
+
+	NodesToCreate     []Node
+	NodesToDelete     []Node
+	NodePoolsToDelete []string
+	// NodePoolHosts contains the host lists of shrunk node pools to be persisted once their hosts are removed
+	NodePoolHosts map[string][]pke.Host
+
+	Labels map[string]map[string]string
+}
+
+func UpdateClusterWorkflow(ctx workflow.Context, input UpdateClusterWorkflowInput) error {
+	ctx = workflow.WithActivityOptions(ctx, defaultActivityOptions())
+
+	// Draining is retried until pod disruption budgets allow evicting every pod from the node.
+	drainCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		HeartbeatTimeout:       time.Minute,
+		WaitForCancellation:    true,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          30 * time.Second,
+			BackoffCoefficient:       1.0,
+			ExpirationInterval:       6 * time.Hour,
+			NonRetriableErrorReasons: []string{pkgCadence.ClientErrorReason, "cadenceInternal:Panic"},
+		},
+	})
+
+	// Drain nodes to be removed
+	for _, node := range input.NodesToDelete {
+		activityInput := clusterworkflow.DrainNodeActivityInput{
+			ClusterID: input.ClusterID,
+			NodeName:  node.Name,
+		}
+
+		err := workflow.ExecuteActivity(drainCtx, clusterworkflow.DrainNodeActivityName, activityInput).Get(ctx, nil)
+		if err = errors.WrapIff(err, "draining node %q", node.Name); err != nil {
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	// Uninstall PKE from the removed hosts
+	{
+		futures := make(map[string]workflow.Future)
+
+		for _, node := range input.NodesToDelete {
+			activityInput := UninstallNodeActivityInput{
+				OrganizationID: input.OrganizationID,
+				ClusterID:      input.ClusterID,
+				SecretID:       input.SecretID,
+				ClusterName:    input.ClusterName,
+				Node:           node,
+			}
+			futures[node.Name] = workflow.ExecuteActivity(ctx, UninstallNodeActivityName, activityInput)
+		}
+
+		errs := []error{}
+
+		for _, node := range input.NodesToDelete {
+			errs = append(errs, errors.WrapIff(futures[node.Name].Get(ctx, nil), "uninstalling node %q", node.Name))
+		}
+
+		if err := errors.Combine(errs...); err != nil {
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	// Remove uninstalled nodes from Kubernetes
+	for _, node := range input.NodesToDelete {
+		activityInput := DeleteK8sNodeActivityInput{
+			ClusterID: input.ClusterID,
+			NodeName:  node.Name,
+		}
+
+		err := workflow.ExecuteActivity(ctx, DeleteK8sNodeActivityName, activityInput).Get(ctx, nil)
+		if err = errors.WrapIff(err, "deleting Kubernetes node %q", node.Name); err != nil {
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	if len(input.NodePoolHosts) > 0 {
+		activityInput := SetNodePoolHostsActivityInput{
+			ClusterID:     input.ClusterID,
+			NodePoolHosts: input.NodePoolHosts,
+		}
+		if err := workflow.ExecuteActivity(ctx, SetNodePoolHostsActivityName, activityInput).Get(ctx, nil); err != nil {
+			err = errors.WrapIff(err, "%q activity failed", SetNodePoolHostsActivityName)
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	if len(input.NodePoolsToDelete) > 0 {
+		activityInput := DeleteNodePoolFromStoreActivityInput{
+			ClusterID:     input.ClusterID,
+			NodePoolNames: input.NodePoolsToDelete,
+		}
+		if err := workflow.ExecuteActivity(ctx, DeleteNodePoolFromStoreActivityName, activityInput).Get(ctx, nil); err != nil {
+			err = errors.WrapIff(err, "%q activity failed", DeleteNodePoolFromStoreActivityName)
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	// set up node pool labels set
+	{
+		activityInput := clustersetup.ConfigureNodePoolLabelsActivityInput{
+			ConfigSecretID: brn.New(input.OrganizationID, brn.SecretResourceType, input.ConfigSecretID).String(),
+			Labels:         input.Labels,
+		}
+		err := workflow.ExecuteActivity(ctx, clustersetup.ConfigureNodePoolLabelsActivityName, activityInput).Get(ctx, nil)
+		if err != nil {
+			err = errors.WrapIff(err, "%q activity failed", clustersetup.ConfigureNodePoolLabelsActivityName)
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	if len(input.NodesToCreate) > 0 {
+		nodeParams, err := assembleNodeParams(ctx, input.OrganizationID, input.HTTPProxy)
+		if err != nil {
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+
+		// every node joins with a freshly generated token
+		if err := installNodes(ctx, input.OrganizationID, input.ClusterID, input.ClusterName, input.SecretID, input.NodesToCreate, nodeParams); err != nil {
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+
+		if err := waitForNodes(ctx, input.ClusterID, input.NodesToCreate); err != nil {
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	setClusterStatus(ctx, input.ClusterID, pkgCluster.Running, pkgCluster.RunningMessage) // nolint: errcheck
+
+	return nil
+}
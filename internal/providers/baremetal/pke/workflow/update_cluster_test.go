@@ -0,0 +1,202 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clustersetup"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	intPKEWorkflow "github.com/banzaicloud/pipeline/internal/pke/workflow"
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+func init() {
+	workflow.RegisterWithOptions(UpdateClusterWorkflow, workflow.RegisterOptions{Name: UpdateClusterWorkflowName})
+
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input clusterworkflow.DrainNodeActivityInput) error { return nil },
+		activity.RegisterOptions{Name: clusterworkflow.DrainNodeActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input UninstallNodeActivityInput) error { return nil },
+		activity.RegisterOptions{Name: UninstallNodeActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input DeleteK8sNodeActivityInput) error { return nil },
+		activity.RegisterOptions{Name: DeleteK8sNodeActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input SetNodePoolHostsActivityInput) error { return nil },
+		activity.RegisterOptions{Name: SetNodePoolHostsActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input DeleteNodePoolFromStoreActivityInput) error { return nil },
+		activity.RegisterOptions{Name: DeleteNodePoolFromStoreActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input clustersetup.ConfigureNodePoolLabelsActivityInput) error { return nil },
+		activity.RegisterOptions{Name: clustersetup.ConfigureNodePoolLabelsActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input intPKEWorkflow.AssembleHTTPProxySettingsActivityInput) (intPKEWorkflow.AssembleHTTPProxySettingsActivityOutput, error) {
+			return intPKEWorkflow.AssembleHTTPProxySettingsActivityOutput{}, nil
+		},
+		activity.RegisterOptions{Name: intPKEWorkflow.AssembleHTTPProxySettingsActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input InstallNodeActivityInput) error { return nil },
+		activity.RegisterOptions{Name: InstallNodeActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input WaitForNodeActivityInput) error { return nil },
+		activity.RegisterOptions{Name: WaitForNodeActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input SetClusterStatusActivityInput) error { return nil },
+		activity.RegisterOptions{Name: SetClusterStatusActivityName},
+	)
+}
+
+type UpdateClusterWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestUpdateClusterWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(UpdateClusterWorkflowTestSuite))
+}
+
+func (s *UpdateClusterWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+}
+
+func (s *UpdateClusterWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+func (s *UpdateClusterWorkflowTestSuite) Test_AddHosts() {
+	input := UpdateClusterWorkflowInput{
+		ClusterID:      1,
+		ClusterName:    "example-cluster",
+		OrganizationID: 2,
+		SecretID:       "ssh-secret",
+		ConfigSecretID: "config-secret",
+		NodesToCreate: []Node{
+			{Name: "node3", Address: "10.0.0.3", NodePoolName: "pool1"},
+			{Name: "node4", Address: "10.0.0.4", NodePoolName: "pool2", InstallScriptParams: map[string]string{"PublicAddress": "10.0.0.1"}},
+		},
+	}
+
+	s.env.OnActivity(clustersetup.ConfigureNodePoolLabelsActivityName, mock.Anything, mock.Anything).Return(nil).Once()
+	s.env.OnActivity(intPKEWorkflow.AssembleHTTPProxySettingsActivityName, mock.Anything, mock.Anything).Return(
+		intPKEWorkflow.AssembleHTTPProxySettingsActivityOutput{
+			Settings: intPKEWorkflow.HTTPProxy{HTTPProxyURL: "http://proxy:3128"},
+		},
+		nil,
+	).Once()
+	s.env.OnActivity(InstallNodeActivityName, mock.Anything, mock.MatchedBy(func(input InstallNodeActivityInput) bool {
+		return input.SecretID == "ssh-secret" && input.InstallScriptParams["HttpProxy"] == "http://proxy:3128"
+	})).Return(nil).Twice()
+	s.env.OnActivity(WaitForNodeActivityName, mock.Anything, WaitForNodeActivityInput{ClusterID: 1, NodeName: "node3"}).Return(nil).Once()
+	s.env.OnActivity(WaitForNodeActivityName, mock.Anything, WaitForNodeActivityInput{ClusterID: 1, NodeName: "node4"}).Return(nil).Once()
+	s.env.OnActivity(SetClusterStatusActivityName, mock.Anything, SetClusterStatusActivityInput{
+		ClusterID:     1,
+		Status:        pkgCluster.Running,
+		StatusMessage: pkgCluster.RunningMessage,
+	}).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(UpdateClusterWorkflowName, input)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
+
+func (s *UpdateClusterWorkflowTestSuite) Test_RemoveHostsAndNodePool() {
+	input := UpdateClusterWorkflowInput{
+		ClusterID:      1,
+		ClusterName:    "example-cluster",
+		OrganizationID: 2,
+		SecretID:       "ssh-secret",
+		ConfigSecretID: "config-secret",
+		NodesToDelete: []Node{
+			{Name: "node3", Address: "10.0.0.3", NodePoolName: "pool1"},
+			{Name: "node4", Address: "10.0.0.4", NodePoolName: "pool2"},
+		},
+		NodePoolsToDelete: []string{"pool2"},
+		NodePoolHosts: map[string][]pke.Host{
+			"pool1": {{Name: "node2", Address: "10.0.0.2", PrivateIP: "10.0.0.2"}},
+		},
+	}
+
+	s.env.OnActivity(clusterworkflow.DrainNodeActivityName, mock.Anything, mock.Anything).Return(nil).Twice()
+	s.env.OnActivity(UninstallNodeActivityName, mock.Anything, mock.Anything).Return(nil).Twice()
+	s.env.OnActivity(DeleteK8sNodeActivityName, mock.Anything, mock.Anything).Return(nil).Twice()
+	s.env.OnActivity(SetNodePoolHostsActivityName, mock.Anything, SetNodePoolHostsActivityInput{
+		ClusterID:     1,
+		NodePoolHosts: input.NodePoolHosts,
+	}).Return(nil).Once()
+	s.env.OnActivity(DeleteNodePoolFromStoreActivityName, mock.Anything, DeleteNodePoolFromStoreActivityInput{
+		ClusterID:     1,
+		NodePoolNames: []string{"pool2"},
+	}).Return(nil).Once()
+	s.env.OnActivity(clustersetup.ConfigureNodePoolLabelsActivityName, mock.Anything, mock.Anything).Return(nil).Once()
+	s.env.OnActivity(SetClusterStatusActivityName, mock.Anything, SetClusterStatusActivityInput{
+		ClusterID:     1,
+		Status:        pkgCluster.Running,
+		StatusMessage: pkgCluster.RunningMessage,
+	}).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(UpdateClusterWorkflowName, input)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
+
+func (s *UpdateClusterWorkflowTestSuite) Test_UninstallNodeFailure() {
+	input := UpdateClusterWorkflowInput{
+		ClusterID:      1,
+		ClusterName:    "example-cluster",
+		OrganizationID: 2,
+		NodesToDelete: []Node{
+			{Name: "node3", Address: "10.0.0.3", NodePoolName: "pool1"},
+		},
+		NodePoolHosts: map[string][]pke.Host{
+			"pool1": {{Name: "node2", Address: "10.0.0.2", PrivateIP: "10.0.0.2"}},
+		},
+	}
+
+	s.env.OnActivity(clusterworkflow.DrainNodeActivityName, mock.Anything, mock.Anything).Return(nil).Once()
+	s.env.OnActivity(UninstallNodeActivityName, mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+	s.env.OnActivity(SetClusterStatusActivityName, mock.Anything, mock.MatchedBy(func(input SetClusterStatusActivityInput) bool {
+		return input.Status == pkgCluster.Warning
+	})).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(UpdateClusterWorkflowName, input)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.Error(s.env.GetWorkflowError())
+}
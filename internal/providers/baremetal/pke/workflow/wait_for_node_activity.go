@@ -0,0 +1,71 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	"github.com/banzaicloud/pipeline/pkg/cadence"
+)
+
+// WaitForNodeActivityName is the default registration name of the activity
+const WaitForNodeActivityName = "pke-baremetal-wait-for-node"
+
+// WaitForNodeActivity verifies that an installed host registered as a ready Kubernetes node
+type WaitForNodeActivity struct {
+	clientFactory clusterworkflow.ClientFactory
+}
+
+// MakeWaitForNodeActivity returns a new WaitForNodeActivity
+func MakeWaitForNodeActivity(clientFactory clusterworkflow.ClientFactory) WaitForNodeActivity {
+	return WaitForNodeActivity{
+		clientFactory: clientFactory,
+	}
+}
+
+// WaitForNodeActivityInput represents the input needed for executing a WaitForNodeActivity
+type WaitForNodeActivityInput struct {
+	ClusterID uint
+	NodeName  string
+}
+
+// Execute performs the activity, it fails until the node is ready so that it can be retried by the workflow
+func (a WaitForNodeActivity) Execute(ctx context.Context, input WaitForNodeActivityInput) error {
+	client, err := a.clientFactory.FromClusterID(ctx, input.ClusterID)
+	if err != nil {
+		return cadence.WrapClientError(err)
+	}
+
+	node, err := client.CoreV1().Nodes().Get(input.NodeName, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return errors.NewWithDetails("node is not registered yet", "node", input.NodeName)
+	} else if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to get node", "node", input.NodeName)
+	}
+
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+			return nil
+		}
+	}
+
+	return errors.NewWithDetails("node is not ready yet", "node", input.NodeName)
+}
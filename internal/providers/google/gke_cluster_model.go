@@ -21,6 +21,7 @@ import (
 	"github.com/jinzhu/gorm"
 
 	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter/clustermodel"
+	"github.com/banzaicloud/pipeline/pkg/common"
 )
 
 // GKEClusterModel is the schema for the DB.
@@ -112,6 +113,7 @@ type GKENodePoolModel struct {
 	NodeCount        int
 	NodeInstanceType string
 	Labels           map[string]string `gorm:"-"`
+	Taints           common.Taints     `gorm:"type:text"`
 	Delete           bool              `gorm:"-"`
 }
 
@@ -15,6 +15,7 @@
 package google
 
 import (
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
 	"github.com/banzaicloud/pipeline/src/auth"
 )
 
@@ -36,6 +37,8 @@ type ObjectStoreBucketModel struct {
 	SecretRef string
 	Status    string
 	StatusMsg string `sql:"type:text;"`
+
+	Settings objectstore.BucketSettings `gorm:"type:json"`
 }
 
 // TableName changes the default table name.
@@ -0,0 +1,87 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// CredentialExpiry returns the time the credentials of a Kubernetes client configuration expire.
+// Client certificates and JWT bearer tokens are inspected. It returns nil if the credentials never expire
+// or their expiry cannot be determined (eg. credentials provided by exec or auth provider plugins).
+func CredentialExpiry(config *rest.Config) *time.Time {
+	var expiresAt *time.Time
+
+	for _, t := range []*time.Time{getCertificateExpiry(config.CertData), getTokenExpiry(config.BearerToken)} {
+		if t != nil && (expiresAt == nil || t.Before(*expiresAt)) {
+			expiresAt = t
+		}
+	}
+
+	return expiresAt
+}
+
+// getCertificateExpiry returns the expiry of the first (client) certificate of a PEM bundle.
+func getCertificateExpiry(certData []byte) *time.Time {
+	for block, remaining := pem.Decode(certData); block != nil; block, remaining = pem.Decode(remaining) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil
+		}
+
+		notAfter := cert.NotAfter.UTC()
+
+		return &notAfter
+	}
+
+	return nil
+}
+
+// getTokenExpiry returns the value of the exp claim of a JWT.
+// The signature of the token is not verified.
+func getTokenExpiry(token string) *time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return nil
+	}
+
+	exp := time.Unix(claims.Exp, 0).UTC()
+
+	return &exp
+}
@@ -0,0 +1,114 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func newTestCertificate(t *testing.T, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "admin"},
+		NotBefore:    notAfter.Add(-24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func newTestToken(exp time.Time) string {
+	encode := base64.RawURLEncoding.EncodeToString
+
+	return fmt.Sprintf(
+		"%s.%s.%s",
+		encode([]byte(`{"alg":"RS256"}`)),
+		encode([]byte(fmt.Sprintf(`{"sub":"admin","exp":%d}`, exp.Unix()))),
+		encode([]byte("signature")),
+	)
+}
+
+func TestCredentialExpiry(t *testing.T) {
+	certExpiry := time.Date(2020, time.April, 1, 0, 0, 0, 0, time.UTC)
+	tokenExpiry := time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		config   rest.Config
+		expected *time.Time
+	}{
+		{
+			name:     "no credentials",
+			config:   rest.Config{},
+			expected: nil,
+		},
+		{
+			name:     "static token",
+			config:   rest.Config{BearerToken: "token"},
+			expected: nil,
+		},
+		{
+			name:     "client certificate",
+			config:   rest.Config{TLSClientConfig: rest.TLSClientConfig{CertData: newTestCertificate(t, certExpiry)}},
+			expected: &certExpiry,
+		},
+		{
+			name:     "jwt",
+			config:   rest.Config{BearerToken: newTestToken(tokenExpiry)},
+			expected: &tokenExpiry,
+		},
+		{
+			name: "earliest expiry",
+			config: rest.Config{
+				BearerToken:     newTestToken(tokenExpiry),
+				TLSClientConfig: rest.TLSClientConfig{CertData: newTestCertificate(t, certExpiry)},
+			},
+			expected: &tokenExpiry,
+		},
+		{
+			name:     "invalid certificate",
+			config:   rest.Config{TLSClientConfig: rest.TLSClientConfig{CertData: []byte("invalid")}},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, CredentialExpiry(&test.config))
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+// Status messages set by the health check.
+// Warnings starting with any of them are cleared once the cluster is healthy again.
+const (
+	unreachableMessage         = "Kubernetes API server is not reachable"
+	credentialsExpiredMessage  = "Kubeconfig credentials expired"
+	credentialsExpiringMessage = "Kubeconfig credentials expire soon"
+)
+
+// HealthReport is the result of an imported cluster health check.
+type HealthReport struct {
+	CheckedAt time.Time
+	Reachable bool
+
+	// Version is the version of the Kubernetes API server
+	Version string
+
+	NodePools []NodePool
+
+	CredentialExpiresAt *time.Time
+}
+
+// Cluster is an imported cluster.
+type Cluster struct {
+	ID             uint
+	OrganizationID uint
+	Status         string
+	StatusMessage  string
+}
+
+// +testify:mock:testOnly=true
+
+// Store persists the health of imported clusters.
+type Store interface {
+	// ListClusters returns the IDs of the imported clusters that should be checked.
+	ListClusters(ctx context.Context) ([]uint, error)
+
+	// GetCluster returns an imported cluster.
+	GetCluster(ctx context.Context, clusterID uint) (Cluster, error)
+
+	// SetStatus sets the status of an imported cluster.
+	SetStatus(ctx context.Context, clusterID uint, status string, statusMessage string) error
+
+	// SaveHealthReport saves the result of the latest health check of an imported cluster.
+	SaveHealthReport(ctx context.Context, clusterID uint, report HealthReport) error
+}
+
+// +testify:mock:testOnly=true
+
+// ConfigGetter returns the Kubernetes client configuration of a cluster.
+type ConfigGetter interface {
+	GetKubeConfig(ctx context.Context, clusterID uint) (*rest.Config, error)
+}
+
+// ClientFactory creates a Kubernetes client from a client configuration.
+type ClientFactory interface {
+	FromConfig(config *rest.Config) (kubernetes.Interface, error)
+}
+
+// ClientFactoryFunc converts an ordinary function to a ClientFactory.
+type ClientFactoryFunc func(config *rest.Config) (kubernetes.Interface, error)
+
+// FromConfig calls the underlying function.
+func (f ClientFactoryFunc) FromConfig(config *rest.Config) (kubernetes.Interface, error) {
+	return f(config)
+}
+
+// HealthChecker checks the health of imported clusters.
+type HealthChecker struct {
+	store         Store
+	configs       ConfigGetter
+	clientFactory ClientFactory
+
+	credentialExpiryWarning time.Duration
+
+	logger common.Logger
+}
+
+// NewHealthChecker returns a new HealthChecker.
+func NewHealthChecker(
+	store Store,
+	configs ConfigGetter,
+	clientFactory ClientFactory,
+	credentialExpiryWarning time.Duration,
+	logger common.Logger,
+) HealthChecker {
+	return HealthChecker{
+		store:         store,
+		configs:       configs,
+		clientFactory: clientFactory,
+
+		credentialExpiryWarning: credentialExpiryWarning,
+
+		logger: logger,
+	}
+}
+
+// CheckCluster checks the API server reachability, the version, the nodes and the credentials of an imported cluster.
+// The report is saved and the cluster is marked with a warning if it is unhealthy.
+// Clusters not running (eg. being deleted) are skipped and a nil report is returned.
+func (c HealthChecker) CheckCluster(ctx context.Context, clusterID uint) (*HealthReport, error) {
+	logger := c.logger.WithFields(map[string]interface{}{"clusterId": clusterID})
+
+	cl, err := c.store.GetCluster(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cl.Status != cluster.Running && cl.Status != cluster.Warning {
+		logger.Debug("skipping health check of cluster", map[string]interface{}{"status": cl.Status})
+
+		return nil, nil
+	}
+
+	config, err := c.configs.GetKubeConfig(ctx, clusterID)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to get Kubernetes config", "clusterId", clusterID)
+	}
+
+	client, err := c.clientFactory.FromConfig(config)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to create Kubernetes client", "clusterId", clusterID)
+	}
+
+	report := HealthReport{
+		CheckedAt:           time.Now().UTC(),
+		CredentialExpiresAt: CredentialExpiry(config),
+	}
+
+	var problems []string
+
+	version, err := client.Discovery().ServerVersion()
+	if err != nil {
+		problems = append(problems, fmt.Sprintf("%s: %s", unreachableMessage, err.Error()))
+	} else {
+		report.Reachable = true
+		report.Version = version.GitVersion
+
+		// the kubeconfig might not be allowed to list nodes, that is not a health problem on its own
+		nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+		if err != nil {
+			logger.Warn("failed to list nodes", map[string]interface{}{"error": err.Error()})
+		} else {
+			report.NodePools = NodePoolsFromNodes(nodes.Items)
+		}
+	}
+
+	if expiresAt := report.CredentialExpiresAt; expiresAt != nil {
+		if !expiresAt.After(report.CheckedAt) {
+			problems = append(problems, fmt.Sprintf("%s at %s", credentialsExpiredMessage, expiresAt.Format(time.RFC3339)))
+		} else if expiresAt.Sub(report.CheckedAt) < c.credentialExpiryWarning {
+			problems = append(problems, fmt.Sprintf("%s (%s)", credentialsExpiringMessage, expiresAt.Format(time.RFC3339)))
+		}
+	}
+
+	if err := c.store.SaveHealthReport(ctx, clusterID, report); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to save health report", "clusterId", clusterID)
+	}
+
+	if len(problems) > 0 {
+		statusMessage := strings.Join(problems, "; ")
+
+		if cl.Status != cluster.Warning || cl.StatusMessage != statusMessage {
+			logger.Info("cluster is unhealthy", map[string]interface{}{"problems": statusMessage})
+
+			if err := c.store.SetStatus(ctx, clusterID, cluster.Warning, statusMessage); err != nil {
+				return nil, errors.WrapIfWithDetails(err, "failed to set cluster status", "clusterId", clusterID)
+			}
+		}
+	} else if cl.Status == cluster.Warning && isHealthCheckMessage(cl.StatusMessage) {
+		logger.Info("cluster is healthy again")
+
+		if err := c.store.SetStatus(ctx, clusterID, cluster.Running, cluster.RunningMessage); err != nil {
+			return nil, errors.WrapIfWithDetails(err, "failed to set cluster status", "clusterId", clusterID)
+		}
+	}
+
+	return &report, nil
+}
+
+func isHealthCheckMessage(statusMessage string) bool {
+	for _, message := range []string{unreachableMessage, credentialsExpiredMessage, credentialsExpiringMessage} {
+		if strings.HasPrefix(statusMessage, message) {
+			return true
+		}
+	}
+
+	return false
+}
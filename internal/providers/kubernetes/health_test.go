@@ -0,0 +1,191 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+func newFakeClientFactory(gitVersion string, objects ...runtime.Object) ClientFactory {
+	return ClientFactoryFunc(func(_ *rest.Config) (kubernetes.Interface, error) {
+		client := fake.NewSimpleClientset(objects...)
+		client.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: gitVersion}
+
+		return client, nil
+	})
+}
+
+func TestHealthChecker_CheckCluster(t *testing.T) {
+	const clusterID = uint(1)
+
+	node := newTestNode("node", map[string]string{"nodepool.banzaicloud.io/name": "pool1"}, true, "v1.17.3")
+
+	t.Run("Healthy", func(t *testing.T) {
+		ctx := context.Background()
+
+		store := new(MockStore)
+		store.On("GetCluster", ctx, clusterID).Return(Cluster{ID: clusterID, Status: cluster.Running}, nil)
+		store.On("SaveHealthReport", ctx, clusterID, mock.MatchedBy(func(report HealthReport) bool {
+			return report.Reachable && report.Version == "v1.17.3" &&
+				assert.ObjectsAreEqual([]NodePool{{Name: "pool1", Count: 1, ReadyCount: 1, Version: "v1.17.3"}}, report.NodePools)
+		})).Return(nil)
+
+		configs := new(MockConfigGetter)
+		configs.On("GetKubeConfig", ctx, clusterID).Return(&rest.Config{}, nil)
+
+		checker := NewHealthChecker(store, configs, newFakeClientFactory("v1.17.3", &node), time.Hour, common.NoopLogger{})
+
+		report, err := checker.CheckCluster(ctx, clusterID)
+		require.NoError(t, err)
+		require.NotNil(t, report)
+
+		store.AssertExpectations(t)
+		configs.AssertExpectations(t)
+	})
+
+	t.Run("Recovered", func(t *testing.T) {
+		ctx := context.Background()
+
+		store := new(MockStore)
+		store.On("GetCluster", ctx, clusterID).Return(Cluster{ID: clusterID, Status: cluster.Warning, StatusMessage: unreachableMessage + ": timeout"}, nil)
+		store.On("SaveHealthReport", ctx, clusterID, mock.Anything).Return(nil)
+		store.On("SetStatus", ctx, clusterID, cluster.Running, cluster.RunningMessage).Return(nil)
+
+		configs := new(MockConfigGetter)
+		configs.On("GetKubeConfig", ctx, clusterID).Return(&rest.Config{}, nil)
+
+		checker := NewHealthChecker(store, configs, newFakeClientFactory("v1.17.3"), time.Hour, common.NoopLogger{})
+
+		_, err := checker.CheckCluster(ctx, clusterID)
+		require.NoError(t, err)
+
+		store.AssertExpectations(t)
+		configs.AssertExpectations(t)
+	})
+
+	t.Run("ForeignWarningKept", func(t *testing.T) {
+		ctx := context.Background()
+
+		store := new(MockStore)
+		store.On("GetCluster", ctx, clusterID).Return(Cluster{ID: clusterID, Status: cluster.Warning, StatusMessage: "posthook failed"}, nil)
+		store.On("SaveHealthReport", ctx, clusterID, mock.Anything).Return(nil)
+
+		configs := new(MockConfigGetter)
+		configs.On("GetKubeConfig", ctx, clusterID).Return(&rest.Config{}, nil)
+
+		checker := NewHealthChecker(store, configs, newFakeClientFactory("v1.17.3"), time.Hour, common.NoopLogger{})
+
+		_, err := checker.CheckCluster(ctx, clusterID)
+		require.NoError(t, err)
+
+		store.AssertExpectations(t)
+		configs.AssertExpectations(t)
+	})
+
+	t.Run("Unreachable", func(t *testing.T) {
+		ctx := context.Background()
+
+		store := new(MockStore)
+		store.On("GetCluster", ctx, clusterID).Return(Cluster{ID: clusterID, Status: cluster.Running}, nil)
+		store.On("SaveHealthReport", ctx, clusterID, mock.MatchedBy(func(report HealthReport) bool {
+			return !report.Reachable
+		})).Return(nil)
+		store.On("SetStatus", ctx, clusterID, cluster.Warning, mock.MatchedBy(func(message string) bool {
+			return strings.HasPrefix(message, unreachableMessage)
+		})).Return(nil)
+
+		// nothing listens on this address
+		config := &rest.Config{Host: "http://127.0.0.1:1", Timeout: time.Second}
+
+		configs := new(MockConfigGetter)
+		configs.On("GetKubeConfig", ctx, clusterID).Return(config, nil)
+
+		clientFactory := ClientFactoryFunc(func(config *rest.Config) (kubernetes.Interface, error) {
+			return kubernetes.NewForConfig(config)
+		})
+
+		checker := NewHealthChecker(store, configs, clientFactory, time.Hour, common.NoopLogger{})
+
+		report, err := checker.CheckCluster(ctx, clusterID)
+		require.NoError(t, err)
+		require.NotNil(t, report)
+
+		assert.False(t, report.Reachable)
+
+		store.AssertExpectations(t)
+		configs.AssertExpectations(t)
+	})
+
+	t.Run("CredentialsExpiring", func(t *testing.T) {
+		ctx := context.Background()
+
+		config := &rest.Config{BearerToken: newTestToken(time.Now().Add(time.Hour))}
+
+		store := new(MockStore)
+		store.On("GetCluster", ctx, clusterID).Return(Cluster{ID: clusterID, Status: cluster.Running}, nil)
+		store.On("SaveHealthReport", ctx, clusterID, mock.MatchedBy(func(report HealthReport) bool {
+			return report.CredentialExpiresAt != nil
+		})).Return(nil)
+		store.On("SetStatus", ctx, clusterID, cluster.Warning, mock.MatchedBy(func(message string) bool {
+			return strings.HasPrefix(message, credentialsExpiringMessage)
+		})).Return(nil)
+
+		configs := new(MockConfigGetter)
+		configs.On("GetKubeConfig", ctx, clusterID).Return(config, nil)
+
+		checker := NewHealthChecker(store, configs, newFakeClientFactory("v1.17.3"), 24*time.Hour, common.NoopLogger{})
+
+		_, err := checker.CheckCluster(ctx, clusterID)
+		require.NoError(t, err)
+
+		store.AssertExpectations(t)
+		configs.AssertExpectations(t)
+	})
+
+	t.Run("NotRunning", func(t *testing.T) {
+		ctx := context.Background()
+
+		store := new(MockStore)
+		store.On("GetCluster", ctx, clusterID).Return(Cluster{ID: clusterID, Status: cluster.Deleting}, nil)
+
+		configs := new(MockConfigGetter)
+
+		checker := NewHealthChecker(store, configs, newFakeClientFactory("v1.17.3"), time.Hour, common.NoopLogger{})
+
+		report, err := checker.CheckCluster(ctx, clusterID)
+		require.NoError(t, err)
+
+		assert.Nil(t, report)
+
+		store.AssertExpectations(t)
+		configs.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,140 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+)
+
+const (
+	defaultNodePoolName = "default"
+	masterNodePoolName  = "master"
+
+	masterNodeRoleLabel = "node-role.kubernetes.io/master"
+)
+
+// nodePoolLabels are the node labels marking node pool membership in the order of precedence.
+// Besides Pipeline's own label, the labels of the well-known managed Kubernetes offerings are recognized.
+var nodePoolLabels = []string{
+	pkgCommon.LabelKey,
+	"eks.amazonaws.com/nodegroup",
+	"alpha.eksctl.io/nodegroup-name",
+	"cloud.google.com/gke-nodepool",
+	"kubernetes.azure.com/agentpool",
+	"agentpool",
+	"doks.digitalocean.com/node-pool",
+}
+
+// NodePool is a group of nodes of an imported cluster derived from node labels.
+type NodePool struct {
+	Name       string `json:"name"`
+	Count      int    `json:"count"`
+	ReadyCount int    `json:"readyCount"`
+
+	// InstanceType and Version are only set if every node of the node pool shares them
+	InstanceType string `json:"instanceType,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+// NodePoolsFromNodes groups the nodes of a cluster into node pools based on their labels.
+// Nodes without a node pool label end up in the "master" or the "default" node pool.
+func NodePoolsFromNodes(nodes []corev1.Node) []NodePool {
+	nodePools := make(map[string]*NodePool)
+	mixed := make(map[string]bool)
+
+	for _, node := range nodes {
+		name := getNodePoolName(node)
+		instanceType := getInstanceType(node)
+		version := node.Status.NodeInfo.KubeletVersion
+
+		nodePool, ok := nodePools[name]
+		if !ok {
+			nodePool = &NodePool{
+				Name:         name,
+				InstanceType: instanceType,
+				Version:      version,
+			}
+			nodePools[name] = nodePool
+		}
+
+		nodePool.Count++
+		if isNodeReady(node) {
+			nodePool.ReadyCount++
+		}
+
+		if nodePool.InstanceType != instanceType {
+			mixed[name+"/instanceType"] = true
+		}
+
+		if nodePool.Version != version {
+			mixed[name+"/version"] = true
+		}
+	}
+
+	result := make([]NodePool, 0, len(nodePools))
+	for name, nodePool := range nodePools {
+		if mixed[name+"/instanceType"] {
+			nodePool.InstanceType = ""
+		}
+
+		if mixed[name+"/version"] {
+			nodePool.Version = ""
+		}
+
+		result = append(result, *nodePool)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+func getNodePoolName(node corev1.Node) string {
+	for _, label := range nodePoolLabels {
+		if name := node.Labels[label]; name != "" {
+			return name
+		}
+	}
+
+	if _, ok := node.Labels[masterNodeRoleLabel]; ok {
+		return masterNodePoolName
+	}
+
+	return defaultNodePoolName
+}
+
+func getInstanceType(node corev1.Node) string {
+	if instanceType := node.Labels[corev1.LabelInstanceTypeStable]; instanceType != "" {
+		return instanceType
+	}
+
+	return node.Labels[corev1.LabelInstanceType]
+}
+
+func isNodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
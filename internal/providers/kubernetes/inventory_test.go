@@ -0,0 +1,89 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestNode(name string, labels map[string]string, ready bool, version string) corev1.Node {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: status,
+				},
+			},
+			NodeInfo: corev1.NodeSystemInfo{
+				KubeletVersion: version,
+			},
+		},
+	}
+}
+
+func TestNodePoolsFromNodes(t *testing.T) {
+	nodes := []corev1.Node{
+		newTestNode("master", map[string]string{"node-role.kubernetes.io/master": ""}, true, "v1.17.3"),
+		newTestNode("pool1-a", map[string]string{"nodepool.banzaicloud.io/name": "pool1", "node.kubernetes.io/instance-type": "m5.large"}, true, "v1.17.3"),
+		newTestNode("pool1-b", map[string]string{"nodepool.banzaicloud.io/name": "pool1", "beta.kubernetes.io/instance-type": "m5.large"}, false, "v1.17.3"),
+		newTestNode("gke-a", map[string]string{"cloud.google.com/gke-nodepool": "gke-pool", "node.kubernetes.io/instance-type": "n1-standard-2"}, true, "v1.16.8"),
+		newTestNode("gke-b", map[string]string{"cloud.google.com/gke-nodepool": "gke-pool", "node.kubernetes.io/instance-type": "n1-standard-4"}, true, "v1.16.8"),
+		newTestNode("other", nil, true, "v1.17.2"),
+	}
+
+	expected := []NodePool{
+		{
+			Name:       "default",
+			Count:      1,
+			ReadyCount: 1,
+			Version:    "v1.17.2",
+		},
+		{
+			Name:       "gke-pool",
+			Count:      2,
+			ReadyCount: 2,
+			Version:    "v1.16.8",
+		},
+		{
+			Name:       "master",
+			Count:      1,
+			ReadyCount: 1,
+			Version:    "v1.17.3",
+		},
+		{
+			Name:         "pool1",
+			Count:        2,
+			ReadyCount:   1,
+			InstanceType: "m5.large",
+			Version:      "v1.17.3",
+		},
+	}
+
+	assert.Equal(t, expected, NodePoolsFromNodes(nodes))
+}
@@ -15,7 +15,12 @@
 package kubernetesadapter
 
 import (
+	"database/sql/driver"
 	"encoding/json"
+	"time"
+
+	sqljson "github.com/banzaicloud/pipeline/internal/database/sql/json"
+	"github.com/banzaicloud/pipeline/internal/providers/kubernetes"
 )
 
 // KubernetesClusterModel describes the build your own cluster model
@@ -23,6 +28,32 @@ type KubernetesClusterModel struct {
 	ID          uint              `gorm:"primary_key"`
 	Metadata    map[string]string `gorm:"-"`
 	MetadataRaw []byte            `gorm:"meta_data"`
+
+	// Fields updated by the periodic health check
+	Version             string
+	Reachable           bool
+	HealthCheckedAt     *time.Time
+	CredentialExpiresAt *time.Time
+	NodePools           NodePools `gorm:"type:text"`
+}
+
+// NodePools is the node pool inventory of an imported cluster, persisted in a single database column.
+type NodePools []kubernetes.NodePool
+
+// Value implements the driver.Valuer interface
+func (p NodePools) Value() (driver.Value, error) {
+	return sqljson.Value(p)
+}
+
+// Scan implements the sql.Scanner interface
+func (p *NodePools) Scan(src interface{}) error {
+	if src == nil {
+		*p = nil
+
+		return nil
+	}
+
+	return sqljson.Scan(src, p)
 }
 
 // BeforeSave converts the metadata into a json string in case of Kubernetes
@@ -0,0 +1,106 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetesadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter/clustermodel"
+	"github.com/banzaicloud/pipeline/internal/providers/kubernetes"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+// ClusterStatusSetter sets the status of a cluster.
+type ClusterStatusSetter interface {
+	SetStatus(ctx context.Context, id uint, status string, message string) error
+}
+
+// GormClusterStore persists the health of imported clusters in a database.
+type GormClusterStore struct {
+	db       *gorm.DB
+	statuses ClusterStatusSetter
+}
+
+// NewGormClusterStore returns a new GormClusterStore.
+func NewGormClusterStore(db *gorm.DB, statuses ClusterStatusSetter) GormClusterStore {
+	return GormClusterStore{
+		db:       db,
+		statuses: statuses,
+	}
+}
+
+// ListClusters returns the IDs of the running imported clusters.
+func (s GormClusterStore) ListClusters(ctx context.Context) ([]uint, error) {
+	var ids []uint
+
+	err := s.db.Model(&clustermodel.ClusterModel{}).
+		Where("cloud = ? AND status IN (?)", pkgCluster.Kubernetes, []string{cluster.Running, cluster.Warning}).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list imported clusters")
+	}
+
+	return ids, nil
+}
+
+// GetCluster returns an imported cluster.
+func (s GormClusterStore) GetCluster(ctx context.Context, clusterID uint) (kubernetes.Cluster, error) {
+	var model clustermodel.ClusterModel
+
+	err := s.db.Where(clustermodel.ClusterModel{ID: clusterID, Cloud: pkgCluster.Kubernetes}).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return kubernetes.Cluster{}, errors.WithStack(cluster.NotFoundError{ClusterID: clusterID})
+	} else if err != nil {
+		return kubernetes.Cluster{}, errors.WrapIfWithDetails(err, "failed to get cluster", "clusterId", clusterID)
+	}
+
+	return kubernetes.Cluster{
+		ID:             model.ID,
+		OrganizationID: model.OrganizationID,
+		Status:         model.Status,
+		StatusMessage:  model.StatusMessage,
+	}, nil
+}
+
+// SetStatus sets the status of an imported cluster.
+func (s GormClusterStore) SetStatus(ctx context.Context, clusterID uint, status string, statusMessage string) error {
+	return s.statuses.SetStatus(ctx, clusterID, status, statusMessage)
+}
+
+// SaveHealthReport saves the result of the latest health check of an imported cluster.
+func (s GormClusterStore) SaveHealthReport(ctx context.Context, clusterID uint, report kubernetes.HealthReport) error {
+	fields := map[string]interface{}{
+		"version":               report.Version,
+		"reachable":             report.Reachable,
+		"health_checked_at":     report.CheckedAt,
+		"credential_expires_at": report.CredentialExpiresAt,
+	}
+
+	// keep the last known inventory of unreachable clusters
+	if report.Reachable {
+		fields["node_pools"] = NodePools(report.NodePools)
+	}
+
+	err := s.db.Model(&KubernetesClusterModel{ID: clusterID}).Updates(fields).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to save cluster health report", "clusterId", clusterID)
+	}
+
+	return nil
+}
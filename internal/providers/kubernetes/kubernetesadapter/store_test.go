@@ -0,0 +1,111 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetesadapter
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter/clustermodel"
+	"github.com/banzaicloud/pipeline/internal/providers/kubernetes"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	logger := logrus.New()
+	logger.SetOutput(ioutil.Discard)
+
+	err = db.AutoMigrate(&clustermodel.ClusterModel{}).Error
+	require.NoError(t, err)
+
+	err = Migrate(db, logger)
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormClusterStore(t *testing.T) {
+	db := setUpDatabase(t)
+	store := NewGormClusterStore(db, nil)
+	ctx := context.Background()
+
+	clusters := []clustermodel.ClusterModel{
+		{ID: 1, Name: "imported", Cloud: "kubernetes", OrganizationID: 1, Status: cluster.Running},
+		{ID: 2, Name: "warning", Cloud: "kubernetes", OrganizationID: 1, Status: cluster.Warning, StatusMessage: "warning"},
+		{ID: 3, Name: "deleting", Cloud: "kubernetes", OrganizationID: 1, Status: cluster.Deleting},
+		{ID: 4, Name: "pke", Cloud: "amazon", OrganizationID: 1, Status: cluster.Running},
+	}
+	for _, c := range clusters {
+		c := c
+		require.NoError(t, db.Create(&c).Error)
+		require.NoError(t, db.Create(&KubernetesClusterModel{ID: c.ID}).Error)
+	}
+
+	ids, err := store.ListClusters(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint{1, 2}, ids)
+
+	c, err := store.GetCluster(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, kubernetes.Cluster{ID: 2, OrganizationID: 1, Status: cluster.Warning, StatusMessage: "warning"}, c)
+
+	_, err = store.GetCluster(ctx, 4)
+	assert.True(t, errors.As(err, &cluster.NotFoundError{}))
+
+	checkedAt := time.Date(2020, time.March, 27, 12, 0, 0, 0, time.UTC)
+	expiresAt := checkedAt.Add(24 * time.Hour)
+
+	report := kubernetes.HealthReport{
+		CheckedAt: checkedAt,
+		Reachable: true,
+		Version:   "v1.17.3",
+		NodePools: []kubernetes.NodePool{
+			{Name: "pool1", Count: 2, ReadyCount: 2, InstanceType: "m5.large", Version: "v1.17.3"},
+		},
+		CredentialExpiresAt: &expiresAt,
+	}
+	require.NoError(t, store.SaveHealthReport(ctx, 1, report))
+
+	var model KubernetesClusterModel
+	require.NoError(t, db.Where(KubernetesClusterModel{ID: 1}).First(&model).Error)
+
+	assert.Equal(t, "v1.17.3", model.Version)
+	assert.True(t, model.Reachable)
+	assert.True(t, checkedAt.Equal(*model.HealthCheckedAt))
+	assert.True(t, expiresAt.Equal(*model.CredentialExpiresAt))
+	assert.Equal(t, NodePools(report.NodePools), model.NodePools)
+
+	// the inventory of unreachable clusters is kept
+	require.NoError(t, store.SaveHealthReport(ctx, 1, kubernetes.HealthReport{CheckedAt: checkedAt.Add(time.Hour)}))
+
+	model = KubernetesClusterModel{}
+	require.NoError(t, db.Where(KubernetesClusterModel{ID: 1}).First(&model).Error)
+
+	assert.False(t, model.Reachable)
+	assert.Nil(t, model.CredentialExpiresAt)
+	assert.Equal(t, NodePools(report.NodePools), model.NodePools)
+}
@@ -0,0 +1,108 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetesworkflow
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/providers/kubernetes"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+const ListClustersActivityName = "kubernetes-list-imported-clusters-activity"
+
+type ListClustersActivityInput struct{}
+
+type ListClustersActivityOutput struct {
+	ClusterIDs []uint
+}
+
+// ListClustersActivity lists the imported clusters that should be checked.
+type ListClustersActivity struct {
+	clusters kubernetes.Store
+}
+
+// NewListClustersActivity returns a new ListClustersActivity.
+func NewListClustersActivity(clusters kubernetes.Store) ListClustersActivity {
+	return ListClustersActivity{
+		clusters: clusters,
+	}
+}
+
+func (a ListClustersActivity) Execute(ctx context.Context, _ ListClustersActivityInput) (ListClustersActivityOutput, error) {
+	clusterIDs, err := a.clusters.ListClusters(ctx)
+	if err != nil {
+		return ListClustersActivityOutput{}, err
+	}
+
+	return ListClustersActivityOutput{ClusterIDs: clusterIDs}, nil
+}
+
+const CheckClusterHealthActivityName = "kubernetes-check-imported-cluster-health-activity"
+
+type CheckClusterHealthActivityInput struct {
+	ClusterID uint
+}
+
+type CheckClusterHealthActivityOutput struct {
+	// Skipped is true when the cluster was not running at the time of the check
+	Skipped bool
+
+	Reachable           bool
+	Version             string
+	CredentialExpiresAt *time.Time
+}
+
+// CheckClusterHealthActivity checks the health of an imported cluster.
+type CheckClusterHealthActivity struct {
+	clusters kubernetes.Store
+	checker  kubernetes.HealthChecker
+}
+
+// NewCheckClusterHealthActivity returns a new CheckClusterHealthActivity.
+func NewCheckClusterHealthActivity(clusters kubernetes.Store, checker kubernetes.HealthChecker) CheckClusterHealthActivity {
+	return CheckClusterHealthActivity{
+		clusters: clusters,
+		checker:  checker,
+	}
+}
+
+func (a CheckClusterHealthActivity) Execute(ctx context.Context, input CheckClusterHealthActivityInput) (CheckClusterHealthActivityOutput, error) {
+	cluster, err := a.clusters.GetCluster(ctx, input.ClusterID)
+	if err != nil {
+		return CheckClusterHealthActivityOutput{}, errors.WrapIfWithDetails(err, "failed to get cluster", "clusterId", input.ClusterID)
+	}
+
+	// the kubeconfig secret is looked up in the organization of the cluster
+	ctx = auth.SetCurrentOrganizationID(ctx, cluster.OrganizationID)
+
+	report, err := a.checker.CheckCluster(ctx, input.ClusterID)
+	if err != nil {
+		return CheckClusterHealthActivityOutput{}, err
+	}
+
+	if report == nil {
+		return CheckClusterHealthActivityOutput{Skipped: true}, nil
+	}
+
+	return CheckClusterHealthActivityOutput{
+		Reachable:           report.Reachable,
+		Version:             report.Version,
+		CredentialExpiresAt: report.CredentialExpiresAt,
+	}, nil
+}
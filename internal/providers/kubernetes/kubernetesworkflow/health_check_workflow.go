@@ -0,0 +1,67 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetesworkflow
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/workflow"
+)
+
+const HealthCheckWorkflowName = "kubernetes-imported-cluster-health-check"
+
+// HealthCheckWorkflow checks the health of every running imported cluster.
+// It is supposed to be scheduled as a cron workflow.
+func HealthCheckWorkflow(ctx workflow.Context) error {
+	logger := workflow.GetLogger(ctx).Sugar()
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    5 * time.Minute,
+		WaitForCancellation:    true,
+	})
+
+	var clusters ListClustersActivityOutput
+
+	if err := workflow.ExecuteActivity(ctx, ListClustersActivityName, ListClustersActivityInput{}).Get(ctx, &clusters); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to execute activity", "activity", ListClustersActivityName)
+	}
+
+	var failed int
+
+	for _, clusterID := range clusters.ClusterIDs {
+		var output CheckClusterHealthActivityOutput
+
+		err := workflow.ExecuteActivity(ctx, CheckClusterHealthActivityName, CheckClusterHealthActivityInput{ClusterID: clusterID}).Get(ctx, &output)
+		if err != nil {
+			failed++
+
+			logger.Warnw("failed to check imported cluster health", "clusterId", clusterID, "error", err.Error())
+
+			continue
+		}
+
+		if !output.Skipped && !output.Reachable {
+			logger.Infow("imported cluster is not reachable", "clusterId", clusterID)
+		}
+	}
+
+	if failed > 0 {
+		return errors.NewWithDetails("failed to check the health of some imported clusters", "failed", failed, "clusters", len(clusters.ClusterIDs))
+	}
+
+	return nil
+}
@@ -24,9 +24,11 @@ import (
 	"github.com/banzaicloud/pipeline/internal/providers/amazon"
 	"github.com/banzaicloud/pipeline/internal/providers/azure"
 	"github.com/banzaicloud/pipeline/internal/providers/azure/pke/adapter"
+	baremetal "github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/adapter"
 	"github.com/banzaicloud/pipeline/internal/providers/google"
 	"github.com/banzaicloud/pipeline/internal/providers/oracle"
 	"github.com/banzaicloud/pipeline/internal/providers/pke"
+	"github.com/banzaicloud/pipeline/internal/providers/s3compatible"
 	vsphere "github.com/banzaicloud/pipeline/internal/providers/vsphere/pke/adapter"
 )
 
@@ -52,6 +54,10 @@ func Migrate(db *gorm.DB, logger logrus.FieldLogger) error {
 		return err
 	}
 
+	if err := s3compatible.Migrate(db, logger); err != nil {
+		return err
+	}
+
 	if err := pke.Migrate(db, logger); err != nil {
 		return err
 	}
@@ -72,5 +78,9 @@ func Migrate(db *gorm.DB, logger logrus.FieldLogger) error {
 		return err
 	}
 
+	if err := baremetal.Migrate(db, logger); err != nil {
+		return err
+	}
+
 	return nil
 }
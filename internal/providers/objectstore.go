@@ -24,8 +24,14 @@ import (
 	"github.com/banzaicloud/pipeline/internal/providers/azure"
 	"github.com/banzaicloud/pipeline/internal/providers/google"
 	"github.com/banzaicloud/pipeline/internal/providers/oracle"
+	"github.com/banzaicloud/pipeline/internal/providers/s3compatible"
 	pkgErrors "github.com/banzaicloud/pipeline/pkg/errors"
+	commonObjectstore "github.com/banzaicloud/pipeline/pkg/objectstore"
 	"github.com/banzaicloud/pipeline/pkg/providers"
+	alibabaObjectstore "github.com/banzaicloud/pipeline/pkg/providers/alibaba/objectstore"
+	azureObjectstore "github.com/banzaicloud/pipeline/pkg/providers/azure/objectstore"
+	googleObjectstore "github.com/banzaicloud/pipeline/pkg/providers/google/objectstore"
+	oracleObjectstore "github.com/banzaicloud/pipeline/pkg/providers/oracle/objectstore"
 	"github.com/banzaicloud/pipeline/src/auth"
 	"github.com/banzaicloud/pipeline/src/secret"
 )
@@ -68,6 +74,9 @@ func NewObjectStore(ctx *ObjectStoreContext, logger logrus.FieldLogger) (objects
 	case providers.Oracle:
 		return oracle.NewObjectStore(ctx.Location, ctx.Secret, ctx.Organization, db, logger, ctx.ForceOperation)
 
+	case providers.S3Compatible:
+		return s3compatible.NewObjectStore(ctx.Secret, ctx.Organization, db, logger, ctx.ForceOperation)
+
 	default:
 		return nil, pkgErrors.ErrorNotSupportedCloudType
 	}
@@ -87,3 +96,33 @@ func GetBucketLocation(provider string, secret *secret.SecretItemResponse, bucke
 		return "", pkgErrors.ErrorNotSupportedCloudType
 	}
 }
+
+// ValidateBucketSettings checks whether the given bucket settings are valid and supported by the cloud provider.
+func ValidateBucketSettings(provider string, settings commonObjectstore.BucketSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	switch provider {
+	case providers.Alibaba:
+		return alibabaObjectstore.ValidateBucketSettings(settings)
+
+	case providers.Amazon:
+		return nil
+
+	case providers.Azure:
+		return azureObjectstore.ValidateBucketSettings(settings)
+
+	case providers.Google:
+		return googleObjectstore.ValidateBucketSettings(settings)
+
+	case providers.Oracle:
+		return oracleObjectstore.ValidateBucketSettings(settings)
+
+	case providers.S3Compatible:
+		return nil
+
+	default:
+		return pkgErrors.ErrorNotSupportedCloudType
+	}
+}
@@ -38,6 +38,8 @@ func (bucketNotFoundError) NotFound() bool { return true }
 
 type oracleObjectStore interface {
 	commonObjectstore.ObjectStore
+	commonObjectstore.BucketSettingsManager
+	commonObjectstore.ObjectInfoWalker
 	// GetNamespace returns client namespace
 	GetNamespace() string
 }
@@ -126,7 +128,7 @@ func (o *ObjectStore) getLogger() logrus.FieldLogger {
 }
 
 // CreateBucket creates an Oracle object store bucket with the given name and stores it in the database
-func (o *ObjectStore) CreateBucket(bucketName string) error {
+func (o *ObjectStore) CreateBucket(bucketName string, settings *commonObjectstore.BucketSettings) error {
 	logger := o.getLogger().WithField("bucket", bucketName)
 
 	bucket := &ObjectStoreBucketModel{}
@@ -160,6 +162,14 @@ func (o *ObjectStore) CreateBucket(bucketName string) error {
 		return o.createFailed(bucket, errors.WrapIf(err, "failed to create the bucket"))
 	}
 
+	if settings != nil {
+		if err := o.objectStore.SetBucketSettings(bucketName, *settings); err != nil {
+			return o.createFailed(bucket, errors.WrapIf(err, "failed to apply bucket settings"))
+		}
+
+		bucket.Settings = *settings
+	}
+
 	bucket.Status = providers.BucketCreated
 	// save Namespace
 	bucket.Namespace = o.objectStore.GetNamespace()
@@ -231,6 +241,7 @@ func (o *ObjectStore) ListManagedBuckets() ([]*objectstore.BucketInfo, error) {
 
 	bucketList := make([]*objectstore.BucketInfo, 0)
 	for _, bucket := range oracleBuckets {
+		settings := bucket.Settings
 		bucketList = append(bucketList, &objectstore.BucketInfo{
 			Name:      bucket.Name,
 			Managed:   true,
@@ -242,6 +253,7 @@ func (o *ObjectStore) ListManagedBuckets() ([]*objectstore.BucketInfo, error) {
 			},
 			Status:    bucket.Status,
 			StatusMsg: bucket.StatusMsg,
+			Settings:  &settings,
 		})
 	}
 
@@ -315,6 +327,79 @@ func (o *ObjectStore) deleteFailed(bucket *ObjectStoreBucketModel, reason error)
 	return reason
 }
 
+// UpdateBucket applies the given settings to the Oracle object store bucket identified by the specified name
+// provided the storage container is of 'managed' type.
+func (o *ObjectStore) UpdateBucket(bucketName string, settings commonObjectstore.BucketSettings) error {
+	logger := o.getLogger().WithField("bucket", bucketName)
+
+	bucket := &ObjectStoreBucketModel{}
+	searchCriteria := o.newBucketSearchCriteria(bucketName)
+
+	logger.Info("looking up the bucket...")
+
+	if err := o.db.Where(searchCriteria).Find(bucket).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return bucketNotFoundError{}
+		}
+		return errors.WrapIfWithDetails(err, "failed to lookup", "bucket", bucketName)
+	}
+
+	if bucket.Status != providers.BucketCreated {
+		return errors.WithDetails(errors.New("bucket is not in a state that allows updating it"), "bucket", bucketName, "status", bucket.Status)
+	}
+
+	objectStore, err := getProviderObjectStore(o.secret, bucket.Location)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to create object store", "bucket", bucketName)
+	}
+
+	logger.Info("updating bucket settings...")
+
+	if err := objectStore.SetBucketSettings(bucketName, settings); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to update bucket settings on provider", "bucket", bucketName)
+	}
+
+	bucket.Settings = settings
+	if err := o.db.Save(bucket).Error; err != nil {
+		return errors.WrapIfWithDetails(err, "failed to save bucket", "bucket", bucketName)
+	}
+	logger.Info("bucket settings updated")
+
+	return nil
+}
+
+// WalkBucketObjects calls fn for every object (including its size) stored in the managed Oracle object storage bucket.
+func (o *ObjectStore) WalkBucketObjects(bucketName string, fn func(commonObjectstore.ObjectInfo) error) error {
+	logger := o.getLogger().WithField("bucket", bucketName)
+
+	bucket := &ObjectStoreBucketModel{}
+	searchCriteria := o.newBucketSearchCriteria(bucketName)
+
+	logger.Info("looking up the bucket...")
+
+	if err := o.db.Where(searchCriteria).Find(bucket).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return bucketNotFoundError{}
+		}
+		return errors.WrapIfWithDetails(err, "failed to lookup", "bucket", bucketName)
+	}
+
+	if bucket.Status != providers.BucketCreated {
+		return errors.WithDetails(errors.New("bucket is not in a state that allows listing its objects"), "bucket", bucketName, "status", bucket.Status)
+	}
+
+	objectStore, err := getProviderObjectStore(o.secret, bucket.Location)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to create object store", "bucket", bucketName)
+	}
+
+	if err := objectStore.WalkObjectInfos(bucketName, "", fn); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to list bucket objects on provider", "bucket", bucketName)
+	}
+
+	return nil
+}
+
 // CheckBucket check the status of the given Oracle object store bucket
 func (o *ObjectStore) CheckBucket(bucketName string) error {
 	logger := o.getLogger().WithField("bucket", bucketName)
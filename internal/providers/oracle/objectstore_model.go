@@ -15,6 +15,7 @@
 package oracle
 
 import (
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
 	"github.com/banzaicloud/pipeline/src/auth"
 )
 
@@ -38,6 +39,8 @@ type ObjectStoreBucketModel struct {
 	Status    string
 	StatusMsg string `sql:"type:text;"`
 	Namespace string
+
+	Settings objectstore.BucketSettings `gorm:"type:json"`
 }
 
 // TableName changes the default table name.
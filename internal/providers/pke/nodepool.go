@@ -22,6 +22,7 @@ import (
 	"github.com/spf13/cast"
 
 	"github.com/banzaicloud/pipeline/internal/database/sql/json"
+	"github.com/banzaicloud/pipeline/pkg/common"
 )
 
 type NodePools []NodePool
@@ -39,6 +40,7 @@ type NodePool struct {
 	Provider       NodePoolProvider  `yaml:"provider"`
 	ProviderConfig Config            `yaml:"providerConfig" gorm:"column:provider_config;type:text"`
 	Labels         map[string]string `yaml:"labels" gorm:"-"`
+	Taints         common.Taints     `yaml:"taints" gorm:"type:text"`
 	Autoscaling    bool              `yaml:"autoscaling" gorm:"default:false"`
 }
 
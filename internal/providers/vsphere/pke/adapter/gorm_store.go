@@ -58,17 +58,19 @@ func (m rolesModel) Value() (driver.Value, error) {
 }
 
 type nodePoolModel struct {
-	ID          uint `gorm:"primary_key"`
-	Autoscaling bool
-	ClusterID   uint `gorm:"unique_index:idx_vsphere_pke_np_cluster_id_name"`
-	CreatedBy   uint
-	Size        int
-	MaxSize     uint
-	MinSize     uint
-	VCPU        int `gorm:"column:vcpu"`
-	RAM         int
-	Name        string     `gorm:"unique_index:idx_vsphere_pke_np_cluster_id_name"`
-	Roles       rolesModel `gorm:"type:json"`
+	ID            uint `gorm:"primary_key"`
+	Autoscaling   bool
+	ClusterID     uint `gorm:"unique_index:idx_vsphere_pke_np_cluster_id_name"`
+	CreatedBy     uint
+	Size          int
+	MaxSize       uint
+	MinSize       uint
+	VCPU          int `gorm:"column:vcpu"`
+	RAM           int
+	Name          string     `gorm:"unique_index:idx_vsphere_pke_np_cluster_id_name"`
+	Roles         rolesModel `gorm:"type:json"`
+	AdminUsername string
+	TemplateName  string
 }
 
 func (nodePoolModel) TableName() string {
@@ -167,6 +169,8 @@ func fillNodePoolFromModel(nodePool *pke.NodePool, model nodePoolModel) {
 	nodePool.Ram = model.RAM
 	nodePool.Name = model.Name
 	nodePool.Roles = model.Roles
+	nodePool.AdminUsername = model.AdminUsername
+	nodePool.TemplateName = model.TemplateName
 }
 
 func fillModelFromNodePool(model *nodePoolModel, nodePool pke.NodePool) {
@@ -176,6 +180,8 @@ func fillModelFromNodePool(model *nodePoolModel, nodePool pke.NodePool) {
 	model.RAM = nodePool.Ram
 	model.Name = nodePool.Name
 	model.Roles = nodePool.Roles
+	model.AdminUsername = nodePool.AdminUsername
+	model.TemplateName = nodePool.TemplateName
 }
 
 func (s gormVspherePKEClusterStore) CreateNodePool(clusterID uint, nodePool pke.NodePool) error {
@@ -391,6 +397,23 @@ func (s gormVspherePKEClusterStore) SetSSHSecretID(clusterID uint, secretID stri
 	return getError(s.db.Model(&model).Updates(fields), "failed to update cluster model")
 }
 
+func (s gormVspherePKEClusterStore) SetNodePoolSize(clusterID uint, nodePoolName string, size int) error {
+	if err := validateClusterID(clusterID); err != nil {
+		return errors.WrapIf(err, "invalid cluster ID")
+	}
+
+	model := nodePoolModel{
+		ClusterID: clusterID,
+		Name:      nodePoolName,
+	}
+
+	fields := map[string]interface{}{
+		"Size": size,
+	}
+
+	return getError(s.db.Model(&model).Where("cluster_id = ? AND name = ?", clusterID, nodePoolName).Updates(fields), "failed to update nodepool model")
+}
+
 func (s gormVspherePKEClusterStore) GetConfigSecretID(clusterID uint) (string, error) {
 	if err := validateClusterID(clusterID); err != nil {
 		return "", errors.WrapIf(err, "invalid cluster ID")
@@ -24,12 +24,14 @@ import (
 const PKEOnVsphere = "pke-on-vsphere"
 
 type NodePool struct {
-	CreatedBy uint
-	Size      int
-	VCPU      int
-	Ram       int
-	Name      string
-	Roles     []string
+	CreatedBy     uint
+	Size          int
+	VCPU          int
+	Ram           int
+	Name          string
+	Roles         []string
+	AdminUsername string
+	TemplateName  string
 }
 
 func (np NodePool) InstanceType() string {
@@ -103,7 +103,11 @@ func (np NodePool) InstanceType() string {
 }
 
 func (np NodePool) hasRole(role pkgPKE.Role) bool {
-	for _, r := range np.Roles {
+	return hasRole(np.Roles, role)
+}
+
+func hasRole(roles []string, role pkgPKE.Role) bool {
+	for _, r := range roles {
 		if r == string(role) {
 			return true
 		}
@@ -112,11 +116,14 @@ func (np NodePool) hasRole(role pkgPKE.Role) bool {
 }
 
 func (np NodePool) toPke() (pnp pke.NodePool) {
+	pnp.CreatedBy = np.CreatedBy
 	pnp.Size = np.Size
 	pnp.VCPU = np.VCPU
 	pnp.Ram = np.RAM
 	pnp.Name = np.Name
 	pnp.Roles = np.Roles
+	pnp.AdminUsername = np.AdminUsername
+	pnp.TemplateName = np.TemplateName
 	return
 }
 
@@ -157,14 +164,7 @@ func (cc VspherePKEClusterCreator) Create(ctx context.Context, params VspherePKE
 
 	nodePools := make([]pke.NodePool, len(params.NodePools))
 	for i, np := range params.NodePools {
-		nodePools[i] = pke.NodePool{
-			CreatedBy: np.CreatedBy,
-			Name:      np.Name,
-			Roles:     np.Roles,
-			Size:      np.Size,
-			VCPU:      np.VCPU,
-			Ram:       np.RAM,
-		}
+		nodePools[i] = np.toPke()
 	}
 	createParams := pke.CreateParams{
 		Name:             params.Name,
@@ -203,6 +203,7 @@ func (cc VspherePKEClusterCreator) Create(ctx context.Context, params VspherePKE
 		PipelineExternalURLInsecure: cc.config.PipelineExternalURLInsecure,
 		SingleNodePool:              len(cl.NodePools) == 1,
 		SSHPublicKey:                sshKeyPair.PublicKeyData,
+		CRI:                         cl.Kubernetes.CRI,
 	}
 
 	if cl.Kubernetes.OIDC.Enabled {
@@ -21,6 +21,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/banzaicloud/pipeline/internal/common"
+	intPKE "github.com/banzaicloud/pipeline/internal/pke"
 	"github.com/banzaicloud/pipeline/internal/providers/vsphere/pke"
 	"github.com/banzaicloud/pipeline/internal/providers/vsphere/pke/workflow"
 	pkgPKE "github.com/banzaicloud/pipeline/pkg/cluster/pke"
@@ -41,6 +42,7 @@ type nodeTemplateFactory struct {
 	OIDCClientID                string
 	OIDCIssuerURL               string
 	NoProxy                     string
+	CRI                         intPKE.CRI
 }
 
 func (f nodeTemplateFactory) getNode(np NodePool, number int) workflow.Node {
@@ -83,6 +85,11 @@ func (f nodeTemplateFactory) getNode(np NodePool, number int) workflow.Node {
 		}
 	}
 
+	if f.CRI.Runtime != "" {
+		node.UserDataScriptTemplate += ` \
+--kubernetes-container-runtime={{ .ContainerRuntime }}`
+	}
+
 	if np.hasRole(pkgPKE.RolePipelineSystem) {
 		if !f.SingleNodePool {
 			taints = fmt.Sprintf("%s=%s:%s", pkgCommon.NodePoolNameTaintKey, np.Name, corev1.TaintEffectPreferNoSchedule)
@@ -103,6 +110,7 @@ func (f nodeTemplateFactory) getNode(np NodePool, number int) workflow.Node {
 		"KubernetesVersion":    f.KubernetesVersion,
 		"KubernetesMasterMode": k8sMasterMode,
 		"NoProxy":              f.NoProxy,
+		"ContainerRuntime":     f.CRI.Runtime,
 	}
 	return node
 }
@@ -122,6 +122,34 @@ func (p NodePoolPreparer) prepareNewNodePool(ctx context.Context, nodePool *Node
 func (p NodePoolPreparer) prepareExistingNodePool(ctx context.Context, nodePool *NodePool, existing pke.NodePool) error {
 	nodePool.CreatedBy = existing.CreatedBy
 	nodePool.Roles = existing.Roles
+
+	if nodePool.hasRole(pkgPKE.RoleMaster) && nodePool.Size != existing.Size {
+		return validationErrorf("%s.Size cannot be changed for the master node pool", p.namespace)
+	}
+
+	if nodePool.VCPU == 0 {
+		nodePool.VCPU = existing.VCPU
+	} else if nodePool.VCPU != existing.VCPU {
+		return validationErrorf("%s.VCPU cannot be changed", p.namespace)
+	}
+
+	if nodePool.RAM == 0 {
+		nodePool.RAM = existing.Ram
+	} else if nodePool.RAM != existing.Ram {
+		return validationErrorf("%s.RAM cannot be changed", p.namespace)
+	}
+
+	// node pools created before templates were persisted accept the template from the request
+	if nodePool.TemplateName == "" {
+		nodePool.TemplateName = existing.TemplateName
+	} else if existing.TemplateName != "" && nodePool.TemplateName != existing.TemplateName {
+		return validationErrorf("%s.TemplateName cannot be changed", p.namespace)
+	}
+
+	if nodePool.AdminUsername == "" {
+		nodePool.AdminUsername = existing.AdminUsername
+	}
+
 	return nil
 }
 
@@ -51,6 +51,7 @@ type ClusterStore interface {
 	SetActiveWorkflowID(clusterID uint, workflowID string) error
 	SetConfigSecretID(clusterID uint, secretID string) error
 	SetSSHSecretID(clusterID uint, sshSecretID string) error
+	SetNodePoolSize(clusterID uint, nodePoolName string, size int) error
 }
 
 // IsNotFound returns true if the error is about a resource not being found
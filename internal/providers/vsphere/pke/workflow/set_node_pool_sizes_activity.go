@@ -0,0 +1,50 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/providers/vsphere/pke"
+)
+
+const SetNodePoolSizesActivityName = "pke-vsphere-set-node-pool-sizes"
+
+type SetNodePoolSizesActivity struct {
+	store pke.ClusterStore
+}
+
+func MakeSetNodePoolSizesActivity(store pke.ClusterStore) SetNodePoolSizesActivity {
+	return SetNodePoolSizesActivity{
+		store: store,
+	}
+}
+
+type SetNodePoolSizesActivityInput struct {
+	ClusterID     uint
+	NodePoolSizes map[string]int
+}
+
+func (a SetNodePoolSizesActivity) Execute(ctx context.Context, input SetNodePoolSizesActivityInput) error {
+	for name, size := range input.NodePoolSizes {
+		err := a.store.SetNodePoolSize(input.ClusterID, name, size)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "failed to set nodepool size in store", "nodepool", name, "cluster", input.ClusterID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clustersetup"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	intPKE "github.com/banzaicloud/pipeline/internal/pke"
+	intPKEWorkflow "github.com/banzaicloud/pipeline/internal/pke/workflow"
+	"github.com/banzaicloud/pipeline/pkg/brn"
+	pkgCadence "github.com/banzaicloud/pipeline/pkg/cadence"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+const UpdateClusterWorkflowName = "pke-vsphere-update-cluster"
+
+// UpdateClusterWorkflowInput
+type UpdateClusterWorkflowInput struct {
+	ClusterID        uint
+	ClusterName      string
+	OrganizationID   uint
+	ResourcePoolName string
+	FolderName       string
+	DatastoreName    string
+	SecretID         string
+	ConfigSecretID   string
+	HTTPProxy        intPKE.HTTPProxy
+
+	NodesToCreate     []Node
+	NodesToDelete     []Node
+	NodePoolsToDelete []string
+	// NodePoolSizes contains the sizes of shrunk node pools to be persisted once their nodes are deleted
+	NodePoolSizes map[string]int
+
+	Labels map[string]map[string]string
+}
+
+func UpdateClusterWorkflow(ctx workflow.Context, input UpdateClusterWorkflowInput) error {
+	ao := workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		ScheduleToCloseTimeout: 15 * time.Minute,
+		WaitForCancellation:    true,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          2 * time.Second,
+			BackoffCoefficient:       1.5,
+			MaximumInterval:          30 * time.Second,
+			MaximumAttempts:          5,
+			NonRetriableErrorReasons: []string{"cadenceInternal:Panic"},
+		},
+	}
+	ctx = workflow.WithActivityOptions(ctx, ao)
+
+	// Draining is retried until pod disruption budgets allow evicting every pod from the node.
+	drainCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		HeartbeatTimeout:       time.Minute,
+		WaitForCancellation:    true,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          30 * time.Second,
+			BackoffCoefficient:       1.0,
+			ExpirationInterval:       6 * time.Hour,
+			NonRetriableErrorReasons: []string{pkgCadence.ClientErrorReason, "cadenceInternal:Panic"},
+		},
+	})
+
+	// Drain nodes to be deleted
+	for _, node := range input.NodesToDelete {
+		activityInput := clusterworkflow.DrainNodeActivityInput{
+			ClusterID: input.ClusterID,
+			NodeName:  node.Name,
+		}
+
+		err := workflow.ExecuteActivity(drainCtx, clusterworkflow.DrainNodeActivityName, activityInput).Get(ctx, nil)
+		if err = errors.WrapIff(err, "draining node %q", node.Name); err != nil {
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	// Delete VM nodes
+	{
+		futures := make(map[string]workflow.Future)
+
+		for _, node := range input.NodesToDelete {
+			activityInput := DeleteNodeActivityInput{
+				OrganizationID: input.OrganizationID,
+				SecretID:       input.SecretID,
+				ClusterID:      input.ClusterID,
+				ClusterName:    input.ClusterName,
+				Node:           node,
+			}
+			futures[node.Name] = workflow.ExecuteActivity(ctx, DeleteNodeActivityName, activityInput)
+		}
+
+		errs := []error{}
+
+		for i := range futures {
+			var existed bool
+			errs = append(errs, errors.WrapIff(futures[i].Get(ctx, &existed), "deleting node %q", i))
+		}
+
+		if err := errors.Combine(errs...); err != nil {
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	// Remove deleted nodes from Kubernetes
+	for _, node := range input.NodesToDelete {
+		activityInput := DeleteK8sNodeActivityInput{
+			ClusterID: input.ClusterID,
+			NodeName:  node.Name,
+		}
+
+		err := workflow.ExecuteActivity(ctx, DeleteK8sNodeActivityName, activityInput).Get(ctx, nil)
+		if err = errors.WrapIff(err, "deleting Kubernetes node %q", node.Name); err != nil {
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	if len(input.NodePoolSizes) > 0 {
+		activityInput := SetNodePoolSizesActivityInput{
+			ClusterID:     input.ClusterID,
+			NodePoolSizes: input.NodePoolSizes,
+		}
+		if err := workflow.ExecuteActivity(ctx, SetNodePoolSizesActivityName, activityInput).Get(ctx, nil); err != nil {
+			err = errors.WrapIff(err, "%q activity failed", SetNodePoolSizesActivityName)
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	if len(input.NodePoolsToDelete) > 0 {
+		activityInput := DeleteNodePoolFromStoreActivityInput{
+			ClusterID:     input.ClusterID,
+			NodePoolNames: input.NodePoolsToDelete,
+		}
+		if err := workflow.ExecuteActivity(ctx, DeleteNodePoolFromStoreActivityName, activityInput).Get(ctx, nil); err != nil {
+			err = errors.WrapIff(err, "%q activity failed", DeleteNodePoolFromStoreActivityName)
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	// set up node pool labels set
+	{
+		activityInput := clustersetup.ConfigureNodePoolLabelsActivityInput{
+			ConfigSecretID: brn.New(input.OrganizationID, brn.SecretResourceType, input.ConfigSecretID).String(),
+			Labels:         input.Labels,
+		}
+		err := workflow.ExecuteActivity(ctx, clustersetup.ConfigureNodePoolLabelsActivityName, activityInput).Get(ctx, nil)
+		if err != nil {
+			err = errors.WrapIff(err, "%q activity failed", clustersetup.ConfigureNodePoolLabelsActivityName)
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	if len(input.NodesToCreate) > 0 {
+		var httpProxy intPKEWorkflow.HTTPProxy
+		{
+			activityInput := intPKEWorkflow.AssembleHTTPProxySettingsActivityInput{
+				OrganizationID:     input.OrganizationID,
+				HTTPProxyHostPort:  getHostPort(input.HTTPProxy.HTTP),
+				HTTPProxySecretID:  input.HTTPProxy.HTTP.SecretID,
+				HTTPSProxyHostPort: getHostPort(input.HTTPProxy.HTTPS),
+				HTTPSProxySecretID: input.HTTPProxy.HTTPS.SecretID,
+			}
+			var output intPKEWorkflow.AssembleHTTPProxySettingsActivityOutput
+			if err := workflow.ExecuteActivity(ctx, intPKEWorkflow.AssembleHTTPProxySettingsActivityName, activityInput).Get(ctx, &output); err != nil {
+				setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+				return err
+			}
+			httpProxy = output.Settings
+		}
+
+		// Create new nodes, every node joins with a freshly generated token
+		futures := make(map[string]workflow.Future)
+
+		for _, node := range input.NodesToCreate {
+			if node.UserDataScriptParams == nil {
+				node.UserDataScriptParams = make(map[string]string)
+			}
+
+			node.UserDataScriptParams["HttpProxy"] = httpProxy.HTTPProxyURL
+			node.UserDataScriptParams["HttpsProxy"] = httpProxy.HTTPSProxyURL
+
+			activityInput := CreateNodeActivityInput{
+				OrganizationID:   input.OrganizationID,
+				SecretID:         input.SecretID,
+				ClusterID:        input.ClusterID,
+				ClusterName:      input.ClusterName,
+				ResourcePoolName: input.ResourcePoolName,
+				FolderName:       input.FolderName,
+				DatastoreName:    input.DatastoreName,
+				Node:             node,
+			}
+			futures[node.Name] = workflow.ExecuteActivity(ctx, CreateNodeActivityName, activityInput)
+		}
+
+		errs := []error{}
+
+		for i := range futures {
+			errs = append(errs, errors.WrapIff(futures[i].Get(ctx, nil), "creating node %q", i))
+		}
+
+		if err := errors.Combine(errs...); err != nil {
+			setClusterStatus(ctx, input.ClusterID, pkgCluster.Warning, err.Error()) // nolint: errcheck
+			return err
+		}
+	}
+
+	setClusterStatus(ctx, input.ClusterID, pkgCluster.Running, pkgCluster.RunningMessage) // nolint: errcheck
+
+	return nil
+}
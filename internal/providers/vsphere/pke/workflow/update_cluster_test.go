@@ -0,0 +1,192 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/vmware/govmomi/vim25/types"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/clustersetup"
+	"github.com/banzaicloud/pipeline/internal/cluster/clusterworkflow"
+	intPKEWorkflow "github.com/banzaicloud/pipeline/internal/pke/workflow"
+	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+func init() {
+	workflow.RegisterWithOptions(UpdateClusterWorkflow, workflow.RegisterOptions{Name: UpdateClusterWorkflowName})
+
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input clusterworkflow.DrainNodeActivityInput) error { return nil },
+		activity.RegisterOptions{Name: clusterworkflow.DrainNodeActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input DeleteNodeActivityInput) (bool, error) { return true, nil },
+		activity.RegisterOptions{Name: DeleteNodeActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input DeleteK8sNodeActivityInput) error { return nil },
+		activity.RegisterOptions{Name: DeleteK8sNodeActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input SetNodePoolSizesActivityInput) error { return nil },
+		activity.RegisterOptions{Name: SetNodePoolSizesActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input DeleteNodePoolFromStoreActivityInput) error { return nil },
+		activity.RegisterOptions{Name: DeleteNodePoolFromStoreActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input clustersetup.ConfigureNodePoolLabelsActivityInput) error { return nil },
+		activity.RegisterOptions{Name: clustersetup.ConfigureNodePoolLabelsActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input intPKEWorkflow.AssembleHTTPProxySettingsActivityInput) (intPKEWorkflow.AssembleHTTPProxySettingsActivityOutput, error) {
+			return intPKEWorkflow.AssembleHTTPProxySettingsActivityOutput{}, nil
+		},
+		activity.RegisterOptions{Name: intPKEWorkflow.AssembleHTTPProxySettingsActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input CreateNodeActivityInput) (types.ManagedObjectReference, error) {
+			return types.ManagedObjectReference{}, nil
+		},
+		activity.RegisterOptions{Name: CreateNodeActivityName},
+	)
+	activity.RegisterWithOptions(
+		func(ctx context.Context, input SetClusterStatusActivityInput) error { return nil },
+		activity.RegisterOptions{Name: SetClusterStatusActivityName},
+	)
+}
+
+type UpdateClusterWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func TestUpdateClusterWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(UpdateClusterWorkflowTestSuite))
+}
+
+func (s *UpdateClusterWorkflowTestSuite) SetupTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+}
+
+func (s *UpdateClusterWorkflowTestSuite) AfterTest(suiteName, testName string) {
+	s.env.AssertExpectations(s.T())
+}
+
+func (s *UpdateClusterWorkflowTestSuite) Test_ScaleUp() {
+	input := UpdateClusterWorkflowInput{
+		ClusterID:      1,
+		ClusterName:    "example-cluster",
+		OrganizationID: 2,
+		ConfigSecretID: "config-secret",
+		NodesToCreate: []Node{
+			{Name: "example-cluster-pool1-03", NodePoolName: "pool1"},
+			{Name: "example-cluster-pool2-01", NodePoolName: "pool2", UserDataScriptParams: map[string]string{"PublicAddress": "192.168.1.10"}},
+		},
+	}
+
+	s.env.OnActivity(clustersetup.ConfigureNodePoolLabelsActivityName, mock.Anything, mock.Anything).Return(nil).Once()
+	s.env.OnActivity(intPKEWorkflow.AssembleHTTPProxySettingsActivityName, mock.Anything, mock.Anything).Return(
+		intPKEWorkflow.AssembleHTTPProxySettingsActivityOutput{
+			Settings: intPKEWorkflow.HTTPProxy{HTTPProxyURL: "http://proxy:3128"},
+		},
+		nil,
+	).Once()
+	s.env.OnActivity(CreateNodeActivityName, mock.Anything, mock.MatchedBy(func(input CreateNodeActivityInput) bool {
+		return input.UserDataScriptParams["HttpProxy"] == "http://proxy:3128"
+	})).Return(types.ManagedObjectReference{}, nil).Twice()
+	s.env.OnActivity(SetClusterStatusActivityName, mock.Anything, SetClusterStatusActivityInput{
+		ClusterID:     1,
+		Status:        pkgCluster.Running,
+		StatusMessage: pkgCluster.RunningMessage,
+	}).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(UpdateClusterWorkflowName, input)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
+
+func (s *UpdateClusterWorkflowTestSuite) Test_ScaleDownAndRemoveNodePool() {
+	input := UpdateClusterWorkflowInput{
+		ClusterID:      1,
+		ClusterName:    "example-cluster",
+		OrganizationID: 2,
+		ConfigSecretID: "config-secret",
+		NodesToDelete: []Node{
+			{Name: "example-cluster-pool1-03", NodePoolName: "pool1"},
+			{Name: "example-cluster-pool2-01", NodePoolName: "pool2"},
+		},
+		NodePoolsToDelete: []string{"pool2"},
+		NodePoolSizes:     map[string]int{"pool1": 2},
+	}
+
+	s.env.OnActivity(clusterworkflow.DrainNodeActivityName, mock.Anything, mock.Anything).Return(nil).Twice()
+	s.env.OnActivity(DeleteNodeActivityName, mock.Anything, mock.Anything).Return(true, nil).Twice()
+	s.env.OnActivity(DeleteK8sNodeActivityName, mock.Anything, mock.Anything).Return(nil).Twice()
+	s.env.OnActivity(SetNodePoolSizesActivityName, mock.Anything, SetNodePoolSizesActivityInput{
+		ClusterID:     1,
+		NodePoolSizes: map[string]int{"pool1": 2},
+	}).Return(nil).Once()
+	s.env.OnActivity(DeleteNodePoolFromStoreActivityName, mock.Anything, DeleteNodePoolFromStoreActivityInput{
+		ClusterID:     1,
+		NodePoolNames: []string{"pool2"},
+	}).Return(nil).Once()
+	s.env.OnActivity(clustersetup.ConfigureNodePoolLabelsActivityName, mock.Anything, mock.Anything).Return(nil).Once()
+	s.env.OnActivity(SetClusterStatusActivityName, mock.Anything, SetClusterStatusActivityInput{
+		ClusterID:     1,
+		Status:        pkgCluster.Running,
+		StatusMessage: pkgCluster.RunningMessage,
+	}).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(UpdateClusterWorkflowName, input)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.NoError(s.env.GetWorkflowError())
+}
+
+func (s *UpdateClusterWorkflowTestSuite) Test_DeleteNodeFailure() {
+	input := UpdateClusterWorkflowInput{
+		ClusterID:      1,
+		ClusterName:    "example-cluster",
+		OrganizationID: 2,
+		NodesToDelete: []Node{
+			{Name: "example-cluster-pool1-03", NodePoolName: "pool1"},
+		},
+		NodePoolSizes: map[string]int{"pool1": 2},
+	}
+
+	s.env.OnActivity(clusterworkflow.DrainNodeActivityName, mock.Anything, mock.Anything).Return(nil).Once()
+	s.env.OnActivity(DeleteNodeActivityName, mock.Anything, mock.Anything).Return(true, errors.New("vSphere error"))
+	s.env.OnActivity(SetClusterStatusActivityName, mock.Anything, mock.MatchedBy(func(input SetClusterStatusActivityInput) bool {
+		return input.Status == pkgCluster.Warning
+	})).Return(nil).Once()
+
+	s.env.ExecuteWorkflow(UpdateClusterWorkflowName, input)
+
+	s.True(s.env.IsWorkflowCompleted())
+	s.Error(s.env.GetWorkflowError())
+}
@@ -29,14 +29,15 @@ type Meta struct {
 
 // Cloud constants
 const (
-	Alibaba    = "alibaba"
-	Amazon     = "amazon"
-	Azure      = "azure"
-	Google     = "google"
-	Dummy      = "dummy"
-	Kubernetes = "kubernetes"
-	Oracle     = "oracle"
-	Vsphere    = "vsphere"
+	Alibaba      = "alibaba"
+	Amazon       = "amazon"
+	Azure        = "azure"
+	Google       = "google"
+	Dummy        = "dummy"
+	Kubernetes   = "kubernetes"
+	Oracle       = "oracle"
+	S3Compatible = "s3compatible"
+	Vsphere      = "vsphere"
 )
 
 // Alibaba keys
@@ -85,6 +86,15 @@ const (
 	OracleCompartmentOCID   = "compartment_ocid"
 )
 
+// S3 compatible keys
+const (
+	S3CompatibleEndpoint        = "S3_ENDPOINT"
+	S3CompatibleRegion          = "S3_REGION"
+	S3CompatibleAccessKeyId     = "S3_ACCESS_KEY_ID"
+	S3CompatibleSecretAccessKey = "S3_SECRET_ACCESS_KEY"
+	S3CompatibleForcePathStyle  = "S3_FORCE_PATH_STYLE"
+)
+
 // vSphere keys
 const (
 	VsphereURL      = "url"
@@ -104,6 +114,7 @@ const (
 	PublicKeyData        = "public_key_data"
 	PublicKeyFingerprint = "public_key_fingerprint"
 	PrivateKeyData       = "private_key_data"
+	KnownHosts           = "known_hosts"
 )
 
 // TLS keys
@@ -268,6 +279,15 @@ var DefaultRules = map[string]Meta{
 			{Name: OracleCompartmentOCID, Required: true, Description: "Your compartment OCID"},
 		},
 	},
+	S3Compatible: {
+		Fields: []FieldMeta{
+			{Name: S3CompatibleEndpoint, Required: true, Description: "The URL endpoint of the S3 compatible object storage (eg. a MinIO server)"},
+			{Name: S3CompatibleRegion, Required: false, Description: "Region of the object storage"},
+			{Name: S3CompatibleAccessKeyId, Required: true, Description: "Your access key id"},
+			{Name: S3CompatibleSecretAccessKey, Required: true, Description: "Your secret access key"},
+			{Name: S3CompatibleForcePathStyle, Required: false, Description: "Use path style bucket addressing (true by default)"},
+		},
+	},
 	Vsphere: {
 		Fields: []FieldMeta{
 			{Name: VsphereURL, Required: true, Description: "The URL endpoint of the vSphere instance to use (don't include auth info)"},
@@ -282,6 +302,7 @@ var DefaultRules = map[string]Meta{
 			{Name: PublicKeyData, Required: true},
 			{Name: PublicKeyFingerprint, Required: true},
 			{Name: PrivateKeyData, Required: true},
+			{Name: KnownHosts, Required: false, Description: "Host keys of the machines the key pair is used for, in OpenSSH known_hosts format"},
 		},
 	},
 	TLSSecretType: {
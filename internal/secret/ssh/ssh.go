@@ -36,6 +36,12 @@ type KeyPair struct {
 	PrivateKeyData       string
 }
 
+// Signer parses the private key of the key pair
+func (k KeyPair) Signer() (ssh.Signer, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(k.PrivateKeyData))
+	return signer, errors.WrapIf(err, "failed to parse private key")
+}
+
 type KeyPairGenerator struct {
 	Bits    int
 	Comment string
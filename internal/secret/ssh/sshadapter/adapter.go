@@ -21,11 +21,16 @@ import (
 )
 
 func KeyPairFromSecret(s *secret.SecretItemResponse) ssh.KeyPair {
+	return KeyPairFromValues(s.Values)
+}
+
+// KeyPairFromValues returns the key pair stored in the values of an SSH secret
+func KeyPairFromValues(values map[string]string) ssh.KeyPair {
 	return ssh.KeyPair{
-		User:                 s.Values[secrettype.User],
-		Identifier:           s.Values[secrettype.Identifier],
-		PublicKeyData:        s.Values[secrettype.PublicKeyData],
-		PublicKeyFingerprint: s.Values[secrettype.PublicKeyFingerprint],
-		PrivateKeyData:       s.Values[secrettype.PrivateKeyData],
+		User:                 values[secrettype.User],
+		Identifier:           values[secrettype.Identifier],
+		PublicKeyData:        values[secrettype.PublicKeyData],
+		PublicKeyFingerprint: values[secrettype.PublicKeyFingerprint],
+		PrivateKeyData:       values[secrettype.PrivateKeyData],
 	}
 }
@@ -0,0 +1,65 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"strconv"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+const S3Compatible = "s3compatible"
+
+const (
+	FieldS3CompatibleEndpoint        = "S3_ENDPOINT"
+	FieldS3CompatibleRegion          = "S3_REGION"
+	FieldS3CompatibleAccessKeyId     = "S3_ACCESS_KEY_ID"
+	FieldS3CompatibleSecretAccessKey = "S3_SECRET_ACCESS_KEY"
+	FieldS3CompatibleForcePathStyle  = "S3_FORCE_PATH_STYLE"
+)
+
+type S3CompatibleType struct{}
+
+func (S3CompatibleType) Name() string {
+	return S3Compatible
+}
+
+func (S3CompatibleType) Definition() secret.TypeDefinition {
+	return secret.TypeDefinition{
+		Fields: []secret.FieldDefinition{
+			{Name: FieldS3CompatibleEndpoint, Required: true, Description: "The URL endpoint of the S3 compatible object storage (eg. a MinIO server)"},
+			{Name: FieldS3CompatibleRegion, Required: false, Description: "Region of the object storage"},
+			{Name: FieldS3CompatibleAccessKeyId, Required: true, Description: "Your access key id"},
+			{Name: FieldS3CompatibleSecretAccessKey, Required: true, Description: "Your secret access key"},
+			{Name: FieldS3CompatibleForcePathStyle, Required: false, Description: "Use path style bucket addressing (true by default)"},
+		},
+	}
+}
+
+func (t S3CompatibleType) Validate(data map[string]string) error {
+	if err := validateDefinition(data, t.Definition()); err != nil {
+		return err
+	}
+
+	if forcePathStyle := data[FieldS3CompatibleForcePathStyle]; forcePathStyle != "" {
+		if _, err := strconv.ParseBool(forcePathStyle); err != nil {
+			violation := "invalid value for key: " + FieldS3CompatibleForcePathStyle
+
+			return secret.NewValidationError(violation, []string{violation})
+		}
+	}
+
+	return nil
+}
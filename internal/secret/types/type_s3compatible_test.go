@@ -0,0 +1,95 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/pipeline/internal/secret"
+)
+
+func TestS3CompatibleType(t *testing.T) {
+	assert.Implements(t, (*secret.Type)(nil), new(S3CompatibleType))
+}
+
+func TestS3CompatibleType_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]string
+
+		message    string
+		violations []string
+	}{
+		{
+			name:    "Empty",
+			message: "missing key: " + FieldS3CompatibleEndpoint,
+			violations: []string{
+				"missing key: " + FieldS3CompatibleEndpoint,
+				"missing key: " + FieldS3CompatibleAccessKeyId,
+				"missing key: " + FieldS3CompatibleSecretAccessKey,
+			},
+		},
+		{
+			name: "InvalidForcePathStyle",
+			data: map[string]string{
+				FieldS3CompatibleEndpoint:        "http://minio.example.com:9000",
+				FieldS3CompatibleAccessKeyId:     "minio",
+				FieldS3CompatibleSecretAccessKey: "minio123",
+				FieldS3CompatibleForcePathStyle:  "maybe",
+			},
+			message: "invalid value for key: " + FieldS3CompatibleForcePathStyle,
+			violations: []string{
+				"invalid value for key: " + FieldS3CompatibleForcePathStyle,
+			},
+		},
+		{
+			name: "Valid",
+			data: map[string]string{
+				FieldS3CompatibleEndpoint:        "http://minio.example.com:9000",
+				FieldS3CompatibleAccessKeyId:     "minio",
+				FieldS3CompatibleSecretAccessKey: "minio123",
+				FieldS3CompatibleForcePathStyle:  "false",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			typ := S3CompatibleType{}
+
+			err := typ.Validate(test.data)
+
+			if test.message != "" {
+				assert.EqualError(t, err, test.message)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if len(test.violations) > 0 {
+				var verr secret.ValidationError
+				if !errors.As(err, &verr) {
+					t.Fatal("error is expected to be a ValidationError")
+				}
+
+				assert.Equal(t, test.violations, verr.Violations())
+			}
+		})
+	}
+}
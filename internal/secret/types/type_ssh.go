@@ -26,6 +26,7 @@ const (
 	FieldSSHPublicKeyData        = "public_key_data"
 	FieldSSHPublicKeyFingerprint = "public_key_fingerprint"
 	FieldSSHPrivateKeyData       = "private_key_data"
+	FieldSSHKnownHosts           = "known_hosts"
 )
 
 type SSHType struct{}
@@ -42,6 +43,7 @@ func (SSHType) Definition() secret.TypeDefinition {
 			{Name: FieldSSHPublicKeyData, Required: true},
 			{Name: FieldSSHPublicKeyFingerprint, Required: true},
 			{Name: FieldSSHPrivateKeyData, Required: true},
+			{Name: FieldSSHKnownHosts, Required: false, Description: "Host keys of the machines the key pair is used for, in OpenSSH known_hosts format"},
 		},
 	}
 }
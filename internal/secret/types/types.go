@@ -44,6 +44,7 @@ func NewDefaultTypeList(config DefaultTypeListConfig) secret.TypeList {
 		PagerDutyType{},
 		PasswordType{},
 		PKEType{PkeSecreter: config.PkeSecreter},
+		S3CompatibleType{},
 		SlackType{},
 		SSHType{},
 		TLSType{DefaultValidity: config.TLSDefaultValidity},
@@ -17,6 +17,7 @@ package anchore
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"emperror.dev/errors"
 	securityV1Alpha "github.com/banzaicloud/anchore-image-validator/pkg/apis/security/v1alpha1"
@@ -30,6 +31,12 @@ import (
 	"github.com/banzaicloud/pipeline/pkg/security"
 )
 
+// Annotations of whitelist items storing details the WhiteListItem resource has no field for.
+const (
+	WhitelistExpiresAtAnnotation  = "security.banzaicloud.com/expires-at"
+	WhitelistApprovedByAnnotation = "security.banzaicloud.com/approved-by"
+)
+
 // SecurityResourceService gathers operations for managing security (anchore) related resources
 type SecurityResourceService interface {
 	WhitelistService
@@ -44,8 +51,8 @@ type WhitelistService interface {
 }
 
 type ScanlogService interface {
-	ListScanLogs(ctx context.Context, cluster Cluster) (interface{}, error)
-	GetScanLogs(ctx context.Context, cluster Cluster, releaseName string) (interface{}, error)
+	ListScanLogs(ctx context.Context, cluster Cluster) ([]security.ScanLog, error)
+	GetScanLogs(ctx context.Context, cluster Cluster, releaseName string) (*security.ScanLog, error)
 }
 
 type securityResourceService struct {
@@ -98,7 +105,7 @@ func (s securityResourceService) CreateWhitelist(ctx context.Context, cluster Cl
 	return wlItem, nil
 }
 
-func (s securityResourceService) ListScanLogs(ctx context.Context, cluster Cluster) (interface{}, error) {
+func (s securityResourceService) ListScanLogs(ctx context.Context, cluster Cluster) ([]security.ScanLog, error) {
 	logCtx := map[string]interface{}{"clusterID": cluster.GetID()}
 	s.logger.Info("listing scan logs ...", logCtx)
 
@@ -113,14 +120,12 @@ func (s securityResourceService) ListScanLogs(ctx context.Context, cluster Clust
 		return nil, errors.WrapIf(err, "failed to list scan logs")
 	}
 
-	scanLogList := make([]securityV1Alpha.AuditSpec, 0)
+	scanLogList := make([]security.ScanLog, 0, len(audits.Items))
 	for _, audit := range audits.Items {
-		scanLog := securityV1Alpha.AuditSpec{
-			ReleaseName: audit.Spec.ReleaseName,
-			Resource:    audit.Spec.Resource,
-			Action:      audit.Spec.Action,
-			Images:      audit.Spec.Images,
-			Result:      audit.Spec.Result,
+		scanLog, err := ScanLogFromAudit(audit)
+		if err != nil {
+			// the scan log is still returned without vulnerabilities
+			s.logger.Warn(err.Error(), logCtx)
 		}
 		scanLogList = append(scanLogList, scanLog)
 	}
@@ -128,7 +133,7 @@ func (s securityResourceService) ListScanLogs(ctx context.Context, cluster Clust
 	return scanLogList, nil
 }
 
-func (s securityResourceService) GetScanLogs(ctx context.Context, cluster Cluster, releaseName string) (interface{}, error) {
+func (s securityResourceService) GetScanLogs(ctx context.Context, cluster Cluster, releaseName string) (*security.ScanLog, error) {
 	logCtx := map[string]interface{}{"clusterID": cluster.GetID()}
 	s.logger.Info("retrieving scan logs ...", logCtx)
 
@@ -146,13 +151,13 @@ func (s securityResourceService) GetScanLogs(ctx context.Context, cluster Cluste
 		return nil, errors.WrapIf(err, "failed to get audit")
 	}
 
-	return &securityV1Alpha.AuditSpec{
-		ReleaseName: audit.Spec.ReleaseName,
-		Resource:    audit.Spec.Resource,
-		Action:      audit.Spec.Action,
-		Images:      audit.Spec.Images,
-		Result:      audit.Spec.Result,
-	}, nil
+	scanLog, err := ScanLogFromAudit(*audit)
+	if err != nil {
+		// the scan log is still returned without vulnerabilities
+		s.logger.Warn(err.Error(), logCtx)
+	}
+
+	return &scanLog, nil
 }
 
 func (s securityResourceService) DeleteWhitelist(ctx context.Context, cluster Cluster, whitelistItemName string) error {
@@ -217,13 +222,24 @@ func (s securityResourceService) getClusterClient(ctx context.Context, cluster C
 }
 
 func (s securityResourceService) assembleWhiteListItem(whitelistItem security.ReleaseWhiteListItem) *securityV1Alpha.WhiteListItem {
+	annotations := make(map[string]string)
+
+	if whitelistItem.ExpiresAt != nil {
+		annotations[WhitelistExpiresAtAnnotation] = whitelistItem.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+
+	if whitelistItem.ApprovedBy != "" {
+		annotations[WhitelistApprovedByAnnotation] = whitelistItem.ApprovedBy
+	}
+
 	return &securityV1Alpha.WhiteListItem{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "WhiteListItem",
 			APIVersion: fmt.Sprintf("%v/%v", securityV1Alpha.GroupName, securityV1Alpha.GroupVersion),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name: whitelistItem.Name,
+			Name:        whitelistItem.Name,
+			Annotations: annotations,
 		},
 		Spec: securityV1Alpha.WhiteListSpec{
 			Creator: whitelistItem.Owner,
@@ -233,6 +249,25 @@ func (s securityResourceService) assembleWhiteListItem(whitelistItem security.Re
 	}
 }
 
+// ReleaseWhiteListItemFromResource converts a WhiteListItem resource installed in a cluster to an active whitelist item.
+func ReleaseWhiteListItemFromResource(resource securityV1Alpha.WhiteListItem) security.ReleaseWhiteListItem {
+	item := security.ReleaseWhiteListItem{
+		Name:       resource.Name,
+		Owner:      resource.Spec.Creator,
+		Reason:     resource.Spec.Reason,
+		Regexp:     resource.Spec.Regexp,
+		Status:     security.WhiteListItemStatusActive,
+		ApprovedBy: resource.Annotations[WhitelistApprovedByAnnotation],
+	}
+
+	// items with an invalid expiry are kept rather than removed by accident
+	if expiresAt, err := time.Parse(time.RFC3339, resource.Annotations[WhitelistExpiresAtAnnotation]); err == nil {
+		item.ExpiresAt = &expiresAt
+	}
+
+	return item
+}
+
 // Cluster defines operations that can be performed on a k8s cluster
 type Cluster interface {
 	GetK8sConfig() ([]byte, error)
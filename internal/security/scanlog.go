@@ -0,0 +1,165 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anchore
+
+import (
+	"encoding/json"
+
+	"emperror.dev/errors"
+	securityV1Alpha "github.com/banzaicloud/anchore-image-validator/pkg/apis/security/v1alpha1"
+
+	"github.com/banzaicloud/pipeline/pkg/security"
+)
+
+// Annotations of audit resources set by the image validator webhook.
+const (
+	// AuditScannerAnnotation is the scanner that checked the images (Anchore when missing).
+	AuditScannerAnnotation = "security.banzaicloud.com/scanner"
+
+	// AuditVulnerabilitiesAnnotation holds the vulnerabilities of the images by image digest in the scanner's own format.
+	AuditVulnerabilitiesAnnotation = "security.banzaicloud.com/vulnerabilities"
+)
+
+// vulnerabilityDecoders convert scanner specific vulnerability lists to the normalized model.
+var vulnerabilityDecoders = map[string]func(raw json.RawMessage) ([]security.Vulnerability, error){
+	security.ScannerAnchore: decodeAnchoreVulnerabilities,
+	security.ScannerTrivy:   decodeTrivyVulnerabilities,
+}
+
+// ScanLogFromAudit converts an Audit resource created by the image validator webhook to a normalized scan log.
+// When the vulnerabilities cannot be decoded, the scan log is returned without them along with the error.
+func ScanLogFromAudit(audit securityV1Alpha.Audit) (security.ScanLog, error) {
+	scanner := audit.Annotations[AuditScannerAnnotation]
+	if scanner == "" {
+		scanner = security.ScannerAnchore
+	}
+
+	scanLog := security.ScanLog{
+		Scanner:     scanner,
+		ReleaseName: audit.Spec.ReleaseName,
+		Resource:    audit.Spec.Resource,
+		Images:      make([]security.ScannedImage, 0, len(audit.Spec.Images)),
+		Result:      audit.Spec.Result,
+		Action:      audit.Spec.Action,
+	}
+
+	vulnerabilities, err := decodeVulnerabilities(scanner, audit.Annotations[AuditVulnerabilitiesAnnotation])
+	if err != nil {
+		err = errors.WrapIfWithDetails(err, "failed to decode vulnerabilities", "audit", audit.Name)
+	}
+
+	for _, image := range audit.Spec.Images {
+		scanLog.Images = append(scanLog.Images, security.ScannedImage{
+			ImageName:       image.ImageName,
+			ImageTag:        image.ImageTag,
+			ImageDigest:     image.ImageDigest,
+			LastUpdated:     image.LastUpdated,
+			Vulnerabilities: vulnerabilities[image.ImageDigest],
+		})
+	}
+
+	return scanLog, err
+}
+
+func decodeVulnerabilities(scanner string, annotation string) (map[string][]security.Vulnerability, error) {
+	if annotation == "" {
+		return nil, nil
+	}
+
+	decode, ok := vulnerabilityDecoders[scanner]
+	if !ok {
+		return nil, errors.NewWithDetails("unsupported scanner", "scanner", scanner)
+	}
+
+	var rawVulnerabilities map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(annotation), &rawVulnerabilities); err != nil {
+		return nil, errors.WrapIf(err, "failed to unmarshal vulnerabilities")
+	}
+
+	vulnerabilities := make(map[string][]security.Vulnerability, len(rawVulnerabilities))
+	for digest, raw := range rawVulnerabilities {
+		v, err := decode(raw)
+		if err != nil {
+			return nil, errors.WithDetails(err, "image", digest)
+		}
+
+		vulnerabilities[digest] = v
+	}
+
+	return vulnerabilities, nil
+}
+
+func decodeAnchoreVulnerabilities(raw json.RawMessage) ([]security.Vulnerability, error) {
+	var anchoreVulnerabilities []struct {
+		Vuln           string `json:"vuln"`
+		Severity       string `json:"severity"`
+		PackageName    string `json:"package_name"`
+		PackageVersion string `json:"package_version"`
+		Fix            string `json:"fix"`
+		URL            string `json:"url"`
+	}
+
+	if err := json.Unmarshal(raw, &anchoreVulnerabilities); err != nil {
+		return nil, errors.WrapIf(err, "failed to unmarshal anchore vulnerabilities")
+	}
+
+	vulnerabilities := make([]security.Vulnerability, 0, len(anchoreVulnerabilities))
+	for _, v := range anchoreVulnerabilities {
+		fixedVersion := v.Fix
+		if fixedVersion == "None" { // Anchore reports missing fixes this way
+			fixedVersion = ""
+		}
+
+		vulnerabilities = append(vulnerabilities, security.Vulnerability{
+			ID:           v.Vuln,
+			Severity:     security.NormalizeSeverity(v.Severity),
+			Package:      v.PackageName,
+			Version:      v.PackageVersion,
+			FixedVersion: fixedVersion,
+			URL:          v.URL,
+		})
+	}
+
+	return vulnerabilities, nil
+}
+
+func decodeTrivyVulnerabilities(raw json.RawMessage) ([]security.Vulnerability, error) {
+	var trivyVulnerabilities []struct {
+		VulnerabilityID  string `json:"VulnerabilityID"`
+		PkgName          string `json:"PkgName"`
+		InstalledVersion string `json:"InstalledVersion"`
+		FixedVersion     string `json:"FixedVersion"`
+		Severity         string `json:"Severity"`
+		PrimaryURL       string `json:"PrimaryURL"`
+	}
+
+	if err := json.Unmarshal(raw, &trivyVulnerabilities); err != nil {
+		return nil, errors.WrapIf(err, "failed to unmarshal trivy vulnerabilities")
+	}
+
+	vulnerabilities := make([]security.Vulnerability, 0, len(trivyVulnerabilities))
+	for _, v := range trivyVulnerabilities {
+		vulnerabilities = append(vulnerabilities, security.Vulnerability{
+			ID:           v.VulnerabilityID,
+			Severity:     security.NormalizeSeverity(v.Severity),
+			Package:      v.PkgName,
+			Version:      v.InstalledVersion,
+			FixedVersion: v.FixedVersion,
+			URL:          v.PrimaryURL,
+		})
+	}
+
+	return vulnerabilities, nil
+}
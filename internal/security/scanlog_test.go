@@ -0,0 +1,100 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package anchore
+
+import (
+	"testing"
+
+	securityV1Alpha "github.com/banzaicloud/anchore-image-validator/pkg/apis/security/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/pipeline/pkg/security"
+)
+
+func TestScanLogFromAudit(t *testing.T) {
+	auditSpec := securityV1Alpha.AuditSpec{
+		ReleaseName: "release",
+		Resource:    "Pod",
+		Result:      []string{"Image failed policy check: redis:latest"},
+		Action:      "reject",
+		Images: []securityV1Alpha.AuditImage{
+			{ImageName: "redis", ImageTag: "latest", ImageDigest: "sha256:1234"},
+		},
+	}
+
+	t.Run("Anchore", func(t *testing.T) {
+		audit := securityV1Alpha.Audit{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "release",
+				Annotations: map[string]string{
+					AuditVulnerabilitiesAnnotation: `{"sha256:1234":[{"vuln":"CVE-2020-1","severity":"High","package_name":"openssl","package_version":"1.1.1","fix":"None","url":"https://example.com"}]}`,
+				},
+			},
+			Spec: auditSpec,
+		}
+
+		scanLog, err := ScanLogFromAudit(audit)
+		require.NoError(t, err)
+
+		assert.Equal(t, security.ScannerAnchore, scanLog.Scanner)
+		assert.Equal(t, "reject", scanLog.Action)
+		require.Len(t, scanLog.Images, 1)
+		assert.Equal(t, []security.Vulnerability{
+			{ID: "CVE-2020-1", Severity: security.SeverityHigh, Package: "openssl", Version: "1.1.1", URL: "https://example.com"},
+		}, scanLog.Images[0].Vulnerabilities)
+	})
+
+	t.Run("Trivy", func(t *testing.T) {
+		audit := securityV1Alpha.Audit{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "release",
+				Annotations: map[string]string{
+					AuditScannerAnnotation:         security.ScannerTrivy,
+					AuditVulnerabilitiesAnnotation: `{"sha256:1234":[{"VulnerabilityID":"CVE-2020-1","PkgName":"openssl","InstalledVersion":"1.1.1","FixedVersion":"1.1.1g","Severity":"CRITICAL"}]}`,
+				},
+			},
+			Spec: auditSpec,
+		}
+
+		scanLog, err := ScanLogFromAudit(audit)
+		require.NoError(t, err)
+
+		assert.Equal(t, security.ScannerTrivy, scanLog.Scanner)
+		require.Len(t, scanLog.Images, 1)
+		assert.Equal(t, []security.Vulnerability{
+			{ID: "CVE-2020-1", Severity: security.SeverityCritical, Package: "openssl", Version: "1.1.1", FixedVersion: "1.1.1g"},
+		}, scanLog.Images[0].Vulnerabilities)
+	})
+
+	t.Run("InvalidVulnerabilities", func(t *testing.T) {
+		audit := securityV1Alpha.Audit{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "release",
+				Annotations: map[string]string{
+					AuditVulnerabilitiesAnnotation: `[]`,
+				},
+			},
+			Spec: auditSpec,
+		}
+
+		scanLog, err := ScanLogFromAudit(audit)
+		require.Error(t, err)
+
+		require.Len(t, scanLog.Images, 1)
+		assert.Empty(t, scanLog.Images[0].Vulnerabilities)
+	})
+}
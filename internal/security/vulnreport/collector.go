@@ -0,0 +1,120 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreport
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// Collector creates vulnerability reports from the images running in a cluster.
+type Collector struct {
+	images  ImageLister
+	scanner Scanner
+	store   Store
+}
+
+// NewCollector returns a new Collector.
+func NewCollector(images ImageLister, scanner Scanner, store Store) Collector {
+	return Collector{
+		images:  images,
+		scanner: scanner,
+		store:   store,
+	}
+}
+
+// Collect creates and stores a new vulnerability report for a cluster.
+func (c Collector) Collect(ctx context.Context, cluster Cluster) (Report, error) {
+	runningImages, err := c.images.ListImages(ctx, cluster.ID)
+	if err != nil {
+		return Report{}, errors.WrapIfWithDetails(err, "failed to list images", "clusterId", cluster.ID)
+	}
+
+	report := Report{
+		OrganizationID: cluster.OrganizationID,
+		ClusterID:      cluster.ID,
+		CreatedAt:      time.Now(),
+	}
+
+	// the same image is usually running in multiple pods: scan every image only once
+	counts := make(map[string]SeverityCounts)
+	pending := make(map[string]bool)
+	seen := make(map[ImageReport]bool)
+
+	for _, runningImage := range runningImages {
+		digest := runningImage.Image.Digest
+
+		if _, ok := counts[digest]; !ok && !pending[digest] {
+			imageCounts, err := c.scanner.GetSeverityCounts(ctx, cluster.ID, digest)
+			if errors.Is(err, ErrImageNotAnalyzed) {
+				pending[digest] = true
+
+				continue
+			} else if err != nil {
+				return Report{}, errors.WrapIfWithDetails(err, "failed to get image vulnerabilities", "clusterId", cluster.ID, "image", digest)
+			}
+
+			counts[digest] = imageCounts
+			report.Counts.Add(imageCounts)
+		}
+
+		imageCounts, ok := counts[digest]
+		if !ok {
+			continue
+		}
+
+		imageReport := ImageReport{
+			Namespace: runningImage.Namespace,
+			Release:   runningImage.Release,
+			Image:     runningImage.Image,
+		}
+
+		if seen[imageReport] {
+			continue
+		}
+		seen[imageReport] = true
+
+		imageReport.Counts = imageCounts
+		report.Images = append(report.Images, imageReport)
+	}
+
+	report.PendingImages = len(pending)
+
+	sort.Slice(report.Images, func(i, j int) bool {
+		a, b := report.Images[i], report.Images[j]
+
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+
+		if a.Release != b.Release {
+			return a.Release < b.Release
+		}
+
+		return a.Image.Digest < b.Image.Digest
+	})
+
+	id, err := c.store.Create(ctx, report)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report.ID = id
+
+	return report, nil
+}
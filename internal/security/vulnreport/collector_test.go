@@ -0,0 +1,74 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector_Collect(t *testing.T) {
+	ctx := context.Background()
+
+	nginx := Image{Name: "nginx", Tag: "1.17", Digest: "sha256:1"}
+	redis := Image{Name: "redis", Tag: "5", Digest: "sha256:2"}
+	pending := Image{Name: "app", Tag: "dev", Digest: "sha256:3"}
+
+	images := new(MockImageLister)
+	images.On("ListImages", ctx, uint(2)).Return(
+		[]RunningImage{
+			{Namespace: "default", Release: "web", Image: nginx},
+			{Namespace: "default", Release: "web", Image: nginx}, // another replica
+			{Namespace: "default", Release: "web", Image: redis},
+			{Namespace: "other", Image: nginx},
+			{Namespace: "other", Image: pending},
+		},
+		nil,
+	)
+
+	scanner := new(MockScanner)
+	scanner.On("GetSeverityCounts", ctx, uint(2), nginx.Digest).Return(SeverityCounts{High: 2}, nil).Once()
+	scanner.On("GetSeverityCounts", ctx, uint(2), redis.Digest).Return(SeverityCounts{Critical: 1}, nil).Once()
+	scanner.On("GetSeverityCounts", ctx, uint(2), pending.Digest).Return(SeverityCounts{}, ErrImageNotAnalyzed).Once()
+
+	store := new(MockStore)
+	store.On("Create", ctx, mock.AnythingOfType("vulnreport.Report")).Return(uint(5), nil)
+
+	collector := NewCollector(images, scanner, store)
+
+	report, err := collector.Collect(ctx, Cluster{ID: 2, OrganizationID: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(5), report.ID)
+	assert.Equal(t, uint(1), report.OrganizationID)
+	assert.Equal(t, uint(2), report.ClusterID)
+	assert.Equal(t, SeverityCounts{Critical: 1, High: 2}, report.Counts)
+	assert.Equal(t, 1, report.PendingImages)
+
+	expected := []ImageReport{
+		{Namespace: "default", Release: "web", Image: nginx, Counts: SeverityCounts{High: 2}},
+		{Namespace: "default", Release: "web", Image: redis, Counts: SeverityCounts{Critical: 1}},
+		{Namespace: "other", Image: nginx, Counts: SeverityCounts{High: 2}},
+	}
+	assert.Equal(t, expected, report.Images)
+
+	images.AssertExpectations(t)
+	scanner.AssertExpectations(t)
+	store.AssertExpectations(t)
+}
@@ -0,0 +1,181 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreport
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// Vulnerability severities reported by Anchore.
+const (
+	SeverityCritical   = "Critical"
+	SeverityHigh       = "High"
+	SeverityMedium     = "Medium"
+	SeverityLow        = "Low"
+	SeverityNegligible = "Negligible"
+	SeverityUnknown    = "Unknown"
+)
+
+// SeverityCounts holds the number of vulnerabilities by severity.
+type SeverityCounts struct {
+	Critical   int `json:"critical"`
+	High       int `json:"high"`
+	Medium     int `json:"medium"`
+	Low        int `json:"low"`
+	Negligible int `json:"negligible"`
+	Unknown    int `json:"unknown"`
+}
+
+// Count increments the counter of a severity.
+// Unrecognized severities are counted as unknown.
+func (c *SeverityCounts) Count(severity string) {
+	switch severity {
+	case SeverityCritical:
+		c.Critical++
+	case SeverityHigh:
+		c.High++
+	case SeverityMedium:
+		c.Medium++
+	case SeverityLow:
+		c.Low++
+	case SeverityNegligible:
+		c.Negligible++
+	default:
+		c.Unknown++
+	}
+}
+
+// Add adds the counters of another set of counts.
+func (c *SeverityCounts) Add(o SeverityCounts) {
+	c.Critical += o.Critical
+	c.High += o.High
+	c.Medium += o.Medium
+	c.Low += o.Low
+	c.Negligible += o.Negligible
+	c.Unknown += o.Unknown
+}
+
+// Total returns the number of vulnerabilities regardless of their severity.
+func (c SeverityCounts) Total() int {
+	return c.Critical + c.High + c.Medium + c.Low + c.Negligible + c.Unknown
+}
+
+// worseThan tells if the counts are worse than the other ones (compared by severity, most severe first).
+func (c SeverityCounts) worseThan(o SeverityCounts) bool {
+	a := [...]int{c.Critical, c.High, c.Medium, c.Low, c.Negligible, c.Unknown}
+	b := [...]int{o.Critical, o.High, o.Medium, o.Low, o.Negligible, o.Unknown}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] > b[i]
+		}
+	}
+
+	return false
+}
+
+// Image identifies a container image.
+type Image struct {
+	Name   string `json:"name"`
+	Tag    string `json:"tag"`
+	Digest string `json:"digest"`
+}
+
+// RunningImage is an image running in a cluster.
+type RunningImage struct {
+	Namespace string
+
+	// Release is the name of the Helm release the workload belongs to (if any).
+	Release string
+
+	Image Image
+}
+
+// ImageReport holds the vulnerabilities of an image running in a namespace (as part of a release).
+type ImageReport struct {
+	Namespace string         `json:"namespace"`
+	Release   string         `json:"release,omitempty"`
+	Image     Image          `json:"image"`
+	Counts    SeverityCounts `json:"counts"`
+}
+
+// Report is a snapshot of the vulnerabilities of the images running in a cluster.
+type Report struct {
+	ID             uint
+	OrganizationID uint
+	ClusterID      uint
+	CreatedAt      time.Time
+
+	// Counts are the vulnerabilities of every distinct image in the cluster.
+	Counts SeverityCounts
+
+	// PendingImages is the number of images not (yet) analyzed by Anchore.
+	PendingImages int
+
+	Images []ImageReport
+}
+
+// Cluster identifies a cluster vulnerability reports are collected for.
+type Cluster struct {
+	ID             uint
+	OrganizationID uint
+}
+
+// +testify:mock:testOnly=true
+
+// Store persists vulnerability reports.
+type Store interface {
+	// Create stores a new report.
+	Create(ctx context.Context, report Report) (uint, error)
+
+	// FindSummaries returns reports (without images) of an organization created in a time range (oldest first).
+	// If clusterID is not zero, only reports of that cluster are returned.
+	FindSummaries(ctx context.Context, organizationID uint, clusterID uint, from time.Time, to time.Time) ([]Report, error)
+
+	// FindLatest returns the latest report of each cluster of an organization (including images).
+	// If clusterID is not zero, only the latest report of that cluster is returned.
+	FindLatest(ctx context.Context, organizationID uint, clusterID uint) ([]Report, error)
+
+	// DeleteOlderThan deletes every report created before the given time.
+	DeleteOlderThan(ctx context.Context, t time.Time) (int64, error)
+}
+
+// +testify:mock:testOnly=true
+
+// ImageLister lists images running in a cluster.
+type ImageLister interface {
+	// ListImages returns the images running in a cluster.
+	ListImages(ctx context.Context, clusterID uint) ([]RunningImage, error)
+}
+
+// ErrImageNotAnalyzed is returned by a scanner when an image is not (yet) analyzed.
+const ErrImageNotAnalyzed = errors.Sentinel("image is not analyzed")
+
+// +testify:mock:testOnly=true
+
+// Scanner returns vulnerabilities of images.
+type Scanner interface {
+	// GetSeverityCounts returns the number of vulnerabilities of an image by severity.
+	GetSeverityCounts(ctx context.Context, clusterID uint, imageDigest string) (SeverityCounts, error)
+}
+
+// ClusterLister lists clusters vulnerability reports should be collected for.
+type ClusterLister interface {
+	// ListClusters returns every cluster with security scan enabled.
+	ListClusters(ctx context.Context) ([]Cluster, error)
+}
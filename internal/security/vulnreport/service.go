@@ -0,0 +1,319 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultTrendPeriod is the length of the trend period when no start time is specified.
+	DefaultTrendPeriod = 30 * 24 * time.Hour
+
+	// MaxTrendPeriod is the maximum length of a trend period.
+	MaxTrendPeriod = 366 * 24 * time.Hour
+
+	// DefaultTopOffenderLimit is the number of offenders returned when no limit is specified.
+	DefaultTopOffenderLimit = 10
+
+	// MaxTopOffenderLimit is the maximum number of offenders returned.
+	MaxTopOffenderLimit = 100
+)
+
+// Top offender groupings.
+const (
+	GroupByImage     = "image"
+	GroupByRelease   = "release"
+	GroupByNamespace = "namespace"
+)
+
+// TrendOptions selects the time range of a trend.
+// Zero values fall back to defaults (the last DefaultTrendPeriod).
+type TrendOptions struct {
+	// From and To limit the trend to a time range (From is inclusive, To is exclusive).
+	From time.Time
+	To   time.Time
+}
+
+// TrendPoint holds the vulnerabilities reported on a day.
+type TrendPoint struct {
+	// Date is the start of the (UTC) day.
+	Date time.Time `json:"date"`
+
+	// Counts are the vulnerabilities of the last report of each cluster on that day.
+	Counts SeverityCounts `json:"counts"`
+
+	// Clusters is the number of clusters reported on that day.
+	Clusters int `json:"clusters"`
+}
+
+// TopOffenderOptions controls how offenders are grouped and how many of them are returned.
+type TopOffenderOptions struct {
+	// GroupBy is one of image (default), release or namespace.
+	GroupBy string
+
+	Limit int
+}
+
+// Offender is an image, release or namespace with known vulnerabilities.
+type Offender struct {
+	// ClusterIDs are the clusters the offender is found in.
+	ClusterIDs []uint `json:"clusterIds"`
+
+	Namespace string `json:"namespace,omitempty"`
+	Release   string `json:"release,omitempty"`
+	Image     *Image `json:"image,omitempty"`
+
+	// Counts are the vulnerabilities of the distinct images of the offender.
+	Counts SeverityCounts `json:"counts"`
+}
+
+// +kit:endpoint:errorStrategy=service
+// +testify:mock:testOnly=true
+
+// Service provides aggregated vulnerability reports.
+type Service interface {
+	// GetClusterTrend returns the daily vulnerability counts of a cluster.
+	GetClusterTrend(ctx context.Context, organizationID uint, clusterID uint, options TrendOptions) (trend []TrendPoint, err error)
+
+	// GetOrganizationTrend returns the daily vulnerability counts of every cluster of an organization.
+	GetOrganizationTrend(ctx context.Context, organizationID uint, options TrendOptions) (trend []TrendPoint, err error)
+
+	// ListClusterTopOffenders returns the most vulnerable images, releases or namespaces of a cluster.
+	ListClusterTopOffenders(ctx context.Context, organizationID uint, clusterID uint, options TopOffenderOptions) (offenders []Offender, err error)
+
+	// ListOrganizationTopOffenders returns the most vulnerable images, releases or namespaces of an organization.
+	ListOrganizationTopOffenders(ctx context.Context, organizationID uint, options TopOffenderOptions) (offenders []Offender, err error)
+}
+
+// NewService returns a new Service.
+func NewService(store Store) Service {
+	return service{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+type service struct {
+	store Store
+	now   func() time.Time
+}
+
+func (s service) GetClusterTrend(ctx context.Context, organizationID uint, clusterID uint, options TrendOptions) ([]TrendPoint, error) {
+	return s.getTrend(ctx, organizationID, clusterID, options)
+}
+
+func (s service) GetOrganizationTrend(ctx context.Context, organizationID uint, options TrendOptions) ([]TrendPoint, error) {
+	return s.getTrend(ctx, organizationID, 0, options)
+}
+
+func (s service) getTrend(ctx context.Context, organizationID uint, clusterID uint, options TrendOptions) ([]TrendPoint, error) {
+	if options.To.IsZero() {
+		options.To = s.now()
+	}
+
+	if options.From.IsZero() {
+		options.From = options.To.Add(-DefaultTrendPeriod)
+	}
+
+	var violations []string
+
+	if !options.From.Before(options.To) {
+		violations = append(violations, "the start of the time range must be before its end")
+	} else if options.To.Sub(options.From) > MaxTrendPeriod {
+		violations = append(violations, fmt.Sprintf("the time range cannot be longer than %d days", MaxTrendPeriod/(24*time.Hour)))
+	}
+
+	if len(violations) > 0 {
+		return nil, NewValidationError("invalid trend options", violations)
+	}
+
+	reports, err := s.store.FindSummaries(ctx, organizationID, clusterID, options.From, options.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return dailyTrend(reports), nil
+}
+
+// dailyTrend sums the last report of each cluster on each day.
+// Days without reports are omitted.
+func dailyTrend(reports []Report) []TrendPoint {
+	type day struct {
+		date     time.Time
+		clusters map[uint]SeverityCounts
+	}
+
+	var days []*day
+
+	for _, report := range reports {
+		date := report.CreatedAt.UTC().Truncate(24 * time.Hour)
+
+		if len(days) == 0 || !days[len(days)-1].date.Equal(date) {
+			days = append(days, &day{date: date, clusters: make(map[uint]SeverityCounts)})
+		}
+
+		// reports are ordered by time, so the last one wins
+		days[len(days)-1].clusters[report.ClusterID] = report.Counts
+	}
+
+	trend := make([]TrendPoint, 0, len(days))
+
+	for _, d := range days {
+		point := TrendPoint{
+			Date:     d.date,
+			Clusters: len(d.clusters),
+		}
+
+		for _, counts := range d.clusters {
+			point.Counts.Add(counts)
+		}
+
+		trend = append(trend, point)
+	}
+
+	return trend
+}
+
+func (s service) ListClusterTopOffenders(ctx context.Context, organizationID uint, clusterID uint, options TopOffenderOptions) ([]Offender, error) {
+	return s.listTopOffenders(ctx, organizationID, clusterID, options)
+}
+
+func (s service) ListOrganizationTopOffenders(ctx context.Context, organizationID uint, options TopOffenderOptions) ([]Offender, error) {
+	return s.listTopOffenders(ctx, organizationID, 0, options)
+}
+
+func (s service) listTopOffenders(ctx context.Context, organizationID uint, clusterID uint, options TopOffenderOptions) ([]Offender, error) {
+	var violations []string
+
+	switch options.GroupBy {
+	case "":
+		options.GroupBy = GroupByImage
+	case GroupByImage, GroupByRelease, GroupByNamespace:
+	default:
+		violations = append(violations, fmt.Sprintf("unsupported grouping: %s", options.GroupBy))
+	}
+
+	if options.Limit == 0 {
+		options.Limit = DefaultTopOffenderLimit
+	} else if options.Limit < 0 || options.Limit > MaxTopOffenderLimit {
+		violations = append(violations, fmt.Sprintf("limit must be between 1 and %d", MaxTopOffenderLimit))
+	}
+
+	if len(violations) > 0 {
+		return nil, NewValidationError("invalid top offender options", violations)
+	}
+
+	reports, err := s.store.FindLatest(ctx, organizationID, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	offenders := groupOffenders(reports, options.GroupBy)
+
+	sort.SliceStable(offenders, func(i, j int) bool {
+		return offenders[i].Counts.worseThan(offenders[j].Counts)
+	})
+
+	if len(offenders) > options.Limit {
+		offenders = offenders[:options.Limit]
+	}
+
+	return offenders, nil
+}
+
+// groupOffenders aggregates the images of the reports.
+// Images are grouped by their digest across clusters, releases and namespaces are grouped per cluster.
+// Every distinct image is counted only once in a group.
+func groupOffenders(reports []Report, groupBy string) []Offender {
+	type offenderKey struct {
+		clusterID uint
+		namespace string
+		release   string
+		digest    string
+	}
+
+	type group struct {
+		offender Offender
+		clusters map[uint]bool
+		images   map[string]bool
+	}
+
+	groups := make(map[offenderKey]*group)
+	var keys []offenderKey // keeps the order stable
+
+	for _, report := range reports {
+		for _, image := range report.Images {
+			if image.Counts.Total() == 0 {
+				continue
+			}
+
+			var key offenderKey
+			var offender Offender
+
+			switch groupBy {
+			case GroupByRelease:
+				if image.Release == "" {
+					continue
+				}
+
+				key = offenderKey{clusterID: report.ClusterID, namespace: image.Namespace, release: image.Release}
+				offender = Offender{Namespace: image.Namespace, Release: image.Release}
+
+			case GroupByNamespace:
+				key = offenderKey{clusterID: report.ClusterID, namespace: image.Namespace}
+				offender = Offender{Namespace: image.Namespace}
+
+			default:
+				key = offenderKey{digest: image.Image.Digest}
+
+				img := image.Image
+				offender = Offender{Image: &img}
+			}
+
+			g, ok := groups[key]
+			if !ok {
+				g = &group{
+					offender: offender,
+					clusters: make(map[uint]bool),
+					images:   make(map[string]bool),
+				}
+				groups[key] = g
+				keys = append(keys, key)
+			}
+
+			if !g.clusters[report.ClusterID] {
+				g.clusters[report.ClusterID] = true
+				g.offender.ClusterIDs = append(g.offender.ClusterIDs, report.ClusterID)
+			}
+
+			if !g.images[image.Image.Digest] {
+				g.images[image.Image.Digest] = true
+				g.offender.Counts.Add(image.Counts)
+			}
+		}
+	}
+
+	offenders := make([]Offender, 0, len(keys))
+
+	for _, key := range keys {
+		offenders = append(offenders, groups[key].offender)
+	}
+
+	return offenders
+}
@@ -0,0 +1,216 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_GetClusterTrend(t *testing.T) {
+	ctx := context.Background()
+
+	day := time.Date(2020, time.March, 10, 0, 0, 0, 0, time.UTC)
+	from := day.Add(-24 * time.Hour)
+	to := day.Add(48 * time.Hour)
+
+	store := new(MockStore)
+	store.On("FindSummaries", ctx, uint(1), uint(2), from, to).Return(
+		[]Report{
+			{ClusterID: 2, CreatedAt: day.Add(1 * time.Hour), Counts: SeverityCounts{Critical: 5, High: 3}},
+			{ClusterID: 2, CreatedAt: day.Add(13 * time.Hour), Counts: SeverityCounts{Critical: 4, High: 3}},
+			{ClusterID: 2, CreatedAt: day.Add(25 * time.Hour), Counts: SeverityCounts{Critical: 1}},
+		},
+		nil,
+	)
+
+	service := NewService(store)
+
+	trend, err := service.GetClusterTrend(ctx, 1, 2, TrendOptions{From: from, To: to})
+	require.NoError(t, err)
+
+	expected := []TrendPoint{
+		{Date: day, Counts: SeverityCounts{Critical: 4, High: 3}, Clusters: 1},
+		{Date: day.Add(24 * time.Hour), Counts: SeverityCounts{Critical: 1}, Clusters: 1},
+	}
+	assert.Equal(t, expected, trend)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_GetOrganizationTrend(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2020, time.March, 10, 12, 0, 0, 0, time.UTC)
+	day := time.Date(2020, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	store := new(MockStore)
+	store.On("FindSummaries", ctx, uint(1), uint(0), now.Add(-DefaultTrendPeriod), now).Return(
+		[]Report{
+			{ClusterID: 2, CreatedAt: day.Add(1 * time.Hour), Counts: SeverityCounts{Critical: 5}},
+			{ClusterID: 3, CreatedAt: day.Add(2 * time.Hour), Counts: SeverityCounts{High: 2}},
+			{ClusterID: 2, CreatedAt: day.Add(3 * time.Hour), Counts: SeverityCounts{Critical: 3}},
+		},
+		nil,
+	)
+
+	service := service{store: store, now: func() time.Time { return now }}
+
+	trend, err := service.GetOrganizationTrend(ctx, 1, TrendOptions{})
+	require.NoError(t, err)
+
+	expected := []TrendPoint{
+		{Date: day, Counts: SeverityCounts{Critical: 3, High: 2}, Clusters: 2},
+	}
+	assert.Equal(t, expected, trend)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_GetClusterTrend_InvalidRange(t *testing.T) {
+	now := time.Now()
+
+	service := NewService(new(MockStore))
+
+	_, err := service.GetClusterTrend(context.Background(), 1, 2, TrendOptions{From: now, To: now.Add(-time.Hour)})
+	require.Error(t, err)
+
+	var verr ValidationError
+	require.True(t, errors.As(err, &verr))
+
+	_, err = service.GetClusterTrend(context.Background(), 1, 2, TrendOptions{From: now.Add(-MaxTrendPeriod - time.Hour), To: now})
+	require.Error(t, err)
+	require.True(t, errors.As(err, &verr))
+}
+
+func makeLatestReports() []Report {
+	nginx := Image{Name: "nginx", Tag: "1.17", Digest: "sha256:1"}
+	redis := Image{Name: "redis", Tag: "5", Digest: "sha256:2"}
+	busybox := Image{Name: "busybox", Tag: "latest", Digest: "sha256:3"}
+
+	return []Report{
+		{
+			ClusterID: 2,
+			Images: []ImageReport{
+				{Namespace: "default", Release: "web", Image: nginx, Counts: SeverityCounts{High: 2, Low: 1}},
+				{Namespace: "default", Release: "web", Image: redis, Counts: SeverityCounts{Critical: 1}},
+				{Namespace: "tools", Image: busybox},
+			},
+		},
+		{
+			ClusterID: 3,
+			Images: []ImageReport{
+				{Namespace: "default", Release: "web", Image: nginx, Counts: SeverityCounts{High: 2, Low: 1}},
+				{Namespace: "cache", Release: "cache", Image: redis, Counts: SeverityCounts{Critical: 1}},
+			},
+		},
+	}
+}
+
+func TestService_ListOrganizationTopOffenders(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("FindLatest", ctx, uint(1), uint(0)).Return(makeLatestReports(), nil)
+
+	service := NewService(store)
+
+	offenders, err := service.ListOrganizationTopOffenders(ctx, 1, TopOffenderOptions{})
+	require.NoError(t, err)
+
+	expected := []Offender{
+		{
+			ClusterIDs: []uint{2, 3},
+			Image:      &Image{Name: "redis", Tag: "5", Digest: "sha256:2"},
+			Counts:     SeverityCounts{Critical: 1},
+		},
+		{
+			ClusterIDs: []uint{2, 3},
+			Image:      &Image{Name: "nginx", Tag: "1.17", Digest: "sha256:1"},
+			Counts:     SeverityCounts{High: 2, Low: 1},
+		},
+	}
+	assert.Equal(t, expected, offenders)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_ListClusterTopOffenders_Release(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("FindLatest", ctx, uint(1), uint(2)).Return(makeLatestReports()[:1], nil)
+
+	service := NewService(store)
+
+	offenders, err := service.ListClusterTopOffenders(ctx, 1, 2, TopOffenderOptions{GroupBy: GroupByRelease, Limit: 5})
+	require.NoError(t, err)
+
+	expected := []Offender{
+		{
+			ClusterIDs: []uint{2},
+			Namespace:  "default",
+			Release:    "web",
+			Counts:     SeverityCounts{Critical: 1, High: 2, Low: 1},
+		},
+	}
+	assert.Equal(t, expected, offenders)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_ListOrganizationTopOffenders_Namespace(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("FindLatest", ctx, uint(1), uint(0)).Return(makeLatestReports(), nil)
+
+	service := NewService(store)
+
+	offenders, err := service.ListOrganizationTopOffenders(ctx, 1, TopOffenderOptions{GroupBy: GroupByNamespace, Limit: 2})
+	require.NoError(t, err)
+
+	expected := []Offender{
+		{
+			ClusterIDs: []uint{2},
+			Namespace:  "default",
+			Counts:     SeverityCounts{Critical: 1, High: 2, Low: 1},
+		},
+		{
+			ClusterIDs: []uint{3},
+			Namespace:  "cache",
+			Counts:     SeverityCounts{Critical: 1},
+		},
+	}
+	assert.Equal(t, expected, offenders)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_ListOrganizationTopOffenders_InvalidOptions(t *testing.T) {
+	service := NewService(new(MockStore))
+
+	_, err := service.ListOrganizationTopOffenders(context.Background(), 1, TopOffenderOptions{GroupBy: "pod", Limit: -1})
+	require.Error(t, err)
+
+	var verr ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.Len(t, verr.Violations(), 2)
+}
@@ -0,0 +1,78 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreportadapter
+
+import (
+	"context"
+	"net/http"
+
+	"emperror.dev/errors"
+
+	anchoreapi "github.com/banzaicloud/pipeline/.gen/anchore"
+	"github.com/banzaicloud/pipeline/internal/anchore"
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport"
+)
+
+// vulnerabilityTypeAll returns both OS and non-OS package vulnerabilities.
+const vulnerabilityTypeAll = "all"
+
+type anchoreScanner struct {
+	configProvider anchore.ConfigProvider
+}
+
+// NewAnchoreScanner returns a new vulnreport.Scanner that fetches image vulnerabilities from Anchore.
+func NewAnchoreScanner(configProvider anchore.ConfigProvider) vulnreport.Scanner {
+	return anchoreScanner{
+		configProvider: configProvider,
+	}
+}
+
+func (s anchoreScanner) GetSeverityCounts(ctx context.Context, clusterID uint, imageDigest string) (vulnreport.SeverityCounts, error) {
+	config, err := s.configProvider.GetConfiguration(ctx, clusterID)
+	if err != nil {
+		return vulnreport.SeverityCounts{}, errors.WrapIfWithDetails(err, "failed to get anchore configuration", "clusterId", clusterID)
+	}
+
+	client := anchoreapi.NewAPIClient(&anchoreapi.Configuration{
+		BasePath:      config.Endpoint,
+		DefaultHeader: make(map[string]string),
+		UserAgent:     "Pipeline/go",
+	})
+
+	authCtx := context.WithValue(ctx, anchoreapi.ContextBasicAuth, anchoreapi.BasicAuth{
+		UserName: config.User,
+		Password: config.Password,
+	})
+
+	vulnerabilities, resp, err := client.ImagesApi.GetImageVulnerabilitiesByType(authCtx, imageDigest, vulnerabilityTypeAll, nil)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		// the image is unknown to Anchore or its analysis is not finished yet
+		return vulnreport.SeverityCounts{}, errors.WithStack(vulnreport.ErrImageNotAnalyzed)
+	}
+	if err != nil {
+		return vulnreport.SeverityCounts{}, errors.WrapIfWithDetails(
+			err, "failed to get image vulnerabilities from anchore",
+			"clusterId", clusterID,
+			"image", imageDigest,
+		)
+	}
+
+	var counts vulnreport.SeverityCounts
+	for _, vulnerability := range vulnerabilities.Vulnerabilities {
+		counts.Count(vulnerability.Severity)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,66 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreportadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/securityscan"
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport"
+)
+
+type gormClusterLister struct {
+	db *gorm.DB
+}
+
+// NewGormClusterLister returns a new vulnreport.ClusterLister listing clusters with an active security scan integrated service.
+func NewGormClusterLister(db *gorm.DB) vulnreport.ClusterLister {
+	return gormClusterLister{
+		db: db,
+	}
+}
+
+func (l gormClusterLister) ListClusters(_ context.Context) ([]vulnreport.Cluster, error) {
+	rows, err := l.db.
+		Table("clusters").
+		Select("clusters.id, clusters.organization_id").
+		Joins("JOIN cluster_features ON cluster_features.cluster_id = clusters.id").
+		Where("clusters.deleted_at IS NULL").
+		Where("cluster_features.name = ? AND cluster_features.status = ?", securityscan.IntegratedServiceName, integratedservices.IntegratedServiceStatusActive).
+		Order("clusters.id").
+		Rows()
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list clusters with security scan")
+	}
+	defer rows.Close()
+
+	var clusters []vulnreport.Cluster
+
+	for rows.Next() {
+		var cluster vulnreport.Cluster
+
+		if err := rows.Scan(&cluster.ID, &cluster.OrganizationID); err != nil {
+			return nil, errors.WrapIf(err, "failed to scan cluster")
+		}
+
+		clusters = append(clusters, cluster)
+	}
+
+	return clusters, errors.WrapIf(rows.Err(), "failed to list clusters with security scan")
+}
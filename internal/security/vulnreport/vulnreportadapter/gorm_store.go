@@ -0,0 +1,274 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreportadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport"
+)
+
+// reportModel is the persisted form of a vulnerability report.
+type reportModel struct {
+	ID             uint `gorm:"primary_key"`
+	CreatedAt      time.Time
+	OrganizationID uint `gorm:"index:idx_vulnerability_reports_org_cluster"`
+	ClusterID      uint `gorm:"index:idx_vulnerability_reports_org_cluster"`
+	PendingImages  int
+	Critical       int
+	High           int
+	Medium         int
+	Low            int
+	Negligible     int
+	Unknown        int
+}
+
+// TableName changes the default table name.
+func (reportModel) TableName() string {
+	return "vulnerability_reports"
+}
+
+// reportImageModel is the persisted form of an image in a vulnerability report.
+type reportImageModel struct {
+	ID          uint `gorm:"primary_key"`
+	ReportID    uint `gorm:"index"`
+	Namespace   string
+	Release     string
+	ImageName   string
+	ImageTag    string
+	ImageDigest string
+	Critical    int
+	High        int
+	Medium      int
+	Low         int
+	Negligible  int
+	Unknown     int
+}
+
+// TableName changes the default table name.
+func (reportImageModel) TableName() string {
+	return "vulnerability_report_images"
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a new vulnreport.Store backed by a relational database.
+func NewGormStore(db *gorm.DB) vulnreport.Store {
+	return gormStore{
+		db: db,
+	}
+}
+
+func (s gormStore) Create(_ context.Context, report vulnreport.Report) (uint, error) {
+	model := reportModel{
+		CreatedAt:      report.CreatedAt,
+		OrganizationID: report.OrganizationID,
+		ClusterID:      report.ClusterID,
+		PendingImages:  report.PendingImages,
+		Critical:       report.Counts.Critical,
+		High:           report.Counts.High,
+		Medium:         report.Counts.Medium,
+		Low:            report.Counts.Low,
+		Negligible:     report.Counts.Negligible,
+		Unknown:        report.Counts.Unknown,
+	}
+
+	tx := s.db.Begin()
+	if err := tx.Error; err != nil {
+		return 0, errors.WrapIf(err, "failed to begin transaction")
+	}
+
+	if err := tx.Create(&model).Error; err != nil {
+		tx.Rollback()
+
+		return 0, errors.WrapIfWithDetails(err, "failed to create vulnerability report", "clusterId", report.ClusterID)
+	}
+
+	for _, image := range report.Images {
+		imageModel := reportImageModel{
+			ReportID:    model.ID,
+			Namespace:   image.Namespace,
+			Release:     image.Release,
+			ImageName:   image.Image.Name,
+			ImageTag:    image.Image.Tag,
+			ImageDigest: image.Image.Digest,
+			Critical:    image.Counts.Critical,
+			High:        image.Counts.High,
+			Medium:      image.Counts.Medium,
+			Low:         image.Counts.Low,
+			Negligible:  image.Counts.Negligible,
+			Unknown:     image.Counts.Unknown,
+		}
+
+		if err := tx.Create(&imageModel).Error; err != nil {
+			tx.Rollback()
+
+			return 0, errors.WrapIfWithDetails(err, "failed to create vulnerability report image", "clusterId", report.ClusterID)
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return 0, errors.WrapIfWithDetails(err, "failed to commit vulnerability report", "clusterId", report.ClusterID)
+	}
+
+	return model.ID, nil
+}
+
+func (s gormStore) FindSummaries(_ context.Context, organizationID uint, clusterID uint, from time.Time, to time.Time) ([]vulnreport.Report, error) {
+	var models []reportModel
+
+	err := s.db.
+		Where(reportModel{OrganizationID: organizationID, ClusterID: clusterID}).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Order("created_at, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(
+			err, "failed to find vulnerability reports",
+			"organizationId", organizationID,
+			"clusterId", clusterID,
+		)
+	}
+
+	reports := make([]vulnreport.Report, 0, len(models))
+	for _, model := range models {
+		reports = append(reports, toReport(model))
+	}
+
+	return reports, nil
+}
+
+func (s gormStore) FindLatest(_ context.Context, organizationID uint, clusterID uint) ([]vulnreport.Report, error) {
+	// reports of deleted clusters are kept for the trends, but they are no longer relevant here
+	latestIDs := s.db.
+		Model(reportModel{}).
+		Select("MAX(vulnerability_reports.id)").
+		Joins("JOIN clusters ON clusters.id = vulnerability_reports.cluster_id AND clusters.deleted_at IS NULL").
+		Where(reportModel{OrganizationID: organizationID, ClusterID: clusterID}).
+		Group("vulnerability_reports.cluster_id").
+		SubQuery()
+
+	var models []reportModel
+
+	err := s.db.Where("id IN ?", latestIDs).Order("cluster_id").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(
+			err, "failed to find latest vulnerability reports",
+			"organizationId", organizationID,
+			"clusterId", clusterID,
+		)
+	}
+
+	if len(models) == 0 {
+		return nil, nil
+	}
+
+	reports := make([]vulnreport.Report, 0, len(models))
+	indexes := make(map[uint]int, len(models))
+	reportIDs := make([]uint, 0, len(models))
+
+	for i, model := range models {
+		reports = append(reports, toReport(model))
+		indexes[model.ID] = i
+		reportIDs = append(reportIDs, model.ID)
+	}
+
+	var imageModels []reportImageModel
+
+	err = s.db.Where("report_id IN (?)", reportIDs).Order("id").Find(&imageModels).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(
+			err, "failed to find vulnerability report images",
+			"organizationId", organizationID,
+			"clusterId", clusterID,
+		)
+	}
+
+	for _, imageModel := range imageModels {
+		report := &reports[indexes[imageModel.ReportID]]
+
+		report.Images = append(report.Images, vulnreport.ImageReport{
+			Namespace: imageModel.Namespace,
+			Release:   imageModel.Release,
+			Image: vulnreport.Image{
+				Name:   imageModel.ImageName,
+				Tag:    imageModel.ImageTag,
+				Digest: imageModel.ImageDigest,
+			},
+			Counts: vulnreport.SeverityCounts{
+				Critical:   imageModel.Critical,
+				High:       imageModel.High,
+				Medium:     imageModel.Medium,
+				Low:        imageModel.Low,
+				Negligible: imageModel.Negligible,
+				Unknown:    imageModel.Unknown,
+			},
+		})
+	}
+
+	return reports, nil
+}
+
+func (s gormStore) DeleteOlderThan(_ context.Context, t time.Time) (int64, error) {
+	oldIDs := s.db.Model(reportModel{}).Select("id").Where("created_at < ?", t).SubQuery()
+
+	tx := s.db.Begin()
+	if err := tx.Error; err != nil {
+		return 0, errors.WrapIf(err, "failed to begin transaction")
+	}
+
+	if err := tx.Where("report_id IN ?", oldIDs).Delete(reportImageModel{}).Error; err != nil {
+		tx.Rollback()
+
+		return 0, errors.WrapIf(err, "failed to delete old vulnerability report images")
+	}
+
+	result := tx.Where("created_at < ?", t).Delete(reportModel{})
+	if err := result.Error; err != nil {
+		tx.Rollback()
+
+		return 0, errors.WrapIf(err, "failed to delete old vulnerability reports")
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return 0, errors.WrapIf(err, "failed to commit vulnerability report removal")
+	}
+
+	return result.RowsAffected, nil
+}
+
+func toReport(model reportModel) vulnreport.Report {
+	return vulnreport.Report{
+		ID:             model.ID,
+		OrganizationID: model.OrganizationID,
+		ClusterID:      model.ClusterID,
+		CreatedAt:      model.CreatedAt,
+		Counts: vulnreport.SeverityCounts{
+			Critical:   model.Critical,
+			High:       model.High,
+			Medium:     model.Medium,
+			Low:        model.Low,
+			Negligible: model.Negligible,
+			Unknown:    model.Unknown,
+		},
+		PendingImages: model.PendingImages,
+	}
+}
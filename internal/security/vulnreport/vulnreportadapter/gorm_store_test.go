@@ -0,0 +1,182 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreportadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, common.NoopLogger{})
+	require.NoError(t, err)
+
+	// minimal versions of tables owned by other modules
+	err = db.Exec("CREATE TABLE clusters (id integer primary key, organization_id integer, deleted_at datetime)").Error
+	require.NoError(t, err)
+
+	err = db.Exec("CREATE TABLE cluster_features (id integer primary key, cluster_id integer, name varchar(255), status varchar(255))").Error
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore(t *testing.T) {
+	db := setUpDatabase(t)
+	store := NewGormStore(db)
+	ctx := context.Background()
+
+	require.NoError(t, db.Exec("INSERT INTO clusters (id, organization_id) VALUES (2, 1), (3, 1), (4, 1)").Error)
+	require.NoError(t, db.Exec("UPDATE clusters SET deleted_at = ? WHERE id = 4", time.Now()).Error)
+
+	nginx := vulnreport.Image{Name: "nginx", Tag: "1.17", Digest: "sha256:1"}
+	redis := vulnreport.Image{Name: "redis", Tag: "5", Digest: "sha256:2"}
+
+	day := time.Date(2020, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	reports := []vulnreport.Report{
+		{
+			OrganizationID: 1,
+			ClusterID:      2,
+			CreatedAt:      day,
+			Counts:         vulnreport.SeverityCounts{Critical: 1, High: 2},
+			Images: []vulnreport.ImageReport{
+				{Namespace: "default", Release: "web", Image: nginx, Counts: vulnreport.SeverityCounts{High: 2}},
+				{Namespace: "default", Release: "web", Image: redis, Counts: vulnreport.SeverityCounts{Critical: 1}},
+			},
+		},
+		{
+			OrganizationID: 1,
+			ClusterID:      2,
+			CreatedAt:      day.Add(24 * time.Hour),
+			Counts:         vulnreport.SeverityCounts{High: 2},
+			PendingImages:  1,
+			Images: []vulnreport.ImageReport{
+				{Namespace: "default", Release: "web", Image: nginx, Counts: vulnreport.SeverityCounts{High: 2}},
+			},
+		},
+		{
+			OrganizationID: 1,
+			ClusterID:      3,
+			CreatedAt:      day.Add(25 * time.Hour),
+			Counts:         vulnreport.SeverityCounts{Critical: 1},
+			Images: []vulnreport.ImageReport{
+				{Namespace: "cache", Image: redis, Counts: vulnreport.SeverityCounts{Critical: 1}},
+			},
+		},
+		{
+			OrganizationID: 1,
+			ClusterID:      4, // deleted
+			CreatedAt:      day.Add(26 * time.Hour),
+			Counts:         vulnreport.SeverityCounts{Low: 1},
+		},
+		{
+			OrganizationID: 2,
+			ClusterID:      5,
+			CreatedAt:      day,
+			Counts:         vulnreport.SeverityCounts{Low: 1},
+		},
+	}
+
+	for i := range reports {
+		id, err := store.Create(ctx, reports[i])
+		require.NoError(t, err)
+
+		reports[i].ID = id
+	}
+
+	t.Run("FindSummaries", func(t *testing.T) {
+		summaries, err := store.FindSummaries(ctx, 1, 0, day, day.Add(48*time.Hour))
+		require.NoError(t, err)
+
+		require.Len(t, summaries, 4)
+		assert.Equal(t, []uint{2, 2, 3, 4}, []uint{summaries[0].ClusterID, summaries[1].ClusterID, summaries[2].ClusterID, summaries[3].ClusterID})
+		assert.Equal(t, reports[1].Counts, summaries[1].Counts)
+		assert.Equal(t, 1, summaries[1].PendingImages)
+		assert.Empty(t, summaries[0].Images)
+
+		summaries, err = store.FindSummaries(ctx, 1, 2, day.Add(time.Hour), day.Add(48*time.Hour))
+		require.NoError(t, err)
+
+		require.Len(t, summaries, 1)
+		assert.Equal(t, reports[1].ID, summaries[0].ID)
+	})
+
+	t.Run("FindLatest", func(t *testing.T) {
+		latest, err := store.FindLatest(ctx, 1, 0)
+		require.NoError(t, err)
+
+		require.Len(t, latest, 2)
+		assert.Equal(t, reports[1].ID, latest[0].ID)
+		assert.Equal(t, reports[1].Images, latest[0].Images)
+		assert.Equal(t, reports[2].ID, latest[1].ID)
+		assert.Equal(t, reports[2].Images, latest[1].Images)
+
+		latest, err = store.FindLatest(ctx, 1, 3)
+		require.NoError(t, err)
+
+		require.Len(t, latest, 1)
+		assert.Equal(t, reports[2].ID, latest[0].ID)
+
+		latest, err = store.FindLatest(ctx, 3, 0)
+		require.NoError(t, err)
+
+		assert.Empty(t, latest)
+	})
+
+	t.Run("DeleteOlderThan", func(t *testing.T) {
+		deleted, err := store.DeleteOlderThan(ctx, day.Add(time.Hour))
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(2), deleted)
+
+		var images int
+		require.NoError(t, db.Model(reportImageModel{}).Count(&images).Error)
+
+		assert.Equal(t, 2, images)
+	})
+}
+
+func TestGormClusterLister(t *testing.T) {
+	db := setUpDatabase(t)
+	ctx := context.Background()
+
+	require.NoError(t, db.Exec("INSERT INTO clusters (id, organization_id) VALUES (2, 1), (3, 1), (4, 2), (5, 2)").Error)
+	require.NoError(t, db.Exec("UPDATE clusters SET deleted_at = ? WHERE id = 5", time.Now()).Error)
+	require.NoError(t, db.Exec(
+		"INSERT INTO cluster_features (cluster_id, name, status) VALUES (2, 'securityscan', 'ACTIVE'), (3, 'securityscan', 'PENDING'), (4, 'securityscan', 'ACTIVE'), (4, 'dns', 'ACTIVE'), (5, 'securityscan', 'ACTIVE')",
+	).Error)
+
+	clusters, err := NewGormClusterLister(db).ListClusters(ctx)
+	require.NoError(t, err)
+
+	expected := []vulnreport.Cluster{
+		{ID: 2, OrganizationID: 1},
+		{ID: 4, OrganizationID: 2},
+	}
+	assert.Equal(t, expected, clusters)
+}
@@ -0,0 +1,105 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreportadapter
+
+import (
+	"context"
+	"strings"
+
+	"emperror.dev/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport"
+	pkgHelm "github.com/banzaicloud/pipeline/pkg/helm"
+)
+
+// KubernetesService lists objects in a cluster.
+type KubernetesService interface {
+	List(ctx context.Context, clusterID uint, labels map[string]string, obj runtime.Object) error
+}
+
+type imageLister struct {
+	kubernetesService KubernetesService
+}
+
+// NewImageLister returns a new vulnreport.ImageLister that lists images of running pods.
+func NewImageLister(kubernetesService KubernetesService) vulnreport.ImageLister {
+	return imageLister{
+		kubernetesService: kubernetesService,
+	}
+}
+
+func (l imageLister) ListImages(ctx context.Context, clusterID uint) ([]vulnreport.RunningImage, error) {
+	var pods corev1.PodList
+
+	if err := l.kubernetesService.List(ctx, clusterID, nil, &pods); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list pods", "clusterId", clusterID)
+	}
+
+	var images []vulnreport.RunningImage
+
+	for _, pod := range pods.Items {
+		release := pod.Labels[pkgHelm.HelmReleaseNameLabel]
+		if release == "" {
+			release = pod.Labels[pkgHelm.HelmReleaseNameLabelLegacy]
+		}
+
+		statuses := make([]corev1.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+		statuses = append(statuses, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+		for _, status := range statuses {
+			image, ok := parseImage(status.Image, status.ImageID)
+			if !ok {
+				continue
+			}
+
+			images = append(images, vulnreport.RunningImage{
+				Namespace: pod.Namespace,
+				Release:   release,
+				Image:     image,
+			})
+		}
+	}
+
+	return images, nil
+}
+
+// parseImage parses the image reference and ID of a container status.
+// Images without a digest (eg. not pulled yet) are skipped, because Anchore identifies images by their digest.
+func parseImage(ref string, imageID string) (vulnreport.Image, bool) {
+	i := strings.LastIndex(imageID, "@")
+	if i < 0 || !strings.HasPrefix(imageID[i+1:], "sha256:") {
+		return vulnreport.Image{}, false
+	}
+
+	image := vulnreport.Image{
+		Name:   ref,
+		Tag:    "latest",
+		Digest: imageID[i+1:],
+	}
+
+	// the reference might contain a digest instead of a tag
+	if j := strings.Index(image.Name, "@"); j >= 0 {
+		image.Name = image.Name[:j]
+		image.Tag = ""
+	} else if j := strings.LastIndex(image.Name, ":"); j > strings.LastIndex(image.Name, "/") {
+		// the registry host might contain a port as well
+		image.Name, image.Tag = image.Name[:j], image.Name[j+1:]
+	}
+
+	return image, true
+}
@@ -0,0 +1,74 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreportadapter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport"
+)
+
+func TestParseImage(t *testing.T) {
+	const digest = "sha256:4a7a1f401734777daa6ec2e7c4faa0f3a1f1a0e67e8e5c1a9a0ed2c3e4e0c2a1"
+
+	tests := map[string]struct {
+		ref     string
+		imageID string
+		image   vulnreport.Image
+		ok      bool
+	}{
+		"tagged": {
+			ref:     "nginx:1.17",
+			imageID: "docker-pullable://nginx@" + digest,
+			image:   vulnreport.Image{Name: "nginx", Tag: "1.17", Digest: digest},
+			ok:      true,
+		},
+		"untagged": {
+			ref:     "banzaicloud/pipeline",
+			imageID: "docker-pullable://banzaicloud/pipeline@" + digest,
+			image:   vulnreport.Image{Name: "banzaicloud/pipeline", Tag: "latest", Digest: digest},
+			ok:      true,
+		},
+		"registry with port": {
+			ref:     "registry.example.com:5000/app",
+			imageID: "docker-pullable://registry.example.com:5000/app@" + digest,
+			image:   vulnreport.Image{Name: "registry.example.com:5000/app", Tag: "latest", Digest: digest},
+			ok:      true,
+		},
+		"reference by digest": {
+			ref:     "nginx@" + digest,
+			imageID: "docker-pullable://nginx@" + digest,
+			image:   vulnreport.Image{Name: "nginx", Digest: digest},
+			ok:      true,
+		},
+		"no digest": {
+			ref:     "nginx:1.17",
+			imageID: "docker://sha256:1234",
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			image, ok := parseImage(test.ref, test.imageID)
+
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.image, image)
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreportdriver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport"
+)
+
+// RegisterHTTPHandlers mounts all of the service endpoints into an http.Handler.
+// The router is expected to be an organization router (ie. /orgs/{orgId}).
+func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
+
+	router.Methods(http.MethodGet).Path("/vulnerabilities/trend").Handler(kithttp.NewServer(
+		endpoints.GetOrganizationTrend,
+		decodeGetOrganizationTrendHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetOrganizationTrendHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/vulnerabilities/top-offenders").Handler(kithttp.NewServer(
+		endpoints.ListOrganizationTopOffenders,
+		decodeListOrganizationTopOffendersHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListOrganizationTopOffendersHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/clusters/{clusterId}/vulnerabilities/trend").Handler(kithttp.NewServer(
+		endpoints.GetClusterTrend,
+		decodeGetClusterTrendHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetClusterTrendHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/clusters/{clusterId}/vulnerabilities/top-offenders").Handler(kithttp.NewServer(
+		endpoints.ListClusterTopOffenders,
+		decodeListClusterTopOffendersHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListClusterTopOffendersHTTPResponse, errorEncoder),
+		options...,
+	))
+}
+
+func decodeGetOrganizationTrendHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := decodeTrendOptions(r.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	return GetOrganizationTrendRequest{OrganizationID: orgID, Options: options}, nil
+}
+
+func encodeGetOrganizationTrendHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(GetOrganizationTrendResponse)
+
+	return encodeTrend(ctx, w, resp.Trend)
+}
+
+func decodeGetClusterTrendHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	clusterID, err := extractUintParam(r, "clusterId")
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := decodeTrendOptions(r.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	return GetClusterTrendRequest{OrganizationID: orgID, ClusterID: clusterID, Options: options}, nil
+}
+
+func encodeGetClusterTrendHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(GetClusterTrendResponse)
+
+	return encodeTrend(ctx, w, resp.Trend)
+}
+
+func decodeListOrganizationTopOffendersHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := decodeTopOffenderOptions(r.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	return ListOrganizationTopOffendersRequest{OrganizationID: orgID, Options: options}, nil
+}
+
+func encodeListOrganizationTopOffendersHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListOrganizationTopOffendersResponse)
+
+	return encodeOffenders(ctx, w, resp.Offenders)
+}
+
+func decodeListClusterTopOffendersHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	clusterID, err := extractUintParam(r, "clusterId")
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := decodeTopOffenderOptions(r.URL.Query())
+	if err != nil {
+		return nil, err
+	}
+
+	return ListClusterTopOffendersRequest{OrganizationID: orgID, ClusterID: clusterID, Options: options}, nil
+}
+
+func encodeListClusterTopOffendersHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListClusterTopOffendersResponse)
+
+	return encodeOffenders(ctx, w, resp.Offenders)
+}
+
+func encodeTrend(ctx context.Context, w http.ResponseWriter, trend []vulnreport.TrendPoint) error {
+	if trend == nil {
+		trend = []vulnreport.TrendPoint{}
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, trend)
+}
+
+func encodeOffenders(ctx context.Context, w http.ResponseWriter, offenders []vulnreport.Offender) error {
+	if offenders == nil {
+		offenders = []vulnreport.Offender{}
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, offenders)
+}
+
+func decodeTrendOptions(query url.Values) (vulnreport.TrendOptions, error) {
+	var violations []string
+	var options vulnreport.TrendOptions
+
+	if from := query.Get("from"); from != "" {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("invalid start time (RFC3339 expected): %s", from))
+		}
+
+		options.From = t
+	}
+
+	if to := query.Get("to"); to != "" {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("invalid end time (RFC3339 expected): %s", to))
+		}
+
+		options.To = t
+	}
+
+	if len(violations) > 0 {
+		return options, vulnreport.NewValidationError("invalid query parameters", violations)
+	}
+
+	return options, nil
+}
+
+func decodeTopOffenderOptions(query url.Values) (vulnreport.TopOffenderOptions, error) {
+	options := vulnreport.TopOffenderOptions{
+		GroupBy: query.Get("groupBy"),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+
+		options.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return options, vulnreport.NewValidationError("invalid query parameters", []string{fmt.Sprintf("invalid limit: %s", limit)})
+		}
+	}
+
+	return options, nil
+}
+
+func extractUintParam(r *http.Request, param string) (uint, error) {
+	vars := mux.Vars(r)
+
+	value, ok := vars[param]
+	if !ok || value == "" {
+		return 0, errors.NewWithDetails("missing path parameter", "param", param)
+	}
+
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "invalid path parameter", "param", param, "value", value)
+	}
+
+	return uint(id), nil
+}
@@ -0,0 +1,128 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreportdriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport"
+)
+
+func newTestServer(endpoints Endpoints) *httptest.Server {
+	handler := mux.NewRouter()
+	RegisterHTTPHandlers(
+		endpoints,
+		handler.PathPrefix("/orgs/{orgId}").Subrouter(),
+		kithttp.ServerErrorEncoder(kitxhttp.NewJSONProblemErrorEncoder(apphttp.NewDefaultProblemConverter())),
+	)
+
+	return httptest.NewServer(handler)
+}
+
+func TestRegisterHTTPHandlers_GetClusterTrend(t *testing.T) {
+	from := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	ts := newTestServer(Endpoints{
+		GetClusterTrend: func(ctx context.Context, request interface{}) (interface{}, error) {
+			expected := GetClusterTrendRequest{
+				OrganizationID: 1,
+				ClusterID:      2,
+				Options:        vulnreport.TrendOptions{From: from},
+			}
+
+			if !assert.Equal(t, expected, request) {
+				return nil, nil
+			}
+
+			return GetClusterTrendResponse{
+				Trend: []vulnreport.TrendPoint{
+					{Date: from, Counts: vulnreport.SeverityCounts{Critical: 1}, Clusters: 1},
+				},
+			}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/orgs/1/clusters/2/vulnerabilities/trend?from=2020-03-01T00:00:00Z")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var trend []vulnreport.TrendPoint
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&trend))
+
+	require.Len(t, trend, 1)
+	assert.Equal(t, 1, trend[0].Counts.Critical)
+}
+
+func TestRegisterHTTPHandlers_GetOrganizationTrend_InvalidTime(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		GetOrganizationTrend: func(ctx context.Context, request interface{}) (interface{}, error) {
+			t.Error("endpoint should not be called")
+
+			return nil, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/orgs/1/vulnerabilities/trend?to=yesterday")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestRegisterHTTPHandlers_ListOrganizationTopOffenders(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		ListOrganizationTopOffenders: func(ctx context.Context, request interface{}) (interface{}, error) {
+			expected := ListOrganizationTopOffendersRequest{
+				OrganizationID: 1,
+				Options:        vulnreport.TopOffenderOptions{GroupBy: vulnreport.GroupByRelease, Limit: 5},
+			}
+
+			if !assert.Equal(t, expected, request) {
+				return nil, nil
+			}
+
+			return ListOrganizationTopOffendersResponse{}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/orgs/1/vulnerabilities/top-offenders?groupBy=release&limit=5")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var offenders []vulnreport.Offender
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&offenders))
+
+	assert.NotNil(t, offenders)
+	assert.Empty(t, offenders)
+}
@@ -0,0 +1,120 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreportworkflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport"
+)
+
+const ListClustersActivityName = "vulnerability-report-list-clusters-activity"
+
+type ListClustersActivityInput struct{}
+
+type ListClustersActivityOutput struct {
+	Clusters []vulnreport.Cluster
+}
+
+// ListClustersActivity lists clusters vulnerability reports should be collected for.
+type ListClustersActivity struct {
+	clusters vulnreport.ClusterLister
+}
+
+// NewListClustersActivity returns a new ListClustersActivity.
+func NewListClustersActivity(clusters vulnreport.ClusterLister) ListClustersActivity {
+	return ListClustersActivity{
+		clusters: clusters,
+	}
+}
+
+func (a ListClustersActivity) Execute(ctx context.Context, _ ListClustersActivityInput) (ListClustersActivityOutput, error) {
+	clusters, err := a.clusters.ListClusters(ctx)
+	if err != nil {
+		return ListClustersActivityOutput{}, err
+	}
+
+	return ListClustersActivityOutput{Clusters: clusters}, nil
+}
+
+const CollectActivityName = "vulnerability-report-collect-activity"
+
+type CollectActivityInput struct {
+	Cluster vulnreport.Cluster
+}
+
+type CollectActivityOutput struct {
+	ReportID      uint
+	Counts        vulnreport.SeverityCounts
+	PendingImages int
+}
+
+// CollectActivity collects a vulnerability report for a cluster.
+type CollectActivity struct {
+	collector vulnreport.Collector
+}
+
+// NewCollectActivity returns a new CollectActivity.
+func NewCollectActivity(collector vulnreport.Collector) CollectActivity {
+	return CollectActivity{
+		collector: collector,
+	}
+}
+
+func (a CollectActivity) Execute(ctx context.Context, input CollectActivityInput) (CollectActivityOutput, error) {
+	report, err := a.collector.Collect(ctx, input.Cluster)
+	if err != nil {
+		return CollectActivityOutput{}, err
+	}
+
+	return CollectActivityOutput{
+		ReportID:      report.ID,
+		Counts:        report.Counts,
+		PendingImages: report.PendingImages,
+	}, nil
+}
+
+const RetentionActivityName = "vulnerability-report-retention-activity"
+
+type RetentionActivityInput struct {
+	// Reports created before this time are removed
+	Before time.Time
+}
+
+type RetentionActivityOutput struct {
+	Removed int64
+}
+
+// RetentionActivity removes old vulnerability reports.
+type RetentionActivity struct {
+	store vulnreport.Store
+}
+
+// NewRetentionActivity returns a new RetentionActivity.
+func NewRetentionActivity(store vulnreport.Store) RetentionActivity {
+	return RetentionActivity{
+		store: store,
+	}
+}
+
+func (a RetentionActivity) Execute(ctx context.Context, input RetentionActivityInput) (RetentionActivityOutput, error) {
+	removed, err := a.store.DeleteOlderThan(ctx, input.Before)
+	if err != nil {
+		return RetentionActivityOutput{}, err
+	}
+
+	return RetentionActivityOutput{Removed: removed}, nil
+}
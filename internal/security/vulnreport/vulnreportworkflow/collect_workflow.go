@@ -0,0 +1,93 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreportworkflow
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/workflow"
+)
+
+const CollectWorkflowName = "vulnerability-report-collect"
+
+// CollectWorkflowInput defines the fixed inputs of the collect workflow.
+type CollectWorkflowInput struct {
+	// Reports older than this are removed (zero keeps every report)
+	MaxAge time.Duration
+}
+
+// CollectWorkflow collects vulnerability reports for every cluster with security scan enabled
+// and removes the reports older than the configured age.
+// It is supposed to be scheduled as a cron workflow.
+func CollectWorkflow(ctx workflow.Context, input CollectWorkflowInput) error {
+	logger := workflow.GetLogger(ctx).Sugar()
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    10 * time.Minute,
+		WaitForCancellation:    true,
+	})
+
+	var clusters ListClustersActivityOutput
+
+	if err := workflow.ExecuteActivity(ctx, ListClustersActivityName, ListClustersActivityInput{}).Get(ctx, &clusters); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to execute activity", "activity", ListClustersActivityName)
+	}
+
+	var failed int
+
+	// a failing cluster (eg. unreachable API server) should not block the reports of the others
+	for _, cluster := range clusters.Clusters {
+		var output CollectActivityOutput
+
+		err := workflow.ExecuteActivity(ctx, CollectActivityName, CollectActivityInput{Cluster: cluster}).Get(ctx, &output)
+		if err != nil {
+			failed++
+
+			logger.Warnw("failed to collect vulnerability report", "clusterId", cluster.ID, "error", err.Error())
+
+			continue
+		}
+
+		logger.Infow(
+			"vulnerability report collected",
+			"clusterId", cluster.ID,
+			"reportId", output.ReportID,
+			"vulnerabilities", output.Counts.Total(),
+			"pendingImages", output.PendingImages,
+		)
+	}
+
+	if input.MaxAge > 0 {
+		activityInput := RetentionActivityInput{
+			Before: workflow.Now(ctx).Add(-input.MaxAge),
+		}
+
+		var output RetentionActivityOutput
+
+		if err := workflow.ExecuteActivity(ctx, RetentionActivityName, activityInput).Get(ctx, &output); err != nil {
+			return errors.WrapIfWithDetails(err, "failed to execute activity", "activity", RetentionActivityName)
+		}
+
+		logger.Infow("old vulnerability reports removed", "removed", output.Removed)
+	}
+
+	if failed > 0 {
+		return errors.NewWithDetails("failed to collect some vulnerability reports", "failed", failed, "clusters", len(clusters.Clusters))
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnreportworkflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/banzaicloud/pipeline/internal/security/vulnreport"
+)
+
+func testListClustersActivityExecute(_ context.Context, _ ListClustersActivityInput) (ListClustersActivityOutput, error) {
+	return ListClustersActivityOutput{}, nil
+}
+
+func testCollectActivityExecute(_ context.Context, _ CollectActivityInput) (CollectActivityOutput, error) {
+	return CollectActivityOutput{}, nil
+}
+
+func testRetentionActivityExecute(_ context.Context, _ RetentionActivityInput) (RetentionActivityOutput, error) {
+	return RetentionActivityOutput{}, nil
+}
+
+// nolint: gochecknoinits
+func init() {
+	workflow.RegisterWithOptions(CollectWorkflow, workflow.RegisterOptions{Name: CollectWorkflowName})
+
+	activity.RegisterWithOptions(testListClustersActivityExecute, activity.RegisterOptions{Name: ListClustersActivityName})
+	activity.RegisterWithOptions(testCollectActivityExecute, activity.RegisterOptions{Name: CollectActivityName})
+	activity.RegisterWithOptions(testRetentionActivityExecute, activity.RegisterOptions{Name: RetentionActivityName})
+}
+
+func TestCollectWorkflow(t *testing.T) {
+	var testSuite testsuite.WorkflowTestSuite
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	clusters := []vulnreport.Cluster{{ID: 2, OrganizationID: 1}, {ID: 3, OrganizationID: 1}}
+
+	env.OnActivity(ListClustersActivityName, mock.Anything, ListClustersActivityInput{}).
+		Return(ListClustersActivityOutput{Clusters: clusters}, nil)
+	env.OnActivity(CollectActivityName, mock.Anything, CollectActivityInput{Cluster: clusters[0]}).
+		Return(CollectActivityOutput{ReportID: 1}, nil)
+	env.OnActivity(CollectActivityName, mock.Anything, CollectActivityInput{Cluster: clusters[1]}).
+		Return(CollectActivityOutput{}, errors.New("cluster is unreachable"))
+
+	var retentionInput RetentionActivityInput
+	env.OnActivity(RetentionActivityName, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, input RetentionActivityInput) (RetentionActivityOutput, error) {
+			retentionInput = input
+
+			return RetentionActivityOutput{Removed: 3}, nil
+		})
+
+	env.ExecuteWorkflow(CollectWorkflowName, CollectWorkflowInput{MaxAge: 24 * time.Hour})
+
+	require.True(t, env.IsWorkflowCompleted())
+
+	// the failed cluster is reported, but it does not prevent the rest of the workflow
+	assert.Error(t, env.GetWorkflowError())
+	assert.True(t, env.Now().Add(-24*time.Hour).Equal(retentionInput.Before))
+
+	env.AssertExpectations(t)
+}
+
+func TestCollectWorkflow_NoRetention(t *testing.T) {
+	var testSuite testsuite.WorkflowTestSuite
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	env.OnActivity(ListClustersActivityName, mock.Anything, ListClustersActivityInput{}).
+		Return(ListClustersActivityOutput{}, nil)
+
+	env.ExecuteWorkflow(CollectWorkflowName, CollectWorkflowInput{})
+
+	require.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+
+	env.AssertExpectations(t)
+	env.AssertNotCalled(t, RetentionActivityName, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,39 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whitelist
+
+import (
+	"context"
+	"time"
+)
+
+// ItemExpired is dispatched when an expired whitelist item is removed from a cluster.
+type ItemExpired struct {
+	OrganizationID uint
+	ClusterID      uint
+	ClusterName    string
+	Name           string
+	Owner          string
+	Reason         string
+	ExpiresAt      time.Time
+}
+
+// +testify:mock:testOnly=true
+
+// EventDispatcher dispatches whitelist events.
+type EventDispatcher interface {
+	// ItemExpired notifies the owner of a whitelist item about its removal.
+	ItemExpired(ctx context.Context, event ItemExpired) error
+}
@@ -0,0 +1,103 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whitelist
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+
+	anchore "github.com/banzaicloud/pipeline/internal/security"
+	"github.com/banzaicloud/pipeline/pkg/security"
+)
+
+// Expirer removes expired whitelist items from clusters.
+type Expirer struct {
+	resources anchore.WhitelistService
+	requests  RequestStore
+	events    EventDispatcher
+
+	now func() time.Time
+}
+
+// NewExpirer returns a new Expirer.
+func NewExpirer(resources anchore.WhitelistService, requests RequestStore, events EventDispatcher) Expirer {
+	return Expirer{
+		resources: resources,
+		requests:  requests,
+		events:    events,
+
+		now: time.Now,
+	}
+}
+
+// RemoveExpired removes the expired whitelist items of a cluster and notifies their owners.
+// Pending requests that expired before being approved are removed as well (without notification).
+func (e Expirer) RemoveExpired(ctx context.Context, cluster Cluster) ([]security.ReleaseWhiteListItem, error) {
+	now := e.now()
+
+	resources, err := e.resources.GetWhitelists(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []security.ReleaseWhiteListItem
+	var errs error
+
+	for _, resource := range resources {
+		item := anchore.ReleaseWhiteListItemFromResource(resource)
+		if !item.Expired(now) {
+			continue
+		}
+
+		if err := e.resources.DeleteWhitelist(ctx, cluster, item.Name); err != nil {
+			errs = errors.Append(errs, err)
+
+			continue
+		}
+
+		removed = append(removed, item)
+
+		event := ItemExpired{
+			OrganizationID: cluster.GetOrganizationId(),
+			ClusterID:      cluster.GetID(),
+			ClusterName:    cluster.GetName(),
+			Name:           item.Name,
+			Owner:          item.Owner,
+			Reason:         item.Reason,
+			ExpiresAt:      *item.ExpiresAt,
+		}
+
+		if err := e.events.ItemExpired(ctx, event); err != nil {
+			errs = errors.Append(errs, errors.WrapIfWithDetails(err, "failed to dispatch whitelist expiry event", "name", item.Name))
+		}
+	}
+
+	requests, err := e.requests.List(ctx, cluster.GetID())
+	if err != nil {
+		return removed, errors.Append(errs, err)
+	}
+
+	for _, request := range requests {
+		if !request.Item.Expired(now) {
+			continue
+		}
+
+		errs = errors.Append(errs, e.requests.Delete(ctx, cluster.GetID(), request.Item.Name))
+	}
+
+	return removed, errs
+}
@@ -0,0 +1,71 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whitelist
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	securityV1Alpha "github.com/banzaicloud/anchore-image-validator/pkg/apis/security/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	anchore "github.com/banzaicloud/pipeline/internal/security"
+	"github.com/banzaicloud/pipeline/pkg/security"
+)
+
+func TestExpirer_RemoveExpired(t *testing.T) {
+	now := time.Date(2020, time.March, 20, 12, 0, 0, 0, time.UTC)
+	expiredAt := now.Add(-time.Hour)
+	validUntil := now.Add(time.Hour)
+
+	resources := &whitelistServiceStub{items: []securityV1Alpha.WhiteListItem{
+		newResource("expired", map[string]string{anchore.WhitelistExpiresAtAnnotation: expiredAt.Format(time.RFC3339)}),
+		newResource("valid", map[string]string{anchore.WhitelistExpiresAtAnnotation: validUntil.Format(time.RFC3339)}),
+		newResource("permanent", nil),
+	}}
+
+	requests := new(MockRequestStore)
+	requests.On("List", mock.Anything, uint(2)).Return([]Request{
+		{ClusterID: 2, Item: security.ReleaseWhiteListItem{Name: "expired-request", ExpiresAt: &expiredAt}},
+		{ClusterID: 2, Item: security.ReleaseWhiteListItem{Name: "valid-request", ExpiresAt: &validUntil}},
+	}, nil)
+	requests.On("Delete", mock.Anything, uint(2), "expired-request").Return(nil)
+
+	events := new(MockEventDispatcher)
+	events.On("ItemExpired", mock.Anything, ItemExpired{
+		OrganizationID: 1,
+		ClusterID:      2,
+		ClusterName:    "cluster",
+		Name:           "expired",
+		Owner:          "owner",
+		Reason:         "reason",
+		ExpiresAt:      expiredAt,
+	}).Return(nil)
+
+	expirer := Expirer{resources: resources, requests: requests, events: events, now: func() time.Time { return now }}
+
+	removed, err := expirer.RemoveExpired(context.Background(), clusterStub{id: 2})
+	require.NoError(t, err)
+
+	require.Len(t, removed, 1)
+	assert.Equal(t, "expired", removed[0].Name)
+	assert.Equal(t, []string{"expired"}, resources.deleted)
+
+	requests.AssertExpectations(t)
+	events.AssertExpectations(t)
+}
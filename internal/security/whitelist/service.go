@@ -0,0 +1,217 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whitelist
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+
+	anchore "github.com/banzaicloud/pipeline/internal/security"
+	"github.com/banzaicloud/pipeline/pkg/security"
+)
+
+// Config contains whitelist settings.
+type Config struct {
+	// ApprovalRequired makes whitelist items wait for the approval of another organization admin.
+	ApprovalRequired bool
+}
+
+// Service manages the whitelist items of clusters.
+type Service interface {
+	// ListItems lists the active and pending whitelist items of a cluster.
+	ListItems(ctx context.Context, cluster anchore.Cluster) ([]security.ReleaseWhiteListItem, error)
+
+	// CreateItem creates a whitelist item in a cluster or, if approval is required, a request for it.
+	CreateItem(ctx context.Context, cluster anchore.Cluster, item security.ReleaseWhiteListItem, requester User) (security.ReleaseWhiteListItem, error)
+
+	// ApproveItem installs a pending whitelist item in a cluster.
+	ApproveItem(ctx context.Context, cluster anchore.Cluster, name string, approver User) (security.ReleaseWhiteListItem, error)
+
+	// DeleteItem deletes an active whitelist item or rejects a pending one.
+	DeleteItem(ctx context.Context, cluster anchore.Cluster, name string) error
+}
+
+type service struct {
+	config    Config
+	resources anchore.WhitelistService
+	requests  RequestStore
+
+	now func() time.Time
+}
+
+// NewService returns a new Service.
+func NewService(config Config, resources anchore.WhitelistService, requests RequestStore) Service {
+	return service{
+		config:    config,
+		resources: resources,
+		requests:  requests,
+
+		now: time.Now,
+	}
+}
+
+func (s service) ListItems(ctx context.Context, cluster anchore.Cluster) ([]security.ReleaseWhiteListItem, error) {
+	resources, err := s.resources.GetWhitelists(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	requests, err := s.requests.List(ctx, cluster.GetID())
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]security.ReleaseWhiteListItem, 0, len(resources)+len(requests))
+
+	for _, resource := range resources {
+		items = append(items, anchore.ReleaseWhiteListItemFromResource(resource))
+	}
+
+	for _, request := range requests {
+		item := request.Item
+		item.Status = security.WhiteListItemStatusPending
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (s service) CreateItem(ctx context.Context, cluster anchore.Cluster, item security.ReleaseWhiteListItem, requester User) (security.ReleaseWhiteListItem, error) {
+	if err := s.validate(item); err != nil {
+		return item, err
+	}
+
+	if err := s.checkExists(ctx, cluster, item.Name); err != nil {
+		return item, err
+	}
+
+	item.Status = ""
+	item.ApprovedBy = ""
+
+	if !s.config.ApprovalRequired {
+		if _, err := s.resources.CreateWhitelist(ctx, cluster, item); err != nil {
+			return item, err
+		}
+
+		item.Status = security.WhiteListItemStatusActive
+
+		return item, nil
+	}
+
+	request := Request{
+		ClusterID:   cluster.GetID(),
+		Item:        item,
+		RequestedBy: requester.ID,
+	}
+
+	if _, err := s.requests.Create(ctx, request); err != nil {
+		return item, err
+	}
+
+	item.Status = security.WhiteListItemStatusPending
+
+	return item, nil
+}
+
+func (s service) ApproveItem(ctx context.Context, cluster anchore.Cluster, name string, approver User) (security.ReleaseWhiteListItem, error) {
+	request, err := s.requests.Get(ctx, cluster.GetID(), name)
+	if err != nil {
+		return security.ReleaseWhiteListItem{}, err
+	}
+
+	item := request.Item
+
+	if request.RequestedBy == approver.ID {
+		return item, NewValidationError("whitelist items cannot be approved by their requester", nil)
+	}
+
+	if item.Expired(s.now()) {
+		return item, NewValidationError("whitelist item is already expired", nil)
+	}
+
+	item.ApprovedBy = approver.Login
+
+	if _, err := s.resources.CreateWhitelist(ctx, cluster, item); err != nil {
+		return item, err
+	}
+
+	if err := s.requests.Delete(ctx, cluster.GetID(), name); err != nil {
+		return item, err
+	}
+
+	item.Status = security.WhiteListItemStatusActive
+
+	return item, nil
+}
+
+func (s service) DeleteItem(ctx context.Context, cluster anchore.Cluster, name string) error {
+	_, err := s.requests.Get(ctx, cluster.GetID(), name)
+	if errors.As(err, &NotFoundError{}) {
+		return s.resources.DeleteWhitelist(ctx, cluster, name)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.requests.Delete(ctx, cluster.GetID(), name)
+}
+
+func (s service) validate(item security.ReleaseWhiteListItem) error {
+	var violations []string
+
+	if item.Name == "" {
+		violations = append(violations, "name is required")
+	}
+
+	if item.Owner == "" {
+		violations = append(violations, "owner is required")
+	}
+
+	if item.Expired(s.now()) {
+		violations = append(violations, "expiry must be in the future")
+	}
+
+	if len(violations) > 0 {
+		return NewValidationError("invalid whitelist item", violations)
+	}
+
+	return nil
+}
+
+func (s service) checkExists(ctx context.Context, cluster anchore.Cluster, name string) error {
+	resources, err := s.resources.GetWhitelists(ctx, cluster)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources {
+		if resource.Name == name {
+			return errors.WithStack(AlreadyExistsError{ClusterID: cluster.GetID(), Name: name})
+		}
+	}
+
+	_, err = s.requests.Get(ctx, cluster.GetID(), name)
+	if err == nil {
+		return errors.WithStack(AlreadyExistsError{ClusterID: cluster.GetID(), Name: name})
+	}
+	if !errors.As(err, &NotFoundError{}) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whitelist
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	securityV1Alpha "github.com/banzaicloud/anchore-image-validator/pkg/apis/security/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	anchore "github.com/banzaicloud/pipeline/internal/security"
+	"github.com/banzaicloud/pipeline/pkg/security"
+)
+
+type clusterStub struct {
+	id uint
+}
+
+func (c clusterStub) GetK8sConfig() ([]byte, error) { return nil, nil }
+func (c clusterStub) GetID() uint                   { return c.id }
+func (c clusterStub) GetOrganizationId() uint       { return 1 }
+func (c clusterStub) GetName() string               { return "cluster" }
+
+// whitelistServiceStub keeps whitelist items in memory.
+type whitelistServiceStub struct {
+	items   []securityV1Alpha.WhiteListItem
+	created []security.ReleaseWhiteListItem
+	deleted []string
+}
+
+func (s *whitelistServiceStub) GetWhitelists(_ context.Context, _ anchore.Cluster) ([]securityV1Alpha.WhiteListItem, error) {
+	return s.items, nil
+}
+
+func (s *whitelistServiceStub) CreateWhitelist(_ context.Context, _ anchore.Cluster, item security.ReleaseWhiteListItem) (interface{}, error) {
+	s.created = append(s.created, item)
+
+	return nil, nil
+}
+
+func (s *whitelistServiceStub) DeleteWhitelist(_ context.Context, _ anchore.Cluster, name string) error {
+	s.deleted = append(s.deleted, name)
+
+	return nil
+}
+
+func newResource(name string, annotations map[string]string) securityV1Alpha.WhiteListItem {
+	return securityV1Alpha.WhiteListItem{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations},
+		Spec:       securityV1Alpha.WhiteListSpec{Creator: "owner", Reason: "reason"},
+	}
+}
+
+func TestService_CreateItem(t *testing.T) {
+	now := time.Date(2020, time.March, 20, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(24 * time.Hour)
+	cluster := clusterStub{id: 2}
+	item := security.ReleaseWhiteListItem{Name: "release", Owner: "owner", Reason: "reason", ExpiresAt: &expiresAt}
+
+	t.Run("Active", func(t *testing.T) {
+		resources := &whitelistServiceStub{}
+		requests := new(MockRequestStore)
+		requests.On("Get", mock.Anything, uint(2), "release").Return(Request{}, NotFoundError{ClusterID: 2, Name: "release"})
+
+		svc := service{resources: resources, requests: requests, now: func() time.Time { return now }}
+
+		created, err := svc.CreateItem(context.Background(), cluster, item, User{ID: 1, Login: "john"})
+		require.NoError(t, err)
+
+		assert.Equal(t, security.WhiteListItemStatusActive, created.Status)
+		assert.Equal(t, []security.ReleaseWhiteListItem{item}, resources.created)
+		requests.AssertExpectations(t)
+	})
+
+	t.Run("Pending", func(t *testing.T) {
+		resources := &whitelistServiceStub{}
+		requests := new(MockRequestStore)
+		requests.On("Get", mock.Anything, uint(2), "release").Return(Request{}, NotFoundError{ClusterID: 2, Name: "release"})
+		requests.On("Create", mock.Anything, Request{ClusterID: 2, Item: item, RequestedBy: 1}).Return(uint(1), nil)
+
+		svc := service{config: Config{ApprovalRequired: true}, resources: resources, requests: requests, now: func() time.Time { return now }}
+
+		created, err := svc.CreateItem(context.Background(), cluster, item, User{ID: 1, Login: "john"})
+		require.NoError(t, err)
+
+		assert.Equal(t, security.WhiteListItemStatusPending, created.Status)
+		assert.Empty(t, resources.created)
+		requests.AssertExpectations(t)
+	})
+
+	t.Run("AlreadyExists", func(t *testing.T) {
+		resources := &whitelistServiceStub{items: []securityV1Alpha.WhiteListItem{newResource("release", nil)}}
+
+		svc := service{resources: resources, requests: new(MockRequestStore), now: func() time.Time { return now }}
+
+		_, err := svc.CreateItem(context.Background(), cluster, item, User{ID: 1, Login: "john"})
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &AlreadyExistsError{}))
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		expiredAt := now.Add(-time.Hour)
+		item := item
+		item.ExpiresAt = &expiredAt
+
+		svc := service{resources: &whitelistServiceStub{}, requests: new(MockRequestStore), now: func() time.Time { return now }}
+
+		_, err := svc.CreateItem(context.Background(), cluster, item, User{ID: 1, Login: "john"})
+		require.Error(t, err)
+
+		assert.Equal(t, NewValidationError("invalid whitelist item", []string{"expiry must be in the future"}), err)
+	})
+}
+
+func TestService_ApproveItem(t *testing.T) {
+	now := time.Date(2020, time.March, 20, 12, 0, 0, 0, time.UTC)
+	cluster := clusterStub{id: 2}
+	item := security.ReleaseWhiteListItem{Name: "release", Owner: "owner", Reason: "reason"}
+
+	t.Run("Approved", func(t *testing.T) {
+		resources := &whitelistServiceStub{}
+		requests := new(MockRequestStore)
+		requests.On("Get", mock.Anything, uint(2), "release").Return(Request{ClusterID: 2, Item: item, RequestedBy: 1}, nil)
+		requests.On("Delete", mock.Anything, uint(2), "release").Return(nil)
+
+		svc := service{config: Config{ApprovalRequired: true}, resources: resources, requests: requests, now: func() time.Time { return now }}
+
+		approved, err := svc.ApproveItem(context.Background(), cluster, "release", User{ID: 3, Login: "jane"})
+		require.NoError(t, err)
+
+		expected := item
+		expected.ApprovedBy = "jane"
+
+		assert.Equal(t, []security.ReleaseWhiteListItem{expected}, resources.created)
+		assert.Equal(t, security.WhiteListItemStatusActive, approved.Status)
+		requests.AssertExpectations(t)
+	})
+
+	t.Run("Requester", func(t *testing.T) {
+		resources := &whitelistServiceStub{}
+		requests := new(MockRequestStore)
+		requests.On("Get", mock.Anything, uint(2), "release").Return(Request{ClusterID: 2, Item: item, RequestedBy: 1}, nil)
+
+		svc := service{config: Config{ApprovalRequired: true}, resources: resources, requests: requests, now: func() time.Time { return now }}
+
+		_, err := svc.ApproveItem(context.Background(), cluster, "release", User{ID: 1, Login: "john"})
+		require.Error(t, err)
+
+		assert.True(t, errors.As(err, &ValidationError{}))
+		assert.Empty(t, resources.created)
+	})
+}
+
+func TestService_DeleteItem(t *testing.T) {
+	cluster := clusterStub{id: 2}
+
+	t.Run("Pending", func(t *testing.T) {
+		resources := &whitelistServiceStub{}
+		requests := new(MockRequestStore)
+		requests.On("Get", mock.Anything, uint(2), "release").Return(Request{ClusterID: 2}, nil)
+		requests.On("Delete", mock.Anything, uint(2), "release").Return(nil)
+
+		svc := service{resources: resources, requests: requests, now: time.Now}
+
+		err := svc.DeleteItem(context.Background(), cluster, "release")
+		require.NoError(t, err)
+
+		assert.Empty(t, resources.deleted)
+		requests.AssertExpectations(t)
+	})
+
+	t.Run("Active", func(t *testing.T) {
+		resources := &whitelistServiceStub{}
+		requests := new(MockRequestStore)
+		requests.On("Get", mock.Anything, uint(2), "release").Return(Request{}, NotFoundError{ClusterID: 2, Name: "release"})
+
+		svc := service{resources: resources, requests: requests, now: time.Now}
+
+		err := svc.DeleteItem(context.Background(), cluster, "release")
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"release"}, resources.deleted)
+	})
+}
+
+func TestService_ListItems(t *testing.T) {
+	resources := &whitelistServiceStub{items: []securityV1Alpha.WhiteListItem{
+		newResource("active", map[string]string{
+			anchore.WhitelistExpiresAtAnnotation:  "2020-03-21T12:00:00Z",
+			anchore.WhitelistApprovedByAnnotation: "jane",
+		}),
+	}}
+	requests := new(MockRequestStore)
+	requests.On("List", mock.Anything, uint(2)).Return([]Request{{ClusterID: 2, Item: security.ReleaseWhiteListItem{Name: "pending", Owner: "owner"}}}, nil)
+
+	svc := service{resources: resources, requests: requests, now: time.Now}
+
+	items, err := svc.ListItems(context.Background(), clusterStub{id: 2})
+	require.NoError(t, err)
+
+	expiresAt := time.Date(2020, time.March, 21, 12, 0, 0, 0, time.UTC)
+
+	expected := []security.ReleaseWhiteListItem{
+		{Name: "active", Owner: "owner", Reason: "reason", ExpiresAt: &expiresAt, Status: security.WhiteListItemStatusActive, ApprovedBy: "jane"},
+		{Name: "pending", Owner: "owner", Status: security.WhiteListItemStatusPending},
+	}
+
+	assert.Equal(t, expected, items)
+}
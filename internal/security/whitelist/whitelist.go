@@ -0,0 +1,69 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whitelist
+
+import (
+	"context"
+	"time"
+
+	anchore "github.com/banzaicloud/pipeline/internal/security"
+	"github.com/banzaicloud/pipeline/pkg/security"
+)
+
+// Request is a whitelist item waiting for the approval of an organization admin.
+type Request struct {
+	ID          uint
+	ClusterID   uint
+	Item        security.ReleaseWhiteListItem
+	RequestedBy uint
+	CreatedAt   time.Time
+}
+
+// User identifies the user requesting or approving a whitelist item.
+type User struct {
+	ID    uint
+	Login string
+}
+
+// Cluster is a cluster whitelist items are installed in.
+type Cluster interface {
+	anchore.Cluster
+
+	GetOrganizationId() uint
+	GetName() string
+}
+
+// +testify:mock:testOnly=true
+
+// RequestStore persists whitelist items waiting for approval.
+type RequestStore interface {
+	// Create stores a new request.
+	Create(ctx context.Context, request Request) (uint, error)
+
+	// Get returns a request of a cluster.
+	Get(ctx context.Context, clusterID uint, name string) (Request, error)
+
+	// List lists the requests of a cluster.
+	List(ctx context.Context, clusterID uint) ([]Request, error)
+
+	// Delete removes a request.
+	Delete(ctx context.Context, clusterID uint, name string) error
+}
+
+// ClusterLister lists clusters with the security scan integrated service enabled.
+type ClusterLister interface {
+	// ListClusters returns the IDs of the clusters.
+	ListClusters(ctx context.Context) ([]uint, error)
+}
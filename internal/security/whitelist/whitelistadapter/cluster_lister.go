@@ -0,0 +1,54 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whitelistadapter
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/integratedservices/services/securityscan"
+	"github.com/banzaicloud/pipeline/internal/security/whitelist"
+)
+
+type gormClusterLister struct {
+	db *gorm.DB
+}
+
+// NewGormClusterLister returns a new whitelist.ClusterLister listing clusters with an active security scan integrated service.
+func NewGormClusterLister(db *gorm.DB) whitelist.ClusterLister {
+	return gormClusterLister{
+		db: db,
+	}
+}
+
+func (l gormClusterLister) ListClusters(_ context.Context) ([]uint, error) {
+	var clusterIDs []uint
+
+	err := l.db.
+		Table("clusters").
+		Joins("JOIN cluster_features ON cluster_features.cluster_id = clusters.id").
+		Where("clusters.deleted_at IS NULL").
+		Where("cluster_features.name = ? AND cluster_features.status = ?", securityscan.IntegratedServiceName, integratedservices.IntegratedServiceStatusActive).
+		Order("clusters.id").
+		Pluck("clusters.id", &clusterIDs).Error
+	if err != nil {
+		return nil, errors.WrapIf(err, "failed to list clusters with security scan")
+	}
+
+	return clusterIDs, nil
+}
@@ -0,0 +1,142 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whitelistadapter
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/security/whitelist"
+	"github.com/banzaicloud/pipeline/pkg/security"
+)
+
+// requestModel is the persisted form of a whitelist item waiting for approval.
+type requestModel struct {
+	ID          uint `gorm:"primary_key"`
+	CreatedAt   time.Time
+	ClusterID   uint   `gorm:"unique_index:idx_whitelist_requests_cluster_name"`
+	Name        string `gorm:"unique_index:idx_whitelist_requests_cluster_name"`
+	Owner       string
+	Reason      string `gorm:"type:text"`
+	Regexp      string
+	ExpiresAt   *time.Time
+	RequestedBy uint
+}
+
+// TableName changes the default table name.
+func (requestModel) TableName() string {
+	return "security_whitelist_requests"
+}
+
+type gormRequestStore struct {
+	db *gorm.DB
+}
+
+// NewGormRequestStore returns a new whitelist.RequestStore backed by a relational database.
+func NewGormRequestStore(db *gorm.DB) whitelist.RequestStore {
+	return gormRequestStore{
+		db: db,
+	}
+}
+
+func (s gormRequestStore) Create(_ context.Context, request whitelist.Request) (uint, error) {
+	model := requestModel{
+		ClusterID:   request.ClusterID,
+		Name:        request.Item.Name,
+		Owner:       request.Item.Owner,
+		Reason:      request.Item.Reason,
+		Regexp:      request.Item.Regexp,
+		ExpiresAt:   request.Item.ExpiresAt,
+		RequestedBy: request.RequestedBy,
+	}
+
+	if err := s.db.Create(&model).Error; err != nil {
+		return 0, errors.WrapIfWithDetails(
+			err, "failed to create whitelist request",
+			"clusterId", request.ClusterID,
+			"name", request.Item.Name,
+		)
+	}
+
+	return model.ID, nil
+}
+
+func (s gormRequestStore) Get(_ context.Context, clusterID uint, name string) (whitelist.Request, error) {
+	var model requestModel
+
+	err := s.db.Where(requestModel{ClusterID: clusterID, Name: name}).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return whitelist.Request{}, errors.WithStack(whitelist.NotFoundError{
+			ClusterID: clusterID,
+			Name:      name,
+		})
+	} else if err != nil {
+		return whitelist.Request{}, errors.WrapIfWithDetails(
+			err, "failed to get whitelist request",
+			"clusterId", clusterID,
+			"name", name,
+		)
+	}
+
+	return toRequest(model), nil
+}
+
+func (s gormRequestStore) List(_ context.Context, clusterID uint) ([]whitelist.Request, error) {
+	var models []requestModel
+
+	err := s.db.Where(requestModel{ClusterID: clusterID}).Order("id").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list whitelist requests", "clusterId", clusterID)
+	}
+
+	requests := make([]whitelist.Request, 0, len(models))
+	for _, model := range models {
+		requests = append(requests, toRequest(model))
+	}
+
+	return requests, nil
+}
+
+func (s gormRequestStore) Delete(_ context.Context, clusterID uint, name string) error {
+	err := s.db.Where(requestModel{ClusterID: clusterID, Name: name}).Delete(requestModel{}).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(
+			err, "failed to delete whitelist request",
+			"clusterId", clusterID,
+			"name", name,
+		)
+	}
+
+	return nil
+}
+
+func toRequest(model requestModel) whitelist.Request {
+	return whitelist.Request{
+		ID:        model.ID,
+		ClusterID: model.ClusterID,
+		Item: security.ReleaseWhiteListItem{
+			Name:      model.Name,
+			Owner:     model.Owner,
+			Reason:    model.Reason,
+			Regexp:    model.Regexp,
+			ExpiresAt: model.ExpiresAt,
+		},
+		RequestedBy: model.RequestedBy,
+		CreatedAt:   model.CreatedAt,
+	}
+}
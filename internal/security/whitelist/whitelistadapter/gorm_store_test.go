@@ -0,0 +1,89 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whitelistadapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/security/whitelist"
+	"github.com/banzaicloud/pipeline/pkg/security"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, common.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormRequestStore(t *testing.T) {
+	db := setUpDatabase(t)
+	store := NewGormRequestStore(db)
+	ctx := context.Background()
+
+	expiresAt := time.Date(2020, time.March, 21, 12, 0, 0, 0, time.UTC)
+
+	request := whitelist.Request{
+		ClusterID: 2,
+		Item: security.ReleaseWhiteListItem{
+			Name:      "release",
+			Owner:     "owner",
+			Reason:    "reason",
+			Regexp:    "release-.*",
+			ExpiresAt: &expiresAt,
+		},
+		RequestedBy: 1,
+	}
+
+	id, err := store.Create(ctx, request)
+	require.NoError(t, err)
+
+	_, err = store.Create(ctx, request)
+	require.Error(t, err, "names should be unique in a cluster")
+
+	stored, err := store.Get(ctx, 2, "release")
+	require.NoError(t, err)
+
+	assert.Equal(t, id, stored.ID)
+	assert.Equal(t, request.Item.Name, stored.Item.Name)
+	assert.Equal(t, request.Item.Regexp, stored.Item.Regexp)
+	assert.True(t, expiresAt.Equal(*stored.Item.ExpiresAt))
+	assert.Equal(t, uint(1), stored.RequestedBy)
+
+	requests, err := store.List(ctx, 2)
+	require.NoError(t, err)
+	assert.Len(t, requests, 1)
+
+	requests, err = store.List(ctx, 3)
+	require.NoError(t, err)
+	assert.Empty(t, requests)
+
+	require.NoError(t, store.Delete(ctx, 2, "release"))
+
+	_, err = store.Get(ctx, 2, "release")
+	assert.True(t, errors.As(err, &whitelist.NotFoundError{}))
+}
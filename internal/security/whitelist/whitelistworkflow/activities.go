@@ -0,0 +1,97 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whitelistworkflow
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/integratedservices/integratedserviceadapter"
+	"github.com/banzaicloud/pipeline/internal/security/whitelist"
+)
+
+const ListClustersActivityName = "security-whitelist-list-clusters-activity"
+
+type ListClustersActivityInput struct{}
+
+type ListClustersActivityOutput struct {
+	ClusterIDs []uint
+}
+
+// ListClustersActivity lists clusters whitelist items should be checked in.
+type ListClustersActivity struct {
+	clusters whitelist.ClusterLister
+}
+
+// NewListClustersActivity returns a new ListClustersActivity.
+func NewListClustersActivity(clusters whitelist.ClusterLister) ListClustersActivity {
+	return ListClustersActivity{
+		clusters: clusters,
+	}
+}
+
+func (a ListClustersActivity) Execute(ctx context.Context, _ ListClustersActivityInput) (ListClustersActivityOutput, error) {
+	clusterIDs, err := a.clusters.ListClusters(ctx)
+	if err != nil {
+		return ListClustersActivityOutput{}, err
+	}
+
+	return ListClustersActivityOutput{ClusterIDs: clusterIDs}, nil
+}
+
+const ExpireActivityName = "security-whitelist-expire-activity"
+
+type ExpireActivityInput struct {
+	ClusterID uint
+}
+
+type ExpireActivityOutput struct {
+	// Names of the removed whitelist items
+	Removed []string
+}
+
+// ExpireActivity removes the expired whitelist items of a cluster.
+type ExpireActivity struct {
+	clusters integratedserviceadapter.ClusterGetter
+	expirer  whitelist.Expirer
+}
+
+// NewExpireActivity returns a new ExpireActivity.
+func NewExpireActivity(clusters integratedserviceadapter.ClusterGetter, expirer whitelist.Expirer) ExpireActivity {
+	return ExpireActivity{
+		clusters: clusters,
+		expirer:  expirer,
+	}
+}
+
+func (a ExpireActivity) Execute(ctx context.Context, input ExpireActivityInput) (ExpireActivityOutput, error) {
+	cluster, err := a.clusters.GetClusterByIDOnly(ctx, input.ClusterID)
+	if err != nil {
+		return ExpireActivityOutput{}, errors.WrapIfWithDetails(err, "failed to get cluster", "clusterId", input.ClusterID)
+	}
+
+	removed, err := a.expirer.RemoveExpired(ctx, cluster)
+
+	output := ExpireActivityOutput{
+		Removed: make([]string, 0, len(removed)),
+	}
+
+	for _, item := range removed {
+		output.Removed = append(output.Removed, item.Name)
+	}
+
+	return output, err
+}
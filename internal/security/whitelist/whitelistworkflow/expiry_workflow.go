@@ -0,0 +1,68 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whitelistworkflow
+
+import (
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/cadence/workflow"
+)
+
+const ExpiryWorkflowName = "security-whitelist-expiry"
+
+// ExpiryWorkflow removes the expired whitelist items of every cluster with security scan enabled.
+// It is supposed to be scheduled as a cron workflow.
+func ExpiryWorkflow(ctx workflow.Context) error {
+	logger := workflow.GetLogger(ctx).Sugar()
+
+	ctx = workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		ScheduleToStartTimeout: 10 * time.Minute,
+		StartToCloseTimeout:    5 * time.Minute,
+		WaitForCancellation:    true,
+	})
+
+	var clusters ListClustersActivityOutput
+
+	if err := workflow.ExecuteActivity(ctx, ListClustersActivityName, ListClustersActivityInput{}).Get(ctx, &clusters); err != nil {
+		return errors.WrapIfWithDetails(err, "failed to execute activity", "activity", ListClustersActivityName)
+	}
+
+	var failed int
+
+	// a failing cluster (eg. unreachable API server) should not keep expired items in the others
+	for _, clusterID := range clusters.ClusterIDs {
+		var output ExpireActivityOutput
+
+		err := workflow.ExecuteActivity(ctx, ExpireActivityName, ExpireActivityInput{ClusterID: clusterID}).Get(ctx, &output)
+		if err != nil {
+			failed++
+
+			logger.Warnw("failed to remove expired whitelist items", "clusterId", clusterID, "error", err.Error())
+
+			continue
+		}
+
+		if len(output.Removed) > 0 {
+			logger.Infow("expired whitelist items removed", "clusterId", clusterID, "items", output.Removed)
+		}
+	}
+
+	if failed > 0 {
+		return errors.NewWithDetails("failed to remove expired whitelist items of some clusters", "failed", failed, "clusters", len(clusters.ClusterIDs))
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package whitelistworkflow
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+)
+
+func testListClustersActivityExecute(_ context.Context, _ ListClustersActivityInput) (ListClustersActivityOutput, error) {
+	return ListClustersActivityOutput{}, nil
+}
+
+func testExpireActivityExecute(_ context.Context, _ ExpireActivityInput) (ExpireActivityOutput, error) {
+	return ExpireActivityOutput{}, nil
+}
+
+// nolint: gochecknoinits
+func init() {
+	workflow.RegisterWithOptions(ExpiryWorkflow, workflow.RegisterOptions{Name: ExpiryWorkflowName})
+
+	activity.RegisterWithOptions(testListClustersActivityExecute, activity.RegisterOptions{Name: ListClustersActivityName})
+	activity.RegisterWithOptions(testExpireActivityExecute, activity.RegisterOptions{Name: ExpireActivityName})
+}
+
+func TestExpiryWorkflow(t *testing.T) {
+	var testSuite testsuite.WorkflowTestSuite
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	env.OnActivity(ListClustersActivityName, mock.Anything, ListClustersActivityInput{}).
+		Return(ListClustersActivityOutput{ClusterIDs: []uint{2, 3, 4}}, nil)
+	env.OnActivity(ExpireActivityName, mock.Anything, ExpireActivityInput{ClusterID: 2}).
+		Return(ExpireActivityOutput{Removed: []string{"release"}}, nil)
+	env.OnActivity(ExpireActivityName, mock.Anything, ExpireActivityInput{ClusterID: 3}).
+		Return(ExpireActivityOutput{}, errors.New("cluster is unreachable"))
+	env.OnActivity(ExpireActivityName, mock.Anything, ExpireActivityInput{ClusterID: 4}).
+		Return(ExpireActivityOutput{}, nil)
+
+	env.ExecuteWorkflow(ExpiryWorkflowName)
+
+	require.True(t, env.IsWorkflowCompleted())
+
+	// the failed cluster is reported, but the rest of the clusters are still processed
+	assert.Error(t, env.GetWorkflowError())
+
+	env.AssertExpectations(t)
+}
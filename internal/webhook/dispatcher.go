@@ -0,0 +1,231 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/backoff"
+)
+
+// Headers sent along with every webhook request.
+const (
+	// SignatureHeader contains the HMAC-SHA256 signature of the payload (eg. sha256=<hex digest>).
+	SignatureHeader = "X-Pipeline-Signature"
+
+	// EventHeader contains the type of the event.
+	EventHeader = "X-Pipeline-Event"
+
+	// DeliveryHeader contains the ID of the delivery.
+	DeliveryHeader = "X-Pipeline-Delivery"
+)
+
+// Request is a signed webhook request.
+type Request struct {
+	URL        string
+	EventType  string
+	DeliveryID uint
+	Payload    []byte
+	Signature  string
+}
+
+// +testify:mock:testOnly=true
+
+// Sender sends webhook requests to subscribers.
+type Sender interface {
+	// Send sends a single request and returns the status code of the response.
+	Send(ctx context.Context, request Request) (statusCode int, err error)
+}
+
+// DispatcherConfig controls how deliveries are retried.
+type DispatcherConfig struct {
+	// MaxRetries is the number of retries after a failed attempt.
+	MaxRetries int
+
+	// RetryDelay is the time waited between two attempts.
+	RetryDelay time.Duration
+}
+
+// Dispatcher records and sends events to the matching subscriptions.
+type Dispatcher struct {
+	store        Store
+	sender       Sender
+	config       DispatcherConfig
+	logger       Logger
+	errorHandler ErrorHandler
+
+	wg sync.WaitGroup
+}
+
+// NewDispatcher returns a new Dispatcher.
+func NewDispatcher(store Store, sender Sender, config DispatcherConfig, logger Logger, errorHandler ErrorHandler) *Dispatcher {
+	return &Dispatcher{
+		store:        store,
+		sender:       sender,
+		config:       config,
+		logger:       logger,
+		errorHandler: errorHandler,
+	}
+}
+
+// Dispatch records a delivery for every subscription of the event's organization matching the event type
+// and sends them in the background.
+func (d *Dispatcher) Dispatch(ctx context.Context, event Event) error {
+	subscriptions, err := d.store.ListSubscriptions(ctx, event.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(event.Type) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(event)
+			if err != nil {
+				return errors.WrapIfWithDetails(err, "failed to marshal webhook event", "eventType", event.Type)
+			}
+		}
+
+		delivery, cerr := d.store.CreateDelivery(ctx, Delivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+		})
+		if cerr != nil {
+			err = errors.Append(err, cerr)
+
+			continue
+		}
+
+		d.Deliver(subscription, delivery)
+	}
+
+	return err
+}
+
+// Deliver sends a recorded delivery to a subscriber in the background.
+func (d *Dispatcher) Deliver(subscription Subscription, delivery Delivery) {
+	d.wg.Add(1)
+
+	go func() {
+		defer d.wg.Done()
+
+		d.deliver(context.Background(), subscription, delivery)
+	}()
+}
+
+// Wait blocks until every delivery in progress finishes.
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, subscription Subscription, delivery Delivery) {
+	logger := d.logger.WithFields(map[string]interface{}{
+		"organizationId": subscription.OrganizationID,
+		"subscriptionId": subscription.ID,
+		"deliveryId":     delivery.ID,
+		"eventType":      delivery.EventType,
+	})
+
+	request := Request{
+		URL:        subscription.URL,
+		EventType:  delivery.EventType,
+		DeliveryID: delivery.ID,
+		Payload:    delivery.Payload,
+		Signature:  Sign(subscription.Secret, delivery.Payload),
+	}
+
+	policy := backoff.NewConstantBackoffPolicy(backoff.ConstantBackoffConfig{
+		Delay:      d.config.RetryDelay,
+		MaxRetries: d.config.MaxRetries,
+	})
+
+	err := backoff.Retry(func() error {
+		delivery.Attempts++
+
+		statusCode, err := d.sender.Send(ctx, request)
+		delivery.StatusCode = statusCode
+		if err != nil {
+			return err
+		}
+
+		if statusCode >= 200 && statusCode < 300 {
+			return nil
+		}
+
+		err = errors.NewWithDetails("unexpected response status", "statusCode", statusCode)
+		if !isRetryableStatus(statusCode) {
+			return backoff.MarkErrorPermanent(err)
+		}
+
+		return err
+	}, policy)
+
+	completedAt := time.Now().UTC()
+	delivery.CompletedAt = &completedAt
+	delivery.Succeeded = err == nil
+	delivery.Error = ""
+
+	if err != nil {
+		delivery.Error = err.Error()
+
+		logger.Warn("webhook delivery failed", map[string]interface{}{"attempts": delivery.Attempts, "error": err.Error()})
+	} else {
+		logger.Debug("webhook delivered", map[string]interface{}{"attempts": delivery.Attempts})
+	}
+
+	if err := d.store.UpdateDelivery(ctx, delivery); err != nil {
+		d.errorHandler.HandleContext(ctx, errors.WithDetails(err, "deliveryId", delivery.ID))
+	}
+}
+
+// isRetryableStatus tells whether a request failing with the status code is worth retrying.
+// Client errors (apart from timeouts and rate limiting) are considered permanent.
+func isRetryableStatus(statusCode int) bool {
+	switch {
+	case statusCode == http.StatusRequestTimeout, statusCode == http.StatusTooManyRequests:
+		return true
+
+	case statusCode >= 400 && statusCode < 500:
+		return false
+
+	default:
+		return true
+	}
+}
+
+// Sign returns the signature of a payload in the format sent in the SignatureHeader.
+// Subscribers should calculate the HMAC-SHA256 digest of the raw request body using the subscription secret
+// and compare it to the header value.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
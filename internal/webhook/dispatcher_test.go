@@ -0,0 +1,170 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+)
+
+func TestSign(t *testing.T) {
+	// echo -n '{"id":"event"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(
+		t,
+		"sha256=49a2cc434c7ea053dde7a6b35f9862c78e054a09ce1e55a6a4f5772106b8d616",
+		Sign("secret", []byte(`{"id":"event"}`)),
+	)
+}
+
+func TestDispatcher_Dispatch(t *testing.T) {
+	ctx := context.Background()
+	event := NewEvent(1, ClusterCreatedEventType, ClusterEventData{ClusterID: 2, ClusterName: "cluster"})
+
+	payload, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	subscriptions := []Subscription{
+		{ID: 1, OrganizationID: 1, URL: "https://example.com/1", EventTypes: []string{ClusterCreatedEventType}, Secret: "secret1"},
+		{ID: 2, OrganizationID: 1, URL: "https://example.com/2", EventTypes: []string{ClusterDeletedEventType}, Secret: "secret2"},
+		{ID: 3, OrganizationID: 1, URL: "https://example.com/3", EventTypes: []string{AllEventTypes}, Secret: "secret3"},
+	}
+
+	store := new(MockStore)
+	store.On("ListSubscriptions", ctx, uint(1)).Return(subscriptions, nil)
+
+	for _, subscriptionID := range []uint{1, 3} {
+		store.On("CreateDelivery", ctx, Delivery{
+			SubscriptionID: subscriptionID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+		}).Return(Delivery{ID: subscriptionID * 10, SubscriptionID: subscriptionID, EventType: event.Type, Payload: payload}, nil)
+	}
+
+	store.On("UpdateDelivery", mock.Anything, mock.MatchedBy(func(delivery Delivery) bool {
+		return delivery.Succeeded && delivery.Attempts == 1 && delivery.StatusCode == http.StatusOK && delivery.CompletedAt != nil
+	})).Return(nil).Twice()
+
+	sender := new(MockSender)
+	sender.On("Send", mock.Anything, Request{
+		URL:        "https://example.com/1",
+		EventType:  ClusterCreatedEventType,
+		DeliveryID: 10,
+		Payload:    payload,
+		Signature:  Sign("secret1", payload),
+	}).Return(http.StatusOK, nil)
+	sender.On("Send", mock.Anything, Request{
+		URL:        "https://example.com/3",
+		EventType:  ClusterCreatedEventType,
+		DeliveryID: 30,
+		Payload:    payload,
+		Signature:  Sign("secret3", payload),
+	}).Return(http.StatusOK, nil)
+
+	dispatcher := NewDispatcher(store, sender, DispatcherConfig{MaxRetries: 3}, common.NoopLogger{}, common.NoopErrorHandler{})
+
+	err = dispatcher.Dispatch(ctx, event)
+	require.NoError(t, err)
+
+	dispatcher.Wait()
+
+	store.AssertExpectations(t)
+	sender.AssertExpectations(t)
+}
+
+func TestDispatcher_Deliver_Retry(t *testing.T) {
+	tests := map[string]struct {
+		responses        []int
+		sendErr          error
+		expectedAttempts int
+		succeeded        bool
+	}{
+		"recovers": {
+			responses:        []int{http.StatusBadGateway, http.StatusTooManyRequests, http.StatusNoContent},
+			expectedAttempts: 3,
+			succeeded:        true,
+		},
+		"exhausted": {
+			responses:        []int{http.StatusInternalServerError},
+			expectedAttempts: 3,
+		},
+		"permanent": {
+			responses:        []int{http.StatusNotFound},
+			expectedAttempts: 1,
+		},
+		"networkError": {
+			sendErr:          errors.New("connection refused"),
+			expectedAttempts: 3,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			subscription := Subscription{ID: 1, URL: "https://example.com", Secret: "secret"}
+			delivery := Delivery{ID: 2, SubscriptionID: 1, EventType: ClusterDeletedEventType, Payload: json.RawMessage(`{}`)}
+
+			var attempts int
+
+			sender := new(MockSender)
+			sender.On("Send", mock.Anything, mock.Anything).Return(
+				func(context.Context, Request) int {
+					if len(test.responses) == 0 {
+						return 0
+					}
+
+					if attempts < len(test.responses) {
+						return test.responses[attempts]
+					}
+
+					return test.responses[len(test.responses)-1]
+				},
+				func(context.Context, Request) error {
+					attempts++
+
+					return test.sendErr
+				},
+			)
+
+			var result Delivery
+
+			store := new(MockStore)
+			store.On("UpdateDelivery", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				result = args.Get(1).(Delivery)
+			}).Return(nil)
+
+			dispatcher := NewDispatcher(store, sender, DispatcherConfig{MaxRetries: 2}, common.NoopLogger{}, common.NoopErrorHandler{})
+
+			dispatcher.Deliver(subscription, delivery)
+			dispatcher.Wait()
+
+			assert.Equal(t, test.expectedAttempts, result.Attempts)
+			assert.Equal(t, test.succeeded, result.Succeeded)
+			assert.Equal(t, !test.succeeded, result.Error != "")
+
+			store.AssertExpectations(t)
+		})
+	}
+}
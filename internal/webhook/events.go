@@ -0,0 +1,114 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Event types subscriptions can filter for.
+const (
+	// AllEventTypes matches every event type.
+	AllEventTypes = "*"
+
+	ClusterCreatedEventType                 = "cluster.created"
+	ClusterUpdatedEventType                 = "cluster.updated"
+	ClusterDeletedEventType                 = "cluster.deleted"
+	OrganizationCreatedEventType            = "organization.created"
+	IntegratedServiceStatusChangedEventType = "integratedservice.status_changed"
+	DeploymentFinishedEventType             = "deployment.finished"
+	WhitelistItemExpiredEventType           = "security.whitelist_item_expired"
+)
+
+// nolint: gochecknoglobals
+var eventTypes = map[string]bool{
+	AllEventTypes:                           true,
+	ClusterCreatedEventType:                 true,
+	ClusterUpdatedEventType:                 true,
+	ClusterDeletedEventType:                 true,
+	OrganizationCreatedEventType:            true,
+	IntegratedServiceStatusChangedEventType: true,
+	DeploymentFinishedEventType:             true,
+	WhitelistItemExpiredEventType:           true,
+}
+
+// Event is a platform event sent to webhook subscribers.
+type Event struct {
+	ID             string      `json:"id"`
+	Type           string      `json:"type"`
+	OrganizationID uint        `json:"organizationId"`
+	Time           time.Time   `json:"time"`
+	Data           interface{} `json:"data"`
+}
+
+// NewEvent returns a new Event with a unique ID.
+func NewEvent(organizationID uint, eventType string, data interface{}) Event {
+	return Event{
+		ID:             uuid.Must(uuid.NewV4()).String(),
+		Type:           eventType,
+		OrganizationID: organizationID,
+		Time:           time.Now().UTC(),
+		Data:           data,
+	}
+}
+
+// ClusterEventData is the payload of cluster events.
+type ClusterEventData struct {
+	ClusterID   uint   `json:"clusterId,omitempty"`
+	ClusterName string `json:"clusterName"`
+}
+
+// OrganizationEventData is the payload of organization events.
+type OrganizationEventData struct {
+	// UserID is the ID of the user whose login triggered the organization being created.
+	UserID uint `json:"userId"`
+}
+
+// IntegratedServiceEventData is the payload of integrated service events.
+type IntegratedServiceEventData struct {
+	ClusterID         uint   `json:"clusterId"`
+	ClusterName       string `json:"clusterName"`
+	IntegratedService string `json:"integratedService"`
+	Status            string `json:"status"`
+}
+
+// DeploymentEventData is the payload of cluster group deployment events.
+type DeploymentEventData struct {
+	ClusterGroupID uint               `json:"clusterGroupId"`
+	ReleaseName    string             `json:"releaseName"`
+	Status         string             `json:"status"`
+	Message        string             `json:"message,omitempty"`
+	Targets        []DeploymentTarget `json:"targets,omitempty"`
+}
+
+// DeploymentTarget is the result of a deployment on a single member cluster.
+type DeploymentTarget struct {
+	ClusterID   uint   `json:"clusterId"`
+	ClusterName string `json:"clusterName"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+}
+
+// WhitelistItemEventData is the payload of security scan whitelist events.
+type WhitelistItemEventData struct {
+	ClusterID   uint      `json:"clusterId"`
+	ClusterName string    `json:"clusterName"`
+	Name        string    `json:"name"`
+	Owner       string    `json:"owner"`
+	Reason      string    `json:"reason,omitempty"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+}
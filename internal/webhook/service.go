@@ -0,0 +1,312 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+const (
+	// DeliveryListLimit is the number of most recent deliveries returned for a subscription.
+	DeliveryListLimit = 100
+
+	// MinSecretLength is the minimum length of a user supplied signing secret.
+	MinSecretLength = 16
+
+	generatedSecretLength = 32
+)
+
+// Subscription receives events of an organization at a URL.
+type Subscription struct {
+	ID             uint      `json:"id"`
+	OrganizationID uint      `json:"organizationId"`
+	Name           string    `json:"name"`
+	URL            string    `json:"url"`
+	EventTypes     []string  `json:"eventTypes"`
+	CreatedAt      time.Time `json:"createdAt"`
+
+	// Secret is used to sign the payloads. It is only returned when the subscription is created.
+	Secret string `json:"secret,omitempty"`
+}
+
+// Matches tells whether the subscription receives an event type.
+func (s Subscription) Matches(eventType string) bool {
+	for _, t := range s.EventTypes {
+		if t == AllEventTypes || t == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewSubscription contains the details of a new subscription.
+type NewSubscription struct {
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"eventTypes"`
+
+	// Secret is used to sign the payloads. A random secret is generated when it's empty.
+	Secret string `json:"secret,omitempty"`
+}
+
+// Delivery is a recorded attempt to send an event to a subscriber.
+type Delivery struct {
+	ID             uint            `json:"id"`
+	SubscriptionID uint            `json:"subscriptionId"`
+	EventID        string          `json:"eventId"`
+	EventType      string          `json:"eventType"`
+	Payload        json.RawMessage `json:"payload"`
+	Attempts       int             `json:"attempts"`
+	StatusCode     int             `json:"statusCode,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	Succeeded      bool            `json:"succeeded"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	CompletedAt    *time.Time      `json:"completedAt,omitempty"`
+}
+
+// +kit:endpoint:errorStrategy=service
+// +testify:mock:testOnly=true
+
+// Service manages webhook subscriptions.
+type Service interface {
+	// CreateSubscription creates a new subscription.
+	CreateSubscription(ctx context.Context, organizationID uint, newSubscription NewSubscription) (subscription Subscription, err error)
+
+	// ListSubscriptions lists the subscriptions of an organization.
+	ListSubscriptions(ctx context.Context, organizationID uint) (subscriptions []Subscription, err error)
+
+	// GetSubscription returns a subscription.
+	GetSubscription(ctx context.Context, organizationID uint, subscriptionID uint) (subscription Subscription, err error)
+
+	// DeleteSubscription deletes a subscription along with its delivery log.
+	DeleteSubscription(ctx context.Context, organizationID uint, subscriptionID uint) error
+
+	// ListDeliveries lists the most recent deliveries of a subscription, newest first.
+	ListDeliveries(ctx context.Context, organizationID uint, subscriptionID uint) (deliveries []Delivery, err error)
+
+	// RedeliverDelivery sends the event of a previous delivery again.
+	RedeliverDelivery(ctx context.Context, organizationID uint, subscriptionID uint, deliveryID uint) (delivery Delivery, err error)
+}
+
+// +testify:mock:testOnly=true
+
+// Store persists subscriptions and deliveries.
+type Store interface {
+	// CreateSubscription persists a new subscription.
+	CreateSubscription(ctx context.Context, subscription Subscription) (Subscription, error)
+
+	// ListSubscriptions lists the subscriptions of an organization (including their secrets).
+	ListSubscriptions(ctx context.Context, organizationID uint) ([]Subscription, error)
+
+	// GetSubscription returns a subscription (including its secret).
+	// Returns a NotFoundError when the subscription cannot be found.
+	GetSubscription(ctx context.Context, organizationID uint, subscriptionID uint) (Subscription, error)
+
+	// DeleteSubscription deletes a subscription and its deliveries.
+	// Returns a NotFoundError when the subscription cannot be found.
+	DeleteSubscription(ctx context.Context, organizationID uint, subscriptionID uint) error
+
+	// CreateDelivery persists a new delivery.
+	CreateDelivery(ctx context.Context, delivery Delivery) (Delivery, error)
+
+	// UpdateDelivery updates the outcome of a delivery.
+	UpdateDelivery(ctx context.Context, delivery Delivery) error
+
+	// ListDeliveries lists the most recent deliveries of a subscription, newest first.
+	ListDeliveries(ctx context.Context, subscriptionID uint, limit int) ([]Delivery, error)
+
+	// GetDelivery returns a delivery of a subscription.
+	// Returns a NotFoundError when the delivery cannot be found.
+	GetDelivery(ctx context.Context, subscriptionID uint, deliveryID uint) (Delivery, error)
+}
+
+// +testify:mock:testOnly=true
+
+// Deliverer sends recorded deliveries to subscribers in the background.
+type Deliverer interface {
+	// Deliver sends a delivery to a subscriber.
+	Deliver(subscription Subscription, delivery Delivery)
+}
+
+// +testify:mock:testOnly=true
+
+// HostValidator checks that a subscription URL does not point to an internal address.
+type HostValidator interface {
+	// ValidateHost returns an error if the host cannot be resolved or resolves to an internal address.
+	ValidateHost(ctx context.Context, host string) error
+}
+
+// NewService returns a new Service.
+func NewService(store Store, deliverer Deliverer, hostValidator HostValidator) Service {
+	return service{
+		store:         store,
+		deliverer:     deliverer,
+		hostValidator: hostValidator,
+	}
+}
+
+type service struct {
+	store         Store
+	deliverer     Deliverer
+	hostValidator HostValidator
+}
+
+func (s service) CreateSubscription(ctx context.Context, organizationID uint, newSubscription NewSubscription) (Subscription, error) {
+	if err := validateNewSubscription(newSubscription); err != nil {
+		return Subscription{}, err
+	}
+
+	u, _ := url.Parse(newSubscription.URL)
+	if err := s.hostValidator.ValidateHost(ctx, u.Hostname()); err != nil {
+		return Subscription{}, NewValidationError("invalid subscription", []string{
+			fmt.Sprintf("url must point to a public address: %s", err.Error()),
+		})
+	}
+
+	secret := newSubscription.Secret
+	if secret == "" {
+		var err error
+
+		secret, err = generateSecret()
+		if err != nil {
+			return Subscription{}, err
+		}
+	}
+
+	return s.store.CreateSubscription(ctx, Subscription{
+		OrganizationID: organizationID,
+		Name:           newSubscription.Name,
+		URL:            newSubscription.URL,
+		EventTypes:     newSubscription.EventTypes,
+		Secret:         secret,
+	})
+}
+
+func (s service) ListSubscriptions(ctx context.Context, organizationID uint) ([]Subscription, error) {
+	subscriptions, err := s.store.ListSubscriptions(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range subscriptions {
+		subscriptions[i].Secret = ""
+	}
+
+	return subscriptions, nil
+}
+
+func (s service) GetSubscription(ctx context.Context, organizationID uint, subscriptionID uint) (Subscription, error) {
+	subscription, err := s.store.GetSubscription(ctx, organizationID, subscriptionID)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	subscription.Secret = ""
+
+	return subscription, nil
+}
+
+func (s service) DeleteSubscription(ctx context.Context, organizationID uint, subscriptionID uint) error {
+	return s.store.DeleteSubscription(ctx, organizationID, subscriptionID)
+}
+
+func (s service) ListDeliveries(ctx context.Context, organizationID uint, subscriptionID uint) ([]Delivery, error) {
+	// make sure the subscription belongs to the organization
+	_, err := s.store.GetSubscription(ctx, organizationID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.ListDeliveries(ctx, subscriptionID, DeliveryListLimit)
+}
+
+func (s service) RedeliverDelivery(ctx context.Context, organizationID uint, subscriptionID uint, deliveryID uint) (Delivery, error) {
+	subscription, err := s.store.GetSubscription(ctx, organizationID, subscriptionID)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	previous, err := s.store.GetDelivery(ctx, subscriptionID, deliveryID)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	delivery, err := s.store.CreateDelivery(ctx, Delivery{
+		SubscriptionID: subscriptionID,
+		EventID:        previous.EventID,
+		EventType:      previous.EventType,
+		Payload:        previous.Payload,
+	})
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	s.deliverer.Deliver(subscription, delivery)
+
+	return delivery, nil
+}
+
+func validateNewSubscription(newSubscription NewSubscription) error {
+	var violations []string
+
+	if strings.TrimSpace(newSubscription.Name) == "" {
+		violations = append(violations, "name is required")
+	}
+
+	if u, err := url.Parse(newSubscription.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		violations = append(violations, "url must be an absolute HTTP(S) URL")
+	}
+
+	if len(newSubscription.EventTypes) == 0 {
+		violations = append(violations, "at least one event type is required")
+	}
+
+	for _, eventType := range newSubscription.EventTypes {
+		if !eventTypes[eventType] {
+			violations = append(violations, fmt.Sprintf("unknown event type: %s", eventType))
+		}
+	}
+
+	if newSubscription.Secret != "" && len(newSubscription.Secret) < MinSecretLength {
+		violations = append(violations, fmt.Sprintf("secret must be at least %d characters long", MinSecretLength))
+	}
+
+	if len(violations) > 0 {
+		return NewValidationError("invalid subscription", violations)
+	}
+
+	return nil
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, generatedSecretLength)
+
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", errors.WrapIf(err, "failed to generate webhook secret")
+	}
+
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,227 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscription_Matches(t *testing.T) {
+	subscription := Subscription{EventTypes: []string{ClusterCreatedEventType, ClusterDeletedEventType}}
+
+	assert.True(t, subscription.Matches(ClusterCreatedEventType))
+	assert.False(t, subscription.Matches(ClusterUpdatedEventType))
+
+	subscription = Subscription{EventTypes: []string{AllEventTypes}}
+
+	assert.True(t, subscription.Matches(DeploymentFinishedEventType))
+}
+
+func TestService_CreateSubscription(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On(
+		"CreateSubscription",
+		ctx,
+		mock.MatchedBy(func(subscription Subscription) bool {
+			return subscription.OrganizationID == 1 &&
+				subscription.Name == "chatops" &&
+				len(subscription.Secret) == 2*generatedSecretLength
+		}),
+	).Return(func(_ context.Context, subscription Subscription) Subscription {
+		subscription.ID = 1
+
+		return subscription
+	}, nil)
+
+	hostValidator := new(MockHostValidator)
+	hostValidator.On("ValidateHost", ctx, "chatops.example.com").Return(nil)
+
+	service := NewService(store, new(MockDeliverer), hostValidator)
+
+	subscription, err := service.CreateSubscription(ctx, 1, NewSubscription{
+		Name:       "chatops",
+		URL:        "https://chatops.example.com/hooks/pipeline",
+		EventTypes: []string{ClusterCreatedEventType},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(1), subscription.ID)
+	assert.NotEmpty(t, subscription.Secret, "the secret should be returned on creation")
+
+	store.AssertExpectations(t)
+	hostValidator.AssertExpectations(t)
+}
+
+func TestService_CreateSubscription_InternalHost(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+
+	hostValidator := new(MockHostValidator)
+	hostValidator.On("ValidateHost", ctx, "169.254.169.254").Return(errors.New("host is an internal address"))
+
+	service := NewService(store, new(MockDeliverer), hostValidator)
+
+	_, err := service.CreateSubscription(ctx, 1, NewSubscription{
+		Name:       "metadata",
+		URL:        "http://169.254.169.254/latest/meta-data",
+		EventTypes: []string{AllEventTypes},
+	})
+	require.Error(t, err)
+
+	var verr ValidationError
+	assert.True(t, errors.As(err, &verr))
+
+	store.AssertExpectations(t)
+}
+
+func TestService_CreateSubscription_Invalid(t *testing.T) {
+	tests := map[string]NewSubscription{
+		"name": {
+			URL:        "https://example.com",
+			EventTypes: []string{AllEventTypes},
+		},
+		"url": {
+			Name:       "hook",
+			URL:        "example.com/hook",
+			EventTypes: []string{AllEventTypes},
+		},
+		"scheme": {
+			Name:       "hook",
+			URL:        "ftp://example.com/hook",
+			EventTypes: []string{AllEventTypes},
+		},
+		"noEventTypes": {
+			Name: "hook",
+			URL:  "https://example.com",
+		},
+		"unknownEventType": {
+			Name:       "hook",
+			URL:        "https://example.com",
+			EventTypes: []string{"cluster.exploded"},
+		},
+		"shortSecret": {
+			Name:       "hook",
+			URL:        "https://example.com",
+			EventTypes: []string{AllEventTypes},
+			Secret:     "secret",
+		},
+	}
+
+	for name, newSubscription := range tests {
+		name, newSubscription := name, newSubscription
+
+		t.Run(name, func(t *testing.T) {
+			store := new(MockStore)
+
+			service := NewService(store, new(MockDeliverer), new(MockHostValidator))
+
+			_, err := service.CreateSubscription(context.Background(), 1, newSubscription)
+			require.Error(t, err)
+
+			var verr ValidationError
+			assert.True(t, errors.As(err, &verr))
+
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_ListSubscriptions(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("ListSubscriptions", ctx, uint(1)).Return([]Subscription{{ID: 1, Secret: "secret"}}, nil)
+
+	service := NewService(store, new(MockDeliverer), new(MockHostValidator))
+
+	subscriptions, err := service.ListSubscriptions(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Subscription{{ID: 1}}, subscriptions)
+
+	store.AssertExpectations(t)
+}
+
+func TestService_ListDeliveries_NotFound(t *testing.T) {
+	ctx := context.Background()
+
+	store := new(MockStore)
+	store.On("GetSubscription", ctx, uint(1), uint(2)).Return(Subscription{}, NotFoundError{OrganizationID: 1, SubscriptionID: 2})
+
+	service := NewService(store, new(MockDeliverer), new(MockHostValidator))
+
+	_, err := service.ListDeliveries(ctx, 1, 2)
+	require.Error(t, err)
+
+	var notFoundErr NotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+
+	store.AssertExpectations(t)
+}
+
+func TestService_RedeliverDelivery(t *testing.T) {
+	ctx := context.Background()
+
+	subscription := Subscription{ID: 2, OrganizationID: 1, URL: "https://example.com", Secret: "secret"}
+	payload := json.RawMessage(`{"id":"event"}`)
+
+	store := new(MockStore)
+	store.On("GetSubscription", ctx, uint(1), uint(2)).Return(subscription, nil)
+	store.On("GetDelivery", ctx, uint(2), uint(3)).Return(Delivery{
+		ID:             3,
+		SubscriptionID: 2,
+		EventID:        "event",
+		EventType:      ClusterCreatedEventType,
+		Payload:        payload,
+		Attempts:       6,
+		StatusCode:     500,
+		Error:          "all attempts failed",
+	}, nil)
+
+	newDelivery := Delivery{
+		SubscriptionID: 2,
+		EventID:        "event",
+		EventType:      ClusterCreatedEventType,
+		Payload:        payload,
+	}
+
+	createdDelivery := newDelivery
+	createdDelivery.ID = 4
+
+	store.On("CreateDelivery", ctx, newDelivery).Return(createdDelivery, nil)
+
+	deliverer := new(MockDeliverer)
+	deliverer.On("Deliver", subscription, createdDelivery).Return()
+
+	service := NewService(store, deliverer, new(MockHostValidator))
+
+	delivery, err := service.RedeliverDelivery(ctx, 1, 2, 3)
+	require.NoError(t, err)
+
+	assert.Equal(t, createdDelivery, delivery)
+
+	store.AssertExpectations(t)
+	deliverer.AssertExpectations(t)
+}
@@ -0,0 +1,159 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/webhook"
+)
+
+// forbiddenNetworks are address ranges webhooks must not be sent to:
+// they would let organization members reach Pipeline's own network (including cloud metadata services).
+// nolint: gochecknoglobals
+var forbiddenNetworks = mustParseCIDRs(
+	"0.0.0.0/8",      // "this" network
+	"10.0.0.0/8",     // private
+	"100.64.0.0/10",  // carrier-grade NAT
+	"127.0.0.0/8",    // loopback
+	"169.254.0.0/16", // link-local (cloud metadata services)
+	"172.16.0.0/12",  // private
+	"192.0.0.0/24",   // IETF protocol assignments
+	"192.168.0.0/16", // private
+	"198.18.0.0/15",  // benchmarking
+	"224.0.0.0/4",    // multicast
+	"240.0.0.0/4",    // reserved
+	"::/128",         // unspecified
+	"::1/128",        // loopback
+	"fc00::/7",       // unique local (includes the AWS metadata service fd00:ec2::254)
+	"fe80::/10",      // link-local
+	"ff00::/8",       // multicast
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+// isForbiddenIP checks whether an IP address belongs to a network webhooks must not be sent to.
+func isForbiddenIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	for _, network := range forbiddenNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Resolver looks up the IP addresses of a host.
+type Resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+type hostValidator struct {
+	resolver Resolver
+}
+
+// NewHostValidator returns a new webhook.HostValidator that rejects hosts resolving to internal addresses.
+func NewHostValidator(resolver Resolver) webhook.HostValidator {
+	return hostValidator{
+		resolver: resolver,
+	}
+}
+
+func (v hostValidator) ValidateHost(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if isForbiddenIP(ip) {
+			return errors.NewWithDetails("host is an internal address", "host", host)
+		}
+
+		return nil
+	}
+
+	addrs, err := v.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "host cannot be resolved", "host", host)
+	}
+
+	if len(addrs) == 0 {
+		return errors.NewWithDetails("host cannot be resolved", "host", host)
+	}
+
+	for _, addr := range addrs {
+		if isForbiddenIP(addr.IP) {
+			return errors.NewWithDetails("host resolves to an internal address", "host", host, "address", addr.IP.String())
+		}
+	}
+
+	return nil
+}
+
+// NewHTTPClient returns a new HTTP client for sending webhooks.
+// Every connection (including redirects) is checked after name resolution, so internal addresses cannot be reached
+// even if the DNS record of a subscription changes after it was validated (DNS rebinding).
+// Environment proxies are ignored, since they would bypass the check.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   denyForbiddenAddresses,
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+	}
+}
+
+func denyForbiddenAddresses(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "invalid address", "address", address)
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || isForbiddenIP(ip) {
+		return errors.NewWithDetails("webhook destination is an internal address", "address", address)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/webhook"
+)
+
+type staticResolver map[string][]string
+
+func (r staticResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	var addrs []net.IPAddr
+
+	for _, ip := range r[host] {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+
+	return addrs, nil
+}
+
+func TestHostValidator_ValidateHost(t *testing.T) {
+	validator := NewHostValidator(staticResolver{
+		"hooks.example.com": {"93.184.216.34"},
+		"internal.example":  {"93.184.216.34", "10.0.0.1"},
+		"rebind.example":    {"127.0.0.1"},
+	})
+
+	tests := map[string]bool{
+		"hooks.example.com": true,
+		"93.184.216.34":     true,
+		"internal.example":  false,
+		"rebind.example":    false,
+		"unknown.example":   false,
+		"127.0.0.1":         false,
+		"169.254.169.254":   false,
+		"192.168.1.1":       false,
+		"::1":               false,
+		"fd00:ec2::254":     false,
+		"::ffff:10.0.0.1":   false,
+	}
+
+	for host, valid := range tests {
+		host, valid := host, valid
+
+		t.Run(host, func(t *testing.T) {
+			err := validator.ValidateHost(context.Background(), host)
+
+			if valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClient_InternalAddress(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("webhook should not be sent to an internal address")
+	}))
+	defer ts.Close()
+
+	sender := NewHTTPSender(NewHTTPClient(time.Second), "Pipeline/test")
+
+	_, err := sender.Send(context.Background(), webhook.Request{
+		URL:       ts.URL,
+		EventType: webhook.ClusterCreatedEventType,
+		Payload:   []byte("{}"),
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,181 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/webhook"
+)
+
+// EventDispatcher dispatches events to webhook subscribers.
+type EventDispatcher interface {
+	Dispatch(ctx context.Context, event webhook.Event) error
+}
+
+// ClusterStore returns generic cluster details.
+type ClusterStore interface {
+	GetCluster(ctx context.Context, id uint) (cluster.Cluster, error)
+}
+
+// ClusterCreatedEventHandler forwards ClusterCreated events to webhook subscribers.
+type ClusterCreatedEventHandler struct {
+	clusterEventHandler
+}
+
+// NewClusterCreatedEventHandler returns a new ClusterCreatedEventHandler.
+func NewClusterCreatedEventHandler(
+	clusters ClusterStore,
+	dispatcher EventDispatcher,
+	errorHandler common.ErrorHandler,
+) ClusterCreatedEventHandler {
+	return ClusterCreatedEventHandler{
+		clusterEventHandler: clusterEventHandler{
+			clusters:     clusters,
+			dispatcher:   dispatcher,
+			errorHandler: errorHandler,
+		},
+	}
+}
+
+// HandlerName implements the cqrs.EventHandler interface.
+func (ClusterCreatedEventHandler) HandlerName() string {
+	return "webhook_cluster_created"
+}
+
+// NewEvent implements the cqrs.EventHandler interface.
+func (ClusterCreatedEventHandler) NewEvent() interface{} {
+	return &cluster.ClusterCreated{}
+}
+
+// Handle implements the cqrs.EventHandler interface.
+func (h ClusterCreatedEventHandler) Handle(ctx context.Context, event interface{}) error {
+	e, ok := event.(*cluster.ClusterCreated)
+	if !ok {
+		return errors.NewWithDetails("unexpected event type", "type", fmt.Sprintf("%T", event))
+	}
+
+	h.dispatchClusterEvent(ctx, webhook.ClusterCreatedEventType, e.ClusterID)
+
+	return nil
+}
+
+// ClusterUpdatedEventHandler forwards ClusterUpdated events to webhook subscribers.
+type ClusterUpdatedEventHandler struct {
+	clusterEventHandler
+}
+
+// NewClusterUpdatedEventHandler returns a new ClusterUpdatedEventHandler.
+func NewClusterUpdatedEventHandler(
+	clusters ClusterStore,
+	dispatcher EventDispatcher,
+	errorHandler common.ErrorHandler,
+) ClusterUpdatedEventHandler {
+	return ClusterUpdatedEventHandler{
+		clusterEventHandler: clusterEventHandler{
+			clusters:     clusters,
+			dispatcher:   dispatcher,
+			errorHandler: errorHandler,
+		},
+	}
+}
+
+// HandlerName implements the cqrs.EventHandler interface.
+func (ClusterUpdatedEventHandler) HandlerName() string {
+	return "webhook_cluster_updated"
+}
+
+// NewEvent implements the cqrs.EventHandler interface.
+func (ClusterUpdatedEventHandler) NewEvent() interface{} {
+	return &cluster.ClusterUpdated{}
+}
+
+// Handle implements the cqrs.EventHandler interface.
+func (h ClusterUpdatedEventHandler) Handle(ctx context.Context, event interface{}) error {
+	e, ok := event.(*cluster.ClusterUpdated)
+	if !ok {
+		return errors.NewWithDetails("unexpected event type", "type", fmt.Sprintf("%T", event))
+	}
+
+	h.dispatchClusterEvent(ctx, webhook.ClusterUpdatedEventType, e.ClusterID)
+
+	return nil
+}
+
+// ClusterDeletedEventHandler forwards ClusterDeleted events to webhook subscribers.
+type ClusterDeletedEventHandler struct {
+	dispatcher   EventDispatcher
+	errorHandler common.ErrorHandler
+}
+
+// NewClusterDeletedEventHandler returns a new ClusterDeletedEventHandler.
+func NewClusterDeletedEventHandler(dispatcher EventDispatcher, errorHandler common.ErrorHandler) ClusterDeletedEventHandler {
+	return ClusterDeletedEventHandler{
+		dispatcher:   dispatcher,
+		errorHandler: errorHandler,
+	}
+}
+
+// HandlerName implements the cqrs.EventHandler interface.
+func (ClusterDeletedEventHandler) HandlerName() string {
+	return "webhook_cluster_deleted"
+}
+
+// NewEvent implements the cqrs.EventHandler interface.
+func (ClusterDeletedEventHandler) NewEvent() interface{} {
+	return &cluster.ClusterDeleted{}
+}
+
+// Handle implements the cqrs.EventHandler interface.
+func (h ClusterDeletedEventHandler) Handle(ctx context.Context, event interface{}) error {
+	e, ok := event.(*cluster.ClusterDeleted)
+	if !ok {
+		return errors.NewWithDetails("unexpected event type", "type", fmt.Sprintf("%T", event))
+	}
+
+	whEvent := webhook.NewEvent(e.OrganizationID, webhook.ClusterDeletedEventType, webhook.ClusterEventData{ClusterName: e.ClusterName})
+
+	if err := h.dispatcher.Dispatch(ctx, whEvent); err != nil {
+		h.errorHandler.HandleContext(ctx, errors.WithDetails(err, "eventType", whEvent.Type))
+	}
+
+	return nil
+}
+
+type clusterEventHandler struct {
+	clusters     ClusterStore
+	dispatcher   EventDispatcher
+	errorHandler common.ErrorHandler
+}
+
+func (h clusterEventHandler) dispatchClusterEvent(ctx context.Context, eventType string, clusterID uint) {
+	c, err := h.clusters.GetCluster(ctx, clusterID)
+	if err != nil {
+		h.errorHandler.HandleContext(ctx, errors.WithDetails(err, "eventType", eventType, "clusterId", clusterID))
+
+		return
+	}
+
+	event := webhook.NewEvent(c.OrganizationID, eventType, webhook.ClusterEventData{ClusterID: c.ID, ClusterName: c.Name})
+
+	if err := h.dispatcher.Dispatch(ctx, event); err != nil {
+		h.errorHandler.HandleContext(ctx, errors.WithDetails(err, "eventType", eventType, "clusterId", clusterID))
+	}
+}
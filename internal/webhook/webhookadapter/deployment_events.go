@@ -0,0 +1,55 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/clustergroup/deployment"
+	"github.com/banzaicloud/pipeline/internal/webhook"
+)
+
+// DeploymentEventDispatcher forwards cluster group deployment events to webhook subscribers.
+type DeploymentEventDispatcher struct {
+	dispatcher EventDispatcher
+}
+
+// NewDeploymentEventDispatcher returns a new DeploymentEventDispatcher.
+func NewDeploymentEventDispatcher(dispatcher EventDispatcher) DeploymentEventDispatcher {
+	return DeploymentEventDispatcher{
+		dispatcher: dispatcher,
+	}
+}
+
+// DeploymentFinished implements the deployment.DeploymentEventDispatcher interface.
+func (d DeploymentEventDispatcher) DeploymentFinished(ctx context.Context, event deployment.DeploymentFinished) error {
+	data := webhook.DeploymentEventData{
+		ClusterGroupID: event.ClusterGroupID,
+		ReleaseName:    event.ReleaseName,
+		Status:         event.Status,
+		Message:        event.Message,
+	}
+
+	for _, target := range event.Targets {
+		data.Targets = append(data.Targets, webhook.DeploymentTarget{
+			ClusterID:   target.ClusterId,
+			ClusterName: target.ClusterName,
+			Status:      target.Status,
+			Error:       target.Error,
+		})
+	}
+
+	return d.dispatcher.Dispatch(ctx, webhook.NewEvent(event.OrganizationID, webhook.DeploymentFinishedEventType, data))
+}
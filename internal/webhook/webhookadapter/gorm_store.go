@@ -0,0 +1,282 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+
+	"github.com/banzaicloud/pipeline/internal/webhook"
+)
+
+// subscriptionModel is the persisted form of a webhook subscription.
+type subscriptionModel struct {
+	ID             uint `gorm:"primary_key"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	OrganizationID uint `gorm:"index"`
+	Name           string
+	URL            string
+	EventTypes     string `gorm:"type:text"`
+	Secret         string
+}
+
+// TableName changes the default table name.
+func (subscriptionModel) TableName() string {
+	return "webhook_subscriptions"
+}
+
+// deliveryModel is the persisted form of a webhook delivery.
+type deliveryModel struct {
+	ID             uint `gorm:"primary_key"`
+	CreatedAt      time.Time
+	SubscriptionID uint `gorm:"index"`
+	EventID        string
+	EventType      string
+	Payload        string `gorm:"type:text"`
+	Attempts       int
+	StatusCode     int
+	Error          string `gorm:"type:text"`
+	Succeeded      bool
+	CompletedAt    *time.Time
+}
+
+// TableName changes the default table name.
+func (deliveryModel) TableName() string {
+	return "webhook_deliveries"
+}
+
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore returns a new webhook.Store backed by a relational database.
+func NewGormStore(db *gorm.DB) webhook.Store {
+	return gormStore{
+		db: db,
+	}
+}
+
+func (s gormStore) CreateSubscription(_ context.Context, subscription webhook.Subscription) (webhook.Subscription, error) {
+	model := subscriptionModel{
+		OrganizationID: subscription.OrganizationID,
+		Name:           subscription.Name,
+		URL:            subscription.URL,
+		EventTypes:     strings.Join(subscription.EventTypes, ","),
+		Secret:         subscription.Secret,
+	}
+
+	err := s.db.Create(&model).Error
+	if err != nil {
+		return webhook.Subscription{}, errors.WrapIfWithDetails(
+			err, "failed to create webhook subscription",
+			"organizationId", subscription.OrganizationID,
+		)
+	}
+
+	return toSubscription(model), nil
+}
+
+func (s gormStore) ListSubscriptions(_ context.Context, organizationID uint) ([]webhook.Subscription, error) {
+	var models []subscriptionModel
+
+	err := s.db.Where(subscriptionModel{OrganizationID: organizationID}).Order("id").Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list webhook subscriptions", "organizationId", organizationID)
+	}
+
+	subscriptions := make([]webhook.Subscription, 0, len(models))
+	for _, model := range models {
+		subscriptions = append(subscriptions, toSubscription(model))
+	}
+
+	return subscriptions, nil
+}
+
+func (s gormStore) GetSubscription(_ context.Context, organizationID uint, subscriptionID uint) (webhook.Subscription, error) {
+	model, err := s.getSubscription(organizationID, subscriptionID)
+	if err != nil {
+		return webhook.Subscription{}, err
+	}
+
+	return toSubscription(model), nil
+}
+
+func (s gormStore) getSubscription(organizationID uint, subscriptionID uint) (subscriptionModel, error) {
+	var model subscriptionModel
+
+	err := s.db.Where(subscriptionModel{ID: subscriptionID, OrganizationID: organizationID}).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return model, errors.WithStack(webhook.NotFoundError{
+			OrganizationID: organizationID,
+			SubscriptionID: subscriptionID,
+		})
+	} else if err != nil {
+		return model, errors.WrapIfWithDetails(
+			err, "failed to get webhook subscription",
+			"organizationId", organizationID,
+			"subscriptionId", subscriptionID,
+		)
+	}
+
+	return model, nil
+}
+
+func (s gormStore) DeleteSubscription(_ context.Context, organizationID uint, subscriptionID uint) error {
+	model, err := s.getSubscription(organizationID, subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	tx := s.db.Begin()
+
+	err = tx.Where(deliveryModel{SubscriptionID: model.ID}).Delete(deliveryModel{}).Error
+	if err != nil {
+		tx.Rollback()
+
+		return errors.WrapIfWithDetails(err, "failed to delete webhook deliveries", "subscriptionId", model.ID)
+	}
+
+	err = tx.Delete(&model).Error
+	if err != nil {
+		tx.Rollback()
+
+		return errors.WrapIfWithDetails(err, "failed to delete webhook subscription", "subscriptionId", model.ID)
+	}
+
+	return errors.WrapIfWithDetails(tx.Commit().Error, "failed to delete webhook subscription", "subscriptionId", model.ID)
+}
+
+func (s gormStore) CreateDelivery(_ context.Context, delivery webhook.Delivery) (webhook.Delivery, error) {
+	model := toDeliveryModel(delivery)
+
+	err := s.db.Create(&model).Error
+	if err != nil {
+		return webhook.Delivery{}, errors.WrapIfWithDetails(
+			err, "failed to create webhook delivery",
+			"subscriptionId", delivery.SubscriptionID,
+			"eventId", delivery.EventID,
+		)
+	}
+
+	return toDelivery(model), nil
+}
+
+func (s gormStore) UpdateDelivery(_ context.Context, delivery webhook.Delivery) error {
+	model := toDeliveryModel(delivery)
+
+	err := s.db.Model(&model).Updates(map[string]interface{}{
+		"attempts":     model.Attempts,
+		"status_code":  model.StatusCode,
+		"error":        model.Error,
+		"succeeded":    model.Succeeded,
+		"completed_at": model.CompletedAt,
+	}).Error
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to update webhook delivery", "deliveryId", delivery.ID)
+	}
+
+	return nil
+}
+
+func (s gormStore) ListDeliveries(_ context.Context, subscriptionID uint, limit int) ([]webhook.Delivery, error) {
+	var models []deliveryModel
+
+	err := s.db.Where(deliveryModel{SubscriptionID: subscriptionID}).Order("id DESC").Limit(limit).Find(&models).Error
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "failed to list webhook deliveries", "subscriptionId", subscriptionID)
+	}
+
+	deliveries := make([]webhook.Delivery, 0, len(models))
+	for _, model := range models {
+		deliveries = append(deliveries, toDelivery(model))
+	}
+
+	return deliveries, nil
+}
+
+func (s gormStore) GetDelivery(_ context.Context, subscriptionID uint, deliveryID uint) (webhook.Delivery, error) {
+	var model deliveryModel
+
+	err := s.db.Where(deliveryModel{ID: deliveryID, SubscriptionID: subscriptionID}).First(&model).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return webhook.Delivery{}, errors.WithStack(webhook.NotFoundError{
+			SubscriptionID: subscriptionID,
+			DeliveryID:     deliveryID,
+		})
+	} else if err != nil {
+		return webhook.Delivery{}, errors.WrapIfWithDetails(
+			err, "failed to get webhook delivery",
+			"subscriptionId", subscriptionID,
+			"deliveryId", deliveryID,
+		)
+	}
+
+	return toDelivery(model), nil
+}
+
+func toSubscription(model subscriptionModel) webhook.Subscription {
+	var eventTypes []string
+	if model.EventTypes != "" {
+		eventTypes = strings.Split(model.EventTypes, ",")
+	}
+
+	return webhook.Subscription{
+		ID:             model.ID,
+		OrganizationID: model.OrganizationID,
+		Name:           model.Name,
+		URL:            model.URL,
+		EventTypes:     eventTypes,
+		CreatedAt:      model.CreatedAt,
+		Secret:         model.Secret,
+	}
+}
+
+func toDeliveryModel(delivery webhook.Delivery) deliveryModel {
+	return deliveryModel{
+		ID:             delivery.ID,
+		CreatedAt:      delivery.CreatedAt,
+		SubscriptionID: delivery.SubscriptionID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        string(delivery.Payload),
+		Attempts:       delivery.Attempts,
+		StatusCode:     delivery.StatusCode,
+		Error:          delivery.Error,
+		Succeeded:      delivery.Succeeded,
+		CompletedAt:    delivery.CompletedAt,
+	}
+}
+
+func toDelivery(model deliveryModel) webhook.Delivery {
+	return webhook.Delivery{
+		ID:             model.ID,
+		SubscriptionID: model.SubscriptionID,
+		EventID:        model.EventID,
+		EventType:      model.EventType,
+		Payload:        json.RawMessage(model.Payload),
+		Attempts:       model.Attempts,
+		StatusCode:     model.StatusCode,
+		Error:          model.Error,
+		Succeeded:      model.Succeeded,
+		CreatedAt:      model.CreatedAt,
+		CompletedAt:    model.CompletedAt,
+	}
+}
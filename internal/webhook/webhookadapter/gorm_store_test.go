@@ -0,0 +1,152 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/webhook"
+)
+
+func setUpDatabase(t *testing.T) *gorm.DB {
+	db, err := gorm.Open("sqlite3", "file::memory:")
+	require.NoError(t, err)
+
+	err = Migrate(db, common.NoopLogger{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestGormStore_Subscriptions(t *testing.T) {
+	store := NewGormStore(setUpDatabase(t))
+	ctx := context.Background()
+
+	created, err := store.CreateSubscription(ctx, webhook.Subscription{
+		OrganizationID: 1,
+		Name:           "chatops",
+		URL:            "https://example.com/hook",
+		EventTypes:     []string{webhook.ClusterCreatedEventType, webhook.ClusterDeletedEventType},
+		Secret:         "0123456789abcdef",
+	})
+	require.NoError(t, err)
+
+	assert.NotZero(t, created.ID)
+
+	_, err = store.CreateSubscription(ctx, webhook.Subscription{OrganizationID: 2, Name: "other", EventTypes: []string{webhook.AllEventTypes}})
+	require.NoError(t, err)
+
+	subscriptions, err := store.ListSubscriptions(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 1)
+
+	assert.Equal(t, created.ID, subscriptions[0].ID)
+	assert.Equal(t, []string{webhook.ClusterCreatedEventType, webhook.ClusterDeletedEventType}, subscriptions[0].EventTypes)
+	assert.Equal(t, "0123456789abcdef", subscriptions[0].Secret)
+
+	subscription, err := store.GetSubscription(ctx, 1, created.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, "chatops", subscription.Name)
+
+	_, err = store.GetSubscription(ctx, 2, created.ID)
+	require.Error(t, err)
+
+	var notFoundErr webhook.NotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+
+	_, err = store.CreateDelivery(ctx, webhook.Delivery{SubscriptionID: created.ID, EventID: "event"})
+	require.NoError(t, err)
+
+	err = store.DeleteSubscription(ctx, 2, created.ID)
+	require.Error(t, err)
+	assert.True(t, errors.As(err, &notFoundErr))
+
+	err = store.DeleteSubscription(ctx, 1, created.ID)
+	require.NoError(t, err)
+
+	subscriptions, err = store.ListSubscriptions(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, subscriptions)
+
+	deliveries, err := store.ListDeliveries(ctx, created.ID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries, "deliveries of a deleted subscription should be deleted")
+}
+
+func TestGormStore_Deliveries(t *testing.T) {
+	store := NewGormStore(setUpDatabase(t))
+	ctx := context.Background()
+
+	var ids []uint
+
+	for _, eventID := range []string{"event1", "event2", "event3"} {
+		delivery, err := store.CreateDelivery(ctx, webhook.Delivery{
+			SubscriptionID: 1,
+			EventID:        eventID,
+			EventType:      webhook.ClusterCreatedEventType,
+			Payload:        json.RawMessage(`{"id":"` + eventID + `"}`),
+		})
+		require.NoError(t, err)
+
+		ids = append(ids, delivery.ID)
+	}
+
+	completedAt := time.Date(2020, 3, 17, 10, 0, 0, 0, time.UTC)
+
+	err := store.UpdateDelivery(ctx, webhook.Delivery{
+		ID:          ids[1],
+		Attempts:    3,
+		StatusCode:  500,
+		Error:       "all attempts failed",
+		CompletedAt: &completedAt,
+	})
+	require.NoError(t, err)
+
+	deliveries, err := store.ListDeliveries(ctx, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+
+	assert.Equal(t, ids[2], deliveries[0].ID)
+	assert.Equal(t, ids[1], deliveries[1].ID)
+
+	delivery, err := store.GetDelivery(ctx, 1, ids[1])
+	require.NoError(t, err)
+
+	assert.Equal(t, "event2", delivery.EventID)
+	assert.JSONEq(t, `{"id":"event2"}`, string(delivery.Payload))
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Equal(t, 500, delivery.StatusCode)
+	assert.Equal(t, "all attempts failed", delivery.Error)
+	assert.False(t, delivery.Succeeded)
+	require.NotNil(t, delivery.CompletedAt)
+	assert.True(t, completedAt.Equal(*delivery.CompletedAt))
+
+	_, err = store.GetDelivery(ctx, 2, ids[1])
+	require.Error(t, err)
+
+	var notFoundErr webhook.NotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+}
@@ -0,0 +1,68 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/webhook"
+)
+
+// maxDrainedBodySize limits how much of a response body is read before closing it.
+const maxDrainedBodySize = 64 * 1024
+
+type httpSender struct {
+	client    *http.Client
+	userAgent string
+}
+
+// NewHTTPSender returns a new webhook.Sender that posts the payloads as JSON.
+func NewHTTPSender(client *http.Client, userAgent string) webhook.Sender {
+	return httpSender{
+		client:    client,
+		userAgent: userAgent,
+	}
+}
+
+func (s httpSender) Send(ctx context.Context, request webhook.Request) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Payload))
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "failed to create webhook request", "url", request.URL)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", s.userAgent)
+	req.Header.Set(webhook.EventHeader, request.EventType)
+	req.Header.Set(webhook.DeliveryHeader, strconv.FormatUint(uint64(request.DeliveryID), 10))
+	req.Header.Set(webhook.SignatureHeader, request.Signature)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "failed to send webhook request", "url", request.URL)
+	}
+	defer resp.Body.Close()
+
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxDrainedBodySize))
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,64 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/banzaicloud/pipeline/internal/webhook"
+)
+
+func TestHTTPSender_Send(t *testing.T) {
+	payload := []byte(`{"id":"event"}`)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/hook", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Pipeline/test", r.Header.Get("User-Agent"))
+		assert.Equal(t, webhook.ClusterCreatedEventType, r.Header.Get(webhook.EventHeader))
+		assert.Equal(t, "12", r.Header.Get(webhook.DeliveryHeader))
+		assert.Equal(t, webhook.Sign("secret", body), r.Header.Get(webhook.SignatureHeader))
+		assert.Equal(t, payload, body)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer ts.Close()
+
+	sender := NewHTTPSender(ts.Client(), "Pipeline/test")
+
+	statusCode, err := sender.Send(context.Background(), webhook.Request{
+		URL:        ts.URL + "/hook",
+		EventType:  webhook.ClusterCreatedEventType,
+		DeliveryID: 12,
+		Payload:    payload,
+		Signature:  webhook.Sign("secret", payload),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusAccepted, statusCode)
+}
@@ -0,0 +1,93 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/integratedservices"
+	"github.com/banzaicloud/pipeline/internal/webhook"
+)
+
+// IntegratedServiceStatusChangedEventHandler forwards IntegratedServiceStatusChanged events to webhook subscribers.
+type IntegratedServiceStatusChangedEventHandler struct {
+	clusters     ClusterStore
+	dispatcher   EventDispatcher
+	errorHandler common.ErrorHandler
+}
+
+// NewIntegratedServiceStatusChangedEventHandler returns a new IntegratedServiceStatusChangedEventHandler.
+func NewIntegratedServiceStatusChangedEventHandler(
+	clusters ClusterStore,
+	dispatcher EventDispatcher,
+	errorHandler common.ErrorHandler,
+) IntegratedServiceStatusChangedEventHandler {
+	return IntegratedServiceStatusChangedEventHandler{
+		clusters:     clusters,
+		dispatcher:   dispatcher,
+		errorHandler: errorHandler,
+	}
+}
+
+// HandlerName implements the cqrs.EventHandler interface.
+func (IntegratedServiceStatusChangedEventHandler) HandlerName() string {
+	return "webhook_integratedservice_status_changed"
+}
+
+// NewEvent implements the cqrs.EventHandler interface.
+func (IntegratedServiceStatusChangedEventHandler) NewEvent() interface{} {
+	return &integratedservices.IntegratedServiceStatusChanged{}
+}
+
+// Handle implements the cqrs.EventHandler interface.
+func (h IntegratedServiceStatusChangedEventHandler) Handle(ctx context.Context, event interface{}) error {
+	e, ok := event.(*integratedservices.IntegratedServiceStatusChanged)
+	if !ok {
+		return errors.NewWithDetails("unexpected event type", "type", fmt.Sprintf("%T", event))
+	}
+
+	if err := h.dispatch(ctx, *e); err != nil {
+		h.errorHandler.HandleContext(ctx, errors.WithDetails(
+			err,
+			"clusterId", e.ClusterID,
+			"integratedService", e.IntegratedServiceName,
+			"status", e.Status,
+		))
+	}
+
+	return nil
+}
+
+func (h IntegratedServiceStatusChangedEventHandler) dispatch(ctx context.Context, e integratedservices.IntegratedServiceStatusChanged) error {
+	c, err := h.clusters.GetCluster(ctx, e.ClusterID)
+	if err != nil {
+		return err
+	}
+
+	return h.dispatcher.Dispatch(ctx, webhook.NewEvent(
+		c.OrganizationID,
+		webhook.IntegratedServiceStatusChangedEventType,
+		webhook.IntegratedServiceEventData{
+			ClusterID:         c.ID,
+			ClusterName:       c.Name,
+			IntegratedService: e.IntegratedServiceName,
+			Status:            e.Status,
+		},
+	))
+}
@@ -0,0 +1,66 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+	"fmt"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/common"
+	"github.com/banzaicloud/pipeline/internal/webhook"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// OrganizationCreatedEventHandler forwards OrganizationCreated events to webhook subscribers.
+type OrganizationCreatedEventHandler struct {
+	dispatcher   EventDispatcher
+	errorHandler common.ErrorHandler
+}
+
+// NewOrganizationCreatedEventHandler returns a new OrganizationCreatedEventHandler.
+func NewOrganizationCreatedEventHandler(dispatcher EventDispatcher, errorHandler common.ErrorHandler) OrganizationCreatedEventHandler {
+	return OrganizationCreatedEventHandler{
+		dispatcher:   dispatcher,
+		errorHandler: errorHandler,
+	}
+}
+
+// HandlerName implements the cqrs.EventHandler interface.
+func (OrganizationCreatedEventHandler) HandlerName() string {
+	return "webhook_organization_created"
+}
+
+// NewEvent implements the cqrs.EventHandler interface.
+func (OrganizationCreatedEventHandler) NewEvent() interface{} {
+	return &auth.OrganizationCreated{}
+}
+
+// Handle implements the cqrs.EventHandler interface.
+func (h OrganizationCreatedEventHandler) Handle(ctx context.Context, event interface{}) error {
+	e, ok := event.(*auth.OrganizationCreated)
+	if !ok {
+		return errors.NewWithDetails("unexpected event type", "type", fmt.Sprintf("%T", event))
+	}
+
+	whEvent := webhook.NewEvent(e.ID, webhook.OrganizationCreatedEventType, webhook.OrganizationEventData{UserID: e.UserID})
+
+	if err := h.dispatcher.Dispatch(ctx, whEvent); err != nil {
+		h.errorHandler.HandleContext(ctx, errors.WithDetails(err, "eventType", whEvent.Type, "organizationId", e.ID))
+	}
+
+	return nil
+}
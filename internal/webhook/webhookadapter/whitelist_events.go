@@ -0,0 +1,48 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookadapter
+
+import (
+	"context"
+
+	"github.com/banzaicloud/pipeline/internal/security/whitelist"
+	"github.com/banzaicloud/pipeline/internal/webhook"
+)
+
+// WhitelistEventDispatcher forwards security scan whitelist events to webhook subscribers.
+type WhitelistEventDispatcher struct {
+	dispatcher EventDispatcher
+}
+
+// NewWhitelistEventDispatcher returns a new WhitelistEventDispatcher.
+func NewWhitelistEventDispatcher(dispatcher EventDispatcher) WhitelistEventDispatcher {
+	return WhitelistEventDispatcher{
+		dispatcher: dispatcher,
+	}
+}
+
+// ItemExpired implements the whitelist.EventDispatcher interface.
+func (d WhitelistEventDispatcher) ItemExpired(ctx context.Context, event whitelist.ItemExpired) error {
+	data := webhook.WhitelistItemEventData{
+		ClusterID:   event.ClusterID,
+		ClusterName: event.ClusterName,
+		Name:        event.Name,
+		Owner:       event.Owner,
+		Reason:      event.Reason,
+		ExpiresAt:   event.ExpiresAt,
+	}
+
+	return d.dispatcher.Dispatch(ctx, webhook.NewEvent(event.OrganizationID, webhook.WhitelistItemExpiredEventType, data))
+}
@@ -0,0 +1,211 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookdriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"emperror.dev/errors"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+	"github.com/banzaicloud/pipeline/internal/webhook"
+)
+
+// RegisterHTTPHandlers mounts all of the service endpoints into an http.Handler.
+func RegisterHTTPHandlers(endpoints Endpoints, router *mux.Router, options ...kithttp.ServerOption) {
+	errorEncoder := kitxhttp.NewJSONProblemErrorResponseEncoder(apphttp.NewDefaultProblemConverter())
+
+	router.Methods(http.MethodPost).Path("").Handler(kithttp.NewServer(
+		endpoints.CreateSubscription,
+		decodeCreateSubscriptionHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeCreateSubscriptionHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("").Handler(kithttp.NewServer(
+		endpoints.ListSubscriptions,
+		decodeListSubscriptionsHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListSubscriptionsHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/{subscriptionId}").Handler(kithttp.NewServer(
+		endpoints.GetSubscription,
+		decodeGetSubscriptionHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeGetSubscriptionHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodDelete).Path("/{subscriptionId}").Handler(kithttp.NewServer(
+		endpoints.DeleteSubscription,
+		decodeDeleteSubscriptionHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(kitxhttp.StatusCodeResponseEncoder(http.StatusNoContent), errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodGet).Path("/{subscriptionId}/deliveries").Handler(kithttp.NewServer(
+		endpoints.ListDeliveries,
+		decodeListDeliveriesHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeListDeliveriesHTTPResponse, errorEncoder),
+		options...,
+	))
+
+	router.Methods(http.MethodPost).Path("/{subscriptionId}/deliveries/{deliveryId}/redeliver").Handler(kithttp.NewServer(
+		endpoints.RedeliverDelivery,
+		decodeRedeliverDeliveryHTTPRequest,
+		kitxhttp.ErrorResponseEncoder(encodeRedeliverDeliveryHTTPResponse, errorEncoder),
+		options...,
+	))
+}
+
+func decodeCreateSubscriptionHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	var newSubscription webhook.NewSubscription
+
+	err = json.NewDecoder(r.Body).Decode(&newSubscription)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode request")
+	}
+
+	return CreateSubscriptionRequest{OrganizationID: orgID, NewSubscription: newSubscription}, nil
+}
+
+func encodeCreateSubscriptionHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(CreateSubscriptionResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, kitxhttp.WithStatusCode(resp.Subscription, http.StatusCreated))
+}
+
+func decodeListSubscriptionsHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return nil, err
+	}
+
+	return ListSubscriptionsRequest{OrganizationID: orgID}, nil
+}
+
+func encodeListSubscriptionsHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListSubscriptionsResponse)
+
+	if resp.Subscriptions == nil {
+		resp.Subscriptions = []webhook.Subscription{}
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Subscriptions)
+}
+
+func decodeGetSubscriptionHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, subscriptionID, err := extractSubscriptionParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetSubscriptionRequest{OrganizationID: orgID, SubscriptionID: subscriptionID}, nil
+}
+
+func encodeGetSubscriptionHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(GetSubscriptionResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Subscription)
+}
+
+func decodeDeleteSubscriptionHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, subscriptionID, err := extractSubscriptionParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeleteSubscriptionRequest{OrganizationID: orgID, SubscriptionID: subscriptionID}, nil
+}
+
+func decodeListDeliveriesHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, subscriptionID, err := extractSubscriptionParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ListDeliveriesRequest{OrganizationID: orgID, SubscriptionID: subscriptionID}, nil
+}
+
+func encodeListDeliveriesHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(ListDeliveriesResponse)
+
+	if resp.Deliveries == nil {
+		resp.Deliveries = []webhook.Delivery{}
+	}
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, resp.Deliveries)
+}
+
+func decodeRedeliverDeliveryHTTPRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	orgID, subscriptionID, err := extractSubscriptionParams(r)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryID, err := extractUintParam(r, "deliveryId")
+	if err != nil {
+		return nil, err
+	}
+
+	return RedeliverDeliveryRequest{OrganizationID: orgID, SubscriptionID: subscriptionID, DeliveryID: deliveryID}, nil
+}
+
+func encodeRedeliverDeliveryHTTPResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	resp := response.(RedeliverDeliveryResponse)
+
+	return kitxhttp.JSONResponseEncoder(ctx, w, kitxhttp.WithStatusCode(resp.Delivery, http.StatusAccepted))
+}
+
+func extractSubscriptionParams(r *http.Request) (uint, uint, error) {
+	orgID, err := extractUintParam(r, "orgId")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	subscriptionID, err := extractUintParam(r, "subscriptionId")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return orgID, subscriptionID, nil
+}
+
+func extractUintParam(r *http.Request, param string) (uint, error) {
+	vars := mux.Vars(r)
+
+	value, ok := vars[param]
+	if !ok || value == "" {
+		return 0, errors.NewWithDetails("missing path parameter", "param", param)
+	}
+
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, errors.WrapIfWithDetails(err, "invalid path parameter", "param", param, "value", value)
+	}
+
+	return uint(id), nil
+}
@@ -0,0 +1,152 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhookdriver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/gorilla/mux"
+	kitxhttp "github.com/sagikazarmark/kitx/transport/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apphttp "github.com/banzaicloud/pipeline/internal/platform/appkit/transport/http"
+	"github.com/banzaicloud/pipeline/internal/webhook"
+)
+
+func newTestServer(endpoints Endpoints) *httptest.Server {
+	handler := mux.NewRouter()
+	RegisterHTTPHandlers(
+		endpoints,
+		handler.PathPrefix("/orgs/{orgId}/webhooks").Subrouter(),
+		kithttp.ServerErrorEncoder(kitxhttp.NewJSONProblemErrorEncoder(apphttp.NewDefaultProblemConverter())),
+	)
+
+	return httptest.NewServer(handler)
+}
+
+func TestRegisterHTTPHandlers_CreateSubscription(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		CreateSubscription: func(ctx context.Context, request interface{}) (interface{}, error) {
+			expected := CreateSubscriptionRequest{
+				OrganizationID: 1,
+				NewSubscription: webhook.NewSubscription{
+					Name:       "chatops",
+					URL:        "https://example.com/hook",
+					EventTypes: []string{webhook.ClusterCreatedEventType},
+				},
+			}
+
+			if !assert.Equal(t, expected, request) {
+				return nil, nil
+			}
+
+			return CreateSubscriptionResponse{
+				Subscription: webhook.Subscription{ID: 2, OrganizationID: 1, Name: "chatops", Secret: "secret"},
+			}, nil
+		},
+	})
+	defer ts.Close()
+
+	body := `{"name":"chatops","url":"https://example.com/hook","eventTypes":["cluster.created"]}`
+
+	resp, err := ts.Client().Post(ts.URL+"/orgs/1/webhooks", "application/json", strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var subscription webhook.Subscription
+
+	err = json.NewDecoder(resp.Body).Decode(&subscription)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(2), subscription.ID)
+	assert.Equal(t, "secret", subscription.Secret)
+}
+
+func TestRegisterHTTPHandlers_GetSubscription_NotFound(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		GetSubscription: func(ctx context.Context, request interface{}) (interface{}, error) {
+			assert.Equal(t, GetSubscriptionRequest{OrganizationID: 1, SubscriptionID: 2}, request)
+
+			err := webhook.NotFoundError{OrganizationID: 1, SubscriptionID: 2}
+
+			return GetSubscriptionResponse{Err: err}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := ts.Client().Get(ts.URL + "/orgs/1/webhooks/2")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestRegisterHTTPHandlers_ListDeliveries(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		ListDeliveries: func(ctx context.Context, request interface{}) (interface{}, error) {
+			assert.Equal(t, ListDeliveriesRequest{OrganizationID: 1, SubscriptionID: 2}, request)
+
+			return ListDeliveriesResponse{}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := ts.Client().Get(ts.URL + "/orgs/1/webhooks/2/deliveries")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var deliveries []webhook.Delivery
+
+	err = json.NewDecoder(resp.Body).Decode(&deliveries)
+	require.NoError(t, err)
+
+	assert.NotNil(t, deliveries)
+	assert.Empty(t, deliveries)
+}
+
+func TestRegisterHTTPHandlers_RedeliverDelivery(t *testing.T) {
+	ts := newTestServer(Endpoints{
+		RedeliverDelivery: func(ctx context.Context, request interface{}) (interface{}, error) {
+			assert.Equal(t, RedeliverDeliveryRequest{OrganizationID: 1, SubscriptionID: 2, DeliveryID: 3}, request)
+
+			return RedeliverDeliveryResponse{Delivery: webhook.Delivery{ID: 4, SubscriptionID: 2}}, nil
+		},
+	})
+	defer ts.Close()
+
+	resp, err := ts.Client().Post(ts.URL+"/orgs/1/webhooks/2/deliveries/3/redeliver", "application/json", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	var delivery webhook.Delivery
+
+	err = json.NewDecoder(resp.Body).Decode(&delivery)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint(4), delivery.ID)
+}
@@ -0,0 +1,193 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/brn"
+)
+
+// DefaultTokenScope is the scope of every API token.
+const DefaultTokenScope = "api:invoke"
+
+// Token scope claim prefixes
+const (
+	organizationScopePrefix = "org:"
+	clusterScopePrefix      = "cluster:"
+	actionScopePrefix       = "action:"
+)
+
+// Action verbs
+const (
+	ReadVerb  = "read"
+	WriteVerb = "write"
+	AnyVerb   = "*"
+)
+
+// TokenScopes restrict an API token to an organization, a cluster and a set of actions.
+// Empty fields do not restrict the token.
+type TokenScopes struct {
+	// Organization is the ID of the organization the token can be used in.
+	Organization uint `json:"organization,omitempty"`
+
+	// Cluster is the BRN of the cluster the token can be used for (eg. brn:1:cluster:2).
+	Cluster string `json:"cluster,omitempty"`
+
+	// Actions are the allowed actions in resource:verb form (eg. deployments:write, secrets:read).
+	// Write access implies read access.
+	Actions []string `json:"actions,omitempty"`
+}
+
+// IsEmpty checks whether the scopes restrict anything.
+func (s TokenScopes) IsEmpty() bool {
+	return s.Organization == 0 && s.Cluster == "" && len(s.Actions) == 0
+}
+
+// Validate validates the scopes.
+func (s TokenScopes) Validate() error {
+	var errs []error
+
+	if s.Cluster != "" {
+		cluster, err := brn.ParseAs(s.Cluster, brn.ClusterResourceType)
+		if err != nil {
+			errs = append(errs, errors.Errorf("cluster must be a valid cluster BRN (eg. brn:1:cluster:2): %q", s.Cluster))
+		} else if _, err := strconv.ParseUint(cluster.ResourceID, 10, 64); err != nil {
+			errs = append(errs, errors.Errorf("cluster BRN must contain a cluster ID: %q", s.Cluster))
+		} else if cluster.OrganizationID == 0 {
+			errs = append(errs, errors.Errorf("cluster BRN must contain an organization ID: %q", s.Cluster))
+		} else if s.Organization != 0 && cluster.OrganizationID != s.Organization {
+			errs = append(errs, errors.Errorf("cluster %q does not belong to organization %d", s.Cluster, s.Organization))
+		}
+	}
+
+	for _, action := range s.Actions {
+		resource, verb, ok := splitAction(action)
+		if !ok || resource == "" || (verb != ReadVerb && verb != WriteVerb && verb != AnyVerb) {
+			errs = append(errs, errors.Errorf("action must be in resource:verb form, where verb is one of read, write or *: %q", action))
+		}
+	}
+
+	return errors.Combine(errs...)
+}
+
+// ClusterID returns the organization and the cluster ID of the cluster the token is restricted to.
+// It returns false as the third value if the token is not restricted to a cluster.
+func (s TokenScopes) ClusterID() (uint, uint, bool) {
+	if s.Cluster == "" {
+		return 0, 0, false
+	}
+
+	cluster, err := brn.ParseAs(s.Cluster, brn.ClusterResourceType)
+	if err != nil {
+		return 0, 0, true
+	}
+
+	clusterID, _ := strconv.ParseUint(cluster.ResourceID, 10, 64)
+
+	return cluster.OrganizationID, uint(clusterID), true
+}
+
+// AllowsAction checks whether the scopes allow a verb on a resource.
+func (s TokenScopes) AllowsAction(resource string, verb string) bool {
+	if len(s.Actions) == 0 {
+		return true
+	}
+
+	for _, action := range s.Actions {
+		allowedResource, allowedVerb, ok := splitAction(action)
+		if !ok {
+			continue
+		}
+
+		if allowedResource != "*" && allowedResource != resource {
+			continue
+		}
+
+		if allowedVerb == AnyVerb || allowedVerb == verb || (allowedVerb == WriteVerb && verb == ReadVerb) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitAction(action string) (string, string, bool) {
+	i := strings.LastIndex(action, ":")
+	if i < 0 {
+		return "", "", false
+	}
+
+	return action[:i], action[i+1:], true
+}
+
+// FormatTokenScopes returns the value of the scope claim of a token.
+func FormatTokenScopes(scopes *TokenScopes) string {
+	claims := []string{DefaultTokenScope}
+
+	if scopes != nil {
+		if scopes.Organization != 0 {
+			claims = append(claims, fmt.Sprintf("%s%d", organizationScopePrefix, scopes.Organization))
+		}
+
+		if scopes.Cluster != "" {
+			claims = append(claims, clusterScopePrefix+scopes.Cluster)
+		}
+
+		for _, action := range scopes.Actions {
+			claims = append(claims, actionScopePrefix+action)
+		}
+	}
+
+	return strings.Join(claims, " ")
+}
+
+// ParseTokenScopes parses the scope claim of a token.
+// It returns nil if the token is not restricted.
+func ParseTokenScopes(scope string) (*TokenScopes, error) {
+	var scopes TokenScopes
+
+	for _, claim := range strings.Fields(scope) {
+		switch {
+		case strings.HasPrefix(claim, organizationScopePrefix):
+			orgID, err := strconv.ParseUint(strings.TrimPrefix(claim, organizationScopePrefix), 10, 64)
+			if err != nil {
+				return nil, errors.WrapIfWithDetails(err, "invalid organization scope", "scope", claim)
+			}
+
+			scopes.Organization = uint(orgID)
+
+		case strings.HasPrefix(claim, clusterScopePrefix):
+			scopes.Cluster = strings.TrimPrefix(claim, clusterScopePrefix)
+
+		case strings.HasPrefix(claim, actionScopePrefix):
+			scopes.Actions = append(scopes.Actions, strings.TrimPrefix(claim, actionScopePrefix))
+		}
+	}
+
+	if scopes.IsEmpty() {
+		return nil, nil
+	}
+
+	if err := scopes.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &scopes, nil
+}
@@ -0,0 +1,113 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenScopes_Validate(t *testing.T) {
+	tests := map[string]struct {
+		scopes TokenScopes
+		valid  bool
+	}{
+		"empty": {
+			scopes: TokenScopes{},
+			valid:  true,
+		},
+		"valid": {
+			scopes: TokenScopes{Organization: 1, Cluster: "brn:1:cluster:2", Actions: []string{"deployments:write", "secrets:read", "*:*"}},
+			valid:  true,
+		},
+		"invalid cluster BRN": {
+			scopes: TokenScopes{Cluster: "cluster-2"},
+		},
+		"not a cluster BRN": {
+			scopes: TokenScopes{Cluster: "brn:1:secret:2"},
+		},
+		"cluster BRN without organization": {
+			scopes: TokenScopes{Cluster: "brn::cluster:2"},
+		},
+		"cluster of another organization": {
+			scopes: TokenScopes{Organization: 2, Cluster: "brn:1:cluster:2"},
+		},
+		"invalid verb": {
+			scopes: TokenScopes{Actions: []string{"deployments:delete"}},
+		},
+		"missing verb": {
+			scopes: TokenScopes{Actions: []string{"deployments"}},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+
+		t.Run(name, func(t *testing.T) {
+			err := test.scopes.Validate()
+
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestTokenScopes_AllowsAction(t *testing.T) {
+	scopes := TokenScopes{Actions: []string{"deployments:write", "secrets:read", "buckets:*"}}
+
+	assert.True(t, scopes.AllowsAction("deployments", ReadVerb))
+	assert.True(t, scopes.AllowsAction("deployments", WriteVerb))
+	assert.True(t, scopes.AllowsAction("secrets", ReadVerb))
+	assert.False(t, scopes.AllowsAction("secrets", WriteVerb))
+	assert.True(t, scopes.AllowsAction("buckets", WriteVerb))
+	assert.False(t, scopes.AllowsAction("clusters", ReadVerb))
+
+	assert.True(t, TokenScopes{}.AllowsAction("clusters", WriteVerb))
+}
+
+func TestFormatTokenScopes(t *testing.T) {
+	assert.Equal(t, DefaultTokenScope, FormatTokenScopes(nil))
+
+	scopes := &TokenScopes{
+		Organization: 1,
+		Cluster:      "brn:1:cluster:2",
+		Actions:      []string{"deployments:write", "secrets:read"},
+	}
+
+	scope := FormatTokenScopes(scopes)
+	assert.Equal(t, "api:invoke org:1 cluster:brn:1:cluster:2 action:deployments:write action:secrets:read", scope)
+
+	parsedScopes, err := ParseTokenScopes(scope)
+	require.NoError(t, err)
+
+	assert.Equal(t, scopes, parsedScopes)
+}
+
+func TestParseTokenScopes(t *testing.T) {
+	scopes, err := ParseTokenScopes(DefaultTokenScope)
+	require.NoError(t, err)
+	assert.Nil(t, scopes)
+
+	_, err = ParseTokenScopes("api:invoke org:example")
+	assert.Error(t, err)
+
+	_, err = ParseTokenScopes("api:invoke cluster:brn:1:secret:2")
+	assert.Error(t, err)
+}
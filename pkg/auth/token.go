@@ -117,6 +117,11 @@ func NewJWTTokenGenerator(issuer string, audience string, signingKey string, opt
 
 // GenerateToken generates a JWT token.
 func (g JWTTokenGenerator) GenerateToken(sub string, expiresAt int64, tokenType string, tokenText string) (string, string, error) {
+	return g.GenerateScopedToken(sub, expiresAt, tokenType, tokenText, DefaultTokenScope)
+}
+
+// GenerateScopedToken generates a JWT token with a custom scope claim.
+func (g JWTTokenGenerator) GenerateScopedToken(sub string, expiresAt int64, tokenType string, tokenText string, scope string) (string, string, error) {
 	tokenID := g.idgen.Generate()
 
 	claims := struct {
@@ -136,7 +141,7 @@ func (g JWTTokenGenerator) GenerateToken(sub string, expiresAt int64, tokenType
 			Subject:   sub,
 			Id:        tokenID,
 		},
-		Scope: "api:invoke",
+		Scope: scope,
 		Type:  tokenType,
 		Text:  tokenText,
 	}
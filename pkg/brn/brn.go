@@ -29,7 +29,8 @@ const SchemePrefix = Scheme + ":"
 
 // Resource type constants
 const (
-	SecretResourceType = "secret"
+	SecretResourceType  = "secret"
+	ClusterResourceType = "cluster"
 )
 
 // ErrInvalid is returned when a BRN fails validation checks.
@@ -29,6 +29,12 @@ type productCacheKey struct {
 	productType string
 }
 
+type productListCacheKey struct {
+	cloud   string
+	service string
+	region  string
+}
+
 // GetProductDetails returns details for a single product.
 func (c *Client) GetProductDetails(
 	ctx context.Context,
@@ -68,6 +74,27 @@ func (c *Client) GetProductDetails(
 	return cachedProduct.(cloudinfo.ProductDetails), nil
 }
 
+// GetProducts returns the details of every product of a service available in a region.
+func (c *Client) GetProducts(ctx context.Context, cloud string, service string, region string) ([]cloudinfo.ProductDetails, error) {
+	key := productListCacheKey{
+		cloud:   cloud,
+		service: service,
+		region:  region,
+	}
+
+	cachedProducts, ok := c.productCache.Load(key)
+	if !ok {
+		err := c.warmProductCache(ctx, cloud, service, region)
+		if err != nil {
+			return nil, err
+		}
+
+		cachedProducts, _ = c.productCache.Load(key)
+	}
+
+	return cachedProducts.([]cloudinfo.ProductDetails), nil
+}
+
 func (c *Client) warmProductCache(ctx context.Context, cloud string, service string, region string) error {
 	response, _, err := c.apiClient.ProductsApi.GetProducts(ctx, cloud, service, region)
 	if err != nil {
@@ -86,5 +113,14 @@ func (c *Client) warmProductCache(ctx context.Context, cloud string, service str
 		)
 	}
 
+	c.productCache.Store(
+		productListCacheKey{
+			cloud:   cloud,
+			service: service,
+			region:  region,
+		},
+		response.Products,
+	)
+
 	return nil
 }
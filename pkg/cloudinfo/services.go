@@ -16,6 +16,7 @@ package cloudinfo
 
 import (
 	"context"
+	"regexp"
 
 	"emperror.dev/errors"
 	"github.com/antihax/optional"
@@ -23,6 +24,21 @@ import (
 	"github.com/banzaicloud/pipeline/.gen/cloudinfo"
 )
 
+// googleZoneRegexp matches Google Cloud zones (eg. europe-west1-b).
+var googleZoneRegexp = regexp.MustCompile(`^([a-z]+-[a-z]+\d+)-[a-z]$`)
+
+// RegionFromLocation returns the Cloudinfo region of a cluster location.
+// Google clusters can be zonal, but Cloudinfo serves products per region.
+func RegionFromLocation(cloudProvider string, location string) string {
+	if cloudProvider == "google" {
+		if match := googleZoneRegexp.FindStringSubmatch(location); match != nil {
+			return match[1]
+		}
+	}
+
+	return location
+}
+
 // GetServiceRegions returns the cloud provider regions where the specified service is available.
 func (c *Client) GetServiceRegions(ctx context.Context, cloudProvider string, service string) ([]string, error) {
 	regions, _, err := c.apiClient.RegionsApi.GetRegions(ctx, cloudProvider, service)
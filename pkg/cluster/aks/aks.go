@@ -46,9 +46,11 @@ type NodePoolCreate struct {
 	NodeInstanceType string            `json:"instanceType" yaml:"instanceType"`
 	VNetSubnetID     string            `json:"vnetSubnetID,omitempty" yaml:"vnetSubnetID,omitempty"`
 	Labels           map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Taints           pkgCommon.Taints  `json:"taints,omitempty" yaml:"taints,omitempty"`
 }
 
 // NodePoolUpdate describes Azure's node count of a UpdateCluster request
+// (AKS does not support changing the taints of an existing node pool)
 type NodePoolUpdate struct {
 	Autoscaling bool              `json:"autoscaling"`
 	MinCount    int               `json:"minCount"`
@@ -106,6 +108,10 @@ func (azure *CreateClusterAKS) Validate() error {
 		if err := pkgCommon.ValidateNodePoolLabels(npName, np.Labels); err != nil {
 			return err
 		}
+
+		if err := pkgCommon.ValidateNodePoolTaints(npName, np.Taints); err != nil {
+			return err
+		}
 	}
 
 	if len(azure.KubernetesVersion) == 0 {
@@ -41,10 +41,11 @@ const (
 	Warning  = "WARNING"
 	Error    = "ERROR"
 
-	CreatingMessage = "Cluster creation is in progress"
-	RunningMessage  = "Cluster is running"
-	UpdatingMessage = "Update is in progress"
-	DeletingMessage = "Termination is in progress"
+	CreatingMessage  = "Cluster creation is in progress"
+	RunningMessage   = "Cluster is running"
+	UpdatingMessage  = "Update is in progress"
+	DeletingMessage  = "Termination is in progress"
+	UnlinkingMessage = "Unlinking is in progress"
 )
 
 // Cloud constants
@@ -52,6 +53,7 @@ const (
 	Alibaba    = "alibaba"
 	Amazon     = "amazon"
 	Azure      = "azure"
+	Baremetal  = "baremetal"
 	Google     = "google"
 	Kubernetes = "kubernetes"
 	Oracle     = "oracle"
@@ -91,6 +93,7 @@ type CreateClusterRequest struct {
 	PostHooks    PostHooks                `json:"postHooks" yaml:"postHooks"`
 	Properties   *CreateClusterProperties `json:"properties" yaml:"properties" binding:"required"`
 	ScaleOptions *ScaleOptions            `json:"scaleOptions,omitempty" yaml:"scaleOptions,omitempty"`
+	Labels       map[string]string        `json:"labels,omitempty" yaml:"labels,omitempty"`
 }
 
 // CreateClusterProperties contains the cluster flavor specific properties.
@@ -157,6 +160,7 @@ type GetClusterStatusResponse struct {
 	Version       string                     `json:"version,omitempty"`
 	ResourceID    uint                       `json:"id"`
 	NodePools     map[string]*NodePoolStatus `json:"nodePools"`
+	Labels        map[string]string          `json:"labels,omitempty"`
 	pkgCommon.CreatorBaseFields
 
 	// If region not available fall back to Location
@@ -176,6 +180,7 @@ type NodePoolStatus struct {
 	Image        string            `json:"image,omitempty"`
 	Version      string            `json:"version,omitempty"`
 	Labels       map[string]string `json:"labels,omitempty"`
+	Taints       pkgCommon.Taints  `json:"taints,omitempty"`
 
 	pkgCommon.CreatorBaseFields
 }
@@ -343,6 +348,9 @@ func (r *CreateClusterRequest) validateMainFields() error {
 			return pkgErrors.ErrorLocationEmpty
 		}
 	}
+	if err := pkgCommon.ValidateClusterLabels(r.Labels); err != nil {
+		return err
+	}
 	return nil
 }
 
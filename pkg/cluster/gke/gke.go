@@ -52,6 +52,7 @@ type NodePool struct {
 	NodeInstanceType string            `json:"instanceType,omitempty" yaml:"instanceType,omitempty"`
 	Preemptible      bool              `json:"preemptible,omitempty" yaml:"preemptible,omitempty"`
 	Labels           map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Taints           pkgCommon.Taints  `json:"taints,omitempty" yaml:"taints,omitempty"`
 }
 
 // UpdateClusterGoogle describes Google's node fields of an UpdateCluster request
@@ -80,6 +81,10 @@ func validateNodePool(npName string, nodePool *NodePool) error {
 		return err
 	}
 
+	if err := pkgCommon.ValidateNodePoolTaints(npName, nodePool.Taints); err != nil {
+		return err
+	}
+
 	return nil
 }
 
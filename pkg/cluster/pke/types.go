@@ -43,6 +43,10 @@ func (a *UpdateClusterPKE) Validate() error {
 		if err := common.ValidateNodePoolLabels(npName, np.Labels); err != nil {
 			return err
 		}
+
+		if err := common.ValidateNodePoolTaints(npName, np.Taints); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -58,6 +62,7 @@ type UpdateNodePool struct {
 	Count        int               `json:"count" yaml:"count"`
 	Subnets      Subnets           `json:"subnets,omitempty" yaml:"subnets,omitempty"`
 	Labels       map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Taints       common.Taints     `json:"taints,omitempty" yaml:"taints,omitempty"`
 }
 
 type Network struct {
@@ -73,8 +78,19 @@ type NetworkProvider string
 const (
 	NPCalico NetworkProvider = "calico"
 	NPCilium NetworkProvider = "cilium"
+	NPWeave  NetworkProvider = "weave"
 )
 
+// Validate checks whether the network provider is supported.
+func (p NetworkProvider) Validate() error {
+	switch p {
+	case NPCalico, NPCilium, NPWeave:
+		return nil
+	default:
+		return errors.Errorf("unsupported network provider: %s", p)
+	}
+}
+
 type NodePools []NodePool
 
 type NodePool struct {
@@ -84,6 +100,7 @@ type NodePool struct {
 	Provider       NodePoolProvider       `json:"provider" yaml:"provider" binding:"required"`
 	ProviderConfig map[string]interface{} `json:"providerConfig" yaml:"providerConfig" binding:"required"`
 	Labels         map[string]string      `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Taints         common.Taints          `json:"taints,omitempty" yaml:"taints,omitempty"`
 	Autoscaling    bool                   `json:"autoscaling" yaml:"autoscaling"`
 }
 
@@ -186,6 +203,12 @@ type CRI struct {
 	Runtime       Runtime                `json:"runtime" yaml:"runtime" binding:"required"`
 	RuntimeConfig map[string]interface{} `json:"runtimeConfig" yaml:"runtimeConfig"`
 }
+
+// Validate checks whether the runtime is supported.
+func (c CRI) Validate() error {
+	return c.Runtime.Validate()
+}
+
 type Runtime string
 
 const (
@@ -193,6 +216,17 @@ const (
 	CRIContainerd Runtime = "containerd"
 )
 
+// Validate checks whether the runtime is supported.
+// An empty runtime leaves the choice to the PKE installer.
+func (r Runtime) Validate() error {
+	switch r {
+	case "", CRIDocker, CRIContainerd:
+		return nil
+	default:
+		return errors.Errorf("unsupported container runtime: %s", r)
+	}
+}
+
 // //TODO add required field to ExtraArgs if applicable
 type KubeADM struct {
 	ExtraArgs ExtraArgs `json:"extraArgs" yaml:"extraArgs"`
@@ -0,0 +1,57 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pke
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCRI_Validate(t *testing.T) {
+	tests := map[string]struct {
+		cri   CRI
+		valid bool
+	}{
+		"empty": {
+			valid: true,
+		},
+		"unsupported runtime": {
+			cri: CRI{Runtime: "cri-o"},
+		},
+		"docker": {
+			cri:   CRI{Runtime: CRIDocker},
+			valid: true,
+		},
+		"containerd": {
+			cri:   CRI{Runtime: CRIContainerd},
+			valid: true,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			err := test.cri.Validate()
+
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/banzaicloud/pipeline/internal/global/nplabels"
+	"github.com/banzaicloud/pipeline/pkg/kubernetes"
 )
 
 // BanzaiResponse describes Pipeline's responses
@@ -103,3 +104,22 @@ func ValidateNodePoolLabels(nodePoolName string, labels map[string]string) error
 
 	return nil
 }
+
+// ValidateClusterLabels checks whether the cluster labels are valid Kubernetes labels
+func ValidateClusterLabels(labels map[string]string) error {
+	err := kubernetes.LabelValidator{}.ValidateLabels(labels)
+	if err != nil {
+		msg := "invalid cluster labels"
+
+		var verr interface {
+			Violations() []string
+		}
+		if errors.As(err, &verr) {
+			msg += ": " + strings.Join(verr.Violations(), ", ")
+		}
+
+		return errors.New(msg)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/database/sql/json"
+	"github.com/banzaicloud/pipeline/internal/global/nplabels"
+)
+
+// Node taint effects supported by Kubernetes
+const (
+	TaintEffectNoSchedule       = "NoSchedule"
+	TaintEffectPreferNoSchedule = "PreferNoSchedule"
+	TaintEffectNoExecute        = "NoExecute"
+)
+
+// Taint describes a Kubernetes taint applied to every node in a node pool.
+type Taint struct {
+	Key    string `json:"key" yaml:"key"`
+	Value  string `json:"value,omitempty" yaml:"value,omitempty"`
+	Effect string `json:"effect" yaml:"effect"`
+}
+
+// String returns the taint in the format accepted by the kubelet: key[=value]:effect
+func (t Taint) String() string {
+	if t.Value == "" {
+		return fmt.Sprintf("%s:%s", t.Key, t.Effect)
+	}
+
+	return fmt.Sprintf("%s=%s:%s", t.Key, t.Value, t.Effect)
+}
+
+// Taints is a list of node pool taints that can be persisted in a single database column.
+type Taints []Taint
+
+// Strings returns the taints in the format accepted by the kubelet.
+func (t Taints) Strings() []string {
+	taints := make([]string, 0, len(t))
+
+	for _, taint := range t {
+		taints = append(taints, taint.String())
+	}
+
+	return taints
+}
+
+// Value implements the driver.Valuer interface
+func (t Taints) Value() (driver.Value, error) {
+	return json.Value(t)
+}
+
+// Scan implements the sql.Scanner interface
+func (t *Taints) Scan(src interface{}) error {
+	if src == nil {
+		*t = nil
+
+		return nil
+	}
+
+	return json.Scan(src, t)
+}
+
+// TaintLabelValidator validates the key and value of taints (they follow the syntax of labels).
+type TaintLabelValidator interface {
+	ValidateKey(key string) error
+	ValidateValue(value string) error
+}
+
+// TaintViolations returns the reasons why a list of taints is not a valid set of Kubernetes taints.
+func TaintViolations(taints []Taint, labelValidator TaintLabelValidator) []string {
+	var violations []string
+
+	seen := make(map[string]bool, len(taints))
+
+	for _, taint := range taints {
+		if err := labelValidator.ValidateKey(taint.Key); err != nil {
+			violations = append(violations, unwrapViolations(err)...)
+		}
+
+		if taint.Value != "" {
+			if err := labelValidator.ValidateValue(taint.Value); err != nil {
+				violations = append(violations, unwrapViolations(err)...)
+			}
+		}
+
+		switch taint.Effect {
+		case TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute:
+		default:
+			violations = append(violations, fmt.Sprintf("invalid taint effect %q for key %q", taint.Effect, taint.Key))
+		}
+
+		if id := taint.Key + ":" + taint.Effect; seen[id] {
+			violations = append(violations, fmt.Sprintf("duplicate taint %q", id))
+		} else {
+			seen[id] = true
+		}
+	}
+
+	return violations
+}
+
+// ValidateNodePoolTaints checks whether the node pool taints are valid Kubernetes taints.
+func ValidateNodePoolTaints(nodePoolName string, taints []Taint) error {
+	violations := TaintViolations(taints, nplabels.NodePoolLabelValidator())
+
+	if len(violations) > 0 { // Temporary hack: return errors in a readable format for the UI
+		return errors.New(fmt.Sprintf("invalid taints on %s node pool: %s", nodePoolName, strings.Join(violations, ", ")))
+	}
+
+	return nil
+}
+
+func unwrapViolations(err error) []string {
+	var verr interface {
+		Violations() []string
+	}
+
+	if errors.As(err, &verr) {
+		return verr.Violations()
+	}
+
+	return []string{err.Error()}
+}
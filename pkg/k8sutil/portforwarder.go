@@ -58,15 +58,15 @@ func getFirstRunningPod(client corev1.PodsGetter, namespace string, selector lab
 		return nil, fmt.Errorf("could not find pod with labels: %s", selector.String())
 	}
 	for _, p := range pods.Items {
-		if isPodReady(&p) {
+		if IsPodReady(&p) {
 			return &p, nil
 		}
 	}
 	return nil, fmt.Errorf("could not find a ready pod")
 }
 
-// isPodReady returns true if a pod is ready; false otherwise.
-func isPodReady(pod *v1.Pod) bool {
+// IsPodReady returns true if a pod is ready; false otherwise.
+func IsPodReady(pod *v1.Pod) bool {
 	return isPodReadyConditionTrue(pod.Status)
 }
 
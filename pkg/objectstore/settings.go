@@ -0,0 +1,127 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"database/sql/driver"
+
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/database/sql/json"
+)
+
+// BucketSettings describes the optional lifecycle, versioning and encryption settings of a bucket.
+type BucketSettings struct {
+	// Versioning keeps every version of the objects stored in the bucket.
+	Versioning bool `json:"versioning"`
+
+	// Encryption configures the default encryption of new objects.
+	// Encryption is not configured on the bucket when nil.
+	Encryption *BucketEncryption `json:"encryption,omitempty"`
+
+	// LifecycleRules expire or move objects to another storage class based on their age.
+	LifecycleRules []LifecycleRule `json:"lifecycleRules,omitempty"`
+}
+
+// BucketEncryption describes the default encryption of a bucket.
+type BucketEncryption struct {
+	// KeyID identifies the key in the key management service of the provider.
+	// Provider managed keys are used when empty.
+	KeyID string `json:"keyId,omitempty"`
+}
+
+// LifecycleRule expires or moves objects to another storage class based on their age.
+type LifecycleRule struct {
+	ID string `json:"id"`
+
+	// Prefix limits the rule to the objects with the given key prefix.
+	Prefix string `json:"prefix,omitempty"`
+
+	// ExpirationDays is the number of days after which objects are deleted.
+	ExpirationDays int `json:"expirationDays,omitempty"`
+
+	// TransitionDays is the number of days after which objects are moved to TransitionStorageClass.
+	TransitionDays         int    `json:"transitionDays,omitempty"`
+	TransitionStorageClass string `json:"transitionStorageClass,omitempty"`
+}
+
+// Validate validates the bucket settings.
+func (s BucketSettings) Validate() error {
+	var err error
+
+	ids := make(map[string]bool, len(s.LifecycleRules))
+
+	for _, rule := range s.LifecycleRules {
+		if ids[rule.ID] {
+			err = errors.Append(err, errors.Errorf("duplicate lifecycle rule id: %s", rule.ID))
+		}
+		ids[rule.ID] = true
+
+		err = errors.Append(err, rule.Validate())
+	}
+
+	return err
+}
+
+// Validate validates the lifecycle rule.
+func (r LifecycleRule) Validate() error {
+	var err error
+
+	if r.ID == "" {
+		err = errors.Append(err, errors.New("lifecycle rule id is required"))
+	}
+
+	if r.ExpirationDays < 0 || r.TransitionDays < 0 {
+		err = errors.Append(err, errors.Errorf("lifecycle rule %s: days must not be negative", r.ID))
+	}
+
+	if r.ExpirationDays == 0 && r.TransitionDays == 0 {
+		err = errors.Append(err, errors.Errorf("lifecycle rule %s: either expiration or transition is required", r.ID))
+	}
+
+	if (r.TransitionDays > 0) != (r.TransitionStorageClass != "") {
+		err = errors.Append(err, errors.Errorf("lifecycle rule %s: transition requires both days and storage class", r.ID))
+	}
+
+	if r.ExpirationDays > 0 && r.TransitionDays > 0 && r.TransitionDays >= r.ExpirationDays {
+		err = errors.Append(err, errors.Errorf("lifecycle rule %s: objects must be moved before they expire", r.ID))
+	}
+
+	return err
+}
+
+// Scan implements the sql.Scanner interface.
+func (s *BucketSettings) Scan(src interface{}) error {
+	// buckets created before settings were introduced have none
+	if src == nil {
+		*s = BucketSettings{}
+
+		return nil
+	}
+
+	return json.Scan(src, s)
+}
+
+// Value implements the driver.Valuer interface.
+func (s BucketSettings) Value() (driver.Value, error) {
+	return json.Value(s)
+}
+
+// BucketSettingsManager is implemented by object stores that can configure buckets.
+type BucketSettingsManager interface {
+	// SetBucketSettings applies the lifecycle, versioning and encryption settings to the given bucket.
+	// Settings not present (eg. lifecycle rules) are removed from the bucket.
+	SetBucketSettings(bucketName string, settings BucketSettings) error
+}
@@ -0,0 +1,91 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucketSettings_Validate(t *testing.T) {
+	tests := map[string]struct {
+		settings BucketSettings
+		valid    bool
+	}{
+		"empty": {
+			settings: BucketSettings{},
+			valid:    true,
+		},
+		"valid": {
+			settings: BucketSettings{
+				Versioning: true,
+				Encryption: &BucketEncryption{},
+				LifecycleRules: []LifecycleRule{
+					{ID: "logs", Prefix: "logs/", ExpirationDays: 90, TransitionDays: 30, TransitionStorageClass: "GLACIER"},
+					{ID: "tmp", Prefix: "tmp/", ExpirationDays: 1},
+				},
+			},
+			valid: true,
+		},
+		"missing id": {
+			settings: BucketSettings{
+				LifecycleRules: []LifecycleRule{{ExpirationDays: 1}},
+			},
+		},
+		"duplicate id": {
+			settings: BucketSettings{
+				LifecycleRules: []LifecycleRule{
+					{ID: "rule", ExpirationDays: 1},
+					{ID: "rule", ExpirationDays: 2},
+				},
+			},
+		},
+		"no action": {
+			settings: BucketSettings{
+				LifecycleRules: []LifecycleRule{{ID: "rule"}},
+			},
+		},
+		"negative days": {
+			settings: BucketSettings{
+				LifecycleRules: []LifecycleRule{{ID: "rule", ExpirationDays: -1}},
+			},
+		},
+		"transition without storage class": {
+			settings: BucketSettings{
+				LifecycleRules: []LifecycleRule{{ID: "rule", TransitionDays: 30}},
+			},
+		},
+		"transition after expiration": {
+			settings: BucketSettings{
+				LifecycleRules: []LifecycleRule{{ID: "rule", ExpirationDays: 30, TransitionDays: 60, TransitionStorageClass: "GLACIER"}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			err := test.settings.Validate()
+
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
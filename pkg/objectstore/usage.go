@@ -0,0 +1,158 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"sort"
+	"strings"
+)
+
+// ObjectInfo describes an object stored in a bucket.
+type ObjectInfo struct {
+	Key  string `json:"key"`
+	Size int64  `json:"size"`
+}
+
+// ObjectInfoWalker is implemented by object stores that can report the size of the objects.
+type ObjectInfoWalker interface {
+	// WalkObjectInfos calls fn for every object (including its size) with the given prefix in the bucket.
+	// Walking stops at the first error returned by fn.
+	WalkObjectInfos(bucketName string, prefix string, fn func(ObjectInfo) error) error
+}
+
+// Usage is the storage used by a set of objects.
+type Usage struct {
+	ObjectCount int64 `json:"objectCount"`
+	TotalSize   int64 `json:"totalSize"`
+}
+
+// Add adds an object to the usage.
+func (u *Usage) Add(object ObjectInfo) {
+	u.ObjectCount++
+	u.TotalSize += object.Size
+}
+
+// PrefixUsage is the storage used by the objects under a key prefix.
+type PrefixUsage struct {
+	Prefix string `json:"prefix"`
+
+	Usage
+}
+
+// UsageAggregator sums up the storage used by objects, grouped by the first depth segments of their key
+// (separated by delimiter), without keeping the objects in memory.
+// Objects with fewer segments are grouped by their parent "directory" (or the empty prefix).
+type UsageAggregator struct {
+	Usage
+
+	delimiter string
+	depth     int
+	prefixes  map[string]*Usage
+}
+
+// NewUsageAggregator returns a new UsageAggregator. Objects are not grouped when depth is zero.
+func NewUsageAggregator(delimiter string, depth int) *UsageAggregator {
+	return &UsageAggregator{
+		delimiter: delimiter,
+		depth:     depth,
+		prefixes:  make(map[string]*Usage),
+	}
+}
+
+// Add adds an object to the usage.
+func (a *UsageAggregator) Add(object ObjectInfo) {
+	a.Usage.Add(object)
+
+	if a.depth <= 0 || a.delimiter == "" {
+		return
+	}
+
+	segments := strings.Split(object.Key, a.delimiter)
+
+	// the last segment is the name of the object itself
+	n := len(segments) - 1
+	if n > a.depth {
+		n = a.depth
+	}
+
+	prefix := strings.Join(segments[:n], a.delimiter)
+
+	usage, ok := a.prefixes[prefix]
+	if !ok {
+		usage = &Usage{}
+		a.prefixes[prefix] = usage
+	}
+
+	usage.Add(object)
+}
+
+// Prefixes returns the usage of each prefix ordered by prefix.
+func (a *UsageAggregator) Prefixes() []PrefixUsage {
+	if a.depth <= 0 || a.delimiter == "" {
+		return nil
+	}
+
+	result := make([]PrefixUsage, 0, len(a.prefixes))
+	for prefix, usage := range a.prefixes {
+		result = append(result, PrefixUsage{Prefix: prefix, Usage: *usage})
+	}
+
+	sortPrefixUsages(result)
+
+	return result
+}
+
+// RollUpPrefixes regroups prefix usages by the first depth segments of their prefix.
+// Prefixes can only be rolled up to a lower depth than the one they were aggregated with:
+// deeper ones are returned unchanged.
+func RollUpPrefixes(prefixes []PrefixUsage, delimiter string, depth int) []PrefixUsage {
+	if depth <= 0 || delimiter == "" || prefixes == nil {
+		return nil
+	}
+
+	usages := make(map[string]*PrefixUsage)
+
+	for _, prefixUsage := range prefixes {
+		prefix := prefixUsage.Prefix
+
+		if segments := strings.Split(prefix, delimiter); len(segments) > depth {
+			prefix = strings.Join(segments[:depth], delimiter)
+		}
+
+		usage, ok := usages[prefix]
+		if !ok {
+			usage = &PrefixUsage{Prefix: prefix}
+			usages[prefix] = usage
+		}
+
+		usage.ObjectCount += prefixUsage.ObjectCount
+		usage.TotalSize += prefixUsage.TotalSize
+	}
+
+	result := make([]PrefixUsage, 0, len(usages))
+	for _, usage := range usages {
+		result = append(result, *usage)
+	}
+
+	sortPrefixUsages(result)
+
+	return result
+}
+
+func sortPrefixUsages(usages []PrefixUsage) {
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Prefix < usages[j].Prefix
+	})
+}
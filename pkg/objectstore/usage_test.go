@@ -0,0 +1,116 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageAggregator(t *testing.T) {
+	objects := []ObjectInfo{
+		{Key: "logs/app/2020/01/01.gz", Size: 10},
+		{Key: "logs/app/2020/01/02.gz", Size: 20},
+		{Key: "logs/system/2020/01/01.gz", Size: 5},
+		{Key: "backups/cluster-1/backup.tar.gz", Size: 100},
+		{Key: "backups/index.json", Size: 1},
+		{Key: "README", Size: 2},
+	}
+
+	tests := map[string]struct {
+		depth    int
+		expected []PrefixUsage
+	}{
+		"no grouping": {
+			depth: 0,
+		},
+		"top level": {
+			depth: 1,
+			expected: []PrefixUsage{
+				{Prefix: "", Usage: Usage{ObjectCount: 1, TotalSize: 2}},
+				{Prefix: "backups", Usage: Usage{ObjectCount: 2, TotalSize: 101}},
+				{Prefix: "logs", Usage: Usage{ObjectCount: 3, TotalSize: 35}},
+			},
+		},
+		"second level": {
+			depth: 2,
+			expected: []PrefixUsage{
+				{Prefix: "", Usage: Usage{ObjectCount: 1, TotalSize: 2}},
+				{Prefix: "backups", Usage: Usage{ObjectCount: 1, TotalSize: 1}},
+				{Prefix: "backups/cluster-1", Usage: Usage{ObjectCount: 1, TotalSize: 100}},
+				{Prefix: "logs/app", Usage: Usage{ObjectCount: 2, TotalSize: 30}},
+				{Prefix: "logs/system", Usage: Usage{ObjectCount: 1, TotalSize: 5}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			aggregator := NewUsageAggregator("/", test.depth)
+			for _, object := range objects {
+				aggregator.Add(object)
+			}
+
+			assert.Equal(t, Usage{ObjectCount: 6, TotalSize: 138}, aggregator.Usage)
+			assert.Equal(t, test.expected, aggregator.Prefixes())
+		})
+	}
+}
+
+func TestRollUpPrefixes(t *testing.T) {
+	prefixes := []PrefixUsage{
+		{Prefix: "", Usage: Usage{ObjectCount: 1, TotalSize: 2}},
+		{Prefix: "backups", Usage: Usage{ObjectCount: 1, TotalSize: 1}},
+		{Prefix: "backups/cluster-1", Usage: Usage{ObjectCount: 1, TotalSize: 100}},
+		{Prefix: "logs/app", Usage: Usage{ObjectCount: 2, TotalSize: 30}},
+		{Prefix: "logs/system", Usage: Usage{ObjectCount: 1, TotalSize: 5}},
+	}
+
+	tests := map[string]struct {
+		depth    int
+		expected []PrefixUsage
+	}{
+		"no grouping": {
+			depth: 0,
+		},
+		"top level": {
+			depth: 1,
+			expected: []PrefixUsage{
+				{Prefix: "", Usage: Usage{ObjectCount: 1, TotalSize: 2}},
+				{Prefix: "backups", Usage: Usage{ObjectCount: 2, TotalSize: 101}},
+				{Prefix: "logs", Usage: Usage{ObjectCount: 3, TotalSize: 35}},
+			},
+		},
+		"aggregated level": {
+			depth:    2,
+			expected: prefixes,
+		},
+		"deeper level": {
+			depth:    3,
+			expected: prefixes,
+		},
+	}
+
+	for name, test := range tests {
+		name, test := name, test
+
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, RollUpPrefixes(prefixes, "/", test.depth))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"emperror.dev/errors"
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+const (
+	sseAlgorithmAES256 = "AES256"
+	sseAlgorithmKMS    = "KMS"
+
+	lifecycleRuleEnabled = "Enabled"
+)
+
+// ValidateBucketSettings checks whether the settings can be applied to OSS buckets.
+func ValidateBucketSettings(settings objectstore.BucketSettings) error {
+	for _, rule := range settings.LifecycleRules {
+		switch oss.StorageClassType(rule.TransitionStorageClass) {
+		case "", oss.StorageIA, oss.StorageArchive:
+		default:
+			return errors.Errorf("lifecycle rule %s: unsupported storage class: %s", rule.ID, rule.TransitionStorageClass)
+		}
+	}
+
+	return nil
+}
+
+// SetBucketSettings applies the lifecycle, versioning and encryption settings to the given bucket.
+func (o *objectStore) SetBucketSettings(bucketName string, settings objectstore.BucketSettings) error {
+	if err := ValidateBucketSettings(settings); err != nil {
+		return errors.WithDetails(err, "bucket", bucketName)
+	}
+
+	versioning := oss.VersioningConfig{Status: string(oss.VersionSuspended)}
+	if settings.Versioning {
+		versioning.Status = string(oss.VersionEnabled)
+	}
+
+	if err := o.client.SetBucketVersioning(bucketName, versioning); err != nil {
+		err = o.convertError(err)
+		return errors.WrapIfWithDetails(err, "failed to set bucket versioning", "bucket", bucketName)
+	}
+
+	if settings.Encryption == nil {
+		if err := o.client.DeleteBucketEncryption(bucketName); err != nil {
+			err = o.convertError(err)
+			return errors.WrapIfWithDetails(err, "failed to delete bucket encryption", "bucket", bucketName)
+		}
+	} else {
+		rule := oss.ServerEncryptionRule{
+			SSEDefault: oss.SSEDefaultRule{
+				SSEAlgorithm: sseAlgorithmAES256,
+			},
+		}
+
+		if settings.Encryption.KeyID != "" {
+			rule.SSEDefault.SSEAlgorithm = sseAlgorithmKMS
+			rule.SSEDefault.KMSMasterKeyID = settings.Encryption.KeyID
+		}
+
+		if err := o.client.SetBucketEncryption(bucketName, rule); err != nil {
+			err = o.convertError(err)
+			return errors.WrapIfWithDetails(err, "failed to set bucket encryption", "bucket", bucketName)
+		}
+	}
+
+	if len(settings.LifecycleRules) == 0 {
+		if err := o.client.DeleteBucketLifecycle(bucketName); err != nil {
+			err = o.convertError(err)
+			return errors.WrapIfWithDetails(err, "failed to delete bucket lifecycle", "bucket", bucketName)
+		}
+
+		return nil
+	}
+
+	rules := make([]oss.LifecycleRule, 0, len(settings.LifecycleRules))
+	for _, rule := range settings.LifecycleRules {
+		lifecycleRule := oss.LifecycleRule{
+			ID:     rule.ID,
+			Prefix: rule.Prefix,
+			Status: lifecycleRuleEnabled,
+		}
+
+		if rule.ExpirationDays > 0 {
+			lifecycleRule.Expiration = &oss.LifecycleExpiration{
+				Days: rule.ExpirationDays,
+			}
+		}
+
+		if rule.TransitionDays > 0 {
+			lifecycleRule.Transitions = []oss.LifecycleTransition{
+				{
+					Days:         rule.TransitionDays,
+					StorageClass: oss.StorageClassType(rule.TransitionStorageClass),
+				},
+			}
+		}
+
+		rules = append(rules, lifecycleRule)
+	}
+
+	if err := o.client.SetBucketLifecycle(bucketName, rules); err != nil {
+		err = o.convertError(err)
+		return errors.WrapIfWithDetails(err, "failed to set bucket lifecycle", "bucket", bucketName)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"emperror.dev/errors"
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+// WalkObjectInfos calls fn for every object (including its size) with the given prefix in the bucket.
+func (o *objectStore) WalkObjectInfos(bucketName string, prefix string, fn func(objectstore.ObjectInfo) error) error {
+	marker := ""
+	for {
+		result, err := o.listObjectsWithOptions(bucketName, oss.Prefix(prefix), oss.Marker(marker))
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "error listing object for bucket", "bucket", bucketName, "prefix", prefix)
+		}
+
+		for _, object := range result.Objects {
+			if err := fn(objectstore.ObjectInfo{Key: object.Key, Size: object.Size}); err != nil {
+				return err
+			}
+		}
+
+		if !result.IsTruncated {
+			return nil
+		}
+
+		marker = result.NextMarker
+	}
+}
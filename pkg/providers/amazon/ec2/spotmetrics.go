@@ -137,7 +137,7 @@ func (e *SpotMetricsExporter) addPipelineCreatedInstanceTag(client *ec2.EC2, req
 	}
 
 	if len(i.Tags) == 0 {
-		return request
+		return &SpotInstanceRequest{SpotInstanceRequest: request.SpotInstanceRequest, Instance: i}
 	}
 
 	tags := request.Tags
@@ -148,7 +148,7 @@ func (e *SpotMetricsExporter) addPipelineCreatedInstanceTag(client *ec2.EC2, req
 		}
 	}
 
-	return &SpotInstanceRequest{SpotInstanceRequest: request.SetTags(tags)}
+	return &SpotInstanceRequest{SpotInstanceRequest: request.SetTags(tags), Instance: i}
 }
 
 func (e *SpotMetricsExporter) needsMeasure(request *SpotInstanceRequest, lastRun time.Time) bool {
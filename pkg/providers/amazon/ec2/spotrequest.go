@@ -21,6 +21,9 @@ import (
 // SpotInstanceRequest extends ec2.SpotInstanceRequest
 type SpotInstanceRequest struct {
 	*ec2.SpotInstanceRequest
+
+	// Instance is the instance fulfilling the request (if it could be described)
+	Instance *ec2.Instance
 }
 
 // NewSpotInstanceRequest initialises and gives back a SpotInstanceRequest
@@ -88,3 +91,37 @@ func (r *SpotInstanceRequest) GetStatusCode() string {
 	}
 	return status
 }
+
+// IsInterruptionNotice is true if the instance of the request is about to be interrupted by EC2
+func (r *SpotInstanceRequest) IsInterruptionNotice() bool {
+	switch r.GetStatusCode() {
+	case "marked-for-stop", "marked-for-termination":
+		return true
+	}
+
+	return false
+}
+
+// IsInterrupted is true if the instance of the request has been stopped or terminated by EC2
+func (r *SpotInstanceRequest) IsInterrupted() bool {
+	switch r.GetStatusCode() {
+	case "instance-stopped-by-price", "instance-stopped-no-capacity", "instance-stopped-capacity-oversubscribed", "instance-terminated-by-price", "instance-terminated-no-capacity", "instance-terminated-capacity-oversubscribed", "instance-terminated-launch-group-constraint":
+		return true
+	}
+
+	return false
+}
+
+// IsInstanceTerminated is true if the instance of the request has been stopped or terminated (by EC2 or by the user)
+func (r *SpotInstanceRequest) IsInstanceTerminated() bool {
+	if r.IsInterrupted() {
+		return true
+	}
+
+	switch r.GetStatusCode() {
+	case "instance-stopped-by-user", "instance-terminated-by-user", "instance-terminated-by-schedule", "instance-terminated-by-service":
+		return true
+	}
+
+	return false
+}
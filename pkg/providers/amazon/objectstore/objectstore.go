@@ -48,6 +48,12 @@ type objectStore struct {
 type Config struct {
 	Region string
 	Opts   []Option
+
+	// Endpoint overrides the default S3 endpoint (eg. for S3 compatible object stores).
+	Endpoint string
+
+	// ForcePathStyle uses path style bucket addressing instead of virtual hosted buckets.
+	ForcePathStyle bool
 }
 
 // Credentials represents credentials necessary for access
@@ -64,14 +70,21 @@ func NewPlainObjectStore() (*objectStore, error) {
 
 // New returns an Object Store instance that manages Amazon S3 buckets.
 func New(config Config, credentials Credentials) (*objectStore, error) {
-	sess, err := session.NewSession(&aws.Config{
+	awsConfig := &aws.Config{
 		Region: aws.String(config.Region),
 		Credentials: awsCredentials.NewStaticCredentials(
 			credentials.AccessKeyID,
 			credentials.SecretAccessKey,
 			"",
 		),
-	})
+		S3ForcePathStyle: aws.Bool(config.ForcePathStyle),
+	}
+
+	if config.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(config.Endpoint)
+	}
+
+	sess, err := session.NewSession(awsConfig)
 	if err != nil {
 		return nil, errors.WrapIf(err, "cloud not create AWS session")
 	}
@@ -0,0 +1,141 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"emperror.dev/errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+// SetBucketSettings applies the lifecycle, versioning and encryption settings to the given bucket.
+func (s *objectStore) SetBucketSettings(bucketName string, settings objectstore.BucketSettings) error {
+	versioningStatus := s3.BucketVersioningStatusSuspended
+	if settings.Versioning {
+		versioningStatus = s3.BucketVersioningStatusEnabled
+	}
+
+	_, err := s.client.PutBucketVersioning(&s3.PutBucketVersioningInput{
+		Bucket: aws.String(bucketName),
+		VersioningConfiguration: &s3.VersioningConfiguration{
+			Status: aws.String(versioningStatus),
+		},
+	})
+	if err != nil {
+		err = s.convertError(err)
+		return errors.WrapIfWithDetails(err, "failed to set bucket versioning", "bucket", bucketName)
+	}
+
+	if err := s.setBucketEncryption(bucketName, settings.Encryption); err != nil {
+		return err
+	}
+
+	return s.setBucketLifecycle(bucketName, settings.LifecycleRules)
+}
+
+func (s *objectStore) setBucketEncryption(bucketName string, encryption *objectstore.BucketEncryption) error {
+	if encryption == nil {
+		_, err := s.client.DeleteBucketEncryption(&s3.DeleteBucketEncryptionInput{
+			Bucket: aws.String(bucketName),
+		})
+		if err != nil {
+			err = s.convertError(err)
+			return errors.WrapIfWithDetails(err, "failed to delete bucket encryption", "bucket", bucketName)
+		}
+
+		return nil
+	}
+
+	rule := &s3.ServerSideEncryptionByDefault{
+		SSEAlgorithm: aws.String(s3.ServerSideEncryptionAes256),
+	}
+
+	if encryption.KeyID != "" {
+		rule.SSEAlgorithm = aws.String(s3.ServerSideEncryptionAwsKms)
+		rule.KMSMasterKeyID = aws.String(encryption.KeyID)
+	}
+
+	_, err := s.client.PutBucketEncryption(&s3.PutBucketEncryptionInput{
+		Bucket: aws.String(bucketName),
+		ServerSideEncryptionConfiguration: &s3.ServerSideEncryptionConfiguration{
+			Rules: []*s3.ServerSideEncryptionRule{
+				{ApplyServerSideEncryptionByDefault: rule},
+			},
+		},
+	})
+	if err != nil {
+		err = s.convertError(err)
+		return errors.WrapIfWithDetails(err, "failed to set bucket encryption", "bucket", bucketName)
+	}
+
+	return nil
+}
+
+func (s *objectStore) setBucketLifecycle(bucketName string, rules []objectstore.LifecycleRule) error {
+	if len(rules) == 0 {
+		_, err := s.client.DeleteBucketLifecycle(&s3.DeleteBucketLifecycleInput{
+			Bucket: aws.String(bucketName),
+		})
+		if err != nil {
+			err = s.convertError(err)
+			return errors.WrapIfWithDetails(err, "failed to delete bucket lifecycle", "bucket", bucketName)
+		}
+
+		return nil
+	}
+
+	lifecycleRules := make([]*s3.LifecycleRule, 0, len(rules))
+	for _, rule := range rules {
+		lifecycleRule := &s3.LifecycleRule{
+			ID:     aws.String(rule.ID),
+			Status: aws.String(s3.ExpirationStatusEnabled),
+			Filter: &s3.LifecycleRuleFilter{
+				Prefix: aws.String(rule.Prefix),
+			},
+		}
+
+		if rule.ExpirationDays > 0 {
+			lifecycleRule.Expiration = &s3.LifecycleExpiration{
+				Days: aws.Int64(int64(rule.ExpirationDays)),
+			}
+		}
+
+		if rule.TransitionDays > 0 {
+			lifecycleRule.Transitions = []*s3.Transition{
+				{
+					Days:         aws.Int64(int64(rule.TransitionDays)),
+					StorageClass: aws.String(rule.TransitionStorageClass),
+				},
+			}
+		}
+
+		lifecycleRules = append(lifecycleRules, lifecycleRule)
+	}
+
+	_, err := s.client.PutBucketLifecycleConfiguration(&s3.PutBucketLifecycleConfigurationInput{
+		Bucket: aws.String(bucketName),
+		LifecycleConfiguration: &s3.BucketLifecycleConfiguration{
+			Rules: lifecycleRules,
+		},
+	})
+	if err != nil {
+		err = s.convertError(err)
+		return errors.WrapIfWithDetails(err, "failed to set bucket lifecycle", "bucket", bucketName)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"emperror.dev/errors"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+// WalkObjectInfos calls fn for every object (including its size) with the given prefix in the bucket.
+func (s *objectStore) WalkObjectInfos(bucketName string, prefix string, fn func(objectstore.ObjectInfo) error) error {
+	var fnErr error
+
+	err := s.client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: &bucketName,
+		Prefix: &prefix,
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			var size int64
+			if obj.Size != nil {
+				size = *obj.Size
+			}
+
+			if fnErr = fn(objectstore.ObjectInfo{Key: *obj.Key, Size: size}); fnErr != nil {
+				return false
+			}
+		}
+		return !lastPage
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		err = s.convertError(err)
+		return errors.WrapIfWithDetails(err, "error listing object for bucket", "bucket", bucketName, "prefix", prefix)
+	}
+
+	return nil
+}
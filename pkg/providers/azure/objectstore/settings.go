@@ -0,0 +1,191 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest/to"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+const (
+	storageClassCool    = "Cool"
+	storageClassArchive = "Archive"
+
+	lifecycleRuleType = "Lifecycle"
+	blockBlobType     = "blockBlob"
+)
+
+// lifecycle rule names may only contain alphanumeric characters
+var ruleNameRegexp = regexp.MustCompile("[^a-zA-Z0-9]")
+
+// ValidateBucketSettings checks whether the settings can be applied to Azure blob containers.
+func ValidateBucketSettings(settings objectstore.BucketSettings) error {
+	var err error
+
+	if settings.Versioning {
+		err = errors.Append(err, errors.New("versioning is not supported by azure blob storage"))
+	}
+
+	// blobs are always encrypted, customer managed keys are configured for the whole storage account in Key Vault
+	if settings.Encryption != nil && settings.Encryption.KeyID != "" {
+		err = errors.Append(err, errors.New("customer managed encryption keys are not supported by azure blob storage"))
+	}
+
+	for _, rule := range settings.LifecycleRules {
+		switch rule.TransitionStorageClass {
+		case "", storageClassCool, storageClassArchive:
+		default:
+			err = errors.Append(err, errors.Errorf("lifecycle rule %s: unsupported storage class: %s", rule.ID, rule.TransitionStorageClass))
+		}
+	}
+
+	return err
+}
+
+// SetBucketSettings applies the lifecycle settings to the given container.
+// Lifecycle rules are stored in the management policy of the storage account,
+// rules of other containers in the same storage account are left intact.
+func (o *objectStore) SetBucketSettings(bucketName string, settings objectstore.BucketSettings) error {
+	if err := ValidateBucketSettings(settings); err != nil {
+		return errors.WithDetails(err, "bucket", bucketName)
+	}
+
+	client, err := o.newManagementPoliciesClient()
+	if err != nil {
+		return err
+	}
+
+	policy, err := client.Get(context.TODO(), o.config.ResourceGroup, o.config.StorageAccount)
+	if err != nil && policy.StatusCode != http.StatusNotFound {
+		return errors.WrapIfWithDetails(err, "failed to get management policy", "storage-account", o.config.StorageAccount)
+	}
+
+	var rules []storage.ManagementPolicyRule
+	if policy.ManagementPolicyProperties != nil && policy.Policy != nil && policy.Policy.Rules != nil {
+		for _, rule := range *policy.Policy.Rules {
+			if !isContainerRule(rule, bucketName) {
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	for _, rule := range settings.LifecycleRules {
+		rules = append(rules, newManagementPolicyRule(bucketName, rule))
+	}
+
+	if len(rules) == 0 {
+		if policy.StatusCode == http.StatusNotFound {
+			return nil
+		}
+
+		if _, err := client.Delete(context.TODO(), o.config.ResourceGroup, o.config.StorageAccount); err != nil {
+			return errors.WrapIfWithDetails(err, "failed to delete management policy", "storage-account", o.config.StorageAccount)
+		}
+
+		return nil
+	}
+
+	_, err = client.CreateOrUpdate(context.TODO(), o.config.ResourceGroup, o.config.StorageAccount, storage.ManagementPolicy{
+		ManagementPolicyProperties: &storage.ManagementPolicyProperties{
+			Policy: &storage.ManagementPolicySchema{
+				Rules: &rules,
+			},
+		},
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to update management policy", "storage-account", o.config.StorageAccount, "bucket", bucketName)
+	}
+
+	return nil
+}
+
+func (o *objectStore) newManagementPoliciesClient() (storage.ManagementPoliciesClient, error) {
+	client := storage.NewManagementPoliciesClient(o.credentials.SubscriptionID)
+
+	authorizer, err := NewClientCredentialsConfigFromSecret(o.credentials).Authorizer()
+	if err != nil {
+		return client, errors.WrapIf(err, "failed to authorize")
+	}
+
+	client.Authorizer = authorizer
+
+	return client, nil
+}
+
+// isContainerRule tells whether a management policy rule applies to the given container only.
+func isContainerRule(rule storage.ManagementPolicyRule, containerName string) bool {
+	if rule.Definition == nil || rule.Definition.Filters == nil || rule.Definition.Filters.PrefixMatch == nil {
+		return false
+	}
+
+	prefixes := *rule.Definition.Filters.PrefixMatch
+	if len(prefixes) == 0 {
+		return false
+	}
+
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(prefix, containerName+"/") {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newManagementPolicyRule(containerName string, rule objectstore.LifecycleRule) storage.ManagementPolicyRule {
+	baseBlob := &storage.ManagementPolicyBaseBlob{}
+
+	if rule.ExpirationDays > 0 {
+		baseBlob.Delete = &storage.DateAfterModification{
+			DaysAfterModificationGreaterThan: to.Float64Ptr(float64(rule.ExpirationDays)),
+		}
+	}
+
+	if rule.TransitionDays > 0 {
+		transition := &storage.DateAfterModification{
+			DaysAfterModificationGreaterThan: to.Float64Ptr(float64(rule.TransitionDays)),
+		}
+
+		switch rule.TransitionStorageClass {
+		case storageClassCool:
+			baseBlob.TierToCool = transition
+		case storageClassArchive:
+			baseBlob.TierToArchive = transition
+		}
+	}
+
+	return storage.ManagementPolicyRule{
+		Enabled: to.BoolPtr(true),
+		Name:    to.StringPtr(ruleNameRegexp.ReplaceAllString(containerName+rule.ID, "")),
+		Type:    to.StringPtr(lifecycleRuleType),
+		Definition: &storage.ManagementPolicyDefinition{
+			Actions: &storage.ManagementPolicyAction{
+				BaseBlob: baseBlob,
+			},
+			Filters: &storage.ManagementPolicyFilter{
+				PrefixMatch: &[]string{containerName + "/" + rule.Prefix},
+				BlobTypes:   &[]string{blockBlobType},
+			},
+		},
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"emperror.dev/errors"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+// WalkObjectInfos calls fn for every blob (including its size) with the given prefix in the container.
+func (o *objectStore) WalkObjectInfos(bucketName string, prefix string, fn func(objectstore.ObjectInfo) error) error {
+	p, err := o.createAzurePipeline()
+	if err != nil {
+		return errors.WrapIf(err, "failed to create azure pipeline")
+	}
+
+	URL, err := url.Parse(fmt.Sprintf(containerUrlTemplate, o.config.StorageAccount, bucketName))
+	if err != nil {
+		return err
+	}
+	containerURL := azblob.NewContainerURL(*URL, p)
+
+	for marker := (azblob.Marker{}); marker.NotDone(); {
+		list, err := containerURL.ListBlobsFlatSegment(context.TODO(), marker, azblob.ListBlobsSegmentOptions{
+			Prefix: prefix,
+		})
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "could not list blobs", "container", bucketName, "prefix", prefix)
+		}
+
+		for _, item := range list.Segment.BlobItems {
+			var size int64
+			if item.Properties.ContentLength != nil {
+				size = *item.Properties.ContentLength
+			}
+
+			if err := fn(objectstore.ObjectInfo{Key: item.Name, Size: size}); err != nil {
+				return err
+			}
+		}
+
+		marker = list.NextMarker
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"context"
+
+	"cloud.google.com/go/storage"
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+// ValidateBucketSettings checks whether the settings can be applied to Google Cloud Storage buckets.
+func ValidateBucketSettings(settings objectstore.BucketSettings) error {
+	for _, rule := range settings.LifecycleRules {
+		if rule.Prefix != "" {
+			return errors.Errorf("lifecycle rule %s: prefix is not supported by google cloud storage", rule.ID)
+		}
+	}
+
+	return nil
+}
+
+// SetBucketSettings applies the lifecycle, versioning and encryption settings to the given bucket.
+func (o *objectStore) SetBucketSettings(bucketName string, settings objectstore.BucketSettings) error {
+	if err := ValidateBucketSettings(settings); err != nil {
+		return errors.WithDetails(err, "bucket", bucketName)
+	}
+
+	// objects are always encrypted by Google Cloud Storage, only the key can be changed
+	// an empty key name removes the customer managed key from the bucket
+	var encryption storage.BucketEncryption
+	if settings.Encryption != nil {
+		encryption.DefaultKMSKeyName = settings.Encryption.KeyID
+	}
+
+	lifecycle := storage.Lifecycle{
+		Rules: make([]storage.LifecycleRule, 0, len(settings.LifecycleRules)),
+	}
+
+	for _, rule := range settings.LifecycleRules {
+		if rule.ExpirationDays > 0 {
+			lifecycle.Rules = append(lifecycle.Rules, storage.LifecycleRule{
+				Action: storage.LifecycleAction{
+					Type: storage.DeleteAction,
+				},
+				Condition: storage.LifecycleCondition{
+					AgeInDays: int64(rule.ExpirationDays),
+				},
+			})
+		}
+
+		if rule.TransitionDays > 0 {
+			lifecycle.Rules = append(lifecycle.Rules, storage.LifecycleRule{
+				Action: storage.LifecycleAction{
+					Type:         storage.SetStorageClassAction,
+					StorageClass: rule.TransitionStorageClass,
+				},
+				Condition: storage.LifecycleCondition{
+					AgeInDays: int64(rule.TransitionDays),
+				},
+			})
+		}
+	}
+
+	_, err := o.client.Bucket(bucketName).Update(context.Background(), storage.BucketAttrsToUpdate{
+		VersioningEnabled: settings.Versioning,
+		Encryption:        &encryption,
+		Lifecycle:         &lifecycle,
+	})
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "failed to update bucket", "bucket", bucketName)
+	}
+
+	return nil
+}
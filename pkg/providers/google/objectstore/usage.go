@@ -0,0 +1,46 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"context"
+
+	"cloud.google.com/go/storage"
+	"emperror.dev/errors"
+	"google.golang.org/api/iterator"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+// WalkObjectInfos calls fn for every object (including its size) with the given prefix in the bucket.
+func (o *objectStore) WalkObjectInfos(bucketName string, prefix string, fn func(objectstore.ObjectInfo) error) error {
+	iter := o.client.Bucket(bucketName).Objects(context.Background(), &storage.Query{
+		Prefix: prefix,
+	})
+
+	for {
+		object, err := iter.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return errors.WrapIfWithDetails(o.convertBucketError(err, bucketName), "could not list objects", "prefix", prefix)
+		}
+
+		if err := fn(objectstore.ObjectInfo{Key: object.Name, Size: object.Size}); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"emperror.dev/errors"
+	"github.com/oracle/oci-go-sdk/common"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+	"github.com/banzaicloud/pipeline/pkg/providers/oracle/oci"
+)
+
+const (
+	storageClassArchive = "Archive"
+
+	lifecycleTimeUnitDays = "DAYS"
+)
+
+// ValidateBucketSettings checks whether the settings can be applied to Oracle object storage buckets.
+func ValidateBucketSettings(settings objectstore.BucketSettings) error {
+	for _, rule := range settings.LifecycleRules {
+		if rule.TransitionStorageClass != "" && rule.TransitionStorageClass != storageClassArchive {
+			return errors.Errorf("lifecycle rule %s: unsupported storage class: %s", rule.ID, rule.TransitionStorageClass)
+		}
+	}
+
+	return nil
+}
+
+// SetBucketSettings applies the lifecycle, versioning and encryption settings to the given bucket.
+func (o *objectStore) SetBucketSettings(bucketName string, settings objectstore.BucketSettings) error {
+	if err := ValidateBucketSettings(settings); err != nil {
+		return errors.WithDetails(err, "bucket", bucketName)
+	}
+
+	current, err := o.osClient.GetBucketSettings(bucketName)
+	if err != nil {
+		return errors.WrapIf(o.convertBucketError(err, bucketName), "could not get bucket settings")
+	}
+
+	// objects are always encrypted, an empty key removes the customer managed key from the bucket
+	bucketSettings := oci.BucketSettings{
+		KmsKeyID: common.String(""),
+	}
+
+	if settings.Encryption != nil {
+		bucketSettings.KmsKeyID = common.String(settings.Encryption.KeyID)
+	}
+
+	// versioning can only be suspended once it was enabled
+	if settings.Versioning {
+		bucketSettings.Versioning = common.String(oci.BucketVersioningEnabled)
+	} else if current.Versioning != nil && *current.Versioning == oci.BucketVersioningEnabled {
+		bucketSettings.Versioning = common.String(oci.BucketVersioningSuspended)
+	}
+
+	if err := o.osClient.UpdateBucketSettings(bucketName, bucketSettings); err != nil {
+		return errors.WrapIf(o.convertBucketError(err, bucketName), "could not update bucket settings")
+	}
+
+	if len(settings.LifecycleRules) == 0 {
+		if err := o.osClient.DeleteObjectLifecyclePolicy(bucketName); err != nil {
+			return errors.WrapIf(o.convertBucketError(err, bucketName), "could not delete bucket lifecycle policy")
+		}
+
+		return nil
+	}
+
+	rules := make([]oci.ObjectLifecycleRule, 0, len(settings.LifecycleRules))
+	for _, rule := range settings.LifecycleRules {
+		var filter oci.ObjectLifecycleNameFilter
+		if rule.Prefix != "" {
+			filter.InclusionPrefixes = []string{rule.Prefix}
+		}
+
+		if rule.TransitionDays > 0 {
+			rules = append(rules, oci.ObjectLifecycleRule{
+				Name:             rule.ID + "-archive",
+				Action:           oci.LifecycleActionArchive,
+				TimeAmount:       int64(rule.TransitionDays),
+				TimeUnit:         lifecycleTimeUnitDays,
+				IsEnabled:        true,
+				ObjectNameFilter: filter,
+			})
+		}
+
+		if rule.ExpirationDays > 0 {
+			rules = append(rules, oci.ObjectLifecycleRule{
+				Name:             rule.ID + "-delete",
+				Action:           oci.LifecycleActionDelete,
+				TimeAmount:       int64(rule.ExpirationDays),
+				TimeUnit:         lifecycleTimeUnitDays,
+				IsEnabled:        true,
+				ObjectNameFilter: filter,
+			})
+		}
+	}
+
+	if err := o.osClient.PutObjectLifecyclePolicy(bucketName, rules); err != nil {
+		return errors.WrapIf(o.convertBucketError(err, bucketName), "could not set bucket lifecycle policy")
+	}
+
+	return nil
+}
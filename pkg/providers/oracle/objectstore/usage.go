@@ -0,0 +1,46 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"emperror.dev/errors"
+	"github.com/oracle/oci-go-sdk/objectstorage"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
+// WalkObjectInfos calls fn for every object (including its size) with the given prefix in the bucket.
+func (o *objectStore) WalkObjectInfos(bucketName string, prefix string, fn func(objectstore.ObjectInfo) error) error {
+	var fnErr error
+
+	err := o.osClient.WalkObjectSummaries(bucketName, prefix, func(summary objectstorage.ObjectSummary) bool {
+		var size int64
+		if summary.Size != nil {
+			size = *summary.Size
+		}
+
+		fnErr = fn(objectstore.ObjectInfo{Key: *summary.Name, Size: size})
+
+		return fnErr == nil
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+	if err != nil {
+		return errors.WrapIfWithDetails(o.convertBucketError(err, bucketName), "could not list objects", "prefix", prefix)
+	}
+
+	return nil
+}
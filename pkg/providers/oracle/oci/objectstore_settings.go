@@ -0,0 +1,157 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/oracle/oci-go-sdk/common"
+)
+
+// Bucket versioning states
+const (
+	BucketVersioningEnabled   = "Enabled"
+	BucketVersioningSuspended = "Suspended"
+	BucketVersioningDisabled  = "Disabled"
+)
+
+// Object lifecycle actions
+const (
+	LifecycleActionArchive = "ARCHIVE"
+	LifecycleActionDelete  = "DELETE"
+)
+
+// The requests below are not part of the SDK version in use, they are built from the Object Storage API reference.
+
+// BucketSettings holds the versioning and encryption settings of a bucket.
+type BucketSettings struct {
+	// KmsKeyID is the OCID of the master encryption key, Oracle managed keys are used when empty.
+	KmsKeyID *string `mandatory:"false" json:"kmsKeyId"`
+
+	Versioning *string `mandatory:"false" json:"versioning"`
+}
+
+type bucketRequest struct {
+	NamespaceName *string `mandatory:"true" contributesTo:"path" name:"namespaceName"`
+	BucketName    *string `mandatory:"true" contributesTo:"path" name:"bucketName"`
+}
+
+type bucketSettingsRequest struct {
+	NamespaceName *string `mandatory:"true" contributesTo:"path" name:"namespaceName"`
+	BucketName    *string `mandatory:"true" contributesTo:"path" name:"bucketName"`
+
+	BucketSettings `contributesTo:"body"`
+}
+
+type bucketSettingsResponse struct {
+	RawResponse *http.Response
+
+	BucketSettings `presentIn:"body"`
+}
+
+// ObjectLifecycleRule describes an action on objects older than the given number of days.
+type ObjectLifecycleRule struct {
+	Name             string                    `mandatory:"true" json:"name"`
+	Action           string                    `mandatory:"true" json:"action"`
+	TimeAmount       int64                     `mandatory:"true" json:"timeAmount"`
+	TimeUnit         string                    `mandatory:"true" json:"timeUnit"`
+	IsEnabled        bool                      `mandatory:"true" json:"isEnabled"`
+	ObjectNameFilter ObjectLifecycleNameFilter `mandatory:"true" json:"objectNameFilter"`
+}
+
+// ObjectLifecycleNameFilter limits lifecycle rules to objects with the given name prefixes.
+type ObjectLifecycleNameFilter struct {
+	InclusionPrefixes []string `mandatory:"false" json:"inclusionPrefixes"`
+}
+
+type objectLifecyclePolicyDetails struct {
+	Items []ObjectLifecycleRule `mandatory:"true" json:"items"`
+}
+
+type objectLifecyclePolicyRequest struct {
+	NamespaceName *string `mandatory:"true" contributesTo:"path" name:"namespaceName"`
+	BucketName    *string `mandatory:"true" contributesTo:"path" name:"bucketName"`
+
+	Details objectLifecyclePolicyDetails `contributesTo:"body"`
+}
+
+// GetBucketSettings gets the versioning and encryption settings of a bucket.
+func (os *ObjectStorage) GetBucketSettings(name string) (settings BucketSettings, err error) {
+	request := bucketRequest{
+		NamespaceName: &os.Namespace,
+		BucketName:    &name,
+	}
+
+	var response bucketSettingsResponse
+	err = os.call(http.MethodGet, "/n/{namespaceName}/b/{bucketName}/", request, &response)
+
+	return response.BucketSettings, err
+}
+
+// UpdateBucketSettings updates the versioning and encryption settings of a bucket.
+// Settings left nil are not changed.
+func (os *ObjectStorage) UpdateBucketSettings(name string, settings BucketSettings) error {
+	request := bucketSettingsRequest{
+		NamespaceName:  &os.Namespace,
+		BucketName:     &name,
+		BucketSettings: settings,
+	}
+
+	return os.call(http.MethodPost, "/n/{namespaceName}/b/{bucketName}/", request, nil)
+}
+
+// PutObjectLifecyclePolicy replaces the object lifecycle policy of a bucket.
+func (os *ObjectStorage) PutObjectLifecyclePolicy(name string, rules []ObjectLifecycleRule) error {
+	request := objectLifecyclePolicyRequest{
+		NamespaceName: &os.Namespace,
+		BucketName:    &name,
+		Details: objectLifecyclePolicyDetails{
+			Items: rules,
+		},
+	}
+
+	return os.call(http.MethodPut, "/n/{namespaceName}/b/{bucketName}/l", request, nil)
+}
+
+// DeleteObjectLifecyclePolicy deletes the object lifecycle policy of a bucket.
+func (os *ObjectStorage) DeleteObjectLifecyclePolicy(name string) error {
+	request := bucketRequest{
+		NamespaceName: &os.Namespace,
+		BucketName:    &name,
+	}
+
+	return os.call(http.MethodDelete, "/n/{namespaceName}/b/{bucketName}/l", request, nil)
+}
+
+// call sends a signed request built from the tagged request struct and unmarshals the response (if any).
+func (os *ObjectStorage) call(method string, path string, request interface{}, response interface{}) error {
+	httpRequest, err := common.MakeDefaultHTTPRequestWithTaggedStruct(method, path, request)
+	if err != nil {
+		return err
+	}
+
+	httpResponse, err := os.client.Call(context.Background(), &httpRequest)
+	defer common.CloseBodyIfValid(httpResponse)
+	if err != nil {
+		return err
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	return common.UnmarshalResponse(httpResponse, response)
+}
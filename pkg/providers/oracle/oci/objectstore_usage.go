@@ -0,0 +1,55 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+
+	"github.com/oracle/oci-go-sdk/objectstorage"
+)
+
+// objectSummaryFields are the fields requested when listing object summaries
+const objectSummaryFields = "name,size"
+
+// WalkObjectSummaries calls fn with the name and the size of every object with the given prefix in the bucket
+// until it returns false
+func (os *ObjectStorage) WalkObjectSummaries(bucket, prefix string, fn func(objectstorage.ObjectSummary) bool) error {
+	fields := objectSummaryFields
+	request := objectstorage.ListObjectsRequest{
+		NamespaceName: &os.Namespace,
+		BucketName:    &bucket,
+		Prefix:        &prefix,
+		Fields:        &fields,
+	}
+
+	for {
+		response, err := os.client.ListObjects(context.Background(), request)
+		if err != nil {
+			return err
+		}
+
+		for _, object := range response.Objects {
+			if !fn(object) {
+				return nil
+			}
+		}
+
+		if response.NextStartWith == nil {
+			return nil
+		}
+
+		request.Start = response.NextStartWith
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/banzaicloud/pipeline/pkg/providers/azure"
 	"github.com/banzaicloud/pipeline/pkg/providers/google"
 	"github.com/banzaicloud/pipeline/pkg/providers/oracle"
+	"github.com/banzaicloud/pipeline/pkg/providers/s3compatible"
 )
 
 const (
@@ -30,6 +31,8 @@ const (
 	Google  = google.Provider
 	Oracle  = oracle.Provider
 
+	S3Compatible = s3compatible.Provider
+
 	BucketCreating    = "CREATING"
 	BucketCreated     = "AVAILABLE"
 	BucketCreateError = "ERROR_CREATE"
@@ -48,6 +51,7 @@ func ValidateProvider(provider string) error {
 	case Google:
 	case Azure:
 	case Oracle:
+	case S3Compatible:
 	default:
 		// TODO: create an error value in this package instead
 		return pkgErrors.ErrorNotSupportedCloudType
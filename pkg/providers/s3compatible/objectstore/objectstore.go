@@ -0,0 +1,92 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+	amazonObjectstore "github.com/banzaicloud/pipeline/pkg/providers/amazon/objectstore"
+)
+
+// DefaultRegion is used when the object store is not region aware (eg. MinIO).
+const DefaultRegion = "us-east-1"
+
+// s3ObjectStore is the S3 client implementation the object store delegates to.
+type s3ObjectStore interface {
+	objectstore.ObjectStore
+	objectstore.BucketSettingsManager
+	objectstore.ObjectInfoWalker
+}
+
+type objectStore struct {
+	s3ObjectStore
+}
+
+// Config defines configuration
+type Config struct {
+	// Endpoint is the URL of the S3 compatible object store.
+	Endpoint string
+	Region   string
+
+	// ForcePathStyle uses path style bucket addressing (most S3 compatible object stores require it).
+	ForcePathStyle bool
+
+	Opts []amazonObjectstore.Option
+}
+
+// Credentials represents credentials necessary for access
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// NewPlainObjectStore creates an objectstore with no configuration.
+// Instances created with this function may be used to access methods that don't explicitly access external resources
+func NewPlainObjectStore() (*objectStore, error) {
+	return &objectStore{}, nil
+}
+
+// New returns an Object Store instance that manages buckets on an S3 compatible object store (eg. MinIO).
+func New(config Config, credentials Credentials) (*objectStore, error) {
+	if config.Endpoint == "" {
+		return nil, errors.New("endpoint is required for S3 compatible object stores")
+	}
+
+	region := config.Region
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	s3, err := amazonObjectstore.New(
+		amazonObjectstore.Config{
+			Region:         region,
+			Endpoint:       config.Endpoint,
+			ForcePathStyle: config.ForcePathStyle,
+			Opts:           config.Opts,
+		},
+		amazonObjectstore.Credentials{
+			AccessKeyID:     credentials.AccessKeyID,
+			SecretAccessKey: credentials.SecretAccessKey,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &objectStore{
+		s3ObjectStore: s3,
+	}, nil
+}
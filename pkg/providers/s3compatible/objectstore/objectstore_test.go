@@ -0,0 +1,151 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package objectstore
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	amazonObjectstore "github.com/banzaicloud/pipeline/pkg/providers/amazon/objectstore"
+)
+
+// The integration tests run against a local MinIO server, eg.:
+//
+//     docker run -p 9000:9000 -e MINIO_ACCESS_KEY=minio -e MINIO_SECRET_KEY=minio123 minio/minio server /data
+//
+//     S3_ENDPOINT=http://localhost:9000 S3_ACCESS_KEY=minio S3_SECRET_KEY=minio123 go test -run ^TestIntegration$
+
+func getObjectStore(t *testing.T) *objectStore {
+	t.Helper()
+
+	endpoint := strings.TrimSpace(os.Getenv("S3_ENDPOINT"))
+	accessKey := strings.TrimSpace(os.Getenv("S3_ACCESS_KEY"))
+	secretKey := strings.TrimSpace(os.Getenv("S3_SECRET_KEY"))
+
+	if endpoint == "" || accessKey == "" || secretKey == "" {
+		t.Skip("missing endpoint or credentials")
+	}
+
+	config := Config{
+		Endpoint:       endpoint,
+		Region:         strings.TrimSpace(os.Getenv("S3_REGION")),
+		ForcePathStyle: true,
+		Opts: []amazonObjectstore.Option{
+			amazonObjectstore.WaitForCompletion(true),
+		},
+	}
+
+	credentials := Credentials{
+		AccessKeyID:     accessKey,
+		SecretAccessKey: secretKey,
+	}
+
+	s, err := New(config, credentials)
+	if err != nil {
+		t.Fatal("could not create object storage client: ", err.Error())
+	}
+
+	return s
+}
+
+func getBucketName(t *testing.T) string {
+	t.Helper()
+
+	return fmt.Sprintf("banzaicloud-test-bucket-%d", time.Now().UnixNano())
+}
+
+func TestNew_MissingEndpoint(t *testing.T) {
+	_, err := New(Config{}, Credentials{AccessKeyID: "minio", SecretAccessKey: "minio123"})
+
+	assert.Error(t, err)
+}
+
+func TestIntegration(t *testing.T) {
+	if m := flag.Lookup("test.run").Value.String(); m == "" || !regexp.MustCompile(m).MatchString(t.Name()) {
+		t.Skip("skipping as execution was not requested explicitly using go test -run")
+	}
+
+	t.Run("ObjectStore_BucketLifecycle", testObjectStoreBucketLifecycle)
+	t.Run("ObjectStore_GetPutDeleteObject", testObjectStoreGetPutDeleteObject)
+	t.Run("ObjectStore_BucketNotFound", testObjectStoreBucketNotFound)
+}
+
+func testObjectStoreBucketLifecycle(t *testing.T) {
+	s := getObjectStore(t)
+
+	bucketName := getBucketName(t)
+
+	require.NoError(t, s.CreateBucket(bucketName))
+	assert.NoError(t, s.CheckBucket(bucketName))
+
+	buckets, err := s.ListBuckets()
+	require.NoError(t, err)
+	assert.Contains(t, buckets, bucketName)
+
+	require.NoError(t, s.DeleteBucket(bucketName))
+	assert.Error(t, s.CheckBucket(bucketName))
+}
+
+func testObjectStoreGetPutDeleteObject(t *testing.T) {
+	s := getObjectStore(t)
+
+	bucketName := getBucketName(t)
+
+	require.NoError(t, s.CreateBucket(bucketName))
+	defer func() {
+		assert.NoError(t, s.DeleteBucket(bucketName))
+	}()
+
+	const key = "backups/test.txt"
+	content := []byte("test content")
+
+	require.NoError(t, s.PutObject(bucketName, key, bytes.NewReader(content)))
+
+	keys, err := s.ListObjectsWithPrefix(bucketName, "backups/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{key}, keys)
+
+	body, err := s.GetObject(bucketName, key)
+	require.NoError(t, err)
+	defer body.Close()
+
+	actual, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, content, actual)
+
+	url, err := s.GetSignedURL(bucketName, key, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(url, strings.TrimSpace(os.Getenv("S3_ENDPOINT"))))
+
+	assert.NoError(t, s.DeleteObject(bucketName, key))
+}
+
+func testObjectStoreBucketNotFound(t *testing.T) {
+	s := getObjectStore(t)
+
+	err := s.CheckBucket(getBucketName(t))
+
+	assert.Error(t, err)
+}
@@ -15,6 +15,7 @@
 package security
 
 import (
+	"strings"
 	"time"
 )
 
@@ -120,9 +121,83 @@ type WhitelistItem struct {
 	TriggerId string `json:"trigger_id"`
 }
 
+// Whitelist item statuses.
+const (
+	WhiteListItemStatusActive  = "active"
+	WhiteListItemStatusPending = "pending"
+)
+
 type ReleaseWhiteListItem struct {
 	Name   string `json:"name" binding:"required"`
 	Owner  string `json:"owner" binding:"required"`
 	Reason string `json:"reason"`
 	Regexp string `json:"regexp,omitempty"`
+
+	// ExpiresAt is the time after which the item is removed from the cluster (optional).
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// Status and ApprovedBy are set by Pipeline.
+	Status     string `json:"status,omitempty"`
+	ApprovedBy string `json:"approvedBy,omitempty"`
+}
+
+// Expired tells whether the whitelist item is expired at the given time.
+func (i ReleaseWhiteListItem) Expired(t time.Time) bool {
+	return i.ExpiresAt != nil && !i.ExpiresAt.After(t)
+}
+
+// Image scanners supported by the security scan.
+const (
+	ScannerAnchore = "anchore"
+	ScannerTrivy   = "trivy"
+)
+
+// Normalized vulnerability severities.
+const (
+	SeverityCritical   = "Critical"
+	SeverityHigh       = "High"
+	SeverityMedium     = "Medium"
+	SeverityLow        = "Low"
+	SeverityNegligible = "Negligible"
+	SeverityUnknown    = "Unknown"
+)
+
+// ScanLog is the result of an admission time image scan regardless of the scanner used.
+type ScanLog struct {
+	Scanner     string         `json:"scanner"`
+	ReleaseName string         `json:"releaseName"`
+	Resource    string         `json:"resource"`
+	Images      []ScannedImage `json:"image"`
+	Result      []string       `json:"result"`
+	Action      string         `json:"action"`
+}
+
+// ScannedImage is an image checked by the scanner.
+type ScannedImage struct {
+	ImageName       string          `json:"imageName"`
+	ImageTag        string          `json:"imageTag"`
+	ImageDigest     string          `json:"imageDigest"`
+	LastUpdated     string          `json:"lastUpdated"`
+	Vulnerabilities []Vulnerability `json:"vulnerabilities,omitempty"`
+}
+
+// Vulnerability is a known vulnerability of a package found in an image.
+type Vulnerability struct {
+	ID           string `json:"id"`
+	Severity     string `json:"severity"`
+	Package      string `json:"package"`
+	Version      string `json:"version"`
+	FixedVersion string `json:"fixedVersion,omitempty"`
+	URL          string `json:"url,omitempty"`
+}
+
+// NormalizeSeverity converts scanner specific severities (eg. HIGH, high) to the normalized ones.
+func NormalizeSeverity(severity string) string {
+	for _, s := range []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityNegligible} {
+		if strings.EqualFold(severity, s) {
+			return s
+		}
+	}
+
+	return SeverityUnknown
 }
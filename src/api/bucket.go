@@ -33,6 +33,7 @@ import (
 	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
 	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
 	pkgErrors "github.com/banzaicloud/pipeline/pkg/errors"
+	commonObjectstore "github.com/banzaicloud/pipeline/pkg/objectstore"
 	pkgProviders "github.com/banzaicloud/pipeline/pkg/providers"
 	"github.com/banzaicloud/pipeline/src/auth"
 	"github.com/banzaicloud/pipeline/src/secret"
@@ -55,16 +56,18 @@ type secretData struct {
 // BucketResponseItem encapsulates bucket and secret details to be returned
 // it's purpose is to properly format the response details - especially the secret details
 type BucketResponseItem struct {
-	Name       string                                 `json:"name"  binding:"required"`
-	Managed    bool                                   `json:"managed" binding:"required"`
-	Location   string                                 `json:"location,omitempty"`
-	Cloud      string                                 `json:"cloud,omitempty"`
-	Notes      *string                                `json:"notes,omitempty"`
-	SecretInfo *secretData                            `json:"secret"`
-	Azure      *objectstore.BlobStoragePropsForAzure  `json:"aks,omitempty"`
-	Oracle     *objectstore.BlobStoragePropsForOracle `json:"oracle,omitempty"`
-	Status     string                                 `json:"status"`
-	StatusMsg  string                                 `json:"statusMessage"`
+	Name         string                                       `json:"name"  binding:"required"`
+	Managed      bool                                         `json:"managed" binding:"required"`
+	Location     string                                       `json:"location,omitempty"`
+	Cloud        string                                       `json:"cloud,omitempty"`
+	Notes        *string                                      `json:"notes,omitempty"`
+	SecretInfo   *secretData                                  `json:"secret"`
+	Azure        *objectstore.BlobStoragePropsForAzure        `json:"aks,omitempty"`
+	Oracle       *objectstore.BlobStoragePropsForOracle       `json:"oracle,omitempty"`
+	S3Compatible *objectstore.BlobStoragePropsForS3Compatible `json:"s3compatible,omitempty"`
+	Status       string                                       `json:"status"`
+	StatusMsg    string                                       `json:"statusMessage"`
+	Settings     *commonObjectstore.BucketSettings            `json:"settings,omitempty"`
 }
 
 // ListAllBuckets handles 	bucket list requests. The handler method directs the flow to the appropriate retrieval
@@ -146,6 +149,7 @@ func ListManagedBuckets(c *gin.Context) {
 		pkgProviders.Azure,
 		pkgProviders.Google,
 		pkgProviders.Oracle,
+		pkgProviders.S3Compatible,
 	}
 
 	const (
@@ -246,6 +250,18 @@ func CreateBucket(c *gin.Context) {
 		"bucket":   createBucketRequest.Name,
 	})
 
+	if createBucketRequest.Settings != nil {
+		if err := providers.ValidateBucketSettings(cloudType, *createBucketRequest.Settings); err != nil {
+			c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid bucket settings",
+				Error:   err.Error(),
+			})
+
+			return
+		}
+	}
+
 	logger.Debug("validating secret")
 	retrievedSecret, err = getValidatedSecret(organization.ID, createBucketRequest.SecretId, cloudType)
 	if err != nil {
@@ -300,9 +316,11 @@ func CreateBucket(c *gin.Context) {
 	go func() {
 		defer emperror.HandleRecover(errorHandler)
 
-		err := objectStore.CreateBucket(createBucketRequest.Name)
+		err := objectStore.CreateBucket(createBucketRequest.Name, createBucketRequest.Settings)
 		if err != nil {
 			errorHandler.Handle(err)
+
+			return
 		}
 	}()
 
@@ -464,6 +482,106 @@ func DeleteBucket(c *gin.Context) {
 	logger.Info("object store bucket deleted")
 }
 
+// UpdateBucket applies lifecycle, versioning and encryption settings to object storage buckets
+// (object storage container in case of Azure) that can be accessed with the credentials from the given secret
+func UpdateBucket(c *gin.Context) {
+	logger := correlationid.Logger(log, c)
+
+	bucketName := c.Param("name")
+	logger = logger.WithField("bucket", bucketName)
+
+	organization, secretItem, cloudType, ok := getBucketContext(c, logger)
+	if !ok {
+		return
+	}
+
+	logger = logger.WithFields(logrus.Fields{
+		"organization": organization.ID,
+		"secret":       secretItem.ID,
+		"provider":     cloudType,
+	})
+
+	var settings commonObjectstore.BucketSettings
+	if err := c.BindJSON(&settings); err != nil {
+		logger.Error(errors.Wrap(err, "Error parsing request"))
+
+		c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "Error parsing request",
+			Error:   err.Error(),
+		})
+
+		return
+	}
+
+	if err := providers.ValidateBucketSettings(cloudType, settings); err != nil {
+		c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid bucket settings",
+			Error:   err.Error(),
+		})
+
+		return
+	}
+
+	logger.Infof("updating object store bucket")
+
+	objectStoreCtx := &providers.ObjectStoreContext{
+		Provider:     cloudType,
+		Secret:       secretItem,
+		Organization: organization,
+	}
+
+	switch cloudType {
+	case pkgProviders.Oracle:
+		location, ok := ginutils.RequiredQueryOrAbort(c, "location")
+		if !ok {
+			logger.Debug("missing location")
+
+			return
+		}
+
+		objectStoreCtx.Location = location
+
+	case pkgProviders.Azure:
+		resourceGroup, ok := ginutils.RequiredQueryOrAbort(c, "resourceGroup")
+		if !ok {
+			logger.Debug("missing resource group")
+
+			return
+		}
+
+		storageAccount, ok := ginutils.RequiredQueryOrAbort(c, "storageAccount")
+		if !ok {
+			logger.Debug("missing storage account")
+
+			return
+		}
+
+		objectStoreCtx.ResourceGroup = resourceGroup
+		objectStoreCtx.StorageAccount = storageAccount
+	}
+
+	objectStore, err := providers.NewObjectStore(objectStoreCtx, logger)
+	if err != nil {
+		errorHandler.Handle(err)
+		ginutils.ReplyWithErrorResponse(c, ErrorResponseFrom(err))
+
+		return
+	}
+
+	if err = objectStore.UpdateBucket(bucketName, settings); err != nil {
+		errorHandler.Handle(err)
+		ginutils.ReplyWithErrorResponse(c, ErrorResponseFrom(err))
+
+		return
+	}
+
+	logger.Info("object store bucket updated")
+
+	c.Status(http.StatusNoContent)
+}
+
 // hasSecret checks the header for secret references, returns true in case one of the following headers are found:
 // - secretName
 // - secretId
@@ -562,6 +680,9 @@ func determineCloudProviderFromRequest(req CreateBucketRequest, cloudType string
 	if req.Properties.Oracle != nil && cloudType == pkgCluster.Oracle {
 		return pkgCluster.Oracle, nil
 	}
+	if req.Properties.S3Compatible != nil && cloudType == pkgProviders.S3Compatible {
+		return pkgProviders.S3Compatible, nil
+	}
 	return "", pkgErrors.ErrorMissingCloudSpecificProperties
 }
 
@@ -708,15 +829,17 @@ func newBucketResponseItemFromBucketInfo(bi *objectstore.BucketInfo, orgid uint,
 	}
 
 	ret := BucketResponseItem{
-		Name:      bi.Name,
-		Status:    bi.Status,
-		StatusMsg: bi.StatusMsg,
-		Location:  bi.Location,
-		Cloud:     bi.Cloud,
-		Managed:   bi.Managed,
-		Notes:     &notes,
-		Azure:     bi.Azure,
-		Oracle:    bi.Oracle,
+		Name:         bi.Name,
+		Status:       bi.Status,
+		StatusMsg:    bi.StatusMsg,
+		Location:     bi.Location,
+		Cloud:        bi.Cloud,
+		Managed:      bi.Managed,
+		Notes:        &notes,
+		Azure:        bi.Azure,
+		Oracle:       bi.Oracle,
+		Settings:     bi.Settings,
+		S3Compatible: bi.S3Compatible,
 		SecretInfo: &secretData{
 			SecretName:       secretName,
 			SecretId:         bi.SecretRef,
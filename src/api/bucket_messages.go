@@ -14,18 +14,24 @@
 
 package api
 
+import (
+	"github.com/banzaicloud/pipeline/pkg/objectstore"
+)
+
 // CreateBucketRequest to create bucket
 type CreateBucketRequest struct {
 	SecretId   string `json:"secretId"`
 	SecretName string `json:"secretName"`
 	Name       string `json:"name" binding:"required"`
 	Properties struct {
-		Alibaba *CreateAlibabaObjectStoreBucketProperties `json:"alibaba,omitempty"`
-		Amazon  *CreateAmazonObjectStoreBucketProperties  `json:"amazon,omitempty"`
-		Azure   *CreateAzureObjectStoreBucketProperties   `json:"azure,omitempty"`
-		Google  *CreateGoogleObjectStoreBucketProperties  `json:"google,omitempty"`
-		Oracle  *CreateObjectStoreBucketProperties        `json:"oracle,omitempty"`
+		Alibaba      *CreateAlibabaObjectStoreBucketProperties      `json:"alibaba,omitempty"`
+		Amazon       *CreateAmazonObjectStoreBucketProperties       `json:"amazon,omitempty"`
+		Azure        *CreateAzureObjectStoreBucketProperties        `json:"azure,omitempty"`
+		Google       *CreateGoogleObjectStoreBucketProperties       `json:"google,omitempty"`
+		Oracle       *CreateObjectStoreBucketProperties             `json:"oracle,omitempty"`
+		S3Compatible *CreateS3CompatibleObjectStoreBucketProperties `json:"s3compatible,omitempty"`
 	} `json:"properties" binding:"required"`
+	Settings *objectstore.BucketSettings `json:"settings,omitempty"`
 }
 
 // CreateAlibabaObjectStoreBucketProperties describes the properties of
@@ -57,6 +63,10 @@ type CreateObjectStoreBucketProperties struct {
 	Location string `json:"location" binding:"required"`
 }
 
+// CreateS3CompatibleObjectStoreBucketProperties describes S3 compatible Object Store Bucket creation request
+// The endpoint and the region of the object store are taken from the secret.
+type CreateS3CompatibleObjectStoreBucketProperties struct{}
+
 // CreateBucketResponse describes a storage bucket creation response
 type CreateBucketResponse struct {
 	BucketName string `json:"name"`
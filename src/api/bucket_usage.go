@@ -0,0 +1,96 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"emperror.dev/emperror"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/internal/objectstore/bucketusage"
+	"github.com/banzaicloud/pipeline/internal/platform/gin/correlationid"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// BucketUsageAPI reports the storage used by managed buckets.
+type BucketUsageAPI struct {
+	service      bucketusage.Service
+	logger       logrus.FieldLogger
+	errorHandler emperror.Handler
+}
+
+// NewBucketUsageAPI returns a new BucketUsageAPI instance.
+func NewBucketUsageAPI(service bucketusage.Service, logger logrus.FieldLogger, errorHandler emperror.Handler) BucketUsageAPI {
+	return BucketUsageAPI{
+		service:      service,
+		logger:       logger,
+		errorHandler: errorHandler,
+	}
+}
+
+// GetBucketUsage returns the object count and total size of every managed bucket of the organization
+// as of the last periodic collection, the clusters referencing them and whether they are orphaned.
+func (a BucketUsageAPI) GetBucketUsage(c *gin.Context) {
+	logger := correlationid.Logger(a.logger, c)
+	organization := auth.GetCurrentOrganization(c.Request)
+
+	var options bucketusage.Options
+
+	if prefixDepth := c.Query("prefixDepth"); prefixDepth != "" {
+		depth, err := strconv.Atoi(prefixDepth)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "invalid prefix depth",
+				Error:   err.Error(),
+			})
+
+			return
+		}
+
+		options.PrefixDepth = depth
+	}
+
+	if err := options.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "invalid prefix depth",
+			Error:   err.Error(),
+		})
+
+		return
+	}
+
+	logger.WithField("organization", organization.ID).Debug("getting bucket usage")
+
+	usages, err := a.service.GetBucketUsage(c.Request.Context(), organization.ID, options)
+	if err != nil {
+		a.errorHandler.Handle(err)
+
+		c.JSON(http.StatusInternalServerError, pkgCommon.ErrorResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "failed to get bucket usage",
+			Error:   err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, usages)
+}
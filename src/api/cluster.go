@@ -31,9 +31,11 @@ import (
 
 	"github.com/banzaicloud/pipeline/internal/cluster/clusteradapter"
 	eksdriver "github.com/banzaicloud/pipeline/internal/cluster/distribution/eks/eksprovider/driver"
+	"github.com/banzaicloud/pipeline/internal/cluster/kubeproxy"
 	"github.com/banzaicloud/pipeline/internal/cluster/resourcesummary"
 	"github.com/banzaicloud/pipeline/internal/global"
 	azureDriver "github.com/banzaicloud/pipeline/internal/providers/azure/pke/driver"
+	baremetalDriver "github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/driver"
 	vsphereDriver "github.com/banzaicloud/pipeline/internal/providers/vsphere/pke/driver"
 	"github.com/banzaicloud/pipeline/internal/secret/restricted"
 	"github.com/banzaicloud/pipeline/pkg/cloudinfo"
@@ -56,6 +58,7 @@ type ClusterAPI struct {
 	externalBaseURLInsecure bool
 	workflowClient          client.Client
 	clientFactory           common.DynamicClientFactory
+	proxyAccess             kubeproxy.AccessController
 
 	logger          logrus.FieldLogger
 	errorHandler    emperror.Handler
@@ -64,9 +67,10 @@ type ClusterAPI struct {
 }
 
 type ClusterCreators struct {
-	PKEOnAzure   azureDriver.ClusterCreator
-	EKSAmazon    eksdriver.EksClusterCreator
-	PKEOnVsphere vsphereDriver.VspherePKEClusterCreator
+	PKEOnAzure     azureDriver.ClusterCreator
+	EKSAmazon      eksdriver.EksClusterCreator
+	PKEOnVsphere   vsphereDriver.VspherePKEClusterCreator
+	PKEOnBaremetal baremetalDriver.BaremetalPKEClusterCreator
 }
 
 type ClusterDeleters struct {
@@ -75,8 +79,10 @@ type ClusterDeleters struct {
 }
 
 type ClusterUpdaters struct {
-	PKEOnAzure azureDriver.ClusterUpdater
-	EKSAmazon  eksdriver.EksClusterUpdater
+	PKEOnAzure     azureDriver.ClusterUpdater
+	EKSAmazon      eksdriver.EksClusterUpdater
+	PKEOnVsphere   vsphereDriver.ClusterUpdater
+	PKEOnBaremetal baremetalDriver.ClusterUpdater
 }
 
 // NewClusterAPI returns a new ClusterAPI instance.
@@ -91,6 +97,7 @@ func NewClusterAPI(
 	clusterCreators ClusterCreators,
 	clusterUpdaters ClusterUpdaters,
 	clientFactory common.DynamicClientFactory,
+	proxyAccess kubeproxy.AccessController,
 ) *ClusterAPI {
 	return &ClusterAPI{
 		clusterManager:          clusterManager,
@@ -103,6 +110,7 @@ func NewClusterAPI(
 		clusterCreators:         clusterCreators,
 		clusterUpdaters:         clusterUpdaters,
 		clientFactory:           clientFactory,
+		proxyAccess:             proxyAccess,
 	}
 }
 
@@ -458,12 +466,52 @@ func InstallSecretsToCluster(c *gin.Context) {
 }
 
 // ProxyToCluster sets up a proxy and forwards all requests to the cluster's API server.
+// Requests are authorized against the allow-list of the user's role and impersonate the user.
 func (a *ClusterAPI) ProxyToCluster(c *gin.Context) {
 	commonCluster, ok := getClusterFromRequest(c)
 	if !ok {
 		return
 	}
 
+	user := auth.GetCurrentUser(c.Request)
+	if user == nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, pkgCommon.ErrorResponse{
+			Code:    http.StatusUnauthorized,
+			Message: "Unauthorized",
+		})
+		return
+	}
+
+	requestInfo := kubeproxy.NewRequestInfo(c.Request.Method, c.Param("path"), c.Request.URL.Query())
+
+	decision, err := a.proxyAccess.Authorize(
+		c.Request.Context(),
+		commonCluster.GetOrganizationId(),
+		kubeproxy.User{ID: user.ID, Login: user.Login},
+		requestInfo,
+	)
+	if err != nil {
+		log.Errorf("Error authorizing proxy request to cluster [%d]: %s", commonCluster.GetID(), err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, pkgCommon.ErrorResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "Error authorizing proxy request",
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	// recorded by the audit middleware
+	c.Set(kubeproxy.AuditRecordKey, decision.AuditRecord())
+
+	if !decision.Allowed {
+		c.AbortWithStatusJSON(http.StatusForbidden, kubeproxy.ForbiddenStatus(decision))
+		return
+	}
+
+	// the Pipeline credentials of the user must not reach the cluster (the proxy uses the cluster credentials)
+	c.Request.Header.Del("Authorization")
+	kubeproxy.Impersonate(c.Request.Header, decision.Identity)
+
 	apiProxyPrefix := strings.TrimSuffix(c.Request.URL.Path, c.Param("path"))
 
 	kubeProxy, err := a.clusterManager.GetKubeProxy(c.Request.URL.Scheme, c.Request.URL.Host, apiProxyPrefix, commonCluster)
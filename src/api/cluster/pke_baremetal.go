@@ -0,0 +1,101 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"github.com/banzaicloud/pipeline/.gen/pipeline/pipeline"
+	intPKE "github.com/banzaicloud/pipeline/internal/pke"
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke"
+	"github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/driver"
+	"github.com/banzaicloud/pipeline/pkg/cluster"
+)
+
+const PKEOnBaremetal = pke.PKEOnBaremetal
+
+type CreatePKEOnBaremetalClusterRequest pipeline.CreatePkeOnBaremetalClusterRequest
+
+func (req CreatePKEOnBaremetalClusterRequest) ToBaremetalPKEClusterCreationParams(organizationID, userID uint) driver.BaremetalPKEClusterCreationParams {
+	return driver.BaremetalPKEClusterCreationParams{
+		Name:           req.Name,
+		OrganizationID: organizationID,
+		CreatedBy:      userID,
+		ScaleOptions: cluster.ScaleOptions{
+			Enabled:             req.ScaleOptions.Enabled,
+			DesiredCpu:          req.ScaleOptions.DesiredCpu,
+			DesiredMem:          req.ScaleOptions.DesiredMem,
+			DesiredGpu:          int(req.ScaleOptions.DesiredGpu),
+			OnDemandPct:         int(req.ScaleOptions.OnDemandPct),
+			Excludes:            req.ScaleOptions.Excludes,
+			KeepDesiredCapacity: req.ScaleOptions.KeepDesiredCapacity,
+		},
+		SecretID: req.SecretId,
+		Kubernetes: intPKE.Kubernetes{
+			Version: req.Kubernetes.Version,
+			RBAC:    req.Kubernetes.Rbac,
+			Network: intPKE.Network{
+				ServiceCIDR:    req.Kubernetes.Network.ServiceCIDR,
+				PodCIDR:        req.Kubernetes.Network.PodCIDR,
+				Provider:       req.Kubernetes.Network.Provider,
+				ProviderConfig: req.Kubernetes.Network.ProviderConfig,
+			},
+			CRI: intPKE.CRI{
+				Runtime:       req.Kubernetes.Cri.Runtime,
+				RuntimeConfig: req.Kubernetes.Cri.RuntimeConfig,
+			},
+			OIDC: intPKE.OIDC{
+				Enabled: req.Kubernetes.Oidc.Enabled,
+			},
+		},
+		NodePools: baremetalRequestToClusterNodepools(req.NodePools, userID),
+		HTTPProxy: intPKE.HTTPProxy{
+			HTTP:       clientPKEClusterHTTPProxyOptionsToPKEHTTPProxyOptions(req.Proxy.Http),
+			HTTPS:      clientPKEClusterHTTPProxyOptionsToPKEHTTPProxyOptions(req.Proxy.Https),
+			Exceptions: req.Proxy.Exceptions,
+		},
+		APIServerAddress: req.ApiServerAddress,
+	}
+}
+
+type UpdatePKEOnBaremetalClusterRequest pipeline.UpdatePkeOnBaremetalClusterRequest
+
+func (req UpdatePKEOnBaremetalClusterRequest) ToBaremetalPKEClusterUpdateParams(clusterID, userID uint) driver.ClusterUpdateParams {
+	return driver.ClusterUpdateParams{
+		ClusterID: clusterID,
+		NodePools: baremetalRequestToClusterNodepools(req.Nodepools, userID),
+	}
+}
+
+func baremetalRequestToClusterNodepools(request []pipeline.PkeOnBaremetalNodePool, userID uint) []driver.NodePool {
+	nodepools := make([]driver.NodePool, len(request))
+	for i, node := range request {
+		hosts := make([]pke.Host, len(node.Hosts))
+		for j, host := range node.Hosts {
+			hosts[j] = pke.Host{
+				Name:      host.Name,
+				Address:   host.Address,
+				PrivateIP: host.PrivateIP,
+			}
+		}
+
+		nodepools[i] = driver.NodePool{
+			CreatedBy: userID,
+			Name:      node.Name,
+			Roles:     node.Roles,
+			Labels:    node.Labels,
+			Hosts:     hosts,
+		}
+	}
+	return nodepools
+}
@@ -71,6 +71,15 @@ func (req CreatePKEOnVsphereClusterRequest) ToVspherePKEClusterCreationParams(or
 	}
 }
 
+type UpdatePKEOnVsphereClusterRequest pipeline.UpdatePkeOnVsphereClusterRequest
+
+func (req UpdatePKEOnVsphereClusterRequest) ToVspherePKEClusterUpdateParams(clusterID, userID uint) driver.ClusterUpdateParams {
+	return driver.ClusterUpdateParams{
+		ClusterID: clusterID,
+		NodePools: vsphereRequestToClusterNodepools(req.Nodepools, userID),
+	}
+}
+
 func vsphereRequestToClusterNodepools(request []pipeline.PkeOnVsphereNodePool, userID uint) []driver.NodePool {
 	nodepools := make([]driver.NodePool, len(request))
 	for i, node := range request {
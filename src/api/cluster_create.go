@@ -165,6 +165,19 @@ func (a *ClusterAPI) CreateCluster(c *gin.Context) {
 			return
 		}
 		cluster = vsphereCluster
+	case clusterAPI.PKEOnBaremetal:
+		var req clusterAPI.CreatePKEOnBaremetalClusterRequest
+		if ok := a.parseRequest(c, requestBody, &req); !ok {
+			return
+		}
+		req.SecretId = secretID
+		params := req.ToBaremetalPKEClusterCreationParams(orgID, userID)
+		baremetalCluster, err := a.clusterCreators.PKEOnBaremetal.Create(ctx, params)
+		if err = errors.WrapIf(err, "failed to create cluster from request"); err != nil {
+			a.handleCreationError(c, err)
+			return
+		}
+		cluster = baremetalCluster
 	case clusterAPI.PKEOnAzure:
 		var req clusterAPI.CreatePKEOnAzureClusterRequest
 		if ok := a.parseRequest(c, requestBody, &req); !ok {
@@ -80,6 +80,8 @@ func (a *ClusterAPI) GetCluster(c *gin.Context) {
 		Distribution: clusterStatus.Distribution,
 		Spot:         clusterStatus.Spot,
 
+		Labels: clusterStatus.Labels,
+
 		ScaleOptions: commonCluster.GetScaleOptions(),
 
 		// TODO: keep one of the following?
@@ -242,6 +244,8 @@ type GetClusterResponse struct {
 	Distribution string `json:"distribution"`
 	Spot         bool   `json:"spot,omitempty"`
 
+	Labels map[string]string `json:"labels,omitempty"`
+
 	Logging      bool                     `json:"logging"`
 	Monitoring   bool                     `json:"monitoring"`
 	SecurityScan bool                     `json:"securityscan"`
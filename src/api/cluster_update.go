@@ -55,6 +55,32 @@ func (a *ClusterAPI) UpdateCluster(c *gin.Context) {
 		}
 		params := updateRequest.ToAzurePKEClusterUpdateParams(commonCluster.GetID(), auth.GetCurrentUser(c.Request).ID)
 		err = a.clusterUpdaters.PKEOnAzure.Update(c, params)
+	} else if commonCluster.GetCloud() == pkgCluster.Vsphere && commonCluster.GetDistribution() == pkgCluster.PKE {
+		var updateRequest *apicluster.UpdatePKEOnVsphereClusterRequest
+		if err := c.BindJSON(&updateRequest); err != nil {
+			a.logger.Errorf("Error parsing request: %s", err.Error())
+			c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Error parsing request",
+				Error:   err.Error(),
+			})
+			return
+		}
+		params := updateRequest.ToVspherePKEClusterUpdateParams(commonCluster.GetID(), auth.GetCurrentUser(c.Request).ID)
+		err = a.clusterUpdaters.PKEOnVsphere.Update(c, params)
+	} else if commonCluster.GetCloud() == pkgCluster.Baremetal && commonCluster.GetDistribution() == pkgCluster.PKE {
+		var updateRequest *apicluster.UpdatePKEOnBaremetalClusterRequest
+		if err := c.BindJSON(&updateRequest); err != nil {
+			a.logger.Errorf("Error parsing request: %s", err.Error())
+			c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+				Code:    http.StatusBadRequest,
+				Message: "Error parsing request",
+				Error:   err.Error(),
+			})
+			return
+		}
+		params := updateRequest.ToBaremetalPKEClusterUpdateParams(commonCluster.GetID(), auth.GetCurrentUser(c.Request).ID)
+		err = a.clusterUpdaters.PKEOnBaremetal.Update(c, params)
 	} else {
 		// bind request body to UpdateClusterRequest struct
 		var updateRequest *pkgCluster.UpdateClusterRequest
@@ -34,6 +34,7 @@ const (
 type API struct {
 	clusterGroupManager *cgroup.Manager
 	deploymentManager   *pkgDep.CGDeploymentManager
+	driftDetector       *cgroup.DriftDetector
 	logger              logrus.FieldLogger
 	errorHandler        common.ErrorHandler
 }
@@ -41,12 +42,14 @@ type API struct {
 func NewAPI(
 	clusterGroupManager *cgroup.Manager,
 	deploymentManager *pkgDep.CGDeploymentManager,
+	driftDetector *cgroup.DriftDetector,
 	logger logrus.FieldLogger,
 	baseErrorHandler emperror.Handler,
 ) *API {
 	return &API{
 		clusterGroupManager: clusterGroupManager,
 		deploymentManager:   deploymentManager,
+		driftDetector:       driftDetector,
 		logger:              logger,
 		errorHandler: common.ErrorHandler{
 			Handler: baseErrorHandler,
@@ -63,6 +66,7 @@ func (a *API) AddRoutes(group *gin.RouterGroup) {
 		item.GET("", a.Get)
 		item.PUT("", a.Update)
 		item.DELETE("", a.Delete)
+		item.GET("/drift", a.GetDrift)
 	}
 
 	feature.NewAPI(a.clusterGroupManager, a.deploymentManager, a.logger, a.errorHandler.Handler).AddRoutes(item.Group("/features"))
@@ -56,6 +56,12 @@ func (e ErrorHandler) errorResponseFrom(err error) *pkgCommon.ErrorResponse {
 		code = http.StatusBadRequest
 	}
 
+	if deployment.IsInvalidRolloutStrategyError(err) {
+		code = http.StatusBadRequest
+	} else if deployment.IsRolloutStateError(err) {
+		code = http.StatusConflict
+	}
+
 	if code > 0 {
 		return &pkgCommon.ErrorResponse{
 			Code:    code,
@@ -61,5 +61,7 @@ func (a *API) AddRoutes(group *gin.RouterGroup) {
 		item.PUT("", a.Upgrade)
 		item.DELETE("", a.Delete)
 		item.PUT("/sync", a.Sync)
+		item.PUT("/rollout/resume", a.ResumeRollout)
+		item.PUT("/rollout/abort", a.AbortRollout)
 	}
 }
@@ -0,0 +1,105 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	ginutils "github.com/banzaicloud/pipeline/internal/platform/gin/utils"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// @Summary Resume Cluster Group Deployment Rollout
+// @Description resumes a progressive rollout paused because of a failure, starting with the failed batch of target clusters
+// @Tags clustergroup deployments
+// @Accept json
+// @Produce json
+// @Param orgid path uint true "Organization ID"
+// @Param clusterGroupId path uint true "Cluster Group ID"
+// @Param deploymentName path string true "release name of a cluster group deployment"
+// @Success 202 "Rollout resumed"
+// @Failure 400 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Failure 409 {object} common.ErrorResponse "The rollout is not paused"
+// @Router /api/v1/orgs/{orgid}/clustergroups/{clusterGroupId}/deployments/{deploymentName}/rollout/resume [put]
+// @Security bearerAuth
+func (n *API) ResumeRollout(c *gin.Context) {
+	ctx := ginutils.Context(context.Background(), c)
+
+	name := c.Param("name")
+	n.logger.Infof("resume cluster group deployment rollout: [%s]", name)
+
+	clusterGroupID, ok := ginutils.UintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	orgID := auth.GetCurrentOrganization(c.Request).ID
+	clusterGroup, err := n.clusterGroupManager.GetClusterGroupByID(ctx, clusterGroupID, orgID)
+	if err != nil {
+		n.errorHandler.Handle(c, err)
+		return
+	}
+
+	if err := n.deploymentManager.ResumeRollout(clusterGroup, name); err != nil {
+		n.errorHandler.Handle(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// @Summary Abort Cluster Group Deployment Rollout
+// @Description aborts a running or paused progressive rollout, target clusters already deployed to are left as they are
+// @Tags clustergroup deployments
+// @Accept json
+// @Produce json
+// @Param orgid path uint true "Organization ID"
+// @Param clusterGroupId path uint true "Cluster Group ID"
+// @Param deploymentName path string true "release name of a cluster group deployment"
+// @Success 202 "Rollout aborted"
+// @Failure 400 {object} common.ErrorResponse
+// @Failure 404 {object} common.ErrorResponse
+// @Failure 409 {object} common.ErrorResponse "The rollout is not in progress"
+// @Router /api/v1/orgs/{orgid}/clustergroups/{clusterGroupId}/deployments/{deploymentName}/rollout/abort [put]
+// @Security bearerAuth
+func (n *API) AbortRollout(c *gin.Context) {
+	ctx := ginutils.Context(context.Background(), c)
+
+	name := c.Param("name")
+	n.logger.Infof("abort cluster group deployment rollout: [%s]", name)
+
+	clusterGroupID, ok := ginutils.UintParam(c, "id")
+	if !ok {
+		return
+	}
+
+	orgID := auth.GetCurrentOrganization(c.Request).ID
+	clusterGroup, err := n.clusterGroupManager.GetClusterGroupByID(ctx, clusterGroupID, orgID)
+	if err != nil {
+		n.errorHandler.Handle(c, err)
+		return
+	}
+
+	if err := n.deploymentManager.AbortRollout(clusterGroup, name); err != nil {
+		n.errorHandler.Handle(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
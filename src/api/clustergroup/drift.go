@@ -0,0 +1,54 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clustergroup
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	ginutils "github.com/banzaicloud/pipeline/internal/platform/gin/utils"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// @Summary Get Cluster Group Drift Report
+// @Description compare deployments, Kubernetes versions and integrated services of the member clusters against the desired state of the group and against each other
+// @Tags clustergroups
+// @Accept json
+// @Produce json
+// @Param orgid path int true "Organization ID"
+// @Param clusterGroupId path int true "Cluster Group ID"
+// @Success 200 {object} api.DriftReport
+// @Failure 400 {object} common.ErrorResponse Cluster Group Not Found
+// @Router /api/v1/orgs/{orgid}/clustergroups/{clusterGroupId}/drift [get]
+// @Security bearerAuth
+func (a *API) GetDrift(c *gin.Context) {
+	ctx := ginutils.Context(context.Background(), c)
+
+	clusterGroupID, ok := ginutils.UintParam(c, IDParamName)
+	if !ok {
+		return
+	}
+
+	orgID := auth.GetCurrentOrganization(c.Request).ID
+	response, err := a.driftDetector.GetDriftReport(ctx, clusterGroupID, orgID)
+	if err != nil {
+		a.errorHandler.Handle(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
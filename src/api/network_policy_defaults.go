@@ -0,0 +1,97 @@
+// Copyright © 2020 Banzai Cloud
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"emperror.dev/emperror"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/banzaicloud/pipeline/internal/cluster/networkpolicy"
+	"github.com/banzaicloud/pipeline/internal/platform/gin/correlationid"
+	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
+	"github.com/banzaicloud/pipeline/src/auth"
+)
+
+// NetworkPolicyDefaultsAPI manages the default network policies applied to new clusters of an organization.
+type NetworkPolicyDefaultsAPI struct {
+	store        networkpolicy.Store
+	logger       logrus.FieldLogger
+	errorHandler emperror.Handler
+}
+
+// NewNetworkPolicyDefaultsAPI returns a new NetworkPolicyDefaultsAPI instance.
+func NewNetworkPolicyDefaultsAPI(store networkpolicy.Store, logger logrus.FieldLogger, errorHandler emperror.Handler) NetworkPolicyDefaultsAPI {
+	return NetworkPolicyDefaultsAPI{
+		store:        store,
+		logger:       logger,
+		errorHandler: errorHandler,
+	}
+}
+
+// GetDefaults returns the default network policies of the organization.
+func (a NetworkPolicyDefaultsAPI) GetDefaults(c *gin.Context) {
+	organization := auth.GetCurrentOrganization(c.Request)
+
+	defaults, err := a.store.Get(c.Request.Context(), organization.ID)
+	if err != nil {
+		a.errorHandler.Handle(err)
+
+		c.JSON(http.StatusInternalServerError, pkgCommon.ErrorResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "failed to get default network policies",
+			Error:   err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, defaults)
+}
+
+// SetDefaults replaces the default network policies of the organization.
+func (a NetworkPolicyDefaultsAPI) SetDefaults(c *gin.Context) {
+	logger := correlationid.Logger(a.logger, c)
+	organization := auth.GetCurrentOrganization(c.Request)
+
+	var defaults networkpolicy.Defaults
+	if err := c.BindJSON(&defaults); err != nil {
+		c.JSON(http.StatusBadRequest, pkgCommon.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Message: "error parsing request",
+			Error:   err.Error(),
+		})
+
+		return
+	}
+
+	logger.WithField("organization", organization.ID).Debug("saving default network policies")
+
+	if err := a.store.Set(c.Request.Context(), organization.ID, defaults); err != nil {
+		a.errorHandler.Handle(err)
+
+		c.JSON(http.StatusInternalServerError, pkgCommon.ErrorResponse{
+			Code:    http.StatusInternalServerError,
+			Message: "failed to save default network policies",
+			Error:   err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, defaults)
+}
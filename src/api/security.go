@@ -23,16 +23,19 @@ import (
 	"emperror.dev/errors"
 	"github.com/banzaicloud/anchore-image-validator/pkg/apis/security/v1alpha1"
 	"github.com/gin-gonic/gin"
+	appkiterrors "github.com/sagikazarmark/appkit/errors"
 	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	internalCommon "github.com/banzaicloud/pipeline/internal/common"
 	anchore "github.com/banzaicloud/pipeline/internal/security"
+	"github.com/banzaicloud/pipeline/internal/security/whitelist"
 	"github.com/banzaicloud/pipeline/pkg/common"
 	pkgHelm "github.com/banzaicloud/pipeline/pkg/helm"
 	"github.com/banzaicloud/pipeline/pkg/k8sclient"
 	"github.com/banzaicloud/pipeline/pkg/security"
 	apiCommon "github.com/banzaicloud/pipeline/src/api/common"
+	"github.com/banzaicloud/pipeline/src/auth"
 	"github.com/banzaicloud/pipeline/src/helm"
 )
 
@@ -205,6 +208,7 @@ type SecurityHandler interface {
 type WhitelistHandler interface {
 	GetWhiteLists(c *gin.Context)
 	CreateWhiteList(c *gin.Context)
+	ApproveWhiteList(c *gin.Context)
 	DeleteWhiteList(c *gin.Context)
 }
 
@@ -214,22 +218,26 @@ type ScanLogHandler interface {
 }
 
 type securityHandlers struct {
-	clusterGetter   apiCommon.ClusterGetter
-	resourceService anchore.SecurityResourceService
-	errorHandler    internalCommon.ErrorHandler
-	logger          internalCommon.Logger
+	clusterGetter    apiCommon.ClusterGetter
+	resourceService  anchore.SecurityResourceService
+	whitelistService whitelist.Service
+	errorHandler     internalCommon.ErrorHandler
+	logger           internalCommon.Logger
 }
 
 func NewSecurityApiHandlers(
 	clusterGetter apiCommon.ClusterGetter,
+	whitelistRequests whitelist.RequestStore,
+	whitelistConfig whitelist.Config,
 	errorHandler internalCommon.ErrorHandler,
 	logger internalCommon.Logger) SecurityHandler {
 	wlSvc := anchore.NewSecurityResourceService(logger)
 	return securityHandlers{
-		clusterGetter:   clusterGetter,
-		resourceService: wlSvc,
-		errorHandler:    errorHandler,
-		logger:          logger,
+		clusterGetter:    clusterGetter,
+		resourceService:  wlSvc,
+		whitelistService: whitelist.NewService(whitelistConfig, wlSvc, whitelistRequests),
+		errorHandler:     errorHandler,
+		logger:           logger,
 	}
 }
 
@@ -241,28 +249,12 @@ func (s securityHandlers) GetWhiteLists(c *gin.Context) {
 		return
 	}
 
-	whitelist, err := s.resourceService.GetWhitelists(c.Request.Context(), cluster)
+	releaseWhitelist, err := s.whitelistService.ListItems(c.Request.Context(), cluster)
 	if err != nil {
-		s.errorHandler.HandleContext(c.Request.Context(), err)
-
-		c.JSON(http.StatusInternalServerError, common.ErrorResponse{
-			Code:    http.StatusInternalServerError,
-			Message: "Error while retrieving whitelists",
-			Error:   errors.Cause(err).Error(),
-		})
+		s.whitelistErrorResponse(c, "Error while retrieving whitelists", err)
 		return
 	}
 
-	releaseWhitelist := make([]security.ReleaseWhiteListItem, 0)
-	for _, whitelist := range whitelist {
-		whitelistItem := security.ReleaseWhiteListItem{
-			Name:   whitelist.Name,
-			Owner:  whitelist.Spec.Creator,
-			Reason: whitelist.Spec.Reason,
-		}
-		releaseWhitelist = append(releaseWhitelist, whitelistItem)
-	}
-
 	s.successResponse(c, releaseWhitelist)
 }
 
@@ -286,18 +278,36 @@ func (s securityHandlers) CreateWhiteList(c *gin.Context) {
 		return
 	}
 
-	if _, err := s.resourceService.CreateWhitelist(c.Request.Context(), cluster, *whiteListItem); err != nil {
-		s.errorHandler.HandleContext(c.Request.Context(), err)
+	item, err := s.whitelistService.CreateItem(c.Request.Context(), cluster, *whiteListItem, currentWhitelistUser(c))
+	if err != nil {
+		s.whitelistErrorResponse(c, "Error while creating whitelist", err)
+		return
+	}
 
-		c.JSON(http.StatusInternalServerError, common.ErrorResponse{
-			Code:    http.StatusInternalServerError,
-			Message: "Error while creating whitelist",
-			Error:   errors.Cause(err).Error(),
-		})
+	// pending items are installed once an organization admin approves them
+	if item.Status == security.WhiteListItemStatusPending {
+		c.JSON(http.StatusAccepted, item)
 		return
 	}
 
-	c.Status(http.StatusCreated)
+	c.JSON(http.StatusCreated, item)
+}
+
+func (s securityHandlers) ApproveWhiteList(c *gin.Context) {
+	cluster, ok := s.clusterGetter.GetClusterFromRequest(c)
+	if !ok {
+		s.logger.Warn("failed to retrieve cluster based on the request")
+
+		return
+	}
+
+	item, err := s.whitelistService.ApproveItem(c.Request.Context(), cluster, c.Param("name"), currentWhitelistUser(c))
+	if err != nil {
+		s.whitelistErrorResponse(c, "Error while approving whitelist", err)
+		return
+	}
+
+	s.successResponse(c, item)
 }
 
 func (s securityHandlers) DeleteWhiteList(c *gin.Context) {
@@ -318,14 +328,8 @@ func (s securityHandlers) DeleteWhiteList(c *gin.Context) {
 		return
 	}
 
-	if err := s.resourceService.DeleteWhitelist(c.Request.Context(), cluster, whitelisItemtName); err != nil {
-		s.errorHandler.HandleContext(c.Request.Context(), err)
-
-		c.JSON(http.StatusInternalServerError, common.ErrorResponse{
-			Code:    http.StatusInternalServerError,
-			Message: "Error while deleting whitelist",
-			Error:   errors.Cause(err).Error(),
-		})
+	if err := s.whitelistService.DeleteItem(c.Request.Context(), cluster, whitelisItemtName); err != nil {
+		s.whitelistErrorResponse(c, "Error while deleting whitelist", err)
 		return
 	}
 
@@ -380,6 +384,39 @@ func (s securityHandlers) GetScanLogs(c *gin.Context) {
 	c.JSON(http.StatusOK, scanlogs)
 }
 
+func (s securityHandlers) whitelistErrorResponse(c *gin.Context, message string, err error) {
+	code := http.StatusInternalServerError
+
+	switch {
+	case appkiterrors.IsValidationError(err):
+		code = http.StatusBadRequest
+
+	case appkiterrors.IsNotFoundError(err):
+		code = http.StatusNotFound
+
+	case appkiterrors.IsConflictError(err):
+		code = http.StatusConflict
+
+	default:
+		s.errorHandler.HandleContext(c.Request.Context(), err)
+	}
+
+	c.JSON(code, common.ErrorResponse{
+		Code:    code,
+		Message: message,
+		Error:   errors.Cause(err).Error(),
+	})
+}
+
+func currentWhitelistUser(c *gin.Context) whitelist.User {
+	user := auth.GetCurrentUser(c.Request)
+	if user == nil {
+		return whitelist.User{}
+	}
+
+	return whitelist.User{ID: user.ID, Login: user.Login}
+}
+
 func (s securityHandlers) successResponse(ginCtx *gin.Context, payload interface{}) {
 	ginCtx.JSON(http.StatusOK, payload)
 	return
@@ -206,10 +206,19 @@ func Init(db *gorm.DB, cdb *gorm.DB, config Config, tokenStore bauth.TokenStore,
 		func(claims *ginauth.ScopedClaims) interface{} {
 			userID, _ := strconv.ParseUint(claims.Subject, 10, 32)
 
+			tokenScopes, err := pkgAuth.ParseTokenScopes(claims.Scope)
+			if err != nil {
+				// Do not authenticate tokens with invalid scopes rather than granting them full access
+				errorHandler.Handle(errors.WrapIfWithDetails(err, "invalid token scopes", "tokenId", claims.Id))
+
+				return nil
+			}
+
 			return &User{
-				ID:      uint(userID),
-				Login:   claims.Text, // This is needed for CICD virtual user tokens
-				Virtual: claims.Type == ginauth.TokenType(CICDHookTokenType),
+				ID:          uint(userID),
+				Login:       claims.Text, // This is needed for CICD virtual user tokens
+				Virtual:     claims.Type == ginauth.TokenType(CICDHookTokenType),
+				TokenScopes: tokenScopes,
 			}
 		},
 		func(ctx context.Context, value interface{}) context.Context {
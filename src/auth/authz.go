@@ -22,7 +22,11 @@ import (
 	"strings"
 
 	"emperror.dev/errors"
+	"github.com/gin-gonic/gin"
 	"github.com/jinzhu/gorm"
+	"github.com/qor/auth"
+
+	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
 )
 
 // RbacEnforcer makes authorization decisions based on user roles.
@@ -52,7 +56,21 @@ func NewRbacEnforcer(roleSource RoleSource, serviceAccountService ServiceAccount
 }
 
 // Enforce makes authorization decisions.
+// Tokens restricted by scopes are only granted access if both the user's role and the token scopes allow it.
 func (e RbacEnforcer) Enforce(org *Organization, user *User, path, method string) (bool, error) {
+	granted, err := e.enforceRole(org, user, path, method)
+	if err != nil || !granted {
+		return granted, err
+	}
+
+	if user != nil && user.TokenScopes != nil {
+		return e.enforceTokenScopes(org, user, path, method), nil
+	}
+
+	return true, nil
+}
+
+func (e RbacEnforcer) enforceRole(org *Organization, user *User, path, method string) (bool, error) {
 	// Non-organizational resources are always allowed.
 	// TODO: this shouldn't be decided here, remove it!
 	if org == nil {
@@ -117,6 +135,34 @@ func (e RbacEnforcer) Enforce(org *Organization, user *User, path, method string
 	case RoleAdmin:
 		return true, nil
 	case RoleMember:
+		// Members can issue and revoke their own short-lived kube configs (ownership is checked by the service)
+		if ok, err := regexp.MatchString(`^/api/v1/orgs/\d+/clusters/[^/]+/kubeconfigs(?:/[^/]+)?$`, path); err != nil {
+			return false, errors.WithStackIf(err)
+		} else if ok {
+			return true, nil
+		}
+
+		// Members can send any request to the Kubernetes API proxy (requests are authorized by the proxy allow-list)
+		if ok, err := regexp.MatchString(`^/api/v1/orgs/\d+/clusters/[^/]+/proxy(?:/.*)?$`, path); err != nil {
+			return false, errors.WithStackIf(err)
+		} else if ok {
+			return true, nil
+		}
+
+		// Members can request cost estimates (estimation does not change any resource)
+		if ok, err := regexp.MatchString(`^/api/v1/orgs/\d+/(?:clusters/[^/]+/)?cost/estimates$`, path); err != nil {
+			return false, errors.WithStackIf(err)
+		} else if ok && method == http.MethodPost {
+			return true, nil
+		}
+
+		// Members can request node pool recommendations (recommendation does not change any resource)
+		if ok, err := regexp.MatchString(`^/api/v1/orgs/\d+/recommendations/nodepools$`, path); err != nil {
+			return false, errors.WithStackIf(err)
+		} else if ok && method == http.MethodPost {
+			return true, nil
+		}
+
 		// Members can only read organization resources
 		if ok, err := regexp.MatchString(`^/api/v1/orgs(?:/.*)?$`, path); err != nil || (ok && method != http.MethodGet && method != http.MethodHead) {
 			return false, nil
@@ -132,6 +178,16 @@ func (e RbacEnforcer) Enforce(org *Organization, user *User, path, method string
 			return false, errors.WithStackIf(err)
 		}
 
+		// Members cannot access the audit log (it contains request bodies)
+		if ok, err := regexp.MatchString(`^/api/v1/orgs/\d+/audit(?:/.*)?$`, path); err != nil || ok {
+			return false, errors.WithStackIf(err)
+		}
+
+		// Members cannot manage webhooks (they expose signing secrets and event payloads)
+		if ok, err := regexp.MatchString(`^/api/v1/orgs/\d+/webhooks(?:/.*)?$`, path); err != nil || ok {
+			return false, errors.WithStackIf(err)
+		}
+
 		return true, nil
 	default:
 		return false, errors.NewWithDetails(
@@ -145,6 +201,100 @@ func (e RbacEnforcer) Enforce(org *Organization, user *User, path, method string
 	}
 }
 
+var orgResourcePathRegexp = regexp.MustCompile(`^/api/v1/orgs/(\d+)(?:/([^/]+)(?:/([^/]+)(?:/([^/]+))?)?)?`)
+
+// enforceTokenScopes checks if the organization, the cluster and the action (resource and verb) derived from the path
+// are allowed by the scopes of the token.
+// Scoped tokens can only be used for organization resources.
+func (e RbacEnforcer) enforceTokenScopes(org *Organization, user *User, path, method string) bool {
+	scopes := user.TokenScopes
+
+	log := func(reason string) bool {
+		e.logger.Debug("token scopes do not allow access: "+reason, map[string]interface{}{
+			"userId": user.ID,
+			"method": method,
+			"path":   path,
+		})
+
+		return false
+	}
+
+	if org == nil {
+		return log("not an organization resource")
+	}
+
+	matches := orgResourcePathRegexp.FindStringSubmatch(path)
+	if matches == nil {
+		return log("not an organization resource")
+	}
+
+	if scopes.Organization != 0 && scopes.Organization != org.ID {
+		return log("organization mismatch")
+	}
+
+	resource := "orgs"
+	clusterID := ""
+
+	switch {
+	case matches[2] == "clusters":
+		resource = "clusters"
+		clusterID = matches[3]
+
+		if matches[4] != "" {
+			resource = matches[4]
+		}
+
+	case matches[2] != "":
+		resource = matches[2]
+	}
+
+	if scopeOrgID, scopeClusterID, ok := scopes.ClusterID(); ok {
+		if scopeOrgID != org.ID || clusterID != strconv.FormatUint(uint64(scopeClusterID), 10) {
+			return log("cluster mismatch")
+		}
+	}
+
+	verb := pkgAuth.WriteVerb
+	if method == http.MethodGet || method == http.MethodHead {
+		verb = pkgAuth.ReadVerb
+	}
+
+	if !scopes.AllowsAction(resource, verb) {
+		return log("action is not allowed")
+	}
+
+	return true
+}
+
+// ScopedTokenAllowed checks if a path can be accessed by a user at all.
+// Tokens restricted by scopes can only access organization resources (which are further checked by the enforcer),
+// every other route is denied, since it is not covered by any scope.
+func ScopedTokenAllowed(user *User, path string) bool {
+	if user == nil || user.TokenScopes == nil {
+		return true
+	}
+
+	return orgResourcePathRegexp.MatchString(path)
+}
+
+// NewScopedTokenHandler returns a gin middleware that denies requests authenticated by scoped tokens
+// to routes not covered by token scopes.
+// It should be installed right after the authentication middleware.
+func NewScopedTokenHandler(basePath string) gin.HandlerFunc {
+	basePath = "/" + strings.Trim(basePath, "/")
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if basePath != "/" {
+			path = strings.TrimPrefix(path, basePath)
+		}
+
+		if !ScopedTokenAllowed(GetCurrentUser(c.Request), path) {
+			c.AbortWithStatus(http.StatusForbidden)
+		}
+	}
+}
+
 // Authorizer checks if a context has permission to execute an action.
 type Authorizer struct {
 	db         *gorm.DB
@@ -161,6 +311,13 @@ func NewAuthorizer(db *gorm.DB, roleSource RoleSource) Authorizer {
 
 // Authorize authorizes a context to execute an action on an object.
 func (a Authorizer) Authorize(ctx context.Context, action string, object interface{}) (bool, error) {
+	if action == "token.create" {
+		// Tokens restricted by scopes cannot be used to create new (potentially less restricted) tokens
+		if user, ok := ctx.Value(auth.CurrentUser).(*User); ok && user.TokenScopes != nil {
+			return false, nil
+		}
+	}
+
 	if action == "virtualUser.create" {
 		orgName, ok := object.(string)
 		if !ok {
@@ -15,13 +15,16 @@
 package auth
 
 import (
+	"context"
 	"testing"
 
+	"github.com/qor/auth"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/banzaicloud/pipeline/internal/common"
+	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
 )
 
 func TestRbacEnforcer_Enforce_NoOrgIsAllowed(t *testing.T) {
@@ -244,6 +247,90 @@ func TestRbacEnforcer_Enforce(t *testing.T) {
 			method:   "GET",
 			expected: false,
 		},
+		{
+			role:     RoleMember,
+			path:     "/api/v1/orgs/1/clusters/1/kubeconfigs",
+			method:   "POST",
+			expected: true,
+		},
+		{
+			role:     RoleMember,
+			path:     "/api/v1/orgs/1/clusters/1/kubeconfigs/d3c1a0a2-4e1b-4f5c-9a4b-6a2f3f0e7b11",
+			method:   "DELETE",
+			expected: true,
+		},
+		{
+			role:     RoleMember,
+			path:     "/api/v1/orgs/1/clusters/1/kubeconfigs/1/other",
+			method:   "DELETE",
+			expected: false,
+		},
+		{
+			role:     RoleMember,
+			path:     "/api/v1/orgs/1/clusters/1/proxy/api/v1/namespaces/default/pods/nginx",
+			method:   "DELETE",
+			expected: true,
+		},
+		{
+			role:     RoleMember,
+			path:     "/api/v1/orgs/1/clusters/1/proxyfoo",
+			method:   "DELETE",
+			expected: false,
+		},
+		{
+			role:     RoleMember,
+			path:     "/api/v1/orgs/1/cost/estimates",
+			method:   "POST",
+			expected: true,
+		},
+		{
+			role:     RoleMember,
+			path:     "/api/v1/orgs/1/clusters/1/cost/estimates",
+			method:   "POST",
+			expected: true,
+		},
+		{
+			role:     RoleMember,
+			path:     "/api/v1/orgs/1/clusters/1/cost/estimates",
+			method:   "DELETE",
+			expected: false,
+		},
+		{
+			role:     RoleMember,
+			path:     "/api/v1/orgs/1/recommendations/nodepools",
+			method:   "POST",
+			expected: true,
+		},
+		{
+			role:     RoleMember,
+			path:     "/api/v1/orgs/1/audit/events",
+			method:   "GET",
+			expected: false,
+		},
+		{
+			role:     RoleAdmin,
+			path:     "/api/v1/orgs/1/audit/events",
+			method:   "GET",
+			expected: true,
+		},
+		{
+			role:     RoleMember,
+			path:     "/api/v1/orgs/1/webhooks",
+			method:   "POST",
+			expected: false,
+		},
+		{
+			role:     RoleMember,
+			path:     "/api/v1/orgs/1/webhooks/1/deliveries",
+			method:   "GET",
+			expected: false,
+		},
+		{
+			role:     RoleAdmin,
+			path:     "/api/v1/orgs/1/webhooks",
+			method:   "GET",
+			expected: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -262,3 +349,181 @@ func TestRbacEnforcer_Enforce(t *testing.T) {
 		})
 	}
 }
+
+func TestRbacEnforcer_Enforce_TokenScopes(t *testing.T) {
+	org := Organization{
+		ID:   1,
+		Name: "example",
+	}
+
+	tests := []struct {
+		scopes   pkgAuth.TokenScopes
+		org      *Organization
+		path     string
+		method   string
+		expected bool
+	}{
+		{
+			scopes:   pkgAuth.TokenScopes{Organization: 1},
+			org:      &org,
+			path:     "/api/v1/orgs/1/secrets",
+			method:   "POST",
+			expected: true,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Organization: 2},
+			org:      &org,
+			path:     "/api/v1/orgs/1/secrets",
+			method:   "GET",
+			expected: false,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Organization: 1},
+			org:      nil,
+			path:     "/api/v1/orgs",
+			method:   "GET",
+			expected: false,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Cluster: "brn:1:cluster:2", Actions: []string{"deployments:write"}},
+			org:      &org,
+			path:     "/api/v1/orgs/1/clusters/2/deployments",
+			method:   "POST",
+			expected: true,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Cluster: "brn:1:cluster:2", Actions: []string{"deployments:write"}},
+			org:      &org,
+			path:     "/api/v1/orgs/1/clusters/2/deployments/release",
+			method:   "GET",
+			expected: true,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Cluster: "brn:1:cluster:2", Actions: []string{"deployments:write"}},
+			org:      &org,
+			path:     "/api/v1/orgs/1/clusters/3/deployments",
+			method:   "POST",
+			expected: false,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Cluster: "brn:1:cluster:2", Actions: []string{"deployments:write"}},
+			org:      &org,
+			path:     "/api/v1/orgs/1/clusters/2/secrets",
+			method:   "GET",
+			expected: false,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Cluster: "brn:1:cluster:2"},
+			org:      &org,
+			path:     "/api/v1/orgs/1/secrets",
+			method:   "GET",
+			expected: false,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Actions: []string{"secrets:read"}},
+			org:      &org,
+			path:     "/api/v1/orgs/1/secrets/secretID",
+			method:   "GET",
+			expected: true,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Actions: []string{"secrets:read"}},
+			org:      &org,
+			path:     "/api/v1/orgs/1/secrets",
+			method:   "POST",
+			expected: false,
+		},
+		{
+			scopes:   pkgAuth.TokenScopes{Actions: []string{"clusters:*"}},
+			org:      &org,
+			path:     "/api/v1/orgs/1/clusters/2",
+			method:   "DELETE",
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run("", func(t *testing.T) {
+			enforcer := NewRbacEnforcer(nil, NewServiceAccountService(), common.NoopLogger{})
+
+			user := User{
+				ID:          0,
+				Login:       "example",
+				TokenScopes: &test.scopes,
+			}
+
+			ok, err := enforcer.Enforce(test.org, &user, test.path, test.method)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, ok)
+		})
+	}
+}
+
+func TestAuthorizer_Authorize_ScopedTokenCannotCreateToken(t *testing.T) {
+	authorizer := NewAuthorizer(nil, nil)
+
+	ctx := context.WithValue(context.Background(), auth.CurrentUser, &User{
+		ID:          1,
+		TokenScopes: &pkgAuth.TokenScopes{Organization: 1},
+	})
+
+	ok, err := authorizer.Authorize(ctx, "token.create", nil)
+	require.NoError(t, err)
+
+	assert.False(t, ok)
+}
+
+func TestScopedTokenAllowed(t *testing.T) {
+	scopedUser := &User{
+		ID:          1,
+		TokenScopes: &pkgAuth.TokenScopes{Organization: 1},
+	}
+
+	tests := []struct {
+		user     *User
+		path     string
+		expected bool
+	}{
+		{
+			user:     &User{ID: 1},
+			path:     "/api/v1/me",
+			expected: true,
+		},
+		{
+			user:     scopedUser,
+			path:     "/api/v1/orgs/1/clusters",
+			expected: true,
+		},
+		{
+			user:     scopedUser,
+			path:     "/api/v1/me",
+			expected: false,
+		},
+		{
+			user:     scopedUser,
+			path:     "/api/v1/orgs",
+			expected: false,
+		},
+		{
+			user:     scopedUser,
+			path:     "/api/v1/tokens",
+			expected: false,
+		},
+		{
+			user:     scopedUser,
+			path:     "/dashboard/orgs/1/clusters",
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.expected, ScopedTokenAllowed(test.user, test.path))
+		})
+	}
+}
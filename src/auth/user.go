@@ -36,6 +36,7 @@ import (
 	"golang.org/x/oauth2"
 
 	"github.com/banzaicloud/pipeline/internal/global"
+	pkgAuth "github.com/banzaicloud/pipeline/pkg/auth"
 )
 
 const (
@@ -67,17 +68,18 @@ type AuthIdentity struct {
 
 // User struct
 type User struct {
-	ID             uint           `gorm:"primary_key" json:"id"`
-	CreatedAt      time.Time      `json:"createdAt"`
-	UpdatedAt      time.Time      `json:"updatedAt"`
-	Name           string         `form:"name" json:"name,omitempty"`
-	Email          string         `form:"email" json:"email,omitempty"`
-	Login          string         `gorm:"unique;not null" form:"login" json:"login"`
-	Image          string         `form:"image" json:"image,omitempty"`
-	Organizations  []Organization `gorm:"many2many:user_organizations" json:"organizations,omitempty"`
-	Virtual        bool           `json:"-" gorm:"-"` // Used only internally
-	APIToken       string         `json:"-" gorm:"-"` // Used only internally
-	ServiceAccount bool           `json:"-" gorm:"-"` // Used only internally
+	ID             uint                 `gorm:"primary_key" json:"id"`
+	CreatedAt      time.Time            `json:"createdAt"`
+	UpdatedAt      time.Time            `json:"updatedAt"`
+	Name           string               `form:"name" json:"name,omitempty"`
+	Email          string               `form:"email" json:"email,omitempty"`
+	Login          string               `gorm:"unique;not null" form:"login" json:"login"`
+	Image          string               `form:"image" json:"image,omitempty"`
+	Organizations  []Organization       `gorm:"many2many:user_organizations" json:"organizations,omitempty"`
+	Virtual        bool                 `json:"-" gorm:"-"` // Used only internally
+	APIToken       string               `json:"-" gorm:"-"` // Used only internally
+	ServiceAccount bool                 `json:"-" gorm:"-"` // Used only internally
+	TokenScopes    *pkgAuth.TokenScopes `json:"-" gorm:"-"` // Used only internally
 }
 
 // CICDUser struct
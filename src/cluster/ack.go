@@ -308,6 +308,7 @@ func CreateACKClusterFromRequest(request *pkgCluster.CreateClusterRequest, orgId
 		Distribution:   pkgCluster.ACK,
 		OrganizationId: orgId,
 		SecretId:       request.SecretId,
+		Labels:         request.Labels,
 		ACK: alibabaadapter.ACKClusterModel{
 			RegionID:                 request.Properties.CreateClusterACK.RegionID,
 			ZoneID:                   request.Properties.CreateClusterACK.ZoneID,
@@ -593,6 +594,7 @@ func (c *ACKCluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
 		CreatorBaseFields: *NewCreatorBaseFields(c.modelCluster.CreatedAt, c.modelCluster.CreatedBy),
 		Region:            c.modelCluster.ACK.RegionID,
 		StartedAt:         c.modelCluster.StartedAt,
+		Labels:            c.modelCluster.Labels,
 	}, nil
 }
 
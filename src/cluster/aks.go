@@ -72,6 +72,7 @@ func CreateAKSClusterFromRequest(request *pkgCluster.CreateClusterRequest, orgID
 			NodeInstanceType: np.NodeInstanceType,
 			VNetSubnetID:     np.VNetSubnetID,
 			Labels:           np.Labels,
+			Taints:           np.Taints,
 		})
 	}
 
@@ -85,6 +86,7 @@ func CreateAKSClusterFromRequest(request *pkgCluster.CreateClusterRequest, orgID
 		CreatedBy:      userID,
 		SecretId:       request.SecretId,
 		Distribution:   pkgCluster.AKS,
+		Labels:         request.Labels,
 		AKS: azureadapter.AKSClusterModel{
 			ResourceGroup:     request.Properties.CreateClusterAKS.ResourceGroup,
 			KubernetesVersion: request.Properties.CreateClusterAKS.KubernetesVersion,
@@ -189,6 +191,14 @@ func getVNetSubnetID(np *azureadapter.AKSNodePoolModel) *string {
 	return &np.VNetSubnetID
 }
 
+func getNodeTaints(np *azureadapter.AKSNodePoolModel) *[]string {
+	if len(np.Taints) == 0 {
+		return nil
+	}
+	taints := np.Taints.Strings()
+	return &taints
+}
+
 // CreateCluster creates a new cluster
 func (c *AKSCluster) CreateCluster() error {
 	c.log.Info("Creating cluster...")
@@ -214,6 +224,7 @@ func (c *AKSCluster) CreateCluster() error {
 				NodeLabels: map[string]*string{
 					pkgCommon.LabelKey: &name,
 				},
+				NodeTaints: getNodeTaints(np),
 			})
 		}
 	}
@@ -486,6 +497,7 @@ func (c *AKSCluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
 				MaxCount:          np.NodeMaxCount,
 				CreatorBaseFields: *NewCreatorBaseFields(np.CreatedAt, np.CreatedBy),
 				Labels:            np.Labels,
+				Taints:            np.Taints,
 			}
 		}
 	}
@@ -503,6 +515,7 @@ func (c *AKSCluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
 		NodePools:         nodePools,
 		Region:            c.modelCluster.Location,
 		StartedAt:         c.modelCluster.StartedAt,
+		Labels:            c.modelCluster.Labels,
 	}, nil
 }
 
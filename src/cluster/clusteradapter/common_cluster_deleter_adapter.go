@@ -35,6 +35,7 @@ func NewCommonClusterDeleterAdapter(commonClusterDeleter CommonClusterDeleter, c
 
 type CommonClusterDeleter interface {
 	DeleteCluster(ctx context.Context, cluster cluster.CommonCluster, force bool) error
+	UnlinkCluster(ctx context.Context, cluster cluster.CommonCluster, force bool) error
 }
 
 type CommonClusterGetter interface {
@@ -47,5 +48,9 @@ func (a CommonClusterDeleterAdapter) DeleteCluster(ctx context.Context, clusterI
 		return err
 	}
 
+	if options.KeepResources {
+		return a.commonClusterDeleter.UnlinkCluster(ctx, cc, options.Force)
+	}
+
 	return a.commonClusterDeleter.DeleteCluster(ctx, cc, options.Force)
 }
@@ -36,6 +36,8 @@ import (
 	"github.com/banzaicloud/pipeline/internal/providers/azure/pke"
 	"github.com/banzaicloud/pipeline/internal/providers/azure/pke/adapter"
 	pkeAzureAdapter "github.com/banzaicloud/pipeline/internal/providers/azure/pke/driver/commoncluster"
+	baremetaladapter "github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/adapter"
+	pkeBaremetalAdapter "github.com/banzaicloud/pipeline/internal/providers/baremetal/pke/driver/commoncluster"
 	"github.com/banzaicloud/pipeline/internal/providers/kubernetes/kubernetesadapter"
 	vsphereadapter "github.com/banzaicloud/pipeline/internal/providers/vsphere/pke/adapter"
 	pkeVsphereAdapter "github.com/banzaicloud/pipeline/internal/providers/vsphere/pke/driver/commoncluster"
@@ -309,6 +311,8 @@ func GetCommonClusterFromModel(modelCluster *model.ClusterModel) (CommonCluster,
 			return pkeAzureAdapter.MakeCommonClusterGetter(secret.Store, adapter.NewClusterStore(db, logger)).GetByID(modelCluster.ID)
 		case pkgCluster.Vsphere:
 			return pkeVsphereAdapter.MakeCommonClusterGetter(secret.Store, vsphereadapter.NewClusterStore(db)).GetByID(modelCluster.ID)
+		case pkgCluster.Baremetal:
+			return pkeBaremetalAdapter.MakeCommonClusterGetter(secret.Store, baremetaladapter.NewClusterStore(db)).GetByID(modelCluster.ID)
 		default:
 			return createCommonClusterWithDistributionFromModel(modelCluster)
 		}
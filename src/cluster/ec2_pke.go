@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"net"
 	"strconv"
+	"strings"
 	"time"
 
 	"emperror.dev/errors"
@@ -320,10 +321,25 @@ func CreateMasterCF(formation *cloudformation.CloudFormation) error {
 func (c *EC2ClusterPKE) ValidateCreationFields(r *pkgCluster.CreateClusterRequest) error {
 	// TODO(Ecsy): implement me
 
+	network := r.Properties.CreateClusterPKE.Network
+	if network.Provider != "" {
+		if err := network.Provider.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := r.Properties.CreateClusterPKE.CRI.Validate(); err != nil {
+		return err
+	}
+
 	for _, np := range r.Properties.CreateClusterPKE.NodePools {
 		if err := common.ValidateNodePoolLabels(np.Name, np.Labels); err != nil {
 			return err
 		}
+
+		if err := common.ValidateNodePoolTaints(np.Name, np.Taints); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -465,6 +481,11 @@ func (c *EC2ClusterPKE) UpdatePKECluster(ctx context.Context, request *pkgCluste
 		if reqNodePool, ok := reqNodePoolsMap[np.Name]; ok { // update
 			np.Autoscaling = reqNodePool.Autoscaling
 
+			// keep the current taints unless the request explicitly sets them
+			if taints := request.PKE.NodePools[np.Name].Taints; taints != nil {
+				np.Taints = taints
+			}
+
 			providerConfig := internalPke.NodePoolProviderConfigAmazon{}
 			if err := mapstructure.Decode(np.ProviderConfig, &providerConfig); err != nil {
 				return errors.WrapIff(err, "decoding nodepool %q config", np.Name)
@@ -501,6 +522,7 @@ func (c *EC2ClusterPKE) UpdatePKECluster(ctx context.Context, request *pkgCluste
 				CreatedBy:   userID,
 				Roles:       internalPke.Roles{"worker"},
 				Autoscaling: np.Autoscaling,
+				Taints:      request.PKE.NodePools[np.Name].Taints,
 				Provider:    internalPke.NPPAmazon,
 				ProviderConfig: internalPke.Config{
 					"autoScalingGroup": providerConfig.AutoScalingGroup},
@@ -646,6 +668,7 @@ func (c *EC2ClusterPKE) GetStatus() (*pkgCluster.GetClusterStatusResponse, error
 			SpotPrice:         providerConfig.AutoScalingGroup.SpotPrice,
 			CreatorBaseFields: *NewCreatorBaseFields(np.CreatedAt, np.CreatedBy),
 			Labels:            np.Labels,
+			Taints:            np.Taints,
 		}
 
 		if p, err := strconv.ParseFloat(providerConfig.AutoScalingGroup.SpotPrice, 64); err == nil && p > 0.0 {
@@ -667,6 +690,7 @@ func (c *EC2ClusterPKE) GetStatus() (*pkgCluster.GetClusterStatusResponse, error
 		CreatorBaseFields: *NewCreatorBaseFields(c.model.Cluster.CreatedAt, c.model.Cluster.CreatedBy),
 		Region:            c.model.Cluster.Location,
 		StartedAt:         c.model.Cluster.StartedAt,
+		Labels:            c.model.Cluster.Labels,
 	}, nil
 }
 
@@ -905,6 +929,8 @@ func (c *EC2ClusterPKE) GetBootstrapCommand(nodePoolName, url string, urlInsecur
 			masterMode,
 		)
 
+		command = fmt.Sprintf("%s%s", command, c.criCommandFlags())
+
 		if c.model.Cluster.OidcEnabled {
 			oidcClientID := c.GetUID()
 
@@ -921,7 +947,7 @@ func (c *EC2ClusterPKE) GetBootstrapCommand(nodePoolName, url string, urlInsecur
 	}
 
 	// worker
-	return fmt.Sprintf("pke install %s "+
+	command := fmt.Sprintf("pke install %s "+
 		"--pipeline-url=%q "+
 		"--pipeline-insecure=%q "+
 		"--pipeline-token=%q "+
@@ -940,7 +966,26 @@ func (c *EC2ClusterPKE) GetBootstrapCommand(nodePoolName, url string, urlInsecur
 		nodePoolName,
 		version,
 		infrastructureCIDR,
-	), nil
+	)
+
+	command = fmt.Sprintf("%s%s", command, c.criCommandFlags())
+
+	if len(np.Taints) > 0 {
+		command = fmt.Sprintf("%s --taints=%q", command, strings.Join(np.Taints.Strings(), ","))
+	}
+
+	return command, nil
+}
+
+// criCommandFlags returns the pke install flags of the container runtime settings that are set.
+func (c *EC2ClusterPKE) criCommandFlags() string {
+	var flags string
+
+	if c.model.CRI.Runtime != "" {
+		flags += fmt.Sprintf(" --kubernetes-container-runtime=%q", c.model.CRI.Runtime)
+	}
+
+	return flags
 }
 
 func (c *EC2ClusterPKE) GetKubernetesVersion() (string, error) {
@@ -1041,6 +1086,7 @@ func CreateEC2ClusterPKEFromRequest(request *pkgCluster.CreateClusterRequest, or
 			RbacEnabled:    kubernetes.RBAC.Enabled,
 			OidcEnabled:    request.Properties.CreateClusterPKE.Kubernetes.OIDC.Enabled,
 			CreatedBy:      userId,
+			Labels:         request.Labels,
 		},
 		MasterInstanceType: instanceType,
 		MasterImage:        image,
@@ -1096,6 +1142,7 @@ func createEC2ClusterPKENodePoolsFromRequest(pools pke.NodePools, userId uint) i
 			Provider:       convertNodePoolProvider(pool.Provider),
 			ProviderConfig: pool.ProviderConfig,
 			Labels:         pool.Labels,
+			Taints:         pool.Taints,
 			Autoscaling:    pool.Autoscaling,
 		}
 		np.CreatedBy = userId
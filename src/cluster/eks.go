@@ -70,6 +70,7 @@ func CreateEKSClusterFromRequest(request *pkgCluster.CreateClusterRequest, orgId
 			Distribution:   pkgCluster.EKS,
 			RbacEnabled:    true,
 			CreatedBy:      userId,
+			Labels:         request.Labels,
 		},
 		Version:               request.Properties.CreateClusterEKS.Version,
 		LogTypes:              request.Properties.CreateClusterEKS.LogTypes,
@@ -114,6 +115,7 @@ func createNodePoolsFromRequest(nodePools map[string]*pkgEks.NodePool, userId ui
 			NodeImage:        nodePool.Image,
 			NodeInstanceType: nodePool.InstanceType,
 			Labels:           nodePool.Labels,
+			Taints:           nodePool.Taints,
 			Delete:           false,
 		}
 		i++
@@ -463,6 +465,7 @@ func (c *EKSCluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
 				Image:             np.NodeImage,
 				CreatorBaseFields: *NewCreatorBaseFields(np.CreatedAt, np.CreatedBy),
 				Labels:            np.Labels,
+				Taints:            np.Taints,
 			}
 			if np.NodeSpotPrice != "" && np.NodeSpotPrice != "0" {
 				hasSpotNodePool = true
@@ -484,6 +487,7 @@ func (c *EKSCluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
 		CreatorBaseFields: *NewCreatorBaseFields(c.model.Cluster.CreatedAt, c.model.Cluster.CreatedBy),
 		Region:            c.model.Cluster.Location,
 		StartedAt:         c.model.Cluster.StartedAt,
+		Labels:            c.model.Cluster.Labels,
 	}, nil
 }
 
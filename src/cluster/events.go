@@ -14,6 +14,15 @@
 
 package cluster
 
+import (
+	"context"
+
+	"emperror.dev/emperror"
+	"emperror.dev/errors"
+
+	"github.com/banzaicloud/pipeline/internal/cluster"
+)
+
 type clusterEvents interface {
 	// ClusterCreated event is emitted when a cluster creation workflow finishes.
 	ClusterCreated(clusterID uint)
@@ -43,34 +52,37 @@ func (*nopClusterEvents) ClusterDeleted(orgID uint, clusterName string) {
 func (*nopClusterEvents) ClusterUpdated(clusterID uint) {
 }
 
-type eventBus interface {
-	Publish(topic string, args ...interface{})
-}
-
 type clusterEventBus struct {
-	eb eventBus
+	events       cluster.ClusterEvents
+	errorHandler emperror.Handler
 }
 
-const (
-	clusterCreatedTopic = "cluster_created"
-	clusterDeletedTopic = "cluster_deleted"
-	clusterUpdatedTopic = "cluster_updated"
-)
-
-func NewClusterEvents(eb eventBus) *clusterEventBus {
+// NewClusterEvents returns cluster events published through an event dispatcher.
+// Since events are emitted at the end of (otherwise successful) operations, dispatch errors are only reported.
+func NewClusterEvents(events cluster.ClusterEvents, errorHandler emperror.Handler) *clusterEventBus {
 	return &clusterEventBus{
-		eb: eb,
+		events:       events,
+		errorHandler: errorHandler,
 	}
 }
 
 func (c *clusterEventBus) ClusterCreated(clusterID uint) {
-	c.eb.Publish(clusterCreatedTopic, clusterID)
+	err := c.events.ClusterCreated(context.Background(), cluster.ClusterCreated{ClusterID: clusterID})
+	if err != nil {
+		c.errorHandler.Handle(errors.WithDetails(err, "clusterId", clusterID))
+	}
 }
 
 func (c *clusterEventBus) ClusterDeleted(orgID uint, clusterName string) {
-	c.eb.Publish(clusterDeletedTopic, orgID, clusterName)
+	err := c.events.ClusterDeleted(context.Background(), cluster.ClusterDeleted{OrganizationID: orgID, ClusterName: clusterName})
+	if err != nil {
+		c.errorHandler.Handle(errors.WithDetails(err, "organizationId", orgID, "clusterName", clusterName))
+	}
 }
 
 func (c *clusterEventBus) ClusterUpdated(clusterID uint) {
-	c.eb.Publish(clusterUpdatedTopic, clusterID)
+	err := c.events.ClusterUpdated(context.Background(), cluster.ClusterUpdated{ClusterID: clusterID})
+	if err != nil {
+		c.errorHandler.Handle(errors.WithDetails(err, "clusterId", clusterID))
+	}
 }
@@ -102,6 +102,7 @@ func CreateGKEClusterFromRequest(request *pkgCluster.CreateClusterRequest, orgID
 			Cloud:          google.Provider,
 			Distribution:   google.ClusterDistributionGKE,
 			CreatedBy:      userID,
+			Labels:         request.Labels,
 		},
 
 		MasterVersion: request.Properties.CreateClusterGKE.Master.Version,
@@ -397,6 +398,7 @@ func (c *GKECluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
 				Version:           c.model.NodeVersion,
 				CreatorBaseFields: *NewCreatorBaseFields(np.CreatedAt, np.CreatedBy),
 				Labels:            np.Labels,
+				Taints:            np.Taints,
 			}
 			if np.Preemptible {
 				hasSpotNodePool = true
@@ -418,6 +420,7 @@ func (c *GKECluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
 		CreatorBaseFields: *NewCreatorBaseFields(c.model.Cluster.CreatedAt, c.model.Cluster.CreatedBy),
 		Region:            c.model.Region,
 		StartedAt:         c.model.Cluster.StartedAt,
+		Labels:            c.model.Cluster.Labels,
 	}, nil
 }
 
@@ -730,6 +733,7 @@ func (c *GKECluster) updateModel(cluster *gke.Cluster, updatedNodePools []*gke.N
 		for _, nodePoolModel := range c.model.NodePools {
 			if clusterNodePool.Name == nodePoolModel.Name {
 				nodePoolModel.NodeInstanceType = clusterNodePool.Config.MachineType
+				nodePoolModel.Taints = createTaintsFromGKENodeTaints(clusterNodePool.Config.Taints)
 
 				if clusterNodePool.Autoscaling != nil {
 					nodePoolModel.Autoscaling = clusterNodePool.Autoscaling.Enabled
@@ -756,6 +760,7 @@ func (c *GKECluster) updateModel(cluster *gke.Cluster, updatedNodePools []*gke.N
 				Name:             clusterNodePool.Name,
 				NodeInstanceType: clusterNodePool.Config.MachineType,
 				NodeCount:        int(clusterNodePool.InitialNodeCount),
+				Taints:           createTaintsFromGKENodeTaints(clusterNodePool.Config.Taints),
 			}
 			if clusterNodePool.Autoscaling != nil {
 				nodePoolModelAdd.Autoscaling = clusterNodePool.Autoscaling.Enabled
@@ -43,6 +43,7 @@ func createNodePoolsModelFromRequest(nodePoolsData map[string]*pkgClusterGoogle.
 			NodeInstanceType: nodePoolData.NodeInstanceType,
 			Preemptible:      nodePoolData.Preemptible,
 			Labels:           nodePoolData.Labels,
+			Taints:           nodePoolData.Taints,
 		}
 
 		i++
@@ -81,6 +82,7 @@ func createNodePoolsFromClusterModel(clusterModel *google.GKEClusterModel) ([]*g
 					"https://www.googleapis.com/auth/compute",
 				},
 				Preemptible: nodePoolModel.Preemptible,
+				Taints:      createGKENodeTaints(nodePoolModel.Taints),
 			},
 			InitialNodeCount: int64(nodePoolModel.NodeCount),
 			Version:          clusterModel.NodeVersion,
@@ -120,8 +122,54 @@ func createNodePoolsRequestDataFromNodePoolModel(nodePoolsModel []*google.GKENod
 			Count:            nodePoolModel.NodeCount,
 			NodeInstanceType: nodePoolModel.NodeInstanceType,
 			Preemptible:      nodePoolModel.Preemptible,
+			Taints:           nodePoolModel.Taints,
 		}
 	}
 
 	return nodePools, nil
 }
+
+// gkeTaintEffects maps Kubernetes taint effects to their GKE API representation
+// nolint: gochecknoglobals
+var gkeTaintEffects = map[string]string{
+	pkgCommon.TaintEffectNoSchedule:       "NO_SCHEDULE",
+	pkgCommon.TaintEffectPreferNoSchedule: "PREFER_NO_SCHEDULE",
+	pkgCommon.TaintEffectNoExecute:        "NO_EXECUTE",
+}
+
+// createGKENodeTaints converts node pool taints to GKE node taints
+func createGKENodeTaints(taints pkgCommon.Taints) []*gke.NodeTaint {
+	var nodeTaints []*gke.NodeTaint
+
+	for _, taint := range taints {
+		nodeTaints = append(nodeTaints, &gke.NodeTaint{
+			Key:    taint.Key,
+			Value:  taint.Value,
+			Effect: gkeTaintEffects[taint.Effect],
+		})
+	}
+
+	return nodeTaints
+}
+
+// createTaintsFromGKENodeTaints converts GKE node taints to node pool taints
+func createTaintsFromGKENodeTaints(nodeTaints []*gke.NodeTaint) pkgCommon.Taints {
+	var taints pkgCommon.Taints
+
+	for _, nodeTaint := range nodeTaints {
+		taint := pkgCommon.Taint{
+			Key:   nodeTaint.Key,
+			Value: nodeTaint.Value,
+		}
+
+		for effect, gkeEffect := range gkeTaintEffects {
+			if gkeEffect == nodeTaint.Effect {
+				taint.Effect = effect
+			}
+		}
+
+		taints = append(taints, taint)
+	}
+
+	return taints
+}
@@ -16,6 +16,7 @@ package cluster
 
 import (
 	"fmt"
+	"strings"
 
 	"emperror.dev/errors"
 	"github.com/ghodss/yaml"
@@ -31,6 +32,7 @@ import (
 	arkAPI "github.com/banzaicloud/pipeline/internal/ark/api"
 	arkPosthook "github.com/banzaicloud/pipeline/internal/ark/posthook"
 	"github.com/banzaicloud/pipeline/internal/global"
+	"github.com/banzaicloud/pipeline/internal/global/globalcluster"
 	"github.com/banzaicloud/pipeline/internal/hollowtrees"
 	pkgCluster "github.com/banzaicloud/pipeline/pkg/cluster"
 	pkgCommon "github.com/banzaicloud/pipeline/pkg/common"
@@ -408,6 +410,27 @@ func DeployInstanceTerminationHandler(cluster CommonCluster) error {
 			"clusterName":    cluster.GetName(),
 			"jwtToken":       token,
 		}
+	} else if externalURL := global.Config.Pipeline.External.URL; externalURL != "" && globalcluster.TokenGenerator() != nil {
+		// Clusters not managed by Hollowtrees report interruption notices to Pipeline (spot interruption history)
+		_, token, err := globalcluster.TokenGenerator().GenerateClusterToken(cluster.GetOrganizationId(), cluster.GetID())
+		if err != nil {
+			// the termination handler is still useful without notifications
+			errorHandler.Handle(errors.WrapIf(err, "could not generate token for instance termination handler notifications"))
+		} else {
+			values["hollowtreesNotifier"] = map[string]interface{}{
+				"enabled": true,
+				"URL": fmt.Sprintf(
+					"%s/api/v1/orgs/%d/clusters/%d/spot/events",
+					strings.TrimSuffix(externalURL, "/"),
+					cluster.GetOrganizationId(),
+					cluster.GetID(),
+				),
+				"organizationID": cluster.GetOrganizationId(),
+				"clusterID":      cluster.GetID(),
+				"clusterName":    cluster.GetName(),
+				"jwtToken":       token,
+			}
+		}
 	}
 
 	marshalledValues, err := yaml.Marshal(values)
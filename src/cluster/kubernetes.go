@@ -51,6 +51,7 @@ func CreateKubernetesClusterFromRequest(request *pkgCluster.CreateClusterRequest
 		CreatedBy:      userId,
 		SecretId:       request.SecretId,
 		Distribution:   pkgCluster.Unknown,
+		Labels:         request.Labels,
 		Kubernetes: kubernetesadapter.KubernetesClusterModel{
 			Metadata: request.Properties.CreateClusterKubernetes.Metadata,
 		},
@@ -155,6 +156,22 @@ func (c *KubeCluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error)
 		db.Find(&c.modelCluster, model.ClusterModel{ID: c.GetID()})
 	}
 
+	// the node pool inventory is collected by the periodic health check
+	var nodePools map[string]*pkgCluster.NodePoolStatus
+	if len(c.modelCluster.Kubernetes.NodePools) > 0 {
+		nodePools = make(map[string]*pkgCluster.NodePoolStatus, len(c.modelCluster.Kubernetes.NodePools))
+
+		for _, np := range c.modelCluster.Kubernetes.NodePools {
+			nodePools[np.Name] = &pkgCluster.NodePoolStatus{
+				Count:        np.Count,
+				InstanceType: np.InstanceType,
+				MinCount:     np.Count,
+				MaxCount:     np.Count,
+				Version:      np.Version,
+			}
+		}
+	}
+
 	return &pkgCluster.GetClusterStatusResponse{
 		Status:            c.modelCluster.Status,
 		StatusMessage:     c.modelCluster.StatusMessage,
@@ -162,11 +179,13 @@ func (c *KubeCluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error)
 		Location:          c.modelCluster.Location,
 		Cloud:             pkgCluster.Kubernetes,
 		Distribution:      c.modelCluster.Distribution,
+		Version:           c.modelCluster.Kubernetes.Version,
 		ResourceID:        c.modelCluster.ID,
 		CreatorBaseFields: *NewCreatorBaseFields(c.modelCluster.CreatedAt, c.modelCluster.CreatedBy),
-		NodePools:         nil,
+		NodePools:         nodePools,
 		Region:            c.modelCluster.Location,
 		StartedAt:         c.modelCluster.StartedAt,
+		Labels:            c.modelCluster.Labels,
 	}, nil
 }
 
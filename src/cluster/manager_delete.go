@@ -36,6 +36,19 @@ import (
 
 // DeleteCluster deletes a cluster.
 func (m *Manager) DeleteCluster(ctx context.Context, cluster CommonCluster, force bool) error {
+	return m.startClusterDeletion(ctx, cluster, force, false)
+}
+
+// UnlinkCluster removes an imported cluster from Pipeline leaving every resource in the cluster untouched.
+func (m *Manager) UnlinkCluster(ctx context.Context, cluster CommonCluster, force bool) error {
+	if cluster.GetCloud() != pkgCluster.Kubernetes {
+		return errors.NewWithDetails("only imported clusters can be unlinked", "cloud", cluster.GetCloud())
+	}
+
+	return m.startClusterDeletion(ctx, cluster, force, true)
+}
+
+func (m *Manager) startClusterDeletion(ctx context.Context, cluster CommonCluster, force bool, keepResources bool) error {
 	timer, err := m.getClusterStatusChangeMetricTimer(cluster.GetCloud(), cluster.GetLocation(), pkgCluster.Deleting, cluster.GetOrganizationId(), cluster.GetName())
 	if err != nil {
 		return err
@@ -46,7 +59,7 @@ func (m *Manager) DeleteCluster(ctx context.Context, cluster CommonCluster, forc
 	go func() {
 		defer emperror.HandleRecover(errorHandler.WithStatus(pkgCluster.Error, "internal error while deleting cluster"))
 
-		err := m.deleteCluster(context.Background(), cluster, force)
+		err := m.deleteCluster(context.Background(), cluster, force, keepResources)
 		if err != nil {
 			errorHandler.Handle(err)
 			return
@@ -135,16 +148,22 @@ func deleteUnusedSecrets(cluster CommonCluster, logger *logrus.Entry) error {
 	return nil
 }
 
-func (m *Manager) deleteCluster(ctx context.Context, cluster CommonCluster, force bool) error {
+func (m *Manager) deleteCluster(ctx context.Context, cluster CommonCluster, force bool, keepResources bool) error {
 	logger := m.getLogger(ctx).WithFields(logrus.Fields{
-		"organization": cluster.GetOrganizationId(),
-		"cluster":      cluster.GetName(),
-		"force":        force,
+		"organization":  cluster.GetOrganizationId(),
+		"cluster":       cluster.GetName(),
+		"force":         force,
+		"keepResources": keepResources,
 	})
 
 	logger.Info("deleting cluster")
 
-	if err := cluster.SetStatus(pkgCluster.Deleting, pkgCluster.DeletingMessage); err != nil {
+	statusMessage := pkgCluster.DeletingMessage
+	if keepResources {
+		statusMessage = pkgCluster.UnlinkingMessage
+	}
+
+	if err := cluster.SetStatus(pkgCluster.Deleting, statusMessage); err != nil {
 		return errors.WrapIfWithDetails(err, "cluster status update failed", "cluster_id", cluster.GetID())
 	}
 
@@ -168,11 +187,17 @@ func (m *Manager) deleteCluster(ctx context.Context, cluster CommonCluster, forc
 
 	// By default we try to delete resources from the cluster,
 	// but in certain cases we want to skip that step
-	deleteResources := true
+	deleteResources := !keepResources
+
+	var (
+		config []byte
+		err    error
+	)
 
 	// delete k8s resources from the cluster
-	config, err := cluster.GetK8sConfig()
-	if err == ErrConfigNotExists {
+	if keepResources {
+		logger.Info("unlinking cluster without removing resources")
+	} else if config, err = cluster.GetK8sConfig(); err == ErrConfigNotExists {
 		// if the config does not exist, then we were not able to create any k8s resources earlier, so we can proceed with removing the infra
 		logger.Infof("deleting unavailable cluster without removing resources: %v", err)
 
@@ -191,8 +216,8 @@ func (m *Manager) deleteCluster(ctx context.Context, cluster CommonCluster, forc
 		deleteResources = false
 	}
 
+	var namespaceList *corev1.NamespaceList
 	if deleteResources {
-		var namespaceList *corev1.NamespaceList
 		if cluster.GetCloud() == pkgCluster.Kubernetes {
 			// in case of imported cluster delete only resources from namespaces created by Pipeline
 			client, err := k8sclient.NewClientFromKubeConfig(config)
@@ -213,9 +238,14 @@ func (m *Manager) deleteCluster(ctx context.Context, cluster CommonCluster, forc
 				}
 
 				logger.Error(err)
+
+				// without the list of namespaces created by Pipeline every namespace would be cleaned up
+				deleteResources = false
 			}
 		}
+	}
 
+	if deleteResources {
 		err = helm.DeleteAllDeployment(logger, config, namespaceList)
 		if err != nil {
 			err = errors.WrapIf(err, "failed to delete deployments")
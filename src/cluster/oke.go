@@ -71,6 +71,7 @@ func CreateOKEClusterFromRequest(request *pkgCluster.CreateClusterRequest, orgId
 		SecretId:       request.SecretId,
 		CreatedBy:      userId,
 		Distribution:   pkgCluster.OKE,
+		Labels:         request.Labels,
 	}
 	updateScaleOptions(&oke.modelCluster.ScaleOptions, request.ScaleOptions)
 
@@ -354,6 +355,7 @@ func (o *OKECluster) GetStatus() (*pkgCluster.GetClusterStatusResponse, error) {
 		NodePools:         nodePools,
 		Region:            o.modelCluster.Location,
 		StartedAt:         o.modelCluster.StartedAt,
+		Labels:            o.modelCluster.Labels,
 	}, nil
 }
 
@@ -80,6 +80,8 @@ func defaultProxyTransport(requestSchema string, requestHost string, apiProxyPre
 }
 
 // NewKubeAPIProxy creates a new Kubernetes API Server Proxy to the given cluster with a well-defined keep-alive timeout.
+// Requests are sent with the credentials of the cluster, callers should authorize them
+// and impersonate the requesting user (see the kubeproxy package).
 func NewKubeAPIProxy(requestSchema string, requestHost string, apiProxyPrefix string, cluster CommonCluster, keepalive time.Duration) (*KubeAPIProxy, error) {
 	kubeConfig, err := cluster.GetK8sConfig()
 	if err != nil {
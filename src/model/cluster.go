@@ -59,6 +59,7 @@ type ClusterModel struct {
 	Kubernetes     kubernetesadapter.KubernetesClusterModel `gorm:"foreignkey:ID"`
 	OKE            modelOracle.Cluster
 	CreatedBy      uint
+	Labels         clustermodel.Labels `gorm:"type:text"`
 }
 
 // TableName sets ClusterModel's table name